@@ -9,6 +9,7 @@ import (
 
 	"github.com/NeroQue/course-management-backend/internal/api"
 	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/pkg/jwt"
 	"github.com/NeroQue/course-management-backend/pkg/parser"
 	"github.com/NeroQue/course-management-backend/pkg/session"
 	"github.com/NeroQue/course-management-backend/pkg/util"
@@ -45,12 +46,20 @@ func main() {
 	}
 	defer db.Close()
 
+	tokens, err := jwt.LoadSigner()
+	if err != nil {
+		log.Fatalf("Failed to set up session tokens: %v", err)
+	}
+
 	queries := database.New(db)
-	session.Initialize(queries) // global session store - not ideal but works
+	session.Initialize(queries, tokens) // sessions themselves are per client, see server.Sessions
 
 	// wire everything together
 	server := api.NewServer(db, courseParser)
-	handler := server.EnableCORS(server) // needed for frontend requests
+	// CORS goes first so preflight requests never hit the route policies or maintenance mode,
+	// then the session so everything after knows whose request it is,
+	// idempotency last so only requests that were allowed through get remembered
+	handler := server.EnableCORS(server.Sessions(server.MaintenanceMode(server.EnforcePolicies(server.AuditImpersonation(server.Idempotency(server))))))
 
 	fmt.Println("Starting server on :8080")
 	// TODO: make port configurable via env var
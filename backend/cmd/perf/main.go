@@ -0,0 +1,168 @@
+// Command perf is a reproducible performance harness for the hot paths.
+//
+// It generates a seeded course library on disk, imports it into a dedicated
+// database, replays scripted requests against the API in-process and writes
+// a JSON report. Reports from different commits can be compared:
+//
+//	PERF_DB_URL=postgres://... go run ./cmd/perf -out before.json
+//	git checkout my-branch
+//	PERF_DB_URL=postgres://... go run ./cmd/perf -out after.json -baseline before.json
+//
+// The database should be a throwaway one with migrations applied - everything
+// the harness creates is removed again when it finishes.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/api"
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/pkg/jwt"
+	"github.com/NeroQue/course-management-backend/pkg/parser"
+	"github.com/NeroQue/course-management-backend/pkg/session"
+	_ "github.com/lib/pq"
+)
+
+func main() {
+	dbURL := flag.String("db", os.Getenv("PERF_DB_URL"), "database to seed and benchmark against (never point this at real data)")
+	libraryDir := flag.String("library", "", "where to generate the course library (default: a temp dir)")
+	out := flag.String("out", "", "write the JSON report here")
+	baseline := flag.String("baseline", "", "compare against a previous JSON report")
+	threshold := flag.Float64("threshold", 0.15, "allowed p50 slowdown against the baseline before failing (0.15 = 15%)")
+	label := flag.String("label", "", "name for this run in the report (default: current git commit)")
+	verbose := flag.Bool("v", false, "keep the application's own logging")
+
+	config := DefaultConfig()
+	flag.Int64Var(&config.Seed, "seed", config.Seed, "random seed for the generated library")
+	flag.IntVar(&config.Courses, "courses", config.Courses, "number of regular courses to seed")
+	flag.IntVar(&config.ModulesPerCourse, "modules", config.ModulesPerCourse, "modules per regular course")
+	flag.IntVar(&config.ItemsPerModule, "items", config.ItemsPerModule, "content files per regular module")
+	flag.IntVar(&config.LargeCourseFiles, "large-files", config.LargeCourseFiles, "content files in the large course")
+	flag.IntVar(&config.Iterations, "iterations", config.Iterations, "timed runs per request scenario")
+	flag.IntVar(&config.ImportRuns, "import-runs", config.ImportRuns, "timed runs of the large course import")
+	flag.Parse()
+
+	if *dbURL == "" {
+		log.Fatal("No database configured - set PERF_DB_URL or pass -db")
+	}
+
+	if *label == "" {
+		*label = gitCommit()
+	}
+
+	if *libraryDir == "" {
+		dir, err := os.MkdirTemp("", "cms-perf-")
+		if err != nil {
+			log.Fatalf("Failed to create library directory: %v", err)
+		}
+		defer os.RemoveAll(dir)
+		*libraryDir = dir
+	}
+
+	db, err := sql.Open("postgres", *dbURL)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.Ping(); err != nil {
+		log.Fatalf("Database not reachable: %v", err)
+	}
+
+	// the app logs every module it imports, which drowns out the results
+	if !*verbose {
+		log.SetOutput(io.Discard)
+	}
+
+	report, err := run(context.Background(), db, *libraryDir, config)
+	log.SetOutput(os.Stderr)
+	if err != nil {
+		log.Fatalf("Perf run failed: %v", err)
+	}
+	report.Label = *label
+
+	report.Print(os.Stdout)
+
+	if *out != "" {
+		if err := report.Save(*out); err != nil {
+			log.Fatalf("Failed to write report: %v", err)
+		}
+		fmt.Printf("\nReport written to %s\n", *out)
+	}
+
+	if *baseline != "" {
+		previous, err := LoadReport(*baseline)
+		if err != nil {
+			log.Fatalf("Failed to read baseline: %v", err)
+		}
+
+		fmt.Println()
+		if regressions := Compare(os.Stdout, previous, report, *threshold); regressions > 0 {
+			fmt.Printf("\n%d scenario(s) regressed by more than %.0f%%\n", regressions, *threshold*100)
+			os.Exit(1)
+		}
+	}
+}
+
+// run seeds the library, times every scenario and cleans up after itself
+func run(ctx context.Context, db *sql.DB, libraryDir string, config Config) (*Report, error) {
+	library, err := GenerateLibrary(libraryDir, config)
+	if err != nil {
+		return nil, fmt.Errorf("error generating library: %w", err)
+	}
+
+	courseParser := parser.NewCourseParser(libraryDir)
+	tokens, err := jwt.LoadSigner()
+	if err != nil {
+		return nil, fmt.Errorf("error setting up session tokens: %w", err)
+	}
+	session.Initialize(database.New(db), tokens)
+	server := api.NewServer(db, courseParser)
+
+	fixture, err := Seed(ctx, db, courseParser, library)
+	if err != nil {
+		return nil, fmt.Errorf("error seeding database: %w", err)
+	}
+	defer fixture.Cleanup(ctx)
+
+	report := &Report{
+		GoVersion: runtime.Version(),
+		StartedAt: time.Now().UTC(),
+		Config:    config,
+	}
+
+	for _, scenario := range RequestScenarios(fixture) {
+		result, err := scenario.Measure(server, config.Iterations)
+		if err != nil {
+			return nil, fmt.Errorf("scenario %s: %w", scenario.Name, err)
+		}
+		report.Results = append(report.Results, result)
+	}
+
+	importResult, err := MeasureImport(ctx, fixture, library.ImportDir, config.ImportRuns)
+	if err != nil {
+		return nil, fmt.Errorf("scenario import_large: %w", err)
+	}
+	report.Results = append(report.Results, importResult)
+
+	return report, nil
+}
+
+// gitCommit names the run after the checked out commit, if we're in a git tree
+func gitCommit() string {
+	output, err := exec.Command("git", "rev-parse", "--short", "HEAD").Output()
+	if err != nil {
+		return "unknown"
+	}
+	return strings.TrimSpace(string(output))
+}
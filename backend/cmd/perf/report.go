@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+)
+
+// Result is the timing summary for one scenario
+type Result struct {
+	Name         string  `json:"name"`
+	Runs         int     `json:"runs"`
+	MinMs        float64 `json:"min_ms"`
+	P50Ms        float64 `json:"p50_ms"`
+	P95Ms        float64 `json:"p95_ms"`
+	MaxMs        float64 `json:"max_ms"`
+	MeanMs       float64 `json:"mean_ms"`
+	AllocBytesOp uint64  `json:"alloc_bytes_per_op"` // average heap allocation per run
+}
+
+// Report is one full harness run, saved as JSON for comparing across commits
+type Report struct {
+	Label     string    `json:"label"` // usually the git commit
+	GoVersion string    `json:"go_version"`
+	StartedAt time.Time `json:"started_at"`
+	Config    Config    `json:"config"`
+	Results   []Result  `json:"results"`
+}
+
+// summarize turns raw samples into a Result
+func summarize(name string, samples []sample) Result {
+	result := Result{Name: name, Runs: len(samples)}
+	if len(samples) == 0 {
+		return result
+	}
+
+	durations := make([]time.Duration, len(samples))
+	var total time.Duration
+	var totalBytes uint64
+	for i, current := range samples {
+		durations[i] = current.duration
+		total += current.duration
+		totalBytes += current.bytes
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+	result.MinMs = milliseconds(durations[0])
+	result.P50Ms = milliseconds(percentile(durations, 50))
+	result.P95Ms = milliseconds(percentile(durations, 95))
+	result.MaxMs = milliseconds(durations[len(durations)-1])
+	result.MeanMs = milliseconds(total / time.Duration(len(samples)))
+	result.AllocBytesOp = totalBytes / uint64(len(samples))
+	return result
+}
+
+// percentile uses nearest rank on already sorted durations
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// Print writes the results as a table
+func (r *Report) Print(w io.Writer) {
+	fmt.Fprintf(w, "perf run %s (%s, seed %d)\n\n", r.Label, r.GoVersion, r.Config.Seed)
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "scenario\truns\tmin ms\tp50 ms\tp95 ms\tmax ms\talloc/op")
+	for _, result := range r.Results {
+		fmt.Fprintf(table, "%s\t%d\t%.2f\t%.2f\t%.2f\t%.2f\t%s\n", result.Name, result.Runs,
+			result.MinMs, result.P50Ms, result.P95Ms, result.MaxMs, formatBytes(result.AllocBytesOp))
+	}
+	table.Flush()
+}
+
+// Save writes the report as indented JSON
+func (r *Report) Save(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// LoadReport reads a report written by Save
+func LoadReport(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var report Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("invalid report %s: %w", path, err)
+	}
+	return &report, nil
+}
+
+// Compare prints p50 changes against the baseline and returns how many scenarios
+// got slower by more than threshold. Scenarios missing from either side are listed but not counted.
+func Compare(w io.Writer, baseline, current *Report, threshold float64) int {
+	if baseline.Config != current.Config {
+		fmt.Fprintln(w, "Warning: baseline was recorded with a different config, numbers may not be comparable")
+	}
+
+	previous := make(map[string]Result, len(baseline.Results))
+	for _, result := range baseline.Results {
+		previous[result.Name] = result
+	}
+
+	fmt.Fprintf(w, "compared to %s\n\n", baseline.Label)
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "scenario\tbase p50\tp50\tchange\t")
+
+	regressions := 0
+	for _, result := range current.Results {
+		base, ok := previous[result.Name]
+		if !ok || base.P50Ms == 0 {
+			fmt.Fprintf(table, "%s\t-\t%.2f\tnew\t\n", result.Name, result.P50Ms)
+			continue
+		}
+
+		change := result.P50Ms/base.P50Ms - 1
+		status := ""
+		if change > threshold {
+			status = "REGRESSION"
+			regressions++
+		}
+		fmt.Fprintf(table, "%s\t%.2f\t%.2f\t%+.1f%%\t%s\n", result.Name, base.P50Ms, result.P50Ms, change*100, status)
+		delete(previous, result.Name)
+	}
+
+	for name, base := range previous {
+		fmt.Fprintf(table, "%s\t%.2f\t-\tremoved\t\n", name, base.P50Ms)
+	}
+	table.Flush()
+
+	return regressions
+}
+
+// formatBytes prints allocation sizes the way a human would read them
+func formatBytes(n uint64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
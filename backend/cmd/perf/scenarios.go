@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"time"
+)
+
+// Scenario is one scripted request replayed against the API
+type Scenario struct {
+	Name string
+	Path string
+}
+
+// RequestScenarios are the hot paths the UI hits on every page load
+func RequestScenarios(fixture *Fixture) []Scenario {
+	return []Scenario{
+		{Name: "course_list", Path: "/api/courses"},
+		{Name: "course_list_summary", Path: "/api/courses?fields=id,title"},
+		{Name: "course_detail_large", Path: "/api/courses/" + fixture.LargeID.String()},
+		{Name: "course_progress_large", Path: fmt.Sprintf("/api/courses/%s/progress?user_id=%s", fixture.LargeID, fixture.ProfileID)},
+		{Name: "progress_summary", Path: fmt.Sprintf("/api/users/%s/progress", fixture.ProfileID)},
+	}
+}
+
+// Measure replays the request and records how long each run took
+func (s Scenario) Measure(handler http.Handler, iterations int) (Result, error) {
+	// one untimed request so connection setup and caches don't land in the numbers
+	if err := s.serve(handler); err != nil {
+		return Result{}, err
+	}
+
+	samples := make([]sample, 0, iterations)
+	for i := 0; i < iterations; i++ {
+		var err error
+		current := measure(func() { err = s.serve(handler) })
+		if err != nil {
+			return Result{}, err
+		}
+		samples = append(samples, current)
+	}
+
+	return summarize(s.Name, samples), nil
+}
+
+// serve runs one request and fails on anything but a 200
+func (s Scenario) serve(handler http.Handler) error {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, s.Path, nil))
+
+	if recorder.Code != http.StatusOK {
+		body := recorder.Body.String()
+		if len(body) > 200 {
+			body = body[:200]
+		}
+		return fmt.Errorf("GET %s returned %d: %s", s.Path, recorder.Code, body)
+	}
+	return nil
+}
+
+// MeasureImport times importing the large course, deleting it again between runs
+func MeasureImport(ctx context.Context, fixture *Fixture, dir string, runs int) (Result, error) {
+	samples := make([]sample, 0, runs)
+	for i := 0; i < runs; i++ {
+		var err error
+		current := measure(func() {
+			_, err = fixture.Courses.ImportCourseAs(ctx, dir, fixture.ProfileID, fixture.ProfileID)
+		})
+		if err != nil {
+			return Result{}, err
+		}
+		samples = append(samples, current)
+
+		if err := fixture.removeStale(ctx, filepath.Base(dir)); err != nil {
+			return Result{}, fmt.Errorf("error removing imported course: %w", err)
+		}
+	}
+
+	return summarize("import_large", samples), nil
+}
+
+// sample is one timed run
+type sample struct {
+	duration time.Duration
+	bytes    uint64 // heap bytes allocated during the run
+}
+
+// measure times fn and counts what it allocated
+func measure(fn func()) sample {
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	start := time.Now()
+	fn()
+	elapsed := time.Since(start)
+
+	runtime.ReadMemStats(&after)
+	return sample{duration: elapsed, bytes: after.TotalAlloc - before.TotalAlloc}
+}
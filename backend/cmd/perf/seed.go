@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"path/filepath"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/NeroQue/course-management-backend/pkg/parser"
+	"github.com/NeroQue/course-management-backend/pkg/rewards"
+	"github.com/google/uuid"
+)
+
+// filesPerLargeModule keeps the large course shaped like a real one instead of one giant folder
+const filesPerLargeModule = 200
+
+// contentExtensions are picked from at random so every content type shows up
+var contentExtensions = []string{".mp4", ".mkv", ".pdf", ".md", ".txt", ".pptx", ".docx"}
+
+// Config is everything that shapes a run - stored in the report so runs can be compared fairly
+type Config struct {
+	Seed             int64 `json:"seed"`
+	Courses          int   `json:"courses"`
+	ModulesPerCourse int   `json:"modules_per_course"`
+	ItemsPerModule   int   `json:"items_per_module"`
+	LargeCourseFiles int   `json:"large_course_files"`
+	Iterations       int   `json:"iterations"`
+	ImportRuns       int   `json:"import_runs"`
+}
+
+// DefaultConfig is a library roughly the size of a well used home install
+func DefaultConfig() Config {
+	return Config{
+		Seed:             42,
+		Courses:          25,
+		ModulesPerCourse: 8,
+		ItemsPerModule:   12,
+		LargeCourseFiles: 10000,
+		Iterations:       20,
+		ImportRuns:       3,
+	}
+}
+
+// Library is the generated course tree on disk
+type Library struct {
+	BaseDir    string   // courses directory the parser reads from
+	CourseDirs []string // regular courses, seeded up front
+	LargeDir   string   // the 10k file course, seeded up front
+	ImportDir  string   // same shape as LargeDir, only imported by the import scenario
+}
+
+// GenerateLibrary writes a deterministic course tree for the config's seed
+func GenerateLibrary(baseDir string, config Config) (*Library, error) {
+	rng := rand.New(rand.NewSource(config.Seed))
+	library := &Library{BaseDir: baseDir}
+
+	for i := 0; i < config.Courses; i++ {
+		dir := filepath.Join(baseDir, fmt.Sprintf("perf-course-%03d", i))
+		if err := writeCourse(rng, dir, config.ModulesPerCourse, config.ItemsPerModule); err != nil {
+			return nil, err
+		}
+		library.CourseDirs = append(library.CourseDirs, dir)
+	}
+
+	modules := (config.LargeCourseFiles + filesPerLargeModule - 1) / filesPerLargeModule
+	library.LargeDir = filepath.Join(baseDir, "perf-large")
+	if err := writeLargeCourse(rng, library.LargeDir, modules, config.LargeCourseFiles); err != nil {
+		return nil, err
+	}
+
+	library.ImportDir = filepath.Join(baseDir, "perf-large-import")
+	if err := writeLargeCourse(rng, library.ImportDir, modules, config.LargeCourseFiles); err != nil {
+		return nil, err
+	}
+
+	return library, nil
+}
+
+// writeCourse creates a course with the same number of files in every module
+func writeCourse(rng *rand.Rand, dir string, modules, itemsPerModule int) error {
+	for m := 0; m < modules; m++ {
+		moduleDir := filepath.Join(dir, fmt.Sprintf("Module %02d", m+1))
+		if err := writeModule(rng, moduleDir, itemsPerModule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeLargeCourse spreads totalFiles over the given number of modules
+func writeLargeCourse(rng *rand.Rand, dir string, modules, totalFiles int) error {
+	for m := 0; m < modules; m++ {
+		count := filesPerLargeModule
+		if remaining := totalFiles - m*filesPerLargeModule; remaining < count {
+			count = remaining
+		}
+
+		moduleDir := filepath.Join(dir, fmt.Sprintf("Module %03d", m+1))
+		if err := writeModule(rng, moduleDir, count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeModule fills a module directory with small placeholder files
+func writeModule(rng *rand.Rand, dir string, items int) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("error creating %s: %w", dir, err)
+	}
+
+	for i := 0; i < items; i++ {
+		ext := contentExtensions[rng.Intn(len(contentExtensions))]
+		name := filepath.Join(dir, fmt.Sprintf("Lesson %03d%s", i+1, ext))
+
+		// sizes only matter for the size column, keep the library small on disk
+		data := make([]byte, 64+rng.Intn(448))
+		rng.Read(data)
+		if err := os.WriteFile(name, data, 0o644); err != nil {
+			return fmt.Errorf("error writing %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Fixture is what got seeded into the database, so scenarios know what to ask for
+type Fixture struct {
+	Courses   *services.CourseService
+	Profiles  *services.ProfileService
+	ProfileID uuid.UUID   // profile that owns and has progress on everything
+	CourseIDs []uuid.UUID // every seeded course, including the large one
+	LargeID   uuid.UUID   // the 10k file course
+}
+
+// Seed imports the generated library through the normal import path
+func Seed(ctx context.Context, db *sql.DB, courseParser *parser.CourseParser, library *Library) (*Fixture, error) {
+	queries := database.New(db)
+	fixture := &Fixture{
+		Courses:  services.NewCourseService(queries, db, courseParser),
+		Profiles: services.NewProfileService(queries, db, rewards.Default()),
+	}
+
+	// an interrupted run leaves its courses behind and the imports would hit the duplicate check
+	dirs := append(append([]string{}, library.CourseDirs...), library.LargeDir, library.ImportDir)
+	for _, dir := range dirs {
+		if err := fixture.removeStale(ctx, filepath.Base(dir)); err != nil {
+			return nil, err
+		}
+	}
+
+	profile, err := fixture.Profiles.CreateProfile(ctx, models.Profile{Name: "perf-harness"})
+	if err != nil {
+		return nil, fmt.Errorf("error creating profile: %w", err)
+	}
+	fixture.ProfileID = profile.ID
+
+	for _, dir := range library.CourseDirs {
+		course, err := fixture.Courses.ImportCourseAs(ctx, dir, profile.ID, profile.ID)
+		if err != nil {
+			fixture.Cleanup(ctx)
+			return nil, fmt.Errorf("error importing %s: %w", dir, err)
+		}
+		fixture.CourseIDs = append(fixture.CourseIDs, course.ID)
+	}
+
+	large, err := fixture.Courses.ImportCourseAs(ctx, library.LargeDir, profile.ID, profile.ID)
+	if err != nil {
+		fixture.Cleanup(ctx)
+		return nil, fmt.Errorf("error importing large course: %w", err)
+	}
+	fixture.LargeID = large.ID
+	fixture.CourseIDs = append(fixture.CourseIDs, large.ID)
+
+	// a third of the large course done, so progress has real rows to read
+	index := 0
+	for _, module := range large.Modules {
+		for _, item := range module.ContentItems {
+			if index%3 == 0 {
+				if _, err := fixture.Courses.MarkContentItemCompleted(ctx, profile.ID, item.ID); err != nil {
+					fixture.Cleanup(ctx)
+					return nil, fmt.Errorf("error seeding progress: %w", err)
+				}
+			}
+			index++
+		}
+	}
+
+	return fixture, nil
+}
+
+// removeStale deletes a course left over from an earlier run
+func (f *Fixture) removeStale(ctx context.Context, relativePath string) error {
+	existing, err := f.Courses.DB.GetCourseByRelativePath(ctx, relativePath)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("error checking for stale course %s: %w", relativePath, err)
+	}
+	return f.Courses.DeleteCourse(ctx, existing.ID)
+}
+
+// Cleanup removes everything Seed created
+func (f *Fixture) Cleanup(ctx context.Context) {
+	for _, courseID := range f.CourseIDs {
+		if err := f.Courses.DeleteCourse(ctx, courseID); err != nil {
+			log.Printf("Warning: could not delete perf course %s: %v", courseID, err)
+		}
+	}
+
+	if f.ProfileID != uuid.Nil {
+		if err := f.Profiles.DeleteProfileByID(ctx, f.ProfileID); err != nil {
+			log.Printf("Warning: could not delete perf profile: %v", err)
+		}
+	}
+}
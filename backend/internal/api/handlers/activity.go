@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/google/uuid"
+)
+
+// ActivityHandler serves profiles' history timelines
+type ActivityHandler struct {
+	Service  *services.ActivityService // reads activity events
+	Profiles *services.ProfileService  // admin checks
+}
+
+// NewActivityHandler creates handler with injected services
+func NewActivityHandler(service *services.ActivityService, profiles *services.ProfileService) *ActivityHandler {
+	return &ActivityHandler{Service: service, Profiles: profiles}
+}
+
+// List handles GET /api/users/{id}/activity?since=&limit=50&offset=0 - what a profile did,
+// newest first. since takes a date (2006-01-02) or an RFC 3339 time. Profiles see their own
+// timeline, admins everyone's.
+func (h *ActivityHandler) List(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Activity log requested from IP: %s", r.RemoteAddr)
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in activity request", nil)
+		return
+	}
+
+	profileID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid user ID format", http.StatusBadRequest,
+			"Invalid user UUID in activity request", err)
+		return
+	}
+
+	if !requireSelfOrAdmin(w, r, h.Profiles, profileID) {
+		return
+	}
+
+	query := r.URL.Query()
+	var since time.Time
+	if sinceStr := query.Get("since"); sinceStr != "" {
+		since, err = time.Parse(time.RFC3339, sinceStr)
+		if err != nil {
+			since, err = time.ParseInLocation(time.DateOnly, sinceStr, time.Local)
+		}
+		if err != nil {
+			SendErrorResponse(w, "since must be a date (YYYY-MM-DD) or an RFC 3339 time", http.StatusBadRequest,
+				"Invalid since in activity request: "+sinceStr, err)
+			return
+		}
+	}
+
+	limit, offset := services.DefaultActivityLimit, 0
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 || parsed > services.MaxActivityLimit {
+			SendErrorResponse(w, "limit must be between 1 and "+strconv.Itoa(services.MaxActivityLimit), http.StatusBadRequest,
+				"Invalid limit in activity request: "+limitStr, err)
+			return
+		}
+		limit = parsed
+	}
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		parsed, err := strconv.Atoi(offsetStr)
+		if err != nil || parsed < 0 {
+			SendErrorResponse(w, "offset must be zero or more", http.StatusBadRequest,
+				"Invalid offset in activity request: "+offsetStr, err)
+			return
+		}
+		offset = parsed
+	}
+
+	page, err := h.Service.ListActivity(r.Context(), profileID, since, limit, offset)
+	if err != nil {
+		SendErrorResponse(w, "Failed to retrieve activity", http.StatusInternalServerError,
+			"Error retrieving activity of profile "+profileID.String(), err)
+		return
+	}
+
+	SendSuccessResponse(w, "Activity retrieved", page,
+		"Listed "+strconv.Itoa(len(page.Events))+" activity events for profile "+profileID.String())
+}
+
+// Feed handles GET /api/profiles/{id}/feed?limit=10&offset=0 - a profile's milestones for
+// the dashboard, newest first, each with a line saying what happened. Profiles see their
+// own feed, admins everyone's.
+func (h *ActivityHandler) Feed(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Profile feed requested from IP: %s", r.RemoteAddr)
+
+	profileID, ok := profileIDFromPath(w, r)
+	if !ok {
+		return
+	}
+	if !requireSelfOrAdmin(w, r, h.Profiles, profileID) {
+		return
+	}
+
+	query := r.URL.Query()
+	limit, offset := services.DefaultFeedLimit, 0
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 || parsed > services.MaxFeedLimit {
+			SendErrorResponse(w, "limit must be between 1 and "+strconv.Itoa(services.MaxFeedLimit), http.StatusBadRequest,
+				"Invalid limit in feed request: "+limitStr, err)
+			return
+		}
+		limit = parsed
+	}
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		parsed, err := strconv.Atoi(offsetStr)
+		if err != nil || parsed < 0 {
+			SendErrorResponse(w, "offset must be zero or more", http.StatusBadRequest,
+				"Invalid offset in feed request: "+offsetStr, err)
+			return
+		}
+		offset = parsed
+	}
+
+	feed, err := h.Service.GetFeed(r.Context(), profileID, limit, offset)
+	if err != nil {
+		SendErrorResponse(w, "Failed to retrieve feed", http.StatusInternalServerError,
+			"Error retrieving feed of profile "+profileID.String(), err)
+		return
+	}
+
+	SendSuccessResponse(w, "Feed retrieved", feed,
+		"Listed "+strconv.Itoa(len(feed.Items))+" feed items for profile "+profileID.String())
+}
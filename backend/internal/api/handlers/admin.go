@@ -1,20 +1,28 @@
 package handlers
 
 import (
+	"database/sql"
+	"errors"
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
 
+	"github.com/NeroQue/course-management-backend/internal/models"
 	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/google/uuid"
 )
 
 // AdminHandler handles administrative operations
 type AdminHandler struct {
-	Service *services.AdminService // admin operations go through here
+	Service   *services.AdminService    // admin operations go through here
+	Profiles  *services.ProfileService  // needed for admin checks
+	Artifacts *services.ArtifactService // generated file housekeeping
 }
 
-// NewAdminHandler creates handler with injected admin service
-func NewAdminHandler(service *services.AdminService) *AdminHandler {
-	return &AdminHandler{Service: service}
+// NewAdminHandler creates handler with injected services
+func NewAdminHandler(service *services.AdminService, profiles *services.ProfileService, artifacts *services.ArtifactService) *AdminHandler {
+	return &AdminHandler{Service: service, Profiles: profiles, Artifacts: artifacts}
 }
 
 // FactoryReset handles POST /api/admin/factory-reset - clears all database data
@@ -48,3 +56,169 @@ func (h *AdminHandler) GetStats(w http.ResponseWriter, r *http.Request) {
 	SendSuccessResponse(w, "Database statistics retrieved successfully", stats,
 		"Database statistics retrieved and returned to client")
 }
+
+// RenameTitles handles POST /api/admin/titles/rename?dry_run=true - regex rename across titles
+// Without dry_run=false this only previews the changes, so nothing gets renamed by accident.
+func (h *AdminHandler) RenameTitles(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Title rename requested from IP: %s", r.RemoteAddr)
+
+	actorID, ok := requireAdmin(w, r, h.Profiles)
+	if !ok {
+		return
+	}
+
+	var input models.TitleRenameInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in title rename request", err)
+		return
+	}
+
+	dryRun := true
+	if dryRunStr := r.URL.Query().Get("dry_run"); dryRunStr != "" {
+		parsed, err := strconv.ParseBool(dryRunStr)
+		if err != nil {
+			SendErrorResponse(w, "Invalid dry_run value", http.StatusBadRequest,
+				"Invalid dry_run in title rename: "+dryRunStr, err)
+			return
+		}
+		dryRun = parsed
+	}
+
+	var result *models.TitleRenameResult
+	var err error
+	if dryRun {
+		result, err = h.Service.PreviewTitleRename(r.Context(), input)
+	} else {
+		result, err = h.Service.ApplyTitleRename(r.Context(), input, actorID)
+	}
+	if err != nil {
+		SendErrorResponse(w, "Failed to rename titles: "+err.Error(), http.StatusBadRequest,
+			"Error during title rename", err)
+		return
+	}
+
+	message := "Title rename preview"
+	if !dryRun {
+		message = "Titles renamed"
+	}
+	SendSuccessResponse(w, message, result,
+		message+" - "+strconv.Itoa(result.Count)+" titles affected")
+}
+
+// UndoChangeBatch handles POST /api/admin/changes/{batch}/undo - reverts a recorded batch of changes
+func (h *AdminHandler) UndoChangeBatch(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Change undo requested from IP: %s", r.RemoteAddr)
+
+	if _, ok := requireAdmin(w, r, h.Profiles); !ok {
+		return
+	}
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 5 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in change undo request", nil)
+		return
+	}
+
+	batchID, err := uuid.Parse(pathParts[4])
+	if err != nil {
+		SendErrorResponse(w, "Invalid batch ID format", http.StatusBadRequest,
+			"Invalid batch UUID in change undo request", err)
+		return
+	}
+
+	reverted, skipped, err := h.Service.UndoChangeBatch(r.Context(), batchID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			SendErrorResponse(w, "Change batch not found", http.StatusNotFound,
+				"Undo requested for unknown batch "+batchID.String(), err)
+			return
+		}
+		SendErrorResponse(w, "Failed to undo changes: "+err.Error(), http.StatusBadRequest,
+			"Error undoing change batch", err)
+		return
+	}
+
+	responseData := map[string]interface{}{
+		"batch_id": batchID,
+		"reverted": reverted,
+		"skipped":  skipped,
+	}
+
+	SendSuccessResponse(w, "Changes undone", responseData,
+		"Change batch "+batchID.String()+" undone")
+}
+
+// ListAuditLog handles GET /api/admin/audit?limit=50&offset=0 - recent audit entries
+func (h *AdminHandler) ListAuditLog(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Audit log requested from IP: %s", r.RemoteAddr)
+
+	if _, ok := requireAdmin(w, r, h.Profiles); !ok {
+		return
+	}
+
+	limit, offset := 50, 0
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 || parsed > 500 {
+			SendErrorResponse(w, "limit must be between 1 and 500", http.StatusBadRequest,
+				"Invalid limit in audit log request: "+limitStr, err)
+			return
+		}
+		limit = parsed
+	}
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		parsed, err := strconv.Atoi(offsetStr)
+		if err != nil || parsed < 0 {
+			SendErrorResponse(w, "offset must be zero or more", http.StatusBadRequest,
+				"Invalid offset in audit log request: "+offsetStr, err)
+			return
+		}
+		offset = parsed
+	}
+
+	entries, err := h.Service.ListAuditLog(r.Context(), limit, offset)
+	if err != nil {
+		SendErrorResponse(w, "Failed to retrieve audit log", http.StatusInternalServerError,
+			"Error retrieving audit log", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Audit log retrieved", entries,
+		"Returned "+strconv.Itoa(len(entries))+" audit entries")
+}
+
+// GetArtifactUsage handles GET /api/admin/artifacts - disk used by generated files per course
+func (h *AdminHandler) GetArtifactUsage(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Artifact usage requested from IP: %s", r.RemoteAddr)
+
+	if _, ok := requireAdmin(w, r, h.Profiles); !ok {
+		return
+	}
+
+	report, err := h.Artifacts.GetUsageReport(r.Context())
+	if err != nil {
+		SendErrorResponse(w, "Failed to measure artifact usage", http.StatusInternalServerError,
+			"Error building artifact usage report", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Artifact usage retrieved", report,
+		"Artifact usage report returned for "+strconv.Itoa(len(report.Courses))+" courses")
+}
+
+// CleanupArtifacts handles POST /api/admin/artifacts/cleanup - runs the janitor now
+func (h *AdminHandler) CleanupArtifacts(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Artifact cleanup requested from IP: %s", r.RemoteAddr)
+
+	if _, ok := requireAdmin(w, r, h.Profiles); !ok {
+		return
+	}
+
+	taskID := h.Artifacts.StartCleanupTask()
+
+	responseData := map[string]string{"task_id": taskID}
+	SendSuccessResponse(w, "Artifact cleanup started", responseData,
+		"Artifact cleanup task created with ID: "+taskID)
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/NeroQue/course-management-backend/pkg/session"
+	"github.com/google/uuid"
+)
+
+// APITokenHandler processes API token requests
+type APITokenHandler struct {
+	Service  *services.APITokenService // token logic
+	Profiles *services.ProfileService  // needed for admin checks
+}
+
+// NewAPITokenHandler creates handler with injected services
+func NewAPITokenHandler(service *services.APITokenService, profiles *services.ProfileService) *APITokenHandler {
+	return &APITokenHandler{Service: service, Profiles: profiles}
+}
+
+// Create handles POST /api/profiles/{id}/tokens - self or admin. The token is only in
+// this response, it can't be looked up again.
+func (h *APITokenHandler) Create(w http.ResponseWriter, r *http.Request) {
+	log.Printf("API token creation requested from IP: %s", r.RemoteAddr)
+
+	profileID, ok := h.tokenProfile(w, r)
+	if !ok {
+		return
+	}
+
+	// a leaked token mustn't be able to mint more that outlive its revocation
+	if session.CurrentAPIToken(r.Context()) != nil {
+		SendErrorResponse(w, "API tokens can't create API tokens, log in to create one", http.StatusForbidden,
+			"API token creation for "+profileID.String()+" attempted with an API token", nil)
+		return
+	}
+
+	var input models.CreateAPITokenInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in API token creation", err)
+		return
+	}
+
+	token, err := h.Service.CreateAPIToken(r.Context(), profileID, input)
+	if err != nil {
+		sendAPITokenError(w, err, profileID)
+		return
+	}
+
+	SendCreatedResponse(w, "API token created", token,
+		"API token "+token.ID.String()+" ("+token.Scope+") created for profile "+profileID.String())
+}
+
+// List handles GET /api/profiles/{id}/tokens - self or admin, without the tokens themselves
+func (h *APITokenHandler) List(w http.ResponseWriter, r *http.Request) {
+	log.Printf("API token list requested from IP: %s", r.RemoteAddr)
+
+	profileID, ok := h.tokenProfile(w, r)
+	if !ok {
+		return
+	}
+
+	tokens, err := h.Service.ListAPITokens(r.Context(), profileID)
+	if err != nil {
+		sendAPITokenError(w, err, profileID)
+		return
+	}
+
+	SendSuccessResponse(w, "API tokens retrieved", tokens,
+		"Returned "+strconv.Itoa(len(tokens))+" API tokens for profile "+profileID.String())
+}
+
+// Revoke handles DELETE /api/profiles/{id}/tokens/{token} - self or admin
+func (h *APITokenHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	log.Printf("API token revocation requested from IP: %s", r.RemoteAddr)
+
+	profileID, ok := h.tokenProfile(w, r)
+	if !ok {
+		return
+	}
+
+	tokenID, err := uuid.Parse(r.PathValue("token"))
+	if err != nil {
+		SendErrorResponse(w, "Invalid token ID format", http.StatusBadRequest,
+			"Invalid API token UUID in revocation", err)
+		return
+	}
+
+	if err := h.Service.RevokeAPIToken(r.Context(), profileID, tokenID); err != nil {
+		sendAPITokenError(w, err, profileID)
+		return
+	}
+
+	SendSuccessResponse(w, "API token revoked", nil,
+		"API token "+tokenID.String()+" of profile "+profileID.String()+" revoked")
+}
+
+// tokenProfile pulls the profile out of the path and checks the caller may manage its tokens
+func (h *APITokenHandler) tokenProfile(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	profileID, ok := profileIDFromPath(w, r)
+	if !ok {
+		return uuid.Nil, false
+	}
+	if !requireSelfOrAdmin(w, r, h.Profiles, profileID) {
+		return uuid.Nil, false
+	}
+	return profileID, true
+}
+
+// sendAPITokenError maps API token errors to responses
+func sendAPITokenError(w http.ResponseWriter, err error, profileID uuid.UUID) {
+	switch {
+	case errors.Is(err, services.ErrInvalidAPIToken):
+		SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+			"Invalid API token request for "+profileID.String(), err)
+	case errors.Is(err, services.ErrAPITokenNotFound):
+		SendErrorResponse(w, err.Error(), http.StatusNotFound,
+			"Missing API token requested for "+profileID.String(), nil)
+	case errors.Is(err, sql.ErrNoRows):
+		SendErrorResponse(w, "Profile not found", http.StatusNotFound,
+			"API token request for missing profile "+profileID.String(), err)
+	default:
+		SendErrorResponse(w, "Failed to process API token", http.StatusInternalServerError,
+			"Error handling API tokens of "+profileID.String(), err)
+	}
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/NeroQue/course-management-backend/pkg/session"
+	"github.com/google/uuid"
+)
+
+// requireAdmin makes sure the current session belongs to an admin profile.
+// It writes the error response itself, callers just return when ok is false.
+func requireAdmin(w http.ResponseWriter, r *http.Request, profiles *services.ProfileService) (uuid.UUID, bool) {
+	userID := session.GetCurrentUser(r.Context())
+	if userID == uuid.Nil {
+		SendErrorResponse(w, "You must be logged in", http.StatusUnauthorized,
+			"Unauthorized admin request to "+r.URL.Path, nil)
+		return uuid.Nil, false
+	}
+
+	isAdmin, err := profiles.IsAdmin(r.Context(), userID)
+	if err != nil {
+		SendErrorResponse(w, "Failed to check permissions", http.StatusInternalServerError,
+			"Error checking admin rights for "+userID.String(), err)
+		return uuid.Nil, false
+	}
+
+	if !isAdmin {
+		SendErrorResponse(w, "Admin rights required", http.StatusForbidden,
+			"Non-admin profile "+userID.String()+" attempted admin request to "+r.URL.Path, nil)
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
+
+// isLocalRequest reports whether the request comes from the server itself. Anything a
+// reverse proxy forwarded counts as remote, even though the proxy connects from localhost.
+func isLocalRequest(r *http.Request) bool {
+	if r.Header.Get("X-Forwarded-For") != "" || r.Header.Get("Forwarded") != "" || r.Header.Get("X-Real-IP") != "" {
+		return false
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// requireSelfOrAdmin makes sure the current session is the given profile or an admin.
+// Like requireAdmin it writes the error response itself.
+func requireSelfOrAdmin(w http.ResponseWriter, r *http.Request, profiles *services.ProfileService, profileID uuid.UUID) bool {
+	userID := session.GetCurrentUser(r.Context())
+	if userID == uuid.Nil {
+		SendErrorResponse(w, "You must be logged in", http.StatusUnauthorized,
+			"Unauthorized request to "+r.URL.Path, nil)
+		return false
+	}
+
+	if userID == profileID {
+		return true
+	}
+
+	_, ok := requireAdmin(w, r, profiles)
+	return ok
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+
+	"github.com/NeroQue/course-management-backend/internal/services"
+)
+
+// largest avatar upload accepted, phone photos included - it's shrunk before it's stored
+const maxAvatarSize = 20 << 20
+
+// UploadAvatar handles POST /api/profiles/{id}/avatar - sets a profile's picture (self or admin).
+// The image is either the whole request body or the "avatar" field of a multipart form.
+func (h *ProfileHandler) UploadAvatar(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Profile avatar upload requested from IP: %s", r.RemoteAddr)
+
+	profileID, ok := profileIDFromPath(w, r)
+	if !ok {
+		return
+	}
+	if !requireSelfOrAdmin(w, r, h.Service, profileID) {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxAvatarSize)
+	var upload io.Reader = r.Body
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "multipart/form-data" {
+		file, _, err := r.FormFile("avatar")
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			SendErrorResponse(w, "Avatar image is too large", http.StatusRequestEntityTooLarge,
+				"Oversized avatar upload for profile "+profileID.String(), err)
+			return
+		}
+		if err != nil {
+			SendErrorResponse(w, "Avatar image is required in the \"avatar\" form field", http.StatusBadRequest,
+				"Missing avatar field in upload for profile "+profileID.String(), err)
+			return
+		}
+		defer file.Close()
+		upload = file
+	}
+
+	updatedProfile, err := h.Service.SetAvatar(r.Context(), profileID, upload)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			SendErrorResponse(w, "Avatar image is too large", http.StatusRequestEntityTooLarge,
+				"Oversized avatar upload for profile "+profileID.String(), err)
+		case errors.Is(err, services.ErrInvalidAvatar):
+			SendErrorResponse(w, "Avatar must be a JPEG, PNG or GIF image", http.StatusBadRequest,
+				"Undecodable avatar upload for profile "+profileID.String(), err)
+		case errors.Is(err, sql.ErrNoRows):
+			SendErrorResponse(w, "Profile not found", http.StatusNotFound,
+				"Avatar upload for missing profile "+profileID.String(), err)
+		default:
+			SendErrorResponse(w, "Failed to save avatar", http.StatusInternalServerError,
+				"Error saving avatar for profile "+profileID.String(), err)
+		}
+		return
+	}
+
+	SendSuccessResponse(w, "Avatar updated", updatedProfile,
+		"Avatar uploaded for profile "+profileID.String())
+}
+
+// GetAvatar handles GET /api/profiles/{id}/avatar - the profile's picture as a JPEG. It's
+// public like the profile list, the picker shows it before anyone is logged in.
+func (h *ProfileHandler) GetAvatar(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Profile avatar requested from IP: %s", r.RemoteAddr)
+
+	profileID, ok := profileIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	path, version, err := h.Service.GetAvatar(r.Context(), profileID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			SendErrorResponse(w, "Profile not found", http.StatusNotFound,
+				"Avatar requested for missing profile "+profileID.String(), err)
+		case errors.Is(err, services.ErrNoAvatar):
+			SendErrorResponse(w, "Profile has no avatar", http.StatusNotFound,
+				"No avatar for profile "+profileID.String(), err)
+		default:
+			SendErrorResponse(w, "Failed to retrieve avatar", http.StatusInternalServerError,
+				"Error retrieving avatar for profile "+profileID.String(), err)
+		}
+		return
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		SendErrorResponse(w, "Profile has no avatar", http.StatusNotFound,
+			"Avatar vanished for profile "+profileID.String(), err)
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		SendErrorResponse(w, "Failed to retrieve avatar", http.StatusInternalServerError,
+			"Error reading avatar for profile "+profileID.String(), err)
+		return
+	}
+
+	// the avatar_url of a profile names its version, that URL never changes content
+	w.Header().Set("ETag", "\""+version+"\"")
+	if r.URL.Query().Get("v") == version {
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	} else {
+		w.Header().Set("Cache-Control", "no-cache")
+	}
+	http.ServeContent(w, r, "avatar.jpg", info.ModTime(), file)
+}
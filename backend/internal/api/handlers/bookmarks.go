@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/NeroQue/course-management-backend/pkg/session"
+	"github.com/google/uuid"
+)
+
+// BookmarkHandler processes bookmark-related HTTP requests
+type BookmarkHandler struct {
+	Service *services.BookmarkService // bookmark business logic
+}
+
+// NewBookmarkHandler creates handler with injected service
+func NewBookmarkHandler(service *services.BookmarkService) *BookmarkHandler {
+	return &BookmarkHandler{Service: service}
+}
+
+// Create handles POST /api/content/{id}/bookmarks - bookmarks a moment of an audio or video item
+func (h *BookmarkHandler) Create(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Bookmark creation requested from IP: %s", r.RemoteAddr)
+
+	userID, itemID, ok := bookmarkRequestContext(w, r, "content")
+	if !ok {
+		return
+	}
+
+	var input models.CreateBookmarkInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in bookmark request", err)
+		return
+	}
+
+	bookmark, err := h.Service.CreateBookmark(r.Context(), userID, itemID, input)
+	if err != nil {
+		sendBookmarkError(w, err, itemID, userID)
+		return
+	}
+
+	SendCreatedResponse(w, "Bookmark created", bookmark,
+		"Bookmark "+bookmark.ID.String()+" created on content "+itemID.String())
+}
+
+// List handles GET /api/content/{id}/bookmarks - the current profile's bookmarks on an item
+func (h *BookmarkHandler) List(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Bookmarks requested from IP: %s", r.RemoteAddr)
+
+	userID, itemID, ok := bookmarkRequestContext(w, r, "content")
+	if !ok {
+		return
+	}
+
+	bookmarks, err := h.Service.ListBookmarks(r.Context(), userID, itemID)
+	if err != nil {
+		sendBookmarkError(w, err, itemID, userID)
+		return
+	}
+
+	SendSuccessResponse(w, "Bookmarks retrieved", bookmarks,
+		"Bookmarks returned for content "+itemID.String())
+}
+
+// Delete handles DELETE /api/bookmarks/{id}
+func (h *BookmarkHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Bookmark deletion requested from IP: %s", r.RemoteAddr)
+
+	userID, bookmarkID, ok := bookmarkRequestContext(w, r, "bookmark")
+	if !ok {
+		return
+	}
+
+	if err := h.Service.DeleteBookmark(r.Context(), userID, bookmarkID); err != nil {
+		sendBookmarkError(w, err, bookmarkID, userID)
+		return
+	}
+
+	SendSuccessResponse(w, "Bookmark deleted", nil,
+		"Bookmark "+bookmarkID.String()+" deleted")
+}
+
+// bookmarkRequestContext pulls the logged in user and the ID in the path out of the request
+func bookmarkRequestContext(w http.ResponseWriter, r *http.Request, kind string) (uuid.UUID, uuid.UUID, bool) {
+	userID := session.GetCurrentUser(r.Context())
+	if userID == uuid.Nil {
+		SendErrorResponse(w, "You must be logged in to use bookmarks", http.StatusUnauthorized,
+			"Unauthorized bookmark request", nil)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in bookmark request", nil)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid "+kind+" ID format", http.StatusBadRequest,
+			"Invalid "+kind+" UUID in bookmark request", err)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return userID, id, true
+}
+
+// sendBookmarkError maps bookmark errors to responses
+func sendBookmarkError(w http.ResponseWriter, err error, id, userID uuid.UUID) {
+	switch {
+	case errors.Is(err, services.ErrInvalidBookmark), errors.Is(err, services.ErrNotMediaContent):
+		SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+			"Invalid bookmark for "+id.String(), err)
+	case errors.Is(err, services.ErrBookmarkNotFound), errors.Is(err, sql.ErrNoRows):
+		SendErrorResponse(w, err.Error(), http.StatusNotFound,
+			"Missing "+id.String()+" in bookmark request", err)
+	case errors.Is(err, services.ErrContentNotVisible):
+		// hidden courses look the same as missing ones
+		SendErrorResponse(w, "Content item not found", http.StatusNotFound,
+			"Content "+id.String()+" hidden from profile "+userID.String(), nil)
+	default:
+		SendErrorResponse(w, "Failed to process bookmark", http.StatusInternalServerError,
+			"Error handling bookmark for "+id.String(), err)
+	}
+}
@@ -0,0 +1,282 @@
+package handlers
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/NeroQue/course-management-backend/pkg/session"
+	"github.com/google/uuid"
+)
+
+// maxBundleSize caps uploaded bundles - they only hold metadata, not media
+const maxBundleSize = 64 << 20
+
+// ExportCourse handles GET /api/courses/{id}/export?format=json|tar.gz&include_progress=true&user_id={uuid}
+// Produces a bundle another instance can import; tar.gz wraps the same data as metadata.json.
+func (h *CourseHandler) ExportCourse(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Course export requested from IP: %s", r.RemoteAddr)
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in course export request", nil)
+		return
+	}
+
+	courseID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid course ID format", http.StatusBadRequest,
+			"Invalid course UUID in export request", err)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "tar.gz" {
+		SendErrorResponse(w, "format must be json or tar.gz", http.StatusBadRequest,
+			"Invalid export format: "+format, nil)
+		return
+	}
+
+	// progress is opt-in and needs to know whose progress to include
+	var progressUser uuid.NullUUID
+	if includeStr := r.URL.Query().Get("include_progress"); includeStr != "" {
+		include, err := strconv.ParseBool(includeStr)
+		if err != nil {
+			SendErrorResponse(w, "Invalid include_progress value", http.StatusBadRequest,
+				"Invalid include_progress in export request: "+includeStr, err)
+			return
+		}
+
+		if include {
+			userID, err := uuid.Parse(r.URL.Query().Get("user_id"))
+			if err != nil {
+				SendErrorResponse(w, "A valid user_id is required when including progress", http.StatusBadRequest,
+					"Missing or invalid user_id in export request", err)
+				return
+			}
+			progressUser = uuid.NullUUID{UUID: userID, Valid: true}
+		}
+	}
+
+	bundle, err := h.Service.ExportCourse(r.Context(), courseID, progressUser)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			SendErrorResponse(w, "Course not found", http.StatusNotFound,
+				"Export requested for non-existent course", err)
+			return
+		}
+		SendErrorResponse(w, "Failed to export course", http.StatusInternalServerError,
+			"Error exporting course", err)
+		return
+	}
+
+	if format == "json" {
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="course-%s.json"`, courseID))
+		SendSuccessResponse(w, "Course exported", bundle,
+			"Course "+courseID.String()+" exported as JSON")
+		return
+	}
+
+	if err := writeBundleArchive(w, courseID, bundle); err != nil {
+		// headers are already out at this point, so just log it
+		log.Printf("Error writing course export archive: %v", err)
+		return
+	}
+	log.Printf("Course %s exported as tar.gz", courseID.String())
+}
+
+// writeBundleArchive streams the bundle as a tar.gz containing metadata.json
+func writeBundleArchive(w http.ResponseWriter, courseID uuid.UUID, bundle *models.CourseBundle) error {
+	metadata, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode bundle: %w", err)
+	}
+
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="course-%s.tar.gz"`, courseID))
+	w.WriteHeader(http.StatusOK)
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	header := &tar.Header{
+		Name:    "metadata.json",
+		Mode:    0644,
+		Size:    int64(len(metadata)),
+		ModTime: bundle.ExportedAt,
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write archive header: %w", err)
+	}
+	if _, err := tw.Write(metadata); err != nil {
+		return fmt.Errorf("failed to write metadata: %w", err)
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return gz.Close()
+}
+
+// ImportBundle handles POST /api/courses/import-bundle?relative_path=...&strict=true&creator_id=...&all_progress=true
+// Accepts the export as raw JSON, the export API response, or the tar.gz with metadata.json.
+func (h *CourseHandler) ImportBundle(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Course bundle import requested from IP: %s", r.RemoteAddr)
+
+	userID := session.GetCurrentUser(r.Context())
+	if userID == uuid.Nil {
+		SendErrorResponse(w, "You must be logged in to import courses", http.StatusUnauthorized,
+			"Unauthorized bundle import attempt", nil)
+		return
+	}
+
+	query := r.URL.Query()
+
+	ownerID := userID
+	if creatorStr := query.Get("creator_id"); creatorStr != "" {
+		creatorID, err := uuid.Parse(creatorStr)
+		if err != nil {
+			SendErrorResponse(w, "Invalid creator_id format", http.StatusBadRequest,
+				"Invalid creator UUID in bundle import", err)
+			return
+		}
+		if !h.authorizeCreatorOverride(w, r, userID, creatorID) {
+			return
+		}
+		ownerID = creatorID
+	}
+
+	strict := false
+	if strictStr := query.Get("strict"); strictStr != "" {
+		parsed, err := strconv.ParseBool(strictStr)
+		if err != nil {
+			SendErrorResponse(w, "Invalid strict value", http.StatusBadRequest,
+				"Invalid strict in bundle import: "+strictStr, err)
+			return
+		}
+		strict = parsed
+	}
+
+	// the bundle's progress is only the importer's own, unless an admin asks for all of it
+	allProgress := false
+	if allStr := query.Get("all_progress"); allStr != "" {
+		parsed, err := strconv.ParseBool(allStr)
+		if err != nil {
+			SendErrorResponse(w, "Invalid all_progress value", http.StatusBadRequest,
+				"Invalid all_progress in bundle import: "+allStr, err)
+			return
+		}
+		if parsed {
+			if _, ok := requireAdmin(w, r, h.Profiles); !ok {
+				return
+			}
+		}
+		allProgress = parsed
+	}
+
+	bundle, err := readBundle(http.MaxBytesReader(w, r.Body, maxBundleSize))
+	if err != nil {
+		SendErrorResponse(w, "Invalid bundle: "+err.Error(), http.StatusBadRequest,
+			"Could not read uploaded course bundle", err)
+		return
+	}
+
+	result, err := h.Service.ImportBundle(r.Context(), bundle, query.Get("relative_path"), ownerID, userID, strict, allProgress)
+	if err != nil {
+		var duplicate *services.DuplicateCourseError
+		if errors.As(err, &duplicate) {
+			SendErrorResponseWithData(w, "Course has already been imported", http.StatusConflict,
+				map[string]string{"existing_course_id": duplicate.ExistingID.String()},
+				"Duplicate bundle import attempted", err)
+			return
+		}
+		if result != nil {
+			// strict mode - tell the client which files are missing
+			SendErrorResponseWithData(w, "Failed to import bundle: "+err.Error(), http.StatusUnprocessableEntity,
+				map[string]interface{}{"missing_files": result.MissingFiles},
+				"Bundle import refused because of missing files", err)
+			return
+		}
+		SendErrorResponse(w, "Failed to import bundle: "+err.Error(), http.StatusBadRequest,
+			"Error importing course bundle", err)
+		return
+	}
+
+	SendCreatedResponse(w, "Course bundle imported", result,
+		"Course bundle imported as "+result.Course.ID.String()+" with "+
+			strconv.Itoa(len(result.MissingFiles))+" missing files")
+}
+
+// readBundle decodes an uploaded bundle in any of the formats the export endpoint produces
+func readBundle(body io.Reader) (*models.CourseBundle, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, errors.New("request body is required")
+	}
+
+	// gzip magic number means it's the tar.gz export
+	if len(data) > 2 && data[0] == 0x1f && data[1] == 0x8b {
+		data, err = extractBundleMetadata(data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// JSON exports come wrapped in the usual API response, unwrap if needed
+	var envelope struct {
+		FormatVersion int             `json:"format_version"`
+		Data          json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if envelope.FormatVersion == 0 && len(envelope.Data) > 0 {
+		data = envelope.Data
+	}
+
+	var bundle models.CourseBundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("invalid bundle JSON: %w", err)
+	}
+	return &bundle, nil
+}
+
+// extractBundleMetadata pulls metadata.json out of a tar.gz bundle
+func extractBundleMetadata(archive []byte) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("invalid gzip data: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil, errors.New("archive has no metadata.json")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid tar data: %w", err)
+		}
+		if header.Name == "metadata.json" || strings.HasSuffix(header.Name, "/metadata.json") {
+			return io.ReadAll(tr)
+		}
+	}
+}
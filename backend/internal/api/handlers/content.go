@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/NeroQue/course-management-backend/pkg/session"
+	"github.com/google/uuid"
+)
+
+// ContentHandler processes content item editing HTTP requests
+type ContentHandler struct {
+	Service *services.ContentService // content item business logic
+}
+
+// NewContentHandler creates handler with injected content service
+func NewContentHandler(service *services.ContentService) *ContentHandler {
+	return &ContentHandler{Service: service}
+}
+
+// Update handles PATCH /api/content/{id} - renames, edits description or moves to another module
+func (h *ContentHandler) Update(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Content item update requested from IP: %s", r.RemoteAddr)
+
+	if session.GetCurrentUser(r.Context()) == uuid.Nil {
+		SendErrorResponse(w, "You must be logged in to edit content", http.StatusUnauthorized,
+			"Unauthorized content update attempt", nil)
+		return
+	}
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in content update request", nil)
+		return
+	}
+
+	itemID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid content item ID format", http.StatusBadRequest,
+			"Invalid content item UUID in update request", err)
+		return
+	}
+
+	var input models.UpdateContentItemInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in content update request", err)
+		return
+	}
+
+	if input.Title == nil && input.Description == nil && input.ModuleID == nil {
+		SendErrorResponse(w, "Nothing to update - provide title, description or module_id", http.StatusBadRequest,
+			"Empty content update request", nil)
+		return
+	}
+
+	if input.Title != nil && strings.TrimSpace(*input.Title) == "" {
+		SendErrorResponse(w, "Title cannot be empty", http.StatusBadRequest,
+			"Content update attempted with empty title", nil)
+		return
+	}
+
+	item, err := h.Service.UpdateContentItem(r.Context(), itemID, input)
+	if err != nil {
+		sendContentError(w, err, "Failed to update content item", "Error updating content item "+itemID.String())
+		return
+	}
+
+	SendSuccessResponse(w, "Content item updated successfully", item,
+		"Content item "+itemID.String()+" updated")
+}
+
+// SetHidden handles POST /api/content/{id}/hidden - hides or shows an item without deleting it.
+// Body {"hidden": true|false} sets the flag; an empty body flips it.
+func (h *ContentHandler) SetHidden(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Content hidden toggle requested from IP: %s", r.RemoteAddr)
+
+	if session.GetCurrentUser(r.Context()) == uuid.Nil {
+		SendErrorResponse(w, "You must be logged in to hide content", http.StatusUnauthorized,
+			"Unauthorized content hide attempt", nil)
+		return
+	}
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in content hide request", nil)
+		return
+	}
+
+	itemID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid content item ID format", http.StatusBadRequest,
+			"Invalid content item UUID in hide request", err)
+		return
+	}
+
+	var input models.SetContentHiddenInput
+	if r.ContentLength != 0 {
+		if err := ValidateJSONBody(r, &input); err != nil {
+			SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+				"Invalid JSON in content hide request", err)
+			return
+		}
+	}
+
+	item, err := h.Service.SetContentItemHidden(r.Context(), itemID, input.Hidden)
+	if err != nil {
+		sendContentError(w, err, "Failed to update content item", "Error hiding content item "+itemID.String())
+		return
+	}
+
+	message := "Content item is visible again"
+	if item.Hidden {
+		message = "Content item hidden"
+	}
+	SendSuccessResponse(w, message, item,
+		"Content item "+itemID.String()+" hidden="+strconv.FormatBool(item.Hidden))
+}
+
+// Reorder handles POST /api/modules/{id}/content/reorder - sets the order of all items in a module
+func (h *ContentHandler) Reorder(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Content reorder requested from IP: %s", r.RemoteAddr)
+
+	if session.GetCurrentUser(r.Context()) == uuid.Nil {
+		SendErrorResponse(w, "You must be logged in to reorder content", http.StatusUnauthorized,
+			"Unauthorized content reorder attempt", nil)
+		return
+	}
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in content reorder request", nil)
+		return
+	}
+
+	moduleID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid module ID format", http.StatusBadRequest,
+			"Invalid module UUID in content reorder request", err)
+		return
+	}
+
+	var input models.ReorderContentItemsInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in content reorder request", err)
+		return
+	}
+
+	items, err := h.Service.ReorderContentItems(r.Context(), moduleID, input.ContentItemIDs)
+	if err != nil {
+		sendContentError(w, err, "Failed to reorder content", "Error reordering content in module "+moduleID.String())
+		return
+	}
+
+	SendSuccessResponse(w, "Content reordered successfully", items,
+		"Content reordered in module "+moduleID.String())
+}
+
+// SetChapters handles PUT /api/content/{id}/chapters - replaces the chapter markers of an audio
+// or video item. Body {"chapters": [{"title": "...", "start": 90.5}]}; an empty list goes back
+// to the chapters embedded in the file.
+func (h *ContentHandler) SetChapters(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Content chapters update requested from IP: %s", r.RemoteAddr)
+
+	if session.GetCurrentUser(r.Context()) == uuid.Nil {
+		SendErrorResponse(w, "You must be logged in to edit chapters", http.StatusUnauthorized,
+			"Unauthorized chapters update attempt", nil)
+		return
+	}
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in chapters update request", nil)
+		return
+	}
+
+	itemID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid content item ID format", http.StatusBadRequest,
+			"Invalid content item UUID in chapters update request", err)
+		return
+	}
+
+	var input models.SetChaptersInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in chapters update request", err)
+		return
+	}
+
+	markers, err := h.Service.SetChapters(r.Context(), itemID, input.Chapters)
+	if err != nil {
+		sendContentError(w, err, "Failed to update chapters", "Error updating chapters of content item "+itemID.String())
+		return
+	}
+
+	SendSuccessResponse(w, "Chapters updated successfully", markers,
+		"Saved "+strconv.Itoa(len(markers))+" chapters for content item "+itemID.String())
+}
+
+// sendContentError maps content service errors to status codes
+func sendContentError(w http.ResponseWriter, err error, message, logMessage string) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		SendErrorResponse(w, "Not found", http.StatusNotFound, logMessage, err)
+	case errors.Is(err, services.ErrContentFileMissing):
+		SendErrorResponse(w, err.Error(), http.StatusConflict, logMessage, err)
+	case errors.Is(err, services.ErrInvalidContentOrder), errors.Is(err, services.ErrInvalidContentMove),
+		errors.Is(err, services.ErrInvalidChapters), errors.Is(err, services.ErrNotMediaContent):
+		SendErrorResponse(w, err.Error(), http.StatusBadRequest, logMessage, err)
+	default:
+		SendErrorResponse(w, message, http.StatusInternalServerError, logMessage, err)
+	}
+}
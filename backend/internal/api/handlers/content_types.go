@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/internal/services"
+)
+
+// ContentTypeHandler processes unknown content triage requests - all admin only
+type ContentTypeHandler struct {
+	Service  *services.ContentTypeService // extension mappings
+	Profiles *services.ProfileService     // needed for admin checks
+}
+
+// NewContentTypeHandler creates handler with injected services
+func NewContentTypeHandler(service *services.ContentTypeService, profiles *services.ProfileService) *ContentTypeHandler {
+	return &ContentTypeHandler{Service: service, Profiles: profiles}
+}
+
+// ListUnknown handles GET /api/admin/unknown-content - unclassified items grouped by extension
+func (h *ContentTypeHandler) ListUnknown(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Unknown content list requested from IP: %s", r.RemoteAddr)
+
+	if _, ok := requireAdmin(w, r, h.Profiles); !ok {
+		return
+	}
+
+	groups, err := h.Service.ListUnknownContent(r.Context())
+	if err != nil {
+		SendErrorResponse(w, "Failed to list unknown content", http.StatusInternalServerError,
+			"Error retrieving unknown content", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Unknown content retrieved", groups,
+		"Unknown content returned in "+strconv.Itoa(len(groups))+" extension groups")
+}
+
+// MapExtension handles POST /api/admin/unknown-content/map - saves an extension mapping
+// and reclassifies existing unknown items with that extension
+func (h *ContentTypeHandler) MapExtension(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Content type mapping requested from IP: %s", r.RemoteAddr)
+
+	actorID, ok := requireAdmin(w, r, h.Profiles)
+	if !ok {
+		return
+	}
+
+	var input models.MapContentTypeInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in content type mapping", err)
+		return
+	}
+
+	result, err := h.Service.MapExtension(r.Context(), actorID, input)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidContentTypeMapping) {
+			SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+				"Rejected content type mapping", err)
+			return
+		}
+		SendErrorResponse(w, "Failed to map content type", http.StatusInternalServerError,
+			"Error saving content type mapping", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Content type mapping saved", result,
+		"Mapped "+result.Mapping.Extension+" to "+result.Mapping.ContentType+", reclassified "+
+			strconv.FormatInt(result.Reclassified, 10)+" items")
+}
@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"log"
 	"net/http"
 	"path/filepath"
@@ -26,34 +28,460 @@ type BatchImportResponse struct {
 	FailureCount    int              `json:"failure_count"`
 	ImportedCourses []*models.Course `json:"imported_courses"`
 	Errors          []string         `json:"errors,omitempty"`
+	WarningCount    int              `json:"warning_count"` // total warnings across imported courses
 }
 
 // CourseHandler processes course-related HTTP requests
 type CourseHandler struct {
-	Service *services.CourseService // handles all course business logic
+	Service       *services.CourseService       // handles all course business logic
+	Notes         *services.NoteService         // course notes shown alongside courses
+	Profiles      *services.ProfileService      // for checking who may import on behalf of others
+	Notifications *services.NotificationService // tells profiles when batch imports finish
+	Visibility    *services.VisibilityService   // hides courses not assigned to restricted profiles
+	Prerequisites *services.PrerequisiteService // marks courses locked behind unfinished prerequisites
+	Tiering       *services.TieringService      // tracks content access and restores cold files
+	Gamification  *services.GamificationService // awards XP for completions
 }
 
-// NewCourseHandler creates handler with injected service
-func NewCourseHandler(service *services.CourseService) *CourseHandler {
-	return &CourseHandler{Service: service}
+// NewCourseHandler creates handler with injected services
+func NewCourseHandler(service *services.CourseService, notes *services.NoteService, profiles *services.ProfileService,
+	notifications *services.NotificationService, visibility *services.VisibilityService,
+	prerequisites *services.PrerequisiteService, tiering *services.TieringService,
+	gamification *services.GamificationService) *CourseHandler {
+	h := &CourseHandler{
+		Service:       service,
+		Notes:         notes,
+		Profiles:      profiles,
+		Notifications: notifications,
+		Visibility:    visibility,
+		Prerequisites: prerequisites,
+		Tiering:       tiering,
+		Gamification:  gamification,
+	}
+	task.Register("batch_import", h.batchImportWork)
+	return h
+}
+
+// authorizeCreatorOverride checks that the actor may import a course owned by creatorID.
+// Anyone may import for themselves; only admins may import on behalf of another existing profile.
+// Writes the error response itself and returns false if not allowed.
+func (h *CourseHandler) authorizeCreatorOverride(w http.ResponseWriter, r *http.Request, actorID, creatorID uuid.UUID) bool {
+	if creatorID == uuid.Nil || creatorID == actorID {
+		return true
+	}
+
+	isAdmin, err := h.Profiles.IsAdmin(r.Context(), actorID)
+	if err != nil {
+		SendErrorResponse(w, "Failed to check permissions", http.StatusInternalServerError,
+			"Error checking admin rights for "+actorID.String(), err)
+		return false
+	}
+	if !isAdmin {
+		SendErrorResponse(w, "Only admins may import courses on behalf of another profile", http.StatusForbidden,
+			"Non-admin profile "+actorID.String()+" attempted import for "+creatorID.String(), nil)
+		return false
+	}
+
+	if _, err := h.Profiles.GetProfileByID(r.Context(), creatorID); err != nil {
+		SendErrorResponse(w, "creator_id does not match an existing profile", http.StatusBadRequest,
+			"Import attempted for unknown creator "+creatorID.String(), err)
+		return false
+	}
+
+	return true
 }
 
-// List handles GET /api/courses - returns all courses
+// List handles GET /api/courses?enrolled=true&favorites=true&difficulty=&language=&tag=&fields=...&include=... - returns all courses, or just the current profile's
 func (h *CourseHandler) List(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Course list requested from IP: %s", r.RemoteAddr)
 
-	// get courses from service layer
-	courses, err := h.Service.ListCourses(r.Context())
+	view, err := parseCourseView(r)
+	if err != nil {
+		SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+			"Invalid field selection in course list request", err)
+		return
+	}
+
+	filter, err := services.NormalizeCourseFilter(models.CourseFilter{
+		Difficulty: r.URL.Query().Get("difficulty"),
+		Language:   r.URL.Query().Get("language"),
+		Tag:        r.URL.Query().Get("tag"),
+	})
+	if err != nil {
+		SendErrorResponse(w, strings.TrimPrefix(err.Error(), services.ErrInvalidCourseUpdate.Error()+": "), http.StatusBadRequest,
+			"Invalid filter in course list request", err)
+		return
+	}
+
+	// get courses from service layer, only as deep as the client asked for
+	courses, err := h.Service.ListCoursesWithDepth(r.Context(), view.Depth)
 	if err != nil {
 		SendErrorResponse(w, "Failed to retrieve courses", http.StatusInternalServerError,
 			"Error retrieving courses from database", err)
 		return
 	}
+	courses = services.FilterCourses(courses, filter)
+
+	// restricted profiles only see what an admin assigned them
+	if userID := session.GetCurrentUser(r.Context()); userID != uuid.Nil {
+		courses, err = h.Visibility.FilterVisibleCourses(r.Context(), userID, courses)
+		if err != nil {
+			SendErrorResponse(w, "Failed to retrieve courses", http.StatusInternalServerError,
+				"Error applying course visibility", err)
+			return
+		}
+	}
+
+	if r.URL.Query().Get("enrolled") == "true" {
+		userID := session.GetCurrentUser(r.Context())
+		if userID == uuid.Nil {
+			SendErrorResponse(w, "You must select a profile to list enrolled courses", http.StatusUnauthorized,
+				"Enrolled course list requested without a profile", nil)
+			return
+		}
+
+		courses, err = h.Service.FilterEnrolledCourses(r.Context(), userID, courses)
+		if err != nil {
+			SendErrorResponse(w, "Failed to retrieve courses", http.StatusInternalServerError,
+				"Error filtering enrolled courses", err)
+			return
+		}
+	}
+
+	favoritesOnly := r.URL.Query().Get("favorites") == "true"
+	if favoritesOnly && session.GetCurrentUser(r.Context()) == uuid.Nil {
+		SendErrorResponse(w, "You must select a profile to list favorite courses", http.StatusUnauthorized,
+			"Favorite course list requested without a profile", nil)
+		return
+	}
+
+	if favoritesOnly {
+		if err := h.Service.AttachFavorites(r.Context(), session.GetCurrentUser(r.Context()), courses); err != nil {
+			SendErrorResponse(w, "Failed to retrieve courses", http.StatusInternalServerError,
+				"Error loading favorite courses", err)
+			return
+		}
+		courses = services.FilterFavoriteCourses(courses)
+	}
+
+	// the profile's own arrangement, if they made one
+	if userID := session.GetCurrentUser(r.Context()); userID != uuid.Nil {
+		if err := h.Service.ApplyCourseOrder(r.Context(), userID, courses); err != nil {
+			log.Printf("Warning: could not apply course order: %v", err)
+		}
+	}
 
-	SendSuccessResponse(w, "Courses retrieved successfully", courses,
+	if !h.attachProfileData(w, r, view, courses, favoritesOnly) {
+		return
+	}
+
+	data, err := view.projectAll(courses)
+	if err != nil {
+		SendErrorResponse(w, "Failed to retrieve courses", http.StatusInternalServerError,
+			"Error selecting course fields", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Courses retrieved successfully", data,
 		"Successfully retrieved and returned course list")
 }
 
+// Get handles GET /api/courses/{id}?fields=...&include=... - returns one course
+func (h *CourseHandler) Get(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Course details requested from IP: %s", r.RemoteAddr)
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in course request", nil)
+		return
+	}
+
+	courseID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid course ID format", http.StatusBadRequest,
+			"Invalid course UUID in course request", err)
+		return
+	}
+
+	view, err := parseCourseView(r)
+	if err != nil {
+		SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+			"Invalid field selection in course request", err)
+		return
+	}
+
+	// hidden courses look the same as missing ones
+	if userID := session.GetCurrentUser(r.Context()); userID != uuid.Nil {
+		visible, err := h.Visibility.CanSeeCourse(r.Context(), userID, courseID)
+		if err != nil {
+			SendErrorResponse(w, "Failed to retrieve course", http.StatusInternalServerError,
+				"Error checking course visibility", err)
+			return
+		}
+		if !visible {
+			SendErrorResponse(w, "Course not found", http.StatusNotFound,
+				"Course "+courseID.String()+" hidden from profile "+userID.String(), nil)
+			return
+		}
+	}
+
+	course, err := h.Service.GetCourseWithDepth(r.Context(), courseID, view.Depth)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			SendErrorResponse(w, "Course not found", http.StatusNotFound,
+				"Course requested that does not exist: "+courseID.String(), err)
+			return
+		}
+		SendErrorResponse(w, "Failed to retrieve course", http.StatusInternalServerError,
+			"Error retrieving course", err)
+		return
+	}
+
+	if !h.attachProfileData(w, r, view, []*models.Course{course}, false) {
+		return
+	}
+
+	data, err := view.project(course)
+	if err != nil {
+		SendErrorResponse(w, "Failed to retrieve course", http.StatusInternalServerError,
+			"Error selecting course fields", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Course retrieved successfully", data,
+		"Successfully retrieved course "+courseID.String())
+}
+
+// Update handles PATCH /api/courses/{id} - partial metadata update by the course creator or an admin
+func (h *CourseHandler) Update(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Course update requested from IP: %s", r.RemoteAddr)
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in course update request", nil)
+		return
+	}
+
+	courseID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid course ID format", http.StatusBadRequest,
+			"Invalid course UUID in update request", err)
+		return
+	}
+
+	var input models.UpdateCourseInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in course update request", err)
+		return
+	}
+
+	course, err := h.Service.GetCourseWithDepth(r.Context(), courseID, models.CourseDepthSummary)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			SendErrorResponse(w, "Course not found", http.StatusNotFound,
+				"Update attempted on non-existent course "+courseID.String(), err)
+			return
+		}
+		SendErrorResponse(w, "Failed to retrieve course", http.StatusInternalServerError,
+			"Error retrieving course for update", err)
+		return
+	}
+
+	// handing a course to someone else is an admin decision
+	if input.CreatorID != nil && *input.CreatorID != course.CreatorID {
+		if _, ok := requireAdmin(w, r, h.Profiles); !ok {
+			return
+		}
+	} else if !requireSelfOrAdmin(w, r, h.Profiles, course.CreatorID) {
+		return
+	}
+
+	updated, err := h.Service.UpdateCourseMetadata(r.Context(), courseID, input)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidCourseUpdate) {
+			SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+				"Invalid course update", err)
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			SendErrorResponse(w, "Course not found", http.StatusNotFound,
+				"Course "+courseID.String()+" disappeared during update", err)
+			return
+		}
+		SendErrorResponse(w, "Failed to update course", http.StatusInternalServerError,
+			"Error updating course", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Course updated successfully", updated,
+		"Course "+courseID.String()+" updated")
+}
+
+// Delete handles DELETE /api/courses/{id}?delete_files=true - only the course creator or an admin.
+// Modules, content items, progress and other per-course data go with it; files stay unless asked.
+func (h *CourseHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Course deletion requested from IP: %s", r.RemoteAddr)
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in course deletion request", nil)
+		return
+	}
+
+	courseID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid course ID format", http.StatusBadRequest,
+			"Invalid course UUID in deletion request", err)
+		return
+	}
+
+	course, err := h.Service.GetCourseWithDepth(r.Context(), courseID, models.CourseDepthSummary)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			SendErrorResponse(w, "Course not found", http.StatusNotFound,
+				"Deletion attempted on non-existent course "+courseID.String(), err)
+			return
+		}
+		SendErrorResponse(w, "Failed to retrieve course", http.StatusInternalServerError,
+			"Error retrieving course for deletion", err)
+		return
+	}
+
+	if !requireSelfOrAdmin(w, r, h.Profiles, course.CreatorID) {
+		return
+	}
+	actorID := session.GetCurrentUser(r.Context())
+
+	deleteFiles := r.URL.Query().Get("delete_files") == "true"
+	result, err := h.Service.DeleteCourseAs(r.Context(), courseID, actorID, deleteFiles)
+	if err != nil {
+		if errors.Is(err, services.ErrUnsafeCoursePath) {
+			SendErrorResponse(w, "Course folder can't be deleted: "+err.Error(), http.StatusBadRequest,
+				"Refused to delete files for course "+courseID.String(), err)
+			return
+		}
+		if errors.Is(err, services.ErrSharedCourseFolder) {
+			SendErrorResponse(w, "Course folder can't be deleted: "+err.Error(), http.StatusConflict,
+				"Refused to delete shared files for course "+courseID.String(), err)
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			SendErrorResponse(w, "Course not found", http.StatusNotFound,
+				"Course "+courseID.String()+" disappeared during deletion", err)
+			return
+		}
+		SendErrorResponse(w, "Failed to delete course", http.StatusInternalServerError,
+			"Error deleting course", err)
+		return
+	}
+
+	message := "Course deleted"
+	if result.FilesError != "" {
+		message = "Course deleted, but its files could not be removed"
+	}
+
+	SendSuccessResponse(w, message, result,
+		"Course "+courseID.String()+" deleted by "+actorID.String())
+}
+
+// BulkDelete handles POST /api/courses/bulk-delete - deletes several courses, reporting on each one
+func (h *CourseHandler) BulkDelete(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Bulk course deletion requested from IP: %s", r.RemoteAddr)
+
+	actorID := session.GetCurrentUser(r.Context())
+	if actorID == uuid.Nil {
+		SendErrorResponse(w, "You must be logged in to delete courses", http.StatusUnauthorized,
+			"Unauthorized bulk deletion attempt", nil)
+		return
+	}
+
+	var input models.BulkDeleteCoursesInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in bulk deletion request", err)
+		return
+	}
+
+	if len(input.CourseIDs) == 0 {
+		SendErrorResponse(w, "No courses provided for deletion", http.StatusBadRequest,
+			"Bulk deletion attempted with empty course list", nil)
+		return
+	}
+	if len(input.CourseIDs) > services.MaxBulkDeleteCourses {
+		SendErrorResponse(w, "At most "+strconv.Itoa(services.MaxBulkDeleteCourses)+" courses can be deleted at once",
+			http.StatusBadRequest, "Bulk deletion of "+strconv.Itoa(len(input.CourseIDs))+" courses refused", nil)
+		return
+	}
+
+	isAdmin, err := h.Profiles.IsAdmin(r.Context(), actorID)
+	if err != nil {
+		SendErrorResponse(w, "Failed to check permissions", http.StatusInternalServerError,
+			"Error checking admin rights for "+actorID.String(), err)
+		return
+	}
+
+	report := h.Service.BulkDeleteCourses(r.Context(), input, actorID, isAdmin)
+
+	message := "Courses deleted"
+	if report.FailureCount > 0 {
+		message = "Deleted " + strconv.Itoa(report.SuccessCount) + " courses, " +
+			strconv.Itoa(report.FailureCount) + " could not be deleted"
+	}
+
+	SendSuccessResponse(w, message, report,
+		"Bulk deletion by "+actorID.String()+": "+strconv.Itoa(report.SuccessCount)+" deleted, "+
+			strconv.Itoa(report.FailureCount)+" failed")
+}
+
+// attachProfileData adds the current profile's notes, favorites and progress the view asks for.
+// Writes the error response and returns false if the request can't be served.
+func (h *CourseHandler) attachProfileData(w http.ResponseWriter, r *http.Request, view *courseView,
+	courses []*models.Course, favoritesAttached bool) bool {
+	userID := session.GetCurrentUser(r.Context())
+
+	// prerequisites show without a profile too, locked needs one
+	if view.wants("prerequisites") || view.wants("locked") {
+		if err := h.Prerequisites.AttachPrerequisites(r.Context(), userID, courses); err != nil {
+			log.Printf("Warning: could not load course prerequisites: %v", err)
+		}
+	}
+
+	if userID == uuid.Nil {
+		if view.Progress {
+			SendErrorResponse(w, "You must select a profile to include progress", http.StatusUnauthorized,
+				"Course progress requested without a profile", nil)
+			return false
+		}
+		return true
+	}
+
+	if view.wants("note") {
+		if err := h.Notes.AttachCourseNotes(r.Context(), userID, courses); err != nil {
+			log.Printf("Warning: could not load course notes: %v", err)
+		}
+	}
+
+	if view.wants("is_favorite") && !favoritesAttached {
+		if err := h.Service.AttachFavorites(r.Context(), userID, courses); err != nil {
+			log.Printf("Warning: could not load course favorites: %v", err)
+		}
+	}
+
+	if view.Progress {
+		if err := h.Service.AttachProgress(r.Context(), userID, courses); err != nil {
+			SendErrorResponse(w, "Failed to calculate progress", http.StatusInternalServerError,
+				"Error attaching course progress", err)
+			return false
+		}
+	}
+
+	return true
+}
+
 // Create handles POST /api/courses - makes new course from directory
 func (h *CourseHandler) Create(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Course creation requested from IP: %s", r.RemoteAddr)
@@ -79,29 +507,66 @@ func (h *CourseHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// need user logged in to create courses
-	userID := session.GetCurrentUser()
+	userID := session.GetCurrentUser(r.Context())
 	if userID == uuid.Nil {
 		SendErrorResponse(w, "You must be logged in to create courses", http.StatusUnauthorized,
 			"Unauthorized course creation attempt", nil)
 		return
 	}
 
+	// admins can import on behalf of another profile
+	if !h.authorizeCreatorOverride(w, r, userID, input.CreatorID) {
+		return
+	}
+	ownerID := userID
+	if input.CreatorID != uuid.Nil {
+		ownerID = input.CreatorID
+	}
+
 	if input.BasePath == "" {
 		input.BasePath = util.GetCoursesDirectory()
 	}
 
+	if err := h.Service.ValidateEnrollProfiles(r.Context(), input.EnrollProfiles); err != nil {
+		SendErrorResponse(w, "Invalid enroll_profiles: "+err.Error(), http.StatusBadRequest,
+			"Course creation attempted with invalid profiles to enroll", err)
+		return
+	}
+
 	directoryPath := filepath.Join(input.BasePath, input.RelativePath)
 	log.Printf("Creating course from directory: %s for user: %s", directoryPath, userID.String())
 
 	// let service handle the actual import
-	course, err := h.Service.ImportCourse(r.Context(), directoryPath, userID)
+	course, err := h.Service.ImportCourseAs(r.Context(), directoryPath, ownerID, userID)
 	if err != nil {
+		var duplicate *services.DuplicateCourseError
+		if errors.As(err, &duplicate) {
+			SendErrorResponseWithData(w, "Course has already been imported", http.StatusConflict,
+				map[string]string{"existing_course_id": duplicate.ExistingID.String()},
+				"Duplicate course import attempted", err)
+			return
+		}
 		SendErrorResponse(w, "Failed to create course: "+err.Error(), http.StatusBadRequest,
 			"Error importing course from directory", err)
 		return
 	}
 
-	SendCreatedResponse(w, "Course created successfully", course,
+	if len(input.EnrollProfiles) > 0 {
+		enrolled, err := h.Service.EnrollProfiles(r.Context(), course.ID, input.EnrollProfiles)
+		if err != nil {
+			SendErrorResponse(w, "Course created but profile enrollment failed", http.StatusInternalServerError,
+				"Error enrolling profiles in course "+course.ID.String(), err)
+			return
+		}
+		course.EnrolledProfiles = enrolled
+	}
+
+	message := "Course created successfully"
+	if len(course.Warnings) > 0 {
+		message = "Course created with " + strconv.Itoa(len(course.Warnings)) + " warning(s)"
+	}
+
+	SendCreatedResponse(w, message, course,
 		"Course created successfully with ID: "+course.ID.String())
 }
 
@@ -132,9 +597,17 @@ func (h *CourseHandler) ScanNewCourses(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	recommended := 0
+	for _, directory := range newDirectories {
+		if directory.Recommended {
+			recommended++
+		}
+	}
+
 	// Create custom response with count
 	responseData := map[string]interface{}{
 		"count":       len(newDirectories),
+		"recommended": recommended,
 		"directories": newDirectories,
 	}
 
@@ -159,26 +632,42 @@ func (h *CourseHandler) BatchImport(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userID := session.GetCurrentUser()
+	userID := session.GetCurrentUser(r.Context())
 	if userID == uuid.Nil {
 		SendErrorResponse(w, "You must be logged in to import courses", http.StatusUnauthorized,
 			"Unauthorized batch import attempt", nil)
 		return
 	}
 
-	// create background task since this might take a while
-	taskID := task.CreateTask("batch_import")
-	log.Printf("Starting batch import task %s for %d courses", taskID, len(request.Courses))
+	for _, input := range request.Courses {
+		if !h.authorizeCreatorOverride(w, r, userID, input.CreatorID) {
+			return
+		}
+	}
 
-	// do the actual work in background
-	go func() {
-		task.UpdateTaskStatus(taskID, task.StatusProcessing)
-		task.SetTaskMessage(taskID, "Starting import of "+strconv.Itoa(len(request.Courses))+" courses")
+	// background task since this might take a while, the task workers keep several
+	// batches from hammering the disk at once
+	taskID := task.Submit("batch_import", batchImportInput{BatchImportRequest: request, ProfileID: userID})
+	log.Printf("Queued batch import task %s for %d courses", taskID, len(request.Courses))
 
-		// need new context since original request will be done
-		ctx := context.Background()
+	// return task ID so client can check progress
+	responseData := map[string]string{"task_id": taskID}
+	SendSuccessResponse(w, "Import started", responseData,
+		"Batch import task created with ID: "+taskID)
+}
+
+// batchImportInput is what a batch import task runs on
+type batchImportInput struct {
+	BatchImportRequest
+	ProfileID uuid.UUID `json:"profile_id"` // who the courses are imported for
+}
+
+// batchImportWork imports the courses of a batch import task, see BatchImport
+func (h *CourseHandler) batchImportWork(input batchImportInput) task.Work {
+	return func(ctx context.Context, taskID string) (interface{}, error) {
+		task.SetTaskMessage(taskID, "Starting import of "+strconv.Itoa(len(input.Courses))+" courses")
 
-		importedCourses, errs := h.Service.BatchImportCourses(ctx, request.Courses, userID)
+		importedCourses, errs := h.Service.BatchImportCourses(ctx, input.Courses, input.ProfileID)
 
 		response := BatchImportResponse{
 			SuccessCount:    len(importedCourses),
@@ -186,71 +675,63 @@ func (h *CourseHandler) BatchImport(w http.ResponseWriter, r *http.Request) {
 			ImportedCourses: importedCourses,
 		}
 
+		// the task log says which course went wrong and why, the errors alone don't say what worked
+		for _, course := range importedCourses {
+			response.WarningCount += len(course.Warnings)
+			task.Logf(taskID, "Imported %s (%s)", course.Title, course.ID)
+			for _, warning := range course.Warnings {
+				task.Warnf(taskID, "%s: %s", course.Title, warning.Message)
+			}
+		}
 		for _, err := range errs {
 			response.Errors = append(response.Errors, err.Error())
+			task.Errorf(taskID, "%v", err)
+		}
+
+		summary := "Imported " + strconv.Itoa(len(importedCourses)) + " of " + strconv.Itoa(len(input.Courses)) + " courses"
+		if err := h.Notifications.Notify(ctx, input.ProfileID, models.NotifyImports, "Batch import finished", summary); err != nil {
+			log.Printf("Warning: could not send batch import notification: %v", err)
 		}
 
 		// update task based on results
 		if len(errs) > 0 && len(importedCourses) == 0 {
-			task.SetTaskError(taskID, "Failed to import any courses")
-			task.CompleteTask(taskID, response)
 			log.Printf("Batch import %s failed completely", taskID)
+			return response, errors.New("failed to import any courses")
 		} else if len(errs) > 0 {
 			task.SetTaskMessage(taskID, "Imported "+strconv.Itoa(len(importedCourses))+" courses with "+strconv.Itoa(len(errs))+" errors")
-			task.CompleteTask(taskID, response)
 			log.Printf("Batch import %s completed with partial success", taskID)
 		} else {
 			task.SetTaskMessage(taskID, "Successfully imported "+strconv.Itoa(len(importedCourses))+" courses")
-			task.CompleteTask(taskID, response)
 			log.Printf("Batch import %s completed successfully", taskID)
 		}
-	}()
-
-	// return task ID so client can check progress
-	responseData := map[string]string{"task_id": taskID}
-	SendSuccessResponse(w, "Import started", responseData,
-		"Batch import task created with ID: "+taskID)
+		return response, nil
+	}
 }
 
-// GetCourseProgress handles GET /api/courses/{id}/progress?user_id={uuid} - shows course progress for user
+// GetCourseProgress handles GET /api/courses/{id}/progress?user_id={uuid}&weighting=items|duration - shows course progress for user,
+// defaulting to the current profile
 func (h *CourseHandler) GetCourseProgress(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Course progress requested from IP: %s", r.RemoteAddr)
 
-	// extract course ID from URL path
-	pathParts := strings.Split(r.URL.Path, "/")
-	if len(pathParts) < 4 {
-		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
-			"Invalid URL path in course progress request", nil)
-		return
-	}
-
-	courseIDStr := pathParts[3]
-	courseID, err := uuid.Parse(courseIDStr)
-	if err != nil {
-		SendErrorResponse(w, "Invalid course ID format", http.StatusBadRequest,
-			"Invalid course UUID in progress request", err)
-		return
-	}
-
-	// get user ID from query params
-	userIDStr := r.URL.Query().Get("user_id")
-	if userIDStr == "" {
-		SendErrorResponse(w, "user_id query parameter is required", http.StatusBadRequest,
-			"Missing user_id parameter in progress request", nil)
+	courseID, userID, ok := h.bulkProgressTarget(w, r, "course", "progress")
+	if !ok {
 		return
 	}
 
-	userID, err := uuid.Parse(userIDStr)
-	if err != nil {
-		SendErrorResponse(w, "Invalid user ID format", http.StatusBadRequest,
-			"Invalid user UUID in progress request", err)
+	// ?weighting overrides the deployment default for this request
+	weighting := r.URL.Query().Get("weighting")
+	if weighting == "" {
+		weighting = h.Service.Weighting
+	} else if !services.ValidWeighting(weighting) {
+		SendErrorResponse(w, "weighting must be items or duration", http.StatusBadRequest,
+			"Invalid progress weighting: "+weighting, nil)
 		return
 	}
 
 	log.Printf("Calculating course progress for course %s and user %s", courseID.String(), userID.String())
 
 	// calculate course progress
-	progress, err := h.Service.CalculateCourseProgress(r.Context(), userID, courseID)
+	progress, err := h.Service.CalculateCourseProgressWeighted(r.Context(), userID, courseID, weighting)
 	if err != nil {
 		SendErrorResponse(w, "Failed to calculate progress", http.StatusInternalServerError,
 			"Error calculating course progress", err)
@@ -261,45 +742,30 @@ func (h *CourseHandler) GetCourseProgress(w http.ResponseWriter, r *http.Request
 		"Course progress calculated and returned")
 }
 
-// GetModuleProgress handles GET /api/modules/{id}/progress?user_id={uuid} - shows module progress for user
+// GetModuleProgress handles GET /api/modules/{id}/progress?user_id={uuid}&weighting=items|duration - shows module progress for user,
+// defaulting to the current profile
 func (h *CourseHandler) GetModuleProgress(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Module progress requested from IP: %s", r.RemoteAddr)
 
-	// extract module ID from URL path
-	pathParts := strings.Split(r.URL.Path, "/")
-	if len(pathParts) < 4 {
-		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
-			"Invalid URL path in module progress request", nil)
+	moduleID, userID, ok := h.bulkProgressTarget(w, r, "module", "progress")
+	if !ok {
 		return
 	}
 
-	moduleIDStr := pathParts[3]
-	moduleID, err := uuid.Parse(moduleIDStr)
-	if err != nil {
-		SendErrorResponse(w, "Invalid module ID format", http.StatusBadRequest,
-			"Invalid module UUID in progress request", err)
-		return
-	}
-
-	// get user ID from query params
-	userIDStr := r.URL.Query().Get("user_id")
-	if userIDStr == "" {
-		SendErrorResponse(w, "user_id query parameter is required", http.StatusBadRequest,
-			"Missing user_id parameter in progress request", nil)
-		return
-	}
-
-	userID, err := uuid.Parse(userIDStr)
-	if err != nil {
-		SendErrorResponse(w, "Invalid user ID format", http.StatusBadRequest,
-			"Invalid user UUID in progress request", err)
+	// ?weighting overrides the deployment default for this request
+	weighting := r.URL.Query().Get("weighting")
+	if weighting == "" {
+		weighting = h.Service.Weighting
+	} else if !services.ValidWeighting(weighting) {
+		SendErrorResponse(w, "weighting must be items or duration", http.StatusBadRequest,
+			"Invalid progress weighting: "+weighting, nil)
 		return
 	}
 
 	log.Printf("Calculating module progress for module %s and user %s", moduleID.String(), userID.String())
 
 	// calculate module progress
-	progress, err := h.Service.CalculateModuleProgress(r.Context(), userID, moduleID)
+	progress, err := h.Service.CalculateModuleProgressWeighted(r.Context(), userID, moduleID, weighting)
 	if err != nil {
 		SendErrorResponse(w, "Failed to calculate progress", http.StatusInternalServerError,
 			"Error calculating module progress", err)
@@ -332,10 +798,11 @@ func (h *CourseHandler) UpdateContentProgress(w http.ResponseWriter, r *http.Req
 
 	// parse request body
 	type progressUpdate struct {
-		UserID       uuid.UUID `json:"user_id"`
+		UserID       uuid.UUID `json:"user_id,omitempty"` // defaults to the current profile
 		ProgressPct  float32   `json:"progress_pct"`
 		LastPosition int       `json:"last_position,omitempty"`
 		Completed    bool      `json:"completed,omitempty"`
+		Force        bool      `json:"force,omitempty"` // allow lowering progress, e.g. to watch again from the start
 	}
 
 	var update progressUpdate
@@ -345,25 +812,30 @@ func (h *CourseHandler) UpdateContentProgress(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	// validate required fields
-	if update.UserID == uuid.Nil {
-		SendErrorResponse(w, "User ID is required", http.StatusBadRequest,
-			"Progress update attempted with missing user ID", nil)
+	userID, ok := h.progressProfile(w, r, update.UserID)
+	if !ok {
 		return
 	}
+	update.UserID = userID
 
 	log.Printf("Updating content progress for content %s, user %s, progress %.1f%%",
 		contentID.String(), update.UserID.String(), update.ProgressPct)
 
+	// a progress update means the item is being watched - bring it back from cold storage if needed.
+	// A failed restore shouldn't lose the progress, the file just stays unavailable for now.
+	if err := h.Tiering.RecordAccess(r.Context(), contentID); err != nil {
+		log.Printf("Error recording access to content %s: %v", contentID.String(), err)
+	}
+
 	// update progress
-	err = h.Service.UpdateContentItemProgress(r.Context(), update.UserID, contentID, update.ProgressPct, update.LastPosition)
+	progress, err := h.Service.UpdateContentItemProgress(r.Context(), update.UserID, contentID, update.ProgressPct, update.LastPosition, update.Force)
 	if err != nil {
 		SendErrorResponse(w, "Failed to update progress", http.StatusInternalServerError,
 			"Error updating content progress", err)
 		return
 	}
 
-	SendSuccessResponse(w, "Progress updated successfully", nil,
+	SendSuccessResponse(w, "Progress updated successfully", h.withXP(r, progress),
 		"Content progress updated successfully")
 }
 
@@ -389,7 +861,7 @@ func (h *CourseHandler) MarkContentCompleted(w http.ResponseWriter, r *http.Requ
 
 	// parse request body
 	type completeRequest struct {
-		UserID uuid.UUID `json:"user_id"`
+		UserID uuid.UUID `json:"user_id,omitempty"` // defaults to the current profile
 	}
 
 	var req completeRequest
@@ -399,27 +871,55 @@ func (h *CourseHandler) MarkContentCompleted(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	// validate required fields
-	if req.UserID == uuid.Nil {
-		SendErrorResponse(w, "User ID is required", http.StatusBadRequest,
-			"Content completion attempted with missing user ID", nil)
+	userID, ok := h.progressProfile(w, r, req.UserID)
+	if !ok {
 		return
 	}
+	req.UserID = userID
 
 	log.Printf("Marking content %s as completed for user %s", contentID.String(), req.UserID.String())
 
 	// mark as completed
-	err = h.Service.MarkContentItemCompleted(r.Context(), req.UserID, contentID)
+	progress, err := h.Service.MarkContentItemCompleted(r.Context(), req.UserID, contentID)
 	if err != nil {
 		SendErrorResponse(w, "Failed to mark as completed", http.StatusInternalServerError,
 			"Error marking content as completed", err)
 		return
 	}
 
-	SendSuccessResponse(w, "Content marked as completed", nil,
+	SendSuccessResponse(w, "Content marked as completed", h.withXP(r, progress),
 		"Content successfully marked as completed")
 }
 
+// progressProfile picks the profile a progress write is for: the one in the body, or the current
+// profile when the body leaves it out. Writing another profile's progress takes an admin.
+func (h *CourseHandler) progressProfile(w http.ResponseWriter, r *http.Request, userID uuid.UUID) (uuid.UUID, bool) {
+	if userID == uuid.Nil {
+		userID = session.GetCurrentUser(r.Context())
+	}
+	if !requireSelfOrAdmin(w, r, h.Profiles, userID) {
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// withXP pairs an updated progress record with the XP it earned, if it completed the item.
+// The progress is saved either way, so a failed award is only logged.
+func (h *CourseHandler) withXP(r *http.Request, progress *models.UserProgress) *models.ProgressUpdateResult {
+	result := &models.ProgressUpdateResult{Progress: progress}
+	if !progress.Completed {
+		return result
+	}
+
+	award, err := h.Gamification.AwardCompletion(r.Context(), progress.UserID, progress.ContentItemID)
+	if err != nil {
+		log.Printf("Error awarding XP for content %s to user %s: %v", progress.ContentItemID, progress.UserID, err)
+		return result
+	}
+	result.XP = award
+	return result
+}
+
 // GetUserProgressSummary handles GET /api/users/{id}/progress - shows overall progress summary
 func (h *CourseHandler) GetUserProgressSummary(w http.ResponseWriter, r *http.Request) {
 	log.Printf("User progress summary requested from IP: %s", r.RemoteAddr)
@@ -439,6 +939,9 @@ func (h *CourseHandler) GetUserProgressSummary(w http.ResponseWriter, r *http.Re
 			"Invalid user UUID in progress summary request", err)
 		return
 	}
+	if !requireSelfOrAdmin(w, r, h.Profiles, userID) {
+		return
+	}
 
 	log.Printf("Getting progress summary for user %s", userID.String())
 
@@ -453,3 +956,209 @@ func (h *CourseHandler) GetUserProgressSummary(w http.ResponseWriter, r *http.Re
 	SendSuccessResponse(w, "Progress summary retrieved", summary,
 		"User progress summary retrieved and returned")
 }
+
+// GetCapabilityWarnings handles GET /api/courses/{id}/capabilities - optional tools the course needs
+func (h *CourseHandler) GetCapabilityWarnings(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Course capability warnings requested from IP: %s", r.RemoteAddr)
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in capability request", nil)
+		return
+	}
+
+	courseID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid course ID format", http.StatusBadRequest,
+			"Invalid course UUID in capability request", err)
+		return
+	}
+
+	warnings, err := h.Service.GetCapabilityWarnings(r.Context(), courseID)
+	if err != nil {
+		SendErrorResponse(w, "Failed to retrieve capability warnings", http.StatusInternalServerError,
+			"Error retrieving capability warnings", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Capability warnings retrieved", warnings,
+		"Returned "+strconv.Itoa(len(warnings))+" capability warnings for course "+courseID.String())
+}
+
+// Clone handles POST /api/courses/{id}/clone - copies the course structure, not the files.
+// The body is optional: {"title": "...", "module_ids": [...]} keeps only those modules, in that order.
+func (h *CourseHandler) Clone(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Course clone requested from IP: %s", r.RemoteAddr)
+
+	actorID := session.GetCurrentUser(r.Context())
+	if actorID == uuid.Nil {
+		SendErrorResponse(w, "You must be logged in", http.StatusUnauthorized,
+			"Unauthorized course clone attempt", nil)
+		return
+	}
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in course clone request", nil)
+		return
+	}
+
+	courseID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid course ID format", http.StatusBadRequest,
+			"Invalid course UUID in clone request", err)
+		return
+	}
+
+	var input models.CloneCourseInput
+	if r.ContentLength != 0 {
+		if err := ValidateJSONBody(r, &input); err != nil {
+			SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+				"Invalid JSON in course clone request", err)
+			return
+		}
+	}
+
+	// can't clone what you can't see
+	visible, err := h.Visibility.CanSeeCourse(r.Context(), actorID, courseID)
+	if err != nil {
+		SendErrorResponse(w, "Failed to clone course", http.StatusInternalServerError,
+			"Error checking course visibility", err)
+		return
+	}
+	if !visible {
+		SendErrorResponse(w, "Course not found", http.StatusNotFound,
+			"Clone of course "+courseID.String()+" hidden from profile "+actorID.String(), nil)
+		return
+	}
+
+	clone, err := h.Service.CloneCourse(r.Context(), courseID, actorID, input)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidClone) {
+			SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+				"Invalid clone options for course "+courseID.String(), err)
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			SendErrorResponse(w, "Course not found", http.StatusNotFound,
+				"Clone attempted of non-existent course "+courseID.String(), err)
+			return
+		}
+		SendErrorResponse(w, "Failed to clone course", http.StatusInternalServerError,
+			"Error cloning course", err)
+		return
+	}
+
+	SendCreatedResponse(w, "Course cloned", clone,
+		"Course "+courseID.String()+" cloned as "+clone.ID.String()+" by "+actorID.String())
+}
+
+// SetOrder handles PUT /api/courses/order - the current profile's arrangement of the course list
+func (h *CourseHandler) SetOrder(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Course order update requested from IP: %s", r.RemoteAddr)
+
+	userID := session.GetCurrentUser(r.Context())
+	if userID == uuid.Nil {
+		SendErrorResponse(w, "You must select a profile to arrange courses", http.StatusUnauthorized,
+			"Course order update without a profile", nil)
+		return
+	}
+
+	var input models.SetCourseOrderInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in course order update", err)
+		return
+	}
+
+	courseIDs, err := h.Service.SetCourseOrder(r.Context(), userID, input.CourseIDs)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidCourseOrder) {
+			SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+				"Invalid course order from profile "+userID.String(), err)
+			return
+		}
+		SendErrorResponse(w, "Failed to save course order", http.StatusInternalServerError,
+			"Error saving course order", err)
+		return
+	}
+
+	responseData := map[string][]uuid.UUID{"course_ids": courseIDs}
+	SendSuccessResponse(w, "Course order saved", responseData,
+		"Course order saved for profile "+userID.String())
+}
+
+// Favorite handles POST /api/courses/{id}/favorite - pins a course for the current profile
+func (h *CourseHandler) Favorite(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Course favorite requested from IP: %s", r.RemoteAddr)
+
+	userID, courseID, ok := favoriteRequestContext(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.Service.FavoriteCourse(r.Context(), userID, courseID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			SendErrorResponse(w, "Course not found", http.StatusNotFound,
+				"Favorite attempted on non-existent course "+courseID.String(), err)
+			return
+		}
+		SendErrorResponse(w, "Failed to favorite course", http.StatusInternalServerError,
+			"Error favoriting course", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Course added to favorites", map[string]interface{}{
+		"course_id":   courseID,
+		"is_favorite": true,
+	}, "Course "+courseID.String()+" favorited by "+userID.String())
+}
+
+// Unfavorite handles DELETE /api/courses/{id}/favorite - unpins a course for the current profile
+func (h *CourseHandler) Unfavorite(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Course unfavorite requested from IP: %s", r.RemoteAddr)
+
+	userID, courseID, ok := favoriteRequestContext(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.Service.UnfavoriteCourse(r.Context(), userID, courseID); err != nil {
+		SendErrorResponse(w, "Failed to unfavorite course", http.StatusInternalServerError,
+			"Error unfavoriting course", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Course removed from favorites", map[string]interface{}{
+		"course_id":   courseID,
+		"is_favorite": false,
+	}, "Course "+courseID.String()+" unfavorited by "+userID.String())
+}
+
+// favoriteRequestContext gets the session user and course ID for favorite requests
+func favoriteRequestContext(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	userID := session.GetCurrentUser(r.Context())
+	if userID == uuid.Nil {
+		SendErrorResponse(w, "You must select a profile to manage favorites", http.StatusUnauthorized,
+			"Favorite request without a profile", nil)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in favorite request", nil)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	courseID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid course ID format", http.StatusBadRequest,
+			"Invalid course UUID in favorite request", err)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return userID, courseID, true
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/google/uuid"
+)
+
+// EmailHandler processes contact email requests
+type EmailHandler struct {
+	Service  *services.EmailService   // addresses and their verification
+	Profiles *services.ProfileService // needed for admin checks
+}
+
+// NewEmailHandler creates handler with injected services
+func NewEmailHandler(service *services.EmailService, profiles *services.ProfileService) *EmailHandler {
+	return &EmailHandler{Service: service, Profiles: profiles}
+}
+
+// Get handles GET /api/profiles/{id}/email - the profile's contact address (self or admin)
+func (h *EmailHandler) Get(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Profile email requested from IP: %s", r.RemoteAddr)
+
+	profileID, ok := h.emailProfile(w, r)
+	if !ok {
+		return
+	}
+
+	email, err := h.Service.GetEmail(r.Context(), profileID)
+	if err != nil {
+		sendEmailError(w, err, profileID)
+		return
+	}
+
+	SendSuccessResponse(w, "Email retrieved", email,
+		"Returned email of profile "+profileID.String())
+}
+
+// Set handles PUT /api/profiles/{id}/email - changes the profile's contact address and
+// sends a verification link to it (self or admin). An empty email removes it.
+func (h *EmailHandler) Set(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Profile email change requested from IP: %s", r.RemoteAddr)
+
+	profileID, ok := h.emailProfile(w, r)
+	if !ok {
+		return
+	}
+
+	var input models.SetEmailInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in profile email change", err)
+		return
+	}
+
+	email, err := h.Service.SetEmail(r.Context(), profileID, input.Email)
+	if err != nil {
+		sendEmailError(w, err, profileID)
+		return
+	}
+
+	SendSuccessResponse(w, "Email updated", email,
+		"Email of profile "+profileID.String()+" changed")
+}
+
+// Resend handles POST /api/profiles/{id}/email/resend - sends a new verification link
+// for the profile's unverified address (self or admin)
+func (h *EmailHandler) Resend(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Email verification resend requested from IP: %s", r.RemoteAddr)
+
+	profileID, ok := h.emailProfile(w, r)
+	if !ok {
+		return
+	}
+
+	email, err := h.Service.ResendVerification(r.Context(), profileID)
+	if err != nil {
+		sendEmailError(w, err, profileID)
+		return
+	}
+
+	SendSuccessResponse(w, "Verification email sent", email,
+		"Verification email resent for profile "+profileID.String())
+}
+
+// Verify handles POST /api/email/verify - confirms an address with the token emailed to
+// it. It's public: the link may well be opened on a device nobody is logged in on.
+func (h *EmailHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Email verification requested from IP: %s", r.RemoteAddr)
+
+	var input models.VerifyEmailInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in email verification", err)
+		return
+	}
+	token := strings.TrimSpace(input.Token)
+	if token == "" {
+		SendErrorResponse(w, "token is required", http.StatusBadRequest,
+			"Email verification without a token", nil)
+		return
+	}
+
+	email, err := h.Service.VerifyEmail(r.Context(), token)
+	if err != nil {
+		sendEmailError(w, err, uuid.Nil)
+		return
+	}
+
+	SendSuccessResponse(w, "Email verified", email,
+		"Email of profile "+email.ProfileID.String()+" verified")
+}
+
+// emailProfile pulls the profile out of the path and checks the caller may manage its email
+func (h *EmailHandler) emailProfile(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	profileID, ok := profileIDFromPath(w, r)
+	if !ok {
+		return uuid.Nil, false
+	}
+	if !requireSelfOrAdmin(w, r, h.Profiles, profileID) {
+		return uuid.Nil, false
+	}
+	return profileID, true
+}
+
+// sendEmailError maps contact email errors to responses
+func sendEmailError(w http.ResponseWriter, err error, profileID uuid.UUID) {
+	switch {
+	case errors.Is(err, services.ErrInvalidEmail):
+		SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+			"Invalid email for profile "+profileID.String(), err)
+	case errors.Is(err, services.ErrNoEmail), errors.Is(err, services.ErrEmailVerified):
+		SendErrorResponse(w, err.Error(), http.StatusConflict,
+			"Nothing to verify for profile "+profileID.String(), err)
+	case errors.Is(err, services.ErrVerificationTooSoon):
+		SendErrorResponse(w, err.Error(), http.StatusTooManyRequests,
+			"Verification email resent too soon for profile "+profileID.String(), err)
+	case errors.Is(err, services.ErrInvalidVerification):
+		SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+			"Unknown or expired email verification token", nil)
+	case errors.Is(err, services.ErrVerificationNotSent):
+		SendErrorResponse(w, "The address was saved but the verification email could not be sent, try resending it later",
+			http.StatusBadGateway, "Error emailing verification for profile "+profileID.String(), err)
+	case errors.Is(err, sql.ErrNoRows):
+		SendErrorResponse(w, "Profile not found", http.StatusNotFound,
+			"Email request for missing profile "+profileID.String(), err)
+	default:
+		SendErrorResponse(w, "Failed to process email", http.StatusInternalServerError,
+			"Error handling email of "+profileID.String(), err)
+	}
+}
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+)
+
+// courseFieldNames are the top-level JSON fields a client may ask for with ?fields=
+var courseFieldNames = jsonFieldNames(reflect.TypeOf(models.Course{}))
+
+// courseView is the slice of a course a client asked for with ?fields= and ?include=
+type courseView struct {
+	Fields   map[string]bool    // top-level fields to return, nil means everything
+	Depth    models.CourseDepth // how much of the module tree to load
+	Progress bool               // attach the current profile's progress
+}
+
+// parseCourseView reads ?fields=id,title,progress and ?include=modules,items,progress.
+// Without either parameter the full course tree is returned, same as before.
+func parseCourseView(r *http.Request) (*courseView, error) {
+	query := r.URL.Query()
+	view := &courseView{Depth: models.CourseDepthFull}
+
+	_, hasInclude := query["include"]
+	if hasInclude {
+		view.Depth = models.CourseDepthSummary
+		for _, name := range splitList(query.Get("include")) {
+			switch name {
+			case "modules":
+				if view.Depth < models.CourseDepthModules {
+					view.Depth = models.CourseDepthModules
+				}
+			case "items":
+				view.Depth = models.CourseDepthFull
+			case "progress":
+				view.Progress = true
+			default:
+				return nil, fmt.Errorf("unknown include %q (valid: modules, items, progress)", name)
+			}
+		}
+	}
+
+	if _, hasFields := query["fields"]; hasFields {
+		view.Fields = map[string]bool{"id": true} // always identify the course
+		for _, name := range splitList(query.Get("fields")) {
+			if !courseFieldNames[name] {
+				return nil, fmt.Errorf("unknown field %q (valid: %s)", name, strings.Join(sortedKeys(courseFieldNames), ", "))
+			}
+			view.Fields[name] = true
+		}
+
+		// fields alone decide what gets loaded when include isn't given
+		if !hasInclude {
+			view.Depth = models.CourseDepthSummary
+			if view.Fields["modules"] {
+				view.Depth = models.CourseDepthFull
+			}
+			view.Progress = view.Fields["progress"]
+		}
+
+		// anything explicitly included is returned too
+		if view.Depth > models.CourseDepthSummary {
+			view.Fields["modules"] = true
+		}
+		if view.Progress {
+			view.Fields["progress"] = true
+		}
+	}
+
+	return view, nil
+}
+
+// wants reports whether a top-level field will be returned
+func (v *courseView) wants(field string) bool {
+	return v.Fields == nil || v.Fields[field]
+}
+
+// project trims a course down to the requested fields
+func (v *courseView) project(course *models.Course) (interface{}, error) {
+	if v.Fields == nil {
+		return course, nil
+	}
+
+	data, err := json.Marshal(course)
+	if err != nil {
+		return nil, err
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+
+	for field := range all {
+		if !v.Fields[field] {
+			delete(all, field)
+		}
+	}
+	return all, nil
+}
+
+// projectAll trims every course down to the requested fields
+func (v *courseView) projectAll(courses []*models.Course) (interface{}, error) {
+	if v.Fields == nil {
+		return courses, nil
+	}
+
+	projected := make([]interface{}, 0, len(courses))
+	for _, course := range courses {
+		trimmed, err := v.project(course)
+		if err != nil {
+			return nil, err
+		}
+		projected = append(projected, trimmed)
+	}
+	return projected, nil
+}
+
+// splitList splits a comma separated query value, ignoring blanks
+func splitList(value string) []string {
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// jsonFieldNames collects the JSON names of a struct's exported fields
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		tag := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if tag != "" && tag != "-" {
+			names[tag] = true
+		}
+	}
+	return names
+}
+
+// sortedKeys lists map keys alphabetically, for stable error messages
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
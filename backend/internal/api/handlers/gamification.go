@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/google/uuid"
+)
+
+// GamificationHandler serves the history of profiles' XP, gems and streaks, and the rules behind them
+type GamificationHandler struct {
+	Service  *services.GamificationService // reads the gamification ledger
+	Profiles *services.ProfileService      // admin checks
+}
+
+// NewGamificationHandler creates handler with injected services
+func NewGamificationHandler(service *services.GamificationService, profiles *services.ProfileService) *GamificationHandler {
+	return &GamificationHandler{Service: service, Profiles: profiles}
+}
+
+// History handles GET /api/users/{id}/gamification/history?stat=&limit=50&offset=0 - every
+// change to a profile's XP, gems, streaks and streak freezes with why it happened, newest
+// first. stat narrows it down to one of them. Profiles see their own history, admins everyone's.
+func (h *GamificationHandler) History(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Gamification history requested from IP: %s", r.RemoteAddr)
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in gamification history request", nil)
+		return
+	}
+
+	profileID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid user ID format", http.StatusBadRequest,
+			"Invalid user UUID in gamification history request", err)
+		return
+	}
+
+	if !requireSelfOrAdmin(w, r, h.Profiles, profileID) {
+		return
+	}
+
+	limit, offset, ok := pageParams(w, r, "gamification history")
+	if !ok {
+		return
+	}
+
+	history, err := h.Service.GetHistory(r.Context(), profileID, r.URL.Query().Get("stat"), limit, offset)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidHistoryStat) {
+			SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+				"Invalid stat in gamification history request", nil)
+			return
+		}
+		SendErrorResponse(w, "Failed to retrieve gamification history", http.StatusInternalServerError,
+			"Error retrieving gamification history of profile "+profileID.String(), err)
+		return
+	}
+
+	SendSuccessResponse(w, "Gamification history retrieved", history,
+		"Listed "+strconv.Itoa(len(history.Entries))+" gamification changes for profile "+profileID.String())
+}
+
+// Rules handles GET /api/admin/gamification/rules - the XP, gem and streak freeze amounts
+// in effect, from GAMIFICATION_RULES_FILE or the defaults. Admin only.
+func (h *GamificationHandler) Rules(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Gamification rules requested from IP: %s", r.RemoteAddr)
+
+	if _, ok := requireAdmin(w, r, h.Profiles); !ok {
+		return
+	}
+
+	SendSuccessResponse(w, "Gamification rules retrieved", h.Service.Rules,
+		"Gamification rules returned")
+}
+
+// pageParams reads limit and offset for a page of the gamification ledger, writing the
+// error response itself when they're invalid
+func pageParams(w http.ResponseWriter, r *http.Request, request string) (limit, offset int, ok bool) {
+	query := r.URL.Query()
+	limit = services.DefaultHistoryLimit
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 || parsed > services.MaxHistoryLimit {
+			SendErrorResponse(w, "limit must be between 1 and "+strconv.Itoa(services.MaxHistoryLimit), http.StatusBadRequest,
+				"Invalid limit in "+request+" request: "+limitStr, err)
+			return 0, 0, false
+		}
+		limit = parsed
+	}
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		parsed, err := strconv.Atoi(offsetStr)
+		if err != nil || parsed < 0 {
+			SendErrorResponse(w, "offset must be zero or more", http.StatusBadRequest,
+				"Invalid offset in "+request+" request: "+offsetStr, err)
+			return 0, 0, false
+		}
+		offset = parsed
+	}
+	return limit, offset, true
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/google/uuid"
+)
+
+// GemHandler serves profiles' gem balances, the gem shop and the gem ledger
+type GemHandler struct {
+	Service  *services.GemService     // gem economy
+	Profiles *services.ProfileService // admin checks
+}
+
+// NewGemHandler creates handler with injected services
+func NewGemHandler(service *services.GemService, profiles *services.ProfileService) *GemHandler {
+	return &GemHandler{Service: service, Profiles: profiles}
+}
+
+// Wallet handles GET /api/users/{id}/gems - the balance and what it can buy
+func (h *GemHandler) Wallet(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Gem wallet requested from IP: %s", r.RemoteAddr)
+
+	profileID, ok := h.gemProfile(w, r)
+	if !ok {
+		return
+	}
+
+	wallet, err := h.Service.GetWallet(r.Context(), profileID)
+	if err != nil {
+		sendGemError(w, err, profileID)
+		return
+	}
+
+	SendSuccessResponse(w, "Gems retrieved", wallet,
+		"Gem wallet returned for profile "+profileID.String())
+}
+
+// Redeem handles POST /api/users/{id}/gems/redeem - spends gems on a streak freeze or a theme
+func (h *GemHandler) Redeem(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Gem redemption requested from IP: %s", r.RemoteAddr)
+
+	profileID, ok := h.gemProfile(w, r)
+	if !ok {
+		return
+	}
+
+	var input models.RedeemGemsInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in gem redemption request", err)
+		return
+	}
+
+	wallet, err := h.Service.Redeem(r.Context(), profileID, input.Item)
+	if err != nil {
+		sendGemError(w, err, profileID)
+		return
+	}
+
+	SendSuccessResponse(w, "Item redeemed", wallet,
+		"Profile "+profileID.String()+" redeemed "+input.Item)
+}
+
+// Transactions handles GET /api/users/{id}/gems/transactions?limit=50&offset=0 - every gem
+// earned or spent, newest first
+func (h *GemHandler) Transactions(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Gem transactions requested from IP: %s", r.RemoteAddr)
+
+	profileID, ok := h.gemProfile(w, r)
+	if !ok {
+		return
+	}
+
+	limit, offset, ok := pageParams(w, r, "gem transactions")
+	if !ok {
+		return
+	}
+
+	page, err := h.Service.ListTransactions(r.Context(), profileID, limit, offset)
+	if err != nil {
+		sendGemError(w, err, profileID)
+		return
+	}
+
+	SendSuccessResponse(w, "Gem transactions retrieved", page,
+		"Listed "+strconv.Itoa(len(page.Entries))+" gem transactions for profile "+profileID.String())
+}
+
+// gemProfile pulls the profile out of the path. Profiles see their own gems, admins everyone's.
+func (h *GemHandler) gemProfile(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in gem request", nil)
+		return uuid.Nil, false
+	}
+
+	profileID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid user ID format", http.StatusBadRequest,
+			"Invalid user UUID in gem request", err)
+		return uuid.Nil, false
+	}
+
+	if !requireSelfOrAdmin(w, r, h.Profiles, profileID) {
+		return uuid.Nil, false
+	}
+	return profileID, true
+}
+
+// sendGemError maps gem errors to responses
+func sendGemError(w http.ResponseWriter, err error, id uuid.UUID) {
+	switch {
+	case errors.Is(err, services.ErrUnknownGemItem):
+		SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+			"Unknown gem item requested for "+id.String(), nil)
+	case errors.Is(err, services.ErrNotEnoughGems), errors.Is(err, services.ErrTooManyStreakFreezes),
+		errors.Is(err, services.ErrAlreadyOwned):
+		SendErrorResponse(w, err.Error(), http.StatusConflict,
+			"Gem redemption refused for "+id.String(), nil)
+	default:
+		SendErrorResponse(w, "Failed to process gems", http.StatusInternalServerError,
+			"Error handling gems for "+id.String(), err)
+	}
+}
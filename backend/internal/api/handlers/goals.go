@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/google/uuid"
+)
+
+// GoalHandler processes learning goal HTTP requests
+type GoalHandler struct {
+	Service  *services.GoalService    // goal business logic
+	Profiles *services.ProfileService // admin checks
+}
+
+// NewGoalHandler creates handler with injected services
+func NewGoalHandler(service *services.GoalService, profiles *services.ProfileService) *GoalHandler {
+	return &GoalHandler{Service: service, Profiles: profiles}
+}
+
+// List handles GET /api/users/{id}/goals
+func (h *GoalHandler) List(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Learning goals requested from IP: %s", r.RemoteAddr)
+
+	profileID, ok := h.goalProfile(w, r)
+	if !ok {
+		return
+	}
+
+	goals, err := h.Service.ListGoals(r.Context(), profileID)
+	if err != nil {
+		sendGoalError(w, err, profileID)
+		return
+	}
+
+	SendSuccessResponse(w, "Goals retrieved", goals,
+		"Goals returned for profile "+profileID.String())
+}
+
+// Create handles POST /api/users/{id}/goals - sets a daily_minutes, daily_items or course_completion goal
+func (h *GoalHandler) Create(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Learning goal creation requested from IP: %s", r.RemoteAddr)
+
+	profileID, ok := h.goalProfile(w, r)
+	if !ok {
+		return
+	}
+
+	var input models.CreateGoalInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in goal request", err)
+		return
+	}
+
+	goal, err := h.Service.CreateGoal(r.Context(), profileID, input)
+	if err != nil {
+		sendGoalError(w, err, profileID)
+		return
+	}
+
+	SendCreatedResponse(w, "Goal created", goal,
+		"Goal "+goal.ID.String()+" created for profile "+profileID.String())
+}
+
+// Get handles GET /api/users/{id}/goals/{goal} - a goal with its recent daily verdicts
+func (h *GoalHandler) Get(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Learning goal requested from IP: %s", r.RemoteAddr)
+
+	profileID, goalID, ok := h.goalTarget(w, r)
+	if !ok {
+		return
+	}
+
+	goal, err := h.Service.GetGoal(r.Context(), profileID, goalID)
+	if err != nil {
+		sendGoalError(w, err, goalID)
+		return
+	}
+
+	SendSuccessResponse(w, "Goal retrieved", goal,
+		"Goal "+goalID.String()+" returned")
+}
+
+// Update handles PUT /api/users/{id}/goals/{goal} - changes the daily target or due date
+func (h *GoalHandler) Update(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Learning goal update requested from IP: %s", r.RemoteAddr)
+
+	profileID, goalID, ok := h.goalTarget(w, r)
+	if !ok {
+		return
+	}
+
+	var input models.UpdateGoalInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in goal request", err)
+		return
+	}
+
+	goal, err := h.Service.UpdateGoal(r.Context(), profileID, goalID, input)
+	if err != nil {
+		sendGoalError(w, err, goalID)
+		return
+	}
+
+	SendSuccessResponse(w, "Goal saved", goal,
+		"Goal "+goalID.String()+" updated")
+}
+
+// Delete handles DELETE /api/users/{id}/goals/{goal}
+func (h *GoalHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Learning goal deletion requested from IP: %s", r.RemoteAddr)
+
+	profileID, goalID, ok := h.goalTarget(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.Service.DeleteGoal(r.Context(), profileID, goalID); err != nil {
+		sendGoalError(w, err, goalID)
+		return
+	}
+
+	SendSuccessResponse(w, "Goal deleted", nil,
+		"Goal "+goalID.String()+" deleted")
+}
+
+// GetStreakFreezes handles GET /api/users/{id}/streak-freezes - gems and freezes held
+func (h *GoalHandler) GetStreakFreezes(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Streak freezes requested from IP: %s", r.RemoteAddr)
+
+	profileID, ok := h.goalProfile(w, r)
+	if !ok {
+		return
+	}
+
+	freezes, err := h.Service.GetStreakFreezes(r.Context(), profileID)
+	if err != nil {
+		sendGoalError(w, err, profileID)
+		return
+	}
+
+	SendSuccessResponse(w, "Streak freezes retrieved", freezes,
+		"Streak freezes returned for profile "+profileID.String())
+}
+
+// BuyStreakFreeze handles POST /api/users/{id}/streak-freezes - spends gems on a freeze
+func (h *GoalHandler) BuyStreakFreeze(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Streak freeze purchase requested from IP: %s", r.RemoteAddr)
+
+	profileID, ok := h.goalProfile(w, r)
+	if !ok {
+		return
+	}
+
+	freezes, err := h.Service.BuyStreakFreeze(r.Context(), profileID)
+	if err != nil {
+		sendGoalError(w, err, profileID)
+		return
+	}
+
+	SendCreatedResponse(w, "Streak freeze bought", freezes,
+		"Streak freeze bought for profile "+profileID.String())
+}
+
+// RepairStreak handles POST /api/users/{id}/streak/repair - spends gems to buy back the
+// days that broke the streak, while they're within the grace window
+func (h *GoalHandler) RepairStreak(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Streak repair requested from IP: %s", r.RemoteAddr)
+
+	profileID, ok := h.goalProfile(w, r)
+	if !ok {
+		return
+	}
+
+	repair, err := h.Service.RepairStreak(r.Context(), profileID)
+	if err != nil {
+		sendGoalError(w, err, profileID)
+		return
+	}
+
+	SendSuccessResponse(w, "Streak repaired", repair,
+		"Streak of profile "+profileID.String()+" repaired over "+strconv.Itoa(len(repair.RepairedDays))+" days")
+}
+
+// goalProfile pulls the profile out of the path. Profiles manage their own goals, admins everyone's.
+func (h *GoalHandler) goalProfile(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in goal request", nil)
+		return uuid.Nil, false
+	}
+
+	profileID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid user ID format", http.StatusBadRequest,
+			"Invalid user UUID in goal request", err)
+		return uuid.Nil, false
+	}
+
+	if !requireSelfOrAdmin(w, r, h.Profiles, profileID) {
+		return uuid.Nil, false
+	}
+	return profileID, true
+}
+
+// goalTarget pulls the profile and goal out of the path
+func (h *GoalHandler) goalTarget(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	profileID, ok := h.goalProfile(w, r)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	goalID, err := uuid.Parse(r.PathValue("goal"))
+	if err != nil {
+		SendErrorResponse(w, "Invalid goal ID format", http.StatusBadRequest,
+			"Invalid goal UUID in goal request", err)
+		return uuid.Nil, uuid.Nil, false
+	}
+	return profileID, goalID, true
+}
+
+// sendGoalError maps goal errors to responses
+func sendGoalError(w http.ResponseWriter, err error, id uuid.UUID) {
+	switch {
+	case errors.Is(err, services.ErrInvalidGoal):
+		SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+			"Invalid goal request for "+id.String(), err)
+	case errors.Is(err, services.ErrGoalNotFound):
+		SendErrorResponse(w, err.Error(), http.StatusNotFound,
+			"Missing goal "+id.String()+" requested", nil)
+	case errors.Is(err, services.ErrNotEnoughGems), errors.Is(err, services.ErrTooManyStreakFreezes):
+		SendErrorResponse(w, err.Error(), http.StatusConflict,
+			"Gem purchase refused for "+id.String(), nil)
+	case errors.Is(err, services.ErrNoStreakToRepair), errors.Is(err, services.ErrRepairWindowPassed):
+		SendErrorResponse(w, err.Error(), http.StatusConflict,
+			"Streak repair refused for "+id.String(), nil)
+	default:
+		SendErrorResponse(w, "Failed to process goal", http.StatusInternalServerError,
+			"Error handling goal for "+id.String(), err)
+	}
+}
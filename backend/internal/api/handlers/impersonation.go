@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/NeroQue/course-management-backend/pkg/session"
+	"github.com/google/uuid"
+)
+
+// ImpersonationHandler lets admins see the app as another profile does
+type ImpersonationHandler struct {
+	Service  *services.ImpersonationService // starting, stopping and auditing
+	Profiles *services.ProfileService       // for admin checks
+}
+
+// NewImpersonationHandler creates handler with injected services
+func NewImpersonationHandler(service *services.ImpersonationService, profiles *services.ProfileService) *ImpersonationHandler {
+	return &ImpersonationHandler{Service: service, Profiles: profiles}
+}
+
+// Start handles POST /api/admin/impersonation - act as {"profile_id", "minutes"} until the time runs out.
+// Until then every request is made as that profile, including the admin checks.
+func (h *ImpersonationHandler) Start(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Impersonation start requested from IP: %s", r.RemoteAddr)
+
+	adminID, ok := requireAdmin(w, r, h.Profiles)
+	if !ok {
+		return
+	}
+
+	var input models.StartImpersonationInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in impersonation request", err)
+		return
+	}
+
+	status, err := h.Service.Start(r.Context(), adminID, input)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidImpersonation) {
+			SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+				"Invalid impersonation request by "+adminID.String(), err)
+			return
+		}
+		SendErrorResponse(w, "Failed to start impersonation", http.StatusInternalServerError,
+			"Error starting impersonation", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Now acting as profile "+status.ProfileID.String(), status,
+		"Admin "+adminID.String()+" impersonating "+status.ProfileID.String())
+}
+
+// Stop handles DELETE /api/impersonation - goes back to being the admin.
+// Open to the impersonated profile's session, since that's who the admin is while it runs.
+func (h *ImpersonationHandler) Stop(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Impersonation stop requested from IP: %s", r.RemoteAddr)
+
+	if session.GetRealUser(r.Context()) == uuid.Nil {
+		SendErrorResponse(w, "You must be logged in", http.StatusUnauthorized,
+			"Unauthorized impersonation stop attempt", nil)
+		return
+	}
+
+	status, err := h.Service.Stop(r.Context())
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidImpersonation) {
+			SendErrorResponse(w, err.Error(), http.StatusConflict,
+				"Impersonation stop without impersonation", err)
+			return
+		}
+		SendErrorResponse(w, "Failed to stop impersonation", http.StatusInternalServerError,
+			"Error stopping impersonation", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Impersonation ended", status,
+		"Impersonation ended for "+session.GetRealUser(r.Context()).String())
+}
+
+// Status handles GET /api/impersonation - whether the session is acting as someone else
+func (h *ImpersonationHandler) Status(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Impersonation status requested from IP: %s", r.RemoteAddr)
+
+	SendSuccessResponse(w, "Impersonation status retrieved", h.Service.Status(r.Context()),
+		"Impersonation status returned")
+}
@@ -0,0 +1,222 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/google/uuid"
+)
+
+// InstructorHandler processes instructor requests
+type InstructorHandler struct {
+	Service  *services.InstructorService // instructor logic
+	Profiles *services.ProfileService    // needed for admin checks
+}
+
+// NewInstructorHandler creates handler with injected services
+func NewInstructorHandler(service *services.InstructorService, profiles *services.ProfileService) *InstructorHandler {
+	return &InstructorHandler{Service: service, Profiles: profiles}
+}
+
+// List handles GET /api/instructors - every instructor with their course count
+func (h *InstructorHandler) List(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Instructor list requested from IP: %s", r.RemoteAddr)
+
+	instructors, err := h.Service.ListInstructors(r.Context())
+	if err != nil {
+		SendErrorResponse(w, "Failed to retrieve instructors", http.StatusInternalServerError,
+			"Error retrieving instructors", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Instructors retrieved", instructors,
+		"Returned "+strconv.Itoa(len(instructors))+" instructors")
+}
+
+// Get handles GET /api/instructors/{id} - the instructor and the courses they teach
+func (h *InstructorHandler) Get(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Instructor requested from IP: %s", r.RemoteAddr)
+
+	instructorID, ok := instructorIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	instructor, err := h.Service.GetInstructor(r.Context(), instructorID)
+	if err != nil {
+		sendInstructorError(w, instructorID, err)
+		return
+	}
+
+	SendSuccessResponse(w, "Instructor retrieved", instructor,
+		"Instructor "+instructorID.String()+" returned")
+}
+
+// Create handles POST /api/instructors - admin only
+func (h *InstructorHandler) Create(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Instructor creation requested from IP: %s", r.RemoteAddr)
+
+	if _, ok := requireAdmin(w, r, h.Profiles); !ok {
+		return
+	}
+
+	var input models.CreateInstructorInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in instructor creation", err)
+		return
+	}
+
+	instructor, err := h.Service.CreateInstructor(r.Context(), input)
+	if err != nil {
+		sendInstructorError(w, uuid.Nil, err)
+		return
+	}
+
+	SendCreatedResponse(w, "Instructor created", instructor,
+		"Instructor "+instructor.ID.String()+" created")
+}
+
+// Update handles PATCH /api/instructors/{id} - admin only
+func (h *InstructorHandler) Update(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Instructor update requested from IP: %s", r.RemoteAddr)
+
+	instructorID, ok := instructorIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	if _, ok := requireAdmin(w, r, h.Profiles); !ok {
+		return
+	}
+
+	var input models.UpdateInstructorInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in instructor update", err)
+		return
+	}
+
+	instructor, err := h.Service.UpdateInstructor(r.Context(), instructorID, input)
+	if err != nil {
+		sendInstructorError(w, instructorID, err)
+		return
+	}
+
+	SendSuccessResponse(w, "Instructor updated", instructor,
+		"Instructor "+instructorID.String()+" updated")
+}
+
+// Delete handles DELETE /api/instructors/{id} - admin only, their courses are kept
+func (h *InstructorHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Instructor deletion requested from IP: %s", r.RemoteAddr)
+
+	instructorID, ok := instructorIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	if _, ok := requireAdmin(w, r, h.Profiles); !ok {
+		return
+	}
+
+	if err := h.Service.DeleteInstructor(r.Context(), instructorID); err != nil {
+		sendInstructorError(w, instructorID, err)
+		return
+	}
+
+	SendSuccessResponse(w, "Instructor deleted", nil,
+		"Instructor "+instructorID.String()+" deleted")
+}
+
+// SetCourseInstructors handles PUT /api/courses/{id}/instructors - course creator or admin
+func (h *InstructorHandler) SetCourseInstructors(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Course instructors update requested from IP: %s", r.RemoteAddr)
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in course instructors request", nil)
+		return
+	}
+
+	courseID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid course ID format", http.StatusBadRequest,
+			"Invalid course UUID in course instructors request", err)
+		return
+	}
+
+	var input models.SetCourseInstructorsInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in course instructors update", err)
+		return
+	}
+
+	creatorID, err := h.Service.CourseCreator(r.Context(), courseID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			SendErrorResponse(w, "Course not found", http.StatusNotFound,
+				"Instructors set on non-existent course "+courseID.String(), err)
+			return
+		}
+		SendErrorResponse(w, "Failed to retrieve course", http.StatusInternalServerError,
+			"Error retrieving course for instructors update", err)
+		return
+	}
+	if !requireSelfOrAdmin(w, r, h.Profiles, creatorID) {
+		return
+	}
+
+	instructors, err := h.Service.SetCourseInstructors(r.Context(), courseID, input.InstructorIDs)
+	if err != nil {
+		sendInstructorError(w, uuid.Nil, err)
+		return
+	}
+
+	SendSuccessResponse(w, "Course instructors updated", instructors,
+		"Course "+courseID.String()+" now has "+strconv.Itoa(len(instructors))+" instructors")
+}
+
+// instructorIDFromPath pulls the instructor ID out of /api/instructors/{id}
+func instructorIDFromPath(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in instructor request", nil)
+		return uuid.Nil, false
+	}
+
+	instructorID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid instructor ID format", http.StatusBadRequest,
+			"Invalid instructor UUID", err)
+		return uuid.Nil, false
+	}
+	return instructorID, true
+}
+
+// sendInstructorError maps instructor errors to responses
+func sendInstructorError(w http.ResponseWriter, instructorID uuid.UUID, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidInstructor):
+		SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+			"Invalid instructor input", err)
+	case errors.Is(err, services.ErrDuplicateInstructor):
+		SendErrorResponse(w, err.Error(), http.StatusConflict,
+			"Duplicate instructor name", err)
+	case errors.Is(err, sql.ErrNoRows):
+		SendErrorResponse(w, "Instructor not found", http.StatusNotFound,
+			"Request for non-existent instructor "+instructorID.String(), err)
+	default:
+		SendErrorResponse(w, "Failed to process instructor", http.StatusInternalServerError,
+			"Error processing instructor", err)
+	}
+}
@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/NeroQue/course-management-backend/pkg/session"
+	"github.com/google/uuid"
+)
+
+// LearningPathHandler processes learning path requests
+type LearningPathHandler struct {
+	Service  *services.LearningPathService // learning path logic
+	Profiles *services.ProfileService      // needed for admin checks
+}
+
+// NewLearningPathHandler creates handler with injected services
+func NewLearningPathHandler(service *services.LearningPathService, profiles *services.ProfileService) *LearningPathHandler {
+	return &LearningPathHandler{Service: service, Profiles: profiles}
+}
+
+// List handles GET /api/learning-paths - every path with its courses
+func (h *LearningPathHandler) List(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Learning path list requested from IP: %s", r.RemoteAddr)
+
+	paths, err := h.Service.ListLearningPaths(r.Context())
+	if err != nil {
+		SendErrorResponse(w, "Failed to retrieve learning paths", http.StatusInternalServerError,
+			"Error retrieving learning paths", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Learning paths retrieved", paths,
+		"Returned "+strconv.Itoa(len(paths))+" learning paths")
+}
+
+// Get handles GET /api/learning-paths/{id}
+func (h *LearningPathHandler) Get(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Learning path requested from IP: %s", r.RemoteAddr)
+
+	pathID, ok := learningPathIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	path, err := h.Service.GetLearningPath(r.Context(), pathID)
+	if err != nil {
+		sendLearningPathError(w, pathID, err)
+		return
+	}
+
+	SendSuccessResponse(w, "Learning path retrieved", path,
+		"Learning path "+pathID.String()+" returned")
+}
+
+// Create handles POST /api/learning-paths - owned by the current profile
+func (h *LearningPathHandler) Create(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Learning path creation requested from IP: %s", r.RemoteAddr)
+
+	creatorID := session.GetCurrentUser(r.Context())
+	if creatorID == uuid.Nil {
+		SendErrorResponse(w, "You must be logged in", http.StatusUnauthorized,
+			"Unauthorized learning path creation attempt", nil)
+		return
+	}
+
+	var input models.CreateLearningPathInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in learning path creation", err)
+		return
+	}
+
+	path, err := h.Service.CreateLearningPath(r.Context(), creatorID, input)
+	if err != nil {
+		sendLearningPathError(w, uuid.Nil, err)
+		return
+	}
+
+	SendCreatedResponse(w, "Learning path created", path,
+		"Learning path "+path.ID.String()+" created by "+creatorID.String())
+}
+
+// Update handles PATCH /api/learning-paths/{id} - path creator or admin
+func (h *LearningPathHandler) Update(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Learning path update requested from IP: %s", r.RemoteAddr)
+
+	pathID, ok := learningPathIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var input models.UpdateLearningPathInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in learning path update", err)
+		return
+	}
+
+	if !h.requireOwner(w, r, pathID) {
+		return
+	}
+
+	path, err := h.Service.UpdateLearningPath(r.Context(), pathID, input)
+	if err != nil {
+		sendLearningPathError(w, pathID, err)
+		return
+	}
+
+	SendSuccessResponse(w, "Learning path updated", path,
+		"Learning path "+pathID.String()+" updated")
+}
+
+// Delete handles DELETE /api/learning-paths/{id} - path creator or admin, courses are kept
+func (h *LearningPathHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Learning path deletion requested from IP: %s", r.RemoteAddr)
+
+	pathID, ok := learningPathIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	if !h.requireOwner(w, r, pathID) {
+		return
+	}
+
+	if err := h.Service.DeleteLearningPath(r.Context(), pathID); err != nil {
+		sendLearningPathError(w, pathID, err)
+		return
+	}
+
+	SendSuccessResponse(w, "Learning path deleted", nil,
+		"Learning path "+pathID.String()+" deleted")
+}
+
+// GetProgress handles GET /api/learning-paths/{id}/progress?user_id={uuid} - ?user_id, else the current profile
+func (h *LearningPathHandler) GetProgress(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Learning path progress requested from IP: %s", r.RemoteAddr)
+
+	pathID, ok := learningPathIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	userID := session.GetCurrentUser(r.Context())
+	if userIDStr := r.URL.Query().Get("user_id"); userIDStr != "" {
+		var err error
+		userID, err = uuid.Parse(userIDStr)
+		if err != nil {
+			SendErrorResponse(w, "Invalid user ID format", http.StatusBadRequest,
+				"Invalid user UUID in learning path progress request", err)
+			return
+		}
+	}
+	if userID == uuid.Nil {
+		SendErrorResponse(w, "user_id query parameter is required without a selected profile", http.StatusBadRequest,
+			"Missing user_id in learning path progress request", nil)
+		return
+	}
+
+	progress, err := h.Service.GetProgress(r.Context(), pathID, userID)
+	if err != nil {
+		sendLearningPathError(w, pathID, err)
+		return
+	}
+
+	SendSuccessResponse(w, "Learning path progress calculated", progress,
+		"Learning path progress calculated for path "+pathID.String()+" and user "+userID.String())
+}
+
+// requireOwner makes sure the current profile created the path or is an admin.
+// Writes the error response itself.
+func (h *LearningPathHandler) requireOwner(w http.ResponseWriter, r *http.Request, pathID uuid.UUID) bool {
+	path, err := h.Service.GetLearningPath(r.Context(), pathID)
+	if err != nil {
+		sendLearningPathError(w, pathID, err)
+		return false
+	}
+	return requireSelfOrAdmin(w, r, h.Profiles, path.CreatorID)
+}
+
+// learningPathIDFromPath pulls the path ID out of /api/learning-paths/{id}/...
+func learningPathIDFromPath(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in learning path request", nil)
+		return uuid.Nil, false
+	}
+
+	pathID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid learning path ID format", http.StatusBadRequest,
+			"Invalid learning path UUID", err)
+		return uuid.Nil, false
+	}
+	return pathID, true
+}
+
+// sendLearningPathError maps learning path errors to responses
+func sendLearningPathError(w http.ResponseWriter, pathID uuid.UUID, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidLearningPath):
+		SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+			"Invalid learning path input", err)
+	case errors.Is(err, sql.ErrNoRows):
+		SendErrorResponse(w, "Learning path not found", http.StatusNotFound,
+			"Request for non-existent learning path "+pathID.String(), err)
+	default:
+		SendErrorResponse(w, "Failed to process learning path", http.StatusInternalServerError,
+			"Error processing learning path", err)
+	}
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/NeroQue/course-management-backend/pkg/maintenance"
+)
+
+// MaintenanceHandler reports and flips maintenance mode
+type MaintenanceHandler struct {
+	Profiles *services.ProfileService // for admin checks
+}
+
+// NewMaintenanceHandler creates handler with injected services
+func NewMaintenanceHandler(profiles *services.ProfileService) *MaintenanceHandler {
+	return &MaintenanceHandler{Profiles: profiles}
+}
+
+// Get handles GET /api/maintenance - whether changes are currently refused, and why
+func (h *MaintenanceHandler) Get(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Maintenance status requested from IP: %s", r.RemoteAddr)
+
+	SendSuccessResponse(w, "Maintenance status retrieved", maintenance.Current(),
+		"Maintenance status returned")
+}
+
+// Set handles PUT /api/admin/maintenance - {"enabled": true, "reason": "..."}.
+// Only flips the admin switch; restores and migrations that hold maintenance keep it on.
+func (h *MaintenanceHandler) Set(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Maintenance switch requested from IP: %s", r.RemoteAddr)
+
+	adminID, ok := requireAdmin(w, r, h.Profiles)
+	if !ok {
+		return
+	}
+
+	var input models.SetMaintenanceInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in maintenance request", err)
+		return
+	}
+
+	maintenance.SetManual(input.Enabled, input.Reason)
+
+	state := maintenance.Current()
+	message := "Maintenance mode is off"
+	if state.Active {
+		message = "Maintenance mode is on"
+	}
+	SendSuccessResponse(w, message, state,
+		"Maintenance switch set by "+adminID.String())
+}
@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/NeroQue/course-management-backend/pkg/session"
+	"github.com/google/uuid"
+)
+
+// ModuleHandler processes module editing HTTP requests
+type ModuleHandler struct {
+	Service *services.ModuleService // module business logic
+}
+
+// NewModuleHandler creates handler with injected module service
+func NewModuleHandler(service *services.ModuleService) *ModuleHandler {
+	return &ModuleHandler{Service: service}
+}
+
+// Update handles PATCH /api/modules/{id} - edits title and/or description
+func (h *ModuleHandler) Update(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Module update requested from IP: %s", r.RemoteAddr)
+
+	if session.GetCurrentUser(r.Context()) == uuid.Nil {
+		SendErrorResponse(w, "You must be logged in to edit modules", http.StatusUnauthorized,
+			"Unauthorized module update attempt", nil)
+		return
+	}
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in module update request", nil)
+		return
+	}
+
+	moduleID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid module ID format", http.StatusBadRequest,
+			"Invalid module UUID in update request", err)
+		return
+	}
+
+	var input models.UpdateModuleInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in module update request", err)
+		return
+	}
+
+	if input.Title == nil && input.Description == nil {
+		SendErrorResponse(w, "Nothing to update - provide title or description", http.StatusBadRequest,
+			"Empty module update request", nil)
+		return
+	}
+
+	module, err := h.Service.UpdateModule(r.Context(), moduleID, input)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			SendErrorResponse(w, "Module not found", http.StatusNotFound,
+				"Update attempted on non-existent module "+moduleID.String(), err)
+			return
+		}
+		SendErrorResponse(w, "Failed to update module: "+err.Error(), http.StatusBadRequest,
+			"Error updating module", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Module updated successfully", module,
+		"Module "+moduleID.String()+" updated")
+}
+
+// Reorder handles POST /api/courses/{id}/modules/reorder - sets the order of all modules at once
+func (h *ModuleHandler) Reorder(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Module reorder requested from IP: %s", r.RemoteAddr)
+
+	if session.GetCurrentUser(r.Context()) == uuid.Nil {
+		SendErrorResponse(w, "You must be logged in to reorder modules", http.StatusUnauthorized,
+			"Unauthorized module reorder attempt", nil)
+		return
+	}
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in module reorder request", nil)
+		return
+	}
+
+	courseID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid course ID format", http.StatusBadRequest,
+			"Invalid course UUID in module reorder request", err)
+		return
+	}
+
+	var input models.ReorderModulesInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in module reorder request", err)
+		return
+	}
+
+	modules, err := h.Service.ReorderModules(r.Context(), courseID, input.ModuleIDs)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			SendErrorResponse(w, "Course not found", http.StatusNotFound,
+				"Module reorder attempted on non-existent course "+courseID.String(), err)
+			return
+		}
+		if errors.Is(err, services.ErrInvalidModuleOrder) {
+			SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+				"Invalid module order for course "+courseID.String(), err)
+			return
+		}
+		SendErrorResponse(w, "Failed to reorder modules", http.StatusInternalServerError,
+			"Error reordering modules", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Modules reordered successfully", modules,
+		"Modules reordered for course "+courseID.String())
+}
+
+// Merge handles POST /api/courses/{id}/modules/merge - folds several modules into the first listed
+func (h *ModuleHandler) Merge(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Module merge requested from IP: %s", r.RemoteAddr)
+
+	if session.GetCurrentUser(r.Context()) == uuid.Nil {
+		SendErrorResponse(w, "You must be logged in to merge modules", http.StatusUnauthorized,
+			"Unauthorized module merge attempt", nil)
+		return
+	}
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in module merge request", nil)
+		return
+	}
+
+	courseID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid course ID format", http.StatusBadRequest,
+			"Invalid course UUID in module merge request", err)
+		return
+	}
+
+	var input models.MergeModulesInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in module merge request", err)
+		return
+	}
+
+	module, err := h.Service.MergeModules(r.Context(), courseID, input)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			SendErrorResponse(w, "Course not found", http.StatusNotFound,
+				"Module merge attempted on non-existent course "+courseID.String(), err)
+			return
+		}
+		if errors.Is(err, services.ErrInvalidModuleOrder) {
+			SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+				"Invalid module merge for course "+courseID.String(), err)
+			return
+		}
+		SendErrorResponse(w, "Failed to merge modules", http.StatusInternalServerError,
+			"Error merging modules", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Modules merged successfully", module,
+		"Merged "+strconv.Itoa(len(input.ModuleIDs))+" modules into "+module.ID.String())
+}
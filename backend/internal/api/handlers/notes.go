@@ -0,0 +1,260 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/NeroQue/course-management-backend/pkg/session"
+	"github.com/google/uuid"
+)
+
+// NoteHandler processes note-related HTTP requests
+type NoteHandler struct {
+	Service *services.NoteService // note business logic
+	Courses *services.CourseService
+}
+
+// NewNoteHandler creates handler with injected services
+func NewNoteHandler(service *services.NoteService, courses *services.CourseService) *NoteHandler {
+	return &NoteHandler{Service: service, Courses: courses}
+}
+
+// GetCourseNote handles GET /api/courses/{id}/notes - returns the current profile's note
+func (h *NoteHandler) GetCourseNote(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Course note requested from IP: %s", r.RemoteAddr)
+
+	userID, courseID, ok := h.noteRequestContext(w, r, "course")
+	if !ok {
+		return
+	}
+
+	note, err := h.Service.GetCourseNote(r.Context(), userID, courseID)
+	if err != nil {
+		SendErrorResponse(w, "Failed to retrieve course note", http.StatusInternalServerError,
+			"Error retrieving course note", err)
+		return
+	}
+
+	if note == nil {
+		SendErrorResponse(w, "No note for this course", http.StatusNotFound,
+			"No course note for course "+courseID.String(), nil)
+		return
+	}
+
+	SendSuccessResponse(w, "Course note retrieved", note,
+		"Course note returned for course "+courseID.String())
+}
+
+// SaveCourseNote handles PUT /api/courses/{id}/notes - creates or replaces the note
+func (h *NoteHandler) SaveCourseNote(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Course note save requested from IP: %s", r.RemoteAddr)
+
+	userID, courseID, ok := h.noteRequestContext(w, r, "course")
+	if !ok {
+		return
+	}
+
+	var input models.SaveCourseNoteInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in course note request", err)
+		return
+	}
+
+	// make sure course actually exists
+	if _, err := h.Courses.GetCourse(r.Context(), courseID); err != nil {
+		SendErrorResponse(w, "Course not found", http.StatusNotFound,
+			"Attempted to save note for non-existent course", err)
+		return
+	}
+
+	note, err := h.Service.SaveCourseNote(r.Context(), userID, courseID, input.Content)
+	if err != nil {
+		SendErrorResponse(w, "Failed to save course note: "+err.Error(), http.StatusBadRequest,
+			"Error saving course note", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Course note saved", note,
+		"Course note saved for course "+courseID.String())
+}
+
+// DeleteCourseNote handles DELETE /api/courses/{id}/notes - removes the note
+func (h *NoteHandler) DeleteCourseNote(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Course note deletion requested from IP: %s", r.RemoteAddr)
+
+	userID, courseID, ok := h.noteRequestContext(w, r, "course")
+	if !ok {
+		return
+	}
+
+	if err := h.Service.DeleteCourseNote(r.Context(), userID, courseID); err != nil {
+		SendErrorResponse(w, "Failed to delete course note", http.StatusInternalServerError,
+			"Error deleting course note", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Course note deleted", nil,
+		"Course note deleted for course "+courseID.String())
+}
+
+// noteRequestContext pulls the logged in user and the ID in the path out of the request,
+// kind names what the ID is for error messages
+func (h *NoteHandler) noteRequestContext(w http.ResponseWriter, r *http.Request, kind string) (uuid.UUID, uuid.UUID, bool) {
+	userID := session.GetCurrentUser(r.Context())
+	if userID == uuid.Nil {
+		SendErrorResponse(w, "You must be logged in to use notes", http.StatusUnauthorized,
+			"Unauthorized "+kind+" note request", nil)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in "+kind+" note request", nil)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid "+kind+" ID format", http.StatusBadRequest,
+			"Invalid "+kind+" UUID in note request", err)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return userID, id, true
+}
+
+// CreateContentNote handles POST /api/content/{id}/notes - adds a note to a content item,
+// optionally pinned to a timestamp in seconds
+func (h *NoteHandler) CreateContentNote(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Content note creation requested from IP: %s", r.RemoteAddr)
+
+	userID, itemID, ok := h.noteRequestContext(w, r, "content")
+	if !ok {
+		return
+	}
+
+	var input models.SaveContentNoteInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in content note request", err)
+		return
+	}
+
+	note, err := h.Service.CreateContentNote(r.Context(), userID, itemID, input)
+	if err != nil {
+		sendContentNoteError(w, err, itemID, userID)
+		return
+	}
+
+	SendCreatedResponse(w, "Note created", note,
+		"Note "+note.ID.String()+" created on content "+itemID.String())
+}
+
+// ListContentNotes handles GET /api/content/{id}/notes - the current profile's notes on an item
+func (h *NoteHandler) ListContentNotes(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Content notes requested from IP: %s", r.RemoteAddr)
+
+	userID, itemID, ok := h.noteRequestContext(w, r, "content")
+	if !ok {
+		return
+	}
+
+	notes, err := h.Service.ListContentNotes(r.Context(), userID, itemID)
+	if err != nil {
+		sendContentNoteError(w, err, itemID, userID)
+		return
+	}
+
+	SendSuccessResponse(w, "Notes retrieved", notes,
+		"Notes returned for content "+itemID.String())
+}
+
+// ListCourseContentNotes handles GET /api/courses/{id}/content-notes - every note the
+// current profile took in a course, in course order, for review
+func (h *NoteHandler) ListCourseContentNotes(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Course content notes requested from IP: %s", r.RemoteAddr)
+
+	userID, courseID, ok := h.noteRequestContext(w, r, "course")
+	if !ok {
+		return
+	}
+
+	notes, err := h.Service.ListCourseContentNotes(r.Context(), userID, courseID)
+	if err != nil {
+		sendContentNoteError(w, err, courseID, userID)
+		return
+	}
+
+	SendSuccessResponse(w, "Notes retrieved", notes,
+		"Content notes returned for course "+courseID.String())
+}
+
+// UpdateContentNote handles PUT /api/content-notes/{id} - replaces a note's text and timestamp
+func (h *NoteHandler) UpdateContentNote(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Content note update requested from IP: %s", r.RemoteAddr)
+
+	userID, noteID, ok := h.noteRequestContext(w, r, "note")
+	if !ok {
+		return
+	}
+
+	var input models.SaveContentNoteInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in content note request", err)
+		return
+	}
+
+	note, err := h.Service.UpdateContentNote(r.Context(), userID, noteID, input)
+	if err != nil {
+		sendContentNoteError(w, err, noteID, userID)
+		return
+	}
+
+	SendSuccessResponse(w, "Note saved", note,
+		"Note "+noteID.String()+" updated")
+}
+
+// DeleteContentNote handles DELETE /api/content-notes/{id}
+func (h *NoteHandler) DeleteContentNote(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Content note deletion requested from IP: %s", r.RemoteAddr)
+
+	userID, noteID, ok := h.noteRequestContext(w, r, "note")
+	if !ok {
+		return
+	}
+
+	if err := h.Service.DeleteContentNote(r.Context(), userID, noteID); err != nil {
+		sendContentNoteError(w, err, noteID, userID)
+		return
+	}
+
+	SendSuccessResponse(w, "Note deleted", nil,
+		"Note "+noteID.String()+" deleted")
+}
+
+// sendContentNoteError maps content note errors to responses, id is whatever the path named
+func sendContentNoteError(w http.ResponseWriter, err error, id, userID uuid.UUID) {
+	switch {
+	case errors.Is(err, services.ErrInvalidNote):
+		SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+			"Invalid note for "+id.String(), err)
+	case errors.Is(err, services.ErrNoteNotFound), errors.Is(err, sql.ErrNoRows):
+		SendErrorResponse(w, err.Error(), http.StatusNotFound,
+			"Missing "+id.String()+" in note request", err)
+	case errors.Is(err, services.ErrContentNotVisible):
+		// hidden courses look the same as missing ones
+		SendErrorResponse(w, "Not found", http.StatusNotFound,
+			id.String()+" hidden from profile "+userID.String(), nil)
+	default:
+		SendErrorResponse(w, "Failed to process note", http.StatusInternalServerError,
+			"Error handling note for "+id.String(), err)
+	}
+}
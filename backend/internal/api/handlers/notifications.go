@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/internal/services"
+)
+
+// NotificationHandler processes notification preference and inbox requests
+type NotificationHandler struct {
+	Service  *services.NotificationService // notification logic
+	Profiles *services.ProfileService      // needed for permission checks
+}
+
+// NewNotificationHandler creates handler with injected services
+func NewNotificationHandler(service *services.NotificationService, profiles *services.ProfileService) *NotificationHandler {
+	return &NotificationHandler{Service: service, Profiles: profiles}
+}
+
+// GetPreferences handles GET /api/profiles/{id}/notification-preferences
+func (h *NotificationHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Notification preferences requested from IP: %s", r.RemoteAddr)
+
+	profileID, ok := profileIDFromPath(w, r)
+	if !ok || !requireSelfOrAdmin(w, r, h.Profiles, profileID) {
+		return
+	}
+
+	preferences, err := h.Service.GetPreferences(r.Context(), profileID)
+	if err != nil {
+		SendErrorResponse(w, "Failed to retrieve notification preferences", http.StatusInternalServerError,
+			"Error retrieving notification preferences", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Notification preferences retrieved", preferences,
+		"Notification preferences returned for profile "+profileID.String())
+}
+
+// SetPreferences handles PUT /api/profiles/{id}/notification-preferences - e.g. {"preferences": {"imports": "daily"}}
+func (h *NotificationHandler) SetPreferences(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Notification preferences update requested from IP: %s", r.RemoteAddr)
+
+	profileID, ok := profileIDFromPath(w, r)
+	if !ok || !requireSelfOrAdmin(w, r, h.Profiles, profileID) {
+		return
+	}
+
+	var input models.SetNotificationPreferencesInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in notification preferences update", err)
+		return
+	}
+
+	preferences, err := h.Service.SetPreferences(r.Context(), profileID, input)
+	if err != nil {
+		SendErrorResponse(w, "Failed to update notification preferences: "+err.Error(), http.StatusBadRequest,
+			"Error updating notification preferences", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Notification preferences updated", preferences,
+		"Notification preferences updated for profile "+profileID.String())
+}
+
+// List handles GET /api/profiles/{id}/notifications?limit=50 - recent notifications for the inbox
+func (h *NotificationHandler) List(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Notifications requested from IP: %s", r.RemoteAddr)
+
+	profileID, ok := profileIDFromPath(w, r)
+	if !ok || !requireSelfOrAdmin(w, r, h.Profiles, profileID) {
+		return
+	}
+
+	limit := 50
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 || parsed > 200 {
+			SendErrorResponse(w, "limit must be between 1 and 200", http.StatusBadRequest,
+				"Invalid limit in notifications request: "+limitStr, err)
+			return
+		}
+		limit = parsed
+	}
+
+	notifications, err := h.Service.ListNotifications(r.Context(), profileID, limit)
+	if err != nil {
+		SendErrorResponse(w, "Failed to retrieve notifications", http.StatusInternalServerError,
+			"Error retrieving notifications", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Notifications retrieved", notifications,
+		"Returned "+strconv.Itoa(len(notifications))+" notifications for profile "+profileID.String())
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/NeroQue/course-management-backend/pkg/session"
+	"github.com/google/uuid"
+)
+
+// OfflineHandler hands out course download manifests and serves the signed downloads in them
+type OfflineHandler struct {
+	Service *services.OfflineService // builds manifests and checks signed URLs
+}
+
+// NewOfflineHandler creates handler with injected service
+func NewOfflineHandler(service *services.OfflineService) *OfflineHandler {
+	return &OfflineHandler{Service: service}
+}
+
+// Manifest handles GET /api/courses/{id}/offline-manifest - every content file of a course
+// with its size, SHA-256 and a signed download URL, for clients that study offline
+func (h *OfflineHandler) Manifest(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Offline manifest requested from IP: %s", r.RemoteAddr)
+
+	userID := session.GetCurrentUser(r.Context())
+	if userID == uuid.Nil {
+		SendErrorResponse(w, "You must be logged in to download a course", http.StatusUnauthorized,
+			"Unauthorized offline manifest request", nil)
+		return
+	}
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in offline manifest request", nil)
+		return
+	}
+
+	courseID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid course ID format", http.StatusBadRequest,
+			"Invalid course UUID in offline manifest request", err)
+		return
+	}
+
+	manifest, err := h.Service.GetManifest(r.Context(), courseID, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			SendErrorResponse(w, "Course not found", http.StatusNotFound,
+				"Offline manifest requested for missing course "+courseID.String(), err)
+		case errors.Is(err, services.ErrContentNotVisible):
+			// hidden courses look the same as missing ones
+			SendErrorResponse(w, "Course not found", http.StatusNotFound,
+				"Course "+courseID.String()+" hidden from profile "+userID.String(), nil)
+		default:
+			SendErrorResponse(w, "Failed to build offline manifest", http.StatusInternalServerError,
+				"Error building offline manifest for course "+courseID.String(), err)
+		}
+		return
+	}
+
+	SendSuccessResponse(w, "Offline manifest created", manifest,
+		"Offline manifest with "+strconv.Itoa(len(manifest.Files))+" files created for course "+courseID.String())
+}
+
+// Download handles GET /api/offline/{id}?profile=&expires=&sig= - a content file from an
+// offline manifest. The signature stands in for the session, so download managers that
+// don't carry cookies work too.
+func (h *OfflineHandler) Download(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Offline download requested from IP: %s", r.RemoteAddr)
+
+	itemID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		SendErrorResponse(w, "Invalid content ID format", http.StatusBadRequest,
+			"Invalid content UUID in offline download request", err)
+		return
+	}
+
+	query := r.URL.Query()
+	stream, err := h.Service.OpenSignedContent(r.Context(), itemID, query.Get("profile"), query.Get("expires"), query.Get("sig"))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidOfflineURL) {
+			SendErrorResponse(w, err.Error(), http.StatusForbidden,
+				"Rejected offline download URL for content "+itemID.String(), nil)
+			return
+		}
+		sendStreamError(w, err, itemID, uuid.Nil)
+		return
+	}
+
+	serveContentFile(w, r, stream, "attachment")
+}
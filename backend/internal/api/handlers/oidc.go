@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/NeroQue/course-management-backend/pkg/oidc"
+	"github.com/NeroQue/course-management-backend/pkg/session"
+	"github.com/google/uuid"
+)
+
+// the browser holds a login in progress in this cookie while it's at the provider
+const (
+	oidcCookieName = "cms_oidc"
+	oidcCookiePath = "/api/auth/oidc"
+	oidcCookieAge  = 600 // seconds to finish logging in at the provider
+)
+
+// OIDCHandler logs people in through an OpenID Connect provider
+type OIDCHandler struct {
+	Service *services.OIDCService // talks to the provider and maps accounts to profiles
+}
+
+// NewOIDCHandler creates handler with injected service
+func NewOIDCHandler(service *services.OIDCService) *OIDCHandler {
+	return &OIDCHandler{Service: service}
+}
+
+// Status handles GET /api/auth/oidc - whether the login screen should offer OIDC login
+func (h *OIDCHandler) Status(w http.ResponseWriter, r *http.Request) {
+	log.Printf("OIDC status requested from IP: %s", r.RemoteAddr)
+
+	SendSuccessResponse(w, "OIDC status retrieved", h.Service.Status(),
+		"Returned OIDC login status")
+}
+
+// Login handles GET /api/auth/oidc/login - sends the browser to the provider. With
+// ?link=true the account gets linked to the logged in profile instead.
+func (h *OIDCHandler) Login(w http.ResponseWriter, r *http.Request) {
+	log.Printf("OIDC login requested from IP: %s", r.RemoteAddr)
+
+	link := false
+	if linkStr := r.URL.Query().Get("link"); linkStr != "" {
+		parsed, err := strconv.ParseBool(linkStr)
+		if err != nil {
+			SendErrorResponse(w, "Invalid link value", http.StatusBadRequest,
+				"Invalid link in OIDC login: "+linkStr, err)
+			return
+		}
+		link = parsed
+	}
+	if link && session.GetRealUser(r.Context()) == uuid.Nil {
+		SendErrorResponse(w, "You must be logged in to link an account", http.StatusUnauthorized,
+			"OIDC link attempted without a session", nil)
+		return
+	}
+
+	authURL, login, err := h.Service.StartLogin(r.Context(), link)
+	if err != nil {
+		if errors.Is(err, services.ErrOIDCDisabled) {
+			SendErrorResponse(w, err.Error(), http.StatusNotFound,
+				"OIDC login requested while it's off", err)
+			return
+		}
+		SendErrorResponse(w, "Login provider is not available", http.StatusBadGateway,
+			"Error starting OIDC login", err)
+		return
+	}
+
+	linkFlag := "0"
+	if login.Link {
+		linkFlag = "1"
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcCookieName,
+		Value:    strings.Join([]string{login.State, login.Nonce, login.Verifier, linkFlag}, "."),
+		Path:     oidcCookiePath,
+		MaxAge:   oidcCookieAge,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode, // sent along when the provider redirects back
+	})
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// Callback handles GET /api/auth/oidc/callback - where the provider sends the browser
+// back. Starts a session for the account's profile and moves on to the app.
+func (h *OIDCHandler) Callback(w http.ResponseWriter, r *http.Request) {
+	log.Printf("OIDC callback requested from IP: %s", r.RemoteAddr)
+
+	// a login is only good for one callback
+	http.SetCookie(w, &http.Cookie{Name: oidcCookieName, Value: "", Path: oidcCookiePath, MaxAge: -1, HttpOnly: true})
+
+	query := r.URL.Query()
+	if providerError := query.Get("error"); providerError != "" {
+		SendErrorResponse(w, "Login was refused by the provider: "+providerError, http.StatusUnauthorized,
+			"OIDC provider returned "+providerError+": "+query.Get("error_description"), nil)
+		return
+	}
+
+	login := loginFromCookie(r)
+	if login == nil || query.Get("state") != login.State {
+		SendErrorResponse(w, "Login expired or was started elsewhere, please try again", http.StatusBadRequest,
+			"OIDC callback with missing or mismatched state", nil)
+		return
+	}
+	code := query.Get("code")
+	if code == "" {
+		SendErrorResponse(w, "Login code is missing", http.StatusBadRequest,
+			"OIDC callback without a code", nil)
+		return
+	}
+
+	profileID, err := h.Service.FinishLogin(r.Context(), login, code, session.GetRealUser(r.Context()))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrOIDCDisabled):
+			SendErrorResponse(w, err.Error(), http.StatusNotFound,
+				"OIDC callback while it's off", err)
+		case errors.Is(err, services.ErrIdentityLinked):
+			SendErrorResponse(w, err.Error(), http.StatusConflict,
+				"OIDC account already linked elsewhere", err)
+		case errors.Is(err, services.ErrNoLinkedProfile):
+			SendErrorResponse(w, err.Error()+", ask an admin to link it", http.StatusForbidden,
+				"OIDC login refused for an unlinked account", err)
+		case errors.Is(err, oidc.ErrInvalidIDToken):
+			SendErrorResponse(w, "Login could not be verified", http.StatusUnauthorized,
+				"OIDC ID token rejected", err)
+		default:
+			SendErrorResponse(w, "Login failed", http.StatusBadGateway,
+				"Error finishing OIDC login", err)
+		}
+		return
+	}
+
+	token, err := session.SetCurrentUser(r.Context(), profileID)
+	if err != nil {
+		SendErrorResponse(w, "Failed to start session", http.StatusInternalServerError,
+			"Error starting session for profile "+profileID.String(), err)
+		return
+	}
+	session.SetCookie(w, r, token)
+
+	log.Printf("Profile %s logged in through OIDC", profileID)
+	http.Redirect(w, r, h.Service.PostLoginURL, http.StatusFound)
+}
+
+// loginFromCookie reads back the login Login stored, nil when there's none
+func loginFromCookie(r *http.Request) *services.OIDCLogin {
+	cookie, err := r.Cookie(oidcCookieName)
+	if err != nil {
+		return nil
+	}
+	parts := strings.Split(cookie.Value, ".")
+	if len(parts) != 4 || parts[0] == "" {
+		return nil
+	}
+	return &services.OIDCLogin{State: parts[0], Nonce: parts[1], Verifier: parts[2], Link: parts[3] == "1"}
+}
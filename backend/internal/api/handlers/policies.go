@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/NeroQue/course-management-backend/pkg/access"
+)
+
+// PolicyHandler shows the route access policies - admin only
+type PolicyHandler struct {
+	Policies *access.Policies         // effective policy per route
+	Profiles *services.ProfileService // needed for admin checks
+}
+
+// NewPolicyHandler creates handler with injected dependencies
+func NewPolicyHandler(policies *access.Policies, profiles *services.ProfileService) *PolicyHandler {
+	return &PolicyHandler{Policies: policies, Profiles: profiles}
+}
+
+// List handles GET /api/admin/policies - the role every route requires and where that came from
+func (h *PolicyHandler) List(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Route policies requested from IP: %s", r.RemoteAddr)
+
+	if _, ok := requireAdmin(w, r, h.Profiles); !ok {
+		return
+	}
+
+	routes := h.Policies.All()
+	responseData := map[string]interface{}{
+		"default_role": h.Policies.DefaultRole(),
+		"routes":       routes,
+	}
+	SendSuccessResponse(w, "Route policies retrieved", responseData,
+		"Route policies returned for "+strconv.Itoa(len(routes))+" routes")
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/google/uuid"
+)
+
+// PrefetchHandler tells players what to preload
+type PrefetchHandler struct {
+	Service *services.PrefetchService // upcoming items and cache warming
+}
+
+// NewPrefetchHandler creates handler with injected service
+func NewPrefetchHandler(service *services.PrefetchService) *PrefetchHandler {
+	return &PrefetchHandler{Service: service}
+}
+
+// Get handles GET /api/content/{id}/prefetch?count=3&warm=true - the next items after this one.
+// warm=true also restores and reads ahead those items on the server in a background task.
+func (h *PrefetchHandler) Get(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Content prefetch requested from IP: %s", r.RemoteAddr)
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in prefetch request", nil)
+		return
+	}
+
+	itemID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid content ID format", http.StatusBadRequest,
+			"Invalid content UUID in prefetch request", err)
+		return
+	}
+
+	count := services.DefaultPrefetchCount
+	if countStr := r.URL.Query().Get("count"); countStr != "" {
+		count, err = strconv.Atoi(countStr)
+		if err != nil || count < 1 || count > services.MaxPrefetchCount {
+			SendErrorResponse(w, "count must be between 1 and "+strconv.Itoa(services.MaxPrefetchCount), http.StatusBadRequest,
+				"Invalid count in prefetch request", err)
+			return
+		}
+	}
+
+	result, err := h.Service.NextItems(r.Context(), itemID, count)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			SendErrorResponse(w, "Content item not found", http.StatusNotFound,
+				"Prefetch requested for non-existent content "+itemID.String(), err)
+			return
+		}
+		SendErrorResponse(w, "Failed to find upcoming content", http.StatusInternalServerError,
+			"Error building prefetch list", err)
+		return
+	}
+
+	if r.URL.Query().Get("warm") == "true" && len(result.Items) > 0 {
+		result.WarmTaskID = h.Service.StartWarmTask(result.CourseID, result.Items)
+	}
+
+	SendSuccessResponse(w, "Upcoming content retrieved", result,
+		"Prefetch list of "+strconv.Itoa(len(result.Items))+" items returned for content "+itemID.String())
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/NeroQue/course-management-backend/pkg/session"
+	"github.com/google/uuid"
+)
+
+// PrerequisiteHandler processes course prerequisite requests
+type PrerequisiteHandler struct {
+	Service  *services.PrerequisiteService // prerequisite logic
+	Courses  *services.CourseService       // for the creator check
+	Profiles *services.ProfileService      // needed for admin checks
+}
+
+// NewPrerequisiteHandler creates handler with injected services
+func NewPrerequisiteHandler(service *services.PrerequisiteService, courses *services.CourseService,
+	profiles *services.ProfileService) *PrerequisiteHandler {
+	return &PrerequisiteHandler{Service: service, Courses: courses, Profiles: profiles}
+}
+
+// Get handles GET /api/courses/{id}/prerequisites - with completion for the current profile
+func (h *PrerequisiteHandler) Get(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Course prerequisites requested from IP: %s", r.RemoteAddr)
+
+	courseID, ok := prerequisiteCourseID(w, r)
+	if !ok {
+		return
+	}
+
+	prerequisites, err := h.Service.GetPrerequisites(r.Context(), courseID, session.GetCurrentUser(r.Context()))
+	if err != nil {
+		sendPrerequisiteError(w, courseID, err)
+		return
+	}
+
+	SendSuccessResponse(w, "Prerequisites retrieved", prerequisites,
+		"Prerequisites returned for course "+courseID.String())
+}
+
+// Set handles PUT /api/courses/{id}/prerequisites - course creator or admin, replaces the list
+func (h *PrerequisiteHandler) Set(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Course prerequisites update requested from IP: %s", r.RemoteAddr)
+
+	courseID, ok := prerequisiteCourseID(w, r)
+	if !ok {
+		return
+	}
+
+	var input models.SetPrerequisitesInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in prerequisites update", err)
+		return
+	}
+
+	course, err := h.Courses.GetCourseWithDepth(r.Context(), courseID, models.CourseDepthSummary)
+	if err != nil {
+		sendPrerequisiteError(w, courseID, err)
+		return
+	}
+
+	if !requireSelfOrAdmin(w, r, h.Profiles, course.CreatorID) {
+		return
+	}
+
+	prerequisites, err := h.Service.SetPrerequisites(r.Context(), courseID, input.CourseIDs)
+	if err != nil {
+		sendPrerequisiteError(w, courseID, err)
+		return
+	}
+
+	SendSuccessResponse(w, "Prerequisites updated", prerequisites,
+		"Prerequisites updated for course "+courseID.String())
+}
+
+// prerequisiteCourseID pulls the course ID out of /api/courses/{id}/prerequisites
+func prerequisiteCourseID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in prerequisites request", nil)
+		return uuid.Nil, false
+	}
+
+	courseID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid course ID format", http.StatusBadRequest,
+			"Invalid course UUID in prerequisites request", err)
+		return uuid.Nil, false
+	}
+	return courseID, true
+}
+
+// sendPrerequisiteError maps prerequisite errors to responses
+func sendPrerequisiteError(w http.ResponseWriter, courseID uuid.UUID, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidPrerequisite):
+		SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+			"Invalid prerequisites for course "+courseID.String(), err)
+	case errors.Is(err, sql.ErrNoRows):
+		SendErrorResponse(w, "Course not found", http.StatusNotFound,
+			"Prerequisites requested for non-existent course "+courseID.String(), err)
+	default:
+		SendErrorResponse(w, "Failed to process prerequisites", http.StatusInternalServerError,
+			"Error processing prerequisites", err)
+	}
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/internal/services"
+)
+
+// ProfileMergeHandler processes profile merge requests
+type ProfileMergeHandler struct {
+	Service  *services.ProfileMergeService // merge logic
+	Profiles *services.ProfileService      // needed for admin checks
+}
+
+// NewProfileMergeHandler creates handler with injected services
+func NewProfileMergeHandler(service *services.ProfileMergeService, profiles *services.ProfileService) *ProfileMergeHandler {
+	return &ProfileMergeHandler{Service: service, Profiles: profiles}
+}
+
+// Merge handles POST /api/profiles/merge - folds the source profile into the target and
+// deletes it (admin only)
+func (h *ProfileMergeHandler) Merge(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Profile merge requested from IP: %s", r.RemoteAddr)
+
+	currentUser, ok := requireAdmin(w, r, h.Profiles)
+	if !ok {
+		return
+	}
+
+	var input models.MergeProfilesInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in profile merge", err)
+		return
+	}
+
+	// same as with roles, an admin merging themselves away would be logged out mid-request
+	if input.SourceID == currentUser {
+		SendErrorResponse(w, "You cannot merge away your own profile", http.StatusBadRequest,
+			"Admin attempted to merge own profile into "+input.TargetID.String(), nil)
+		return
+	}
+
+	merge, err := h.Service.MergeProfiles(r.Context(), input.SourceID, input.TargetID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidMerge):
+			SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+				"Invalid profile merge by "+currentUser.String(), err)
+		case errors.Is(err, sql.ErrNoRows):
+			SendErrorResponse(w, "Profile not found", http.StatusNotFound,
+				"Merge of "+input.SourceID.String()+" into "+input.TargetID.String()+" names a missing profile", err)
+		default:
+			SendErrorResponse(w, "Failed to merge profiles", http.StatusInternalServerError,
+				"Error merging profile "+input.SourceID.String()+" into "+input.TargetID.String(), err)
+		}
+		return
+	}
+
+	SendSuccessResponse(w, "Profiles merged", merge,
+		"Profile "+input.SourceID.String()+" merged into "+input.TargetID.String()+" by "+currentUser.String())
+}
@@ -1,12 +1,14 @@
 package handlers
 
 import (
+	"errors"
 	"log"
 	"net/http"
 	"strings"
 
 	"github.com/NeroQue/course-management-backend/internal/models"
 	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/NeroQue/course-management-backend/pkg/access"
 	"github.com/NeroQue/course-management-backend/pkg/session"
 	"github.com/google/uuid"
 )
@@ -41,8 +43,8 @@ func (h *ProfileHandler) List(w http.ResponseWriter, r *http.Request) {
 func (h *ProfileHandler) Create(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Profile creation requested from IP: %s", r.RemoteAddr)
 
-	// parse and validate the request body
-	var profile models.Profile
+	// parse and validate the request body - only the name, the server picks the ID and role
+	var profile models.CreateProfileInput
 	if err := ValidateJSONBody(r, &profile); err != nil {
 		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
 			"Invalid JSON in profile creation request", err)
@@ -59,7 +61,7 @@ func (h *ProfileHandler) Create(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Creating new profile with name: %s", profile.Name)
 
 	// use service to create profile
-	createdProfile, err := h.Service.CreateProfile(r.Context(), profile)
+	createdProfile, err := h.Service.CreateProfile(r.Context(), models.Profile{Name: profile.Name})
 	if err != nil {
 		SendErrorResponse(w, "Failed to create profile", http.StatusInternalServerError,
 			"Error creating profile in database", err)
@@ -70,7 +72,8 @@ func (h *ProfileHandler) Create(w http.ResponseWriter, r *http.Request) {
 		"Profile created successfully with ID: "+createdProfile.ID.String())
 }
 
-// Update handles PUT /api/profiles - updates existing profile
+// Update handles PUT /api/profiles - updates existing profile, the caller's own unless
+// they're an admin
 func (h *ProfileHandler) Update(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Profile update requested from IP: %s", r.RemoteAddr)
 
@@ -93,6 +96,9 @@ func (h *ProfileHandler) Update(w http.ResponseWriter, r *http.Request) {
 			"Profile update attempted with missing user ID", nil)
 		return
 	}
+	if !requireSelfOrAdmin(w, r, h.Service, req.UserID) {
+		return
+	}
 
 	if strings.TrimSpace(req.NewName) == "" {
 		SendErrorResponse(w, "New name is required and cannot be empty", http.StatusBadRequest,
@@ -114,7 +120,8 @@ func (h *ProfileHandler) Update(w http.ResponseWriter, r *http.Request) {
 		"Profile "+req.UserID.String()+" updated successfully")
 }
 
-// Delete handles DELETE /api/profiles - removes a profile
+// Delete handles DELETE /api/profiles - removes a profile, the caller's own unless
+// they're an admin
 func (h *ProfileHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Profile deletion requested from IP: %s", r.RemoteAddr)
 
@@ -135,6 +142,9 @@ func (h *ProfileHandler) Delete(w http.ResponseWriter, r *http.Request) {
 			"Profile deletion attempted with missing user ID", nil)
 		return
 	}
+	if !requireSelfOrAdmin(w, r, h.Service, req.UserID) {
+		return
+	}
 
 	log.Printf("Deleting profile: %s", req.UserID.String())
 
@@ -149,7 +159,10 @@ func (h *ProfileHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		"Profile "+req.UserID.String()+" deleted successfully")
 }
 
-// SelectProfile handles POST /api/profiles/{id}/select - sets active profile
+// SelectProfile handles POST /api/profiles/{id}/select - sets active profile. Editors and
+// admins can only be selected on the server itself, or with {"secret": PROFILE_SELECT_SECRET}.
+// With OIDC login on the route is denied unless OIDC_DISABLE_PROFILE_SELECT=false, and even
+// then only viewers without a linked account can be selected.
 func (h *ProfileHandler) SelectProfile(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Profile selection requested from IP: %s", r.RemoteAddr)
 
@@ -172,16 +185,169 @@ func (h *ProfileHandler) SelectProfile(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Selecting profile: %s", profileID.String())
 
 	// make sure profile actually exists
-	_, err = h.Service.GetProfileByID(r.Context(), profileID)
+	profile, err := h.Service.GetProfileByID(r.Context(), profileID)
 	if err != nil {
 		SendErrorResponse(w, "Profile not found", http.StatusNotFound,
 			"Attempted to select non-existent profile", err)
 		return
 	}
+	// a guest belongs to the one session it was made for
+	if profile.IsGuest {
+		SendErrorResponse(w, "Profile not found", http.StatusNotFound,
+			"Attempted to select guest profile "+profileID.String(), nil)
+		return
+	}
+
+	// the body is optional, only editors and admins away from the server need one
+	var input models.SelectProfileInput
+	if r.ContentLength != 0 {
+		if err := ValidateJSONBody(r, &input); err != nil {
+			SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+				"Invalid JSON in profile selection", err)
+			return
+		}
+	}
+	if err := h.Service.CheckSelection(r.Context(), profile, input.Secret, isLocalRequest(r)); err != nil {
+		if !errors.Is(err, services.ErrSelectionSecret) && !errors.Is(err, services.ErrSelectionSSO) {
+			SendErrorResponse(w, "Failed to select profile", http.StatusInternalServerError,
+				"Error checking selection of profile "+profileID.String(), err)
+			return
+		}
+		SendErrorResponse(w, err.Error(), http.StatusForbidden,
+			"Refused selection of "+profile.Role+" profile "+profileID.String()+" from "+r.RemoteAddr, err)
+		return
+	}
 
-	// set as current user in session
-	session.SetCurrentUser(profileID)
+	// start a session for this client only, other browsers keep their own profiles
+	token, err := session.SetCurrentUser(r.Context(), profileID)
+	if err != nil {
+		SendErrorResponse(w, "Failed to start session", http.StatusInternalServerError,
+			"Error starting session for profile "+profileID.String(), err)
+		return
+	}
+	session.SetCookie(w, r, token)
 
-	SendSuccessResponse(w, "Profile selected successfully", nil,
+	SendSuccessResponse(w, "Profile selected successfully", models.SelectProfileResult{ProfileID: profileID, Token: token},
 		"Profile "+profileID.String()+" selected as active")
 }
+
+// StartGuest handles POST /api/guest - logs the client in as a new guest profile, so
+// visitors can look around without a profile of their own. Whatever the guest does is
+// deleted on logout.
+func (h *ProfileHandler) StartGuest(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Guest session requested from IP: %s", r.RemoteAddr)
+
+	profile, err := h.Service.CreateGuestProfile(r.Context())
+	if err != nil {
+		SendErrorResponse(w, "Failed to create guest profile", http.StatusInternalServerError,
+			"Error creating guest profile", err)
+		return
+	}
+
+	token, err := session.SetCurrentUser(r.Context(), profile.ID)
+	if err != nil {
+		// without a session nobody can use it, don't wait for the pruning to get rid of it
+		if _, endErr := h.Service.EndGuestProfile(r.Context(), profile.ID); endErr != nil {
+			log.Printf("Error removing unused guest profile %s: %v", profile.ID, endErr)
+		}
+		SendErrorResponse(w, "Failed to start session", http.StatusInternalServerError,
+			"Error starting session for guest profile "+profile.ID.String(), err)
+		return
+	}
+	session.SetCookie(w, r, token)
+
+	SendCreatedResponse(w, "Guest session started", models.SelectProfileResult{ProfileID: profile.ID, Token: token},
+		"Guest profile "+profile.ID.String()+" created")
+}
+
+// SetAdmin handles PUT /api/profiles/{id}/admin - grants or revokes admin rights (admin only)
+func (h *ProfileHandler) SetAdmin(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Profile admin change requested from IP: %s", r.RemoteAddr)
+
+	currentUser, ok := requireAdmin(w, r, h.Service)
+	if !ok {
+		return
+	}
+
+	profileID, ok := profileIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	type adminRequest struct {
+		IsAdmin bool `json:"is_admin"`
+	}
+
+	var req adminRequest
+	if err := ValidateJSONBody(r, &req); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in profile admin change", err)
+		return
+	}
+
+	// don't let the last admin lock themselves out by accident
+	if profileID == currentUser && !req.IsAdmin {
+		SendErrorResponse(w, "You cannot remove your own admin rights", http.StatusBadRequest,
+			"Admin attempted to revoke own admin rights", nil)
+		return
+	}
+
+	updatedProfile, err := h.Service.SetAdmin(r.Context(), profileID, req.IsAdmin)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidRole) {
+			SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+				"Invalid admin change requested for "+profileID.String(), nil)
+			return
+		}
+		SendErrorResponse(w, "Failed to update profile", http.StatusInternalServerError,
+			"Error updating admin flag", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Profile admin rights updated", updatedProfile,
+		"Profile "+profileID.String()+" admin flag set by "+currentUser.String())
+}
+
+// SetRole handles PUT /api/profiles/{id}/role - makes a profile a viewer, editor or admin (admin only)
+func (h *ProfileHandler) SetRole(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Profile role change requested from IP: %s", r.RemoteAddr)
+
+	currentUser, ok := requireAdmin(w, r, h.Service)
+	if !ok {
+		return
+	}
+
+	profileID, ok := profileIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var input models.SetRoleInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in profile role change", err)
+		return
+	}
+
+	// don't let the last admin lock themselves out by accident
+	if profileID == currentUser && input.Role != access.RoleAdmin {
+		SendErrorResponse(w, "You cannot remove your own admin rights", http.StatusBadRequest,
+			"Admin attempted to change own role to "+input.Role, nil)
+		return
+	}
+
+	updatedProfile, err := h.Service.SetRole(r.Context(), profileID, input.Role)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidRole) {
+			SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+				"Invalid role requested for "+profileID.String(), nil)
+			return
+		}
+		SendErrorResponse(w, "Failed to update profile", http.StatusInternalServerError,
+			"Error updating role", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Profile role updated", updatedProfile,
+		"Profile "+profileID.String()+" made "+input.Role+" by "+currentUser.String())
+}
@@ -0,0 +1,287 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/NeroQue/course-management-backend/pkg/session"
+	"github.com/google/uuid"
+)
+
+// ResetCourseProgress handles POST /api/courses/{id}/progress/reset?user_id={uuid} - deletes
+// all of the profile's progress in the course so it can be taken again
+func (h *CourseHandler) ResetCourseProgress(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Course progress reset requested from IP: %s", r.RemoteAddr)
+
+	courseID, userID, ok := h.bulkProgressTarget(w, r, "course", "progress reset")
+	if !ok {
+		return
+	}
+
+	reset, err := h.Service.ResetCourseProgress(r.Context(), userID, courseID)
+	if err != nil {
+		sendBulkProgressError(w, err, "course", courseID, "Failed to reset progress")
+		return
+	}
+
+	SendSuccessResponse(w, "Course progress reset", reset,
+		"Progress of user "+userID.String()+" reset on course "+courseID.String())
+}
+
+// TransferProgress handles POST /api/courses/{id}/progress/transfer - copies or moves a
+// course's progress from one profile to another (admin only)
+func (h *CourseHandler) TransferProgress(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Course progress transfer requested from IP: %s", r.RemoteAddr)
+
+	currentUser, ok := requireAdmin(w, r, h.Profiles)
+	if !ok {
+		return
+	}
+
+	courseID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		SendErrorResponse(w, "Invalid course ID format", http.StatusBadRequest,
+			"Invalid course UUID in progress transfer request", err)
+		return
+	}
+
+	var input models.TransferProgressInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in progress transfer request", err)
+		return
+	}
+
+	transfer, err := h.Service.TransferCourseProgress(r.Context(), courseID, input)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidTransfer) {
+			SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+				"Invalid progress transfer on course "+courseID.String()+" by "+currentUser.String(), err)
+			return
+		}
+		if errors.Is(err, services.ErrTransferProfileNotFound) {
+			SendErrorResponse(w, err.Error(), http.StatusNotFound,
+				"Progress transfer on course "+courseID.String()+" names a missing profile", err)
+			return
+		}
+		sendBulkProgressError(w, err, "course", courseID, "Failed to transfer progress")
+		return
+	}
+
+	SendSuccessResponse(w, "Course progress transferred", transfer,
+		fmt.Sprintf("%s %d progress records on course %s from %s to %s by %s", transfer.Mode, transfer.ItemsTransferred,
+			courseID, input.SourceID, input.TargetID, currentUser))
+}
+
+// CompleteModule handles POST /api/modules/{id}/complete?user_id={uuid} - marks every item
+// in the module completed
+func (h *CourseHandler) CompleteModule(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Module completion requested from IP: %s", r.RemoteAddr)
+
+	moduleID, userID, ok := h.bulkProgressTarget(w, r, "module", "module completion")
+	if !ok {
+		return
+	}
+
+	result, err := h.Service.CompleteModule(r.Context(), userID, moduleID)
+	if err != nil {
+		sendBulkProgressError(w, err, "module", moduleID, "Failed to complete module")
+		return
+	}
+
+	SendSuccessResponse(w, "Module marked as completed", result,
+		"Module "+moduleID.String()+" completed for user "+userID.String())
+}
+
+// CompleteCourse handles POST /api/courses/{id}/complete?user_id={uuid} - marks every item
+// in the course completed
+func (h *CourseHandler) CompleteCourse(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Course completion requested from IP: %s", r.RemoteAddr)
+
+	courseID, userID, ok := h.bulkProgressTarget(w, r, "course", "course completion")
+	if !ok {
+		return
+	}
+
+	result, err := h.Service.CompleteCourse(r.Context(), userID, courseID)
+	if err != nil {
+		sendBulkProgressError(w, err, "course", courseID, "Failed to complete course")
+		return
+	}
+
+	SendSuccessResponse(w, "Course marked as completed", result,
+		"Course "+courseID.String()+" completed for user "+userID.String())
+}
+
+// bulkProgressTarget pulls the course or module ID out of the path and the profile out of
+// ?user_id, defaulting to the current profile. Other profiles' progress takes an admin.
+func (h *CourseHandler) bulkProgressTarget(w http.ResponseWriter, r *http.Request, kind, action string) (uuid.UUID, uuid.UUID, bool) {
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in "+action+" request", nil)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid "+kind+" ID format", http.StatusBadRequest,
+			"Invalid "+kind+" UUID in "+action+" request", err)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	userID := session.GetCurrentUser(r.Context())
+	if userIDStr := r.URL.Query().Get("user_id"); userIDStr != "" {
+		userID, err = uuid.Parse(userIDStr)
+		if err != nil {
+			SendErrorResponse(w, "Invalid user ID format", http.StatusBadRequest,
+				"Invalid user UUID in "+action+" request", err)
+			return uuid.Nil, uuid.Nil, false
+		}
+	}
+	if !requireSelfOrAdmin(w, r, h.Profiles, userID) {
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return id, userID, true
+}
+
+// sendBulkProgressError answers 404 for a missing course or module and 500 otherwise
+func sendBulkProgressError(w http.ResponseWriter, err error, kind string, id uuid.UUID, message string) {
+	if errors.Is(err, sql.ErrNoRows) {
+		SendErrorResponse(w, strings.ToUpper(kind[:1])+kind[1:]+" not found", http.StatusNotFound,
+			message+" for non-existent "+kind+" "+id.String(), err)
+		return
+	}
+	SendErrorResponse(w, message, http.StatusInternalServerError,
+		message+" "+id.String(), err)
+}
+
+// SyncProgress handles POST /api/progress/sync?user_id={uuid} - applies progress a client
+// queued while offline. Updates never move progress backwards, so retrying a sync is safe.
+func (h *CourseHandler) SyncProgress(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Progress sync requested from IP: %s", r.RemoteAddr)
+
+	userID := session.GetCurrentUser(r.Context())
+	if userIDStr := r.URL.Query().Get("user_id"); userIDStr != "" {
+		var err error
+		userID, err = uuid.Parse(userIDStr)
+		if err != nil {
+			SendErrorResponse(w, "Invalid user ID format", http.StatusBadRequest,
+				"Invalid user UUID in progress sync request", err)
+			return
+		}
+	}
+	if !requireSelfOrAdmin(w, r, h.Profiles, userID) {
+		return
+	}
+
+	var input models.ProgressSyncInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in progress sync request", err)
+		return
+	}
+
+	result, err := h.Service.SyncProgress(r.Context(), userID, input.Updates)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidSync) {
+			SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+				"Invalid progress sync for user "+userID.String(), err)
+			return
+		}
+		SendErrorResponse(w, "Failed to sync progress", http.StatusInternalServerError,
+			"Error syncing progress of user "+userID.String(), err)
+		return
+	}
+
+	SendSuccessResponse(w, "Progress synced", result,
+		fmt.Sprintf("Synced %d progress updates for user %s, %d skipped", result.Applied, userID, len(result.Skipped)))
+}
+
+// ExportProgress handles GET /api/users/{id}/progress/export?format=csv - every progress
+// record of the profile as a spreadsheet-friendly CSV download
+func (h *CourseHandler) ExportProgress(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Progress export requested from IP: %s", r.RemoteAddr)
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in progress export request", nil)
+		return
+	}
+
+	userID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid user ID format", http.StatusBadRequest,
+			"Invalid user UUID in progress export request", err)
+		return
+	}
+
+	// csv is all there is for now, the parameter leaves room for more
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "csv" {
+		SendErrorResponse(w, "format must be csv", http.StatusBadRequest,
+			"Invalid progress export format: "+format, nil)
+		return
+	}
+
+	if !requireSelfOrAdmin(w, r, h.Profiles, userID) {
+		return
+	}
+
+	records, err := h.Service.ListProgressRecords(r.Context(), userID)
+	if err != nil {
+		SendErrorResponse(w, "Failed to export progress", http.StatusInternalServerError,
+			"Error exporting progress of user "+userID.String(), err)
+		return
+	}
+
+	if err := writeProgressCSV(w, userID, records); err != nil {
+		// headers are already out at this point, so just log it
+		log.Printf("Error writing progress export: %v", err)
+		return
+	}
+	log.Printf("Exported %d progress records of user %s", len(records), userID.String())
+}
+
+// writeProgressCSV streams progress records as CSV with a header row. Times are RFC 3339
+// in UTC, empty when unset.
+func writeProgressCSV(w http.ResponseWriter, userID uuid.UUID, records []models.ProgressRecord) error {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="progress-%s.csv"`, userID))
+	w.WriteHeader(http.StatusOK)
+
+	formatTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"course", "module", "item", "content_type", "progress_pct", "completed", "completed_at", "last_accessed"})
+	for _, record := range records {
+		cw.Write([]string{
+			record.CourseTitle,
+			record.ModuleTitle,
+			record.ContentTitle,
+			record.ContentType,
+			strconv.FormatFloat(float64(record.ProgressPct), 'f', -1, 32),
+			strconv.FormatBool(record.Completed),
+			formatTime(record.CompletedAt),
+			formatTime(record.LastAccessed),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
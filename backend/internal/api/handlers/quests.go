@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/google/uuid"
+)
+
+// QuestHandler serves profiles' weekly quests
+type QuestHandler struct {
+	Service  *services.QuestService   // quest rotation and progress
+	Profiles *services.ProfileService // admin checks
+}
+
+// NewQuestHandler creates handler with injected services
+func NewQuestHandler(service *services.QuestService, profiles *services.ProfileService) *QuestHandler {
+	return &QuestHandler{Service: service, Profiles: profiles}
+}
+
+// List handles GET /api/users/{id}/quests - this week's quests with their progress. The
+// first request of a week hands out the new set.
+func (h *QuestHandler) List(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Quests requested from IP: %s", r.RemoteAddr)
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in quests request", nil)
+		return
+	}
+
+	profileID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid user ID format", http.StatusBadRequest,
+			"Invalid user UUID in quests request", err)
+		return
+	}
+
+	if !requireSelfOrAdmin(w, r, h.Profiles, profileID) {
+		return
+	}
+
+	board, err := h.Service.GetQuests(r.Context(), profileID)
+	if err != nil {
+		SendErrorResponse(w, "Failed to retrieve quests", http.StatusInternalServerError,
+			"Error retrieving quests for "+profileID.String(), err)
+		return
+	}
+
+	SendSuccessResponse(w, "Quests retrieved", board,
+		"Returned "+strconv.Itoa(len(board.Quests))+" quests for profile "+profileID.String())
+}
@@ -8,8 +8,9 @@ import (
 
 // Common response structures for consistency across all handlers
 type ErrorResponse struct {
-	Message string `json:"message"`
-	Success bool   `json:"success"`
+	Message string      `json:"message"`
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"` // extra context, e.g. the conflicting resource
 }
 
 type SuccessResponse struct {
@@ -44,6 +45,28 @@ func SendErrorResponse(w http.ResponseWriter, message string, statusCode int, lo
 	}
 }
 
+// SendErrorResponseWithData sends an error response that carries extra data for the client
+func SendErrorResponseWithData(w http.ResponseWriter, message string, statusCode int, data interface{}, logMessage string, err error) {
+	if err != nil {
+		log.Printf("%s: %v", logMessage, err)
+	} else {
+		log.Printf("%s", logMessage)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	response := ErrorResponse{
+		Message: message,
+		Success: false,
+		Data:    data,
+	}
+
+	if encodeErr := json.NewEncoder(w).Encode(response); encodeErr != nil {
+		log.Printf("Failed to encode error response: %v", encodeErr)
+	}
+}
+
 // SendSuccessResponse sends a consistent success response with logging
 func SendSuccessResponse(w http.ResponseWriter, message string, data interface{}, logMessage string) {
 	// Log the success
@@ -88,6 +111,27 @@ func SendCreatedResponse(w http.ResponseWriter, message string, data interface{}
 	}
 }
 
+// SendAcceptedResponse sends a consistent response for work that was started but isn't done yet
+func SendAcceptedResponse(w http.ResponseWriter, message string, data interface{}, logMessage string) {
+	// Log the success
+	log.Printf("%s", logMessage)
+
+	// Set headers and status code
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+
+	// Send structured success response
+	response := SuccessResponse{
+		Message: message,
+		Success: true,
+		Data:    data,
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Printf("Failed to encode accepted response: %v", err)
+	}
+}
+
 // ValidateJSONBody validates and decodes JSON request body
 func ValidateJSONBody(r *http.Request, dest interface{}) error {
 	if r.Body == nil {
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/NeroQue/course-management-backend/pkg/session"
+)
+
+// SearchHandler handles library-wide search
+type SearchHandler struct {
+	Service *services.SearchService // matches titles, descriptions and transcripts
+}
+
+// NewSearchHandler creates handler with injected service
+func NewSearchHandler(service *services.SearchService) *SearchHandler {
+	return &SearchHandler{Service: service}
+}
+
+// Search handles GET /api/search?q=...&limit=20 - courses and content items matching the
+// query, including content where it's said in the transcript
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Search requested from IP: %s", r.RemoteAddr)
+
+	limit := services.DefaultSearchLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 || parsed > services.MaxSearchLimit {
+			SendErrorResponse(w, "limit must be between 1 and "+strconv.Itoa(services.MaxSearchLimit), http.StatusBadRequest,
+				"Invalid limit in search request: "+limitStr, err)
+			return
+		}
+		limit = parsed
+	}
+
+	results, err := h.Service.Search(r.Context(), r.URL.Query().Get("q"), limit, session.GetCurrentUser(r.Context()))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidSearch) {
+			SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+				"Invalid search query", err)
+			return
+		}
+		SendErrorResponse(w, "Failed to search", http.StatusInternalServerError,
+			"Error running search", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Search completed", results,
+		"Search for "+strconv.Quote(results.Query)+" found "+strconv.Itoa(len(results.Courses))+" courses and "+
+			strconv.Itoa(len(results.Content))+" content items")
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/NeroQue/course-management-backend/pkg/session"
+	"github.com/google/uuid"
+)
+
+// SessionHandler processes logout and session management requests
+type SessionHandler struct {
+	Service  *services.SessionService // session listing and revocation
+	Profiles *services.ProfileService // needed for admin checks
+}
+
+// NewSessionHandler creates handler with injected services
+func NewSessionHandler(service *services.SessionService, profiles *services.ProfileService) *SessionHandler {
+	return &SessionHandler{Service: service, Profiles: profiles}
+}
+
+// Logout handles POST /api/logout - ends the requesting client's session. Logging out
+// without a session is fine, there's nothing left to end.
+func (h *SessionHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Logout requested from IP: %s", r.RemoteAddr)
+
+	if token := session.CurrentAPIToken(r.Context()); token != nil {
+		SendErrorResponse(w, "API tokens have no session to log out of, revoke the token instead", http.StatusBadRequest,
+			"Logout attempted with API token "+token.ID.String(), nil)
+		return
+	}
+
+	userID := session.GetRealUser(r.Context())
+	session.ClearCurrentUser(r.Context())
+	session.ClearCookie(w)
+
+	// a guest's progress goes with it, the pruning catches it if this fails
+	if userID != uuid.Nil {
+		if guest, err := h.Profiles.EndGuestProfile(r.Context(), userID); err != nil {
+			log.Printf("Error ending guest profile %s: %v", userID, err)
+		} else if guest {
+			SendSuccessResponse(w, "Logged out", nil,
+				"Guest profile "+userID.String()+" logged out and deleted")
+			return
+		}
+	}
+
+	SendSuccessResponse(w, "Logged out", nil,
+		"Profile "+userID.String()+" logged out")
+}
+
+// List handles GET /api/profiles/{id}/sessions - where a profile is logged in (self or admin)
+func (h *SessionHandler) List(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Session list requested from IP: %s", r.RemoteAddr)
+
+	profileID, ok := profileIDFromPath(w, r)
+	if !ok {
+		return
+	}
+	if !requireSelfOrAdmin(w, r, h.Profiles, profileID) {
+		return
+	}
+
+	sessions, err := h.Service.ListSessions(r.Context(), profileID, session.CurrentSessionID(r.Context()))
+	if err != nil {
+		SendErrorResponse(w, "Failed to retrieve sessions", http.StatusInternalServerError,
+			"Error listing sessions of "+profileID.String(), err)
+		return
+	}
+
+	SendSuccessResponse(w, "Sessions retrieved", sessions,
+		"Returned "+strconv.Itoa(len(sessions))+" sessions for profile "+profileID.String())
+}
+
+// Revoke handles DELETE /api/sessions/{id} - logs one of a profile's clients out (the
+// session's own profile or an admin)
+func (h *SessionHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Session revocation requested from IP: %s", r.RemoteAddr)
+
+	sessionID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		SendErrorResponse(w, "Invalid session ID format", http.StatusBadRequest,
+			"Invalid session UUID in revocation", err)
+		return
+	}
+
+	owner, err := h.Service.GetSessionOwner(r.Context(), sessionID)
+	if err != nil {
+		if errors.Is(err, services.ErrSessionNotFound) {
+			SendErrorResponse(w, "Session not found", http.StatusNotFound,
+				"Revocation of missing session "+sessionID.String(), err)
+			return
+		}
+		SendErrorResponse(w, "Failed to revoke session", http.StatusInternalServerError,
+			"Error retrieving session "+sessionID.String(), err)
+		return
+	}
+	if !requireSelfOrAdmin(w, r, h.Profiles, owner) {
+		return
+	}
+
+	if err := h.Service.RevokeSession(r.Context(), sessionID); err != nil {
+		SendErrorResponse(w, "Failed to revoke session", http.StatusInternalServerError,
+			"Error revoking session "+sessionID.String(), err)
+		return
+	}
+	// ending the session this request came with is logging out
+	if sessionID == session.CurrentSessionID(r.Context()) {
+		session.ClearCookie(w)
+	}
+
+	SendSuccessResponse(w, "Session revoked", nil,
+		"Session "+sessionID.String()+" of profile "+owner.String()+" revoked")
+}
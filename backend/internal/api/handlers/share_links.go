@@ -0,0 +1,278 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/NeroQue/course-management-backend/pkg/session"
+	"github.com/google/uuid"
+)
+
+// SharePasswordHeader carries the password for protected share links
+const SharePasswordHeader = "X-Share-Password"
+
+// shareAccessCookie remembers a checked password for one share link
+const shareAccessCookie = "share_access"
+
+// ShareLinkHandler processes content share link requests
+type ShareLinkHandler struct {
+	Service  *services.ShareLinkService // share link logic
+	Profiles *services.ProfileService   // needed for admin checks
+}
+
+// NewShareLinkHandler creates handler with injected services
+func NewShareLinkHandler(service *services.ShareLinkService, profiles *services.ProfileService) *ShareLinkHandler {
+	return &ShareLinkHandler{Service: service, Profiles: profiles}
+}
+
+// Create handles POST /api/content/{id}/share-links - course creator or admin
+func (h *ShareLinkHandler) Create(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Share link creation requested from IP: %s", r.RemoteAddr)
+
+	itemID, ok := h.authorizeContentOwner(w, r)
+	if !ok {
+		return
+	}
+
+	var input models.CreateShareLinkInput
+	if r.ContentLength != 0 {
+		if err := ValidateJSONBody(r, &input); err != nil {
+			SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+				"Invalid JSON in share link creation", err)
+			return
+		}
+	}
+
+	link, err := h.Service.CreateShareLink(r.Context(), itemID, session.GetCurrentUser(r.Context()), input)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidShareLink) {
+			SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+				"Invalid share link options for content "+itemID.String(), err)
+			return
+		}
+		sendShareLinkError(w, err)
+		return
+	}
+
+	SendCreatedResponse(w, "Share link created", link,
+		"Share link "+link.ID.String()+" created for content "+itemID.String())
+}
+
+// List handles GET /api/content/{id}/share-links - course creator or admin
+func (h *ShareLinkHandler) List(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Share link list requested from IP: %s", r.RemoteAddr)
+
+	itemID, ok := h.authorizeContentOwner(w, r)
+	if !ok {
+		return
+	}
+
+	links, err := h.Service.ListShareLinks(r.Context(), itemID)
+	if err != nil {
+		sendShareLinkError(w, err)
+		return
+	}
+
+	SendSuccessResponse(w, "Share links retrieved", links,
+		"Returned "+strconv.Itoa(len(links))+" share links for content "+itemID.String())
+}
+
+// Revoke handles DELETE /api/share-links/{id} - whoever made the link or an admin
+func (h *ShareLinkHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Share link revocation requested from IP: %s", r.RemoteAddr)
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in share link revocation", nil)
+		return
+	}
+
+	linkID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid share link ID format", http.StatusBadRequest,
+			"Invalid share link UUID in revocation", err)
+		return
+	}
+
+	link, err := h.Service.GetShareLink(r.Context(), linkID)
+	if err != nil {
+		sendShareLinkError(w, err)
+		return
+	}
+
+	if !requireSelfOrAdmin(w, r, h.Profiles, link.CreatedBy) {
+		return
+	}
+
+	link, err = h.Service.RevokeShareLink(r.Context(), linkID)
+	if err != nil {
+		sendShareLinkError(w, err)
+		return
+	}
+
+	SendSuccessResponse(w, "Share link revoked", link,
+		"Share link "+linkID.String()+" revoked")
+}
+
+// Open handles GET /api/share/{token} - public, streams the shared file.
+// Protected links take the password in the X-Share-Password header, or the access
+// cookie set by an earlier open or by Unlock.
+func (h *ShareLinkHandler) Open(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Shared content requested from IP: %s", r.RemoteAddr)
+
+	shared, ok := h.openShared(w, r, r.Header.Get(SharePasswordHeader))
+	if !ok {
+		return
+	}
+
+	file, err := os.Open(shared.Path)
+	if err != nil {
+		SendErrorResponse(w, "Shared file is not available", http.StatusNotFound,
+			"Shared file missing on disk for link "+shared.LinkID.String(), err)
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		SendErrorResponse(w, "Shared file is not available", http.StatusInternalServerError,
+			"Error reading shared file for link "+shared.LinkID.String(), err)
+		return
+	}
+
+	// players fetch in ranges - only count the request that starts from the top
+	rangeHeader := r.Header.Get("Range")
+	if r.Method == http.MethodGet && (rangeHeader == "" || strings.HasPrefix(rangeHeader, "bytes=0-")) {
+		if err := h.Service.RecordView(r.Context(), shared.LinkID); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+
+	w.Header().Set("Content-Disposition", "inline; filename=\""+strings.ReplaceAll(shared.Filename, "\"", "")+"\"")
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, shared.Filename, info.ModTime(), file)
+}
+
+// Unlock handles POST /api/share/{token} - public, checks a protected link's password
+// from the body and sets the access cookie, so a player can open the link without it
+func (h *ShareLinkHandler) Unlock(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Share link unlock requested from IP: %s", r.RemoteAddr)
+
+	var req struct {
+		Password string `json:"password"`
+	}
+	if err := ValidateJSONBody(r, &req); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in share link unlock request", err)
+		return
+	}
+
+	shared, ok := h.openShared(w, r, req.Password)
+	if !ok {
+		return
+	}
+
+	SendSuccessResponse(w, "Share link unlocked", map[string]any{"expires_at": shared.AccessExpires},
+		"Share link "+shared.LinkID.String()+" unlocked")
+}
+
+// openShared resolves the link in the path and refreshes the access cookie when a password
+// was checked. It writes the error response itself.
+func (h *ShareLinkHandler) openShared(w http.ResponseWriter, r *http.Request, password string) (*models.SharedFile, bool) {
+	token := r.PathValue("token")
+	access := ""
+	if cookie, err := r.Cookie(shareAccessCookie); err == nil {
+		access = cookie.Value
+	}
+	address, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		address = r.RemoteAddr
+	}
+
+	shared, err := h.Service.OpenSharedContent(r.Context(), token, password, access, address)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrShareLinkNotFound):
+			SendErrorResponse(w, "Share link not found", http.StatusNotFound,
+				"Unknown share link token used", nil)
+		case errors.Is(err, services.ErrShareLinkExpired):
+			SendErrorResponse(w, err.Error(), http.StatusGone,
+				"Expired or revoked share link used", nil)
+		case errors.Is(err, services.ErrSharePasswordRequired):
+			SendErrorResponse(w, "A valid password is required for this link", http.StatusUnauthorized,
+				"Share link opened without the right password", nil)
+		case errors.Is(err, services.ErrShareTooManyAttempts):
+			SendErrorResponse(w, err.Error(), http.StatusTooManyRequests,
+				"Too many wrong share link passwords from "+address, nil)
+		case errors.Is(err, services.ErrUnsafeContentPath):
+			SendErrorResponse(w, "Shared file is not available", http.StatusNotFound,
+				"Share link points outside the courses directory", err)
+		default:
+			SendErrorResponse(w, "Failed to open shared content", http.StatusInternalServerError,
+				"Error opening shared content", err)
+		}
+		return nil, false
+	}
+
+	if shared.Access != "" {
+		http.SetCookie(w, &http.Cookie{
+			Name:     shareAccessCookie,
+			Value:    shared.Access,
+			Path:     "/api/share/" + token, // only good for this link
+			Expires:  shared.AccessExpires,
+			HttpOnly: true,
+			Secure:   r.TLS != nil,
+			SameSite: http.SameSiteLaxMode,
+		})
+	}
+	return shared, true
+}
+
+// authorizeContentOwner parses the content ID and checks the caller owns its course or is an admin
+func (h *ShareLinkHandler) authorizeContentOwner(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in share link request", nil)
+		return uuid.Nil, false
+	}
+
+	itemID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid content ID format", http.StatusBadRequest,
+			"Invalid content UUID in share link request", err)
+		return uuid.Nil, false
+	}
+
+	ownerID, err := h.Service.ContentOwner(r.Context(), itemID)
+	if err != nil {
+		sendShareLinkError(w, err)
+		return uuid.Nil, false
+	}
+
+	if !requireSelfOrAdmin(w, r, h.Profiles, ownerID) {
+		return uuid.Nil, false
+	}
+	return itemID, true
+}
+
+// sendShareLinkError maps share link management errors to responses
+func sendShareLinkError(w http.ResponseWriter, err error) {
+	if errors.Is(err, sql.ErrNoRows) {
+		SendErrorResponse(w, "Not found", http.StatusNotFound,
+			"Share link request for something that does not exist", err)
+		return
+	}
+	SendErrorResponse(w, "Failed to process share link", http.StatusInternalServerError,
+		"Error processing share link", err)
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/google/uuid"
+)
+
+// SnapshotHandler processes library snapshot and rollback requests - all admin only
+type SnapshotHandler struct {
+	Service  *services.SnapshotService // snapshot logic
+	Profiles *services.ProfileService  // needed for admin checks
+}
+
+// NewSnapshotHandler creates handler with injected services
+func NewSnapshotHandler(service *services.SnapshotService, profiles *services.ProfileService) *SnapshotHandler {
+	return &SnapshotHandler{Service: service, Profiles: profiles}
+}
+
+// List handles GET /api/admin/snapshots - all snapshots, newest first
+func (h *SnapshotHandler) List(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Snapshot list requested from IP: %s", r.RemoteAddr)
+
+	if _, ok := requireAdmin(w, r, h.Profiles); !ok {
+		return
+	}
+
+	snapshots, err := h.Service.ListSnapshots(r.Context())
+	if err != nil {
+		SendErrorResponse(w, "Failed to retrieve snapshots", http.StatusInternalServerError,
+			"Error retrieving snapshots", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Snapshots retrieved", snapshots,
+		"Returned "+strconv.Itoa(len(snapshots))+" snapshots")
+}
+
+// Create handles POST /api/admin/snapshots - saves the current library metadata
+func (h *SnapshotHandler) Create(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Snapshot creation requested from IP: %s", r.RemoteAddr)
+
+	actorID, ok := requireAdmin(w, r, h.Profiles)
+	if !ok {
+		return
+	}
+
+	// body is optional, it only carries a label
+	var input models.CreateSnapshotInput
+	if r.ContentLength != 0 {
+		if err := ValidateJSONBody(r, &input); err != nil {
+			SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+				"Invalid JSON in snapshot request", err)
+			return
+		}
+	}
+
+	snapshot, err := h.Service.CreateSnapshot(r.Context(), input.Label, actorID)
+	if err != nil {
+		SendErrorResponse(w, "Failed to create snapshot", http.StatusInternalServerError,
+			"Error creating library snapshot", err)
+		return
+	}
+
+	SendCreatedResponse(w, "Snapshot created", snapshot,
+		"Library snapshot "+snapshot.ID.String()+" created with "+strconv.Itoa(snapshot.CourseCount)+" courses")
+}
+
+// Rollback handles POST /api/admin/snapshots/{id}/rollback?prune=true - restores a snapshot
+func (h *SnapshotHandler) Rollback(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Snapshot rollback requested from IP: %s", r.RemoteAddr)
+
+	if _, ok := requireAdmin(w, r, h.Profiles); !ok {
+		return
+	}
+
+	snapshotID, ok := snapshotIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	prune := false
+	if pruneStr := r.URL.Query().Get("prune"); pruneStr != "" {
+		parsed, err := strconv.ParseBool(pruneStr)
+		if err != nil {
+			SendErrorResponse(w, "Invalid prune value", http.StatusBadRequest,
+				"Invalid prune in snapshot rollback: "+pruneStr, err)
+			return
+		}
+		prune = parsed
+	}
+
+	result, err := h.Service.Rollback(r.Context(), snapshotID, prune)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			SendErrorResponse(w, "Snapshot not found", http.StatusNotFound,
+				"Rollback requested for unknown snapshot "+snapshotID.String(), err)
+			return
+		}
+		SendErrorResponse(w, "Failed to roll back: "+err.Error(), http.StatusInternalServerError,
+			"Error rolling back to snapshot", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Library rolled back", result,
+		"Library rolled back to snapshot "+snapshotID.String())
+}
+
+// Delete handles DELETE /api/admin/snapshots/{id}
+func (h *SnapshotHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Snapshot deletion requested from IP: %s", r.RemoteAddr)
+
+	if _, ok := requireAdmin(w, r, h.Profiles); !ok {
+		return
+	}
+
+	snapshotID, ok := snapshotIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.Service.DeleteSnapshot(r.Context(), snapshotID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			SendErrorResponse(w, "Snapshot not found", http.StatusNotFound,
+				"Delete requested for unknown snapshot "+snapshotID.String(), err)
+			return
+		}
+		SendErrorResponse(w, "Failed to delete snapshot", http.StatusInternalServerError,
+			"Error deleting snapshot", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Snapshot deleted", nil,
+		"Library snapshot "+snapshotID.String()+" deleted")
+}
+
+// snapshotIDFromPath pulls the snapshot ID out of /api/admin/snapshots/{id}/...
+func snapshotIDFromPath(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 5 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in snapshot request", nil)
+		return uuid.Nil, false
+	}
+
+	snapshotID, err := uuid.Parse(pathParts[4])
+	if err != nil {
+		SendErrorResponse(w, "Invalid snapshot ID format", http.StatusBadRequest,
+			"Invalid snapshot UUID in request", err)
+		return uuid.Nil, false
+	}
+	return snapshotID, true
+}
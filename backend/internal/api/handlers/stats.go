@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/google/uuid"
+)
+
+// StatsHandler serves aggregated learning statistics for charts
+type StatsHandler struct {
+	Service  *services.StatsService   // aggregates progress and watch time
+	Profiles *services.ProfileService // admin checks
+}
+
+// NewStatsHandler creates handler with injected services
+func NewStatsHandler(service *services.StatsService, profiles *services.ProfileService) *StatsHandler {
+	return &StatsHandler{Service: service, Profiles: profiles}
+}
+
+// Get handles GET /api/users/{id}/stats?period=week|month - items completed, minutes spent
+// and courses touched per day over the last week, or per week over the last month
+func (h *StatsHandler) Get(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Learning stats requested from IP: %s", r.RemoteAddr)
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in stats request", nil)
+		return
+	}
+
+	profileID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid user ID format", http.StatusBadRequest,
+			"Invalid user UUID in stats request", err)
+		return
+	}
+
+	if !requireSelfOrAdmin(w, r, h.Profiles, profileID) {
+		return
+	}
+
+	period := r.URL.Query().Get("period")
+	if period == "" {
+		period = services.StatsPeriodWeek
+	}
+
+	stats, err := h.Service.GetStats(r.Context(), profileID, period)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidStatsPeriod) {
+			SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+				"Invalid stats period: "+period, nil)
+			return
+		}
+		SendErrorResponse(w, "Failed to calculate stats", http.StatusInternalServerError,
+			"Error calculating stats of profile "+profileID.String(), err)
+		return
+	}
+
+	SendSuccessResponse(w, "Stats calculated", stats,
+		"Learning stats for the last "+period+" returned for profile "+profileID.String())
+}
+
+// Heatmap handles GET /api/users/{id}/heatmap?year= - activity per day of a year for a
+// contribution-style calendar, the current year by default
+func (h *StatsHandler) Heatmap(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Activity heatmap requested from IP: %s", r.RemoteAddr)
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in heatmap request", nil)
+		return
+	}
+
+	profileID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid user ID format", http.StatusBadRequest,
+			"Invalid user UUID in heatmap request", err)
+		return
+	}
+
+	if !requireSelfOrAdmin(w, r, h.Profiles, profileID) {
+		return
+	}
+
+	year := time.Now().In(h.Service.Location).Year()
+	if yearStr := r.URL.Query().Get("year"); yearStr != "" {
+		year, err = strconv.Atoi(yearStr)
+		if err != nil {
+			SendErrorResponse(w, "year must be a number", http.StatusBadRequest,
+				"Invalid year in heatmap request: "+yearStr, err)
+			return
+		}
+	}
+
+	heatmap, err := h.Service.GetHeatmap(r.Context(), profileID, year)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidHeatmapYear) {
+			SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+				"Heatmap year out of range: "+strconv.Itoa(year), nil)
+			return
+		}
+		SendErrorResponse(w, "Failed to build heatmap", http.StatusInternalServerError,
+			"Error building heatmap of profile "+profileID.String(), err)
+		return
+	}
+
+	SendSuccessResponse(w, "Heatmap retrieved", heatmap,
+		"Heatmap for "+strconv.Itoa(year)+" returned for profile "+profileID.String())
+}
+
+// Leaderboard handles GET /api/leaderboard?period=week|month|all&metric=xp|minutes&limit=20&offset=0 -
+// every profile ranked by XP earned or minutes watched over the period
+func (h *StatsHandler) Leaderboard(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Leaderboard requested from IP: %s", r.RemoteAddr)
+
+	query := r.URL.Query()
+	period := query.Get("period")
+	if period == "" {
+		period = services.LeaderboardWeek
+	}
+	metric := query.Get("metric")
+	if metric == "" {
+		metric = services.LeaderboardXP
+	}
+
+	limit, offset := services.DefaultLeaderboardLimit, 0
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 || parsed > services.MaxLeaderboardLimit {
+			SendErrorResponse(w, "limit must be between 1 and "+strconv.Itoa(services.MaxLeaderboardLimit), http.StatusBadRequest,
+				"Invalid limit in leaderboard request: "+limitStr, err)
+			return
+		}
+		limit = parsed
+	}
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		parsed, err := strconv.Atoi(offsetStr)
+		if err != nil || parsed < 0 {
+			SendErrorResponse(w, "offset must be zero or more", http.StatusBadRequest,
+				"Invalid offset in leaderboard request: "+offsetStr, err)
+			return
+		}
+		offset = parsed
+	}
+
+	board, err := h.Service.GetLeaderboard(r.Context(), period, metric, limit, offset)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidLeaderboardPeriod) || errors.Is(err, services.ErrInvalidLeaderboardMetric) {
+			SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+				"Invalid leaderboard request: period "+period+", metric "+metric, nil)
+			return
+		}
+		SendErrorResponse(w, "Failed to retrieve leaderboard", http.StatusInternalServerError,
+			"Error retrieving leaderboard", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Leaderboard retrieved", board,
+		"Listed "+strconv.Itoa(len(board.Entries))+" leaderboard entries by "+metric+" for "+period)
+}
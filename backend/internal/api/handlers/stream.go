@@ -0,0 +1,306 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/NeroQue/course-management-backend/pkg/chapters"
+	"github.com/NeroQue/course-management-backend/pkg/session"
+	"github.com/google/uuid"
+)
+
+// StreamHandler serves content files to the player
+type StreamHandler struct {
+	Service *services.StreamService // resolves and checks content files
+}
+
+// NewStreamHandler creates handler with injected service
+func NewStreamHandler(service *services.StreamService) *StreamHandler {
+	return &StreamHandler{Service: service}
+}
+
+// Stream handles GET /api/content/{id}/stream?variant= - serves the file with Range support so
+// the browser player can seek. variant picks another resolution/language by label or ID.
+func (h *StreamHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Content stream requested from IP: %s", r.RemoteAddr)
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in stream request", nil)
+		return
+	}
+
+	itemID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid content ID format", http.StatusBadRequest,
+			"Invalid content UUID in stream request", err)
+		return
+	}
+
+	// players fetch in ranges - only the request that starts from the top counts as an access
+	rangeHeader := r.Header.Get("Range")
+	startsPlayback := r.Method == http.MethodGet && (rangeHeader == "" || strings.HasPrefix(rangeHeader, "bytes=0-"))
+
+	userID := session.GetCurrentUser(r.Context())
+	stream, err := h.Service.OpenContent(r.Context(), itemID, userID, r.URL.Query().Get("variant"), startsPlayback)
+	if err != nil {
+		sendStreamError(w, err, itemID, userID)
+		return
+	}
+
+	serveContentFile(w, r, stream, "inline")
+}
+
+// Download handles GET /api/content/{id}/download - the original file as an attachment.
+// Range and If-Range work the same as for streaming, so an interrupted download of a
+// large video or PDF can resume where it stopped instead of starting over. Like streaming
+// it needs a profile that can see the course, offline URLs are for downloading without one.
+func (h *StreamHandler) Download(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Content download requested from IP: %s", r.RemoteAddr)
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in download request", nil)
+		return
+	}
+
+	itemID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid content ID format", http.StatusBadRequest,
+			"Invalid content UUID in download request", err)
+		return
+	}
+
+	// a resumed download asks for the rest of the file, only the first request counts
+	startsDownload := r.Method == http.MethodGet && r.Header.Get("Range") == ""
+
+	userID := session.GetCurrentUser(r.Context())
+	stream, err := h.Service.OpenContent(r.Context(), itemID, userID, "", startsDownload)
+	if err != nil {
+		sendStreamError(w, err, itemID, userID)
+		return
+	}
+
+	serveContentFile(w, r, stream, "attachment")
+}
+
+// mediaTypes covers audio formats the system MIME table often doesn't know -
+// sniffing an m4b gives video/mp4 or nothing, and audio players need the right type
+var mediaTypes = map[string]string{
+	".m4a":  "audio/mp4",
+	".m4b":  "audio/mp4",
+	".aac":  "audio/aac",
+	".oga":  "audio/ogg",
+	".opus": "audio/ogg",
+	".flac": "audio/flac",
+}
+
+// serveContentFile writes a content file with Range support. disposition is "inline" for
+// the player or "attachment" to make the browser save it. The ETag changes whenever the
+// file does, so If-Range only resumes against the same file.
+func serveContentFile(w http.ResponseWriter, r *http.Request, stream *models.StreamFile, disposition string) {
+	file, err := os.Open(stream.Path)
+	if err != nil {
+		SendErrorResponse(w, "Content file is not available", http.StatusNotFound,
+			"Content file missing on disk for "+stream.ContentItemID.String(), err)
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil || info.IsDir() {
+		SendErrorResponse(w, "Content file is not available", http.StatusNotFound,
+			"Error reading content file for "+stream.ContentItemID.String(), err)
+		return
+	}
+
+	ext := strings.ToLower(filepath.Ext(stream.Filename))
+	if mime.TypeByExtension(ext) == "" && mediaTypes[ext] != "" {
+		w.Header().Set("Content-Type", mediaTypes[ext])
+	}
+	w.Header().Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": stream.Filename}))
+	w.Header().Set("ETag", "\""+strconv.FormatInt(info.Size(), 36)+"-"+strconv.FormatInt(info.ModTime().UnixNano(), 36)+"\"")
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, stream.Filename, info.ModTime(), file)
+}
+
+// Rendered handles GET /api/content/{id}/rendered - a text item as sanitized HTML, with its
+// headings and code blocks listed so the frontend doesn't have to fetch and parse the raw file
+func (h *StreamHandler) Rendered(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Rendered content requested from IP: %s", r.RemoteAddr)
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in rendered content request", nil)
+		return
+	}
+
+	itemID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid content ID format", http.StatusBadRequest,
+			"Invalid content UUID in rendered content request", err)
+		return
+	}
+
+	userID := session.GetCurrentUser(r.Context())
+	rendered, err := h.Service.RenderText(r.Context(), itemID, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNotTextContent):
+			SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+				"Rendering requested for non-text content "+itemID.String(), err)
+		case errors.Is(err, services.ErrTextTooLarge):
+			SendErrorResponse(w, err.Error(), http.StatusRequestEntityTooLarge,
+				"Text content "+itemID.String()+" too large to render", err)
+		default:
+			sendStreamError(w, err, itemID, userID)
+		}
+		return
+	}
+
+	SendSuccessResponse(w, "Content rendered", rendered,
+		"Rendered "+rendered.Format+" content "+itemID.String())
+}
+
+// Chapters handles GET /api/content/{id}/chapters - the chapter markers of an audio or video
+// item, e.g. an m4b audiobook, with the profile's progress through each chapter
+func (h *StreamHandler) Chapters(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Content chapters requested from IP: %s", r.RemoteAddr)
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in chapters request", nil)
+		return
+	}
+
+	itemID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid content ID format", http.StatusBadRequest,
+			"Invalid content UUID in chapters request", err)
+		return
+	}
+
+	userID := session.GetCurrentUser(r.Context())
+	result, err := h.Service.ListChapters(r.Context(), itemID, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNotMediaContent):
+			SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+				"Chapters requested for non-media content "+itemID.String(), err)
+		case errors.Is(err, chapters.ErrToolMissing):
+			SendErrorResponse(w, "Chapters can't be read: "+err.Error(), http.StatusServiceUnavailable,
+				"Chapter tool missing for content "+itemID.String(), err)
+		default:
+			sendStreamError(w, err, itemID, userID)
+		}
+		return
+	}
+
+	SendSuccessResponse(w, "Chapters retrieved", result,
+		"Listed "+strconv.Itoa(len(result.Chapters))+" chapters for content "+itemID.String())
+}
+
+// sendStreamError maps errors from opening content for playback to responses
+func sendStreamError(w http.ResponseWriter, err error, itemID, userID uuid.UUID) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows), errors.Is(err, services.ErrVariantNotFound):
+		SendErrorResponse(w, err.Error(), http.StatusNotFound,
+			"Missing content "+itemID.String()+" requested", err)
+	case errors.Is(err, services.ErrContentNotVisible):
+		// hidden courses look the same as missing ones
+		SendErrorResponse(w, "Content item not found", http.StatusNotFound,
+			"Content "+itemID.String()+" hidden from profile "+userID.String(), nil)
+	case errors.Is(err, services.ErrPlaybackNotAllowed):
+		SendErrorResponse(w, err.Error(), http.StatusForbidden,
+			"Playback refused for profile "+userID.String()+" over time limit", nil)
+	case errors.Is(err, services.ErrUnsafeContentPath):
+		SendErrorResponse(w, "Content file can't be served", http.StatusForbidden,
+			"Refused to serve content "+itemID.String()+" outside the courses directory", err)
+	case errors.Is(err, services.ErrTieringDisabled):
+		SendErrorResponse(w, "Content file is in cold storage and can't be restored", http.StatusServiceUnavailable,
+			"Cold content "+itemID.String()+" requested", err)
+	default:
+		SendErrorResponse(w, "Failed to open content", http.StatusInternalServerError,
+			"Error opening content "+itemID.String(), err)
+	}
+}
+
+// Subtitles handles GET /api/content/{id}/subtitles - the caption tracks that go with a video
+func (h *StreamHandler) Subtitles(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Subtitle tracks requested from IP: %s", r.RemoteAddr)
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in subtitle list request", nil)
+		return
+	}
+
+	itemID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid content ID format", http.StatusBadRequest,
+			"Invalid content UUID in subtitle list request", err)
+		return
+	}
+
+	userID := session.GetCurrentUser(r.Context())
+	tracks, err := h.Service.ListSubtitles(r.Context(), itemID, userID)
+	if err != nil {
+		sendStreamError(w, err, itemID, userID)
+		return
+	}
+
+	SendSuccessResponse(w, "Subtitle tracks retrieved", tracks,
+		"Listed "+strconv.Itoa(len(tracks))+" subtitle tracks for content "+itemID.String())
+}
+
+// Subtitle handles GET /api/content/{id}/subtitles/{track} - one caption track as WebVTT,
+// converted from SRT when that's what is on disk, ready for a <track> element
+func (h *StreamHandler) Subtitle(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Subtitle track requested from IP: %s", r.RemoteAddr)
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in subtitle request", nil)
+		return
+	}
+
+	itemID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid content ID format", http.StatusBadRequest,
+			"Invalid content UUID in subtitle request", err)
+		return
+	}
+
+	userID := session.GetCurrentUser(r.Context())
+	vtt, err := h.Service.OpenSubtitle(r.Context(), itemID, userID, r.PathValue("track"))
+	if err != nil {
+		if errors.Is(err, services.ErrSubtitleNotFound) {
+			SendErrorResponse(w, err.Error(), http.StatusNotFound,
+				"Unknown subtitle track requested for content "+itemID.String(), err)
+			return
+		}
+		sendStreamError(w, err, itemID, userID)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/vtt; charset=utf-8")
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Write(vtt)
+}
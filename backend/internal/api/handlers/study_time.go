@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/NeroQue/course-management-backend/pkg/session"
+	"github.com/google/uuid"
+)
+
+// StudyTimeHandler processes study time estimate and pacing requests
+type StudyTimeHandler struct {
+	Service *services.StudyTimeService // estimates and pacing
+}
+
+// NewStudyTimeHandler creates handler with injected service
+func NewStudyTimeHandler(service *services.StudyTimeService) *StudyTimeHandler {
+	return &StudyTimeHandler{Service: service}
+}
+
+// GetEstimate handles GET /api/courses/{id}/study-time?user_id={uuid} - estimated study time per module
+func (h *StudyTimeHandler) GetEstimate(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Study time estimate requested from IP: %s", r.RemoteAddr)
+
+	courseID, userID, ok := studyTimeRequestContext(w, r)
+	if !ok {
+		return
+	}
+
+	estimate, err := h.Service.EstimateCourse(r.Context(), courseID, userID)
+	if err != nil {
+		sendStudyTimeError(w, courseID, err)
+		return
+	}
+
+	SendSuccessResponse(w, "Study time estimated", estimate,
+		"Study time estimated for course "+courseID.String())
+}
+
+// GetPacing handles GET /api/courses/{id}/pacing?hours_per_week=5&start=2026-01-05&user_id={uuid}
+func (h *StudyTimeHandler) GetPacing(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Course pacing requested from IP: %s", r.RemoteAddr)
+
+	courseID, userID, ok := studyTimeRequestContext(w, r)
+	if !ok {
+		return
+	}
+
+	hoursPerWeek, err := strconv.ParseFloat(r.URL.Query().Get("hours_per_week"), 64)
+	if err != nil {
+		SendErrorResponse(w, "hours_per_week query parameter must be a number", http.StatusBadRequest,
+			"Invalid hours_per_week in pacing request", err)
+		return
+	}
+
+	start := time.Now()
+	if startStr := r.URL.Query().Get("start"); startStr != "" {
+		start, err = time.Parse("2006-01-02", startStr)
+		if err != nil {
+			SendErrorResponse(w, "start must be a date like 2026-01-05", http.StatusBadRequest,
+				"Invalid start date in pacing request", err)
+			return
+		}
+	}
+
+	pacing, err := h.Service.Pacing(r.Context(), courseID, userID, hoursPerWeek, start)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidPace) {
+			SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+				"Invalid pace in pacing request", err)
+			return
+		}
+		sendStudyTimeError(w, courseID, err)
+		return
+	}
+
+	SendSuccessResponse(w, pacing.Summary, pacing,
+		"Pacing calculated for course "+courseID.String())
+}
+
+// studyTimeRequestContext gets the course ID and whose progress to use - ?user_id, else the current profile
+func studyTimeRequestContext(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in study time request", nil)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	courseID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid course ID format", http.StatusBadRequest,
+			"Invalid course UUID in study time request", err)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	userID := session.GetCurrentUser(r.Context())
+	if userIDStr := r.URL.Query().Get("user_id"); userIDStr != "" {
+		userID, err = uuid.Parse(userIDStr)
+		if err != nil {
+			SendErrorResponse(w, "Invalid user ID format", http.StatusBadRequest,
+				"Invalid user UUID in study time request", err)
+			return uuid.Nil, uuid.Nil, false
+		}
+	}
+
+	return courseID, userID, true
+}
+
+// sendStudyTimeError maps estimate errors to responses
+func sendStudyTimeError(w http.ResponseWriter, courseID uuid.UUID, err error) {
+	if errors.Is(err, sql.ErrNoRows) {
+		SendErrorResponse(w, "Course not found", http.StatusNotFound,
+			"Study time requested for non-existent course "+courseID.String(), err)
+		return
+	}
+	SendErrorResponse(w, "Failed to estimate study time", http.StatusInternalServerError,
+		"Error estimating study time", err)
+}
@@ -1,8 +1,10 @@
 package handlers
 
 import (
+	"errors"
 	"log"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/NeroQue/course-management-backend/pkg/task"
@@ -16,7 +18,7 @@ func NewTaskHandler() *TaskHandler {
 	return &TaskHandler{}
 }
 
-// GetTask handles GET /api/tasks?id={taskId} - checks task status
+// GetTask handles GET /api/tasks?id={taskId} - checks task status, with the latest lines of its log
 func (h *TaskHandler) GetTask(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Task status requested from IP: %s", r.RemoteAddr)
 
@@ -42,6 +44,32 @@ func (h *TaskHandler) GetTask(w http.ResponseWriter, r *http.Request) {
 		"Task status retrieved for: "+taskID)
 }
 
+// RetryTask handles POST /api/tasks/{id}/retry - runs a failed task again with what it was
+// started with, up to TASK_MAX_RETRIES times
+func (h *TaskHandler) RetryTask(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Task retry requested from IP: %s", r.RemoteAddr)
+
+	taskID := r.PathValue("id")
+	t, err := task.Retry(taskID)
+	if err != nil {
+		switch {
+		case errors.Is(err, task.ErrTaskNotFound):
+			SendErrorResponse(w, "Task not found", http.StatusNotFound,
+				"Retry requested for missing task: "+taskID, nil)
+		case errors.Is(err, task.ErrNotRetryable), errors.Is(err, task.ErrRetryLimit):
+			SendErrorResponse(w, err.Error(), http.StatusConflict,
+				"Task "+taskID+" can't be retried", err)
+		default:
+			SendErrorResponse(w, "Failed to retry task", http.StatusInternalServerError,
+				"Error retrying task "+taskID, err)
+		}
+		return
+	}
+
+	SendSuccessResponse(w, "Task queued again", t,
+		"Task "+taskID+" ("+t.Type+") queued for retry "+strconv.Itoa(t.Retries))
+}
+
 // CleanupTasks handles POST /api/tasks/cleanup - manually cleans old tasks
 func (h *TaskHandler) CleanupTasks(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Task cleanup requested from IP: %s", r.RemoteAddr)
@@ -0,0 +1,332 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/NeroQue/course-management-backend/pkg/pdf"
+	"github.com/NeroQue/course-management-backend/pkg/session"
+	"github.com/NeroQue/course-management-backend/pkg/thumbnail"
+	"github.com/google/uuid"
+)
+
+// ThumbnailHandler serves preview images of content items
+type ThumbnailHandler struct {
+	Service *services.ThumbnailService // generates and caches thumbnails
+}
+
+// NewThumbnailHandler creates handler with injected service
+func NewThumbnailHandler(service *services.ThumbnailService) *ThumbnailHandler {
+	return &ThumbnailHandler{Service: service}
+}
+
+// Get handles GET /api/content/{id}/thumbnail - a JPEG preview of a video, PDF or image,
+// generated on first request and cached after that
+func (h *ThumbnailHandler) Get(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Content thumbnail requested from IP: %s", r.RemoteAddr)
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in thumbnail request", nil)
+		return
+	}
+
+	itemID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid content ID format", http.StatusBadRequest,
+			"Invalid content UUID in thumbnail request", err)
+		return
+	}
+
+	userID := session.GetCurrentUser(r.Context())
+	path, checksum, err := h.Service.GetThumbnail(r.Context(), itemID, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			SendErrorResponse(w, "Content item not found", http.StatusNotFound,
+				"Thumbnail requested for missing content "+itemID.String(), err)
+		case errors.Is(err, services.ErrContentNotVisible):
+			// hidden courses look the same as missing ones
+			SendErrorResponse(w, "Content item not found", http.StatusNotFound,
+				"Content "+itemID.String()+" hidden from profile "+userID.String(), nil)
+		case errors.Is(err, thumbnail.ErrUnsupported), errors.Is(err, services.ErrThumbnailUnavailable):
+			SendErrorResponse(w, "No thumbnail available: "+err.Error(), http.StatusNotFound,
+				"No thumbnail for content "+itemID.String(), err)
+		case errors.Is(err, thumbnail.ErrToolMissing):
+			SendErrorResponse(w, "Thumbnails can't be generated: "+err.Error(), http.StatusServiceUnavailable,
+				"Thumbnail tool missing for content "+itemID.String(), err)
+		case errors.Is(err, services.ErrUnsafeContentPath):
+			SendErrorResponse(w, "Content file can't be read", http.StatusForbidden,
+				"Refused thumbnail for content "+itemID.String()+" outside the courses directory", err)
+		default:
+			SendErrorResponse(w, "Failed to generate thumbnail", http.StatusInternalServerError,
+				"Error generating thumbnail for content "+itemID.String(), err)
+		}
+		return
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		SendErrorResponse(w, "Thumbnail is not available", http.StatusNotFound,
+			"Thumbnail vanished for content "+itemID.String(), err)
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		SendErrorResponse(w, "Thumbnail is not available", http.StatusInternalServerError,
+			"Error reading thumbnail for content "+itemID.String(), err)
+		return
+	}
+
+	// the checksum changes with the file, so browsers can keep these for a while
+	w.Header().Set("ETag", "\""+checksum+"\"")
+	w.Header().Set("Cache-Control", "private, max-age=86400")
+	http.ServeContent(w, r, "thumbnail.jpg", info.ModTime(), file)
+}
+
+// Sprite handles GET /api/content/{id}/sprites/{file} - timeline previews for scrubbing a video.
+// index.vtt maps times to frames on the sheet-NNN.jpg files, in the format players use for
+// thumbnail tracks. Until the sheets exist this answers 202 with the task generating them.
+func (h *ThumbnailHandler) Sprite(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Content sprite requested from IP: %s", r.RemoteAddr)
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in sprite request", nil)
+		return
+	}
+
+	itemID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid content ID format", http.StatusBadRequest,
+			"Invalid content UUID in sprite request", err)
+		return
+	}
+
+	userID := session.GetCurrentUser(r.Context())
+	name := r.PathValue("file")
+	path, taskID, err := h.Service.GetSpriteFile(r.Context(), itemID, userID, name)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrSpritesPending):
+			w.Header().Set("Retry-After", "30")
+			SendAcceptedResponse(w, err.Error(), map[string]string{"task_id": taskID},
+				"Sprite generation task "+taskID+" running for content "+itemID.String())
+		case errors.Is(err, services.ErrSpriteNotFound):
+			SendErrorResponse(w, err.Error(), http.StatusNotFound,
+				"Unknown sprite file requested for content "+itemID.String(), err)
+		case errors.Is(err, sql.ErrNoRows):
+			SendErrorResponse(w, "Content item not found", http.StatusNotFound,
+				"Sprites requested for missing content "+itemID.String(), err)
+		case errors.Is(err, services.ErrContentNotVisible):
+			// hidden courses look the same as missing ones
+			SendErrorResponse(w, "Content item not found", http.StatusNotFound,
+				"Content "+itemID.String()+" hidden from profile "+userID.String(), nil)
+		case errors.Is(err, thumbnail.ErrUnsupported), errors.Is(err, services.ErrThumbnailUnavailable):
+			SendErrorResponse(w, "No preview sprites available: "+err.Error(), http.StatusNotFound,
+				"No sprites for content "+itemID.String(), err)
+		case errors.Is(err, thumbnail.ErrToolMissing):
+			SendErrorResponse(w, "Preview sprites can't be generated: "+err.Error(), http.StatusServiceUnavailable,
+				"Sprite tool missing for content "+itemID.String(), err)
+		case errors.Is(err, services.ErrUnsafeContentPath):
+			SendErrorResponse(w, "Content file can't be read", http.StatusForbidden,
+				"Refused sprites for content "+itemID.String()+" outside the courses directory", err)
+		default:
+			SendErrorResponse(w, "Failed to get preview sprites", http.StatusInternalServerError,
+				"Error getting sprites for content "+itemID.String(), err)
+		}
+		return
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		SendErrorResponse(w, "Sprite file is not available", http.StatusNotFound,
+			"Sprite file vanished for content "+itemID.String(), err)
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		SendErrorResponse(w, "Sprite file is not available", http.StatusInternalServerError,
+			"Error reading sprite file for content "+itemID.String(), err)
+		return
+	}
+
+	if strings.HasSuffix(name, ".vtt") {
+		w.Header().Set("Content-Type", "text/vtt; charset=utf-8")
+	}
+	// the folder is keyed by the video's checksum, a new video means new sprites
+	w.Header().Set("Cache-Control", "private, max-age=86400")
+	http.ServeContent(w, r, name, info.ModTime(), file)
+}
+
+// Page handles GET /api/content/{id}/pages/{n} - one page of a PDF as a JPEG for the built-in
+// reader, rendered on first request and cached after that. Pages count from 1.
+func (h *ThumbnailHandler) Page(w http.ResponseWriter, r *http.Request) {
+	log.Printf("PDF page requested from IP: %s", r.RemoteAddr)
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in page request", nil)
+		return
+	}
+
+	itemID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid content ID format", http.StatusBadRequest,
+			"Invalid content UUID in page request", err)
+		return
+	}
+
+	page, err := strconv.Atoi(r.PathValue("n"))
+	if err != nil {
+		SendErrorResponse(w, "Page must be a number", http.StatusBadRequest,
+			"Invalid page number in page request", err)
+		return
+	}
+
+	userID := session.GetCurrentUser(r.Context())
+	path, checksum, err := h.Service.GetPage(r.Context(), itemID, userID, page)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrPageNotFound):
+			SendErrorResponse(w, err.Error(), http.StatusNotFound,
+				"Missing page requested for content "+itemID.String(), err)
+		case errors.Is(err, sql.ErrNoRows):
+			SendErrorResponse(w, "Content item not found", http.StatusNotFound,
+				"Page requested for missing content "+itemID.String(), err)
+		case errors.Is(err, services.ErrContentNotVisible):
+			// hidden courses look the same as missing ones
+			SendErrorResponse(w, "Content item not found", http.StatusNotFound,
+				"Content "+itemID.String()+" hidden from profile "+userID.String(), nil)
+		case errors.Is(err, thumbnail.ErrUnsupported), errors.Is(err, services.ErrThumbnailUnavailable):
+			SendErrorResponse(w, "No pages available: "+err.Error(), http.StatusNotFound,
+				"No pages for content "+itemID.String(), err)
+		case errors.Is(err, pdf.ErrToolMissing):
+			SendErrorResponse(w, "Pages can't be rendered: "+err.Error(), http.StatusServiceUnavailable,
+				"PDF tool missing for content "+itemID.String(), err)
+		case errors.Is(err, services.ErrUnsafeContentPath):
+			SendErrorResponse(w, "Content file can't be read", http.StatusForbidden,
+				"Refused page of content "+itemID.String()+" outside the courses directory", err)
+		default:
+			SendErrorResponse(w, "Failed to render page", http.StatusInternalServerError,
+				"Error rendering page of content "+itemID.String(), err)
+		}
+		return
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		SendErrorResponse(w, "Page is not available", http.StatusNotFound,
+			"Page vanished for content "+itemID.String(), err)
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		SendErrorResponse(w, "Page is not available", http.StatusInternalServerError,
+			"Error reading page of content "+itemID.String(), err)
+		return
+	}
+
+	// the checksum changes with the file, so browsers can keep these for a while
+	w.Header().Set("ETag", "\""+checksum+"-"+strconv.Itoa(page)+"\"")
+	w.Header().Set("Cache-Control", "private, max-age=86400")
+	http.ServeContent(w, r, strconv.Itoa(page)+".jpg", info.ModTime(), file)
+}
+
+// Image handles GET /api/content/{id}/image?w=&h= - an image content item scaled down to fit
+// in w x h and recompressed, so pages full of screenshots don't load the originals. Sizes
+// snap up to a fixed set that gets cached; without either the image is 1280 wide.
+func (h *ThumbnailHandler) Image(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Resized image requested from IP: %s", r.RemoteAddr)
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in image request", nil)
+		return
+	}
+
+	itemID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid content ID format", http.StatusBadRequest,
+			"Invalid content UUID in image request", err)
+		return
+	}
+
+	var size [2]int
+	for i, param := range []string{"w", "h"} {
+		value := r.URL.Query().Get(param)
+		if value == "" {
+			continue
+		}
+		size[i], err = strconv.Atoi(value)
+		if err != nil || size[i] < 1 {
+			SendErrorResponse(w, param+" must be a positive number", http.StatusBadRequest,
+				"Invalid "+param+" in image request", err)
+			return
+		}
+	}
+
+	userID := session.GetCurrentUser(r.Context())
+	path, err := h.Service.GetImage(r.Context(), itemID, userID, size[0], size[1])
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			SendErrorResponse(w, "Content item not found", http.StatusNotFound,
+				"Image requested for missing content "+itemID.String(), err)
+		case errors.Is(err, services.ErrContentNotVisible):
+			// hidden courses look the same as missing ones
+			SendErrorResponse(w, "Content item not found", http.StatusNotFound,
+				"Content "+itemID.String()+" hidden from profile "+userID.String(), nil)
+		case errors.Is(err, thumbnail.ErrUnsupported):
+			SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+				"Resized image requested for non-image content "+itemID.String(), err)
+		case errors.Is(err, services.ErrThumbnailUnavailable):
+			SendErrorResponse(w, "No resized image available: "+err.Error(), http.StatusNotFound,
+				"No resized image for content "+itemID.String(), err)
+		case errors.Is(err, services.ErrUnsafeContentPath):
+			SendErrorResponse(w, "Content file can't be read", http.StatusForbidden,
+				"Refused image of content "+itemID.String()+" outside the courses directory", err)
+		default:
+			SendErrorResponse(w, "Failed to resize image", http.StatusInternalServerError,
+				"Error resizing image of content "+itemID.String(), err)
+		}
+		return
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		SendErrorResponse(w, "Image is not available", http.StatusNotFound,
+			"Resized image vanished for content "+itemID.String(), err)
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		SendErrorResponse(w, "Image is not available", http.StatusInternalServerError,
+			"Error reading resized image of content "+itemID.String(), err)
+		return
+	}
+
+	// the name holds the checksum and box size, so it makes a good ETag
+	w.Header().Set("ETag", "\""+strings.TrimSuffix(filepath.Base(path), ".jpg")+"\"")
+	w.Header().Set("Cache-Control", "private, max-age=86400")
+	http.ServeContent(w, r, "image.jpg", info.ModTime(), file)
+}
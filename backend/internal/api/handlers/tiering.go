@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/NeroQue/course-management-backend/internal/services"
+)
+
+// TieringHandler processes storage tiering requests - all admin only
+type TieringHandler struct {
+	Service  *services.TieringService // hot/cold storage
+	Profiles *services.ProfileService // needed for admin checks
+}
+
+// NewTieringHandler creates handler with injected services
+func NewTieringHandler(service *services.TieringService, profiles *services.ProfileService) *TieringHandler {
+	return &TieringHandler{Service: service, Profiles: profiles}
+}
+
+// GetStatus handles GET /api/admin/tiering - policy, usage per tier and what's in cold storage
+func (h *TieringHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Tiering status requested from IP: %s", r.RemoteAddr)
+
+	if _, ok := requireAdmin(w, r, h.Profiles); !ok {
+		return
+	}
+
+	status, err := h.Service.GetStatus(r.Context())
+	if err != nil {
+		SendErrorResponse(w, "Failed to get tiering status", http.StatusInternalServerError,
+			"Error building tiering status", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Tiering status retrieved", status,
+		"Tiering status returned to client")
+}
+
+// Run handles POST /api/admin/tiering/run - runs a policy pass now instead of waiting for the schedule
+func (h *TieringHandler) Run(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Tiering run requested from IP: %s", r.RemoteAddr)
+
+	if _, ok := requireAdmin(w, r, h.Profiles); !ok {
+		return
+	}
+
+	if !h.Service.Enabled() {
+		SendErrorResponse(w, "Storage tiering is not configured", http.StatusConflict,
+			"Tiering run requested without a cold backend", services.ErrTieringDisabled)
+		return
+	}
+
+	taskID := h.Service.StartPolicyTask()
+
+	responseData := map[string]string{"task_id": taskID}
+	SendSuccessResponse(w, "Tiering run started", responseData,
+		"Tiering task created with ID: "+taskID)
+}
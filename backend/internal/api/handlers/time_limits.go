@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/google/uuid"
+)
+
+// TimeLimitHandler processes viewing time limit requests
+type TimeLimitHandler struct {
+	Service  *services.TimeLimitService // limit and usage logic
+	Profiles *services.ProfileService   // needed for admin checks
+}
+
+// NewTimeLimitHandler creates handler with injected services
+func NewTimeLimitHandler(service *services.TimeLimitService, profiles *services.ProfileService) *TimeLimitHandler {
+	return &TimeLimitHandler{Service: service, Profiles: profiles}
+}
+
+// GetStatus handles GET /api/profiles/{id}/time-limits - shows limits and current usage, own profile or admin
+func (h *TimeLimitHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Time limit status requested from IP: %s", r.RemoteAddr)
+
+	profileID, ok := profileIDFromPath(w, r)
+	if !ok {
+		return
+	}
+	if !requireSelfOrAdmin(w, r, h.Profiles, profileID) {
+		return
+	}
+
+	status, err := h.Service.GetStatus(r.Context(), profileID)
+	if err != nil {
+		SendErrorResponse(w, "Failed to get time limit status", http.StatusInternalServerError,
+			"Error calculating time limit status", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Time limit status retrieved", status,
+		"Time limit status returned for profile "+profileID.String())
+}
+
+// SetLimits handles PUT /api/profiles/{id}/time-limits - admin only
+func (h *TimeLimitHandler) SetLimits(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Time limit update requested from IP: %s", r.RemoteAddr)
+
+	if _, ok := requireAdmin(w, r, h.Profiles); !ok {
+		return
+	}
+
+	profileID, ok := profileIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var input models.SetTimeLimitsInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in time limit update", err)
+		return
+	}
+
+	// make sure profile actually exists
+	if _, err := h.Profiles.GetProfileByID(r.Context(), profileID); err != nil {
+		SendErrorResponse(w, "Profile not found", http.StatusNotFound,
+			"Attempted to set time limits for non-existent profile", err)
+		return
+	}
+
+	limits, err := h.Service.SetLimits(r.Context(), profileID, input)
+	if err != nil {
+		SendErrorResponse(w, "Failed to set time limits: "+err.Error(), http.StatusBadRequest,
+			"Error setting time limits", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Time limits updated", limits,
+		"Time limits updated for profile "+profileID.String())
+}
+
+// ClearLimits handles DELETE /api/profiles/{id}/time-limits - admin only
+func (h *TimeLimitHandler) ClearLimits(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Time limit removal requested from IP: %s", r.RemoteAddr)
+
+	if _, ok := requireAdmin(w, r, h.Profiles); !ok {
+		return
+	}
+
+	profileID, ok := profileIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.Service.ClearLimits(r.Context(), profileID); err != nil {
+		SendErrorResponse(w, "Failed to remove time limits", http.StatusInternalServerError,
+			"Error removing time limits", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Time limits removed", nil,
+		"Time limits removed for profile "+profileID.String())
+}
+
+// profileIDFromPath pulls the profile ID out of paths like /api/profiles/{id}/...
+func profileIDFromPath(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in profile request", nil)
+		return uuid.Nil, false
+	}
+
+	profileID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid profile ID format", http.StatusBadRequest,
+			"Invalid profile UUID in request", err)
+		return uuid.Nil, false
+	}
+
+	return profileID, true
+}
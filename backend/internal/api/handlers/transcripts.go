@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/NeroQue/course-management-backend/pkg/session"
+	"github.com/google/uuid"
+)
+
+// TranscriptHandler starts transcriptions of audio and video items and serves the results
+type TranscriptHandler struct {
+	Service *services.TranscriptService // runs the configured transcription provider
+}
+
+// NewTranscriptHandler creates handler with injected service
+func NewTranscriptHandler(service *services.TranscriptService) *TranscriptHandler {
+	return &TranscriptHandler{Service: service}
+}
+
+// Start handles POST /api/content/{id}/transcript - transcribes the item in the background
+// and returns the task to poll
+func (h *TranscriptHandler) Start(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Content transcription requested from IP: %s", r.RemoteAddr)
+
+	userID := session.GetCurrentUser(r.Context())
+	if userID == uuid.Nil {
+		SendErrorResponse(w, "You must be logged in to transcribe content", http.StatusUnauthorized,
+			"Unauthorized transcription attempt", nil)
+		return
+	}
+
+	itemID, ok := transcriptItemID(w, r, "transcription")
+	if !ok {
+		return
+	}
+
+	taskID, err := h.Service.StartTranscription(r.Context(), itemID, userID)
+	if err != nil {
+		sendTranscriptError(w, err, itemID, userID)
+		return
+	}
+
+	SendAcceptedResponse(w, "Transcription started", map[string]string{"task_id": taskID},
+		"Started transcription task "+taskID+" for content "+itemID.String())
+}
+
+// Get handles GET /api/content/{id}/transcript - the transcript as JSON, or as WebVTT
+// captions with ?format=vtt
+func (h *TranscriptHandler) Get(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Content transcript requested from IP: %s", r.RemoteAddr)
+
+	itemID, ok := transcriptItemID(w, r, "transcript")
+	if !ok {
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "vtt" {
+		SendErrorResponse(w, "format must be json or vtt", http.StatusBadRequest,
+			"Invalid transcript format: "+format, nil)
+		return
+	}
+
+	userID := session.GetCurrentUser(r.Context())
+	transcript, vtt, err := h.Service.GetTranscript(r.Context(), itemID, userID)
+	if err != nil {
+		sendTranscriptError(w, err, itemID, userID)
+		return
+	}
+
+	if format == "vtt" {
+		w.Header().Set("Content-Type", "text/vtt; charset=utf-8")
+		w.Header().Set("Cache-Control", "private, no-store")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Write([]byte(vtt))
+		return
+	}
+
+	SendSuccessResponse(w, "Transcript retrieved", transcript,
+		"Retrieved transcript of content "+itemID.String())
+}
+
+// transcriptItemID reads the content item ID from the path, sending the error response itself
+func transcriptItemID(w http.ResponseWriter, r *http.Request, request string) (uuid.UUID, bool) {
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in "+request+" request", nil)
+		return uuid.Nil, false
+	}
+
+	itemID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid content ID format", http.StatusBadRequest,
+			"Invalid content UUID in "+request+" request", err)
+		return uuid.Nil, false
+	}
+	return itemID, true
+}
+
+// sendTranscriptError maps transcript errors to responses
+func sendTranscriptError(w http.ResponseWriter, err error, itemID, userID uuid.UUID) {
+	switch {
+	case errors.Is(err, services.ErrTranscriptionDisabled):
+		SendErrorResponse(w, "Transcription is not set up on this server", http.StatusServiceUnavailable,
+			"Transcription requested for content "+itemID.String()+" without a provider", err)
+	case errors.Is(err, services.ErrTranscriptNotFound):
+		SendErrorResponse(w, err.Error(), http.StatusNotFound,
+			"No transcript for content "+itemID.String(), nil)
+	case errors.Is(err, services.ErrNotMediaContent):
+		SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+			"Transcription requested for non-media content "+itemID.String(), err)
+	default:
+		sendStreamError(w, err, itemID, userID)
+	}
+}
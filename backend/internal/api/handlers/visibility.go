@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/internal/services"
+)
+
+// VisibilityHandler processes course assignment requests
+type VisibilityHandler struct {
+	Service  *services.VisibilityService // who sees which courses
+	Profiles *services.ProfileService    // needed for admin checks
+}
+
+// NewVisibilityHandler creates handler with injected services
+func NewVisibilityHandler(service *services.VisibilityService, profiles *services.ProfileService) *VisibilityHandler {
+	return &VisibilityHandler{Service: service, Profiles: profiles}
+}
+
+// Get handles GET /api/profiles/{id}/course-visibility - the profile itself or an admin
+func (h *VisibilityHandler) Get(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Course visibility requested from IP: %s", r.RemoteAddr)
+
+	profileID, ok := profileIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	if !requireSelfOrAdmin(w, r, h.Profiles, profileID) {
+		return
+	}
+
+	visibility, err := h.Service.GetVisibility(r.Context(), profileID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			SendErrorResponse(w, "Profile not found", http.StatusNotFound,
+				"Course visibility requested for non-existent profile", err)
+			return
+		}
+		SendErrorResponse(w, "Failed to get course visibility", http.StatusInternalServerError,
+			"Error retrieving course visibility", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Course visibility retrieved", visibility,
+		"Course visibility returned for profile "+profileID.String())
+}
+
+// Set handles PUT /api/profiles/{id}/course-visibility - admin only, replaces the assigned courses
+func (h *VisibilityHandler) Set(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Course visibility update requested from IP: %s", r.RemoteAddr)
+
+	actorID, ok := requireAdmin(w, r, h.Profiles)
+	if !ok {
+		return
+	}
+
+	profileID, ok := profileIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var input models.SetCourseVisibilityInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in course visibility update", err)
+		return
+	}
+
+	// make sure profile actually exists
+	if _, err := h.Profiles.GetProfileByID(r.Context(), profileID); err != nil {
+		SendErrorResponse(w, "Profile not found", http.StatusNotFound,
+			"Attempted to set course visibility for non-existent profile", err)
+		return
+	}
+
+	visibility, err := h.Service.SetVisibility(r.Context(), profileID, actorID, input)
+	if err != nil {
+		if errors.Is(err, services.ErrUnknownCourse) {
+			SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+				"Course visibility update names unknown course", err)
+			return
+		}
+		SendErrorResponse(w, "Failed to update course visibility", http.StatusInternalServerError,
+			"Error updating course visibility", err)
+		return
+	}
+
+	SendSuccessResponse(w, "Course visibility updated", visibility,
+		"Course visibility updated for profile "+profileID.String()+" by "+actorID.String())
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/NeroQue/course-management-backend/pkg/session"
+	"github.com/google/uuid"
+)
+
+// WatchTimeHandler takes playback heartbeats from players
+type WatchTimeHandler struct {
+	Service *services.WatchTimeService // credits watch time per heartbeat
+}
+
+// NewWatchTimeHandler creates handler with injected service
+func NewWatchTimeHandler(service *services.WatchTimeService) *WatchTimeHandler {
+	return &WatchTimeHandler{Service: service}
+}
+
+// Heartbeat handles POST /api/content/{id}/heartbeat - players send {"seconds": n} every
+// 15-30 seconds while playing, and get back what was credited and the time limit status
+func (h *WatchTimeHandler) Heartbeat(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Playback heartbeat from IP: %s", r.RemoteAddr)
+
+	userID := session.GetCurrentUser(r.Context())
+	if userID == uuid.Nil {
+		SendErrorResponse(w, "You must be logged in to track watch time", http.StatusUnauthorized,
+			"Unauthorized heartbeat", nil)
+		return
+	}
+
+	pathParts := strings.Split(r.URL.Path, "/")
+	if len(pathParts) < 4 {
+		SendErrorResponse(w, "Invalid URL path format", http.StatusBadRequest,
+			"Invalid URL path in heartbeat", nil)
+		return
+	}
+
+	itemID, err := uuid.Parse(pathParts[3])
+	if err != nil {
+		SendErrorResponse(w, "Invalid content ID format", http.StatusBadRequest,
+			"Invalid content UUID in heartbeat", err)
+		return
+	}
+
+	var input models.HeartbeatInput
+	if err := ValidateJSONBody(r, &input); err != nil {
+		SendErrorResponse(w, "Invalid request format: "+err.Error(), http.StatusBadRequest,
+			"Invalid JSON in heartbeat", err)
+		return
+	}
+
+	result, err := h.Service.RecordHeartbeat(r.Context(), itemID, userID, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidHeartbeat):
+			SendErrorResponse(w, err.Error(), http.StatusBadRequest,
+				"Invalid heartbeat for content "+itemID.String(), err)
+		case errors.Is(err, sql.ErrNoRows):
+			SendErrorResponse(w, "Content item not found", http.StatusNotFound,
+				"Heartbeat for missing content "+itemID.String(), err)
+		case errors.Is(err, services.ErrContentNotVisible):
+			// hidden courses look the same as missing ones
+			SendErrorResponse(w, "Content item not found", http.StatusNotFound,
+				"Content "+itemID.String()+" hidden from profile "+userID.String(), nil)
+		default:
+			SendErrorResponse(w, "Failed to record heartbeat", http.StatusInternalServerError,
+				"Error recording heartbeat for content "+itemID.String(), err)
+		}
+		return
+	}
+
+	if result.Suspicious {
+		log.Printf("Suspicious heartbeat from profile %s on content %s: %s", userID, itemID, result.Reason)
+	}
+	SendSuccessResponse(w, "Heartbeat recorded", result,
+		"Credited "+strconv.Itoa(result.CreditedSeconds)+"s of watch time to profile "+userID.String())
+}
@@ -1,7 +1,25 @@
 package api
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
 	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/api/handlers"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/NeroQue/course-management-backend/pkg/access"
+	"github.com/NeroQue/course-management-backend/pkg/jwt"
+	"github.com/NeroQue/course-management-backend/pkg/maintenance"
+	"github.com/NeroQue/course-management-backend/pkg/session"
+	"github.com/google/uuid"
 )
 
 // EnableCORS adds CORS headers so frontend can talk to the API
@@ -11,10 +29,14 @@ func (s *Server) EnableCORS(next http.Handler) http.Handler {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 
 		// allow the HTTP methods we use
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+
+		// need this for JSON requests, plus the session token and the key that makes import retries safe
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Idempotency-Key")
 
-		// need this for JSON requests
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		// let the frontend show a banner while an admin is acting as someone else,
+		// and tell a retried import apart from a fresh one
+		w.Header().Set("Access-Control-Expose-Headers", "X-Impersonating, Idempotent-Replayed, X-Session-Token")
 
 		// handle preflight requests from browser
 		if r.Method == http.MethodOptions {
@@ -27,4 +49,276 @@ func (s *Server) EnableCORS(next http.Handler) http.Handler {
 	})
 }
 
-// TODO: need to add middleware for auth, logging, etc.
+// Sessions works out whose request it is from the session token it carries and puts the
+// session in the request context, where session.GetCurrentUser finds it. A token that
+// fails verification or names an ended session makes an anonymous request, like having
+// none. An expired one is refused with 401 so the client knows to log in again; browsers
+// lose the cookie with it. Sessions in use get refreshed on the way through. API tokens
+// are different: scripts have no login to fall back to, so a bad one is refused outright.
+func (s *Server) Sessions(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := session.TokenFromRequest(r)
+		if session.IsAPIToken(token) {
+			apiToken, err := session.ResolveAPIToken(r.Context(), token)
+			if err != nil {
+				handlers.SendErrorResponse(w, "Failed to check API token", http.StatusInternalServerError,
+					"Error resolving API token for "+r.Method+" "+r.URL.Path, err)
+				return
+			}
+			if apiToken == nil {
+				handlers.SendErrorResponse(w, "Invalid, expired or revoked API token", http.StatusUnauthorized,
+					"Unknown API token on "+r.Method+" "+r.URL.Path+" from "+r.RemoteAddr, nil)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(session.WithAPIToken(r.Context(), apiToken)))
+			return
+		}
+
+		current, err := session.Resolve(r.Context(), token)
+		switch {
+		case errors.Is(err, jwt.ErrInvalidToken):
+			log.Printf("Ignoring invalid session token on %s %s from %s: %v", r.Method, r.URL.Path, r.RemoteAddr, err)
+			session.ClearCookie(w)
+		case errors.Is(err, jwt.ErrExpiredToken):
+			session.ClearCookie(w)
+			handlers.SendErrorResponse(w, "Session expired, please log in again", http.StatusUnauthorized,
+				"Expired session on "+r.Method+" "+r.URL.Path+" from "+r.RemoteAddr, err)
+			return
+		case err != nil:
+			handlers.SendErrorResponse(w, "Failed to check session", http.StatusInternalServerError,
+				"Error resolving session for "+r.Method+" "+r.URL.Path, err)
+			return
+		}
+
+		ctx := session.WithSession(r.Context(), current, r.UserAgent())
+		refreshed, err := session.Refresh(ctx)
+		if err != nil {
+			// the old token still works for now, the next request can try again
+			log.Printf("Error refreshing session on %s %s: %v", r.Method, r.URL.Path, err)
+		}
+		if refreshed != "" {
+			session.SetCookie(w, r, refreshed)
+			w.Header().Set(session.RefreshHeader, refreshed)
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// EnforcePolicies checks the role a route requires before its handler runs.
+// Handlers still do their own checks (creator or admin etc.), this is the coarse layer on top.
+func (s *Server) EnforcePolicies(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, pattern := s.Router.Handler(r)
+		if pattern == "" {
+			next.ServeHTTP(w, r) // let the router send its 404/405
+			return
+		}
+
+		policy := s.Policies.Resolve(pattern)
+		if policy.Role == access.RolePublic {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		userID := session.GetCurrentUser(r.Context())
+		loggedIn := userID != uuid.Nil
+
+		// any role will do for user routes, only look it up when it matters
+		role := ""
+		if loggedIn {
+			role = access.RoleViewer
+		}
+		if loggedIn && (policy.Role == access.RoleEditor || policy.Role == access.RoleAdmin) {
+			var err error
+			role, err = s.Profiles.GetRole(r.Context(), userID)
+			if err != nil {
+				handlers.SendErrorResponse(w, "Failed to check permissions", http.StatusInternalServerError,
+					"Error checking the role of "+userID.String()+" for route policy "+pattern, err)
+				return
+			}
+		}
+
+		if access.Allows(policy.Role, role) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		switch {
+		case policy.Role == access.RoleDeny:
+			handlers.SendErrorResponse(w, "This endpoint is disabled", http.StatusForbidden,
+				"Request to disabled route "+pattern, nil)
+		case !loggedIn:
+			handlers.SendErrorResponse(w, "You must be logged in", http.StatusUnauthorized,
+				"Unauthenticated request to "+pattern+" (requires "+policy.Role+")", nil)
+		case policy.Role == access.RoleEditor:
+			handlers.SendErrorResponse(w, "Editor rights required", http.StatusForbidden,
+				"Profile "+userID.String()+" ("+role+") denied "+pattern+" by route policy", nil)
+		default:
+			handlers.SendErrorResponse(w, "Admin rights required", http.StatusForbidden,
+				"Profile "+userID.String()+" ("+role+") denied "+pattern+" by route policy", nil)
+		}
+	})
+}
+
+// AuditImpersonation marks responses made while an admin impersonates a profile and
+// writes every change made that way to the audit log before it happens
+func (s *Server) AuditImpersonation(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		impersonation := session.CurrentImpersonation(r.Context())
+		if impersonation == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("X-Impersonating", impersonation.ProfileID.String())
+
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+		default:
+			// a change we can't attribute to the admin doesn't get made
+			if err := s.Impersonation.RecordRequest(r.Context(), impersonation, r.Method, r.URL.Path); err != nil {
+				handlers.SendErrorResponse(w, "Failed to audit impersonated request", http.StatusInternalServerError,
+					"Error auditing impersonated request "+r.Method+" "+r.URL.Path, err)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// maintenanceExempt are writes that keep working in maintenance mode - logging in and
+// out, so an admin can get in, and the switch itself, so they can turn it off again
+var maintenanceExempt = map[string]bool{
+	"POST /api/profiles/{id}/select": true,
+	"POST /api/logout":               true,
+	"PUT /api/admin/maintenance":     true,
+}
+
+// MaintenanceMode refuses changes with 503 and Retry-After while maintenance is on.
+// Reads keep working so people can still browse and watch.
+func (s *Server) MaintenanceMode(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		state := maintenance.Current()
+		if !state.Active {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if _, pattern := s.Router.Handler(r); maintenanceExempt[pattern] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Retry-After", strconv.Itoa(state.RetryAfter))
+		handlers.SendErrorResponse(w, "The server is in maintenance mode ("+strings.Join(state.Reasons, ", ")+"), try again later",
+			http.StatusServiceUnavailable, "Refused "+r.Method+" "+r.URL.Path+" during maintenance", nil)
+	})
+}
+
+// idempotentRoutes honour an Idempotency-Key header - the imports, where a retried
+// request would otherwise create duplicate courses or start a second background task
+var idempotentRoutes = map[string]bool{
+	"POST /api/courses":       true,
+	"POST /api/courses/batch": true,
+}
+
+// Idempotency replays the stored response when a request to an idempotent route is retried
+// with the same Idempotency-Key. Keys are per profile and per route; reusing one for a
+// different request body is refused. Server errors free the key so the retry runs again.
+func (s *Server) Idempotency(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimSpace(r.Header.Get("Idempotency-Key"))
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		_, pattern := s.Router.Handler(r)
+		userID := session.GetCurrentUser(r.Context())
+		if !idempotentRoutes[pattern] || userID == uuid.Nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if len(key) > services.MaxIdempotencyKeyLength {
+			handlers.SendErrorResponse(w, "Idempotency-Key must be at most "+strconv.Itoa(services.MaxIdempotencyKeyLength)+" characters",
+				http.StatusBadRequest, "Oversized idempotency key on "+pattern, nil)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			handlers.SendErrorResponse(w, "Failed to read request body", http.StatusBadRequest,
+				"Error reading body of idempotent request to "+pattern, err)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		sum := sha256.Sum256(body)
+		requestHash := hex.EncodeToString(sum[:])
+
+		stored, err := s.IdempotencyKeys.Begin(r.Context(), userID, pattern, key, requestHash)
+		if err != nil {
+			switch {
+			case errors.Is(err, services.ErrIdempotencyKeyInUse):
+				handlers.SendErrorResponse(w, err.Error(), http.StatusConflict,
+					"Concurrent retry of "+pattern+" with idempotency key "+key, err)
+			case errors.Is(err, services.ErrIdempotencyKeyReused):
+				handlers.SendErrorResponse(w, err.Error(), http.StatusUnprocessableEntity,
+					"Idempotency key "+key+" reused with a different body on "+pattern, err)
+			default:
+				handlers.SendErrorResponse(w, "Failed to check idempotency key", http.StatusInternalServerError,
+					"Error checking idempotency key for "+pattern, err)
+			}
+			return
+		}
+
+		if stored != nil {
+			log.Printf("Replaying stored response for %s with idempotency key %s", pattern, key)
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Idempotent-Replayed", "true")
+			w.WriteHeader(stored.StatusCode)
+			w.Write(stored.Body)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		// the client may be gone by now, the key still has to be settled
+		ctx := context.WithoutCancel(r.Context())
+		if recorder.status >= http.StatusInternalServerError {
+			err = s.IdempotencyKeys.Release(ctx, userID, pattern, key)
+		} else {
+			err = s.IdempotencyKeys.Complete(ctx, userID, pattern, key,
+				models.IdempotentResponse{StatusCode: recorder.status, Body: recorder.body.Bytes()})
+		}
+		if err != nil {
+			log.Printf("Warning: could not settle idempotency key %s for %s: %v", key, pattern, err)
+		}
+	})
+}
+
+// responseRecorder passes a response through while keeping a copy of it
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (rec *responseRecorder) WriteHeader(status int) {
+	rec.status = status
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	rec.body.Write(b)
+	return rec.ResponseWriter.Write(b)
+}
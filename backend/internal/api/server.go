@@ -1,16 +1,27 @@
 package api
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
+	"log"
 	"net/http"
+	"os"
 	"time"
 
 	"github.com/NeroQue/course-management-backend/internal/api/handlers"
 	"github.com/NeroQue/course-management-backend/internal/database"
 	"github.com/NeroQue/course-management-backend/internal/services"
+	"github.com/NeroQue/course-management-backend/pkg/access"
+	"github.com/NeroQue/course-management-backend/pkg/events"
+	"github.com/NeroQue/course-management-backend/pkg/mail"
+	"github.com/NeroQue/course-management-backend/pkg/metrics"
+	"github.com/NeroQue/course-management-backend/pkg/notify"
 	"github.com/NeroQue/course-management-backend/pkg/parser"
+	"github.com/NeroQue/course-management-backend/pkg/rewards"
+	"github.com/NeroQue/course-management-backend/pkg/session"
 	"github.com/NeroQue/course-management-backend/pkg/task"
+	"github.com/NeroQue/course-management-backend/pkg/util"
 )
 
 // Server holds all the app components together
@@ -19,34 +30,207 @@ type Server struct {
 
 	Router *http.ServeMux // handles routing requests
 
+	Policies *access.Policies         // which role each route requires
+	Profiles *services.ProfileService // admin checks for route policies
+
+	Impersonation   *services.ImpersonationService // audits requests made while impersonating
+	IdempotencyKeys *services.IdempotencyService   // replays responses to retried imports
+
 	// handlers for different parts of the API
-	ProfileHandler *handlers.ProfileHandler
-	CourseHandler  *handlers.CourseHandler
-	TaskHandler    *handlers.TaskHandler
-	AdminHandler   *handlers.AdminHandler // for admin operations
+	ProfileHandler       *handlers.ProfileHandler
+	CourseHandler        *handlers.CourseHandler
+	TaskHandler          *handlers.TaskHandler
+	AdminHandler         *handlers.AdminHandler         // for admin operations
+	TimeLimitHandler     *handlers.TimeLimitHandler     // parental/learning time limits
+	NoteHandler          *handlers.NoteHandler          // course and content notes
+	ModuleHandler        *handlers.ModuleHandler        // for editing modules after import
+	ContentHandler       *handlers.ContentHandler       // for editing content items after import
+	NotificationHandler  *handlers.NotificationHandler  // notification preferences and inbox
+	SnapshotHandler      *handlers.SnapshotHandler      // library snapshots and rollback
+	VisibilityHandler    *handlers.VisibilityHandler    // per-profile course assignments
+	StudyTimeHandler     *handlers.StudyTimeHandler     // study time estimates and pacing
+	PrerequisiteHandler  *handlers.PrerequisiteHandler  // course prerequisites
+	ContentTypeHandler   *handlers.ContentTypeHandler   // unknown content triage
+	TieringHandler       *handlers.TieringHandler       // hot/cold storage tiering
+	LearningPathHandler  *handlers.LearningPathHandler  // ordered course collections
+	PolicyHandler        *handlers.PolicyHandler        // effective route policies
+	ShareLinkHandler     *handlers.ShareLinkHandler     // public links to single content items
+	PrefetchHandler      *handlers.PrefetchHandler      // what players should preload next
+	ImpersonationHandler *handlers.ImpersonationHandler // admins acting as another profile
+	InstructorHandler    *handlers.InstructorHandler    // who teaches which course
+	MaintenanceHandler   *handlers.MaintenanceHandler   // refusing changes during restores and migrations
+	StreamHandler        *handlers.StreamHandler        // serving content files to the player
+	TranscriptHandler    *handlers.TranscriptHandler    // speech-to-text of audio and video
+	SearchHandler        *handlers.SearchHandler        // library-wide search
+	OfflineHandler       *handlers.OfflineHandler       // downloading courses for offline study
+	WatchTimeHandler     *handlers.WatchTimeHandler     // playback heartbeats
+	ActivityHandler      *handlers.ActivityHandler      // per-profile history timeline and feed
+	ThumbnailHandler     *handlers.ThumbnailHandler     // preview images of content items
+	BookmarkHandler      *handlers.BookmarkHandler      // moments to jump back to in audio and video
+	StatsHandler         *handlers.StatsHandler         // learning statistics and heatmap
+	GoalHandler          *handlers.GoalHandler          // learning goals profiles set themselves
+	GemHandler           *handlers.GemHandler           // gem balances, shop and ledger
+	GamificationHandler  *handlers.GamificationHandler  // history of XP, gem and streak changes
+	QuestHandler         *handlers.QuestHandler         // weekly challenges
+	OIDCHandler          *handlers.OIDCHandler          // logging in through an OpenID Connect provider
+	APITokenHandler      *handlers.APITokenHandler      // long-lived tokens for scripts
+	ProfileMergeHandler  *handlers.ProfileMergeHandler  // folding duplicate profiles together
+	SessionHandler       *handlers.SessionHandler       // logging out and ending sessions on other devices
+	EmailHandler         *handlers.EmailHandler         // verified contact addresses of profiles
 }
 
 // NewServer wires up all the dependencies and returns a ready-to-use server
 func NewServer(db *sql.DB, courseParser *parser.CourseParser) *Server {
 	dbQueries := database.New(db)
 
-	task.Initialize()
+	// background tasks share a few workers, imports and sprite generation are heavy on the
+	// disk and transcription on the CPU, so those get fewer
+	task.Initialize(task.Config{
+		Workers: util.GetEnvInt("TASK_WORKERS", task.DefaultWorkers),
+		Limits: map[string]int{
+			"batch_import":      util.GetEnvInt("TASK_IMPORT_WORKERS", 1),
+			"sprite_generation": util.GetEnvInt("TASK_TRANSCODE_WORKERS", 1),
+			"transcription":     util.GetEnvInt("TASK_TRANSCRIPTION_WORKERS", 1),
+		},
+		MaxRetries: util.GetEnvInt("TASK_MAX_RETRIES", task.DefaultMaxRetries),
+		Store:      services.NewTaskStore(dbQueries),
+	})
+	// tasks from before a restart can still be looked at and retried
+	if restored, err := task.Restore(context.Background()); err != nil {
+		log.Printf("Warning: could not restore background tasks: %v", err)
+	} else if restored > 0 {
+		log.Printf("Restored %d background tasks from before the restart", restored)
+	}
 	// start cleanup routine in background - cleans old tasks every hour
 	go task.CleanupRoutine(1*time.Hour, 24*time.Hour)
 
 	// create service layer instances
-	profileSvc := services.NewProfileService(dbQueries)
-	courseSvc := services.NewCourseService(dbQueries, courseParser)
-	adminSvc := services.NewAdminService(dbQueries)
+	// XP, gems and streak freezes pay out and cost what the rules file says
+	rules, err := rewards.Load(os.Getenv("GAMIFICATION_RULES_FILE"))
+	if err != nil {
+		log.Fatalf("Failed to load gamification rules: %v", err)
+	}
+
+	profileSvc := services.NewProfileService(dbQueries, db, rules)
+	courseSvc := services.NewCourseService(dbQueries, db, courseParser)
+	adminSvc := services.NewAdminService(dbQueries, db)
+	timeLimitSvc := services.NewTimeLimitService(dbQueries)
+	moduleSvc := services.NewModuleService(dbQueries, db)
+	artifactSvc := services.NewArtifactService(dbQueries)
+	contentSvc := services.NewContentService(dbQueries, db, courseParser.BasePath)
+	notificationSvc := services.NewNotificationService(dbQueries, notify.LogSender{})
+	snapshotSvc := services.NewSnapshotService(dbQueries, db, courseSvc)
+	visibilitySvc := services.NewVisibilityService(dbQueries, db)
+	noteSvc := services.NewNoteService(dbQueries, visibilitySvc)
+	studyTimeSvc := services.NewStudyTimeService(courseSvc)
+	prerequisiteSvc := services.NewPrerequisiteService(dbQueries, db, courseSvc)
+	contentTypeSvc := services.NewContentTypeService(dbQueries)
+	tieringSvc := services.NewTieringService(dbQueries, courseParser.BasePath)
+	learningPathSvc := services.NewLearningPathService(dbQueries, db, courseSvc)
+	shareLinkSvc := services.NewShareLinkService(dbQueries, tieringSvc)
+	prefetchSvc := services.NewPrefetchService(dbQueries, tieringSvc, courseParser.BasePath)
+	impersonationSvc := services.NewImpersonationService(dbQueries)
+	instructorSvc := services.NewInstructorService(dbQueries, db)
+	streamSvc := services.NewStreamService(dbQueries, tieringSvc, timeLimitSvc, visibilitySvc)
+	thumbnailSvc := services.NewThumbnailService(dbQueries, visibilitySvc)
+	transcriptSvc := services.NewTranscriptService(dbQueries, tieringSvc, visibilitySvc)
+	goalSvc := services.NewGoalService(dbQueries, db, courseSvc, visibilitySvc, rules)
+	gamificationSvc := services.NewGamificationService(dbQueries, db, courseSvc, rules)
+	gemSvc := services.NewGemService(dbQueries, db, rules)
+	questSvc := services.NewQuestService(dbQueries, db, courseSvc, gamificationSvc, rules)
+	oidcSvc := services.NewOIDCService(dbQueries, profileSvc)
+	activitySvc := services.NewActivityService(dbQueries)
+
+	// progress and gamification milestones other features react to
+	courseSvc.Events.Subscribe(events.CourseCompleted, notificationSvc.NotifyCourseCompleted)
+	courseSvc.Events.Subscribe(events.LevelUp, notificationSvc.NotifyLevelUp)
+	courseSvc.Events.Subscribe(events.LevelUp, gemSvc.AwardLevelUp)
+	courseSvc.Events.Subscribe(events.LevelUp, activitySvc.RecordLevelUp)
+	courseSvc.Events.Subscribe(events.ContentCompleted, questSvc.TrackProgress)
+	courseSvc.Events.Subscribe(events.ModuleCompleted, questSvc.TrackProgress)
+
+	// user-defined extension mappings have to be in place before anything gets imported
+	if err := contentTypeSvc.LoadMappings(context.Background()); err != nil {
+		log.Printf("Warning: could not load content type mappings: %v", err)
+	}
+
+	// janitor keeps thumbnails, HLS renditions etc. within their retention policies
+	go artifactSvc.JanitorRoutine(util.GetEnvDuration("ARTIFACT_JANITOR_INTERVAL", 6*time.Hour))
+	// daily/weekly notification digests go out from here
+	go notificationSvc.DispatcherRoutine(util.GetEnvDuration("NOTIFICATION_DISPATCH_INTERVAL", 15*time.Minute))
+	// rarely used media moves to cold storage from here, when a cold backend is configured
+	go tieringSvc.PolicyRoutine(util.GetEnvDuration("TIERING_INTERVAL", 24*time.Hour))
+	// finished days get judged against profiles' learning goals from here
+	go goalSvc.EvaluationRoutine(util.GetEnvDuration("GOAL_EVALUATION_INTERVAL", time.Hour))
+	// sessions nobody came back for get cleared out from here
+	go session.PruneRoutine(util.GetEnvDuration("SESSION_PRUNE_INTERVAL", time.Hour))
+	// guests whose session is gone get deleted along with their progress from here
+	go profileSvc.GuestPruneRoutine(util.GetEnvDuration("GUEST_PRUNE_INTERVAL", time.Hour))
+
+	// instance owners can lock down or open up routes without code changes
+	policies, err := access.Load(os.Getenv("ACCESS_POLICY_FILE"))
+	if err != nil {
+		log.Fatalf("Failed to load access policies: %v", err)
+	}
+	// single sign-on replaces picking a profile, unless the instance wants both
+	if oidcSvc.Provider != nil {
+		profileSvc.SSOOnly = true
+		if util.GetEnvBool("OIDC_DISABLE_PROFILE_SELECT", true) {
+			if err := policies.OverrideBuiltin(access.Rule{Pattern: "POST /api/profiles/{id}/select", Role: access.RoleDeny}); err != nil {
+				log.Fatalf("Failed to switch off profile selection: %v", err)
+			}
+			log.Printf("OIDC login is on, profile selection is switched off (OIDC_DISABLE_PROFILE_SELECT)")
+		}
+	}
 
 	// wire everything together
 	server := &Server{
-		DB:             dbQueries,
-		Router:         http.NewServeMux(),
-		ProfileHandler: handlers.NewProfileHandler(profileSvc),
-		CourseHandler:  handlers.NewCourseHandler(courseSvc),
-		TaskHandler:    handlers.NewTaskHandler(),
-		AdminHandler:   handlers.NewAdminHandler(adminSvc),
+		DB:                   dbQueries,
+		Router:               http.NewServeMux(),
+		Policies:             policies,
+		Profiles:             profileSvc,
+		Impersonation:        impersonationSvc,
+		IdempotencyKeys:      services.NewIdempotencyService(dbQueries),
+		ProfileHandler:       handlers.NewProfileHandler(profileSvc),
+		CourseHandler:        handlers.NewCourseHandler(courseSvc, noteSvc, profileSvc, notificationSvc, visibilitySvc, prerequisiteSvc, tieringSvc, gamificationSvc),
+		TaskHandler:          handlers.NewTaskHandler(),
+		AdminHandler:         handlers.NewAdminHandler(adminSvc, profileSvc, artifactSvc),
+		TimeLimitHandler:     handlers.NewTimeLimitHandler(timeLimitSvc, profileSvc),
+		NoteHandler:          handlers.NewNoteHandler(noteSvc, courseSvc),
+		ModuleHandler:        handlers.NewModuleHandler(moduleSvc),
+		ContentHandler:       handlers.NewContentHandler(contentSvc),
+		NotificationHandler:  handlers.NewNotificationHandler(notificationSvc, profileSvc),
+		SnapshotHandler:      handlers.NewSnapshotHandler(snapshotSvc, profileSvc),
+		VisibilityHandler:    handlers.NewVisibilityHandler(visibilitySvc, profileSvc),
+		StudyTimeHandler:     handlers.NewStudyTimeHandler(studyTimeSvc),
+		PrerequisiteHandler:  handlers.NewPrerequisiteHandler(prerequisiteSvc, courseSvc, profileSvc),
+		PolicyHandler:        handlers.NewPolicyHandler(policies, profileSvc),
+		ShareLinkHandler:     handlers.NewShareLinkHandler(shareLinkSvc, profileSvc),
+		PrefetchHandler:      handlers.NewPrefetchHandler(prefetchSvc),
+		ImpersonationHandler: handlers.NewImpersonationHandler(impersonationSvc, profileSvc),
+		InstructorHandler:    handlers.NewInstructorHandler(instructorSvc, profileSvc),
+		MaintenanceHandler:   handlers.NewMaintenanceHandler(profileSvc),
+		StreamHandler:        handlers.NewStreamHandler(streamSvc),
+		TranscriptHandler:    handlers.NewTranscriptHandler(transcriptSvc),
+		SearchHandler:        handlers.NewSearchHandler(services.NewSearchService(dbQueries, visibilitySvc)),
+		OfflineHandler:       handlers.NewOfflineHandler(services.NewOfflineService(dbQueries, tieringSvc, visibilitySvc)),
+		WatchTimeHandler:     handlers.NewWatchTimeHandler(services.NewWatchTimeService(dbQueries, timeLimitSvc, visibilitySvc)),
+		ActivityHandler:      handlers.NewActivityHandler(activitySvc, profileSvc),
+		ThumbnailHandler:     handlers.NewThumbnailHandler(thumbnailSvc),
+		BookmarkHandler:      handlers.NewBookmarkHandler(services.NewBookmarkService(dbQueries, visibilitySvc)),
+		StatsHandler:         handlers.NewStatsHandler(services.NewStatsService(dbQueries), profileSvc),
+		GoalHandler:          handlers.NewGoalHandler(goalSvc, profileSvc),
+		GemHandler:           handlers.NewGemHandler(gemSvc, profileSvc),
+		GamificationHandler:  handlers.NewGamificationHandler(gamificationSvc, profileSvc),
+		QuestHandler:         handlers.NewQuestHandler(questSvc, profileSvc),
+		OIDCHandler:          handlers.NewOIDCHandler(oidcSvc),
+		APITokenHandler:      handlers.NewAPITokenHandler(services.NewAPITokenService(dbQueries), profileSvc),
+		ProfileMergeHandler:  handlers.NewProfileMergeHandler(services.NewProfileMergeService(dbQueries, db, profileSvc), profileSvc),
+		SessionHandler:       handlers.NewSessionHandler(services.NewSessionService(dbQueries), profileSvc),
+		EmailHandler:         handlers.NewEmailHandler(services.NewEmailService(dbQueries, mail.FromEnv()), profileSvc),
+		ContentTypeHandler:   handlers.NewContentTypeHandler(contentTypeSvc, profileSvc),
+		TieringHandler:       handlers.NewTieringHandler(tieringSvc, profileSvc),
+		LearningPathHandler:  handlers.NewLearningPathHandler(learningPathSvc, profileSvc),
 	}
 
 	server.setupRoutes()
@@ -55,36 +239,244 @@ func NewServer(db *sql.DB, courseParser *parser.CourseParser) *Server {
 
 // setupRoutes maps all the endpoints to handler functions
 func (s *Server) setupRoutes() {
-	s.Router.HandleFunc("/api", s.HelloHandler)
+	s.handle("/api", s.HelloHandler)
+
+	// prometheus scrape endpoint
+	s.handle("GET /metrics", metrics.Handler)
 
 	// profile management
-	s.Router.HandleFunc("GET /api/profiles", s.ProfileHandler.List)
-	s.Router.HandleFunc("POST /api/profiles", s.ProfileHandler.Create)
-	s.Router.HandleFunc("PUT /api/profiles", s.ProfileHandler.Update)
-	s.Router.HandleFunc("DELETE /api/profiles", s.ProfileHandler.Delete)
-	s.Router.HandleFunc("POST /api/profiles/{id}/select", s.ProfileHandler.SelectProfile)
+	s.handle("GET /api/profiles", s.ProfileHandler.List)
+	s.handle("POST /api/profiles", s.ProfileHandler.Create)
+	s.handle("PUT /api/profiles", s.ProfileHandler.Update)
+	s.handle("DELETE /api/profiles", s.ProfileHandler.Delete)
+	s.handle("POST /api/profiles/{id}/select", s.ProfileHandler.SelectProfile)
+	s.handle("POST /api/guest", s.ProfileHandler.StartGuest)
+	s.handle("PUT /api/profiles/{id}/admin", s.ProfileHandler.SetAdmin)
+	s.handle("PUT /api/profiles/{id}/role", s.ProfileHandler.SetRole)
+	s.handle("GET /api/profiles/{id}/avatar", s.ProfileHandler.GetAvatar)
+	s.handle("POST /api/profiles/{id}/avatar", s.ProfileHandler.UploadAvatar)
+	s.handle("POST /api/profiles/merge", s.ProfileMergeHandler.Merge)
+
+	// contact email, verified by a link sent to it
+	s.handle("GET /api/profiles/{id}/email", s.EmailHandler.Get)
+	s.handle("PUT /api/profiles/{id}/email", s.EmailHandler.Set)
+	s.handle("POST /api/profiles/{id}/email/resend", s.EmailHandler.Resend)
+	s.handle("POST /api/email/verify", s.EmailHandler.Verify)
+
+	// sessions: logging out, and seeing and ending the ones on other devices
+	s.handle("POST /api/logout", s.SessionHandler.Logout)
+	s.handle("GET /api/profiles/{id}/sessions", s.SessionHandler.List)
+	s.handle("DELETE /api/sessions/{id}", s.SessionHandler.Revoke)
+
+	// API tokens let scripts act as a profile without selecting it
+	s.handle("GET /api/profiles/{id}/tokens", s.APITokenHandler.List)
+	s.handle("POST /api/profiles/{id}/tokens", s.APITokenHandler.Create)
+	s.handle("DELETE /api/profiles/{id}/tokens/{token}", s.APITokenHandler.Revoke)
+
+	// single sign-on through an OpenID Connect provider, when one is configured
+	s.handle("GET /api/auth/oidc", s.OIDCHandler.Status)
+	s.handle("GET /api/auth/oidc/login", s.OIDCHandler.Login)
+	s.handle("GET /api/auth/oidc/callback", s.OIDCHandler.Callback)
+
+	// viewing time limits - changing them is admin only
+	s.handle("GET /api/profiles/{id}/time-limits", s.TimeLimitHandler.GetStatus)
+	s.handle("PUT /api/profiles/{id}/time-limits", s.TimeLimitHandler.SetLimits)
+	s.handle("DELETE /api/profiles/{id}/time-limits", s.TimeLimitHandler.ClearLimits)
+
+	// which courses a profile sees - assigning them is admin only
+	s.handle("GET /api/profiles/{id}/course-visibility", s.VisibilityHandler.Get)
+	s.handle("PUT /api/profiles/{id}/course-visibility", s.VisibilityHandler.Set)
+
+	// notification digests and inbox
+	s.handle("GET /api/profiles/{id}/notification-preferences", s.NotificationHandler.GetPreferences)
+	s.handle("PUT /api/profiles/{id}/notification-preferences", s.NotificationHandler.SetPreferences)
+	s.handle("GET /api/profiles/{id}/notifications", s.NotificationHandler.List)
 
 	// course stuff
-	s.Router.HandleFunc("GET /api/courses", s.CourseHandler.List)
-	s.Router.HandleFunc("POST /api/courses", s.CourseHandler.Create)
-	s.Router.HandleFunc("GET /api/courses/directories", s.CourseHandler.ListDirectories)
-	s.Router.HandleFunc("GET /api/courses/scan", s.CourseHandler.ScanNewCourses)
-	s.Router.HandleFunc("POST /api/courses/batch", s.CourseHandler.BatchImport)
+	s.handle("GET /api/courses", s.CourseHandler.List)
+	s.handle("POST /api/courses", s.CourseHandler.Create)
+	s.handle("GET /api/courses/directories", s.CourseHandler.ListDirectories)
+	s.handle("GET /api/courses/scan", s.CourseHandler.ScanNewCourses)
+	s.handle("POST /api/courses/batch", s.CourseHandler.BatchImport)
+	s.handle("POST /api/courses/bulk-delete", s.CourseHandler.BulkDelete)
+	s.handle("PUT /api/courses/order", s.CourseHandler.SetOrder)
+	s.handle("GET /api/courses/{id}", s.CourseHandler.Get)
+	s.handle("PATCH /api/courses/{id}", s.CourseHandler.Update)
+	s.handle("DELETE /api/courses/{id}", s.CourseHandler.Delete)
+	s.handle("POST /api/courses/{id}/clone", s.CourseHandler.Clone)
+
+	// favorites for the current profile
+	s.handle("POST /api/courses/{id}/favorite", s.CourseHandler.Favorite)
+	s.handle("DELETE /api/courses/{id}/favorite", s.CourseHandler.Unfavorite)
+
+	// prerequisites between courses
+	s.handle("GET /api/courses/{id}/prerequisites", s.PrerequisiteHandler.Get)
+	s.handle("PUT /api/courses/{id}/prerequisites", s.PrerequisiteHandler.Set)
+
+	// course notes for the current profile
+	s.handle("GET /api/courses/{id}/notes", s.NoteHandler.GetCourseNote)
+	s.handle("PUT /api/courses/{id}/notes", s.NoteHandler.SaveCourseNote)
+	s.handle("DELETE /api/courses/{id}/notes", s.NoteHandler.DeleteCourseNote)
+	s.handle("GET /api/courses/{id}/content-notes", s.NoteHandler.ListCourseContentNotes)
+	s.handle("POST /api/content/{id}/notes", s.NoteHandler.CreateContentNote)
+	s.handle("GET /api/content/{id}/notes", s.NoteHandler.ListContentNotes)
+	s.handle("PUT /api/content-notes/{id}", s.NoteHandler.UpdateContentNote)
+	s.handle("DELETE /api/content-notes/{id}", s.NoteHandler.DeleteContentNote)
+
+	// bookmarks in audio and video
+	s.handle("POST /api/content/{id}/bookmarks", s.BookmarkHandler.Create)
+	s.handle("GET /api/content/{id}/bookmarks", s.BookmarkHandler.List)
+	s.handle("DELETE /api/bookmarks/{id}", s.BookmarkHandler.Delete)
+
+	// optional tools (ffmpeg, OCR) a course's items depend on
+	s.handle("GET /api/courses/{id}/capabilities", s.CourseHandler.GetCapabilityWarnings)
+
+	// portable course bundles
+	s.handle("GET /api/courses/{id}/export", s.CourseHandler.ExportCourse)
+	s.handle("POST /api/courses/import-bundle", s.CourseHandler.ImportBundle)
+
+	// learning paths - ordered sequences of courses
+	s.handle("GET /api/learning-paths", s.LearningPathHandler.List)
+	s.handle("POST /api/learning-paths", s.LearningPathHandler.Create)
+	s.handle("GET /api/learning-paths/{id}", s.LearningPathHandler.Get)
+	s.handle("PATCH /api/learning-paths/{id}", s.LearningPathHandler.Update)
+	s.handle("DELETE /api/learning-paths/{id}", s.LearningPathHandler.Delete)
+	s.handle("GET /api/learning-paths/{id}/progress", s.LearningPathHandler.GetProgress)
+
+	// instructors
+	s.handle("GET /api/instructors", s.InstructorHandler.List)
+	s.handle("POST /api/instructors", s.InstructorHandler.Create)
+	s.handle("GET /api/instructors/{id}", s.InstructorHandler.Get)
+	s.handle("PATCH /api/instructors/{id}", s.InstructorHandler.Update)
+	s.handle("DELETE /api/instructors/{id}", s.InstructorHandler.Delete)
+	s.handle("PUT /api/courses/{id}/instructors", s.InstructorHandler.SetCourseInstructors)
+
+	// module editing
+	s.handle("PATCH /api/modules/{id}", s.ModuleHandler.Update)
+	s.handle("POST /api/courses/{id}/modules/reorder", s.ModuleHandler.Reorder)
+	s.handle("POST /api/courses/{id}/modules/merge", s.ModuleHandler.Merge)
+
+	// content item editing
+	s.handle("PATCH /api/content/{id}", s.ContentHandler.Update)
+	s.handle("POST /api/content/{id}/hidden", s.ContentHandler.SetHidden)
+	s.handle("PUT /api/content/{id}/chapters", s.ContentHandler.SetChapters)
+	s.handle("POST /api/modules/{id}/content/reorder", s.ContentHandler.Reorder)
+
+	// playback - files, captions, chapters, rendered text, preloading, previews, PDF pages and resized images
+	s.handle("GET /api/content/{id}/stream", s.StreamHandler.Stream)
+	s.handle("GET /api/content/{id}/download", s.StreamHandler.Download)
+	s.handle("GET /api/content/{id}/subtitles", s.StreamHandler.Subtitles)
+	s.handle("GET /api/content/{id}/subtitles/{track}", s.StreamHandler.Subtitle)
+	s.handle("GET /api/content/{id}/chapters", s.StreamHandler.Chapters)
+	s.handle("GET /api/content/{id}/rendered", s.StreamHandler.Rendered)
+	s.handle("GET /api/content/{id}/prefetch", s.PrefetchHandler.Get)
+	s.handle("GET /api/content/{id}/thumbnail", s.ThumbnailHandler.Get)
+	s.handle("GET /api/content/{id}/sprites/{file}", s.ThumbnailHandler.Sprite)
+	s.handle("GET /api/content/{id}/pages/{n}", s.ThumbnailHandler.Page)
+	s.handle("GET /api/content/{id}/image", s.ThumbnailHandler.Image)
+
+	// speech-to-text transcripts, searchable below
+	s.handle("POST /api/content/{id}/transcript", s.TranscriptHandler.Start)
+	s.handle("GET /api/content/{id}/transcript", s.TranscriptHandler.Get)
+
+	// search across course titles, descriptions, content titles and transcripts
+	s.handle("GET /api/search", s.SearchHandler.Search)
+
+	// offline study - a manifest for the whole course, the files behind signed URLs
+	s.handle("GET /api/courses/{id}/offline-manifest", s.OfflineHandler.Manifest)
+	s.handle("GET /api/offline/{id}", s.OfflineHandler.Download)
+
+	// share links - managing them needs the course owner, opening them is public
+	s.handle("POST /api/content/{id}/share-links", s.ShareLinkHandler.Create)
+	s.handle("GET /api/content/{id}/share-links", s.ShareLinkHandler.List)
+	s.handle("DELETE /api/share-links/{id}", s.ShareLinkHandler.Revoke)
+	s.handle("GET /api/share/{token}", s.ShareLinkHandler.Open)
+	s.handle("POST /api/share/{token}", s.ShareLinkHandler.Unlock)
 
 	// progress tracking endpoints
-	s.Router.HandleFunc("GET /api/courses/{id}/progress", s.CourseHandler.GetCourseProgress)
-	s.Router.HandleFunc("GET /api/modules/{id}/progress", s.CourseHandler.GetModuleProgress)
-	s.Router.HandleFunc("POST /api/content/{id}/progress", s.CourseHandler.UpdateContentProgress)
-	s.Router.HandleFunc("POST /api/content/{id}/complete", s.CourseHandler.MarkContentCompleted)
-	s.Router.HandleFunc("GET /api/users/{id}/progress", s.CourseHandler.GetUserProgressSummary)
+	s.handle("GET /api/courses/{id}/progress", s.CourseHandler.GetCourseProgress)
+	s.handle("POST /api/courses/{id}/progress/reset", s.CourseHandler.ResetCourseProgress)
+	s.handle("POST /api/courses/{id}/progress/transfer", s.CourseHandler.TransferProgress)
+	s.handle("GET /api/courses/{id}/study-time", s.StudyTimeHandler.GetEstimate)
+	s.handle("GET /api/courses/{id}/pacing", s.StudyTimeHandler.GetPacing)
+	s.handle("GET /api/modules/{id}/progress", s.CourseHandler.GetModuleProgress)
+	s.handle("POST /api/content/{id}/progress", s.CourseHandler.UpdateContentProgress)
+	s.handle("POST /api/content/{id}/complete", s.CourseHandler.MarkContentCompleted)
+	s.handle("POST /api/modules/{id}/complete", s.CourseHandler.CompleteModule)
+	s.handle("POST /api/courses/{id}/complete", s.CourseHandler.CompleteCourse)
+	s.handle("POST /api/content/{id}/heartbeat", s.WatchTimeHandler.Heartbeat)
+	s.handle("POST /api/progress/sync", s.CourseHandler.SyncProgress)
+	s.handle("GET /api/users/{id}/progress", s.CourseHandler.GetUserProgressSummary)
+	s.handle("GET /api/users/{id}/progress/export", s.CourseHandler.ExportProgress)
+	s.handle("GET /api/users/{id}/activity", s.ActivityHandler.List)
+	s.handle("GET /api/profiles/{id}/feed", s.ActivityHandler.Feed)
+	s.handle("GET /api/users/{id}/stats", s.StatsHandler.Get)
+	s.handle("GET /api/users/{id}/heatmap", s.StatsHandler.Heatmap)
+	s.handle("GET /api/leaderboard", s.StatsHandler.Leaderboard)
+
+	// learning goals
+	s.handle("GET /api/users/{id}/goals", s.GoalHandler.List)
+	s.handle("POST /api/users/{id}/goals", s.GoalHandler.Create)
+	s.handle("GET /api/users/{id}/goals/{goal}", s.GoalHandler.Get)
+	s.handle("PUT /api/users/{id}/goals/{goal}", s.GoalHandler.Update)
+	s.handle("DELETE /api/users/{id}/goals/{goal}", s.GoalHandler.Delete)
+	s.handle("GET /api/users/{id}/streak-freezes", s.GoalHandler.GetStreakFreezes)
+	s.handle("POST /api/users/{id}/streak-freezes", s.GoalHandler.BuyStreakFreeze)
+	s.handle("POST /api/users/{id}/streak/repair", s.GoalHandler.RepairStreak)
+
+	// gem economy
+	s.handle("GET /api/users/{id}/gems", s.GemHandler.Wallet)
+	s.handle("POST /api/users/{id}/gems/redeem", s.GemHandler.Redeem)
+	s.handle("GET /api/users/{id}/gems/transactions", s.GemHandler.Transactions)
+	s.handle("GET /api/users/{id}/gamification/history", s.GamificationHandler.History)
+	s.handle("GET /api/users/{id}/quests", s.QuestHandler.List)
 
 	// admin endpoints
-	s.Router.HandleFunc("POST /api/admin/factory-reset", s.AdminHandler.FactoryReset)
-	s.Router.HandleFunc("GET /api/admin/stats", s.AdminHandler.GetStats)
+	s.handle("POST /api/admin/factory-reset", s.AdminHandler.FactoryReset)
+	s.handle("GET /api/admin/stats", s.AdminHandler.GetStats)
+	s.handle("POST /api/admin/titles/rename", s.AdminHandler.RenameTitles)
+	s.handle("POST /api/admin/changes/{batch}/undo", s.AdminHandler.UndoChangeBatch)
+	s.handle("GET /api/admin/audit", s.AdminHandler.ListAuditLog)
+	s.handle("GET /api/admin/artifacts", s.AdminHandler.GetArtifactUsage)
+	s.handle("POST /api/admin/artifacts/cleanup", s.AdminHandler.CleanupArtifacts)
+
+	// library metadata snapshots
+	s.handle("GET /api/admin/snapshots", s.SnapshotHandler.List)
+	s.handle("POST /api/admin/snapshots", s.SnapshotHandler.Create)
+	s.handle("POST /api/admin/snapshots/{id}/rollback", s.SnapshotHandler.Rollback)
+	s.handle("DELETE /api/admin/snapshots/{id}", s.SnapshotHandler.Delete)
+
+	// triage for files the parser couldn't classify
+	s.handle("GET /api/admin/unknown-content", s.ContentTypeHandler.ListUnknown)
+	s.handle("POST /api/admin/unknown-content/map", s.ContentTypeHandler.MapExtension)
+
+	// hot/cold storage tiering
+	s.handle("GET /api/admin/tiering", s.TieringHandler.GetStatus)
+	s.handle("POST /api/admin/tiering/run", s.TieringHandler.Run)
+
+	// admins acting as another profile, time-boxed and audited
+	s.handle("POST /api/admin/impersonation", s.ImpersonationHandler.Start)
+	s.handle("GET /api/impersonation", s.ImpersonationHandler.Status)
+	s.handle("DELETE /api/impersonation", s.ImpersonationHandler.Stop)
+
+	// maintenance mode
+	s.handle("GET /api/maintenance", s.MaintenanceHandler.Get)
+	s.handle("PUT /api/admin/maintenance", s.MaintenanceHandler.Set)
+
+	// effective access policy per route
+	s.handle("GET /api/admin/policies", s.PolicyHandler.List)
+	s.handle("GET /api/admin/gamification/rules", s.GamificationHandler.Rules)
 
 	// task tracking
-	s.Router.HandleFunc("GET /api/tasks", s.TaskHandler.GetTask)
-	s.Router.HandleFunc("POST /api/tasks/cleanup", s.TaskHandler.CleanupTasks)
+	s.handle("GET /api/tasks", s.TaskHandler.GetTask)
+	s.handle("POST /api/tasks/cleanup", s.TaskHandler.CleanupTasks)
+	s.handle("POST /api/tasks/{id}/retry", s.TaskHandler.RetryTask)
+}
+
+// handle registers a route with the router and the access policies
+func (s *Server) handle(pattern string, handler http.HandlerFunc) {
+	s.Router.HandleFunc(pattern, handler)
+	s.Policies.Register(pattern)
 }
 
 // ServeHTTP implements the http.Handler interface
@@ -10,6 +10,7 @@ import (
 	"database/sql"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 const createContentItem = `-- name: CreateContentItem :one
@@ -22,11 +23,12 @@ INSERT INTO content_items (
     content_type,
     duration,
     size,
-    "order"
+    "order",
+    page_count
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
 )
-RETURNING id, module_id, title, description, relative_path, content_type, duration, size, "order", created_at, updated_at
+RETURNING id, module_id, title, description, relative_path, content_type, duration, size, "order", created_at, updated_at, hidden, page_count
 `
 
 type CreateContentItemParams struct {
@@ -39,6 +41,7 @@ type CreateContentItemParams struct {
 	Duration     sql.NullInt32
 	Size         sql.NullInt64
 	Order        int32
+	PageCount    sql.NullInt32
 }
 
 func (q *Queries) CreateContentItem(ctx context.Context, arg CreateContentItemParams) (ContentItem, error) {
@@ -52,6 +55,7 @@ func (q *Queries) CreateContentItem(ctx context.Context, arg CreateContentItemPa
 		arg.Duration,
 		arg.Size,
 		arg.Order,
+		arg.PageCount,
 	)
 	var i ContentItem
 	err := row.Scan(
@@ -66,6 +70,8 @@ func (q *Queries) CreateContentItem(ctx context.Context, arg CreateContentItemPa
 		&i.Order,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Hidden,
+		&i.PageCount,
 	)
 	return i, err
 }
@@ -80,8 +86,23 @@ func (q *Queries) DeleteContentItem(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+const deleteContentItemsNotIn = `-- name: DeleteContentItemsNotIn :exec
+DELETE FROM content_items
+WHERE module_id = $1 AND NOT (id = ANY($2::uuid[]))
+`
+
+type DeleteContentItemsNotInParams struct {
+	ModuleID uuid.UUID
+	KeepIds  []uuid.UUID
+}
+
+func (q *Queries) DeleteContentItemsNotIn(ctx context.Context, arg DeleteContentItemsNotInParams) error {
+	_, err := q.db.ExecContext(ctx, deleteContentItemsNotIn, arg.ModuleID, pq.Array(arg.KeepIds))
+	return err
+}
+
 const getContentItem = `-- name: GetContentItem :one
-SELECT id, module_id, title, description, relative_path, content_type, duration, size, "order", created_at, updated_at FROM content_items
+SELECT id, module_id, title, description, relative_path, content_type, duration, size, "order", created_at, updated_at, hidden, page_count FROM content_items
 WHERE id = $1
 `
 
@@ -100,12 +121,108 @@ func (q *Queries) GetContentItem(ctx context.Context, id uuid.UUID) (ContentItem
 		&i.Order,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Hidden,
+		&i.PageCount,
+	)
+	return i, err
+}
+
+const getContentItemLocation = `-- name: GetContentItemLocation :one
+SELECT ci.id, ci.title, ci.relative_path, ci.content_type, ci.module_id, m.course_id, c.creator_id
+FROM content_items ci
+JOIN modules m ON m.id = ci.module_id
+JOIN courses c ON c.id = m.course_id
+WHERE ci.id = $1
+`
+
+type GetContentItemLocationRow struct {
+	ID           uuid.UUID
+	Title        string
+	RelativePath string
+	ContentType  string
+	ModuleID     uuid.UUID
+	CourseID     uuid.UUID
+	CreatorID    uuid.NullUUID
+}
+
+func (q *Queries) GetContentItemLocation(ctx context.Context, id uuid.UUID) (GetContentItemLocationRow, error) {
+	row := q.db.QueryRowContext(ctx, getContentItemLocation, id)
+	var i GetContentItemLocationRow
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.RelativePath,
+		&i.ContentType,
+		&i.ModuleID,
+		&i.CourseID,
+		&i.CreatorID,
 	)
 	return i, err
 }
 
+const listAllContentItemsWithCourse = `-- name: ListAllContentItemsWithCourse :many
+SELECT ci.id, ci.module_id, ci.title, ci.description, ci.relative_path, ci.content_type, ci.duration, ci.size, ci."order", ci.created_at, ci.updated_at, ci.hidden, ci.page_count, m.course_id FROM content_items ci
+JOIN modules m ON ci.module_id = m.id
+ORDER BY m.course_id, m."order", ci."order"
+`
+
+type ListAllContentItemsWithCourseRow struct {
+	ID           uuid.UUID
+	ModuleID     uuid.UUID
+	Title        string
+	Description  sql.NullString
+	RelativePath string
+	ContentType  string
+	Duration     sql.NullInt32
+	Size         sql.NullInt64
+	Order        int32
+	CreatedAt    sql.NullTime
+	UpdatedAt    sql.NullTime
+	Hidden       bool
+	PageCount    sql.NullInt32
+	CourseID     uuid.UUID
+}
+
+func (q *Queries) ListAllContentItemsWithCourse(ctx context.Context) ([]ListAllContentItemsWithCourseRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAllContentItemsWithCourse)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAllContentItemsWithCourseRow
+	for rows.Next() {
+		var i ListAllContentItemsWithCourseRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ModuleID,
+			&i.Title,
+			&i.Description,
+			&i.RelativePath,
+			&i.ContentType,
+			&i.Duration,
+			&i.Size,
+			&i.Order,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Hidden,
+			&i.PageCount,
+			&i.CourseID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listContentItemsByModule = `-- name: ListContentItemsByModule :many
-SELECT id, module_id, title, description, relative_path, content_type, duration, size, "order", created_at, updated_at FROM content_items
+SELECT id, module_id, title, description, relative_path, content_type, duration, size, "order", created_at, updated_at, hidden, page_count FROM content_items
 WHERE module_id = $1
 ORDER BY "order" ASC
 `
@@ -131,6 +248,52 @@ func (q *Queries) ListContentItemsByModule(ctx context.Context, moduleID uuid.UU
 			&i.Order,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Hidden,
+			&i.PageCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCourseContentItems = `-- name: ListCourseContentItems :many
+SELECT ci.id, ci.module_id, ci.title, ci.description, ci.relative_path, ci.content_type, ci.duration, ci.size, ci."order", ci.created_at, ci.updated_at, ci.hidden, ci.page_count FROM content_items ci
+JOIN modules m ON m.id = ci.module_id
+WHERE m.course_id = $1
+ORDER BY m."order", ci."order"
+`
+
+func (q *Queries) ListCourseContentItems(ctx context.Context, courseID uuid.UUID) ([]ContentItem, error) {
+	rows, err := q.db.QueryContext(ctx, listCourseContentItems, courseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ContentItem
+	for rows.Next() {
+		var i ContentItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.ModuleID,
+			&i.Title,
+			&i.Description,
+			&i.RelativePath,
+			&i.ContentType,
+			&i.Duration,
+			&i.Size,
+			&i.Order,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Hidden,
+			&i.PageCount,
 		); err != nil {
 			return nil, err
 		}
@@ -145,6 +308,107 @@ func (q *Queries) ListContentItemsByModule(ctx context.Context, moduleID uuid.UU
 	return items, nil
 }
 
+const moveContentItem = `-- name: MoveContentItem :exec
+UPDATE content_items
+SET
+    module_id = $2,
+    "order" = $3,
+    updated_at = now()
+WHERE id = $1
+`
+
+type MoveContentItemParams struct {
+	ID       uuid.UUID
+	ModuleID uuid.UUID
+	Order    int32
+}
+
+func (q *Queries) MoveContentItem(ctx context.Context, arg MoveContentItemParams) error {
+	_, err := q.db.ExecContext(ctx, moveContentItem, arg.ID, arg.ModuleID, arg.Order)
+	return err
+}
+
+const restoreContentItem = `-- name: RestoreContentItem :exec
+INSERT INTO content_items (id, module_id, title, description, relative_path, content_type, duration, size, "order", page_count, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, now())
+ON CONFLICT (id)
+DO UPDATE SET
+    module_id = EXCLUDED.module_id,
+    title = EXCLUDED.title,
+    description = EXCLUDED.description,
+    relative_path = EXCLUDED.relative_path,
+    content_type = EXCLUDED.content_type,
+    duration = EXCLUDED.duration,
+    size = EXCLUDED.size,
+    "order" = EXCLUDED."order",
+    page_count = EXCLUDED.page_count,
+    updated_at = now()
+`
+
+type RestoreContentItemParams struct {
+	ID           uuid.UUID
+	ModuleID     uuid.UUID
+	Title        string
+	Description  sql.NullString
+	RelativePath string
+	ContentType  string
+	Duration     sql.NullInt32
+	Size         sql.NullInt64
+	Order        int32
+	PageCount    sql.NullInt32
+}
+
+func (q *Queries) RestoreContentItem(ctx context.Context, arg RestoreContentItemParams) error {
+	_, err := q.db.ExecContext(ctx, restoreContentItem,
+		arg.ID,
+		arg.ModuleID,
+		arg.Title,
+		arg.Description,
+		arg.RelativePath,
+		arg.ContentType,
+		arg.Duration,
+		arg.Size,
+		arg.Order,
+		arg.PageCount,
+	)
+	return err
+}
+
+const setContentItemHidden = `-- name: SetContentItemHidden :one
+UPDATE content_items
+SET
+    hidden = $2,
+    updated_at = now()
+WHERE id = $1
+RETURNING id, module_id, title, description, relative_path, content_type, duration, size, "order", created_at, updated_at, hidden, page_count
+`
+
+type SetContentItemHiddenParams struct {
+	ID     uuid.UUID
+	Hidden bool
+}
+
+func (q *Queries) SetContentItemHidden(ctx context.Context, arg SetContentItemHiddenParams) (ContentItem, error) {
+	row := q.db.QueryRowContext(ctx, setContentItemHidden, arg.ID, arg.Hidden)
+	var i ContentItem
+	err := row.Scan(
+		&i.ID,
+		&i.ModuleID,
+		&i.Title,
+		&i.Description,
+		&i.RelativePath,
+		&i.ContentType,
+		&i.Duration,
+		&i.Size,
+		&i.Order,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Hidden,
+		&i.PageCount,
+	)
+	return i, err
+}
+
 const updateContentItem = `-- name: UpdateContentItem :one
 UPDATE content_items
 SET
@@ -155,7 +419,7 @@ SET
     "order" = $6,
     updated_at = now()
 WHERE id = $1
-RETURNING id, module_id, title, description, relative_path, content_type, duration, size, "order", created_at, updated_at
+RETURNING id, module_id, title, description, relative_path, content_type, duration, size, "order", created_at, updated_at, hidden, page_count
 `
 
 type UpdateContentItemParams struct {
@@ -189,6 +453,44 @@ func (q *Queries) UpdateContentItem(ctx context.Context, arg UpdateContentItemPa
 		&i.Order,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Hidden,
+		&i.PageCount,
 	)
 	return i, err
 }
+
+const updateContentItemOrder = `-- name: UpdateContentItemOrder :exec
+UPDATE content_items
+SET
+    "order" = $2,
+    updated_at = now()
+WHERE id = $1
+`
+
+type UpdateContentItemOrderParams struct {
+	ID    uuid.UUID
+	Order int32
+}
+
+func (q *Queries) UpdateContentItemOrder(ctx context.Context, arg UpdateContentItemOrderParams) error {
+	_, err := q.db.ExecContext(ctx, updateContentItemOrder, arg.ID, arg.Order)
+	return err
+}
+
+const updateContentItemTitle = `-- name: UpdateContentItemTitle :exec
+UPDATE content_items
+SET
+    title = $2,
+    updated_at = now()
+WHERE id = $1
+`
+
+type UpdateContentItemTitleParams struct {
+	ID    uuid.UUID
+	Title string
+}
+
+func (q *Queries) UpdateContentItemTitle(ctx context.Context, arg UpdateContentItemTitleParams) error {
+	_, err := q.db.ExecContext(ctx, updateContentItemTitle, arg.ID, arg.Title)
+	return err
+}
@@ -10,19 +10,69 @@ import (
 	"database/sql"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
+const countCourseContent = `-- name: CountCourseContent :one
+SELECT COUNT(DISTINCT m.id) AS modules, COUNT(ci.id) AS content_items
+FROM modules m
+LEFT JOIN content_items ci ON ci.module_id = m.id
+WHERE m.course_id = $1
+`
+
+type CountCourseContentRow struct {
+	Modules      int64
+	ContentItems int64
+}
+
+func (q *Queries) CountCourseContent(ctx context.Context, courseID uuid.UUID) (CountCourseContentRow, error) {
+	row := q.db.QueryRowContext(ctx, countCourseContent, courseID)
+	var i CountCourseContentRow
+	err := row.Scan(&i.Modules, &i.ContentItems)
+	return i, err
+}
+
+const countCourseProgress = `-- name: CountCourseProgress :one
+SELECT COUNT(*)
+FROM user_progress up
+JOIN content_items ci ON ci.id = up.content_item_id
+JOIN modules m ON m.id = ci.module_id
+WHERE m.course_id = $1
+`
+
+func (q *Queries) CountCourseProgress(ctx context.Context, courseID uuid.UUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countCourseProgress, courseID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countCoursesByRelativePath = `-- name: CountCoursesByRelativePath :one
+SELECT COUNT(*) FROM courses
+WHERE relative_path = $1
+`
+
+func (q *Queries) CountCoursesByRelativePath(ctx context.Context, relativePath string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countCoursesByRelativePath, relativePath)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createCourse = `-- name: CreateCourse :one
 INSERT INTO courses (
     id,
     title,
     description,
     creator_id,
-    relative_path
+    relative_path,
+    tags,
+    difficulty,
+    language
 ) VALUES (
-    $1, $2, $3, $4, $5
+    $1, $2, $3, $4, $5, $6, $7, $8
 )
-RETURNING id, title, description, creator_id, relative_path, created_at, updated_at
+RETURNING id, title, description, creator_id, relative_path, created_at, updated_at, tags, difficulty, cloned_from, language
 `
 
 type CreateCourseParams struct {
@@ -31,6 +81,9 @@ type CreateCourseParams struct {
 	Description  sql.NullString
 	CreatorID    uuid.NullUUID
 	RelativePath string
+	Tags         []string
+	Difficulty   sql.NullString
+	Language     sql.NullString
 }
 
 func (q *Queries) CreateCourse(ctx context.Context, arg CreateCourseParams) (Course, error) {
@@ -40,6 +93,9 @@ func (q *Queries) CreateCourse(ctx context.Context, arg CreateCourseParams) (Cou
 		arg.Description,
 		arg.CreatorID,
 		arg.RelativePath,
+		pq.Array(arg.Tags),
+		arg.Difficulty,
+		arg.Language,
 	)
 	var i Course
 	err := row.Scan(
@@ -50,6 +106,57 @@ func (q *Queries) CreateCourse(ctx context.Context, arg CreateCourseParams) (Cou
 		&i.RelativePath,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		pq.Array(&i.Tags),
+		&i.Difficulty,
+		&i.ClonedFrom,
+		&i.Language,
+	)
+	return i, err
+}
+
+const createCourseClone = `-- name: CreateCourseClone :one
+INSERT INTO courses (id, title, description, creator_id, relative_path, tags, difficulty, language, cloned_from)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, title, description, creator_id, relative_path, created_at, updated_at, tags, difficulty, cloned_from, language
+`
+
+type CreateCourseCloneParams struct {
+	ID           uuid.UUID
+	Title        string
+	Description  sql.NullString
+	CreatorID    uuid.NullUUID
+	RelativePath string
+	Tags         []string
+	Difficulty   sql.NullString
+	Language     sql.NullString
+	ClonedFrom   uuid.NullUUID
+}
+
+func (q *Queries) CreateCourseClone(ctx context.Context, arg CreateCourseCloneParams) (Course, error) {
+	row := q.db.QueryRowContext(ctx, createCourseClone,
+		arg.ID,
+		arg.Title,
+		arg.Description,
+		arg.CreatorID,
+		arg.RelativePath,
+		pq.Array(arg.Tags),
+		arg.Difficulty,
+		arg.Language,
+		arg.ClonedFrom,
+	)
+	var i Course
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.CreatorID,
+		&i.RelativePath,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		pq.Array(&i.Tags),
+		&i.Difficulty,
+		&i.ClonedFrom,
+		&i.Language,
 	)
 	return i, err
 }
@@ -65,7 +172,7 @@ func (q *Queries) DeleteCourse(ctx context.Context, id uuid.UUID) error {
 }
 
 const getCourse = `-- name: GetCourse :one
-SELECT id, title, description, creator_id, relative_path, created_at, updated_at FROM courses
+SELECT id, title, description, creator_id, relative_path, created_at, updated_at, tags, difficulty, cloned_from, language FROM courses
 WHERE id = $1
 `
 
@@ -80,12 +187,41 @@ func (q *Queries) GetCourse(ctx context.Context, id uuid.UUID) (Course, error) {
 		&i.RelativePath,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		pq.Array(&i.Tags),
+		&i.Difficulty,
+		&i.ClonedFrom,
+		&i.Language,
+	)
+	return i, err
+}
+
+const getCourseByRelativePath = `-- name: GetCourseByRelativePath :one
+SELECT id, title, description, creator_id, relative_path, created_at, updated_at, tags, difficulty, cloned_from, language FROM courses
+WHERE relative_path = $1 AND cloned_from IS NULL
+LIMIT 1
+`
+
+func (q *Queries) GetCourseByRelativePath(ctx context.Context, relativePath string) (Course, error) {
+	row := q.db.QueryRowContext(ctx, getCourseByRelativePath, relativePath)
+	var i Course
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.CreatorID,
+		&i.RelativePath,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		pq.Array(&i.Tags),
+		&i.Difficulty,
+		&i.ClonedFrom,
+		&i.Language,
 	)
 	return i, err
 }
 
 const listCourses = `-- name: ListCourses :many
-SELECT id, title, description, creator_id, relative_path, created_at, updated_at FROM courses
+SELECT id, title, description, creator_id, relative_path, created_at, updated_at, tags, difficulty, cloned_from, language FROM courses
 ORDER BY created_at DESC
 `
 
@@ -106,6 +242,10 @@ func (q *Queries) ListCourses(ctx context.Context) ([]Course, error) {
 			&i.RelativePath,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			pq.Array(&i.Tags),
+			&i.Difficulty,
+			&i.ClonedFrom,
+			&i.Language,
 		); err != nil {
 			return nil, err
 		}
@@ -121,7 +261,7 @@ func (q *Queries) ListCourses(ctx context.Context) ([]Course, error) {
 }
 
 const listCoursesByCreator = `-- name: ListCoursesByCreator :many
-SELECT id, title, description, creator_id, relative_path, created_at, updated_at FROM courses
+SELECT id, title, description, creator_id, relative_path, created_at, updated_at, tags, difficulty, cloned_from, language FROM courses
 WHERE creator_id = $1
 ORDER BY created_at DESC
 `
@@ -143,6 +283,10 @@ func (q *Queries) ListCoursesByCreator(ctx context.Context, creatorID uuid.NullU
 			&i.RelativePath,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			pq.Array(&i.Tags),
+			&i.Difficulty,
+			&i.ClonedFrom,
+			&i.Language,
 		); err != nil {
 			return nil, err
 		}
@@ -157,24 +301,71 @@ func (q *Queries) ListCoursesByCreator(ctx context.Context, creatorID uuid.NullU
 	return items, nil
 }
 
+const restoreCourse = `-- name: RestoreCourse :exec
+INSERT INTO courses (id, title, description, creator_id, relative_path, updated_at)
+VALUES ($1, $2, $3, $4, $5, now())
+ON CONFLICT (id)
+DO UPDATE SET
+    title = EXCLUDED.title,
+    description = EXCLUDED.description,
+    creator_id = EXCLUDED.creator_id,
+    relative_path = EXCLUDED.relative_path,
+    updated_at = now()
+`
+
+type RestoreCourseParams struct {
+	ID           uuid.UUID
+	Title        string
+	Description  sql.NullString
+	CreatorID    uuid.NullUUID
+	RelativePath string
+}
+
+func (q *Queries) RestoreCourse(ctx context.Context, arg RestoreCourseParams) error {
+	_, err := q.db.ExecContext(ctx, restoreCourse,
+		arg.ID,
+		arg.Title,
+		arg.Description,
+		arg.CreatorID,
+		arg.RelativePath,
+	)
+	return err
+}
+
 const updateCourse = `-- name: UpdateCourse :one
 UPDATE courses
 SET
     title = $2,
     description = $3,
+    tags = $4,
+    difficulty = $5,
+    creator_id = $6,
+    language = $7,
     updated_at = now()
 WHERE id = $1
-RETURNING id, title, description, creator_id, relative_path, created_at, updated_at
+RETURNING id, title, description, creator_id, relative_path, created_at, updated_at, tags, difficulty, cloned_from, language
 `
 
 type UpdateCourseParams struct {
 	ID          uuid.UUID
 	Title       string
 	Description sql.NullString
+	Tags        []string
+	Difficulty  sql.NullString
+	CreatorID   uuid.NullUUID
+	Language    sql.NullString
 }
 
 func (q *Queries) UpdateCourse(ctx context.Context, arg UpdateCourseParams) (Course, error) {
-	row := q.db.QueryRowContext(ctx, updateCourse, arg.ID, arg.Title, arg.Description)
+	row := q.db.QueryRowContext(ctx, updateCourse,
+		arg.ID,
+		arg.Title,
+		arg.Description,
+		pq.Array(arg.Tags),
+		arg.Difficulty,
+		arg.CreatorID,
+		arg.Language,
+	)
 	var i Course
 	err := row.Scan(
 		&i.ID,
@@ -184,6 +375,28 @@ func (q *Queries) UpdateCourse(ctx context.Context, arg UpdateCourseParams) (Cou
 		&i.RelativePath,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		pq.Array(&i.Tags),
+		&i.Difficulty,
+		&i.ClonedFrom,
+		&i.Language,
 	)
 	return i, err
 }
+
+const updateCourseTitle = `-- name: UpdateCourseTitle :exec
+UPDATE courses
+SET
+    title = $2,
+    updated_at = now()
+WHERE id = $1
+`
+
+type UpdateCourseTitleParams struct {
+	ID    uuid.UUID
+	Title string
+}
+
+func (q *Queries) UpdateCourseTitle(ctx context.Context, arg UpdateCourseTitleParams) error {
+	_, err := q.db.ExecContext(ctx, updateCourseTitle, arg.ID, arg.Title)
+	return err
+}
@@ -6,10 +6,82 @@ package database
 
 import (
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+type ActivityEvent struct {
+	ID            uuid.UUID
+	ProfileID     uuid.UUID
+	EventType     string
+	CourseID      uuid.NullUUID
+	ContentItemID uuid.NullUUID
+	Title         string
+	CreatedAt     time.Time
+}
+
+type ApiToken struct {
+	ID         uuid.UUID
+	ProfileID  uuid.UUID
+	Name       string
+	TokenHash  string
+	Scope      string
+	ExpiresAt  sql.NullTime
+	RevokedAt  sql.NullTime
+	LastUsedAt sql.NullTime
+	CreatedAt  time.Time
+}
+
+type AuditLog struct {
+	ID         uuid.UUID
+	ActorID    uuid.NullUUID
+	SubjectID  uuid.NullUUID
+	Action     string
+	EntityType sql.NullString
+	EntityID   uuid.NullUUID
+	Details    sql.NullString
+	CreatedAt  sql.NullTime
+}
+
+type ChangeHistory struct {
+	ID         uuid.UUID
+	BatchID    uuid.UUID
+	EntityType string
+	EntityID   uuid.UUID
+	Field      string
+	OldValue   sql.NullString
+	NewValue   sql.NullString
+	ChangedBy  uuid.NullUUID
+	RevertedAt sql.NullTime
+	CreatedAt  sql.NullTime
+}
+
+type ContentBookmark struct {
+	ID            uuid.UUID
+	ContentItemID uuid.UUID
+	UserID        uuid.UUID
+	Position      float64
+	Label         string
+	CreatedAt     sql.NullTime
+}
+
+type ContentChapter struct {
+	ContentItemID uuid.UUID
+	Position      int32
+	Title         string
+	StartTime     float64
+	CreatedAt     sql.NullTime
+}
+
+type ContentChecksum struct {
+	ContentItemID uuid.UUID
+	Size          int64
+	ModifiedNs    int64
+	Sha256        string
+	CreatedAt     sql.NullTime
+}
+
 type ContentItem struct {
 	ID           uuid.UUID
 	ModuleID     uuid.UUID
@@ -22,6 +94,78 @@ type ContentItem struct {
 	Order        int32
 	CreatedAt    sql.NullTime
 	UpdatedAt    sql.NullTime
+	Hidden       bool
+	PageCount    sql.NullInt32
+}
+
+type ContentItemRequirement struct {
+	ContentItemID uuid.UUID
+	Capability    string
+	CreatedAt     sql.NullTime
+}
+
+type ContentItemVariant struct {
+	ID            uuid.UUID
+	ContentItemID uuid.UUID
+	Label         string
+	Resolution    sql.NullString
+	Language      sql.NullString
+	RelativePath  string
+	Size          sql.NullInt64
+	IsPrimary     bool
+	Position      int32
+	CreatedAt     sql.NullTime
+}
+
+type ContentNote struct {
+	ID            uuid.UUID
+	ContentItemID uuid.UUID
+	UserID        uuid.UUID
+	Content       string
+	Position      sql.NullFloat64
+	CreatedAt     sql.NullTime
+	UpdatedAt     sql.NullTime
+}
+
+type ContentShareLink struct {
+	ID            uuid.UUID
+	TokenHash     string
+	ContentItemID uuid.UUID
+	CreatedBy     uuid.NullUUID
+	PasswordHash  sql.NullString
+	ExpiresAt     time.Time
+	RevokedAt     sql.NullTime
+	ViewCount     int32
+	LastViewedAt  sql.NullTime
+	CreatedAt     sql.NullTime
+}
+
+type ContentTiering struct {
+	ContentItemID  uuid.UUID
+	Tier           string
+	AccessCount    int32
+	LastAccessedAt sql.NullTime
+	ColdKey        sql.NullString
+	TieredAt       sql.NullTime
+	UpdatedAt      sql.NullTime
+}
+
+type ContentTranscript struct {
+	ContentItemID uuid.UUID
+	Provider      string
+	Language      sql.NullString
+	Vtt           string
+	Text          string
+	CreatedAt     sql.NullTime
+	UpdatedAt     sql.NullTime
+}
+
+type ContentTypeMapping struct {
+	Extension   string
+	ContentType string
+	CreatedBy   uuid.NullUUID
+	CreatedAt   sql.NullTime
+	UpdatedAt   sql.NullTime
 }
 
 type Course struct {
@@ -32,6 +176,153 @@ type Course struct {
 	RelativePath string
 	CreatedAt    sql.NullTime
 	UpdatedAt    sql.NullTime
+	Tags         []string
+	Difficulty   sql.NullString
+	ClonedFrom   uuid.NullUUID
+	Language     sql.NullString
+}
+
+type CourseAssignment struct {
+	CourseID   uuid.UUID
+	ProfileID  uuid.UUID
+	AssignedBy uuid.NullUUID
+	CreatedAt  sql.NullTime
+}
+
+type CourseEnrollment struct {
+	CourseID  uuid.UUID
+	UserID    uuid.UUID
+	CreatedAt sql.NullTime
+}
+
+type CourseFavorite struct {
+	CourseID  uuid.UUID
+	UserID    uuid.UUID
+	CreatedAt sql.NullTime
+}
+
+type CourseInstructor struct {
+	CourseID     uuid.UUID
+	InstructorID uuid.UUID
+	Position     int32
+}
+
+type CourseNote struct {
+	ID        uuid.UUID
+	CourseID  uuid.UUID
+	UserID    uuid.UUID
+	Content   string
+	CreatedAt sql.NullTime
+	UpdatedAt sql.NullTime
+}
+
+type CoursePrerequisite struct {
+	CourseID       uuid.UUID
+	PrerequisiteID uuid.UUID
+	CreatedAt      sql.NullTime
+}
+
+type CourseSortOrder struct {
+	UserID    uuid.UUID
+	CourseIds []uuid.UUID
+	UpdatedAt sql.NullTime
+}
+
+type DailyActivity struct {
+	ProfileID uuid.UUID
+	Day       time.Time
+	Updates   int32
+	Repaired  bool
+}
+
+type EmailVerification struct {
+	ProfileID uuid.UUID
+	Email     string
+	TokenHash string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+type GamificationLedger struct {
+	ID          uuid.UUID
+	ProfileID   uuid.UUID
+	Stat        string
+	Amount      int32
+	Balance     int32
+	Reason      string
+	Subject     string
+	SourceEvent string
+	Description string
+	CreatedAt   sql.NullTime
+}
+
+type GoalEvaluation struct {
+	GoalID  uuid.UUID
+	Day     time.Time
+	Met     bool
+	Minutes int32
+	Items   int32
+	Frozen  bool
+}
+
+type IdempotencyKey struct {
+	ProfileID    uuid.UUID
+	Route        string
+	Key          string
+	RequestHash  string
+	StatusCode   sql.NullInt32
+	ResponseBody sql.NullString
+	CreatedAt    time.Time
+}
+
+type Instructor struct {
+	ID        uuid.UUID
+	Name      string
+	Bio       sql.NullString
+	AvatarUrl sql.NullString
+	CreatedAt sql.NullTime
+	UpdatedAt sql.NullTime
+}
+
+type LearningGoal struct {
+	ID               uuid.UUID
+	ProfileID        uuid.UUID
+	GoalType         string
+	TargetMinutes    sql.NullInt32
+	CourseID         uuid.NullUUID
+	DueDate          sql.NullTime
+	Status           string
+	Streak           int32
+	LongestStreak    int32
+	EvaluatedThrough sql.NullTime
+	CreatedAt        sql.NullTime
+	UpdatedAt        sql.NullTime
+	TargetItems      sql.NullInt32
+}
+
+type LearningPath struct {
+	ID          uuid.UUID
+	Title       string
+	Description sql.NullString
+	CreatorID   uuid.NullUUID
+	CreatedAt   sql.NullTime
+	UpdatedAt   sql.NullTime
+}
+
+type LearningPathCourse struct {
+	PathID   uuid.UUID
+	CourseID uuid.UUID
+	Position int32
+}
+
+type LibrarySnapshot struct {
+	ID          uuid.UUID
+	Label       string
+	CreatedBy   uuid.NullUUID
+	CourseCount int32
+	Data        string
+	RestoredAt  sql.NullTime
+	CreatedAt   sql.NullTime
 }
 
 type Module struct {
@@ -45,18 +336,112 @@ type Module struct {
 	UpdatedAt    sql.NullTime
 }
 
+type Notification struct {
+	ID          uuid.UUID
+	ProfileID   uuid.UUID
+	Category    string
+	Title       string
+	Body        sql.NullString
+	DeliveredAt sql.NullTime
+	CreatedAt   sql.NullTime
+}
+
+type NotificationPreference struct {
+	ProfileID    uuid.UUID
+	Category     string
+	Frequency    string
+	LastDigestAt sql.NullTime
+	UpdatedAt    sql.NullTime
+}
+
 type Profile struct {
-	ID        uuid.UUID
-	Name      string
+	ID                uuid.UUID
+	Name              string
+	CreatedAt         sql.NullTime
+	UpdatedAt         sql.NullTime
+	CoursesRestricted bool
+	Streak            int32
+	LongestStreak     int32
+	LastActiveDate    sql.NullTime
+	Experience        int32
+	Gems              int32
+	StreakFreezes     int32
+	Role              string
+	AvatarUpdatedAt   sql.NullTime
+	Email             sql.NullString
+	EmailVerifiedAt   sql.NullTime
+	IsGuest           bool
+}
+
+type ProfileIdentity struct {
+	Issuer      string
+	Subject     string
+	ProfileID   uuid.UUID
+	Email       sql.NullString
+	CreatedAt   time.Time
+	LastLoginAt time.Time
+}
+
+type ProfileTimeLimit struct {
+	ProfileID     uuid.UUID
+	DailyMinutes  sql.NullInt32
+	WeeklyMinutes sql.NullInt32
+	WarningPct    int32
+	CreatedAt     sql.NullTime
+	UpdatedAt     sql.NullTime
+}
+
+type ProfileUnlock struct {
+	ProfileID uuid.UUID
+	Item      string
 	CreatedAt sql.NullTime
-	UpdatedAt sql.NullTime
+}
+
+type Quest struct {
+	ID          uuid.UUID
+	ProfileID   uuid.UUID
+	Week        time.Time
+	QuestType   string
+	Title       string
+	Target      int32
+	Progress    int32
+	CourseID    uuid.NullUUID
+	RewardXp    int32
+	RewardGems  int32
+	CompletedAt sql.NullTime
+	CreatedAt   sql.NullTime
 }
 
 type Session struct {
-	ID        uuid.UUID
-	UserID    uuid.UUID
-	CreatedAt sql.NullTime
-	UpdatedAt sql.NullTime
+	ID                     uuid.UUID
+	UserID                 uuid.UUID
+	CreatedAt              sql.NullTime
+	UpdatedAt              sql.NullTime
+	ImpersonatedID         uuid.NullUUID
+	ImpersonationExpiresAt sql.NullTime
+	ExpiresAt              time.Time
+	UserAgent              string
+	LastSeenAt             time.Time
+}
+
+type StreakFreezeDay struct {
+	ProfileID uuid.UUID
+	Day       time.Time
+}
+
+type Task struct {
+	ID           uuid.UUID
+	Type         string
+	Status       string
+	Input        string
+	Result       string
+	Message      string
+	ErrorMessage string
+	Retries      int32
+	Logs         string
+	CreatedAt    time.Time
+	StartedAt    sql.NullTime
+	CompletedAt  sql.NullTime
 }
 
 type UserProgress struct {
@@ -69,4 +454,30 @@ type UserProgress struct {
 	LastAccessed  sql.NullTime
 	CreatedAt     sql.NullTime
 	UpdatedAt     sql.NullTime
+	CompletedAt   sql.NullTime
+}
+
+type ViewingTimeDaily struct {
+	ProfileID uuid.UUID
+	Day       time.Time
+	Seconds   int32
+}
+
+type WatchSession struct {
+	ID            uuid.UUID
+	ProfileID     uuid.UUID
+	ContentItemID uuid.UUID
+	StartedAt     time.Time
+	LastBeatAt    time.Time
+	Seconds       int32
+	Suspicious    bool
+	FlagReason    sql.NullString
+}
+
+type XpAward struct {
+	ProfileID uuid.UUID
+	Source    string
+	SubjectID uuid.UUID
+	Xp        int32
+	CreatedAt sql.NullTime
 }
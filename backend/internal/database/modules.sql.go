@@ -10,6 +10,7 @@ import (
 	"database/sql"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 const createModule = `-- name: CreateModule :one
@@ -68,6 +69,21 @@ func (q *Queries) DeleteModule(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+const deleteModulesNotIn = `-- name: DeleteModulesNotIn :exec
+DELETE FROM modules
+WHERE course_id = $1 AND NOT (id = ANY($2::uuid[]))
+`
+
+type DeleteModulesNotInParams struct {
+	CourseID uuid.UUID
+	KeepIds  []uuid.UUID
+}
+
+func (q *Queries) DeleteModulesNotIn(ctx context.Context, arg DeleteModulesNotInParams) error {
+	_, err := q.db.ExecContext(ctx, deleteModulesNotIn, arg.CourseID, pq.Array(arg.KeepIds))
+	return err
+}
+
 const getModule = `-- name: GetModule :one
 SELECT id, course_id, title, description, relative_path, "order", created_at, updated_at FROM modules
 WHERE id = $1
@@ -89,6 +105,43 @@ func (q *Queries) GetModule(ctx context.Context, id uuid.UUID) (Module, error) {
 	return i, err
 }
 
+const listAllModules = `-- name: ListAllModules :many
+SELECT id, course_id, title, description, relative_path, "order", created_at, updated_at FROM modules
+ORDER BY course_id, "order" ASC
+`
+
+func (q *Queries) ListAllModules(ctx context.Context) ([]Module, error) {
+	rows, err := q.db.QueryContext(ctx, listAllModules)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Module
+	for rows.Next() {
+		var i Module
+		if err := rows.Scan(
+			&i.ID,
+			&i.CourseID,
+			&i.Title,
+			&i.Description,
+			&i.RelativePath,
+			&i.Order,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listModulesByCourse = `-- name: ListModulesByCourse :many
 SELECT id, course_id, title, description, relative_path, "order", created_at, updated_at FROM modules
 WHERE course_id = $1
@@ -127,6 +180,40 @@ func (q *Queries) ListModulesByCourse(ctx context.Context, courseID uuid.UUID) (
 	return items, nil
 }
 
+const restoreModule = `-- name: RestoreModule :exec
+INSERT INTO modules (id, course_id, title, description, relative_path, "order", updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, now())
+ON CONFLICT (id)
+DO UPDATE SET
+    course_id = EXCLUDED.course_id,
+    title = EXCLUDED.title,
+    description = EXCLUDED.description,
+    relative_path = EXCLUDED.relative_path,
+    "order" = EXCLUDED."order",
+    updated_at = now()
+`
+
+type RestoreModuleParams struct {
+	ID           uuid.UUID
+	CourseID     uuid.UUID
+	Title        string
+	Description  sql.NullString
+	RelativePath string
+	Order        int32
+}
+
+func (q *Queries) RestoreModule(ctx context.Context, arg RestoreModuleParams) error {
+	_, err := q.db.ExecContext(ctx, restoreModule,
+		arg.ID,
+		arg.CourseID,
+		arg.Title,
+		arg.Description,
+		arg.RelativePath,
+		arg.Order,
+	)
+	return err
+}
+
 const updateModule = `-- name: UpdateModule :one
 UPDATE modules
 SET
@@ -165,3 +252,39 @@ func (q *Queries) UpdateModule(ctx context.Context, arg UpdateModuleParams) (Mod
 	)
 	return i, err
 }
+
+const updateModuleOrder = `-- name: UpdateModuleOrder :exec
+UPDATE modules
+SET
+    "order" = $2,
+    updated_at = now()
+WHERE id = $1
+`
+
+type UpdateModuleOrderParams struct {
+	ID    uuid.UUID
+	Order int32
+}
+
+func (q *Queries) UpdateModuleOrder(ctx context.Context, arg UpdateModuleOrderParams) error {
+	_, err := q.db.ExecContext(ctx, updateModuleOrder, arg.ID, arg.Order)
+	return err
+}
+
+const updateModuleTitle = `-- name: UpdateModuleTitle :exec
+UPDATE modules
+SET
+    title = $2,
+    updated_at = now()
+WHERE id = $1
+`
+
+type UpdateModuleTitleParams struct {
+	ID    uuid.UUID
+	Title string
+}
+
+func (q *Queries) UpdateModuleTitle(ctx context.Context, arg UpdateModuleTitleParams) error {
+	_, err := q.db.ExecContext(ctx, updateModuleTitle, arg.ID, arg.Title)
+	return err
+}
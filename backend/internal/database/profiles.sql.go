@@ -11,34 +11,128 @@ import (
 	"github.com/google/uuid"
 )
 
+const createGuestProfile = `-- name: CreateGuestProfile :one
+INSERT INTO profiles (id, created_at, updated_at, name, is_guest)
+VALUES ($1, now(), now(), $2, true)
+RETURNING id, name, created_at, updated_at, courses_restricted, streak, longest_streak, last_active_date, experience, gems, streak_freezes, role, avatar_updated_at, email, email_verified_at, is_guest
+`
+
+type CreateGuestProfileParams struct {
+	ID   uuid.UUID
+	Name string
+}
+
+func (q *Queries) CreateGuestProfile(ctx context.Context, arg CreateGuestProfileParams) (Profile, error) {
+	row := q.db.QueryRowContext(ctx, createGuestProfile, arg.ID, arg.Name)
+	var i Profile
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CoursesRestricted,
+		&i.Streak,
+		&i.LongestStreak,
+		&i.LastActiveDate,
+		&i.Experience,
+		&i.Gems,
+		&i.StreakFreezes,
+		&i.Role,
+		&i.AvatarUpdatedAt,
+		&i.Email,
+		&i.EmailVerifiedAt,
+		&i.IsGuest,
+	)
+	return i, err
+}
+
 const createProfile = `-- name: CreateProfile :one
-INSERT INTO profiles (id, created_at, updated_at, name)
+INSERT INTO profiles (id, created_at, updated_at, name, role)
 VALUES (
     $1,
     now(),
     now(),
-    $2
+    $2,
+    $3
 )
-RETURNING id, name, created_at, updated_at
+RETURNING id, name, created_at, updated_at, courses_restricted, streak, longest_streak, last_active_date, experience, gems, streak_freezes, role, avatar_updated_at, email, email_verified_at, is_guest
 `
 
 type CreateProfileParams struct {
 	ID   uuid.UUID
 	Name string
+	Role string
 }
 
 func (q *Queries) CreateProfile(ctx context.Context, arg CreateProfileParams) (Profile, error) {
-	row := q.db.QueryRowContext(ctx, createProfile, arg.ID, arg.Name)
+	row := q.db.QueryRowContext(ctx, createProfile, arg.ID, arg.Name, arg.Role)
 	var i Profile
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CoursesRestricted,
+		&i.Streak,
+		&i.LongestStreak,
+		&i.LastActiveDate,
+		&i.Experience,
+		&i.Gems,
+		&i.StreakFreezes,
+		&i.Role,
+		&i.AvatarUpdatedAt,
+		&i.Email,
+		&i.EmailVerifiedAt,
+		&i.IsGuest,
 	)
 	return i, err
 }
 
+const deleteAbandonedGuestProfiles = `-- name: DeleteAbandonedGuestProfiles :many
+DELETE FROM profiles
+WHERE is_guest AND NOT EXISTS (
+    SELECT 1 FROM sessions s WHERE s.user_id = profiles.id
+)
+RETURNING id
+`
+
+func (q *Queries) DeleteAbandonedGuestProfiles(ctx context.Context) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, deleteAbandonedGuestProfiles)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteGuestProfile = `-- name: DeleteGuestProfile :execrows
+DELETE
+FROM profiles
+WHERE id = $1 AND is_guest
+`
+
+func (q *Queries) DeleteGuestProfile(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteGuestProfile, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const deleteProfile = `-- name: DeleteProfile :exec
 DELETE
 FROM profiles
@@ -51,7 +145,8 @@ func (q *Queries) DeleteProfile(ctx context.Context, id uuid.UUID) error {
 }
 
 const getAllProfiles = `-- name: GetAllProfiles :many
-SELECT id, name, created_at, updated_at FROM profiles
+SELECT id, name, created_at, updated_at, courses_restricted, streak, longest_streak, last_active_date, experience, gems, streak_freezes, role, avatar_updated_at, email, email_verified_at, is_guest FROM profiles
+WHERE NOT is_guest
 `
 
 func (q *Queries) GetAllProfiles(ctx context.Context) ([]Profile, error) {
@@ -68,6 +163,18 @@ func (q *Queries) GetAllProfiles(ctx context.Context) ([]Profile, error) {
 			&i.Name,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.CoursesRestricted,
+			&i.Streak,
+			&i.LongestStreak,
+			&i.LastActiveDate,
+			&i.Experience,
+			&i.Gems,
+			&i.StreakFreezes,
+			&i.Role,
+			&i.AvatarUpdatedAt,
+			&i.Email,
+			&i.EmailVerifiedAt,
+			&i.IsGuest,
 		); err != nil {
 			return nil, err
 		}
@@ -83,7 +190,7 @@ func (q *Queries) GetAllProfiles(ctx context.Context) ([]Profile, error) {
 }
 
 const getProfileById = `-- name: GetProfileById :one
-SELECT id, name, created_at, updated_at
+SELECT id, name, created_at, updated_at, courses_restricted, streak, longest_streak, last_active_date, experience, gems, streak_freezes, role, avatar_updated_at, email, email_verified_at, is_guest
 FROM profiles
 WHERE id = $1
 `
@@ -96,12 +203,24 @@ func (q *Queries) GetProfileById(ctx context.Context, id uuid.UUID) (Profile, er
 		&i.Name,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CoursesRestricted,
+		&i.Streak,
+		&i.LongestStreak,
+		&i.LastActiveDate,
+		&i.Experience,
+		&i.Gems,
+		&i.StreakFreezes,
+		&i.Role,
+		&i.AvatarUpdatedAt,
+		&i.Email,
+		&i.EmailVerifiedAt,
+		&i.IsGuest,
 	)
 	return i, err
 }
 
 const getProfileByName = `-- name: GetProfileByName :one
-SELECT id, name, created_at, updated_at
+SELECT id, name, created_at, updated_at, courses_restricted, streak, longest_streak, last_active_date, experience, gems, streak_freezes, role, avatar_updated_at, email, email_verified_at, is_guest
 FROM profiles
 WHERE name = $1
 `
@@ -114,12 +233,24 @@ func (q *Queries) GetProfileByName(ctx context.Context, name string) (Profile, e
 		&i.Name,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CoursesRestricted,
+		&i.Streak,
+		&i.LongestStreak,
+		&i.LastActiveDate,
+		&i.Experience,
+		&i.Gems,
+		&i.StreakFreezes,
+		&i.Role,
+		&i.AvatarUpdatedAt,
+		&i.Email,
+		&i.EmailVerifiedAt,
+		&i.IsGuest,
 	)
 	return i, err
 }
 
 const getProfilesByNamePattern = `-- name: GetProfilesByNamePattern :many
-SELECT id, name, created_at, updated_at
+SELECT id, name, created_at, updated_at, courses_restricted, streak, longest_streak, last_active_date, experience, gems, streak_freezes, role, avatar_updated_at, email, email_verified_at, is_guest
 FROM profiles
 WHERE name LIKE $1
 `
@@ -138,6 +269,18 @@ func (q *Queries) GetProfilesByNamePattern(ctx context.Context, name string) ([]
 			&i.Name,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.CoursesRestricted,
+			&i.Streak,
+			&i.LongestStreak,
+			&i.LastActiveDate,
+			&i.Experience,
+			&i.Gems,
+			&i.StreakFreezes,
+			&i.Role,
+			&i.AvatarUpdatedAt,
+			&i.Email,
+			&i.EmailVerifiedAt,
+			&i.IsGuest,
 		); err != nil {
 			return nil, err
 		}
@@ -155,6 +298,7 @@ func (q *Queries) GetProfilesByNamePattern(ctx context.Context, name string) ([]
 const getProfilesCount = `-- name: GetProfilesCount :one
 SELECT COUNT(*)
 FROM profiles
+WHERE NOT is_guest
 `
 
 func (q *Queries) GetProfilesCount(ctx context.Context) (int64, error) {
@@ -164,12 +308,90 @@ func (q *Queries) GetProfilesCount(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const lockProfiles = `-- name: LockProfiles :exec
+LOCK TABLE profiles IN SHARE ROW EXCLUSIVE MODE
+`
+
+func (q *Queries) LockProfiles(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, lockProfiles)
+	return err
+}
+
+const setProfileAvatarUpdated = `-- name: SetProfileAvatarUpdated :one
+UPDATE profiles
+SET avatar_updated_at = now(),
+    updated_at        = now()
+WHERE id = $1
+RETURNING id, name, created_at, updated_at, courses_restricted, streak, longest_streak, last_active_date, experience, gems, streak_freezes, role, avatar_updated_at, email, email_verified_at, is_guest
+`
+
+func (q *Queries) SetProfileAvatarUpdated(ctx context.Context, id uuid.UUID) (Profile, error) {
+	row := q.db.QueryRowContext(ctx, setProfileAvatarUpdated, id)
+	var i Profile
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CoursesRestricted,
+		&i.Streak,
+		&i.LongestStreak,
+		&i.LastActiveDate,
+		&i.Experience,
+		&i.Gems,
+		&i.StreakFreezes,
+		&i.Role,
+		&i.AvatarUpdatedAt,
+		&i.Email,
+		&i.EmailVerifiedAt,
+		&i.IsGuest,
+	)
+	return i, err
+}
+
+const setProfileRole = `-- name: SetProfileRole :one
+UPDATE profiles
+SET role       = $2,
+    updated_at = now()
+WHERE id = $1
+RETURNING id, name, created_at, updated_at, courses_restricted, streak, longest_streak, last_active_date, experience, gems, streak_freezes, role, avatar_updated_at, email, email_verified_at, is_guest
+`
+
+type SetProfileRoleParams struct {
+	ID   uuid.UUID
+	Role string
+}
+
+func (q *Queries) SetProfileRole(ctx context.Context, arg SetProfileRoleParams) (Profile, error) {
+	row := q.db.QueryRowContext(ctx, setProfileRole, arg.ID, arg.Role)
+	var i Profile
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CoursesRestricted,
+		&i.Streak,
+		&i.LongestStreak,
+		&i.LastActiveDate,
+		&i.Experience,
+		&i.Gems,
+		&i.StreakFreezes,
+		&i.Role,
+		&i.AvatarUpdatedAt,
+		&i.Email,
+		&i.EmailVerifiedAt,
+		&i.IsGuest,
+	)
+	return i, err
+}
+
 const updateProfileByID = `-- name: UpdateProfileByID :one
 UPDATE profiles
 SET name       = $2,
     updated_at = now()
 WHERE id = $1
-RETURNING id, name, created_at, updated_at
+RETURNING id, name, created_at, updated_at, courses_restricted, streak, longest_streak, last_active_date, experience, gems, streak_freezes, role, avatar_updated_at, email, email_verified_at, is_guest
 `
 
 type UpdateProfileByIDParams struct {
@@ -185,6 +407,18 @@ func (q *Queries) UpdateProfileByID(ctx context.Context, arg UpdateProfileByIDPa
 		&i.Name,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CoursesRestricted,
+		&i.Streak,
+		&i.LongestStreak,
+		&i.LastActiveDate,
+		&i.Experience,
+		&i.Gems,
+		&i.StreakFreezes,
+		&i.Role,
+		&i.AvatarUpdatedAt,
+		&i.Email,
+		&i.EmailVerifiedAt,
+		&i.IsGuest,
 	)
 	return i, err
 }
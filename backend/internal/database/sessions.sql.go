@@ -7,34 +7,51 @@ package database
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 const createSession = `-- name: CreateSession :one
-INSERT INTO sessions (id, user_id, created_at, updated_at)
+INSERT INTO sessions (id, user_id, expires_at, user_agent, last_seen_at, created_at, updated_at)
 VALUES (
     $1,
     $2,
+    $3,
+    $4,
+    now(),
     now(),
     now()
 )
-RETURNING id, user_id, created_at, updated_at
+RETURNING id, user_id, created_at, updated_at, impersonated_id, impersonation_expires_at, expires_at, user_agent, last_seen_at
 `
 
 type CreateSessionParams struct {
-	ID     uuid.UUID
-	UserID uuid.UUID
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	ExpiresAt time.Time
+	UserAgent string
 }
 
 func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error) {
-	row := q.db.QueryRowContext(ctx, createSession, arg.ID, arg.UserID)
+	row := q.db.QueryRowContext(ctx, createSession,
+		arg.ID,
+		arg.UserID,
+		arg.ExpiresAt,
+		arg.UserAgent,
+	)
 	var i Session
 	err := row.Scan(
 		&i.ID,
 		&i.UserID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ImpersonatedID,
+		&i.ImpersonationExpiresAt,
+		&i.ExpiresAt,
+		&i.UserAgent,
+		&i.LastSeenAt,
 	)
 	return i, err
 }
@@ -48,6 +65,19 @@ func (q *Queries) DeleteAllSessions(ctx context.Context) error {
 	return err
 }
 
+const deleteExpiredSessions = `-- name: DeleteExpiredSessions :execrows
+DELETE FROM sessions
+WHERE expires_at < $1
+`
+
+func (q *Queries) DeleteExpiredSessions(ctx context.Context, expiresAt time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteExpiredSessions, expiresAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const deleteSession = `-- name: DeleteSession :exec
 DELETE FROM sessions
 WHERE id = $1
@@ -58,37 +88,137 @@ func (q *Queries) DeleteSession(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
-const getActiveSession = `-- name: GetActiveSession :one
-SELECT id, user_id, created_at, updated_at FROM sessions
-ORDER BY created_at DESC
-LIMIT 1
+const getSessionByID = `-- name: GetSessionByID :one
+SELECT id, user_id, created_at, updated_at, impersonated_id, impersonation_expires_at, expires_at, user_agent, last_seen_at FROM sessions
+WHERE id = $1
 `
 
-func (q *Queries) GetActiveSession(ctx context.Context) (Session, error) {
-	row := q.db.QueryRowContext(ctx, getActiveSession)
+func (q *Queries) GetSessionByID(ctx context.Context, id uuid.UUID) (Session, error) {
+	row := q.db.QueryRowContext(ctx, getSessionByID, id)
 	var i Session
 	err := row.Scan(
 		&i.ID,
 		&i.UserID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ImpersonatedID,
+		&i.ImpersonationExpiresAt,
+		&i.ExpiresAt,
+		&i.UserAgent,
+		&i.LastSeenAt,
 	)
 	return i, err
 }
 
-const getSessionByID = `-- name: GetSessionByID :one
-SELECT id, user_id, created_at, updated_at FROM sessions
+const listSessionsByProfile = `-- name: ListSessionsByProfile :many
+SELECT id, user_id, created_at, updated_at, impersonated_id, impersonation_expires_at, expires_at, user_agent, last_seen_at FROM sessions
+WHERE user_id = $1
+ORDER BY last_seen_at DESC
+`
+
+func (q *Queries) ListSessionsByProfile(ctx context.Context, userID uuid.UUID) ([]Session, error) {
+	rows, err := q.db.QueryContext(ctx, listSessionsByProfile, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Session
+	for rows.Next() {
+		var i Session
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ImpersonatedID,
+			&i.ImpersonationExpiresAt,
+			&i.ExpiresAt,
+			&i.UserAgent,
+			&i.LastSeenAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const refreshSession = `-- name: RefreshSession :one
+UPDATE sessions
+SET expires_at = $2,
+    updated_at = now()
 WHERE id = $1
+RETURNING id, user_id, created_at, updated_at, impersonated_id, impersonation_expires_at, expires_at, user_agent, last_seen_at
 `
 
-func (q *Queries) GetSessionByID(ctx context.Context, id uuid.UUID) (Session, error) {
-	row := q.db.QueryRowContext(ctx, getSessionByID, id)
+type RefreshSessionParams struct {
+	ID        uuid.UUID
+	ExpiresAt time.Time
+}
+
+func (q *Queries) RefreshSession(ctx context.Context, arg RefreshSessionParams) (Session, error) {
+	row := q.db.QueryRowContext(ctx, refreshSession, arg.ID, arg.ExpiresAt)
 	var i Session
 	err := row.Scan(
 		&i.ID,
 		&i.UserID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ImpersonatedID,
+		&i.ImpersonationExpiresAt,
+		&i.ExpiresAt,
+		&i.UserAgent,
+		&i.LastSeenAt,
 	)
 	return i, err
 }
+
+const setSessionImpersonation = `-- name: SetSessionImpersonation :one
+UPDATE sessions
+SET
+    impersonated_id = $2,
+    impersonation_expires_at = $3,
+    updated_at = now()
+WHERE id = $1
+RETURNING id, user_id, created_at, updated_at, impersonated_id, impersonation_expires_at, expires_at, user_agent, last_seen_at
+`
+
+type SetSessionImpersonationParams struct {
+	ID                     uuid.UUID
+	ImpersonatedID         uuid.NullUUID
+	ImpersonationExpiresAt sql.NullTime
+}
+
+func (q *Queries) SetSessionImpersonation(ctx context.Context, arg SetSessionImpersonationParams) (Session, error) {
+	row := q.db.QueryRowContext(ctx, setSessionImpersonation, arg.ID, arg.ImpersonatedID, arg.ImpersonationExpiresAt)
+	var i Session
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ImpersonatedID,
+		&i.ImpersonationExpiresAt,
+		&i.ExpiresAt,
+		&i.UserAgent,
+		&i.LastSeenAt,
+	)
+	return i, err
+}
+
+const touchSession = `-- name: TouchSession :exec
+UPDATE sessions
+SET last_seen_at = now()
+WHERE id = $1
+`
+
+func (q *Queries) TouchSession(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, touchSession, id)
+	return err
+}
@@ -10,8 +10,124 @@ import (
 	"database/sql"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
+const completeCourseItems = `-- name: CompleteCourseItems :execrows
+INSERT INTO user_progress (
+    id, user_id, content_item_id, completed, progress_pct, last_accessed, completed_at, created_at, updated_at
+)
+SELECT gen_random_uuid(), $1::uuid, ci.id, true, 100, now(), now(), now(), now()
+FROM content_items ci
+JOIN modules m ON ci.module_id = m.id
+WHERE m.course_id = $2 AND NOT ci.hidden
+ON CONFLICT (user_id, content_item_id)
+DO UPDATE SET
+    completed = true,
+    progress_pct = 100,
+    last_accessed = now(),
+    completed_at = COALESCE(user_progress.completed_at, now()),
+    updated_at = now()
+WHERE NOT user_progress.completed OR user_progress.progress_pct < 100
+`
+
+type CompleteCourseItemsParams struct {
+	UserID   uuid.UUID
+	CourseID uuid.UUID
+}
+
+func (q *Queries) CompleteCourseItems(ctx context.Context, arg CompleteCourseItemsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, completeCourseItems, arg.UserID, arg.CourseID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const completeModuleItems = `-- name: CompleteModuleItems :execrows
+INSERT INTO user_progress (
+    id, user_id, content_item_id, completed, progress_pct, last_accessed, completed_at, created_at, updated_at
+)
+SELECT gen_random_uuid(), $1::uuid, ci.id, true, 100, now(), now(), now(), now()
+FROM content_items ci
+WHERE ci.module_id = $2 AND NOT ci.hidden
+ON CONFLICT (user_id, content_item_id)
+DO UPDATE SET
+    completed = true,
+    progress_pct = 100,
+    last_accessed = now(),
+    completed_at = COALESCE(user_progress.completed_at, now()),
+    updated_at = now()
+WHERE NOT user_progress.completed OR user_progress.progress_pct < 100
+`
+
+type CompleteModuleItemsParams struct {
+	UserID   uuid.UUID
+	ModuleID uuid.UUID
+}
+
+func (q *Queries) CompleteModuleItems(ctx context.Context, arg CompleteModuleItemsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, completeModuleItems, arg.UserID, arg.ModuleID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const copyCourseProgress = `-- name: CopyCourseProgress :execrows
+INSERT INTO user_progress (id, user_id, content_item_id, completed, progress_pct, last_position, last_accessed, completed_at, created_at, updated_at)
+SELECT gen_random_uuid(), $1::uuid, content_item_id, completed, progress_pct, last_position, last_accessed, completed_at, created_at, now()
+FROM user_progress
+WHERE user_id = $2 AND content_item_id IN (
+    SELECT ci.id FROM content_items ci
+    JOIN modules m ON ci.module_id = m.id
+    WHERE m.course_id = $3
+)
+ON CONFLICT (user_id, content_item_id) DO UPDATE
+SET completed = user_progress.completed OR EXCLUDED.completed,
+    progress_pct = GREATEST(user_progress.progress_pct, EXCLUDED.progress_pct),
+    last_position = CASE WHEN EXCLUDED.progress_pct > user_progress.progress_pct THEN EXCLUDED.last_position ELSE user_progress.last_position END,
+    last_accessed = GREATEST(user_progress.last_accessed, EXCLUDED.last_accessed),
+    completed_at = LEAST(user_progress.completed_at, EXCLUDED.completed_at),
+    updated_at = now()
+`
+
+type CopyCourseProgressParams struct {
+	TargetID uuid.UUID
+	SourceID uuid.UUID
+	CourseID uuid.UUID
+}
+
+func (q *Queries) CopyCourseProgress(ctx context.Context, arg CopyCourseProgressParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, copyCourseProgress, arg.TargetID, arg.SourceID, arg.CourseID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteUserCourseProgress = `-- name: DeleteUserCourseProgress :execrows
+DELETE FROM user_progress
+WHERE user_id = $1 AND content_item_id IN (
+    SELECT ci.id FROM content_items ci
+    JOIN modules m ON ci.module_id = m.id
+    WHERE m.course_id = $2
+)
+`
+
+type DeleteUserCourseProgressParams struct {
+	UserID   uuid.UUID
+	CourseID uuid.UUID
+}
+
+func (q *Queries) DeleteUserCourseProgress(ctx context.Context, arg DeleteUserCourseProgressParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteUserCourseProgress, arg.UserID, arg.CourseID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const getCourseProgressStats = `-- name: GetCourseProgressStats :one
 SELECT
     COUNT(DISTINCT m.id) as total_modules,
@@ -19,7 +135,7 @@ SELECT
     COUNT(DISTINCT ci.id) FILTER (WHERE up.completed = true) as completed_items,
     MAX(up.last_accessed) as last_accessed
 FROM modules m
-LEFT JOIN content_items ci ON m.id = ci.module_id
+LEFT JOIN content_items ci ON m.id = ci.module_id AND NOT ci.hidden
 LEFT JOIN user_progress up ON ci.id = up.content_item_id AND up.user_id = $2
 WHERE m.course_id = $1
 `
@@ -55,7 +171,7 @@ SELECT
     COALESCE(AVG(up.progress_pct), 0) as avg_progress
 FROM content_items ci
 LEFT JOIN user_progress up ON ci.id = up.content_item_id AND up.user_id = $2
-WHERE ci.module_id = $1
+WHERE ci.module_id = $1 AND NOT ci.hidden
 `
 
 type GetModuleProgressStatsParams struct {
@@ -77,7 +193,7 @@ func (q *Queries) GetModuleProgressStats(ctx context.Context, arg GetModuleProgr
 }
 
 const getUserProgressByContentItem = `-- name: GetUserProgressByContentItem :one
-SELECT id, user_id, content_item_id, completed, progress_pct, last_position, last_accessed, created_at, updated_at FROM user_progress
+SELECT id, user_id, content_item_id, completed, progress_pct, last_position, last_accessed, created_at, updated_at, completed_at FROM user_progress
 WHERE user_id = $1 AND content_item_id = $2
 `
 
@@ -99,12 +215,132 @@ func (q *Queries) GetUserProgressByContentItem(ctx context.Context, arg GetUserP
 		&i.LastAccessed,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CompletedAt,
 	)
 	return i, err
 }
 
+const listCoursesItemProgress = `-- name: ListCoursesItemProgress :many
+SELECT m.course_id, m.id AS module_id, ci.id AS content_item_id, ci.content_type, ci.duration, ci.size,
+    up.completed, up.progress_pct, up.last_position, up.last_accessed
+FROM modules m
+LEFT JOIN content_items ci ON ci.module_id = m.id AND NOT ci.hidden
+LEFT JOIN user_progress up ON up.content_item_id = ci.id AND up.user_id = $1
+WHERE m.course_id = ANY($2::uuid[])
+ORDER BY m.course_id, m."order", m.id, ci."order"
+`
+
+type ListCoursesItemProgressParams struct {
+	UserID    uuid.UUID
+	CourseIds []uuid.UUID
+}
+
+type ListCoursesItemProgressRow struct {
+	CourseID      uuid.UUID
+	ModuleID      uuid.UUID
+	ContentItemID uuid.NullUUID
+	ContentType   sql.NullString
+	Duration      sql.NullInt32
+	Size          sql.NullInt64
+	Completed     sql.NullBool
+	ProgressPct   sql.NullFloat64
+	LastPosition  sql.NullInt32
+	LastAccessed  sql.NullTime
+}
+
+func (q *Queries) ListCoursesItemProgress(ctx context.Context, arg ListCoursesItemProgressParams) ([]ListCoursesItemProgressRow, error) {
+	rows, err := q.db.QueryContext(ctx, listCoursesItemProgress, arg.UserID, pq.Array(arg.CourseIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCoursesItemProgressRow
+	for rows.Next() {
+		var i ListCoursesItemProgressRow
+		if err := rows.Scan(
+			&i.CourseID,
+			&i.ModuleID,
+			&i.ContentItemID,
+			&i.ContentType,
+			&i.Duration,
+			&i.Size,
+			&i.Completed,
+			&i.ProgressPct,
+			&i.LastPosition,
+			&i.LastAccessed,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listModuleItemProgress = `-- name: ListModuleItemProgress :many
+SELECT ci.id AS content_item_id, ci.content_type, ci.duration, ci.size,
+    up.completed, up.progress_pct, up.last_position, up.last_accessed
+FROM content_items ci
+LEFT JOIN user_progress up ON up.content_item_id = ci.id AND up.user_id = $1
+WHERE ci.module_id = $2 AND NOT ci.hidden
+ORDER BY ci."order"
+`
+
+type ListModuleItemProgressParams struct {
+	UserID   uuid.UUID
+	ModuleID uuid.UUID
+}
+
+type ListModuleItemProgressRow struct {
+	ContentItemID uuid.UUID
+	ContentType   string
+	Duration      sql.NullInt32
+	Size          sql.NullInt64
+	Completed     sql.NullBool
+	ProgressPct   sql.NullFloat64
+	LastPosition  sql.NullInt32
+	LastAccessed  sql.NullTime
+}
+
+func (q *Queries) ListModuleItemProgress(ctx context.Context, arg ListModuleItemProgressParams) ([]ListModuleItemProgressRow, error) {
+	rows, err := q.db.QueryContext(ctx, listModuleItemProgress, arg.UserID, arg.ModuleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListModuleItemProgressRow
+	for rows.Next() {
+		var i ListModuleItemProgressRow
+		if err := rows.Scan(
+			&i.ContentItemID,
+			&i.ContentType,
+			&i.Duration,
+			&i.Size,
+			&i.Completed,
+			&i.ProgressPct,
+			&i.LastPosition,
+			&i.LastAccessed,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listUserProgressByCourse = `-- name: ListUserProgressByCourse :many
-SELECT up.id, up.user_id, up.content_item_id, up.completed, up.progress_pct, up.last_position, up.last_accessed, up.created_at, up.updated_at FROM user_progress up
+SELECT up.id, up.user_id, up.content_item_id, up.completed, up.progress_pct, up.last_position, up.last_accessed, up.created_at, up.updated_at, up.completed_at FROM user_progress up
 JOIN content_items ci ON up.content_item_id = ci.id
 JOIN modules m ON ci.module_id = m.id
 WHERE m.course_id = $1 AND up.user_id = $2
@@ -135,6 +371,7 @@ func (q *Queries) ListUserProgressByCourse(ctx context.Context, arg ListUserProg
 			&i.LastAccessed,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.CompletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -149,20 +386,142 @@ func (q *Queries) ListUserProgressByCourse(ctx context.Context, arg ListUserProg
 	return items, nil
 }
 
+const listUserProgressRecords = `-- name: ListUserProgressRecords :many
+SELECT c.id AS course_id, c.title AS course_title, m.title AS module_title,
+    ci.id AS content_item_id, ci.title AS content_title, ci.content_type,
+    up.progress_pct, up.completed, up.completed_at, up.last_accessed
+FROM user_progress up
+JOIN content_items ci ON up.content_item_id = ci.id
+JOIN modules m ON ci.module_id = m.id
+JOIN courses c ON m.course_id = c.id
+WHERE up.user_id = $1
+ORDER BY c.title, c.id, m."order", ci."order"
+`
+
+type ListUserProgressRecordsRow struct {
+	CourseID      uuid.UUID
+	CourseTitle   string
+	ModuleTitle   string
+	ContentItemID uuid.UUID
+	ContentTitle  string
+	ContentType   string
+	ProgressPct   float32
+	Completed     bool
+	CompletedAt   sql.NullTime
+	LastAccessed  sql.NullTime
+}
+
+func (q *Queries) ListUserProgressRecords(ctx context.Context, userID uuid.UUID) ([]ListUserProgressRecordsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listUserProgressRecords, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUserProgressRecordsRow
+	for rows.Next() {
+		var i ListUserProgressRecordsRow
+		if err := rows.Scan(
+			&i.CourseID,
+			&i.CourseTitle,
+			&i.ModuleTitle,
+			&i.ContentItemID,
+			&i.ContentTitle,
+			&i.ContentType,
+			&i.ProgressPct,
+			&i.Completed,
+			&i.CompletedAt,
+			&i.LastAccessed,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const syncUserProgress = `-- name: SyncUserProgress :one
+INSERT INTO user_progress (
+    id, user_id, content_item_id, completed, progress_pct, last_position, last_accessed, completed_at, created_at, updated_at
+) VALUES (
+    gen_random_uuid(), $1, $2, $3, $4, $5, $6, CASE WHEN $3 THEN $6 END, now(), now()
+)
+ON CONFLICT (user_id, content_item_id)
+DO UPDATE SET
+    completed = user_progress.completed OR EXCLUDED.completed,
+    progress_pct = GREATEST(user_progress.progress_pct, EXCLUDED.progress_pct),
+    last_position = CASE
+        WHEN EXCLUDED.progress_pct > user_progress.progress_pct THEN EXCLUDED.last_position
+        WHEN EXCLUDED.progress_pct = user_progress.progress_pct
+            AND EXCLUDED.last_accessed >= COALESCE(user_progress.last_accessed, EXCLUDED.last_accessed) THEN EXCLUDED.last_position
+        ELSE user_progress.last_position
+    END,
+    last_accessed = GREATEST(user_progress.last_accessed, EXCLUDED.last_accessed),
+    completed_at = CASE
+        WHEN user_progress.completed OR EXCLUDED.completed THEN COALESCE(user_progress.completed_at, EXCLUDED.completed_at, now())
+    END,
+    updated_at = now()
+RETURNING id, user_id, content_item_id, completed, progress_pct, last_position, last_accessed, created_at, updated_at, completed_at
+`
+
+type SyncUserProgressParams struct {
+	UserID        uuid.UUID
+	ContentItemID uuid.UUID
+	Completed     bool
+	ProgressPct   float32
+	LastPosition  sql.NullInt32
+	LastAccessed  sql.NullTime
+}
+
+func (q *Queries) SyncUserProgress(ctx context.Context, arg SyncUserProgressParams) (UserProgress, error) {
+	row := q.db.QueryRowContext(ctx, syncUserProgress,
+		arg.UserID,
+		arg.ContentItemID,
+		arg.Completed,
+		arg.ProgressPct,
+		arg.LastPosition,
+		arg.LastAccessed,
+	)
+	var i UserProgress
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ContentItemID,
+		&i.Completed,
+		&i.ProgressPct,
+		&i.LastPosition,
+		&i.LastAccessed,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CompletedAt,
+	)
+	return i, err
+}
+
 const upsertUserProgress = `-- name: UpsertUserProgress :one
 INSERT INTO user_progress (
-    id, user_id, content_item_id, completed, progress_pct, last_position, last_accessed, created_at, updated_at
+    id, user_id, content_item_id, completed, progress_pct, last_position, last_accessed, completed_at, created_at, updated_at
 ) VALUES (
-    gen_random_uuid(), $1, $2, $3, $4, $5, $6, now(), now()
+    gen_random_uuid(), $1, $2, $3, $4, $5, $6,
+    CASE WHEN $3 THEN now() END, now(), now()
 )
 ON CONFLICT (user_id, content_item_id)
 DO UPDATE SET
-    completed = EXCLUDED.completed,
-    progress_pct = EXCLUDED.progress_pct,
+    completed = CASE WHEN $7::boolean THEN EXCLUDED.completed ELSE user_progress.completed OR EXCLUDED.completed END,
+    progress_pct = CASE WHEN $7::boolean THEN EXCLUDED.progress_pct
+        ELSE GREATEST(user_progress.progress_pct, EXCLUDED.progress_pct) END,
     last_position = EXCLUDED.last_position,
     last_accessed = EXCLUDED.last_accessed,
+    completed_at = CASE
+        WHEN EXCLUDED.completed OR (user_progress.completed AND NOT $7::boolean) THEN COALESCE(user_progress.completed_at, now())
+    END,
     updated_at = now()
-RETURNING id, user_id, content_item_id, completed, progress_pct, last_position, last_accessed, created_at, updated_at
+RETURNING id, user_id, content_item_id, completed, progress_pct, last_position, last_accessed, created_at, updated_at, completed_at
 `
 
 type UpsertUserProgressParams struct {
@@ -172,6 +531,7 @@ type UpsertUserProgressParams struct {
 	ProgressPct   float32
 	LastPosition  sql.NullInt32
 	LastAccessed  sql.NullTime
+	Force         bool
 }
 
 func (q *Queries) UpsertUserProgress(ctx context.Context, arg UpsertUserProgressParams) (UserProgress, error) {
@@ -182,6 +542,7 @@ func (q *Queries) UpsertUserProgress(ctx context.Context, arg UpsertUserProgress
 		arg.ProgressPct,
 		arg.LastPosition,
 		arg.LastAccessed,
+		arg.Force,
 	)
 	var i UserProgress
 	err := row.Scan(
@@ -194,6 +555,7 @@ func (q *Queries) UpsertUserProgress(ctx context.Context, arg UpsertUserProgress
 		&i.LastAccessed,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CompletedAt,
 	)
 	return i, err
 }
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// activity event types
+const (
+	ActivityItemStarted     = "item.started"
+	ActivityItemCompleted   = "item.completed"
+	ActivityCourseStarted   = "course.started"
+	ActivityModuleCompleted = "module.completed"
+	ActivityCourseCompleted = "course.completed"
+	ActivityCourseImported  = "course.imported"
+	ActivityCourseCloned    = "course.cloned"
+	ActivityCourseReset     = "course.reset"
+	ActivityGoalMet         = "goal.met"
+	ActivityGoalMissed      = "goal.missed"
+	ActivityQuestCompleted  = "quest.completed"
+	ActivityLevelReached    = "level.reached"
+)
+
+// FeedEventTypes are the activity events worth showing on a dashboard, the milestones
+// rather than every item opened
+var FeedEventTypes = []string{
+	ActivityCourseStarted, ActivityModuleCompleted, ActivityCourseCompleted,
+	ActivityQuestCompleted, ActivityGoalMet, ActivityLevelReached,
+}
+
+// ActivityEvent is one entry in a profile's history timeline
+type ActivityEvent struct {
+	ID            uuid.UUID `json:"id"`
+	Type          string    `json:"type"` // what happened, e.g. item.completed
+	CourseID      uuid.UUID `json:"course_id,omitempty"`
+	ContentItemID uuid.UUID `json:"content_item_id,omitempty"`
+	Title         string    `json:"title"` // course or item title at the time
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// ActivityPage is a page of a profile's timeline, newest first
+type ActivityPage struct {
+	Events  []ActivityEvent `json:"events"`
+	Limit   int             `json:"limit"`
+	Offset  int             `json:"offset"`
+	HasMore bool            `json:"has_more"`
+}
+
+// FeedItem is a milestone in a profile's feed, with a line saying what happened
+type FeedItem struct {
+	ActivityEvent
+	Summary string `json:"summary"` // e.g. "Completed module Basics"
+}
+
+// Feed is a page of a profile's milestones, newest first
+type Feed struct {
+	Items   []FeedItem `json:"items"`
+	Limit   int        `json:"limit"`
+	Offset  int        `json:"offset"`
+	HasMore bool       `json:"has_more"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIToken lets a script act as a profile without picking it first. The token is never
+// stored, it's only returned once when it's created.
+type APIToken struct {
+	ID         uuid.UUID    `json:"id"`
+	ProfileID  uuid.UUID    `json:"profile_id"`
+	Name       string       `json:"name"`
+	Scope      string       `json:"scope"`                // viewer, editor or admin - never more than the profile's role
+	ExpiresAt  sql.NullTime `json:"expires_at,omitempty"` // unset means it doesn't expire
+	RevokedAt  sql.NullTime `json:"revoked_at,omitempty"`
+	LastUsedAt sql.NullTime `json:"last_used_at,omitempty"`
+	Active     bool         `json:"active"` // not expired and not revoked
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+// CreatedAPIToken is a new token along with the secret to send as "Authorization: Bearer <token>"
+type CreatedAPIToken struct {
+	APIToken
+	Token string `json:"token"`
+}
+
+// CreateAPITokenInput names a token and limits what it may do
+type CreateAPITokenInput struct {
+	Name          string `json:"name"`
+	Scope         string `json:"scope,omitempty"`           // defaults to viewer
+	ExpiresInDays int    `json:"expires_in_days,omitempty"` // 0 means it doesn't expire
+}
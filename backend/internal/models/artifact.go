@@ -0,0 +1,33 @@
+package models
+
+import "github.com/google/uuid"
+
+// ArtifactTypeUsage is how much disk one kind of generated file takes for a course
+type ArtifactTypeUsage struct {
+	Bytes int64 `json:"bytes"`
+	Files int   `json:"files"`
+}
+
+// CourseArtifactUsage is the generated-file disk usage of one course
+type CourseArtifactUsage struct {
+	CourseID    uuid.UUID                    `json:"course_id"`
+	CourseTitle string                       `json:"course_title,omitempty"` // empty for orphans
+	Orphaned    bool                         `json:"orphaned"`               // course no longer exists
+	TotalBytes  int64                        `json:"total_bytes"`
+	Types       map[string]ArtifactTypeUsage `json:"types"`
+}
+
+// ArtifactPolicy is the retention policy for one artifact type
+type ArtifactPolicy struct {
+	Type          string `json:"type"`
+	MaxAgeSeconds int64  `json:"max_age_seconds"` // 0 means keep forever
+	MaxBytes      int64  `json:"max_bytes"`       // 0 means no size limit
+}
+
+// ArtifactUsageReport is the admin view of generated-file disk usage
+type ArtifactUsageReport struct {
+	Root       string                `json:"root"`
+	TotalBytes int64                 `json:"total_bytes"`
+	Policies   []ArtifactPolicy      `json:"policies"`
+	Courses    []CourseArtifactUsage `json:"courses"`
+}
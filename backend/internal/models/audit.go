@@ -0,0 +1,30 @@
+package models
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+// audit actions
+const (
+	AuditCourseImport = "course.import"
+	AuditCourseDelete = "course.delete"
+	AuditCourseClone  = "course.clone"
+
+	AuditImpersonationStart   = "impersonation.start"
+	AuditImpersonationStop    = "impersonation.stop"
+	AuditImpersonationRequest = "impersonation.request" // a change made while impersonating
+)
+
+// AuditEntry records who did what, and on whose behalf
+type AuditEntry struct {
+	ID         uuid.UUID    `json:"id"`
+	ActorID    uuid.UUID    `json:"actor_id,omitempty"`    // profile that made the request
+	SubjectID  uuid.UUID    `json:"subject_id,omitempty"`  // profile it was done for (owner), if different
+	Action     string       `json:"action"`                // what happened, e.g. course.import
+	EntityType string       `json:"entity_type,omitempty"` // what kind of thing it touched
+	EntityID   uuid.UUID    `json:"entity_id,omitempty"`   // which thing it touched
+	Details    string       `json:"details,omitempty"`     // free-form extra info
+	CreatedAt  sql.NullTime `json:"created_at,omitempty"`
+}
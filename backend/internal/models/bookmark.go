@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Bookmark is a moment in an audio or video item a profile wants to jump back to
+type Bookmark struct {
+	ID            uuid.UUID `json:"id"`
+	ContentItemID uuid.UUID `json:"content_item_id"`
+	UserID        uuid.UUID `json:"user_id"`
+	Timestamp     float64   `json:"timestamp"` // seconds into the item
+	Label         string    `json:"label"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// CreateBookmarkInput is what we expect when bookmarking a moment
+type CreateBookmarkInput struct {
+	Timestamp *float64 `json:"timestamp"`
+	Label     string   `json:"label"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CourseBundleVersion is bumped whenever the bundle layout changes
+const CourseBundleVersion = 1
+
+// CourseBundle is a portable snapshot of a course that another instance can import
+type CourseBundle struct {
+	FormatVersion int              `json:"format_version"`     // layout version for importers
+	ExportedAt    time.Time        `json:"exported_at"`        // when the bundle was made
+	Course        *Course          `json:"course"`             // course with modules and items
+	Progress      []BundleProgress `json:"progress,omitempty"` // optional progress for one profile
+}
+
+// BundleProgress is a progress record keyed by file path instead of database IDs
+// IDs differ between instances, relative paths don't.
+type BundleProgress struct {
+	UserID       uuid.UUID  `json:"user_id"`                 // profile the progress belongs to
+	RelativePath string     `json:"relative_path"`           // content item path
+	Completed    bool       `json:"completed"`               // whether it was finished
+	ProgressPct  float32    `json:"progress_pct"`            // how much done (0-100)
+	LastPosition int        `json:"last_position,omitempty"` // seconds for videos, page number for PDFs
+	LastAccessed *time.Time `json:"last_accessed,omitempty"` // when it was last viewed
+}
+
+// BundleImportResult reports what happened when a bundle was imported
+type BundleImportResult struct {
+	Course           *Course  `json:"course"`             // the recreated course
+	MissingFiles     []string `json:"missing_files"`      // items whose files aren't on this machine
+	RestoredProgress int      `json:"restored_progress"`  // progress records restored
+	SkippedProgress  int      `json:"skipped_progress"`   // progress for unknown profiles or items
+	Warnings         []string `json:"warnings,omitempty"` // anything else worth knowing
+}
@@ -0,0 +1,20 @@
+package models
+
+import "github.com/google/uuid"
+
+// CapabilityItem is a content item that needs an optional subsystem
+type CapabilityItem struct {
+	ID           uuid.UUID `json:"id"`
+	Title        string    `json:"title"`
+	RelativePath string    `json:"relative_path"`
+}
+
+// CapabilityWarning explains why some of a course's items may not work on this machine
+type CapabilityWarning struct {
+	Capability string           `json:"capability"` // e.g. transcoding, ocr
+	Tool       string           `json:"tool"`       // binary that provides it, e.g. ffmpeg
+	Available  bool             `json:"available"`  // whether the tool is installed right now
+	ItemCount  int              `json:"item_count"`
+	Message    string           `json:"message"`
+	Items      []CapabilityItem `json:"items"`
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+// entity types recorded in the change history
+const (
+	EntityCourse      = "course"
+	EntityModule      = "module"
+	EntityContentItem = "content_item"
+)
+
+// ChangeRecord is one field change kept in the change history so it can be undone
+type ChangeRecord struct {
+	ID         uuid.UUID    `json:"id"`
+	BatchID    uuid.UUID    `json:"batch_id"` // changes made by the same operation share a batch
+	EntityType string       `json:"entity_type"`
+	EntityID   uuid.UUID    `json:"entity_id"`
+	Field      string       `json:"field"`
+	OldValue   string       `json:"old_value"`
+	NewValue   string       `json:"new_value"`
+	ChangedBy  uuid.UUID    `json:"changed_by,omitempty"`
+	RevertedAt sql.NullTime `json:"reverted_at,omitempty"`
+	CreatedAt  sql.NullTime `json:"created_at,omitempty"`
+}
+
+// TitleRenameInput describes a regex search-and-replace over titles
+type TitleRenameInput struct {
+	Pattern     string    `json:"pattern"`             // Go regexp, use (?i) for case-insensitive
+	Replacement string    `json:"replacement"`         // may reference groups like $1
+	Scopes      []string  `json:"scopes,omitempty"`    // course, module, content_item - all if empty
+	CourseID    uuid.UUID `json:"course_id,omitempty"` // limit to one course
+	TrimSpace   bool      `json:"trim_space,omitempty"`
+}
+
+// TitleChange is one title that a rename would change
+type TitleChange struct {
+	EntityType string    `json:"entity_type"`
+	EntityID   uuid.UUID `json:"entity_id"`
+	CourseID   uuid.UUID `json:"course_id"`
+	OldTitle   string    `json:"old_title"`
+	NewTitle   string    `json:"new_title"`
+}
+
+// TitleRenameResult is returned by both the dry run and the real rename
+type TitleRenameResult struct {
+	DryRun  bool          `json:"dry_run"`
+	BatchID *uuid.UUID    `json:"batch_id,omitempty"` // use this to undo an applied rename
+	Count   int           `json:"count"`
+	Changes []TitleChange `json:"changes"`
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -17,15 +18,43 @@ type ContentItem struct {
 	RelativePath string `json:"relative_path"` // path to the actual file
 	ContentType  string `json:"content_type"`  // video, pdf, text, etc.
 
-	Duration int   `json:"duration,omitempty"` // seconds (for videos)
-	Size     int64 `json:"size,omitempty"`     // file size in bytes
-	Order    int   `json:"order,omitempty"`    // position in module
+	Duration  int   `json:"duration,omitempty"`   // seconds (for videos)
+	PageCount int   `json:"page_count,omitempty"` // pages (for PDFs)
+	Size      int64 `json:"size,omitempty"`       // file size in bytes
+	Order     int   `json:"order,omitempty"`      // position in module
+
+	Hidden bool `json:"hidden,omitempty"` // hidden by the user, left out of progress
+
+	Variants []ContentVariant `json:"variants,omitempty"` // same lesson in other resolutions/languages, primary first
+	Chapters []ChapterMarker  `json:"chapters,omitempty"` // audio and video only, in playback order
 
 	// timestamps
 	CreatedAt sql.NullTime `json:"created_at,omitempty"`
 	UpdatedAt sql.NullTime `json:"updated_at,omitempty"`
 }
 
+// ContentVariant is one file of a content item that exists in several versions
+type ContentVariant struct {
+	ID           uuid.UUID `json:"id"`
+	Label        string    `json:"label"`                // e.g. "1080p", "de", "de 720p" or "original"
+	Resolution   string    `json:"resolution,omitempty"` // e.g. "1080p", "4k"
+	Language     string    `json:"language,omitempty"`   // two letter code, e.g. "en"
+	RelativePath string    `json:"relative_path"`
+	Size         int64     `json:"size,omitempty"`
+	Primary      bool      `json:"primary,omitempty"` // the file the item itself points at
+}
+
+// ChapterMarker is where a chapter of an audio or video item starts
+type ChapterMarker struct {
+	Title string  `json:"title"`
+	Start float64 `json:"start"` // seconds from the beginning
+}
+
+// SetChaptersInput replaces an item's chapters - an empty list goes back to the ones embedded in the file
+type SetChaptersInput struct {
+	Chapters []ChapterMarker `json:"chapters"`
+}
+
 // CreateContentItemInput is what we expect when creating new content
 type CreateContentItemInput struct {
 	ModuleID     uuid.UUID `json:"module_id"`
@@ -37,3 +66,89 @@ type CreateContentItemInput struct {
 	Size         int64     `json:"size,omitempty"`
 	Order        int       `json:"order,omitempty"`
 }
+
+// UpdateContentItemInput is what we expect when editing content - nil fields stay unchanged
+type UpdateContentItemInput struct {
+	Title       *string    `json:"title,omitempty"`
+	Description *string    `json:"description,omitempty"`
+	ModuleID    *uuid.UUID `json:"module_id,omitempty"` // move to another module of the same course
+}
+
+// SetContentHiddenInput hides or shows an item - without a value the flag is flipped
+type SetContentHiddenInput struct {
+	Hidden *bool `json:"hidden,omitempty"`
+}
+
+// ReorderContentItemsInput lists every item of a module in its new order
+type ReorderContentItemsInput struct {
+	ContentItemIDs []uuid.UUID `json:"content_item_ids"`
+}
+
+// StreamFile is a content item file resolved for playback
+type StreamFile struct {
+	ContentItemID uuid.UUID
+	CourseID      uuid.UUID
+	ContentType   string
+	Path          string // absolute path on disk
+	Filename      string // name to offer the browser
+}
+
+// RenderedContent is a text content item converted to HTML on the server
+type RenderedContent struct {
+	ContentItemID uuid.UUID           `json:"content_item_id"`
+	Format        string              `json:"format"` // "markdown" or "plain"
+	HTML          string              `json:"html"`   // sanitized, safe to insert as is
+	Headings      []RenderedHeading   `json:"headings"`
+	CodeBlocks    []RenderedCodeBlock `json:"code_blocks"` // for client-side highlighting
+}
+
+// RenderedHeading is one heading of rendered text, for a table of contents
+type RenderedHeading struct {
+	Level int    `json:"level"`
+	Text  string `json:"text"`
+	ID    string `json:"id"` // anchor ID of the heading element
+}
+
+// RenderedCodeBlock points at a <code data-code-block="index"> element and says how to highlight it
+type RenderedCodeBlock struct {
+	Index    int    `json:"index"`
+	Language string `json:"language,omitempty"` // from the code fence, empty if unknown
+	Lines    int    `json:"lines"`
+}
+
+// SubtitleTrack is a caption file that goes with a video, served as WebVTT
+type SubtitleTrack struct {
+	ID       string `json:"id"`       // caption file name, used in the track URL
+	Language string `json:"language"` // two letter code, empty if the file name has none
+	Label    string `json:"label"`
+	Format   string `json:"format"` // format on disk - "srt" or "vtt"
+	URL      string `json:"url"`    // serves the track as WebVTT
+}
+
+// ContentChapters lists the chapter markers of an audio or video item, with the
+// profile's progress through each one worked out from their last position
+type ContentChapters struct {
+	ContentItemID uuid.UUID        `json:"content_item_id"`
+	Position      int              `json:"position"` // seconds, 0 for anonymous requests
+	Chapters      []ContentChapter `json:"chapters"`
+}
+
+// ContentChapter is one chapter, times in seconds
+type ContentChapter struct {
+	Index       int     `json:"index"`
+	Title       string  `json:"title"`
+	Start       float64 `json:"start"`
+	End         float64 `json:"end"` // 0 when the last chapter's end isn't known
+	ProgressPct float32 `json:"progress_pct"`
+	Completed   bool    `json:"completed"`
+	Current     bool    `json:"current"` // the chapter the position is in
+}
+
+// ContentTranscript is the speech-to-text transcript of an audio or video item
+type ContentTranscript struct {
+	ContentItemID uuid.UUID `json:"content_item_id"`
+	Provider      string    `json:"provider"`           // what made it, e.g. "whisper:base"
+	Language      string    `json:"language,omitempty"` // the hint it was made with, empty if detected
+	Text          string    `json:"text"`               // plain text, one caption line per line
+	UpdatedAt     time.Time `json:"updated_at"`
+}
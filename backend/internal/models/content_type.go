@@ -0,0 +1,35 @@
+package models
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+// UnknownContentGroup is every unclassified item sharing one extension
+type UnknownContentGroup struct {
+	Extension string   `json:"extension"`          // empty for files without one
+	Count     int      `json:"count"`              // items with this extension
+	Examples  []string `json:"examples,omitempty"` // a few relative paths to look at
+}
+
+// ContentTypeMapping is a user-defined extension -> content type rule
+type ContentTypeMapping struct {
+	Extension   string       `json:"extension"`
+	ContentType string       `json:"content_type"`
+	CreatedBy   uuid.UUID    `json:"created_by,omitempty"`
+	CreatedAt   sql.NullTime `json:"created_at,omitempty"`
+	UpdatedAt   sql.NullTime `json:"updated_at,omitempty"`
+}
+
+// MapContentTypeInput maps an extension to a content type
+type MapContentTypeInput struct {
+	Extension   string `json:"extension"`
+	ContentType string `json:"content_type"`
+}
+
+// MapContentTypeResult is the saved rule plus how many existing items it reclassified
+type MapContentTypeResult struct {
+	Mapping      ContentTypeMapping `json:"mapping"`
+	Reclassified int64              `json:"reclassified"`
+}
@@ -16,17 +16,85 @@ type Course struct {
 	Creator   string    `json:"creator,omitempty"`    // who added it
 	CreatorID uuid.UUID `json:"creator_id,omitempty"` // creator's profile ID/the profile who added it
 
+	Tags       []string `json:"tags,omitempty"`       // free-form labels, lower case
+	Difficulty string   `json:"difficulty,omitempty"` // beginner, intermediate or advanced
+	Language   string   `json:"language,omitempty"`   // language code the course is taught in, e.g. "en" or "pt-br"
+
 	// file path stuff - BasePath not stored in DB, just used during processing
 	BasePath     string `json:"base_path,omitempty"`
 	RelativePath string `json:"relative_path"` // path relative to courses dir
 
 	Modules []*Module `json:"modules,omitempty"` // course content
 
+	Note *CourseNote `json:"note,omitempty"` // current profile's course note, if any
+
+	EnrolledProfiles []uuid.UUID `json:"enrolled_profiles,omitempty"` // profiles enrolled at import
+
+	IsFavorite bool `json:"is_favorite,omitempty"` // current profile pinned this course
+
+	SortOrder int `json:"sort_order,omitempty"` // position in the current profile's arrangement, 0 if not arranged
+
+	Progress *CourseProgress `json:"progress,omitempty"` // current profile's progress, only when requested
+
+	Warnings []ImportWarning `json:"warnings,omitempty"` // things to double-check, only set right after import
+
+	Prerequisites      []uuid.UUID `json:"prerequisites,omitempty"`       // courses to finish first
+	Locked             bool        `json:"locked,omitempty"`              // current profile hasn't finished the prerequisites
+	UnmetPrerequisites []uuid.UUID `json:"unmet_prerequisites,omitempty"` // which ones are still open
+
+	ClonedFrom *uuid.UUID `json:"cloned_from,omitempty"` // course this one was copied from, shares its files
+
+	Instructors []Instructor `json:"instructors,omitempty"` // who teaches it, in display order
+
 	// timestamps
 	CreatedAt sql.NullTime `json:"created_at,omitempty"`
 	UpdatedAt sql.NullTime `json:"updated_at,omitempty"`
 }
 
+// course difficulty levels
+const (
+	DifficultyBeginner     = "beginner"
+	DifficultyIntermediate = "intermediate"
+	DifficultyAdvanced     = "advanced"
+)
+
+// UpdateCourseInput is a partial update - only fields that are set get changed
+type UpdateCourseInput struct {
+	Title       *string    `json:"title,omitempty"`
+	Description *string    `json:"description,omitempty"`
+	Tags        *[]string  `json:"tags,omitempty"`       // replaces the whole list
+	Difficulty  *string    `json:"difficulty,omitempty"` // empty string clears it
+	Language    *string    `json:"language,omitempty"`   // empty string clears it
+	CreatorID   *uuid.UUID `json:"creator_id,omitempty"` // hand the course to another profile, admin only
+}
+
+// CourseFilter narrows the course list - empty fields match everything
+type CourseFilter struct {
+	Difficulty string
+	Language   string
+	Tag        string
+}
+
+// SetCourseOrderInput is a profile's arrangement of the course list, first to last
+type SetCourseOrderInput struct {
+	CourseIDs []uuid.UUID `json:"course_ids"`
+}
+
+// CloneCourseInput controls what a clone keeps - everything by default
+type CloneCourseInput struct {
+	Title     string      `json:"title,omitempty"`      // defaults to "<original title> (copy)"
+	ModuleIDs []uuid.UUID `json:"module_ids,omitempty"` // only these modules, in this order
+}
+
+// CourseDepth controls how much of the module tree is loaded with a course
+type CourseDepth int
+
+const (
+	CourseDepthSummary CourseDepth = iota // course row only
+	CourseDepthModules                    // plus modules, without content items
+	CourseDepthFull                       // plus content items
+)
+
 // CreateCourseInput is what we expect when creating a new course
 type CreateCourseInput struct {
 	Title        string    `json:"title"`
@@ -35,6 +103,43 @@ type CreateCourseInput struct {
 	CreatorID    uuid.UUID `json:"creator_id,omitempty"`
 	BasePath     string    `json:"base_path,omitempty"`
 	RelativePath string    `json:"relative_path"`
+
+	EnrollProfiles []uuid.UUID `json:"enroll_profiles,omitempty"` // profiles to enroll right after import
+}
+
+// CourseDeletion reports what went away with a deleted course
+type CourseDeletion struct {
+	CourseID        uuid.UUID `json:"course_id"`
+	Title           string    `json:"title"`
+	Modules         int64     `json:"modules"`          // removed with the course
+	ContentItems    int64     `json:"content_items"`    // removed with their modules
+	ProgressRecords int64     `json:"progress_records"` // every profile's progress on those items
+	AlsoRemoved     []string  `json:"also_removed"`     // other per-course data removed by cascade
+
+	FilesDeleted bool   `json:"files_deleted"`         // whether the course folder was removed from disk
+	FilesPath    string `json:"files_path,omitempty"`  // folder that was (or would have been) removed
+	FilesError   string `json:"files_error,omitempty"` // why removing the folder failed, if it did
+}
+
+// BulkDeleteCoursesInput lists courses to delete in one go, e.g. after a mistaken batch import
+type BulkDeleteCoursesInput struct {
+	CourseIDs   []uuid.UUID `json:"course_ids"`
+	DeleteFiles bool        `json:"delete_files,omitempty"` // also remove each course folder from disk
+}
+
+// BulkCourseDeletionResult is the outcome for one course in a bulk delete
+type BulkCourseDeletionResult struct {
+	CourseID uuid.UUID       `json:"course_id"`
+	Success  bool            `json:"success"`
+	Error    string          `json:"error,omitempty"`    // why this course was not deleted
+	Deletion *CourseDeletion `json:"deletion,omitempty"` // what went away, on success
+}
+
+// BulkCourseDeletion reports a bulk delete course by course - one failure doesn't stop the rest
+type BulkCourseDeletion struct {
+	SuccessCount int                        `json:"success_count"`
+	FailureCount int                        `json:"failure_count"`
+	Results      []BulkCourseDeletionResult `json:"results"`
 }
 
 // CourseWithProgress shows course + how much user has completed
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+// ProfileEmail is a profile's contact address. It's kept out of Profile, the profile list
+// is public.
+type ProfileEmail struct {
+	ProfileID          uuid.UUID    `json:"profile_id"`
+	Email              string       `json:"email"` // empty when the profile has none
+	Verified           bool         `json:"verified"`
+	VerifiedAt         sql.NullTime `json:"verified_at,omitempty"`
+	VerificationSentAt sql.NullTime `json:"verification_sent_at,omitempty"` // of the pending link, if any
+}
+
+// SetEmailInput is what we expect when changing a profile's contact address
+type SetEmailInput struct {
+	Email string `json:"email"` // empty removes the address
+}
+
+// VerifyEmailInput carries the token from a verification email
+type VerifyEmailInput struct {
+	Token string `json:"token"`
+}
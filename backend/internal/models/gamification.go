@@ -0,0 +1,96 @@
+package models
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+// what an XP award was for
+const (
+	XPSourceContent = "content"
+	XPSourceModule  = "module"
+	XPSourceCourse  = "course"
+)
+
+// XPAward is the experience one completion earned - finishing an item can finish its
+// module and course too, and each pays out
+type XPAward struct {
+	Total      int           `json:"total"`      // XP earned by this completion, 0 if it paid out before
+	Experience int           `json:"experience"` // the profile's XP after the award
+	Level      int           `json:"level"`      // the profile's level after the award
+	LevelUp    bool          `json:"level_up"`   // the award took the profile to a new level
+	Awards     []XPAwardItem `json:"awards"`
+}
+
+// XPAwardItem is the XP for one completed item, module or course
+type XPAwardItem struct {
+	Source    string    `json:"source"` // content, module or course
+	SubjectID uuid.UUID `json:"subject_id"`
+	Title     string    `json:"title"`
+	XP        int       `json:"xp"`
+}
+
+// ProgressUpdateResult is where an item stands after a progress update, with any XP it earned
+type ProgressUpdateResult struct {
+	Progress *UserProgress `json:"progress"`
+	XP       *XPAward      `json:"xp,omitempty"`
+}
+
+// stats the gamification ledger tracks
+const (
+	StatXP            = "xp"
+	StatGems          = "gems"
+	StatStreak        = "streak"      // the profile's days-active streak
+	StatGoalStreak    = "goal_streak" // a daily goal's streak, the goal is the subject
+	StatStreakFreezes = "streak_freezes"
+)
+
+// GamificationStats lists every stat in the ledger
+var GamificationStats = []string{StatXP, StatGems, StatStreak, StatGoalStreak, StatStreakFreezes}
+
+// why a stat changed
+const (
+	LedgerReasonCompletion   = "completion"   // finished an item, module or course
+	LedgerReasonGoalMet      = "goal_met"     // a learning goal was met
+	LedgerReasonGoalMissed   = "goal_missed"  // a daily goal was missed and broke its streak
+	LedgerReasonLevelUp      = "level_up"     // the profile reached a new level
+	LedgerReasonQuest        = "quest"        // finished a weekly quest
+	LedgerReasonRedeem       = "redeem"       // bought in the gem shop
+	LedgerReasonFrozen       = "frozen"       // a streak freeze covered a missed day
+	LedgerReasonRepair       = "repair"       // missed days bought back to mend the streak
+	LedgerReasonActivity     = "activity"     // first activity of the day
+	LedgerReasonRecalculated = "recalculated" // recomputed from the activity history
+	LedgerReasonMerge        = "merge"        // brought over from a profile merged into this one
+)
+
+// what set a change off, when it isn't one of the event bus types
+const (
+	LedgerSourceRedemption = "gems.redeemed"
+	LedgerSourceActivity   = "activity.recorded"
+	LedgerSourceEvaluation = "goal.evaluated"
+	LedgerSourceStreakSync = "streak.synced"
+	LedgerSourceQuest      = "quest.completed"
+	LedgerSourceMerge      = "profiles.merged"
+)
+
+// LedgerEntry is one change to one of a profile's gamification stats
+type LedgerEntry struct {
+	ID          uuid.UUID    `json:"id"`
+	Stat        string       `json:"stat"`              // xp, gems, streak, goal_streak or streak_freezes
+	Amount      int          `json:"amount"`            // how much the stat went up, or down if negative
+	Balance     int          `json:"balance"`           // the stat after the change
+	Reason      string       `json:"reason"`            // why it changed
+	Subject     string       `json:"subject,omitempty"` // the item, goal, level or shop item it was about
+	SourceEvent string       `json:"source_event"`      // what set the change off
+	Description string       `json:"description"`
+	CreatedAt   sql.NullTime `json:"created_at"`
+}
+
+// GamificationHistory is one page of a profile's gamification ledger, newest first
+type GamificationHistory struct {
+	Entries []LedgerEntry `json:"entries"`
+	Limit   int           `json:"limit"`
+	Offset  int           `json:"offset"`
+	HasMore bool          `json:"has_more"`
+}
@@ -0,0 +1,28 @@
+package models
+
+// kinds of things gems buy
+const (
+	GemItemStreakFreeze = "streak_freeze" // consumable, used up on a missed day
+	GemItemTheme        = "theme"         // stays unlocked for good
+)
+
+// GemItem is something in the gem shop
+type GemItem struct {
+	ID        string `json:"id"` // what to redeem, like streak_freeze or theme_ocean
+	Kind      string `json:"kind"`
+	Title     string `json:"title"`
+	Cost      int    `json:"cost"`
+	Owned     int    `json:"owned"`     // freezes held, or 1 for an unlocked theme
+	Available bool   `json:"available"` // can be bought now, gems and limits allowing
+}
+
+// GemWallet is a profile's gem balance along with the shop
+type GemWallet struct {
+	Gems  int       `json:"gems"`
+	Items []GemItem `json:"items"`
+}
+
+// RedeemGemsInput is what we expect when spending gems
+type RedeemGemsInput struct {
+	Item string `json:"item"`
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// goal types
+const (
+	GoalDailyMinutes     = "daily_minutes"     // study at least TargetMinutes every day
+	GoalDailyItems       = "daily_items"       // complete at least TargetItems every day
+	GoalCourseCompletion = "course_completion" // finish CourseID by DueDate
+)
+
+// goal statuses, daily goals stay active and get a verdict per day instead
+const (
+	GoalActive = "active"
+	GoalMet    = "met"
+	GoalMissed = "missed"
+)
+
+// LearningGoal is a goal a profile set itself
+type LearningGoal struct {
+	ID            uuid.UUID `json:"id"`
+	ProfileID     uuid.UUID `json:"profile_id"`
+	Type          string    `json:"type"`
+	TargetMinutes int       `json:"target_minutes,omitempty"` // daily_minutes goals
+	TargetItems   int       `json:"target_items,omitempty"`   // daily_items goals
+	CourseID      uuid.UUID `json:"course_id,omitempty"`      // course_completion goals
+	DueDate       string    `json:"due_date,omitempty"`       // course_completion goals, 2006-01-02
+	Status        string    `json:"status"`
+
+	// daily goals: consecutive days met, and minutes studied or items completed so far today
+	Streak        int `json:"streak"`
+	LongestStreak int `json:"longest_streak"`
+	TodayMinutes  int `json:"today_minutes,omitempty"`
+	TodayItems    int `json:"today_items,omitempty"`
+
+	EvaluatedThrough string           `json:"evaluated_through,omitempty"` // last day judged
+	RecentDays       []GoalEvaluation `json:"recent_days,omitempty"`       // newest first, on single goals
+	CreatedAt        time.Time        `json:"created_at"`
+}
+
+// GoalEvaluation is the verdict on a daily goal for one day
+type GoalEvaluation struct {
+	Day     string `json:"day"` // 2006-01-02
+	Met     bool   `json:"met"`
+	Minutes int    `json:"minutes"`
+	Items   int    `json:"items"`
+	Frozen  bool   `json:"frozen,omitempty"` // missed, but a streak freeze kept the streak
+}
+
+// CreateGoalInput is what we expect when setting a goal
+type CreateGoalInput struct {
+	Type          string    `json:"type"`
+	TargetMinutes int       `json:"target_minutes"`
+	TargetItems   int       `json:"target_items"`
+	CourseID      uuid.UUID `json:"course_id"`
+	DueDate       string    `json:"due_date"`
+}
+
+// UpdateGoalInput changes a goal's target or due date, fields left out stay as they are
+type UpdateGoalInput struct {
+	TargetMinutes *int    `json:"target_minutes"`
+	TargetItems   *int    `json:"target_items"`
+	DueDate       *string `json:"due_date"`
+}
+
+// StreakFreezes is what a profile has in its gems economy: gems are earned by meeting
+// daily goals and buy freezes, each of which keeps daily goal streaks over one missed day
+type StreakFreezes struct {
+	Gems       int `json:"gems"`
+	Freezes    int `json:"freezes"`     // held, used up automatically
+	MaxFreezes int `json:"max_freezes"` // how many can be held at once
+	Cost       int `json:"cost"`        // gems per freeze
+}
+
+// StreakRepair is the outcome of buying back the days that broke a profile's streak
+type StreakRepair struct {
+	RepairedDays  []string `json:"repaired_days"` // YYYY-MM-DD, oldest first
+	Cost          int      `json:"cost"`          // gems spent
+	Gems          int      `json:"gems"`          // left after
+	Streak        int      `json:"streak"`
+	LongestStreak int      `json:"longest_streak"`
+}
@@ -0,0 +1,8 @@
+package models
+
+// IdempotentResponse is the stored answer to a request made with an Idempotency-Key,
+// sent again as-is when the same request is retried
+type IdempotentResponse struct {
+	StatusCode int
+	Body       []byte
+}
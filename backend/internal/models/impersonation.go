@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StartImpersonationInput picks who an admin acts as, and for how long
+type StartImpersonationInput struct {
+	ProfileID uuid.UUID `json:"profile_id"`
+	Minutes   int       `json:"minutes,omitempty"` // defaults to 30
+}
+
+// ImpersonationStatus says whether the session is acting as someone else
+type ImpersonationStatus struct {
+	Active           bool       `json:"active"`
+	AdminID          uuid.UUID  `json:"admin_id,omitempty"`   // who logged in
+	ProfileID        uuid.UUID  `json:"profile_id,omitempty"` // who requests are made as
+	ExpiresAt        *time.Time `json:"expires_at,omitempty"`
+	RemainingSeconds int        `json:"remaining_seconds,omitempty"`
+}
@@ -0,0 +1,17 @@
+package models
+
+// import warning codes - the UI keys its hints off these
+const (
+	WarnUnknownExtension = "unknown_extension" // file type we don't know how to show
+	WarnSkippedFile      = "skipped_file"      // file or folder left out of the course
+	WarnEmptyModule      = "empty_module"      // module without any content
+	WarnMissingDuration  = "missing_duration"  // videos we couldn't get a length for
+	WarnInvalidManifest  = "invalid_manifest"  // course.json unreadable or had values we dropped
+)
+
+// ImportWarning is something that didn't stop an import but the user should double-check
+type ImportWarning struct {
+	Code    string `json:"code"`           // one of the Warn* codes
+	Path    string `json:"path,omitempty"` // relative path the warning is about
+	Message string `json:"message"`        // human readable explanation
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+// Instructor is someone who teaches one or more courses
+type Instructor struct {
+	ID          uuid.UUID          `json:"id"`
+	Name        string             `json:"name"`
+	Bio         string             `json:"bio,omitempty"`
+	AvatarURL   string             `json:"avatar_url,omitempty"`
+	CourseCount int                `json:"course_count"`
+	Courses     []InstructorCourse `json:"courses,omitempty"` // only when a single instructor is fetched
+	CreatedAt   sql.NullTime       `json:"created_at,omitempty"`
+	UpdatedAt   sql.NullTime       `json:"updated_at,omitempty"`
+}
+
+// InstructorCourse is a course an instructor teaches
+type InstructorCourse struct {
+	CourseID uuid.UUID `json:"course_id"`
+	Title    string    `json:"title"`
+}
+
+// CreateInstructorInput is what's needed to add an instructor
+type CreateInstructorInput struct {
+	Name      string `json:"name"`
+	Bio       string `json:"bio,omitempty"`
+	AvatarURL string `json:"avatar_url,omitempty"`
+}
+
+// UpdateInstructorInput is a partial update - only fields that are set get changed
+type UpdateInstructorInput struct {
+	Name      *string `json:"name,omitempty"`
+	Bio       *string `json:"bio,omitempty"`
+	AvatarURL *string `json:"avatar_url,omitempty"`
+}
+
+// SetCourseInstructorsInput replaces who teaches a course, in display order
+type SetCourseInstructorsInput struct {
+	InstructorIDs []uuid.UUID `json:"instructor_ids"`
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+// LearningPathCourse is one step of a learning path
+type LearningPathCourse struct {
+	CourseID uuid.UUID `json:"course_id"`
+	Title    string    `json:"title"`
+	Position int       `json:"position"` // 1-based, the order courses should be taken in
+}
+
+// LearningPath is an ordered sequence of courses
+type LearningPath struct {
+	ID          uuid.UUID            `json:"id"`
+	Title       string               `json:"title"`
+	Description string               `json:"description,omitempty"`
+	CreatorID   uuid.UUID            `json:"creator_id,omitempty"`
+	Courses     []LearningPathCourse `json:"courses"`
+	CreatedAt   sql.NullTime         `json:"created_at,omitempty"`
+	UpdatedAt   sql.NullTime         `json:"updated_at,omitempty"`
+}
+
+// CreateLearningPathInput is what's needed to create a learning path
+type CreateLearningPathInput struct {
+	Title       string      `json:"title"`
+	Description string      `json:"description,omitempty"`
+	CourseIDs   []uuid.UUID `json:"course_ids"` // in order
+}
+
+// UpdateLearningPathInput is a partial update - only fields that are set get changed
+type UpdateLearningPathInput struct {
+	Title       *string      `json:"title,omitempty"`
+	Description *string      `json:"description,omitempty"`
+	CourseIDs   *[]uuid.UUID `json:"course_ids,omitempty"` // replaces the whole sequence
+}
+
+// LearningPathProgress is a profile's progress across every course of a path
+type LearningPathProgress struct {
+	PathID           uuid.UUID         `json:"path_id"`
+	UserID           uuid.UUID         `json:"user_id"`
+	CompletedCourses int               `json:"completed_courses"`
+	TotalCourses     int               `json:"total_courses"`
+	CompletedItems   int               `json:"completed_items"`
+	TotalItems       int               `json:"total_items"`
+	CompletionPct    float32           `json:"completion_pct"` // weighted by items, so long courses count more
+	IsCompleted      bool              `json:"is_completed"`
+	NextCourseID     *uuid.UUID        `json:"next_course_id,omitempty"` // first course in order that isn't finished
+	Courses          []*CourseProgress `json:"courses"`                  // in path order
+}
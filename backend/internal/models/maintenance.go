@@ -0,0 +1,7 @@
+package models
+
+// SetMaintenanceInput flips the admin maintenance switch
+type SetMaintenanceInput struct {
+	Enabled bool   `json:"enabled"`
+	Reason  string `json:"reason,omitempty"` // shown to clients while it's on
+}
@@ -29,3 +29,20 @@ type CreateModuleInput struct {
 	RelativePath string    `json:"relative_path"`
 	Order        int       `json:"order,omitempty"`
 }
+
+// UpdateModuleInput is what we expect when editing a module - nil fields stay unchanged
+type UpdateModuleInput struct {
+	Title       *string `json:"title,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// ReorderModulesInput lists every module of a course in its new order
+type ReorderModulesInput struct {
+	ModuleIDs []uuid.UUID `json:"module_ids"`
+}
+
+// MergeModulesInput lists modules to merge - items end up in the first one, in list order
+type MergeModulesInput struct {
+	ModuleIDs []uuid.UUID `json:"module_ids"`
+	Title     string      `json:"title,omitempty"` // new title for the merged module, keeps the first one's if empty
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CourseNote is a free-form markdown note a profile keeps for a whole course
+type CourseNote struct {
+	ID       uuid.UUID `json:"id"`
+	CourseID uuid.UUID `json:"course_id"`
+	UserID   uuid.UUID `json:"user_id"`
+	Content  string    `json:"content"` // markdown
+
+	// timestamps
+	CreatedAt sql.NullTime `json:"created_at,omitempty"`
+	UpdatedAt sql.NullTime `json:"updated_at,omitempty"`
+}
+
+// SaveCourseNoteInput is what we expect when saving a course note
+type SaveCourseNoteInput struct {
+	Content string `json:"content"`
+}
+
+// ContentNote is a markdown note a profile keeps on one content item. Notes on audio and
+// video can be pinned to a moment, Timestamp in seconds, so players can jump back to it.
+type ContentNote struct {
+	ID            uuid.UUID `json:"id"`
+	ContentItemID uuid.UUID `json:"content_item_id"`
+	UserID        uuid.UUID `json:"user_id"`
+	Content       string    `json:"content"` // markdown
+	Timestamp     *float64  `json:"timestamp,omitempty"`
+
+	// where the item sits, only filled in on the per-course listing
+	ContentTitle string    `json:"content_title,omitempty"`
+	ContentType  string    `json:"content_type,omitempty"`
+	ModuleID     uuid.UUID `json:"module_id,omitempty"`
+	ModuleTitle  string    `json:"module_title,omitempty"`
+
+	// timestamps
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SaveContentNoteInput is what we expect when creating or replacing a content note.
+// Timestamp is left out for notes on the item as a whole.
+type SaveContentNoteInput struct {
+	Content   string   `json:"content"`
+	Timestamp *float64 `json:"timestamp"`
+}
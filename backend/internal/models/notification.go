@@ -0,0 +1,39 @@
+package models
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+// notification categories a profile can set a frequency for
+const (
+	NotifyImports  = "imports"  // course imports finishing or failing
+	NotifyProgress = "progress" // milestones like finishing a course
+	NotifySystem   = "system"   // maintenance and admin messages
+)
+
+// NotificationCategories lists every category in display order
+var NotificationCategories = []string{NotifyImports, NotifyProgress, NotifySystem}
+
+// NotificationPreference is how often a profile wants one category delivered
+type NotificationPreference struct {
+	Category     string       `json:"category"`
+	Frequency    string       `json:"frequency"` // immediate, daily or weekly
+	LastDigestAt sql.NullTime `json:"last_digest_at,omitempty"`
+}
+
+// SetNotificationPreferencesInput maps categories to frequencies; unlisted ones stay as they are
+type SetNotificationPreferencesInput struct {
+	Preferences map[string]string `json:"preferences"`
+}
+
+// Notification is one event queued for or delivered to a profile
+type Notification struct {
+	ID          uuid.UUID    `json:"id"`
+	Category    string       `json:"category"`
+	Title       string       `json:"title"`
+	Body        string       `json:"body,omitempty"`
+	DeliveredAt sql.NullTime `json:"delivered_at,omitempty"` // null while waiting for a digest
+	CreatedAt   sql.NullTime `json:"created_at,omitempty"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OfflineManifest lists every content file of a course with what a client needs to
+// download it for offline study and check nothing got corrupted on the way
+type OfflineManifest struct {
+	CourseID    uuid.UUID     `json:"course_id"`
+	Title       string        `json:"title"`
+	GeneratedAt time.Time     `json:"generated_at"`
+	ExpiresAt   time.Time     `json:"expires_at"`  // the download URLs stop working after this
+	TotalBytes  int64         `json:"total_bytes"` // sum of the file sizes, for a disk space check
+	Files       []OfflineFile `json:"files"`
+}
+
+// OfflineFile is one content file in an offline manifest
+type OfflineFile struct {
+	ContentItemID uuid.UUID `json:"content_item_id"`
+	ModuleID      uuid.UUID `json:"module_id"`
+	Title         string    `json:"title"`
+	ContentType   string    `json:"content_type"`
+	Path          string    `json:"path"` // where the file sits inside the course folder, slash separated
+	Size          int64     `json:"size"`
+	SHA256        string    `json:"sha256,omitempty"` // hex, empty while the file is in cold storage and never hashed
+	URL           string    `json:"url"`              // signed download URL, no session needed
+}
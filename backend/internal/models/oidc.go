@@ -0,0 +1,8 @@
+package models
+
+// OIDCStatus tells the login screen whether to offer logging in through the OpenID Connect provider
+type OIDCStatus struct {
+	Enabled    bool   `json:"enabled"`
+	LoginURL   string `json:"login_url,omitempty"` // send the browser here, add ?link=true to link the logged in profile
+	AutoCreate bool   `json:"auto_create"`         // accounts without a profile get one on their first login
+}
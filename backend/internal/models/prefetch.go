@@ -0,0 +1,25 @@
+package models
+
+import "github.com/google/uuid"
+
+// PrefetchItem is everything a player needs to start loading an upcoming item
+type PrefetchItem struct {
+	ID          uuid.UUID `json:"id"`
+	ModuleID    uuid.UUID `json:"module_id"`
+	Title       string    `json:"title"`
+	ContentType string    `json:"content_type"`
+	Format      string    `json:"format,omitempty"`    // file extension without the dot
+	MimeType    string    `json:"mime_type,omitempty"` // guessed from the extension
+	Size        int64     `json:"size,omitempty"`      // bytes
+	Duration    int       `json:"duration,omitempty"`  // seconds
+	StreamURL   string    `json:"stream_url"`
+	Cold        bool      `json:"cold,omitempty"` // in cold storage, the first request will be slow unless warmed
+}
+
+// PrefetchResult lists the items after the current one, in course order
+type PrefetchResult struct {
+	ContentItemID uuid.UUID      `json:"content_item_id"`
+	CourseID      uuid.UUID      `json:"course_id"`
+	Items         []PrefetchItem `json:"items"`
+	WarmTaskID    string         `json:"warm_task_id,omitempty"` // set when a cache warm was started
+}
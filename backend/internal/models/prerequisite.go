@@ -0,0 +1,22 @@
+package models
+
+import "github.com/google/uuid"
+
+// PrerequisiteStatus is one course that has to be finished first
+type PrerequisiteStatus struct {
+	CourseID  uuid.UUID `json:"course_id"`
+	Title     string    `json:"title"`
+	Completed *bool     `json:"completed,omitempty"` // for the current profile, nil without one
+}
+
+// CoursePrerequisites lists what a course requires
+type CoursePrerequisites struct {
+	CourseID      uuid.UUID            `json:"course_id"`
+	Prerequisites []PrerequisiteStatus `json:"prerequisites"`
+	Locked        *bool                `json:"locked,omitempty"` // for the current profile, nil without one
+}
+
+// SetPrerequisitesInput replaces a course's prerequisites
+type SetPrerequisitesInput struct {
+	CourseIDs []uuid.UUID `json:"course_ids"`
+}
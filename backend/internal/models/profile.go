@@ -12,12 +12,20 @@ import (
 type Profile struct {
 	ID uuid.UUID `json:"id"` // unique identifier
 
-	Name string `json:"name"` // display name
+	Name      string `json:"name"`                 // display name
+	AvatarURL string `json:"avatar_url,omitempty"` // uploaded picture, changes with each upload
+
+	Role    string `json:"role"`               // viewer, editor or admin
+	IsAdmin bool   `json:"is_admin"`           // can manage other profiles and the instance
+	IsGuest bool   `json:"is_guest,omitempty"` // deleted with everything it did on logout
 
 	// gamification stuff
-	Experience int `json:"experience"` // XP points
-	Gems       int `json:"gems"`       // special currency
-	Streak     int `json:"streak"`     // consecutive active days
+	Experience    int `json:"experience"`       // XP points
+	Level         int `json:"level"`            // worked out from experience
+	XPToNextLevel int `json:"xp_to_next_level"` // XP still needed for the next level
+	Gems          int `json:"gems"`             // special currency
+	StreakFreezes int `json:"streak_freezes"`   // bought with gems, keep goal streaks over a missed day
+	Streak        int `json:"streak"`           // consecutive active days
 
 	LastActiveDate sql.NullTime `json:"last_active_date,omitempty"` // for streak tracking
 
@@ -36,6 +44,42 @@ type UpdateProfileInput struct {
 	Name string `json:"name,omitempty"`
 }
 
+// SetRoleInput is what we expect when changing a profile's role
+type SetRoleInput struct {
+	Role string `json:"role"` // viewer, editor or admin
+}
+
+// MergeProfilesInput names the profile to fold into another one. The source is deleted.
+type MergeProfilesInput struct {
+	SourceID uuid.UUID `json:"source_id"`
+	TargetID uuid.UUID `json:"target_id"`
+}
+
+// ProfileMerge is what a merge brought over to the target profile
+type ProfileMerge struct {
+	Profile       Profile `json:"profile"`        // the target, after the merge
+	Progress      int     `json:"progress"`       // progress records added or combined
+	CourseNotes   int     `json:"course_notes"`   // course notes added or appended to
+	ContentNotes  int     `json:"content_notes"`  // notes on content items moved over
+	Bookmarks     int     `json:"bookmarks"`      // bookmarks moved over
+	Favorites     int     `json:"favorites"`      // favorite courses the target didn't have yet
+	Experience    int     `json:"experience"`     // XP added, without awards both profiles earned
+	Gems          int     `json:"gems"`           // gems added
+	StreakFreezes int     `json:"streak_freezes"` // streak freezes added
+}
+
+// SelectProfileInput is the optional body of a profile selection
+type SelectProfileInput struct {
+	Secret string `json:"secret,omitempty"` // PROFILE_SELECT_SECRET, for editors and admins away from the server
+}
+
+// SelectProfileResult is the session a client gets for selecting a profile. Browsers also
+// get the token as a cookie; other clients send it back as "Authorization: Bearer <token>".
+type SelectProfileResult struct {
+	ProfileID uuid.UUID `json:"profile_id"`
+	Token     string    `json:"token"`
+}
+
 // GamificationUpdate represents changes to user's game stats
 type GamificationUpdate struct {
 	Experience int       `json:"experience"`
@@ -17,8 +17,9 @@ type UserProgress struct {
 	Completed   bool    `json:"completed"`    // whether they finished it
 	ProgressPct float32 `json:"progress_pct"` // how much done (0-100)
 
-	LastPosition int          `json:"last_position,omitempty"` // seconds (for videos)
+	LastPosition int          `json:"last_position,omitempty"` // seconds for videos, page number for PDFs
 	LastAccessed sql.NullTime `json:"last_accessed,omitempty"` // when they last viewed it
+	CompletedAt  sql.NullTime `json:"completed_at,omitempty"`  // when they finished it
 
 	// timestamps
 	CreatedAt sql.NullTime `json:"created_at,omitempty"`
@@ -34,6 +35,86 @@ type CreateProgressInput struct {
 	LastPosition  int       `json:"last_position,omitempty"`
 }
 
+// ProgressRecord is one row of a profile's progress export, with where the item sits
+type ProgressRecord struct {
+	CourseID      uuid.UUID  `json:"course_id"`
+	CourseTitle   string     `json:"course_title"`
+	ModuleTitle   string     `json:"module_title"`
+	ContentItemID uuid.UUID  `json:"content_item_id"`
+	ContentTitle  string     `json:"content_title"`
+	ContentType   string     `json:"content_type"`
+	ProgressPct   float32    `json:"progress_pct"`
+	Completed     bool       `json:"completed"`
+	CompletedAt   *time.Time `json:"completed_at,omitempty"`
+	LastAccessed  *time.Time `json:"last_accessed,omitempty"`
+}
+
+// ProgressSyncInput is a batch of progress updates a client queued while it was offline
+type ProgressSyncInput struct {
+	Updates []ProgressSyncUpdate `json:"updates"`
+}
+
+// ProgressSyncUpdate is one queued progress update, stamped with when it happened on the client
+type ProgressSyncUpdate struct {
+	ContentItemID   uuid.UUID `json:"content_item_id"`
+	Completed       bool      `json:"completed"`
+	ProgressPct     float32   `json:"progress_pct"`
+	LastPosition    int       `json:"last_position,omitempty"`
+	ClientTimestamp time.Time `json:"client_timestamp"`
+}
+
+// ProgressSyncResult is where a sync left each item, and the updates that couldn't be applied
+type ProgressSyncResult struct {
+	Applied  int                `json:"applied"`
+	Progress []*UserProgress    `json:"progress"`
+	Skipped  []ProgressSyncSkip `json:"skipped,omitempty"`
+}
+
+// ProgressSyncSkip is an update a sync left out, and why
+type ProgressSyncSkip struct {
+	ContentItemID uuid.UUID `json:"content_item_id"`
+	Reason        string    `json:"reason"`
+}
+
+// ProgressReset is what a course progress reset removed
+type ProgressReset struct {
+	CourseID   uuid.UUID `json:"course_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	ItemsReset int64     `json:"items_reset"` // progress records deleted
+}
+
+// progress transfer modes
+const (
+	TransferCopy = "copy" // the source keeps its progress
+	TransferMove = "move" // the source's progress in the course is deleted
+)
+
+// TransferProgressInput names the profiles a course's progress goes between
+type TransferProgressInput struct {
+	SourceID uuid.UUID `json:"source_id"`
+	TargetID uuid.UUID `json:"target_id"`
+	Mode     string    `json:"mode"` // copy (default) or move
+}
+
+// ProgressTransfer is what a course progress transfer changed
+type ProgressTransfer struct {
+	CourseID         uuid.UUID `json:"course_id"`
+	SourceID         uuid.UUID `json:"source_id"`
+	TargetID         uuid.UUID `json:"target_id"`
+	Mode             string    `json:"mode"`
+	ItemsTransferred int64     `json:"items_transferred"` // progress records written to the target
+	ItemsRemoved     int64     `json:"items_removed"`     // source records deleted by a move
+}
+
+// BulkCompletion is what marking a whole module or course complete changed
+type BulkCompletion struct {
+	CourseID        uuid.UUID `json:"course_id"`
+	ModuleID        uuid.UUID `json:"module_id,omitempty"` // set when a single module was completed
+	UserID          uuid.UUID `json:"user_id"`
+	ItemsCompleted  int64     `json:"items_completed"`  // items that weren't complete before
+	CourseCompleted bool      `json:"course_completed"` // whether the whole course is done now
+}
+
 // ModuleProgress represents calculated progress for a module
 type ModuleProgress struct {
 	ModuleID       uuid.UUID  `json:"module_id"`
@@ -43,6 +124,9 @@ type ModuleProgress struct {
 	CompletionPct  float32    `json:"completion_pct"`
 	LastAccessedAt *time.Time `json:"last_accessed_at,omitempty"`
 	IsCompleted    bool       `json:"is_completed"` // true when all content items done
+
+	EstimatedTimeLeft int    `json:"estimated_time_left,omitempty"` // minutes
+	Weighting         string `json:"weighting"`                     // what CompletionPct counts, items or duration
 }
 
 // CourseProgress represents calculated progress for an entire course
@@ -57,6 +141,7 @@ type CourseProgress struct {
 	LastAccessedAt    *time.Time `json:"last_accessed_at,omitempty"`
 	IsCompleted       bool       `json:"is_completed"`                  // true when all modules done
 	EstimatedTimeLeft int        `json:"estimated_time_left,omitempty"` // minutes
+	Weighting         string     `json:"weighting"`                     // what CompletionPct counts, items or duration
 }
 
 // ProgressSummary gives overall user progress across all courses
@@ -65,6 +150,24 @@ type ProgressSummary struct {
 	TotalCourses      int       `json:"total_courses"`
 	CompletedCourses  int       `json:"completed_courses"`
 	InProgressCourses int       `json:"in_progress_courses"`
-	TotalTimeSpent    int       `json:"total_time_spent"` // minutes
-	StreakDays        int       `json:"streak_days"`
+	TotalTimeSpent    int       `json:"total_time_spent"`    // minutes
+	StreakDays        int       `json:"streak_days"`         // consecutive days with activity up to today or yesterday
+	LongestStreakDays int       `json:"longest_streak_days"` // best run ever
+}
+
+// HeartbeatInput is what players send every 15-30 seconds while content is playing
+type HeartbeatInput struct {
+	Seconds float64 `json:"seconds"` // watch time since the last heartbeat
+}
+
+// HeartbeatResult is what a heartbeat was worth, with the time limit status so the
+// player can stop when the budget runs out
+type HeartbeatResult struct {
+	SessionID       uuid.UUID        `json:"session_id"`
+	CreditedSeconds int              `json:"credited_seconds"`
+	SessionSeconds  int              `json:"session_seconds"` // credited so far in this sitting
+	Paused          bool             `json:"paused"`          // the gap was too long, a new session started
+	Suspicious      bool             `json:"suspicious"`      // flagged sessions don't count towards stats
+	Reason          string           `json:"reason,omitempty"`
+	TimeLimit       *TimeLimitStatus `json:"time_limit,omitempty"`
 }
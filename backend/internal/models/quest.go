@@ -0,0 +1,37 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// kinds of weekly quest
+const (
+	QuestCompleteItems  = "complete_items"  // complete a number of content items
+	QuestCompleteVideos = "complete_videos" // complete a number of videos
+	QuestStudyMinutes   = "study_minutes"   // watch for a number of minutes
+	QuestFinishModule   = "finish_module"   // finish a module in a course the profile started
+)
+
+// Quest is one of a profile's challenges for the week
+type Quest struct {
+	ID          uuid.UUID    `json:"id"`
+	Type        string       `json:"type"`
+	Title       string       `json:"title"`
+	Target      int          `json:"target"`
+	Progress    int          `json:"progress"`
+	CourseID    uuid.UUID    `json:"course_id,omitempty"` // finish_module quests only
+	RewardXP    int          `json:"reward_xp"`
+	RewardGems  int          `json:"reward_gems"`
+	Completed   bool         `json:"completed"`
+	CompletedAt sql.NullTime `json:"completed_at,omitempty"`
+}
+
+// QuestBoard is a profile's quests for the current week
+type QuestBoard struct {
+	Week   time.Time `json:"week"`    // the Monday the week starts on
+	EndsAt time.Time `json:"ends_at"` // when the next set of quests comes
+	Quests []Quest   `json:"quests"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "github.com/google/uuid"
+
+// SearchResults are the courses and content items matching a search, limited to what
+// the profile can see
+type SearchResults struct {
+	Query   string             `json:"query"`
+	Courses []SearchCourseHit  `json:"courses"`
+	Content []SearchContentHit `json:"content"`
+}
+
+// SearchCourseHit is a course whose title or description matches
+type SearchCourseHit struct {
+	ID          uuid.UUID `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+}
+
+// SearchContentHit is a content item whose title or transcript matches
+type SearchContentHit struct {
+	ID              uuid.UUID `json:"id"`
+	Title           string    `json:"title"`
+	ContentType     string    `json:"content_type"`
+	ModuleID        uuid.UUID `json:"module_id"`
+	CourseID        uuid.UUID `json:"course_id"`
+	CourseTitle     string    `json:"course_title"`
+	TranscriptMatch bool      `json:"transcript_match"`  // matched on what's said, not the title
+	Snippet         string    `json:"snippet,omitempty"` // transcript text around the match
+}
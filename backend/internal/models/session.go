@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session is one client a profile is logged in on
+type Session struct {
+	ID         uuid.UUID `json:"id"`
+	Device     string    `json:"device"` // the User-Agent it logged in with
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen"`  // to the minute
+	ExpiresAt  time.Time `json:"expires_at"` // moves on while the session is used
+	Current    bool      `json:"current"`    // the session the list was asked for with
+}
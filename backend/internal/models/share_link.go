@@ -0,0 +1,45 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ShareLink is a public link to a single content item. The token is never stored,
+// it's only returned once when the link is created.
+type ShareLink struct {
+	ID            uuid.UUID    `json:"id"`
+	ContentItemID uuid.UUID    `json:"content_item_id"`
+	CreatedBy     uuid.UUID    `json:"created_by,omitempty"`
+	HasPassword   bool         `json:"has_password"`
+	ExpiresAt     time.Time    `json:"expires_at"`
+	RevokedAt     sql.NullTime `json:"revoked_at,omitempty"`
+	Active        bool         `json:"active"` // not expired and not revoked
+	ViewCount     int          `json:"view_count"`
+	LastViewedAt  sql.NullTime `json:"last_viewed_at,omitempty"`
+	CreatedAt     sql.NullTime `json:"created_at,omitempty"`
+}
+
+// CreatedShareLink is a new link along with the token needed to use it
+type CreatedShareLink struct {
+	ShareLink
+	Token string `json:"token"`
+	URL   string `json:"url"` // path of the public endpoint, relative to the API host
+}
+
+// CreateShareLinkInput controls how long a link lives and whether it needs a password
+type CreateShareLinkInput struct {
+	ExpiresInHours int    `json:"expires_in_hours,omitempty"` // defaults to a week
+	Password       string `json:"password,omitempty"`         // empty means no password
+}
+
+// SharedFile is what a valid share link resolves to
+type SharedFile struct {
+	LinkID        uuid.UUID
+	Path          string    // absolute path on disk
+	Filename      string    // name to offer the browser
+	Access        string    // set when a password was checked, lets later requests skip it
+	AccessExpires time.Time // when Access stops working
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+// LibrarySnapshot describes a saved copy of the library metadata (not the snapshot data itself)
+type LibrarySnapshot struct {
+	ID          uuid.UUID    `json:"id"`
+	Label       string       `json:"label"`
+	CreatedBy   uuid.UUID    `json:"created_by,omitempty"`
+	CourseCount int          `json:"course_count"`
+	RestoredAt  sql.NullTime `json:"restored_at,omitempty"` // last time it was rolled back to
+	CreatedAt   sql.NullTime `json:"created_at,omitempty"`
+}
+
+// CreateSnapshotInput is what we expect when taking a snapshot
+type CreateSnapshotInput struct {
+	Label string `json:"label"`
+}
+
+// SnapshotRollbackResult reports what a rollback changed
+type SnapshotRollbackResult struct {
+	SnapshotID      uuid.UUID   `json:"snapshot_id"`
+	RestoredCourses int         `json:"restored_courses"`
+	RemovedCourses  []uuid.UUID `json:"removed_courses"` // only filled when pruning
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LearningStats is a profile's learning over the last week or month, bucketed for charts
+type LearningStats struct {
+	UserID  uuid.UUID     `json:"user_id"`
+	Period  string        `json:"period"` // week or month
+	Bucket  string        `json:"bucket"` // day or week, what each entry in Buckets covers
+	From    time.Time     `json:"from"`   // first day covered
+	To      time.Time     `json:"to"`     // last day covered, today
+	Buckets []StatsBucket `json:"buckets"`
+	Total   StatsBucket   `json:"total"` // the whole period, courses counted once
+}
+
+// StatsBucket is what a profile did in one day or week
+type StatsBucket struct {
+	Start          time.Time `json:"start"` // first day of the bucket
+	ItemsCompleted int       `json:"items_completed"`
+	MinutesSpent   int       `json:"minutes_spent"`   // credited watch time
+	CoursesTouched int       `json:"courses_touched"` // courses with any activity
+}
+
+// Heatmap is a profile's activity per day over a calendar year, for a contribution-style calendar
+type Heatmap struct {
+	UserID     uuid.UUID    `json:"user_id"`
+	Year       int          `json:"year"`
+	Total      int          `json:"total"`       // progress updates over the year
+	ActiveDays int          `json:"active_days"` // days with any activity
+	MaxCount   int          `json:"max_count"`   // busiest day, for scaling colors
+	Days       []HeatmapDay `json:"days"`        // every day of the year, in order
+}
+
+// HeatmapDay is the activity count of one day
+type HeatmapDay struct {
+	Date  string `json:"date"` // 2006-01-02
+	Count int    `json:"count"`
+}
+
+// Leaderboard is a page of profiles ranked by XP or minutes learned over a period
+type Leaderboard struct {
+	Period  string             `json:"period"`          // week, month or all
+	Metric  string             `json:"metric"`          // xp or minutes
+	Since   *time.Time         `json:"since,omitempty"` // start of the period, unset for all time
+	Entries []LeaderboardEntry `json:"entries"`
+	Limit   int                `json:"limit"`
+	Offset  int                `json:"offset"`
+	HasMore bool               `json:"has_more"`
+}
+
+// LeaderboardEntry is one profile's place on a leaderboard. Equal scores share a rank.
+type LeaderboardEntry struct {
+	Rank      int       `json:"rank"`
+	ProfileID uuid.UUID `json:"profile_id"`
+	Name      string    `json:"name"`
+	Score     int       `json:"score"` // XP, or minutes of credited watch time
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ModuleStudyEstimate is how long a module should take to work through
+type ModuleStudyEstimate struct {
+	ModuleID         uuid.UUID  `json:"module_id"`
+	Title            string     `json:"title"`
+	Order            int        `json:"order"`
+	Items            int        `json:"items"`
+	GuessedItems     int        `json:"guessed_items"`       // items estimated from file size rather than a real duration
+	TotalMinutes     int        `json:"total_minutes"`       // whole module
+	RemainingMinutes int        `json:"remaining_minutes"`   // what's left for the profile
+	FinishBy         *time.Time `json:"finish_by,omitempty"` // only on pacing responses
+}
+
+// CourseStudyEstimate adds up the module estimates for a course
+type CourseStudyEstimate struct {
+	CourseID         uuid.UUID             `json:"course_id"`
+	UserID           uuid.UUID             `json:"user_id,omitempty"` // whose progress "remaining" is based on
+	TotalMinutes     int                   `json:"total_minutes"`
+	RemainingMinutes int                   `json:"remaining_minutes"`
+	GuessedItems     int                   `json:"guessed_items"`
+	Modules          []ModuleStudyEstimate `json:"modules"`
+}
+
+// CoursePacing is when a profile finishes the course at a given weekly pace
+type CoursePacing struct {
+	CourseStudyEstimate
+	HoursPerWeek float64   `json:"hours_per_week"`
+	StartDate    time.Time `json:"start_date"`
+	FinishDate   time.Time `json:"finish_date"`
+	Weeks        float64   `json:"weeks"`   // weeks of study left at this pace
+	Summary      string    `json:"summary"` // e.g. "At 5h/week you'll finish on March 3, 2027"
+}
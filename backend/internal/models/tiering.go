@@ -0,0 +1,49 @@
+package models
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+// TieringPolicy is the admin view of when content moves to cold storage
+type TieringPolicy struct {
+	ColdAfterSeconds int64 `json:"cold_after_seconds"` // idle time before an item is moved
+	MaxAccesses      int   `json:"max_accesses"`       // items accessed more often stay hot
+	MinBytes         int64 `json:"min_bytes"`          // smaller items stay hot
+	BatchSize        int   `json:"batch_size"`         // items moved per policy pass
+}
+
+// TierUsage is how many items and bytes sit in one tier
+type TierUsage struct {
+	Tier  string `json:"tier"`
+	Items int64  `json:"items"`
+	Bytes int64  `json:"bytes"`
+}
+
+// ColdContentItem is a content item currently parked in cold storage
+type ColdContentItem struct {
+	ID             uuid.UUID    `json:"id"`
+	Title          string       `json:"title"`
+	RelativePath   string       `json:"relative_path"`
+	Size           int64        `json:"size"`
+	AccessCount    int          `json:"access_count"`
+	LastAccessedAt sql.NullTime `json:"last_accessed_at,omitempty"`
+	TieredAt       sql.NullTime `json:"tiered_at,omitempty"`
+}
+
+// TieringStatus is the admin view of storage tiering
+type TieringStatus struct {
+	Enabled   bool              `json:"enabled"`           // false when no cold backend is configured
+	Backend   string            `json:"backend,omitempty"` // where cold files go
+	Policy    TieringPolicy     `json:"policy"`
+	Tiers     []TierUsage       `json:"tiers"`
+	ColdItems []ColdContentItem `json:"cold_items"` // most recently moved first
+}
+
+// TieringRunResult says what one policy pass moved
+type TieringRunResult struct {
+	ItemsMoved int      `json:"items_moved"`
+	BytesMoved int64    `json:"bytes_moved"`
+	Errors     []string `json:"errors,omitempty"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+// time limit states returned to the player/dashboard
+const (
+	TimeLimitOK       = "ok"       // plenty of time left
+	TimeLimitWarning  = "warning"  // close to a limit, show a soft warning
+	TimeLimitExceeded = "exceeded" // limit reached, playback should be denied
+)
+
+// TimeLimits holds the viewing time limits for a profile (nil means no limit)
+type TimeLimits struct {
+	ProfileID     uuid.UUID `json:"profile_id"`
+	DailyMinutes  *int      `json:"daily_minutes"`
+	WeeklyMinutes *int      `json:"weekly_minutes"`
+	WarningPct    int       `json:"warning_pct"` // warn once usage passes this % of a limit
+
+	// timestamps
+	CreatedAt sql.NullTime `json:"created_at,omitempty"`
+	UpdatedAt sql.NullTime `json:"updated_at,omitempty"`
+}
+
+// SetTimeLimitsInput is what admins send to configure limits
+type SetTimeLimitsInput struct {
+	DailyMinutes  *int `json:"daily_minutes"`
+	WeeklyMinutes *int `json:"weekly_minutes"`
+	WarningPct    *int `json:"warning_pct,omitempty"`
+}
+
+// TimeLimitStatus shows current usage against the configured limits
+type TimeLimitStatus struct {
+	ProfileID        uuid.UUID   `json:"profile_id"`
+	Limits           *TimeLimits `json:"limits,omitempty"` // nil when no limits are set
+	UsedTodayMinutes int         `json:"used_today_minutes"`
+	UsedWeekMinutes  int         `json:"used_week_minutes"`
+	RemainingMinutes *int        `json:"remaining_minutes,omitempty"` // smallest remaining budget
+	State            string      `json:"state"`                       // ok, warning or exceeded
+	Message          string      `json:"message,omitempty"`
+	PlaybackAllowed  bool        `json:"playback_allowed"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "github.com/google/uuid"
+
+// CourseVisibility is which courses a profile may see
+type CourseVisibility struct {
+	ProfileID  uuid.UUID   `json:"profile_id"`
+	Restricted bool        `json:"restricted"` // false means the profile sees the whole library
+	CourseIDs  []uuid.UUID `json:"course_ids"` // assigned courses, only enforced when restricted
+}
+
+// SetCourseVisibilityInput replaces a profile's visibility settings
+type SetCourseVisibilityInput struct {
+	Restricted bool        `json:"restricted"`
+	CourseIDs  []uuid.UUID `json:"course_ids"`
+}
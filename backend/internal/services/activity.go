@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// activityLocation is the timezone whose midnight starts a new streak day, STREAK_TIMEZONE.
+// Defaults to the server's own zone, which is what viewing time limits go by too.
+func activityLocation() *time.Location {
+	name := os.Getenv("STREAK_TIMEZONE")
+	if name == "" {
+		return time.Local
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		log.Printf("Warning: invalid STREAK_TIMEZONE %q, using the server timezone: %v", name, err)
+		return time.Local
+	}
+	return loc
+}
+
+// activityDay returns the calendar day of t in loc, as midnight UTC the way DATE columns come back
+func activityDay(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.In(loc).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// recordActivity notes that a profile did something today. The first activity of a day
+// moves the streak on the profile along. Failures are only logged, progress updates
+// shouldn't fail over a streak.
+func (s *CourseService) recordActivity(ctx context.Context, userID uuid.UUID) {
+	today := activityDay(time.Now(), s.Location)
+
+	updates, err := s.DB.RecordDailyActivity(ctx, database.RecordDailyActivityParams{
+		ProfileID: userID,
+		Day:       today,
+	})
+	if err != nil {
+		log.Printf("Warning: could not record activity for profile %s: %v", userID, err)
+		return
+	}
+	if updates > 1 {
+		return
+	}
+
+	err = runInTx(ctx, s.Conn, s.DB, func(q *database.Queries) error {
+		profile, err := q.GetProfileById(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("error retrieving profile: %w", err)
+		}
+		if profile.LastActiveDate.Valid && !profile.LastActiveDate.Time.Before(today) {
+			return nil // already counted
+		}
+
+		if err := q.AdvanceProfileStreak(ctx, database.AdvanceProfileStreakParams{Day: today, ID: userID}); err != nil {
+			return err
+		}
+
+		// same rule as the query: the streak goes on from yesterday or starts over
+		streak := 1
+		if profile.LastActiveDate.Valid && profile.LastActiveDate.Time.Equal(today.AddDate(0, 0, -1)) {
+			streak = int(profile.Streak) + 1
+		}
+		return recordLedgerEntry(ctx, q, userID, ledgerEntry{
+			stat:        models.StatStreak,
+			amount:      streak - int(profile.Streak),
+			balance:     streak,
+			reason:      models.LedgerReasonActivity,
+			subject:     today.Format(time.DateOnly),
+			sourceEvent: models.LedgerSourceActivity,
+			description: "Active on " + today.Format(time.DateOnly),
+		})
+	})
+	if err != nil {
+		log.Printf("Warning: could not update streak for profile %s: %v", userID, err)
+	}
+}
+
+// calculateStreaks works the current and longest streak out of a profile's activity days,
+// newest first. The current streak survives until a whole day is missed - not having
+// studied yet today doesn't break it.
+func calculateStreaks(days []time.Time, today time.Time) (current, longest int) {
+	run := 0
+	var previous time.Time
+	for i, day := range days {
+		if i > 0 && previous.AddDate(0, 0, -1).Equal(day) {
+			run++
+		} else {
+			run = 1
+		}
+		previous = day
+
+		// the first run counts as current only if it reaches today or yesterday
+		if i+1 == run && !days[0].Before(today.AddDate(0, 0, -1)) {
+			current = run
+		}
+		longest = max(longest, run)
+	}
+	return current, longest
+}
+
+// currentStreak returns the stored streak of a profile, or 0 once a day was missed since
+func currentStreak(streak int32, lastActive sql.NullTime, loc *time.Location) int {
+	yesterday := activityDay(time.Now(), loc).AddDate(0, 0, -1)
+	if !lastActive.Valid || lastActive.Time.Before(yesterday) {
+		return 0
+	}
+	return int(streak)
+}
+
+// syncStreak recomputes a profile's streaks from its full activity history and stores
+// them, fixing up anything the day-by-day updates missed
+func (s *CourseService) syncStreak(ctx context.Context, userID uuid.UUID) (current, longest int, err error) {
+	days, err := s.DB.ListActivityDays(ctx, userID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error retrieving activity: %w", err)
+	}
+
+	current, longest = calculateStreaks(days, activityDay(time.Now(), s.Location))
+
+	lastActive := sql.NullTime{}
+	if len(days) > 0 {
+		lastActive = sql.NullTime{Time: days[0], Valid: true}
+	}
+	err = runInTx(ctx, s.Conn, s.DB, func(q *database.Queries) error {
+		profile, err := q.GetProfileById(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("error retrieving profile: %w", err)
+		}
+
+		err = q.SetProfileStreak(ctx, database.SetProfileStreakParams{
+			ID:             userID,
+			Streak:         int32(current),
+			LongestStreak:  int32(longest),
+			LastActiveDate: lastActive,
+		})
+		if err != nil {
+			return fmt.Errorf("error saving streak: %w", err)
+		}
+
+		// only a correction is worth a ledger entry
+		if current == int(profile.Streak) {
+			return nil
+		}
+		return recordLedgerEntry(ctx, q, userID, ledgerEntry{
+			stat:        models.StatStreak,
+			amount:      current - int(profile.Streak),
+			balance:     current,
+			reason:      models.LedgerReasonRecalculated,
+			sourceEvent: models.LedgerSourceStreakSync,
+			description: "Streak recalculated from activity history",
+		})
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return current, longest, nil
+}
@@ -0,0 +1,287 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/events"
+	"github.com/google/uuid"
+)
+
+// activity log paging limits
+const (
+	DefaultActivityLimit = 50
+	MaxActivityLimit     = 200
+)
+
+// feed paging limits, it's a dashboard widget
+const (
+	DefaultFeedLimit = 10
+	MaxFeedLimit     = 50
+)
+
+// how each kind of milestone reads in the feed, %s is the event's title
+var feedSummaries = map[string]string{
+	models.ActivityCourseStarted:   "Started course %s",
+	models.ActivityModuleCompleted: "Completed module %s",
+	models.ActivityCourseCompleted: "Completed course %s",
+	models.ActivityQuestCompleted:  "Finished quest: %s",
+	models.ActivityGoalMet:         "Met goal: %s",
+	models.ActivityLevelReached:    "Reached %s",
+}
+
+// ActivityService reads profiles' history timelines
+type ActivityService struct {
+	DB *database.Queries // database access
+}
+
+// NewActivityService creates service with db dependency
+func NewActivityService(db *database.Queries) *ActivityService {
+	return &ActivityService{
+		DB: db,
+	}
+}
+
+// ListActivity returns a page of a profile's activity since a point in time, newest first
+func (s *ActivityService) ListActivity(ctx context.Context, profileID uuid.UUID, since time.Time, limit, offset int) (*models.ActivityPage, error) {
+	// one extra row tells whether there's another page
+	rows, err := s.DB.ListActivityEvents(ctx, database.ListActivityEventsParams{
+		ProfileID: profileID,
+		Since:     since.In(time.Local),
+		Limit:     int32(limit + 1),
+		Offset:    int32(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving activity: %w", err)
+	}
+
+	page := &models.ActivityPage{
+		Events:  make([]models.ActivityEvent, 0, min(len(rows), limit)),
+		Limit:   limit,
+		Offset:  offset,
+		HasMore: len(rows) > limit,
+	}
+	for _, row := range rows[:min(len(rows), limit)] {
+		page.Events = append(page.Events, models.ActivityEvent{
+			ID:            row.ID,
+			Type:          row.EventType,
+			CourseID:      row.CourseID.UUID,
+			ContentItemID: row.ContentItemID.UUID,
+			Title:         row.Title,
+			CreatedAt:     row.CreatedAt,
+		})
+	}
+	return page, nil
+}
+
+// GetFeed returns a page of a profile's milestones, newest first: courses started and
+// finished, modules completed, quests, goals and levels
+func (s *ActivityService) GetFeed(ctx context.Context, profileID uuid.UUID, limit, offset int) (*models.Feed, error) {
+	// one extra row tells whether there's another page
+	rows, err := s.DB.ListFeedEvents(ctx, database.ListFeedEventsParams{
+		ProfileID:  profileID,
+		EventTypes: models.FeedEventTypes,
+		Limit:      int32(limit + 1),
+		Offset:     int32(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving feed: %w", err)
+	}
+
+	feed := &models.Feed{
+		Items:   make([]models.FeedItem, 0, min(len(rows), limit)),
+		Limit:   limit,
+		Offset:  offset,
+		HasMore: len(rows) > limit,
+	}
+	for _, row := range rows[:min(len(rows), limit)] {
+		feed.Items = append(feed.Items, models.FeedItem{
+			ActivityEvent: models.ActivityEvent{
+				ID:            row.ID,
+				Type:          row.EventType,
+				CourseID:      row.CourseID.UUID,
+				ContentItemID: row.ContentItemID.UUID,
+				Title:         row.Title,
+				CreatedAt:     row.CreatedAt,
+			},
+			Summary: fmt.Sprintf(feedSummaries[row.EventType], row.Title),
+		})
+	}
+	return feed, nil
+}
+
+// RecordLevelUp puts a level reached on the profile's timeline, for the level.up event
+func (s *ActivityService) RecordLevelUp(ctx context.Context, event events.Event) error {
+	return recordActivityEvent(ctx, s.DB, event.ProfileID, models.ActivityLevelReached, uuid.Nil, uuid.Nil, event.Title)
+}
+
+// recordActivityEvent adds an entry to a profile's timeline
+func recordActivityEvent(ctx context.Context, q *database.Queries, profileID uuid.UUID, eventType string, courseID, itemID uuid.UUID, title string) error {
+	err := q.CreateActivityEvent(ctx, database.CreateActivityEventParams{
+		ProfileID:     profileID,
+		EventType:     eventType,
+		CourseID:      toNullUUID(courseID),
+		ContentItemID: toNullUUID(itemID),
+		Title:         title,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write activity event: %w", err)
+	}
+	return nil
+}
+
+// progressBefore returns a profile's progress on an item before an update, nil if there was none
+func (s *CourseService) progressBefore(ctx context.Context, userID, itemID uuid.UUID) *database.UserProgress {
+	progress, err := s.DB.GetUserProgressByContentItem(ctx, database.GetUserProgressByContentItemParams{
+		UserID:        userID,
+		ContentItemID: itemID,
+	})
+	if err != nil {
+		return nil
+	}
+	return &progress
+}
+
+// recordProgress notes a progress update for the streak and puts starting or finishing
+// an item - and with it the course - on the profile's timeline. Failures are only logged,
+// the progress itself is saved already.
+func (s *CourseService) recordProgress(ctx context.Context, userID, itemID uuid.UUID, before *database.UserProgress, completed bool) {
+	s.recordActivity(ctx, userID)
+	s.recordProgressEvents(ctx, userID, itemID, before, completed)
+}
+
+// recordProgressEvents puts starting or finishing an item, and the course, on the timeline
+// and publishes the milestones - the item, its module and its course being completed.
+// Starting the first item of a course starts the course.
+func (s *CourseService) recordProgressEvents(ctx context.Context, userID, itemID uuid.UUID, before *database.UserProgress, completed bool) {
+	started := before == nil
+	finished := completed && (before == nil || !before.Completed)
+	if !started && !finished {
+		return
+	}
+
+	location, err := s.DB.GetContentItemLocation(ctx, itemID)
+	if err != nil {
+		log.Printf("Warning: could not look up content %s for the activity log: %v", itemID, err)
+		return
+	}
+
+	if started {
+		if err := recordActivityEvent(ctx, s.DB, userID, models.ActivityItemStarted, location.CourseID, itemID, location.Title); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+		s.recordCourseStarted(ctx, userID, location.CourseID, itemID)
+	}
+	if !finished {
+		return
+	}
+	if err := recordActivityEvent(ctx, s.DB, userID, models.ActivityItemCompleted, location.CourseID, itemID, location.Title); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	s.Events.Publish(events.Event{
+		Type:          events.ContentCompleted,
+		ProfileID:     userID,
+		CourseID:      location.CourseID,
+		ModuleID:      location.ModuleID,
+		ContentItemID: itemID,
+		Title:         location.Title,
+	})
+
+	// finishing the last item of a module finishes the module
+	moduleProgress, err := s.CalculateModuleProgress(ctx, userID, location.ModuleID)
+	if err != nil {
+		log.Printf("Warning: could not check module completion: %v", err)
+		return
+	}
+	if !moduleProgress.IsCompleted {
+		return
+	}
+	s.publishModuleCompleted(ctx, userID, location.ModuleID)
+
+	// ...and finishing the last module finishes the course
+	progress, err := s.CalculateCourseProgress(ctx, userID, location.CourseID)
+	if err != nil {
+		log.Printf("Warning: could not check course completion for the activity log: %v", err)
+		return
+	}
+	if !progress.IsCompleted {
+		return
+	}
+	s.recordCourseCompleted(ctx, userID, location.CourseID)
+}
+
+// recordCourseStarted puts course.started on the timeline when itemID holds the profile's
+// first progress in the course. A batch sync starts several items at the same moment, the
+// first of them in course order counts.
+func (s *CourseService) recordCourseStarted(ctx context.Context, userID, courseID, itemID uuid.UUID) {
+	progress, err := s.DB.ListUserProgressByCourse(ctx, database.ListUserProgressByCourseParams{
+		CourseID: courseID,
+		UserID:   userID,
+	})
+	if err != nil {
+		log.Printf("Warning: could not check whether course %s was just started: %v", courseID, err)
+		return
+	}
+	if len(progress) == 0 {
+		return
+	}
+	first := progress[0]
+	for _, p := range progress[1:] {
+		if p.CreatedAt.Time.Before(first.CreatedAt.Time) {
+			first = p
+		}
+	}
+	if first.ContentItemID != itemID {
+		return
+	}
+
+	course, err := s.DB.GetCourse(ctx, courseID)
+	if err != nil {
+		log.Printf("Warning: could not look up course %s for the activity log: %v", courseID, err)
+		return
+	}
+	if err := recordActivityEvent(ctx, s.DB, userID, models.ActivityCourseStarted, course.ID, uuid.Nil, course.Title); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+}
+
+// publishModuleCompleted puts module.completed on the timeline and publishes it
+func (s *CourseService) publishModuleCompleted(ctx context.Context, userID, moduleID uuid.UUID) {
+	module, err := s.DB.GetModule(ctx, moduleID)
+	if err != nil {
+		log.Printf("Warning: could not look up module %s for its completion event: %v", moduleID, err)
+		return
+	}
+	if err := recordActivityEvent(ctx, s.DB, userID, models.ActivityModuleCompleted, module.CourseID, uuid.Nil, module.Title); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	s.Events.Publish(events.Event{
+		Type:      events.ModuleCompleted,
+		ProfileID: userID,
+		CourseID:  module.CourseID,
+		ModuleID:  module.ID,
+		Title:     module.Title,
+	})
+}
+
+// recordCourseCompleted puts course.completed on the timeline and publishes it
+func (s *CourseService) recordCourseCompleted(ctx context.Context, userID, courseID uuid.UUID) {
+	course, err := s.DB.GetCourse(ctx, courseID)
+	if err != nil {
+		log.Printf("Warning: could not look up course %s for the activity log: %v", courseID, err)
+		return
+	}
+	if err := recordActivityEvent(ctx, s.DB, userID, models.ActivityCourseCompleted, course.ID, uuid.Nil, course.Title); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	s.Events.Publish(events.Event{
+		Type:      events.CourseCompleted,
+		ProfileID: userID,
+		CourseID:  course.ID,
+		Title:     course.Title,
+	})
+}
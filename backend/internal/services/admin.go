@@ -2,29 +2,34 @@ package services
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"log"
 
 	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/pkg/maintenance"
 	"github.com/NeroQue/course-management-backend/pkg/session"
 	"github.com/NeroQue/course-management-backend/pkg/task"
 )
 
 // AdminService handles administrative operations like factory reset
 type AdminService struct {
-	DB *database.Queries // database access
+	DB   *database.Queries // database access
+	Conn *sql.DB           // raw connection for transactions
 }
 
-// NewAdminService creates admin service with database dependency
-func NewAdminService(db *database.Queries) *AdminService {
+// NewAdminService creates admin service with database dependencies
+func NewAdminService(db *database.Queries, conn *sql.DB) *AdminService {
 	return &AdminService{
-		DB: db,
+		DB:   db,
+		Conn: conn,
 	}
 }
 
 // FactoryResetDatabase clears all data from the database
 func (s *AdminService) FactoryResetDatabase(ctx context.Context) error {
 	log.Println("Starting factory reset - clearing all database data")
+	defer maintenance.Enter("factory reset")()
 
 	// use the generated database method to clear all data
 	err := s.DB.FactoryResetDatabase(ctx)
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/access"
+	"github.com/NeroQue/course-management-backend/pkg/secret"
+	"github.com/NeroQue/course-management-backend/pkg/session"
+	"github.com/google/uuid"
+)
+
+// API token limits
+const (
+	apiTokenBytes        = 32
+	maxAPITokenNameLen   = 100
+	maxAPITokenExpiresIn = 10 * 365
+)
+
+// API token errors, the handler maps each to its own status
+var (
+	ErrInvalidAPIToken  = errors.New("invalid API token")
+	ErrAPITokenNotFound = errors.New("API token not found")
+)
+
+// APITokenService manages the long-lived tokens scripts use to trigger scans and imports
+// without a session. A token's scope is a role: requests made with it get that role, or
+// the profile's own if that's lower by now.
+type APITokenService struct {
+	DB *database.Queries // database access
+}
+
+// NewAPITokenService creates service with database dependency
+func NewAPITokenService(db *database.Queries) *APITokenService {
+	return &APITokenService{DB: db}
+}
+
+// CreateAPIToken makes a token for a profile and returns it with its secret. The scope
+// can't be above what the profile may do.
+func (s *APITokenService) CreateAPIToken(ctx context.Context, profileID uuid.UUID, input models.CreateAPITokenInput) (*models.CreatedAPIToken, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" || len(name) > maxAPITokenNameLen {
+		return nil, fmt.Errorf("%w: name must be 1 to %d characters", ErrInvalidAPIToken, maxAPITokenNameLen)
+	}
+	scope := input.Scope
+	if scope == "" {
+		scope = access.RoleViewer
+	}
+	if !access.ValidProfileRole(scope) {
+		return nil, fmt.Errorf("%w: scope must be one of %s", ErrInvalidAPIToken, strings.Join(access.ProfileRoles, ", "))
+	}
+	if input.ExpiresInDays < 0 || input.ExpiresInDays > maxAPITokenExpiresIn {
+		return nil, fmt.Errorf("%w: expires_in_days must be between 0 (never) and %d", ErrInvalidAPIToken, maxAPITokenExpiresIn)
+	}
+
+	profile, err := s.DB.GetProfileById(ctx, profileID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("profile not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving profile: %w", err)
+	}
+	if !access.Allows(scope, profile.Role) {
+		return nil, fmt.Errorf("%w: a %s profile can't have %s tokens", ErrInvalidAPIToken, profile.Role, scope)
+	}
+
+	token, err := secret.NewToken(apiTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+	token = session.APITokenPrefix + token
+
+	expiresAt := sql.NullTime{}
+	if input.ExpiresInDays > 0 {
+		expiresAt = sql.NullTime{Time: time.Now().AddDate(0, 0, input.ExpiresInDays), Valid: true}
+	}
+
+	row, err := s.DB.CreateAPIToken(ctx, database.CreateAPITokenParams{
+		ID:        uuid.New(),
+		ProfileID: profileID,
+		Name:      name,
+		TokenHash: secret.HashToken(token),
+		Scope:     scope,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating API token: %w", err)
+	}
+
+	return &models.CreatedAPIToken{
+		APIToken: toAPITokenModel(row, time.Now()),
+		Token:    token,
+	}, nil
+}
+
+// ListAPITokens returns every token a profile has had, newest first
+func (s *APITokenService) ListAPITokens(ctx context.Context, profileID uuid.UUID) ([]models.APIToken, error) {
+	rows, err := s.DB.ListAPITokensByProfile(ctx, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving API tokens: %w", err)
+	}
+
+	now := time.Now()
+	tokens := make([]models.APIToken, 0, len(rows))
+	for _, row := range rows {
+		tokens = append(tokens, toAPITokenModel(row, now))
+	}
+	return tokens, nil
+}
+
+// RevokeAPIToken stops a profile's token from working. It stays in the list.
+func (s *APITokenService) RevokeAPIToken(ctx context.Context, profileID, tokenID uuid.UUID) error {
+	revoked, err := s.DB.RevokeAPIToken(ctx, database.RevokeAPITokenParams{ID: tokenID, ProfileID: profileID})
+	if err != nil {
+		return fmt.Errorf("error revoking API token: %w", err)
+	}
+	if revoked == 0 {
+		return fmt.Errorf("%w: %s is not an active token of this profile", ErrAPITokenNotFound, tokenID)
+	}
+	return nil
+}
+
+// toAPITokenModel converts a token row, working out whether it still works at now
+func toAPITokenModel(row database.ApiToken, now time.Time) models.APIToken {
+	return models.APIToken{
+		ID:         row.ID,
+		ProfileID:  row.ProfileID,
+		Name:       row.Name,
+		Scope:      row.Scope,
+		ExpiresAt:  row.ExpiresAt,
+		RevokedAt:  row.RevokedAt,
+		LastUsedAt: row.LastUsedAt,
+		Active:     !row.RevokedAt.Valid && (!row.ExpiresAt.Valid || now.Before(row.ExpiresAt.Time)),
+		CreatedAt:  row.CreatedAt,
+	}
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/artifacts"
+	"github.com/NeroQue/course-management-backend/pkg/task"
+	"github.com/google/uuid"
+)
+
+// ArtifactService manages generated files (thumbnails, HLS, transcripts, extracted text)
+type ArtifactService struct {
+	DB       *database.Queries           // database access
+	Store    *artifacts.Store            // where artifacts live on disk
+	Policies map[string]artifacts.Policy // retention per artifact type
+}
+
+// NewArtifactService creates service with the configured store and retention policies
+func NewArtifactService(db *database.Queries) *ArtifactService {
+	s := &ArtifactService{
+		DB:       db,
+		Store:    artifacts.NewStore(),
+		Policies: artifacts.LoadPolicies(),
+	}
+	task.Register("artifact_cleanup", s.cleanupWork)
+	return s
+}
+
+// GetUsageReport returns artifact disk usage per course, biggest first
+func (s *ArtifactService) GetUsageReport(ctx context.Context) (*models.ArtifactUsageReport, error) {
+	usage, err := s.Store.Usage()
+	if err != nil {
+		return nil, fmt.Errorf("error measuring artifact usage: %w", err)
+	}
+
+	titles, err := s.courseTitles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.ArtifactUsageReport{
+		Root:     s.Store.Root,
+		Policies: []models.ArtifactPolicy{},
+		Courses:  []models.CourseArtifactUsage{},
+	}
+
+	for _, artifactType := range artifacts.Types {
+		policy := s.Policies[artifactType]
+		report.Policies = append(report.Policies, models.ArtifactPolicy{
+			Type:          artifactType,
+			MaxAgeSeconds: int64(policy.MaxAge.Seconds()),
+			MaxBytes:      policy.MaxBytes,
+		})
+	}
+
+	for courseIDStr, courseUsage := range usage {
+		courseID, err := uuid.Parse(courseIDStr)
+		if err != nil {
+			continue // stray folder that isn't a course
+		}
+
+		title, known := titles[courseIDStr]
+		entry := models.CourseArtifactUsage{
+			CourseID:    courseID,
+			CourseTitle: title,
+			Orphaned:    !known,
+			TotalBytes:  courseUsage.TotalBytes,
+			Types:       make(map[string]models.ArtifactTypeUsage),
+		}
+		for artifactType, typeUsage := range courseUsage.Types {
+			entry.Types[artifactType] = models.ArtifactTypeUsage{Bytes: typeUsage.Bytes, Files: typeUsage.Files}
+		}
+
+		report.TotalBytes += entry.TotalBytes
+		report.Courses = append(report.Courses, entry)
+	}
+
+	sort.Slice(report.Courses, func(i, j int) bool {
+		return report.Courses[i].TotalBytes > report.Courses[j].TotalBytes
+	})
+
+	return report, nil
+}
+
+// Cleanup runs one janitor pass: applies retention and drops artifacts of deleted courses
+func (s *ArtifactService) Cleanup(ctx context.Context) (artifacts.CleanupResult, error) {
+	titles, err := s.courseTitles(ctx)
+	if err != nil {
+		return artifacts.CleanupResult{}, err
+	}
+
+	known := make(map[string]bool, len(titles))
+	for id := range titles {
+		known[id] = true
+	}
+
+	result := s.Store.Clean(s.Policies, known, time.Now())
+	log.Printf("Artifact cleanup removed %d files (%d bytes), %d orphaned folders",
+		result.FilesRemoved, result.BytesFreed, len(result.OrphansRemoved))
+	return result, nil
+}
+
+// StartCleanupTask runs a janitor pass as a background task and returns the task ID
+func (s *ArtifactService) StartCleanupTask() string {
+	return task.Submit("artifact_cleanup", nil)
+}
+
+// cleanupWork is a janitor pass, it takes no input
+func (s *ArtifactService) cleanupWork(struct{}) task.Work {
+	return func(ctx context.Context, taskID string) (interface{}, error) {
+		task.SetTaskMessage(taskID, "Cleaning up generated artifacts")
+		return s.Cleanup(ctx)
+	}
+}
+
+// JanitorRoutine runs a cleanup task every interval - meant to run in its own goroutine
+func (s *ArtifactService) JanitorRoutine(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.StartCleanupTask()
+	}
+}
+
+// courseTitles maps course ID strings to titles for every course in the database
+func (s *ArtifactService) courseTitles(ctx context.Context) (map[string]string, error) {
+	courses, err := s.DB.ListCourses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving courses: %w", err)
+	}
+
+	titles := make(map[string]string, len(courses))
+	for _, course := range courses {
+		titles[course.ID.String()] = course.Title
+	}
+	return titles, nil
+}
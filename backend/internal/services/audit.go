@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// recordAudit writes an entry to the audit log
+func recordAudit(ctx context.Context, q *database.Queries, entry models.AuditEntry) error {
+	_, err := q.CreateAuditEntry(ctx, database.CreateAuditEntryParams{
+		ActorID:    toNullUUID(entry.ActorID),
+		SubjectID:  toNullUUID(entry.SubjectID),
+		Action:     entry.Action,
+		EntityType: sql.NullString{String: entry.EntityType, Valid: entry.EntityType != ""},
+		EntityID:   toNullUUID(entry.EntityID),
+		Details:    sql.NullString{String: entry.Details, Valid: entry.Details != ""},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+	return nil
+}
+
+// ListAuditLog returns audit entries, newest first
+func (s *AdminService) ListAuditLog(ctx context.Context, limit, offset int) ([]models.AuditEntry, error) {
+	rows, err := s.DB.ListAuditEntries(ctx, database.ListAuditEntriesParams{
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit log: %w", err)
+	}
+
+	entries := make([]models.AuditEntry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, models.AuditEntry{
+			ID:         row.ID,
+			ActorID:    row.ActorID.UUID,
+			SubjectID:  row.SubjectID.UUID,
+			Action:     row.Action,
+			EntityType: row.EntityType.String,
+			EntityID:   row.EntityID.UUID,
+			Details:    row.Details.String,
+			CreatedAt:  row.CreatedAt,
+		})
+	}
+	return entries, nil
+}
+
+// toNullUUID treats uuid.Nil as "not set"
+func toNullUUID(id uuid.UUID) uuid.NullUUID {
+	return uuid.NullUUID{UUID: id, Valid: id != uuid.Nil}
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/thumbnail"
+	"github.com/google/uuid"
+)
+
+// AvatarSize is the box avatars are shrunk to fit, big enough for the profile picker
+// on a high density screen
+const AvatarSize = 256
+
+// avatar errors
+var (
+	ErrInvalidAvatar = errors.New("invalid avatar image")
+	ErrNoAvatar      = errors.New("profile has no avatar")
+)
+
+// SetAvatar replaces a profile's picture with an uploaded image. Anything Go can decode
+// (JPEG, PNG, GIF) is accepted and stored as a JPEG fitting AvatarSize, so what's kept
+// never depends on what was sent.
+func (s *ProfileService) SetAvatar(ctx context.Context, profileID uuid.UUID, upload io.Reader) (models.Profile, error) {
+	if _, err := s.DB.GetProfileById(ctx, profileID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Profile{}, fmt.Errorf("profile not found: %w", err)
+		}
+		return models.Profile{}, fmt.Errorf("error retrieving profile: %w", err)
+	}
+
+	if err := os.MkdirAll(s.AvatarDir, 0755); err != nil {
+		return models.Profile{}, fmt.Errorf("error creating avatar folder: %w", err)
+	}
+
+	// the resizer works on files, so the upload goes to disk first
+	original, err := os.CreateTemp(s.AvatarDir, profileID.String()+"-*.upload")
+	if err != nil {
+		return models.Profile{}, fmt.Errorf("error saving upload: %w", err)
+	}
+	defer os.Remove(original.Name())
+	if _, err := io.Copy(original, upload); err != nil {
+		original.Close()
+		return models.Profile{}, fmt.Errorf("error saving upload: %w", err)
+	}
+	if err := original.Close(); err != nil {
+		return models.Profile{}, fmt.Errorf("error saving upload: %w", err)
+	}
+
+	path := s.avatarPath(profileID)
+	tmp := path + ".tmp"
+	if err := thumbnail.Resize(original.Name(), tmp, AvatarSize, AvatarSize, 85); err != nil {
+		os.Remove(tmp)
+		return models.Profile{}, fmt.Errorf("%w: %v", ErrInvalidAvatar, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return models.Profile{}, fmt.Errorf("error saving avatar: %w", err)
+	}
+
+	updatedProfile, err := s.DB.SetProfileAvatarUpdated(ctx, profileID)
+	if err != nil {
+		return models.Profile{}, fmt.Errorf("error saving avatar: %w", err)
+	}
+	return s.toProfileModel(updatedProfile), nil
+}
+
+// GetAvatar returns the path of a profile's picture and its version for caching,
+// ErrNoAvatar when none was uploaded
+func (s *ProfileService) GetAvatar(ctx context.Context, profileID uuid.UUID) (string, string, error) {
+	profile, err := s.DB.GetProfileById(ctx, profileID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", "", fmt.Errorf("profile not found: %w", err)
+		}
+		return "", "", fmt.Errorf("error retrieving profile: %w", err)
+	}
+	if !profile.AvatarUpdatedAt.Valid {
+		return "", "", ErrNoAvatar
+	}
+
+	path := s.avatarPath(profileID)
+	if _, err := os.Stat(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", "", fmt.Errorf("%w: file is missing from %s", ErrNoAvatar, s.AvatarDir)
+		}
+		return "", "", fmt.Errorf("error reading avatar: %w", err)
+	}
+	return path, avatarVersion(profile.AvatarUpdatedAt), nil
+}
+
+// removeAvatar deletes a profile's picture, if it has one
+func (s *ProfileService) removeAvatar(profileID uuid.UUID) error {
+	if err := os.Remove(s.avatarPath(profileID)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error deleting avatar: %w", err)
+	}
+	return nil
+}
+
+// avatarPath is where a profile's picture is kept
+func (s *ProfileService) avatarPath(profileID uuid.UUID) string {
+	return filepath.Join(s.AvatarDir, profileID.String()+".jpg")
+}
+
+// avatarURL is where clients fetch a profile's picture, empty without one. The version
+// changes with every upload, so browsers can cache each URL for good.
+func avatarURL(profileID uuid.UUID, updatedAt sql.NullTime) string {
+	if !updatedAt.Valid {
+		return ""
+	}
+	return "/api/profiles/" + profileID.String() + "/avatar?v=" + avatarVersion(updatedAt)
+}
+
+// avatarVersion identifies one upload of a profile's picture
+func avatarVersion(updatedAt sql.NullTime) string {
+	return fmt.Sprintf("%x", updatedAt.Time.UnixMicro())
+}
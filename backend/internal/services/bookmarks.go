@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// maxBookmarkLabel keeps labels short enough to show on a player's timeline
+const maxBookmarkLabel = 200
+
+// bookmark errors, the handler maps each to its own status
+var (
+	ErrInvalidBookmark  = errors.New("invalid bookmark")
+	ErrBookmarkNotFound = errors.New("bookmark not found")
+)
+
+// BookmarkService keeps the moments in long lectures profiles want to come back to
+type BookmarkService struct {
+	DB         *database.Queries  // database access
+	Visibility *VisibilityService // restricted profiles only see assigned courses
+}
+
+// NewBookmarkService creates service with its dependencies
+func NewBookmarkService(db *database.Queries, visibility *VisibilityService) *BookmarkService {
+	return &BookmarkService{
+		DB:         db,
+		Visibility: visibility,
+	}
+}
+
+// CreateBookmark bookmarks a moment of an audio or video item for the user
+func (s *BookmarkService) CreateBookmark(ctx context.Context, userID, itemID uuid.UUID, input models.CreateBookmarkInput) (*models.Bookmark, error) {
+	location, err := s.locate(ctx, itemID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if location.ContentType != "audio" && location.ContentType != "video" {
+		return nil, fmt.Errorf("%w: %s is %s", ErrNotMediaContent, location.Title, location.ContentType)
+	}
+
+	if input.Timestamp == nil {
+		return nil, fmt.Errorf("%w: timestamp is required", ErrInvalidBookmark)
+	}
+	position := *input.Timestamp
+	if position < 0 || math.IsNaN(position) || math.IsInf(position, 0) {
+		return nil, fmt.Errorf("%w: timestamp must be a number of seconds, 0 or more", ErrInvalidBookmark)
+	}
+
+	label := strings.TrimSpace(input.Label)
+	if utf8.RuneCountInString(label) > maxBookmarkLabel {
+		return nil, fmt.Errorf("%w: label cannot be longer than %d characters", ErrInvalidBookmark, maxBookmarkLabel)
+	}
+
+	row, err := s.DB.CreateContentBookmark(ctx, database.CreateContentBookmarkParams{
+		ContentItemID: itemID,
+		UserID:        userID,
+		Position:      position,
+		Label:         label,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error saving bookmark: %w", err)
+	}
+	return toBookmarkModel(row), nil
+}
+
+// ListBookmarks returns the user's bookmarks on an item in playback order
+func (s *BookmarkService) ListBookmarks(ctx context.Context, userID, itemID uuid.UUID) ([]*models.Bookmark, error) {
+	if _, err := s.locate(ctx, itemID, userID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.DB.ListContentBookmarks(ctx, database.ListContentBookmarksParams{
+		ContentItemID: itemID,
+		UserID:        userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving bookmarks: %w", err)
+	}
+
+	bookmarks := make([]*models.Bookmark, 0, len(rows))
+	for _, row := range rows {
+		bookmarks = append(bookmarks, toBookmarkModel(row))
+	}
+	return bookmarks, nil
+}
+
+// DeleteBookmark removes one of the user's bookmarks, other profiles' bookmarks count as missing
+func (s *BookmarkService) DeleteBookmark(ctx context.Context, userID, bookmarkID uuid.UUID) error {
+	bookmark, err := s.DB.GetContentBookmark(ctx, bookmarkID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrBookmarkNotFound
+		}
+		return fmt.Errorf("error retrieving bookmark: %w", err)
+	}
+	if bookmark.UserID != userID {
+		return ErrBookmarkNotFound
+	}
+
+	if err := s.DB.DeleteContentBookmark(ctx, bookmarkID); err != nil {
+		return fmt.Errorf("error deleting bookmark: %w", err)
+	}
+	return nil
+}
+
+// locate looks up a content item, refusing items in courses the profile can't see
+func (s *BookmarkService) locate(ctx context.Context, itemID, profileID uuid.UUID) (database.GetContentItemLocationRow, error) {
+	location, err := s.DB.GetContentItemLocation(ctx, itemID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return location, fmt.Errorf("content item not found: %w", err)
+		}
+		return location, fmt.Errorf("error retrieving content item: %w", err)
+	}
+
+	visible, err := s.Visibility.CanSeeCourse(ctx, profileID, location.CourseID)
+	if err != nil {
+		return location, fmt.Errorf("error checking course visibility: %w", err)
+	}
+	if !visible {
+		return location, ErrContentNotVisible
+	}
+	return location, nil
+}
+
+// toBookmarkModel converts the db row to the app model
+func toBookmarkModel(row database.ContentBookmark) *models.Bookmark {
+	return &models.Bookmark{
+		ID:            row.ID,
+		ContentItemID: row.ContentItemID,
+		UserID:        row.UserID,
+		Timestamp:     row.Position,
+		Label:         row.Label,
+		CreatedAt:     row.CreatedAt.Time,
+	}
+}
@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/metrics"
+	"github.com/google/uuid"
+)
+
+// ExportCourse builds a portable bundle of a course's structure and metadata
+// If progressUserID is set, that profile's progress is included keyed by content path.
+func (s *CourseService) ExportCourse(ctx context.Context, courseID uuid.UUID, progressUserID uuid.NullUUID) (*models.CourseBundle, error) {
+	course, err := s.GetCourse(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	// base path is local to this machine, meaningless elsewhere
+	course.BasePath = ""
+
+	bundle := &models.CourseBundle{
+		FormatVersion: models.CourseBundleVersion,
+		ExportedAt:    time.Now().UTC(),
+		Course:        course,
+	}
+
+	if !progressUserID.Valid {
+		return bundle, nil
+	}
+
+	records, err := s.GetUserCourseProgress(ctx, progressUserID.UUID, courseID)
+	if err != nil {
+		return nil, fmt.Errorf("error exporting course progress: %w", err)
+	}
+
+	// map content IDs back to paths so progress survives the re-import
+	itemPaths := make(map[uuid.UUID]string)
+	for _, module := range course.Modules {
+		for _, item := range module.ContentItems {
+			itemPaths[item.ID] = item.RelativePath
+		}
+	}
+
+	for _, record := range records {
+		path, ok := itemPaths[record.ContentItemID]
+		if !ok {
+			continue
+		}
+
+		entry := models.BundleProgress{
+			UserID:       record.UserID,
+			RelativePath: path,
+			Completed:    record.Completed,
+			ProgressPct:  record.ProgressPct,
+			LastPosition: record.LastPosition,
+		}
+		if record.LastAccessed.Valid {
+			lastAccessed := record.LastAccessed.Time
+			entry.LastAccessed = &lastAccessed
+		}
+		bundle.Progress = append(bundle.Progress, entry)
+	}
+
+	return bundle, nil
+}
+
+// ImportBundle recreates a course from a bundle exported by another instance
+// Paths are re-mapped under relativePath (defaults to the bundle's own path) in the local
+// base directory. Missing files are reported, and with strict set they abort the import.
+// Progress is only restored for the importing profile, unless allProgress is set.
+func (s *CourseService) ImportBundle(ctx context.Context, bundle *models.CourseBundle, relativePath string, ownerID, actorID uuid.UUID, strict, allProgress bool) (*models.BundleImportResult, error) {
+	if bundle == nil || bundle.Course == nil {
+		metrics.IncCounter(metrics.FailedImports, "invalid_input")
+		return nil, errors.New("bundle has no course")
+	}
+	if bundle.FormatVersion < 1 || bundle.FormatVersion > models.CourseBundleVersion {
+		metrics.IncCounter(metrics.FailedImports, "invalid_input")
+		return nil, fmt.Errorf("unsupported bundle format version %d", bundle.FormatVersion)
+	}
+
+	course := bundle.Course
+	oldRoot := normalizeRelativePath(course.RelativePath)
+	newRoot, err := bundleRoot(oldRoot, relativePath)
+	if err != nil {
+		metrics.IncCounter(metrics.FailedImports, "invalid_input")
+		return nil, err
+	}
+
+	if err := s.checkDuplicateImport(ctx, newRoot); err != nil {
+		var duplicate *DuplicateCourseError
+		if errors.As(err, &duplicate) {
+			metrics.IncCounter(metrics.FailedImports, "duplicate")
+		}
+		return nil, err
+	}
+
+	result := &models.BundleImportResult{MissingFiles: []string{}}
+
+	// fresh IDs so the same bundle can't collide with what's already here,
+	// and remember old item IDs -> paths so progress can be matched afterwards
+	course.ID = uuid.Nil
+	course.CreatorID = ownerID
+	course.RelativePath = newRoot
+	course.BasePath = s.Parser.BasePath
+	course.Note = nil
+	course.EnrolledProfiles = nil
+	for _, module := range course.Modules {
+		module.ID = uuid.Nil
+		module.RelativePath = remapBundlePath(module.RelativePath, oldRoot, newRoot)
+
+		for _, item := range module.ContentItems {
+			item.ID = uuid.Nil
+			item.RelativePath = remapBundlePath(item.RelativePath, oldRoot, newRoot)
+
+			fullPath := filepath.Join(s.Parser.BasePath, filepath.FromSlash(item.RelativePath))
+			if _, err := os.Stat(fullPath); err != nil {
+				result.MissingFiles = append(result.MissingFiles, item.RelativePath)
+			}
+		}
+	}
+
+	if strict && len(result.MissingFiles) > 0 {
+		metrics.IncCounter(metrics.FailedImports, "directory")
+		return result, fmt.Errorf("%d files from the bundle are missing locally", len(result.MissingFiles))
+	}
+
+	created, err := s.CreateCourse(ctx, course)
+	if err != nil {
+		metrics.IncCounter(metrics.FailedImports, importFailureReason(err))
+		return nil, err
+	}
+	result.Course = created
+
+	entry := models.AuditEntry{
+		ActorID:    actorID,
+		Action:     models.AuditCourseImport,
+		EntityType: models.EntityCourse,
+		EntityID:   created.ID,
+		Details:    "bundle: " + created.RelativePath,
+	}
+	if ownerID != actorID {
+		entry.SubjectID = ownerID
+	}
+	if err := recordAudit(ctx, s.DB, entry); err != nil {
+		log.Printf("Warning: could not audit bundle import of course %s: %v", created.ID, err)
+	}
+
+	s.restoreBundleProgress(ctx, bundle.Progress, created, oldRoot, newRoot, actorID, allProgress, result)
+
+	if len(result.MissingFiles) > 0 {
+		result.Warnings = append(result.Warnings,
+			fmt.Sprintf("%d items have no file under %s yet", len(result.MissingFiles), s.Parser.BasePath))
+	}
+
+	metrics.SetTimestamp(metrics.LastSuccessfulImport, time.Now())
+	return result, nil
+}
+
+// restoreBundleProgress brings over the importing profile's progress, or with allProgress
+// progress for every profile that also exists on this instance
+func (s *CourseService) restoreBundleProgress(ctx context.Context, progress []models.BundleProgress, course *models.Course, oldRoot, newRoot string, actorID uuid.UUID, allProgress bool, result *models.BundleImportResult) {
+	if len(progress) == 0 {
+		return
+	}
+
+	itemIDs := make(map[string]uuid.UUID)
+	for _, module := range course.Modules {
+		for _, item := range module.ContentItems {
+			itemIDs[item.RelativePath] = item.ID
+		}
+	}
+
+	knownProfiles := make(map[uuid.UUID]bool)
+	for _, record := range progress {
+		itemID, ok := itemIDs[remapBundlePath(record.RelativePath, oldRoot, newRoot)]
+		if !ok || (!allProgress && record.UserID != actorID) {
+			result.SkippedProgress++
+			continue
+		}
+
+		known, checked := knownProfiles[record.UserID]
+		if !checked {
+			_, err := s.DB.GetProfileById(ctx, record.UserID)
+			known = err == nil
+			knownProfiles[record.UserID] = known
+		}
+		if !known {
+			result.SkippedProgress++
+			continue
+		}
+
+		lastAccessed := sql.NullTime{}
+		if record.LastAccessed != nil {
+			lastAccessed = sql.NullTime{Time: *record.LastAccessed, Valid: true}
+		}
+
+		_, err := s.DB.UpsertUserProgress(ctx, database.UpsertUserProgressParams{
+			UserID:        record.UserID,
+			ContentItemID: itemID,
+			Completed:     record.Completed,
+			ProgressPct:   record.ProgressPct,
+			LastPosition:  sql.NullInt32{Int32: int32(record.LastPosition), Valid: record.LastPosition > 0},
+			LastAccessed:  lastAccessed,
+		})
+		if err != nil {
+			log.Printf("Warning: could not restore progress for %s: %v", record.RelativePath, err)
+			result.SkippedProgress++
+			continue
+		}
+		result.RestoredProgress++
+	}
+
+	if result.SkippedProgress > 0 {
+		result.Warnings = append(result.Warnings,
+			fmt.Sprintf("%d progress records skipped (other or unknown profile, or unknown item)", result.SkippedProgress))
+	}
+}
+
+// bundleRoot picks where an imported course goes: relativePath, or the bundle's own path.
+// Either way it has to be a folder strictly inside the courses directory.
+func bundleRoot(oldRoot, relativePath string) (string, error) {
+	newRoot := oldRoot
+	if relativePath != "" {
+		newRoot = normalizeRelativePath(relativePath)
+	}
+	if _, err := resolveContentPath(newRoot); err != nil {
+		return "", fmt.Errorf("invalid course path %q: %w", newRoot, err)
+	}
+	return newRoot, nil
+}
+
+// remapBundlePath moves a path from the exporting instance's course folder to the local one
+func remapBundlePath(relativePath, oldRoot, newRoot string) string {
+	cleaned := normalizeRelativePath(relativePath)
+	if oldRoot != "" && (cleaned == oldRoot || strings.HasPrefix(cleaned, oldRoot+"/")) {
+		return path.Join(newRoot, strings.TrimPrefix(cleaned, oldRoot))
+	}
+	// path wasn't under the old course folder, keep its shape but nest it locally
+	return path.Join(newRoot, path.Base(cleaned))
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestBundleRoot(t *testing.T) {
+	t.Setenv("INTERNAL_COURSES_DIR", t.TempDir())
+
+	tests := []struct {
+		name         string
+		oldRoot      string
+		relativePath string
+		want         string // empty when the path is refused
+	}{
+		{"bundle's own path", "go/basics", "", "go/basics"},
+		{"new path", "go/basics", "imported/go", "imported/go"},
+		{"new path is cleaned", "go/basics", "./imported//go/", "imported/go"},
+		{"dot dot inside the folder", "go/basics", "imported/../go", "go"},
+		{"escapes the folder", "go/basics", "../elsewhere", ""},
+		{"escapes through a subfolder", "go/basics", "imported/../../elsewhere", ""},
+		{"courses folder itself", "go/basics", ".", ""},
+		{"bundle path escapes", "../elsewhere", "", ""},
+		{"bundle without a path", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := bundleRoot(tt.oldRoot, tt.relativePath)
+			if tt.want == "" {
+				if !errors.Is(err, ErrUnsafeContentPath) {
+					t.Fatalf("got %q, %v, want ErrUnsafeContentPath", got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("got %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemapBundlePath(t *testing.T) {
+	tests := []struct {
+		relativePath string
+		oldRoot      string
+		want         string
+	}{
+		{"go/basics/01 intro/video.mp4", "go/basics", "imported/go/01 intro/video.mp4"},
+		{"go/basics", "go/basics", "imported/go"},
+		{"./go/basics//notes.pdf", "go/basics", "imported/go/notes.pdf"},
+		{"go/basics-extra/video.mp4", "go/basics", "imported/go/video.mp4"}, // only whole folders match
+		{"elsewhere/deep/video.mp4", "go/basics", "imported/go/video.mp4"},
+		{"video.mp4", "", "imported/go/video.mp4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.relativePath, func(t *testing.T) {
+			if got := remapBundlePath(tt.relativePath, tt.oldRoot, "imported/go"); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImportBundleRefusesBadBundles(t *testing.T) {
+	t.Setenv("INTERNAL_COURSES_DIR", t.TempDir())
+	// every case fails before the database is asked, there is none behind it
+	s := &CourseService{}
+	course := func(relativePath string) *models.Course {
+		return &models.Course{Title: "Go", RelativePath: relativePath}
+	}
+
+	tests := []struct {
+		name         string
+		bundle       *models.CourseBundle
+		relativePath string
+	}{
+		{"no bundle", nil, ""},
+		{"no course", &models.CourseBundle{FormatVersion: 1}, ""},
+		{"unknown version", &models.CourseBundle{FormatVersion: models.CourseBundleVersion + 1, Course: course("go")}, ""},
+		{"no version", &models.CourseBundle{Course: course("go")}, ""},
+		{"new path escapes", &models.CourseBundle{FormatVersion: 1, Course: course("go")}, "../../etc"},
+		{"bundle path escapes", &models.CourseBundle{FormatVersion: 1, Course: course("../../etc")}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := s.ImportBundle(context.Background(), tt.bundle, tt.relativePath, uuid.New(), uuid.New(), false, false)
+			if err == nil || result != nil {
+				t.Fatalf("got %+v, %v, want an error", result, err)
+			}
+		})
+	}
+}
+
+func TestRestoreBundleProgressSkipsOtherProfiles(t *testing.T) {
+	actorID, otherID := uuid.New(), uuid.New()
+	course := &models.Course{Modules: []*models.Module{{
+		ContentItems: []*models.ContentItem{{ID: uuid.New(), RelativePath: "imported/go/video.mp4"}},
+	}}}
+	progress := []models.BundleProgress{
+		{UserID: otherID, RelativePath: "go/video.mp4", Completed: true},
+		{UserID: otherID, RelativePath: "go/video.mp4", ProgressPct: 50},
+		{UserID: actorID, RelativePath: "go/missing.mp4", Completed: true},
+	}
+
+	// nothing here may reach the database, there is none behind it
+	s := &CourseService{}
+	result := &models.BundleImportResult{}
+	s.restoreBundleProgress(context.Background(), progress, course, "go", "imported/go", actorID, false, result)
+
+	if result.RestoredProgress != 0 || result.SkippedProgress != len(progress) {
+		t.Errorf("restored %d, skipped %d, want 0 and %d", result.RestoredProgress, result.SkippedProgress, len(progress))
+	}
+	if len(result.Warnings) != 1 {
+		t.Errorf("got warnings %q, want one about the skipped records", result.Warnings)
+	}
+}
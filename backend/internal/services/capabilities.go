@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/capability"
+	"github.com/google/uuid"
+)
+
+// recordCapabilityRequirements sniffs each item's file and stores which optional subsystems it needs
+// Failures only get logged - a missed requirement shouldn't break an import.
+func (s *CourseService) recordCapabilityRequirements(ctx context.Context, course *models.Course) {
+	for _, module := range course.Modules {
+		for _, item := range module.ContentItems {
+			fullPath := filepath.Join(s.Parser.BasePath, item.RelativePath)
+			for _, needed := range capability.Detect(fullPath, item.ContentType) {
+				err := s.DB.AddContentItemRequirement(ctx, database.AddContentItemRequirementParams{
+					ContentItemID: item.ID,
+					Capability:    needed,
+				})
+				if err != nil {
+					log.Printf("Warning: could not record %s requirement for %s: %v", needed, item.RelativePath, err)
+				}
+			}
+		}
+	}
+}
+
+// GetCapabilityWarnings lists the optional subsystems a course needs and whether they're installed
+func (s *CourseService) GetCapabilityWarnings(ctx context.Context, courseID uuid.UUID) ([]models.CapabilityWarning, error) {
+	rows, err := s.DB.ListCourseRequirements(ctx, courseID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving course requirements: %w", err)
+	}
+
+	// rows come sorted by capability, so group as we go
+	warnings := []models.CapabilityWarning{}
+	for _, row := range rows {
+		if len(warnings) == 0 || warnings[len(warnings)-1].Capability != row.Capability {
+			warnings = append(warnings, models.CapabilityWarning{
+				Capability: row.Capability,
+				Tool:       capability.Tool(row.Capability),
+				Available:  capability.Available(row.Capability),
+				Items:      []models.CapabilityItem{},
+			})
+		}
+
+		warning := &warnings[len(warnings)-1]
+		warning.Items = append(warning.Items, models.CapabilityItem{
+			ID:           row.ContentItemID,
+			Title:        row.Title,
+			RelativePath: row.RelativePath,
+		})
+		warning.ItemCount++
+	}
+
+	for i := range warnings {
+		warnings[i].Message = capabilityMessage(warnings[i])
+	}
+	return warnings, nil
+}
+
+// capabilityMessage gives a human explanation for the UI
+func capabilityMessage(warning models.CapabilityWarning) string {
+	if warning.Available {
+		return fmt.Sprintf("%d items use %s, which is installed", warning.ItemCount, warning.Tool)
+	}
+
+	switch warning.Capability {
+	case capability.Transcoding:
+		return fmt.Sprintf("%d videos use a format browsers can't play; install %s to convert them", warning.ItemCount, warning.Tool)
+	case capability.OCR:
+		return fmt.Sprintf("%d PDFs look scanned and won't be searchable until %s is installed", warning.ItemCount, warning.Tool)
+	default:
+		return fmt.Sprintf("%d items need %s, which is not installed", warning.ItemCount, warning.Tool)
+	}
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/artifacts"
+	"github.com/NeroQue/course-management-backend/pkg/chapters"
+	"github.com/NeroQue/course-management-backend/pkg/thumbnail"
+	"github.com/google/uuid"
+)
+
+// ErrNotMediaContent is returned when chapters are asked for on something that isn't audio or video
+var ErrNotMediaContent = errors.New("content item is not audio or video")
+
+// ListChapters returns the chapters of an audio or video item with the profile's progress
+// through each one. Chapters saved for the item - read at import or edited by hand - win;
+// otherwise they're read from the file with ffprobe, m4b audiobooks mostly, and cached
+// by checksum.
+func (s *StreamService) ListChapters(ctx context.Context, itemID, profileID uuid.UUID) (*models.ContentChapters, error) {
+	location, err := s.locate(ctx, itemID, profileID)
+	if err != nil {
+		return nil, err
+	}
+	if location.ContentType != "audio" && location.ContentType != "video" {
+		return nil, fmt.Errorf("%w: %s is %s", ErrNotMediaContent, location.Title, location.ContentType)
+	}
+
+	markers, err := s.savedChapters(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(markers) == 0 {
+		// the file has to be on disk to read it, same as for playing it
+		file, err := s.OpenContent(ctx, itemID, profileID, "", false)
+		if err != nil {
+			return nil, err
+		}
+
+		markers, err = s.chapterMarkers(ctx, location.CourseID, itemID, file.Path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := &models.ContentChapters{ContentItemID: itemID, Chapters: make([]models.ContentChapter, 0, len(markers))}
+	completed := false
+	if profileID != uuid.Nil {
+		progress, err := s.DB.GetUserProgressByContentItem(ctx, database.GetUserProgressByContentItemParams{
+			UserID:        profileID,
+			ContentItemID: itemID,
+		})
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("error retrieving progress: %w", err)
+		}
+		result.Position = int(progress.LastPosition.Int32)
+		completed = progress.Completed
+	}
+
+	position := float64(result.Position)
+	for i, marker := range markers {
+		chapter := models.ContentChapter{Index: i, Title: marker.Title, Start: marker.Start, End: marker.End}
+		switch {
+		case completed || (marker.End > 0 && position >= marker.End):
+			chapter.ProgressPct = 100
+			chapter.Completed = true
+		case position >= marker.Start:
+			if marker.End > marker.Start {
+				chapter.ProgressPct = float32((position - marker.Start) * 100 / (marker.End - marker.Start))
+			}
+			chapter.Current = result.Position > 0
+		}
+		result.Chapters = append(result.Chapters, chapter)
+	}
+	return result, nil
+}
+
+// savedChapters returns the chapters stored for an item, each ending where the next one
+// starts and the last one at the end of the item - or 0 when its length isn't known
+func (s *StreamService) savedChapters(ctx context.Context, itemID uuid.UUID) ([]chapters.Chapter, error) {
+	dbChapters, err := s.DB.ListContentChapters(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving content chapters: %w", err)
+	}
+	if len(dbChapters) == 0 {
+		return nil, nil
+	}
+
+	item, err := s.DB.GetContentItem(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving content item: %w", err)
+	}
+
+	markers := make([]chapters.Chapter, len(dbChapters))
+	for i, dbChapter := range dbChapters {
+		markers[i] = chapters.Chapter{Title: dbChapter.Title, Start: dbChapter.StartTime, End: float64(item.Duration.Int32)}
+		if i > 0 {
+			markers[i-1].End = dbChapter.StartTime
+		}
+	}
+	return markers, nil
+}
+
+// chapterMarkers reads a file's chapters, from the artifact cache when it's been read before
+func (s *StreamService) chapterMarkers(ctx context.Context, courseID, itemID uuid.UUID, path string) ([]chapters.Chapter, error) {
+	checksum, err := thumbnail.Checksum(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading content file: %w", err)
+	}
+
+	cached := filepath.Join(s.Artifacts.ItemDir(artifacts.Chapters, courseID, itemID), checksum+".json")
+	if data, err := os.ReadFile(cached); err == nil {
+		var markers []chapters.Chapter
+		if json.Unmarshal(data, &markers) == nil {
+			return markers, nil
+		}
+	}
+
+	markers, err := chapters.Extract(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	// the cache is only a shortcut, failing to write it doesn't fail the request
+	if data, err := json.Marshal(markers); err == nil {
+		if os.MkdirAll(filepath.Dir(cached), 0755) == nil {
+			os.WriteFile(cached, data, 0644)
+		}
+	}
+	return markers, nil
+}
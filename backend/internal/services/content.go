@@ -0,0 +1,266 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// errors the content handlers map to 4xx responses
+var (
+	ErrInvalidContentOrder = errors.New("invalid content order")
+	ErrInvalidContentMove  = errors.New("invalid content move")
+	ErrContentFileMissing  = errors.New("content file no longer exists")
+)
+
+// ContentService handles editing content items after import
+type ContentService struct {
+	DB       *database.Queries // database access
+	Conn     *sql.DB           // raw connection for transactions
+	BasePath string            // courses directory, used to check files still exist
+}
+
+// NewContentService creates service with database dependencies and the courses directory
+func NewContentService(db *database.Queries, conn *sql.DB, basePath string) *ContentService {
+	return &ContentService{
+		DB:       db,
+		Conn:     conn,
+		BasePath: basePath,
+	}
+}
+
+// UpdateContentItem renames, re-describes and/or moves a content item
+// Moves are only allowed between modules of the same course, and the item goes to the end.
+func (s *ContentService) UpdateContentItem(ctx context.Context, itemID uuid.UUID, input models.UpdateContentItemInput) (*models.ContentItem, error) {
+	existing, err := s.DB.GetContentItem(ctx, itemID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("content item not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving content item: %w", err)
+	}
+
+	if err := s.checkFileResolves(existing.RelativePath); err != nil {
+		return nil, err
+	}
+
+	title := existing.Title
+	if input.Title != nil {
+		title = strings.TrimSpace(*input.Title)
+		if title == "" {
+			return nil, errors.New("content item title cannot be empty")
+		}
+	}
+
+	description := existing.Description
+	if input.Description != nil {
+		trimmed := strings.TrimSpace(*input.Description)
+		description = sql.NullString{String: trimmed, Valid: trimmed != ""}
+	}
+
+	err = runInTx(ctx, s.Conn, s.DB, func(q *database.Queries) error {
+		_, err := q.UpdateContentItem(ctx, database.UpdateContentItemParams{
+			ID:          itemID,
+			Title:       title,
+			Description: description,
+			ContentType: existing.ContentType,
+			Duration:    existing.Duration,
+			Order:       existing.Order,
+		})
+		if err != nil {
+			return fmt.Errorf("error updating content item: %w", err)
+		}
+
+		if input.ModuleID != nil && *input.ModuleID != existing.ModuleID {
+			return s.moveContentItem(ctx, q, existing, *input.ModuleID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.DB.GetContentItem(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving content item: %w", err)
+	}
+	return toContentItemModel(updated), nil
+}
+
+// SetContentItemHidden hides or shows an item; a nil hidden flips the current flag
+func (s *ContentService) SetContentItemHidden(ctx context.Context, itemID uuid.UUID, hidden *bool) (*models.ContentItem, error) {
+	existing, err := s.DB.GetContentItem(ctx, itemID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("content item not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving content item: %w", err)
+	}
+
+	value := !existing.Hidden
+	if hidden != nil {
+		value = *hidden
+	}
+
+	updated, err := s.DB.SetContentItemHidden(ctx, database.SetContentItemHiddenParams{
+		ID:     itemID,
+		Hidden: value,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error updating hidden flag: %w", err)
+	}
+	return toContentItemModel(updated), nil
+}
+
+// moveContentItem puts an item at the end of another module and closes the gap it left
+func (s *ContentService) moveContentItem(ctx context.Context, q *database.Queries, item database.ContentItem, targetModuleID uuid.UUID) error {
+	source, err := q.GetModule(ctx, item.ModuleID)
+	if err != nil {
+		return fmt.Errorf("error retrieving current module: %w", err)
+	}
+
+	target, err := q.GetModule(ctx, targetModuleID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: target module %s not found", ErrInvalidContentMove, targetModuleID)
+		}
+		return fmt.Errorf("error retrieving target module: %w", err)
+	}
+
+	if target.CourseID != source.CourseID {
+		return fmt.Errorf("%w: items can only move between modules of the same course", ErrInvalidContentMove)
+	}
+
+	targetItems, err := q.ListContentItemsByModule(ctx, targetModuleID)
+	if err != nil {
+		return fmt.Errorf("error retrieving target module items: %w", err)
+	}
+
+	if err := q.MoveContentItem(ctx, database.MoveContentItemParams{
+		ID:       item.ID,
+		ModuleID: targetModuleID,
+		Order:    int32(len(targetItems)),
+	}); err != nil {
+		return fmt.Errorf("error moving content item: %w", err)
+	}
+
+	// renumber what's left in the old module so orders stay contiguous
+	remaining, err := q.ListContentItemsByModule(ctx, source.ID)
+	if err != nil {
+		return fmt.Errorf("error retrieving module items: %w", err)
+	}
+	for i, remainingItem := range remaining {
+		if remainingItem.Order == int32(i) {
+			continue
+		}
+		if err := q.UpdateContentItemOrder(ctx, database.UpdateContentItemOrderParams{
+			ID:    remainingItem.ID,
+			Order: int32(i),
+		}); err != nil {
+			return fmt.Errorf("error updating content order: %w", err)
+		}
+	}
+	return nil
+}
+
+// ReorderContentItems sets the order of a module's items in one transaction
+// itemIDs must contain every item of the module exactly once, and their files must still exist.
+func (s *ContentService) ReorderContentItems(ctx context.Context, moduleID uuid.UUID, itemIDs []uuid.UUID) ([]*models.ContentItem, error) {
+	if _, err := s.DB.GetModule(ctx, moduleID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("module not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving module: %w", err)
+	}
+
+	err := runInTx(ctx, s.Conn, s.DB, func(q *database.Queries) error {
+		current, err := q.ListContentItemsByModule(ctx, moduleID)
+		if err != nil {
+			return fmt.Errorf("error retrieving content items: %w", err)
+		}
+
+		if len(itemIDs) != len(current) {
+			return fmt.Errorf("%w: expected %d content item IDs, got %d", ErrInvalidContentOrder, len(current), len(itemIDs))
+		}
+
+		paths := make(map[uuid.UUID]string, len(current))
+		for _, item := range current {
+			paths[item.ID] = item.RelativePath
+		}
+
+		seen := make(map[uuid.UUID]bool, len(itemIDs))
+		for _, id := range itemIDs {
+			path, ok := paths[id]
+			if !ok {
+				return fmt.Errorf("%w: content item %s is not part of this module", ErrInvalidContentOrder, id)
+			}
+			if seen[id] {
+				return fmt.Errorf("%w: content item %s listed twice", ErrInvalidContentOrder, id)
+			}
+			if err := s.checkFileResolves(path); err != nil {
+				return err
+			}
+			seen[id] = true
+		}
+
+		for i, id := range itemIDs {
+			if err := q.UpdateContentItemOrder(ctx, database.UpdateContentItemOrderParams{
+				ID:    id,
+				Order: int32(i),
+			}); err != nil {
+				return fmt.Errorf("error updating content order: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reordered, err := s.DB.ListContentItemsByModule(ctx, moduleID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving content items: %w", err)
+	}
+
+	items := make([]*models.ContentItem, 0, len(reordered))
+	for _, item := range reordered {
+		items = append(items, toContentItemModel(item))
+	}
+	return items, nil
+}
+
+// checkFileResolves makes sure an item's file is still on disk before we edit it
+func (s *ContentService) checkFileResolves(relativePath string) error {
+	fullPath := filepath.Join(s.BasePath, relativePath)
+	if _, err := os.Stat(fullPath); err != nil {
+		return fmt.Errorf("%w: %s", ErrContentFileMissing, relativePath)
+	}
+	return nil
+}
+
+// toContentItemModel converts a database content item to the API model
+func toContentItemModel(dbItem database.ContentItem) *models.ContentItem {
+	return &models.ContentItem{
+		ID:           dbItem.ID,
+		ModuleID:     dbItem.ModuleID,
+		Title:        dbItem.Title,
+		Description:  dbItem.Description.String,
+		RelativePath: dbItem.RelativePath,
+		ContentType:  dbItem.ContentType,
+		Duration:     int(dbItem.Duration.Int32),
+		PageCount:    int(dbItem.PageCount.Int32),
+		Size:         dbItem.Size.Int64,
+		Order:        int(dbItem.Order),
+		Hidden:       dbItem.Hidden,
+		CreatedAt:    dbItem.CreatedAt,
+		UpdatedAt:    dbItem.UpdatedAt,
+	}
+}
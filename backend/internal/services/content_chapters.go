@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// limits on hand-edited chapters
+const (
+	MaxChapters           = 500
+	MaxChapterTitleLength = 200
+)
+
+// ErrInvalidChapters is returned for a chapter list that can't be saved as it is
+var ErrInvalidChapters = errors.New("invalid chapters")
+
+// createContentChapters stores an item's chapter markers in order
+func createContentChapters(ctx context.Context, q *database.Queries, itemID uuid.UUID, markers []models.ChapterMarker) error {
+	for i, marker := range markers {
+		err := q.CreateContentChapter(ctx, database.CreateContentChapterParams{
+			ContentItemID: itemID,
+			Position:      int32(i),
+			Title:         marker.Title,
+			StartTime:     marker.Start,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create content chapter: %w", err)
+		}
+	}
+	return nil
+}
+
+// copyContentChapters gives a cloned item the same chapters as the original
+func copyContentChapters(ctx context.Context, q *database.Queries, fromItemID, toItemID uuid.UUID) error {
+	dbChapters, err := q.ListContentChapters(ctx, fromItemID)
+	if err != nil {
+		return fmt.Errorf("error retrieving content chapters: %w", err)
+	}
+	return createContentChapters(ctx, q, toItemID, toChapterMarkers(dbChapters))
+}
+
+// attachContentChapters fills in Chapters for the items of one module
+func attachContentChapters(ctx context.Context, q *database.Queries, moduleID uuid.UUID, items []*models.ContentItem) error {
+	dbChapters, err := q.ListContentChaptersByModule(ctx, moduleID)
+	if err != nil {
+		return fmt.Errorf("error retrieving content chapters: %w", err)
+	}
+	if len(dbChapters) == 0 {
+		return nil
+	}
+
+	byItem := make(map[uuid.UUID][]database.ContentChapter)
+	for _, dbChapter := range dbChapters {
+		byItem[dbChapter.ContentItemID] = append(byItem[dbChapter.ContentItemID], dbChapter)
+	}
+	for _, item := range items {
+		item.Chapters = toChapterMarkers(byItem[item.ID])
+	}
+	return nil
+}
+
+// toChapterMarkers converts database chapter rows to the API model
+func toChapterMarkers(dbChapters []database.ContentChapter) []models.ChapterMarker {
+	if len(dbChapters) == 0 {
+		return nil
+	}
+	markers := make([]models.ChapterMarker, 0, len(dbChapters))
+	for _, dbChapter := range dbChapters {
+		markers = append(markers, models.ChapterMarker{Title: dbChapter.Title, Start: dbChapter.StartTime})
+	}
+	return markers
+}
+
+// SetChapters replaces the chapters of an audio or video item. Chapters are sorted by start
+// time; two can't start at the same moment. An empty list removes the edited chapters so the
+// ones embedded in the file are used again.
+func (s *ContentService) SetChapters(ctx context.Context, itemID uuid.UUID, markers []models.ChapterMarker) ([]models.ChapterMarker, error) {
+	item, err := s.DB.GetContentItem(ctx, itemID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("content item not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving content item: %w", err)
+	}
+	if item.ContentType != "audio" && item.ContentType != "video" {
+		return nil, fmt.Errorf("%w: %s is %s", ErrNotMediaContent, item.Title, item.ContentType)
+	}
+
+	markers, err = normalizeChapters(markers, float64(item.Duration.Int32))
+	if err != nil {
+		return nil, err
+	}
+
+	err = runInTx(ctx, s.Conn, s.DB, func(q *database.Queries) error {
+		if err := q.DeleteContentChapters(ctx, itemID); err != nil {
+			return fmt.Errorf("error removing content chapters: %w", err)
+		}
+		return createContentChapters(ctx, q, itemID, markers)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return markers, nil
+}
+
+// normalizeChapters trims titles, sorts by start and checks the list makes sense.
+// duration is 0 when the item's length isn't known.
+func normalizeChapters(markers []models.ChapterMarker, duration float64) ([]models.ChapterMarker, error) {
+	if len(markers) > MaxChapters {
+		return nil, fmt.Errorf("%w: at most %d chapters", ErrInvalidChapters, MaxChapters)
+	}
+
+	normalized := make([]models.ChapterMarker, 0, len(markers))
+	for i, marker := range markers {
+		marker.Title = strings.TrimSpace(marker.Title)
+		switch {
+		case marker.Title == "":
+			return nil, fmt.Errorf("%w: chapter %d has no title", ErrInvalidChapters, i+1)
+		case len(marker.Title) > MaxChapterTitleLength:
+			return nil, fmt.Errorf("%w: chapter %d title is longer than %d characters", ErrInvalidChapters, i+1, MaxChapterTitleLength)
+		case marker.Start < 0:
+			return nil, fmt.Errorf("%w: chapter %d has an invalid start", ErrInvalidChapters, i+1)
+		case duration > 0 && marker.Start >= duration:
+			return nil, fmt.Errorf("%w: chapter %d starts after the end", ErrInvalidChapters, i+1)
+		}
+		normalized = append(normalized, marker)
+	}
+
+	sort.SliceStable(normalized, func(i, j int) bool { return normalized[i].Start < normalized[j].Start })
+	for i := 1; i < len(normalized); i++ {
+		if normalized[i].Start == normalized[i-1].Start {
+			return nil, fmt.Errorf("%w: %q and %q start at the same time", ErrInvalidChapters, normalized[i-1].Title, normalized[i].Title)
+		}
+	}
+	return normalized, nil
+}
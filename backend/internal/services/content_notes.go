@@ -0,0 +1,227 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// content note errors, the handler maps each to its own status
+var (
+	ErrInvalidNote  = errors.New("invalid note")
+	ErrNoteNotFound = errors.New("note not found")
+)
+
+// CreateContentNote adds a note to a content item. A timestamp pins the note to a moment
+// of an audio or video item.
+func (s *NoteService) CreateContentNote(ctx context.Context, userID, itemID uuid.UUID, input models.SaveContentNoteInput) (*models.ContentNote, error) {
+	location, err := s.locate(ctx, itemID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	position, err := validateContentNote(input, location.ContentType)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := s.DB.CreateContentNote(ctx, database.CreateContentNoteParams{
+		ContentItemID: itemID,
+		UserID:        userID,
+		Content:       input.Content,
+		Position:      position,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error saving note: %w", err)
+	}
+	return toContentNoteModel(row), nil
+}
+
+// ListContentNotes returns the user's notes on a content item, notes on the whole item
+// first and the rest in playback order
+func (s *NoteService) ListContentNotes(ctx context.Context, userID, itemID uuid.UUID) ([]*models.ContentNote, error) {
+	if _, err := s.locate(ctx, itemID, userID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.DB.ListContentNotes(ctx, database.ListContentNotesParams{
+		ContentItemID: itemID,
+		UserID:        userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving notes: %w", err)
+	}
+
+	notes := make([]*models.ContentNote, 0, len(rows))
+	for _, row := range rows {
+		notes = append(notes, toContentNoteModel(row))
+	}
+	return notes, nil
+}
+
+// ListCourseContentNotes returns all of the user's content notes in a course in course
+// order, with the item and module each belongs to, for going over a course afterwards
+func (s *NoteService) ListCourseContentNotes(ctx context.Context, userID, courseID uuid.UUID) ([]*models.ContentNote, error) {
+	if _, err := s.DB.GetCourse(ctx, courseID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("course not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving course: %w", err)
+	}
+
+	visible, err := s.Visibility.CanSeeCourse(ctx, userID, courseID)
+	if err != nil {
+		return nil, fmt.Errorf("error checking course visibility: %w", err)
+	}
+	if !visible {
+		return nil, ErrContentNotVisible
+	}
+
+	rows, err := s.DB.ListCourseContentNotes(ctx, database.ListCourseContentNotesParams{
+		CourseID: courseID,
+		UserID:   userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving notes: %w", err)
+	}
+
+	notes := make([]*models.ContentNote, 0, len(rows))
+	for _, row := range rows {
+		note := toContentNoteModel(database.ContentNote{
+			ID:            row.ID,
+			ContentItemID: row.ContentItemID,
+			UserID:        row.UserID,
+			Content:       row.Content,
+			Position:      row.Position,
+			CreatedAt:     row.CreatedAt,
+			UpdatedAt:     row.UpdatedAt,
+		})
+		note.ContentTitle = row.ContentTitle
+		note.ContentType = row.ContentType
+		note.ModuleID = row.ModuleID
+		note.ModuleTitle = row.ModuleTitle
+		notes = append(notes, note)
+	}
+	return notes, nil
+}
+
+// UpdateContentNote replaces the text and timestamp of one of the user's notes
+func (s *NoteService) UpdateContentNote(ctx context.Context, userID, noteID uuid.UUID, input models.SaveContentNoteInput) (*models.ContentNote, error) {
+	note, err := s.ownNote(ctx, userID, noteID)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := s.locate(ctx, note.ContentItemID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	position, err := validateContentNote(input, location.ContentType)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := s.DB.UpdateContentNote(ctx, database.UpdateContentNoteParams{
+		ID:       noteID,
+		Content:  input.Content,
+		Position: position,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error saving note: %w", err)
+	}
+	return toContentNoteModel(row), nil
+}
+
+// DeleteContentNote removes one of the user's notes
+func (s *NoteService) DeleteContentNote(ctx context.Context, userID, noteID uuid.UUID) error {
+	if _, err := s.ownNote(ctx, userID, noteID); err != nil {
+		return err
+	}
+
+	if err := s.DB.DeleteContentNote(ctx, noteID); err != nil {
+		return fmt.Errorf("error deleting note: %w", err)
+	}
+	return nil
+}
+
+// ownNote loads a note, treating other profiles' notes as missing
+func (s *NoteService) ownNote(ctx context.Context, userID, noteID uuid.UUID) (database.ContentNote, error) {
+	note, err := s.DB.GetContentNote(ctx, noteID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return note, ErrNoteNotFound
+		}
+		return note, fmt.Errorf("error retrieving note: %w", err)
+	}
+	if note.UserID != userID {
+		return note, ErrNoteNotFound
+	}
+	return note, nil
+}
+
+// locate looks up a content item, refusing items in courses the profile can't see
+func (s *NoteService) locate(ctx context.Context, itemID, profileID uuid.UUID) (database.GetContentItemLocationRow, error) {
+	location, err := s.DB.GetContentItemLocation(ctx, itemID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return location, fmt.Errorf("content item not found: %w", err)
+		}
+		return location, fmt.Errorf("error retrieving content item: %w", err)
+	}
+
+	visible, err := s.Visibility.CanSeeCourse(ctx, profileID, location.CourseID)
+	if err != nil {
+		return location, fmt.Errorf("error checking course visibility: %w", err)
+	}
+	if !visible {
+		return location, ErrContentNotVisible
+	}
+	return location, nil
+}
+
+// validateContentNote checks a note's text and timestamp, returning the timestamp as
+// stored. Only audio and video have a timeline to pin notes to.
+func validateContentNote(input models.SaveContentNoteInput, contentType string) (sql.NullFloat64, error) {
+	if strings.TrimSpace(input.Content) == "" {
+		return sql.NullFloat64{}, fmt.Errorf("%w: note content cannot be empty", ErrInvalidNote)
+	}
+	if len(input.Content) > maxNoteLength {
+		return sql.NullFloat64{}, fmt.Errorf("%w: note content cannot be longer than %d characters", ErrInvalidNote, maxNoteLength)
+	}
+
+	if input.Timestamp == nil {
+		return sql.NullFloat64{}, nil
+	}
+	if contentType != "audio" && contentType != "video" {
+		return sql.NullFloat64{}, fmt.Errorf("%w: only audio and video notes can have a timestamp", ErrInvalidNote)
+	}
+	if *input.Timestamp < 0 || math.IsNaN(*input.Timestamp) || math.IsInf(*input.Timestamp, 0) {
+		return sql.NullFloat64{}, fmt.Errorf("%w: timestamp must be a number of seconds, 0 or more", ErrInvalidNote)
+	}
+	return sql.NullFloat64{Float64: *input.Timestamp, Valid: true}, nil
+}
+
+// toContentNoteModel converts the db row to the app model
+func toContentNoteModel(row database.ContentNote) *models.ContentNote {
+	note := &models.ContentNote{
+		ID:            row.ID,
+		ContentItemID: row.ContentItemID,
+		UserID:        row.UserID,
+		Content:       row.Content,
+		CreatedAt:     row.CreatedAt.Time,
+		UpdatedAt:     row.UpdatedAt.Time,
+	}
+	if row.Position.Valid {
+		timestamp := row.Position.Float64
+		note.Timestamp = &timestamp
+	}
+	return note
+}
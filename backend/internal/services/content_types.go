@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/parser"
+	"github.com/google/uuid"
+)
+
+// maxUnknownExamples keeps the triage list readable for extensions with thousands of files
+const maxUnknownExamples = 5
+
+// ErrInvalidContentTypeMapping is returned for empty extensions or types the app doesn't know
+var ErrInvalidContentTypeMapping = errors.New("invalid content type mapping")
+
+// ContentTypeService handles the library-wide cleanup of unclassified content
+type ContentTypeService struct {
+	DB *database.Queries // database access
+}
+
+// NewContentTypeService creates service with db dependency
+func NewContentTypeService(db *database.Queries) *ContentTypeService {
+	return &ContentTypeService{
+		DB: db,
+	}
+}
+
+// LoadMappings registers every saved mapping with the parser so new imports use them
+func (s *ContentTypeService) LoadMappings(ctx context.Context) error {
+	mappings, err := s.DB.ListContentTypeMappings(ctx)
+	if err != nil {
+		return fmt.Errorf("error retrieving content type mappings: %w", err)
+	}
+
+	for _, mapping := range mappings {
+		parser.RegisterExtension(mapping.Extension, mapping.ContentType)
+	}
+	log.Printf("Loaded %d content type mappings", len(mappings))
+	return nil
+}
+
+// ListUnknownContent groups unclassified items by extension, most common first
+func (s *ContentTypeService) ListUnknownContent(ctx context.Context) ([]models.UnknownContentGroup, error) {
+	items, err := s.DB.ListUnknownContentItems(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving unknown content: %w", err)
+	}
+
+	groups := make(map[string]*models.UnknownContentGroup)
+	for _, item := range items {
+		ext := parser.NormalizeExtension(filepath.Ext(item.RelativePath))
+		group, ok := groups[ext]
+		if !ok {
+			group = &models.UnknownContentGroup{Extension: ext}
+			groups[ext] = group
+		}
+		group.Count++
+		if len(group.Examples) < maxUnknownExamples {
+			group.Examples = append(group.Examples, item.RelativePath)
+		}
+	}
+
+	result := make([]models.UnknownContentGroup, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, *group)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Extension < result[j].Extension
+	})
+
+	return result, nil
+}
+
+// MapExtension saves an extension -> type rule, registers it with the parser
+// and reclassifies every unknown item that has the extension
+func (s *ContentTypeService) MapExtension(ctx context.Context, actorID uuid.UUID, input models.MapContentTypeInput) (*models.MapContentTypeResult, error) {
+	ext := parser.NormalizeExtension(input.Extension)
+	if ext == "" || ext == "." {
+		return nil, fmt.Errorf("%w: extension is required", ErrInvalidContentTypeMapping)
+	}
+
+	contentType := strings.ToLower(strings.TrimSpace(input.ContentType))
+	if !parser.IsContentType(contentType) {
+		return nil, fmt.Errorf("%w: content type must be one of %s", ErrInvalidContentTypeMapping,
+			strings.Join(parser.ContentTypes, ", "))
+	}
+
+	saved, err := s.DB.UpsertContentTypeMapping(ctx, database.UpsertContentTypeMappingParams{
+		Extension:   ext,
+		ContentType: contentType,
+		CreatedBy:   toNullUUID(actorID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error saving content type mapping: %w", err)
+	}
+	parser.RegisterExtension(ext, contentType)
+
+	// match on the same normalised extension the triage list groups by
+	items, err := s.DB.ListUnknownContentItems(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving unknown content: %w", err)
+	}
+
+	var ids []uuid.UUID
+	for _, item := range items {
+		if parser.NormalizeExtension(filepath.Ext(item.RelativePath)) == ext {
+			ids = append(ids, item.ID)
+		}
+	}
+
+	var reclassified int64
+	if len(ids) > 0 {
+		reclassified, err = s.DB.SetContentItemsType(ctx, database.SetContentItemsTypeParams{
+			ContentType: contentType,
+			Ids:         ids,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error reclassifying content: %w", err)
+		}
+	}
+
+	return &models.MapContentTypeResult{
+		Mapping: models.ContentTypeMapping{
+			Extension:   saved.Extension,
+			ContentType: saved.ContentType,
+			CreatedBy:   saved.CreatedBy.UUID,
+			CreatedAt:   saved.CreatedAt,
+			UpdatedAt:   saved.UpdatedAt,
+		},
+		Reclassified: reclassified,
+	}, nil
+}
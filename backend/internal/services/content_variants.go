@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// createContentVariants stores the variant files the parser grouped under an item
+func createContentVariants(ctx context.Context, q *database.Queries, itemID uuid.UUID, variants []models.ContentVariant) error {
+	for i, variant := range variants {
+		if variant.ID == uuid.Nil {
+			variant.ID = uuid.New()
+		}
+		err := q.CreateContentItemVariant(ctx, database.CreateContentItemVariantParams{
+			ID:            variant.ID,
+			ContentItemID: itemID,
+			Label:         variant.Label,
+			Resolution:    sql.NullString{String: variant.Resolution, Valid: variant.Resolution != ""},
+			Language:      sql.NullString{String: variant.Language, Valid: variant.Language != ""},
+			RelativePath:  variant.RelativePath,
+			Size:          sql.NullInt64{Int64: variant.Size, Valid: variant.Size > 0},
+			IsPrimary:     variant.Primary,
+			Position:      int32(i),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create content variant: %w", err)
+		}
+	}
+	return nil
+}
+
+// copyContentVariants gives a cloned item the same variant files as the original
+func copyContentVariants(ctx context.Context, q *database.Queries, fromItemID, toItemID uuid.UUID) error {
+	dbVariants, err := q.ListContentItemVariants(ctx, fromItemID)
+	if err != nil {
+		return fmt.Errorf("error retrieving content variants: %w", err)
+	}
+
+	variants := make([]models.ContentVariant, 0, len(dbVariants))
+	for _, dbVariant := range dbVariants {
+		variant := toContentVariantModel(dbVariant)
+		variant.ID = uuid.Nil
+		variants = append(variants, variant)
+	}
+	return createContentVariants(ctx, q, toItemID, variants)
+}
+
+// attachContentVariants fills in Variants for the items of one module
+func attachContentVariants(ctx context.Context, q *database.Queries, moduleID uuid.UUID, items []*models.ContentItem) error {
+	dbVariants, err := q.ListContentItemVariantsByModule(ctx, moduleID)
+	if err != nil {
+		return fmt.Errorf("error retrieving content variants: %w", err)
+	}
+	if len(dbVariants) == 0 {
+		return nil
+	}
+
+	byItem := make(map[uuid.UUID][]models.ContentVariant)
+	for _, dbVariant := range dbVariants {
+		byItem[dbVariant.ContentItemID] = append(byItem[dbVariant.ContentItemID], toContentVariantModel(dbVariant))
+	}
+	for _, item := range items {
+		item.Variants = byItem[item.ID]
+	}
+	return nil
+}
+
+// toContentVariantModel converts a database variant row to the API model
+func toContentVariantModel(dbVariant database.ContentItemVariant) models.ContentVariant {
+	return models.ContentVariant{
+		ID:           dbVariant.ID,
+		Label:        dbVariant.Label,
+		Resolution:   dbVariant.Resolution.String,
+		Language:     dbVariant.Language.String,
+		RelativePath: dbVariant.RelativePath,
+		Size:         dbVariant.Size.Int64,
+		Primary:      dbVariant.IsPrimary,
+	}
+}
@@ -0,0 +1,226 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/tiering"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidClone is returned when clone options name modules the course doesn't have
+var ErrInvalidClone = errors.New("invalid course clone")
+
+// CloneCourse copies a course's modules and content items into a new course owned by actorID.
+// Files aren't copied - the clone points at the same folder as the original.
+func (s *CourseService) CloneCourse(ctx context.Context, courseID, actorID uuid.UUID, input models.CloneCourseInput) (*models.Course, error) {
+	source, err := s.DB.GetCourse(ctx, courseID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("course not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving course: %w", err)
+	}
+
+	modules, err := s.cloneModuleSelection(ctx, courseID, input.ModuleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	title := strings.TrimSpace(input.Title)
+	if title == "" {
+		title = source.Title + " (copy)"
+	}
+
+	clone, err := s.DB.CreateCourseClone(ctx, database.CreateCourseCloneParams{
+		ID:           uuid.New(),
+		Title:        title,
+		Description:  source.Description,
+		CreatorID:    toNullUUID(actorID),
+		RelativePath: source.RelativePath,
+		Tags:         source.Tags,
+		Difficulty:   source.Difficulty,
+		Language:     source.Language,
+		ClonedFrom:   uuid.NullUUID{UUID: source.ID, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating course clone: %w", err)
+	}
+
+	if err := s.copyCourseStructure(ctx, courseID, clone.ID, modules); err != nil {
+		// don't leave a half-copied course behind
+		if cleanupErr := s.DB.DeleteCourse(ctx, clone.ID); cleanupErr != nil {
+			log.Printf("Error removing partial clone %s: %v", clone.ID, cleanupErr)
+		}
+		return nil, err
+	}
+
+	err = recordAudit(ctx, s.DB, models.AuditEntry{
+		ActorID:    actorID,
+		Action:     models.AuditCourseClone,
+		EntityType: models.EntityCourse,
+		EntityID:   clone.ID,
+		Details:    "cloned from " + courseID.String(),
+	})
+	if err != nil {
+		log.Printf("Warning: could not audit clone of course %s: %v", courseID, err)
+	}
+	if err := recordActivityEvent(ctx, s.DB, actorID, models.ActivityCourseCloned, clone.ID, uuid.Nil, clone.Title); err != nil {
+		log.Printf("Warning: could not record clone of course %s in activity: %v", courseID, err)
+	}
+
+	return s.GetCourse(ctx, clone.ID)
+}
+
+// cloneModuleSelection returns the modules to copy - all of them, or the requested ones in the requested order
+func (s *CourseService) cloneModuleSelection(ctx context.Context, courseID uuid.UUID, moduleIDs []uuid.UUID) ([]database.Module, error) {
+	modules, err := s.DB.ListModulesByCourse(ctx, courseID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving modules: %w", err)
+	}
+	if len(moduleIDs) == 0 {
+		return modules, nil
+	}
+
+	byID := make(map[uuid.UUID]database.Module, len(modules))
+	for _, module := range modules {
+		byID[module.ID] = module
+	}
+
+	selected := make([]database.Module, 0, len(moduleIDs))
+	seen := make(map[uuid.UUID]bool, len(moduleIDs))
+	for _, moduleID := range moduleIDs {
+		module, ok := byID[moduleID]
+		if !ok {
+			return nil, fmt.Errorf("%w: module %s is not part of this course", ErrInvalidClone, moduleID)
+		}
+		if seen[moduleID] {
+			return nil, fmt.Errorf("%w: module %s listed twice", ErrInvalidClone, moduleID)
+		}
+		seen[moduleID] = true
+		selected = append(selected, module)
+	}
+	return selected, nil
+}
+
+// copyCourseStructure creates new modules and content items under cloneID, keeping
+// capability requirements and cold storage state with each item, then links the same instructors
+func (s *CourseService) copyCourseStructure(ctx context.Context, sourceID, cloneID uuid.UUID, modules []database.Module) error {
+	requirements, err := s.DB.ListCourseRequirements(ctx, sourceID)
+	if err != nil {
+		return fmt.Errorf("error retrieving capability requirements: %w", err)
+	}
+	requirementsByItem := make(map[uuid.UUID][]string)
+	for _, requirement := range requirements {
+		requirementsByItem[requirement.ContentItemID] = append(requirementsByItem[requirement.ContentItemID], requirement.Capability)
+	}
+
+	for i, module := range modules {
+		newModule, err := s.DB.CreateModule(ctx, database.CreateModuleParams{
+			ID:           uuid.New(),
+			CourseID:     cloneID,
+			Title:        module.Title,
+			Description:  module.Description,
+			RelativePath: module.RelativePath,
+			Order:        int32(i),
+		})
+		if err != nil {
+			return fmt.Errorf("error creating module: %w", err)
+		}
+
+		items, err := s.DB.ListContentItemsByModule(ctx, module.ID)
+		if err != nil {
+			return fmt.Errorf("error retrieving content items: %w", err)
+		}
+
+		for _, item := range items {
+			newItem, err := s.DB.CreateContentItem(ctx, database.CreateContentItemParams{
+				ID:           uuid.New(),
+				ModuleID:     newModule.ID,
+				Title:        item.Title,
+				Description:  item.Description,
+				RelativePath: item.RelativePath,
+				ContentType:  item.ContentType,
+				Duration:     item.Duration,
+				Size:         item.Size,
+				Order:        item.Order,
+				PageCount:    item.PageCount,
+			})
+			if err != nil {
+				return fmt.Errorf("error creating content item: %w", err)
+			}
+			if item.Hidden {
+				if _, err := s.DB.SetContentItemHidden(ctx, database.SetContentItemHiddenParams{ID: newItem.ID, Hidden: true}); err != nil {
+					return fmt.Errorf("error copying hidden flag: %w", err)
+				}
+			}
+
+			for _, needed := range requirementsByItem[item.ID] {
+				err := s.DB.AddContentItemRequirement(ctx, database.AddContentItemRequirementParams{
+					ContentItemID: newItem.ID,
+					Capability:    needed,
+				})
+				if err != nil {
+					return fmt.Errorf("error copying capability requirement: %w", err)
+				}
+			}
+
+			if err := copyContentVariants(ctx, s.DB, item.ID, newItem.ID); err != nil {
+				return err
+			}
+			if err := copyContentChapters(ctx, s.DB, item.ID, newItem.ID); err != nil {
+				return err
+			}
+
+			if err := s.copyColdState(ctx, item.ID, newItem.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	instructors, err := s.DB.ListCourseInstructors(ctx, sourceID)
+	if err != nil {
+		return fmt.Errorf("error retrieving course instructors: %w", err)
+	}
+	for i, instructor := range instructors {
+		err := s.DB.AddCourseInstructor(ctx, database.AddCourseInstructorParams{
+			CourseID:     cloneID,
+			InstructorID: instructor.ID,
+			Position:     int32(i),
+		})
+		if err != nil {
+			return fmt.Errorf("error copying course instructor: %w", err)
+		}
+	}
+	return nil
+}
+
+// copyColdState marks the clone's item cold too when the original's file is parked in cold storage,
+// so accessing either one brings the shared file back
+func (s *CourseService) copyColdState(ctx context.Context, sourceItemID, cloneItemID uuid.UUID) error {
+	state, err := s.DB.GetContentTiering(ctx, sourceItemID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil // never accessed or tiered
+		}
+		return fmt.Errorf("error retrieving content tier: %w", err)
+	}
+	if state.Tier != tiering.Cold {
+		return nil
+	}
+
+	err = s.DB.MarkContentCold(ctx, database.MarkContentColdParams{
+		ContentItemID: cloneItemID,
+		ColdKey:       state.ColdKey,
+	})
+	if err != nil {
+		return fmt.Errorf("error copying cold storage state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// ErrUnsafeCoursePath is returned when a course folder resolves outside the courses directory
+var ErrUnsafeCoursePath = errors.New("course folder is outside the courses directory")
+
+// ErrSharedCourseFolder is returned when deleting files would pull them out from under a clone
+var ErrSharedCourseFolder = errors.New("course folder is shared with other courses")
+
+// ErrNotCourseOwner is returned when a non-admin tries to delete someone else's course
+var ErrNotCourseOwner = errors.New("only the course creator or an admin may delete this course")
+
+// MaxBulkDeleteCourses caps how many courses one bulk delete request may name
+const MaxBulkDeleteCourses = 500
+
+// cascadedCourseData is everything else the database drops along with a course
+var cascadedCourseData = []string{"course notes", "favorites", "enrollments", "course assignments", "capability requirements"}
+
+// DeleteCourseAs deletes a course on behalf of actorID, optionally removing its folder from disk.
+// The database row goes first - a folder that can't be removed is reported, not treated as a failure.
+func (s *CourseService) DeleteCourseAs(ctx context.Context, courseID, actorID uuid.UUID, deleteFiles bool) (*models.CourseDeletion, error) {
+	course, err := s.DB.GetCourse(ctx, courseID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("course not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving course: %w", err)
+	}
+
+	// resolve the folder before anything is deleted so a bad path can't leave things half done
+	var folder string
+	if deleteFiles {
+		folder, err = s.courseFolder(course.RelativePath)
+		if err != nil {
+			return nil, err
+		}
+
+		sharing, err := s.DB.CountCoursesByRelativePath(ctx, course.RelativePath)
+		if err != nil {
+			return nil, fmt.Errorf("error checking for clones: %w", err)
+		}
+		if sharing > 1 {
+			return nil, fmt.Errorf("%w: %d other courses use %s", ErrSharedCourseFolder, sharing-1, course.RelativePath)
+		}
+	}
+
+	content, err := s.DB.CountCourseContent(ctx, courseID)
+	if err != nil {
+		return nil, fmt.Errorf("error counting course content: %w", err)
+	}
+
+	progress, err := s.DB.CountCourseProgress(ctx, courseID)
+	if err != nil {
+		return nil, fmt.Errorf("error counting course progress: %w", err)
+	}
+
+	if err := s.DeleteCourse(ctx, courseID); err != nil {
+		return nil, err
+	}
+
+	result := &models.CourseDeletion{
+		CourseID:        courseID,
+		Title:           course.Title,
+		Modules:         content.Modules,
+		ContentItems:    content.ContentItems,
+		ProgressRecords: progress,
+		AlsoRemoved:     cascadedCourseData,
+		FilesPath:       folder,
+	}
+
+	if deleteFiles {
+		if err := os.RemoveAll(folder); err != nil {
+			log.Printf("Warning: course %s deleted but its folder could not be removed: %v", courseID, err)
+			result.FilesError = err.Error()
+		} else {
+			result.FilesDeleted = true
+		}
+	}
+
+	details := course.RelativePath
+	if result.FilesDeleted {
+		details += " (files deleted)"
+	}
+	err = recordAudit(ctx, s.DB, models.AuditEntry{
+		ActorID:    actorID,
+		Action:     models.AuditCourseDelete,
+		EntityType: models.EntityCourse,
+		EntityID:   courseID,
+		Details:    details,
+	})
+	if err != nil {
+		log.Printf("Warning: could not audit deletion of course %s: %v", courseID, err)
+	}
+
+	return result, nil
+}
+
+// BulkDeleteCourses deletes each listed course on behalf of actorID and reports on every one.
+// Each course goes in a single cascading delete, so a failure leaves that course untouched
+// and the rest of the list carries on. Non-admins may only delete courses they created.
+func (s *CourseService) BulkDeleteCourses(ctx context.Context, input models.BulkDeleteCoursesInput, actorID uuid.UUID, isAdmin bool) *models.BulkCourseDeletion {
+	report := &models.BulkCourseDeletion{Results: []models.BulkCourseDeletionResult{}}
+	seen := map[uuid.UUID]bool{}
+
+	for _, courseID := range input.CourseIDs {
+		if seen[courseID] {
+			continue
+		}
+		seen[courseID] = true
+
+		result := models.BulkCourseDeletionResult{CourseID: courseID}
+		deletion, err := s.bulkDeleteCourse(ctx, courseID, actorID, isAdmin, input.DeleteFiles)
+		if err != nil {
+			log.Printf("[BulkDeleteCourses] Could not delete course %s: %v", courseID, err)
+			result.Error = err.Error()
+			report.FailureCount++
+		} else {
+			result.Success = true
+			result.Deletion = deletion
+			report.SuccessCount++
+		}
+		report.Results = append(report.Results, result)
+	}
+
+	return report
+}
+
+// bulkDeleteCourse checks the actor may delete one course of a bulk delete, then deletes it
+func (s *CourseService) bulkDeleteCourse(ctx context.Context, courseID, actorID uuid.UUID, isAdmin, deleteFiles bool) (*models.CourseDeletion, error) {
+	if !isAdmin {
+		course, err := s.DB.GetCourse(ctx, courseID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, fmt.Errorf("course not found: %w", err)
+			}
+			return nil, fmt.Errorf("error retrieving course: %w", err)
+		}
+		if course.CreatorID.UUID != actorID {
+			return nil, ErrNotCourseOwner
+		}
+	}
+
+	return s.DeleteCourseAs(ctx, courseID, actorID, deleteFiles)
+}
+
+// courseFolder resolves a course's folder and makes sure it is strictly inside the courses directory
+func (s *CourseService) courseFolder(relativePath string) (string, error) {
+	base, err := filepath.Abs(s.Parser.BasePath)
+	if err != nil {
+		return "", fmt.Errorf("error resolving courses directory: %w", err)
+	}
+
+	folder := filepath.Join(base, relativePath)
+	rel, err := filepath.Rel(base, folder)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: %s", ErrUnsafeCoursePath, relativePath)
+	}
+	return folder, nil
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidCourseOrder is returned for duplicate or unknown course IDs in a custom order
+var ErrInvalidCourseOrder = errors.New("invalid course order")
+
+// SetCourseOrder replaces a profile's arrangement of the course list.
+// Courses left out keep the default order after the arranged ones; an empty list resets it.
+func (s *CourseService) SetCourseOrder(ctx context.Context, userID uuid.UUID, courseIDs []uuid.UUID) ([]uuid.UUID, error) {
+	seen := make(map[uuid.UUID]bool, len(courseIDs))
+	for _, courseID := range courseIDs {
+		if seen[courseID] {
+			return nil, fmt.Errorf("%w: course %s listed twice", ErrInvalidCourseOrder, courseID)
+		}
+		seen[courseID] = true
+	}
+
+	existing, err := s.DB.CountExistingCourses(ctx, courseIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error checking courses: %w", err)
+	}
+	if int(existing) != len(courseIDs) {
+		return nil, fmt.Errorf("%w: %d of the courses do not exist", ErrInvalidCourseOrder, len(courseIDs)-int(existing))
+	}
+
+	if courseIDs == nil {
+		courseIDs = []uuid.UUID{}
+	}
+	err = s.DB.SetCourseSortOrder(ctx, database.SetCourseSortOrderParams{
+		UserID:    userID,
+		CourseIds: courseIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error saving course order: %w", err)
+	}
+	return courseIDs, nil
+}
+
+// ApplyCourseOrder sorts courses into the profile's arrangement and fills in SortOrder.
+// Courses the profile never arranged stay after the others in their current order.
+func (s *CourseService) ApplyCourseOrder(ctx context.Context, userID uuid.UUID, courses []*models.Course) error {
+	courseIDs, err := s.DB.GetCourseSortOrder(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil // never arranged anything
+		}
+		return fmt.Errorf("error retrieving course order: %w", err)
+	}
+
+	positions := make(map[uuid.UUID]int, len(courseIDs))
+	for i, courseID := range courseIDs {
+		positions[courseID] = i + 1
+	}
+
+	for _, course := range courses {
+		if position, ok := positions[course.ID]; ok {
+			course.SortOrder = position
+		}
+	}
+
+	sort.SliceStable(courses, func(i, j int) bool {
+		a, b := courses[i].SortOrder, courses[j].SortOrder
+		if a == 0 || b == 0 {
+			return a != 0 && b == 0 // arranged before unarranged
+		}
+		return a < b
+	})
+	return nil
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// ListCoursesWithDepth lists courses, loading only as much of the module tree as asked for
+func (s *CourseService) ListCoursesWithDepth(ctx context.Context, depth models.CourseDepth) ([]*models.Course, error) {
+	if depth == models.CourseDepthFull {
+		return s.ListCourses(ctx)
+	}
+
+	dbCourses, err := s.DB.ListCourses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving courses: %w", err)
+	}
+
+	courses := make([]*models.Course, 0, len(dbCourses))
+	for _, dbCourse := range dbCourses {
+		course := s.toCourseModel(dbCourse)
+
+		if depth == models.CourseDepthModules {
+			modules, err := s.GetModulesByCourse(ctx, course.ID)
+			if err != nil {
+				log.Printf("Warning: Could not load modules for %s: %v", course.Title, err)
+			}
+			course.Modules = modules
+		}
+
+		courses = append(courses, course)
+	}
+
+	return courses, nil
+}
+
+// NormalizeCourseFilter validates list filters the same way metadata updates are validated
+func NormalizeCourseFilter(filter models.CourseFilter) (models.CourseFilter, error) {
+	var err error
+	if filter.Difficulty, err = normalizeDifficulty(filter.Difficulty); err != nil {
+		return filter, err
+	}
+	if filter.Language, err = normalizeLanguage(filter.Language); err != nil {
+		return filter, err
+	}
+	filter.Tag = strings.ToLower(strings.TrimSpace(filter.Tag))
+	return filter, nil
+}
+
+// FilterCourses keeps courses matching every set field of the filter.
+// A language filter of "pt" also matches regional codes like "pt-br".
+func FilterCourses(courses []*models.Course, filter models.CourseFilter) []*models.Course {
+	if filter == (models.CourseFilter{}) {
+		return courses
+	}
+
+	filtered := []*models.Course{}
+	for _, course := range courses {
+		if filter.Difficulty != "" && course.Difficulty != filter.Difficulty {
+			continue
+		}
+		if filter.Language != "" && course.Language != filter.Language &&
+			!strings.HasPrefix(course.Language, filter.Language+"-") {
+			continue
+		}
+		if filter.Tag != "" && !slices.Contains(course.Tags, filter.Tag) {
+			continue
+		}
+		filtered = append(filtered, course)
+	}
+	return filtered
+}
+
+// GetCourseWithDepth gets a single course, loading only as much of the module tree as asked for
+func (s *CourseService) GetCourseWithDepth(ctx context.Context, id uuid.UUID, depth models.CourseDepth) (*models.Course, error) {
+	if depth == models.CourseDepthFull {
+		return s.GetCourse(ctx, id)
+	}
+
+	dbCourse, err := s.DB.GetCourse(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("course not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving course: %w", err)
+	}
+
+	course := s.toCourseModel(dbCourse)
+	if depth == models.CourseDepthModules {
+		course.Modules, err = s.GetModulesByCourse(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return course, nil
+}
+
+// AttachProgress fills in Progress on each course for the given user, in one query for the whole list
+func (s *CourseService) AttachProgress(ctx context.Context, userID uuid.UUID, courses []*models.Course) error {
+	courseIDs := make([]uuid.UUID, 0, len(courses))
+	for _, course := range courses {
+		courseIDs = append(courseIDs, course.ID)
+	}
+
+	progress, err := s.CalculateCoursesProgress(ctx, userID, courseIDs, s.Weighting)
+	if err != nil {
+		return fmt.Errorf("error calculating course progress: %w", err)
+	}
+	for _, course := range courses {
+		course.Progress = progress[course.ID]
+	}
+	return nil
+}
+
+// toCourseModel converts the db row to the app model, without modules
+func (s *CourseService) toCourseModel(dbCourse database.Course) *models.Course {
+	course := &models.Course{
+		ID:           dbCourse.ID,
+		Title:        dbCourse.Title,
+		Description:  dbCourse.Description.String,
+		CreatorID:    dbCourse.CreatorID.UUID,
+		Tags:         dbCourse.Tags,
+		Difficulty:   dbCourse.Difficulty.String,
+		Language:     dbCourse.Language.String,
+		RelativePath: dbCourse.RelativePath,
+		BasePath:     s.Parser.BasePath,
+		CreatedAt:    dbCourse.CreatedAt,
+		UpdatedAt:    dbCourse.UpdatedAt,
+	}
+	if dbCourse.ClonedFrom.Valid {
+		clonedFrom := dbCourse.ClonedFrom.UUID
+		course.ClonedFrom = &clonedFrom
+	}
+	return course
+}
@@ -8,26 +8,64 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/NeroQue/course-management-backend/internal/database"
 	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/events"
+	"github.com/NeroQue/course-management-backend/pkg/metrics"
 	"github.com/NeroQue/course-management-backend/pkg/parser"
+	"github.com/NeroQue/course-management-backend/pkg/studytime"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 // CourseService handles all course business logic
 type CourseService struct {
-	DB     *database.Queries    // database access
-	Parser *parser.CourseParser // for reading course files
+	DB         *database.Queries    // database access
+	Conn       *sql.DB              // raw connection for transactions
+	Parser     *parser.CourseParser // for reading course files
+	Location   *time.Location       // where a day starts for streaks, STREAK_TIMEZONE
+	Heuristics studytime.Heuristics // minutes per item guesses for time left
+	Weighting  string               // what completion percentages count by default, PROGRESS_WEIGHTING
+	Events     *events.Bus          // progress milestones go out here for whoever subscribed
+}
+
+// tag limits keep tags usable as filters rather than descriptions
+const (
+	maxTags      = 20
+	maxTagLength = 32
+)
+
+// ErrInvalidCourseUpdate is returned when a course update fails validation
+var ErrInvalidCourseUpdate = errors.New("invalid course update")
+
+// languageCodeRegex matches language codes with an optional region, e.g. "en", "pt-br", "zh-hant"
+var languageCodeRegex = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})?$`)
+
+// DuplicateCourseError is returned when a directory has already been imported
+type DuplicateCourseError struct {
+	ExistingID   uuid.UUID // the course that already uses this path
+	RelativePath string
+}
+
+func (e *DuplicateCourseError) Error() string {
+	return fmt.Sprintf("course already imported from %s (existing course ID: %s)", e.RelativePath, e.ExistingID)
 }
 
 // NewCourseService creates service with dependencies
-func NewCourseService(db *database.Queries, parser *parser.CourseParser) *CourseService {
+func NewCourseService(db *database.Queries, conn *sql.DB, parser *parser.CourseParser) *CourseService {
 	return &CourseService{
-		DB:     db,
-		Parser: parser,
+		DB:         db,
+		Conn:       conn,
+		Parser:     parser,
+		Location:   activityLocation(),
+		Heuristics: studytime.LoadHeuristics(),
+		Weighting:  progressWeighting(),
+		Events:     events.NewBus(),
 	}
 }
 
@@ -75,6 +113,7 @@ func (s *CourseService) ImportCourse(ctx context.Context, directoryPath string,
 
 			info, err = os.Stat(adjustedFallback)
 			if err != nil {
+				metrics.IncCounter(metrics.FailedImports, "directory")
 				return nil, fmt.Errorf("course directory not accessible: %s", fullPath)
 			}
 			fullPath = adjustedFallback
@@ -86,6 +125,7 @@ func (s *CourseService) ImportCourse(ctx context.Context, directoryPath string,
 
 	// Ensure it's a directory
 	if !info.IsDir() {
+		metrics.IncCounter(metrics.FailedImports, "directory")
 		return nil, fmt.Errorf("specified path is not a directory: %s", fullPath)
 	}
 
@@ -93,14 +133,106 @@ func (s *CourseService) ImportCourse(ctx context.Context, directoryPath string,
 	// This builds the in-memory representation of the course structure
 	course, err := s.Parser.ParseCourseFolder(fullPath)
 	if err != nil {
+		metrics.IncCounter(metrics.FailedImports, "parse")
 		return nil, fmt.Errorf("error parsing course folder: %w", err)
 	}
 
 	// Set the creator ID
 	course.CreatorID = creatorID
 
+	// Refuse to import the same directory twice
+	course.RelativePath = normalizeRelativePath(course.RelativePath)
+	if err := s.checkDuplicateImport(ctx, course.RelativePath); err != nil {
+		metrics.IncCounter(metrics.FailedImports, importFailureReason(err))
+		return nil, err
+	}
+
+	// collect warnings before saving, the saved course comes back without them
+	warnings := append(course.Warnings, checkImportedMetadata(course)...)
+	warnings = append(warnings, contentWarnings(course)...)
+
 	// Create the course in the database using the CreateCourse method
-	return s.CreateCourse(ctx, course)
+	created, err := s.CreateCourse(ctx, course)
+	if err != nil {
+		metrics.IncCounter(metrics.FailedImports, importFailureReason(err))
+		return nil, err
+	}
+	created.Warnings = warnings
+
+	metrics.SetTimestamp(metrics.LastSuccessfulImport, time.Now())
+	return created, nil
+}
+
+// ImportCourseAs imports a course owned by ownerID on behalf of actorID and records it in the audit log
+// Owner and actor are the same for normal imports; they differ when an admin imports for someone else.
+func (s *CourseService) ImportCourseAs(ctx context.Context, directoryPath string, ownerID, actorID uuid.UUID) (*models.Course, error) {
+	course, err := s.ImportCourse(ctx, directoryPath, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := models.AuditEntry{
+		ActorID:    actorID,
+		Action:     models.AuditCourseImport,
+		EntityType: models.EntityCourse,
+		EntityID:   course.ID,
+		Details:    course.RelativePath,
+	}
+	if ownerID != actorID {
+		entry.SubjectID = ownerID
+	}
+
+	// course is already in, a missing audit entry shouldn't fail the import
+	if err := recordAudit(ctx, s.DB, entry); err != nil {
+		log.Printf("Warning: could not audit import of course %s: %v", course.ID, err)
+	}
+	if err := recordActivityEvent(ctx, s.DB, ownerID, models.ActivityCourseImported, course.ID, uuid.Nil, course.Title); err != nil {
+		log.Printf("Warning: could not record import of course %s in activity: %v", course.ID, err)
+	}
+
+	return course, nil
+}
+
+// checkDuplicateImport returns a DuplicateCourseError if a course with this path already exists
+func (s *CourseService) checkDuplicateImport(ctx context.Context, relativePath string) error {
+	existing, err := s.DB.GetCourseByRelativePath(ctx, relativePath)
+	if err == nil {
+		return &DuplicateCourseError{ExistingID: existing.ID, RelativePath: relativePath}
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("error checking for existing course: %w", err)
+	}
+	return nil
+}
+
+// duplicateImport turns a violation of the one-import-per-folder index into a
+// DuplicateCourseError, for two imports of the same folder that both got past
+// checkDuplicateImport. It returns nil for any other error.
+func (s *CourseService) duplicateImport(ctx context.Context, relativePath string, err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) || pqErr.Code != "23505" || pqErr.Constraint != "idx_courses_imported_path" {
+		return nil
+	}
+	if duplicate := s.checkDuplicateImport(ctx, relativePath); duplicate != nil {
+		return duplicate
+	}
+	return &DuplicateCourseError{RelativePath: relativePath}
+}
+
+// importFailureReason is the failed imports metric label for a duplicate or database error
+func importFailureReason(err error) string {
+	var duplicate *DuplicateCourseError
+	if errors.As(err, &duplicate) {
+		return "duplicate"
+	}
+	return "database"
+}
+
+// normalizeRelativePath cleans up a course path so "foo/", "./foo" and "foo" compare equal
+func normalizeRelativePath(relativePath string) string {
+	cleaned := filepath.ToSlash(filepath.Clean(relativePath))
+	cleaned = strings.TrimPrefix(cleaned, "./")
+	return strings.Trim(cleaned, "/")
 }
 
 // ListCourses retrieves all courses from the database
@@ -119,17 +251,8 @@ func (s *CourseService) ListCourses(ctx context.Context) ([]*models.Course, erro
 		if err != nil {
 			// If we can't get the full course structure, fall back to basic info
 			log.Printf("Warning: Could not load full course structure for %s: %v", dbCourse.Title, err)
-			course = &models.Course{
-				ID:           dbCourse.ID,
-				Title:        dbCourse.Title,
-				Description:  dbCourse.Description.String,
-				CreatorID:    dbCourse.CreatorID.UUID,
-				RelativePath: dbCourse.RelativePath,
-				BasePath:     s.Parser.BasePath,
-				CreatedAt:    dbCourse.CreatedAt,
-				UpdatedAt:    dbCourse.UpdatedAt,
-				Modules:      []*models.Module{}, // Empty modules if we can't load them
-			}
+			course = s.toCourseModel(dbCourse)
+			course.Modules = []*models.Module{} // Empty modules if we can't load them
 		}
 		courses = append(courses, course)
 	}
@@ -149,16 +272,7 @@ func (s *CourseService) GetCourse(ctx context.Context, id uuid.UUID) (*models.Co
 	}
 
 	// Create the course model
-	course := &models.Course{
-		ID:           dbCourse.ID,
-		Title:        dbCourse.Title,
-		Description:  dbCourse.Description.String,
-		CreatorID:    dbCourse.CreatorID.UUID,
-		RelativePath: dbCourse.RelativePath,
-		BasePath:     s.Parser.BasePath,
-		CreatedAt:    dbCourse.CreatedAt,
-		UpdatedAt:    dbCourse.UpdatedAt,
-	}
+	course := s.toCourseModel(dbCourse)
 
 	// Retrieve the modules for this course
 	dbModules, err := s.DB.ListModulesByCourse(ctx, id)
@@ -195,17 +309,31 @@ func (s *CourseService) GetCourse(ctx context.Context, id uuid.UUID) (*models.Co
 				RelativePath: dbItem.RelativePath,
 				ContentType:  dbItem.ContentType,
 				Duration:     int(dbItem.Duration.Int32),
+				PageCount:    int(dbItem.PageCount.Int32),
 				Size:         dbItem.Size.Int64,
 				Order:        int(dbItem.Order),
+				Hidden:       dbItem.Hidden,
 				CreatedAt:    dbItem.CreatedAt,
 				UpdatedAt:    dbItem.UpdatedAt,
 			}
 			module.ContentItems = append(module.ContentItems, item)
 		}
 
+		if err := attachContentVariants(ctx, s.DB, module.ID, module.ContentItems); err != nil {
+			return nil, err
+		}
+		if err := attachContentChapters(ctx, s.DB, module.ID, module.ContentItems); err != nil {
+			return nil, err
+		}
+
 		course.Modules = append(course.Modules, module)
 	}
 
+	course.Instructors, err = listCourseInstructors(ctx, s.DB, id)
+	if err != nil {
+		return nil, err
+	}
+
 	return course, nil
 }
 
@@ -230,26 +358,149 @@ func (s *CourseService) ValidateCourseFile(ctx context.Context, relativePath str
 	return true, nil
 }
 
-// UpdateCourseMetadata updates the metadata for a course
-// This allows users to edit course information without changing the file structure
-func (s *CourseService) UpdateCourseMetadata(ctx context.Context, courseID uuid.UUID, title, description string) (*models.Course, error) {
-	// Validate inputs
-	if strings.TrimSpace(title) == "" {
-		return nil, errors.New("course title cannot be empty")
+// UpdateCourseMetadata applies a partial update to a course's title, description, tags, difficulty, language and creator
+func (s *CourseService) UpdateCourseMetadata(ctx context.Context, courseID uuid.UUID, input models.UpdateCourseInput) (*models.Course, error) {
+	existing, err := s.DB.GetCourse(ctx, courseID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("course not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving course: %w", err)
 	}
 
-	// Update the course in the database
-	_, err := s.DB.UpdateCourse(ctx, database.UpdateCourseParams{
+	params := database.UpdateCourseParams{
 		ID:          courseID,
-		Title:       title,
-		Description: sql.NullString{String: description},
-	})
+		Title:       existing.Title,
+		Description: existing.Description,
+		Tags:        existing.Tags,
+		Difficulty:  existing.Difficulty,
+		CreatorID:   existing.CreatorID,
+		Language:    existing.Language,
+	}
+
+	if input.Title != nil {
+		title := strings.TrimSpace(*input.Title)
+		if title == "" {
+			return nil, fmt.Errorf("%w: course title cannot be empty", ErrInvalidCourseUpdate)
+		}
+		params.Title = title
+	}
+
+	if input.Description != nil {
+		description := strings.TrimSpace(*input.Description)
+		params.Description = sql.NullString{String: description, Valid: description != ""}
+	}
+
+	if input.Tags != nil {
+		tags, err := normalizeTags(*input.Tags)
+		if err != nil {
+			return nil, err
+		}
+		params.Tags = tags
+	}
+
+	if input.Difficulty != nil {
+		difficulty, err := normalizeDifficulty(*input.Difficulty)
+		if err != nil {
+			return nil, err
+		}
+		params.Difficulty = sql.NullString{String: difficulty, Valid: difficulty != ""}
+	}
+
+	if input.Language != nil {
+		language, err := normalizeLanguage(*input.Language)
+		if err != nil {
+			return nil, err
+		}
+		params.Language = sql.NullString{String: language, Valid: language != ""}
+	}
+
+	if input.CreatorID != nil {
+		if _, err := s.DB.GetProfileById(ctx, *input.CreatorID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, fmt.Errorf("%w: creator profile %s does not exist", ErrInvalidCourseUpdate, *input.CreatorID)
+			}
+			return nil, fmt.Errorf("error retrieving creator profile: %w", err)
+		}
+		params.CreatorID = uuid.NullUUID{UUID: *input.CreatorID, Valid: true}
+	}
+
+	updated, err := s.DB.UpdateCourse(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("error updating course: %w", err)
 	}
 
-	// Retrieve the updated course
-	return s.GetCourse(ctx, courseID)
+	return s.toCourseModel(updated), nil
+}
+
+// normalizeTags lower-cases, trims and de-duplicates tags, keeping their order
+func normalizeTags(tags []string) ([]string, error) {
+	normalized := []string{}
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if len(tag) > maxTagLength {
+			return nil, fmt.Errorf("%w: tag %q is longer than %d characters", ErrInvalidCourseUpdate, tag, maxTagLength)
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	if len(normalized) > maxTags {
+		return nil, fmt.Errorf("%w: a course can have at most %d tags", ErrInvalidCourseUpdate, maxTags)
+	}
+	return normalized, nil
+}
+
+// normalizeDifficulty lower-cases a difficulty and checks it's one we know; empty is allowed
+func normalizeDifficulty(difficulty string) (string, error) {
+	difficulty = strings.ToLower(strings.TrimSpace(difficulty))
+	switch difficulty {
+	case "", models.DifficultyBeginner, models.DifficultyIntermediate, models.DifficultyAdvanced:
+		return difficulty, nil
+	}
+	return "", fmt.Errorf("%w: difficulty must be beginner, intermediate or advanced", ErrInvalidCourseUpdate)
+}
+
+// normalizeLanguage lower-cases a language code like "en" or "pt-BR"; empty is allowed
+func normalizeLanguage(language string) (string, error) {
+	language = strings.ToLower(strings.TrimSpace(strings.ReplaceAll(language, "_", "-")))
+	if language != "" && !languageCodeRegex.MatchString(language) {
+		return "", fmt.Errorf("%w: language must be a code like \"en\" or \"pt-br\"", ErrInvalidCourseUpdate)
+	}
+	return language, nil
+}
+
+// checkImportedMetadata validates what the manifest set on a parsed course, dropping
+// values that wouldn't pass a metadata update and warning about them instead
+func checkImportedMetadata(course *models.Course) []models.ImportWarning {
+	var warnings []models.ImportWarning
+	manifestPath := filepath.Join(course.RelativePath, parser.ManifestFileName)
+	drop := func(err error) {
+		warnings = append(warnings, models.ImportWarning{
+			Code:    models.WarnInvalidManifest,
+			Path:    manifestPath,
+			Message: "Ignored manifest value: " + strings.TrimPrefix(err.Error(), ErrInvalidCourseUpdate.Error()+": "),
+		})
+	}
+
+	tags, err := normalizeTags(course.Tags)
+	if err != nil {
+		drop(err)
+		tags = []string{}
+	}
+	course.Tags = tags
+
+	if course.Difficulty, err = normalizeDifficulty(course.Difficulty); err != nil {
+		drop(err)
+	}
+	if course.Language, err = normalizeLanguage(course.Language); err != nil {
+		drop(err)
+	}
+	return warnings
 }
 
 // DeleteCourse removes a course from the database
@@ -266,10 +517,12 @@ func (s *CourseService) DeleteCourse(ctx context.Context, courseID uuid.UUID) er
 }
 
 // TrackUserProgress updates a user's progress for a specific content item
-// This records information like completion status and progress percentage
+// This records information like completion status and progress percentage, which never go down
 func (s *CourseService) TrackUserProgress(ctx context.Context, userID, contentItemID uuid.UUID,
 	completed bool, progressPct float32, lastPosition int) (*models.UserProgress, error) {
 
+	before := s.progressBefore(ctx, userID, contentItemID)
+
 	// Create/update the user progress record using UpsertUserProgress
 	dbProgress, err := s.DB.UpsertUserProgress(ctx, database.UpsertUserProgressParams{
 		UserID:        userID,
@@ -282,21 +535,9 @@ func (s *CourseService) TrackUserProgress(ctx context.Context, userID, contentIt
 	if err != nil {
 		return nil, fmt.Errorf("error tracking user progress: %w", err)
 	}
+	s.recordProgress(ctx, userID, contentItemID, before, dbProgress.Completed)
 
-	// Convert to model
-	progress := &models.UserProgress{
-		ID:            dbProgress.ID,
-		UserID:        dbProgress.UserID,
-		ContentItemID: dbProgress.ContentItemID,
-		Completed:     dbProgress.Completed,
-		ProgressPct:   dbProgress.ProgressPct,
-		LastPosition:  int(dbProgress.LastPosition.Int32),
-		LastAccessed:  dbProgress.LastAccessed,
-		CreatedAt:     dbProgress.CreatedAt,
-		UpdatedAt:     dbProgress.UpdatedAt,
-	}
-
-	return progress, nil
+	return toUserProgressModel(dbProgress), nil
 }
 
 // GetUserCourseProgress retrieves a user's progress for an entire course
@@ -322,6 +563,7 @@ func (s *CourseService) GetUserCourseProgress(ctx context.Context, userID, cours
 			ProgressPct:   dbProgress.ProgressPct,
 			LastPosition:  int(dbProgress.LastPosition.Int32),
 			LastAccessed:  dbProgress.LastAccessed,
+			CompletedAt:   dbProgress.CompletedAt,
 			CreatedAt:     dbProgress.CreatedAt,
 			UpdatedAt:     dbProgress.UpdatedAt,
 		}
@@ -346,6 +588,12 @@ func (s *CourseService) CreateCourse(ctx context.Context, course *models.Course)
 		course.ID = uuid.New()
 	}
 
+	// tags can't be NULL in the database
+	tags := course.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
 	// Create the course record
 	_, err := s.DB.CreateCourse(ctx, database.CreateCourseParams{
 		ID:           course.ID,
@@ -353,8 +601,14 @@ func (s *CourseService) CreateCourse(ctx context.Context, course *models.Course)
 		Description:  sql.NullString{String: course.Description, Valid: course.Description != ""},
 		CreatorID:    uuid.NullUUID{UUID: course.CreatorID, Valid: course.CreatorID != uuid.Nil},
 		RelativePath: course.RelativePath,
+		Tags:         tags,
+		Difficulty:   sql.NullString{String: course.Difficulty, Valid: course.Difficulty != ""},
+		Language:     sql.NullString{String: course.Language, Valid: course.Language != ""},
 	})
 	if err != nil {
+		if duplicate := s.duplicateImport(ctx, course.RelativePath, err); duplicate != nil {
+			return nil, duplicate
+		}
 		return nil, fmt.Errorf("failed to create course: %w", err)
 	}
 
@@ -396,13 +650,29 @@ func (s *CourseService) CreateCourse(ctx context.Context, course *models.Course)
 				Duration:     sql.NullInt32{Int32: int32(item.Duration), Valid: item.Duration > 0},
 				Size:         sql.NullInt64{Int64: item.Size, Valid: item.Size > 0},
 				Order:        int32(item.Order),
+				PageCount:    sql.NullInt32{Int32: int32(item.PageCount), Valid: item.PageCount > 0},
 			})
 			if err != nil {
 				return nil, fmt.Errorf("failed to create content item: %w", err)
 			}
+
+			if err := createContentVariants(ctx, s.DB, item.ID, item.Variants); err != nil {
+				return nil, err
+			}
+			if err := createContentChapters(ctx, s.DB, item.ID, item.Chapters); err != nil {
+				return nil, err
+			}
 		}
 	}
 
+	// instructors named in the course manifest
+	if err := linkImportedInstructors(ctx, s.DB, course.ID, course.Instructors); err != nil {
+		return nil, err
+	}
+
+	// note which items need ffmpeg, OCR etc. so the UI can explain why they won't work
+	s.recordCapabilityRequirements(ctx, course)
+
 	// Return the complete course with database-generated fields
 	return s.GetCourse(ctx, course.ID)
 }
@@ -453,8 +723,10 @@ func (s *CourseService) GetContentItemsByModule(ctx context.Context, moduleID uu
 			RelativePath: dbItem.RelativePath,
 			ContentType:  dbItem.ContentType,
 			Duration:     int(dbItem.Duration.Int32),
+			PageCount:    int(dbItem.PageCount.Int32),
 			Size:         dbItem.Size.Int64,
 			Order:        int(dbItem.Order),
+			Hidden:       dbItem.Hidden,
 			CreatedAt:    dbItem.CreatedAt,
 			UpdatedAt:    dbItem.UpdatedAt,
 		}
@@ -465,8 +737,9 @@ func (s *CourseService) GetContentItemsByModule(ctx context.Context, moduleID uu
 }
 
 // ScanNewCourses returns course directories that haven't been imported to the database yet
-// This compares filesystem directories against database records to find potential new courses
-func (s *CourseService) ScanNewCourses(ctx context.Context) ([]parser.FileInfo, error) {
+// This compares filesystem directories against database records to find potential new courses.
+// Each candidate is scored so the UI can pre-select likely courses, best matches first.
+func (s *CourseService) ScanNewCourses(ctx context.Context) ([]parser.CandidateScore, error) {
 	// Get all available directories from the filesystem
 	allDirectories, err := s.Parser.ListCourseDirectories()
 	if err != nil {
@@ -491,20 +764,26 @@ func (s *CourseService) ScanNewCourses(ctx context.Context) ([]parser.FileInfo,
 	}
 
 	// Filter to only include directories that don't exist in the database
-	var newDirectories []parser.FileInfo
+	var newDirectories []parser.CandidateScore
 	for _, directory := range allDirectories {
 		// Check if this directory is already in the database
 		if !existingCoursePaths[directory.Path] && !existingCoursePaths[directory.RelativePath] {
-			newDirectories = append(newDirectories, directory)
+			newDirectories = append(newDirectories, s.Parser.ScoreCourseDirectory(directory))
 		}
 	}
 
+	sort.SliceStable(newDirectories, func(i, j int) bool {
+		return newDirectories[i].Score > newDirectories[j].Score
+	})
+
+	metrics.SetTimestamp(metrics.LastSuccessfulScan, time.Now())
 	return newDirectories, nil
 }
 
 // BatchImportCourses imports multiple courses from the file system into the database
-// This is useful for bulk importing courses that were found via the scan endpoint
-func (s *CourseService) BatchImportCourses(ctx context.Context, inputs []models.CreateCourseInput, creatorID uuid.UUID) ([]*models.Course, []error) {
+// This is useful for bulk importing courses that were found via the scan endpoint.
+// Each course is owned by its input's CreatorID if set, otherwise by the actor.
+func (s *CourseService) BatchImportCourses(ctx context.Context, inputs []models.CreateCourseInput, actorID uuid.UUID) ([]*models.Course, []error) {
 	var importedCourses []*models.Course
 	var errors []error
 
@@ -516,6 +795,7 @@ func (s *CourseService) BatchImportCourses(ctx context.Context, inputs []models.
 
 		// Skip empty paths
 		if input.RelativePath == "" {
+			metrics.IncCounter(metrics.FailedImports, "invalid_input")
 			err := fmt.Errorf("relative path is required for course '%s'", input.Title)
 			log.Printf("[BatchImportCourses] Error: %v", err)
 			errors = append(errors, err)
@@ -603,6 +883,7 @@ func (s *CourseService) BatchImportCourses(ctx context.Context, inputs []models.
 				input.RelativePath = "test-course"
 				directoryPath = fallbackPath
 			} else {
+				metrics.IncCounter(metrics.FailedImports, "directory")
 				err = fmt.Errorf("directory does not exist or is not accessible: %s (original: %s)", directoryPath, originalPath)
 				log.Printf("[BatchImportCourses] Error: %v", err)
 				errors = append(errors, err)
@@ -610,9 +891,22 @@ func (s *CourseService) BatchImportCourses(ctx context.Context, inputs []models.
 			}
 		}
 
+		// Check enrollments up front so a bad profile ID doesn't leave a half-set-up course
+		if err := s.ValidateEnrollProfiles(ctx, input.EnrollProfiles); err != nil {
+			metrics.IncCounter(metrics.FailedImports, "invalid_input")
+			err = fmt.Errorf("failed to import course '%s': %w", input.Title, err)
+			log.Printf("[BatchImportCourses] Error: %v", err)
+			errors = append(errors, err)
+			continue
+		}
+
 		// Import the course
 		log.Printf("[BatchImportCourses] Importing course from directory: %s", directoryPath)
-		course, err := s.ImportCourse(ctx, directoryPath, creatorID)
+		ownerID := actorID
+		if input.CreatorID != uuid.Nil {
+			ownerID = input.CreatorID
+		}
+		course, err := s.ImportCourseAs(ctx, directoryPath, ownerID, actorID)
 		if err != nil {
 			err = fmt.Errorf("failed to import course '%s': %w", input.Title, err)
 			log.Printf("[BatchImportCourses] Error: %v", err)
@@ -623,6 +917,17 @@ func (s *CourseService) BatchImportCourses(ctx context.Context, inputs []models.
 		// Verify the course was created
 		log.Printf("[BatchImportCourses] Course imported successfully: %s (ID: %s)", course.Title, course.ID)
 
+		if len(input.EnrollProfiles) > 0 {
+			enrolled, err := s.EnrollProfiles(ctx, course.ID, input.EnrollProfiles)
+			if err != nil {
+				err = fmt.Errorf("course '%s' imported but enrollment failed: %w", input.Title, err)
+				log.Printf("[BatchImportCourses] Error: %v", err)
+				errors = append(errors, err)
+			} else {
+				course.EnrolledProfiles = enrolled
+			}
+		}
+
 		// Add the successfully imported course to the result list
 		importedCourses = append(importedCourses, course)
 	}
@@ -633,129 +938,36 @@ func (s *CourseService) BatchImportCourses(ctx context.Context, inputs []models.
 	return importedCourses, errors
 }
 
-// CalculateModuleProgress computes progress for a specific module
+// CalculateModuleProgress computes progress for a specific module, weighted the deployment's way
 func (s *CourseService) CalculateModuleProgress(ctx context.Context, userID, moduleID uuid.UUID) (*models.ModuleProgress, error) {
-	// get all content items in this module
-	contentItems, err := s.GetContentItemsByModule(ctx, moduleID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get content items: %w", err)
-	}
-
-	if len(contentItems) == 0 {
-		return &models.ModuleProgress{
-			ModuleID:       moduleID,
-			UserID:         userID,
-			CompletedItems: 0,
-			TotalItems:     0,
-			CompletionPct:  0,
-			IsCompleted:    true, // empty module is considered complete
-		}, nil
-	}
-
-	// get progress for each content item
-	completedCount := 0
-	var lastAccessed *time.Time
-
-	for _, item := range contentItems {
-		progress, err := s.DB.GetUserProgressByContentItem(ctx, database.GetUserProgressByContentItemParams{
-			UserID:        userID,
-			ContentItemID: item.ID,
-		})
-
-		if err == nil && progress.Completed {
-			completedCount++
-		}
+	return s.CalculateModuleProgressWeighted(ctx, userID, moduleID, s.Weighting)
+}
 
-		// track most recent access time
-		if err == nil && progress.LastAccessed.Valid {
-			accessTime := progress.LastAccessed.Time
-			if lastAccessed == nil || accessTime.After(*lastAccessed) {
-				lastAccessed = &accessTime
-			}
-		}
+// CalculateModuleProgressWeighted computes progress for a specific module, with the
+// completion percentage counting items or study time depending on weighting
+func (s *CourseService) CalculateModuleProgressWeighted(ctx context.Context, userID, moduleID uuid.UUID, weighting string) (*models.ModuleProgress, error) {
+	items, err := s.moduleItems(ctx, userID, moduleID)
+	if err != nil {
+		return nil, err
 	}
 
-	completionPct := float32(completedCount) / float32(len(contentItems)) * 100
-	isCompleted := completedCount == len(contentItems)
-
-	return &models.ModuleProgress{
-		ModuleID:       moduleID,
-		UserID:         userID,
-		CompletedItems: completedCount,
-		TotalItems:     len(contentItems),
-		CompletionPct:  completionPct,
-		LastAccessedAt: lastAccessed,
-		IsCompleted:    isCompleted,
-	}, nil
+	progress, _ := s.summarizeModule(userID, moduleID, items, weighting)
+	return progress, nil
 }
 
-// CalculateCourseProgress computes progress for an entire course
+// CalculateCourseProgress computes progress for an entire course, weighted the deployment's way
 func (s *CourseService) CalculateCourseProgress(ctx context.Context, userID, courseID uuid.UUID) (*models.CourseProgress, error) {
-	// get all modules in this course
-	modules, err := s.GetModulesByCourse(ctx, courseID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get modules: %w", err)
-	}
-
-	if len(modules) == 0 {
-		return &models.CourseProgress{
-			CourseID:         courseID,
-			UserID:           userID,
-			CompletedModules: 0,
-			TotalModules:     0,
-			CompletedItems:   0,
-			TotalItems:       0,
-			CompletionPct:    0,
-			IsCompleted:      true, // empty course is considered complete
-		}, nil
-	}
-
-	// calculate progress for each module
-	completedModules := 0
-	totalCompletedItems := 0
-	totalItems := 0
-	var lastAccessed *time.Time
-
-	for _, module := range modules {
-		moduleProgress, err := s.CalculateModuleProgress(ctx, userID, module.ID)
-		if err != nil {
-			log.Printf("Error calculating module progress for %s: %v", module.ID, err)
-			continue
-		}
-
-		if moduleProgress.IsCompleted {
-			completedModules++
-		}
-
-		totalCompletedItems += moduleProgress.CompletedItems
-		totalItems += moduleProgress.TotalItems
-
-		// track most recent access time
-		if moduleProgress.LastAccessedAt != nil {
-			if lastAccessed == nil || moduleProgress.LastAccessedAt.After(*lastAccessed) {
-				lastAccessed = moduleProgress.LastAccessedAt
-			}
-		}
-	}
+	return s.CalculateCourseProgressWeighted(ctx, userID, courseID, s.Weighting)
+}
 
-	var completionPct float32 = 0
-	if totalItems > 0 {
-		completionPct = float32(totalCompletedItems) / float32(totalItems) * 100
+// CalculateCourseProgressWeighted computes progress for an entire course, with the
+// completion percentage counting items or study time depending on weighting
+func (s *CourseService) CalculateCourseProgressWeighted(ctx context.Context, userID, courseID uuid.UUID, weighting string) (*models.CourseProgress, error) {
+	progress, err := s.CalculateCoursesProgress(ctx, userID, []uuid.UUID{courseID}, weighting)
+	if err != nil {
+		return nil, err
 	}
-
-	isCompleted := completedModules == len(modules)
-
-	return &models.CourseProgress{
-		CourseID:         courseID,
-		UserID:           userID,
-		CompletedModules: completedModules,
-		TotalModules:     len(modules),
-		CompletedItems:   totalCompletedItems,
-		TotalItems:       totalItems,
-		CompletionPct:    completionPct,
-		LastAccessedAt:   lastAccessed,
-		IsCompleted:      isCompleted,
-	}, nil
+	return progress[courseID], nil
 }
 
 // GetUserProgressSummary provides overall progress across all courses
@@ -766,15 +978,19 @@ func (s *CourseService) GetUserProgressSummary(ctx context.Context, userID uuid.
 		return nil, fmt.Errorf("failed to get courses: %w", err)
 	}
 
+	courseIDs := make([]uuid.UUID, 0, len(allCourses))
+	for _, course := range allCourses {
+		courseIDs = append(courseIDs, course.ID)
+	}
+	progress, err := s.CalculateCoursesProgress(ctx, userID, courseIDs, s.Weighting)
+	if err != nil {
+		return nil, err
+	}
+
 	completedCourses := 0
 	inProgressCourses := 0
 
-	for _, course := range allCourses {
-		courseProgress, err := s.CalculateCourseProgress(ctx, userID, course.ID)
-		if err != nil {
-			continue // skip courses we can't calculate progress for
-		}
-
+	for _, courseProgress := range progress {
 		if courseProgress.CompletedItems > 0 { // user has started this course
 			if courseProgress.IsCompleted {
 				completedCourses++
@@ -784,43 +1000,79 @@ func (s *CourseService) GetUserProgressSummary(ctx context.Context, userID uuid.
 		}
 	}
 
-	// TODO: calculate actual time spent and streak from user activity
+	streak, longestStreak, err := s.syncStreak(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	watchSeconds, err := s.DB.GetTotalWatchSeconds(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving watch time: %w", err)
+	}
+
 	return &models.ProgressSummary{
 		UserID:            userID,
 		TotalCourses:      len(allCourses),
 		CompletedCourses:  completedCourses,
 		InProgressCourses: inProgressCourses,
-		TotalTimeSpent:    0, // implement later with activity tracking
-		StreakDays:        0, // implement later with daily activity
+		TotalTimeSpent:    int(watchSeconds / 60),
+		StreakDays:        streak,
+		LongestStreakDays: longestStreak,
 	}, nil
 }
 
-// MarkContentItemCompleted marks a content item as completed for a user
-func (s *CourseService) MarkContentItemCompleted(ctx context.Context, userID, contentItemID uuid.UUID) error {
+// MarkContentItemCompleted marks a content item as completed for a user and returns the record
+func (s *CourseService) MarkContentItemCompleted(ctx context.Context, userID, contentItemID uuid.UUID) (*models.UserProgress, error) {
+	before := s.progressBefore(ctx, userID, contentItemID)
+
 	// create or update progress record
-	_, err := s.DB.UpsertUserProgress(ctx, database.UpsertUserProgressParams{
+	dbProgress, err := s.DB.UpsertUserProgress(ctx, database.UpsertUserProgressParams{
 		UserID:        userID,
 		ContentItemID: contentItemID,
 		Completed:     true,
 		ProgressPct:   100.0,
 		LastAccessed:  sql.NullTime{Time: time.Now(), Valid: true},
 	})
+	if err != nil {
+		return nil, err
+	}
 
-	return err
+	s.recordProgress(ctx, userID, contentItemID, before, true)
+	return toUserProgressModel(dbProgress), nil
 }
 
 // UpdateContentItemProgress updates progress for a content item (for videos, etc.)
-func (s *CourseService) UpdateContentItemProgress(ctx context.Context, userID, contentItemID uuid.UUID, progressPct float32, lastPosition int) error {
+// For PDFs lastPosition is the page, and without a percentage one is worked out from it.
+// Progress never goes down - a device that's behind can't undo another one's progress -
+// unless force is set, for deliberately rewinding or un-completing an item. Returns the
+// merged record.
+func (s *CourseService) UpdateContentItemProgress(ctx context.Context, userID, contentItemID uuid.UUID, progressPct float32, lastPosition int, force bool) (*models.UserProgress, error) {
+	if progressPct == 0 && lastPosition > 0 {
+		item, err := s.DB.GetContentItem(ctx, contentItemID)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving content item: %w", err)
+		}
+		if item.ContentType == "pdf" && item.PageCount.Int32 > 0 {
+			progressPct = min(float32(lastPosition)*100/float32(item.PageCount.Int32), 100)
+		}
+	}
+
 	completed := progressPct >= 100.0
+	before := s.progressBefore(ctx, userID, contentItemID)
 
-	_, err := s.DB.UpsertUserProgress(ctx, database.UpsertUserProgressParams{
+	dbProgress, err := s.DB.UpsertUserProgress(ctx, database.UpsertUserProgressParams{
 		UserID:        userID,
 		ContentItemID: contentItemID,
 		Completed:     completed,
 		ProgressPct:   progressPct,
 		LastPosition:  sql.NullInt32{Int32: int32(lastPosition), Valid: lastPosition > 0},
 		LastAccessed:  sql.NullTime{Time: time.Now(), Valid: true},
+		Force:         force,
 	})
+	if err != nil {
+		return nil, err
+	}
 
-	return err
+	s.recordProgress(ctx, userID, contentItemID, before, dbProgress.Completed)
+	return toUserProgressModel(dbProgress), nil
 }
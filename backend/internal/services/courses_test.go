@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+func TestNormalizeRelativePath(t *testing.T) {
+	tests := []struct {
+		relativePath string
+		want         string
+	}{
+		{"go/basics", "go/basics"},
+		{"go/basics/", "go/basics"},
+		{"./go/basics", "go/basics"},
+		{"/go/basics", "go/basics"},
+		{"go//basics", "go/basics"},
+		{"go/./basics/../basics", "go/basics"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.relativePath, func(t *testing.T) {
+			if got := normalizeRelativePath(tt.relativePath); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuplicateImportIgnoresOtherErrors(t *testing.T) {
+	// none of these may reach the database, there is none behind it
+	s := &CourseService{}
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"not a database error", errors.New("disk full")},
+		{"another unique index", &pq.Error{Code: "23505", Constraint: "courses_pkey"}},
+		{"another error on the index", &pq.Error{Code: "23502", Constraint: "idx_courses_imported_path"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.duplicateImport(context.Background(), "go/basics", tt.err); got != nil {
+				t.Errorf("got %v, want nil", got)
+			}
+		})
+	}
+}
+
+func TestImportFailureReason(t *testing.T) {
+	duplicate := &DuplicateCourseError{ExistingID: uuid.New(), RelativePath: "go/basics"}
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"duplicate", duplicate, "duplicate"},
+		{"wrapped duplicate", fmt.Errorf("error creating course: %w", duplicate), "duplicate"},
+		{"anything else", errors.New("connection refused"), "database"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := importFailureReason(tt.err); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// progressRow is a user_progress row as the progress queries return it
+func progressRow(userID, itemID uuid.UUID, completed bool, pct float64, position int64) []driver.Value {
+	now := time.Now()
+	var completedAt driver.Value
+	if completed {
+		completedAt = now
+	}
+	return []driver.Value{uuid.NewString(), userID.String(), itemID.String(), completed, pct, position, now, now, now, completedAt}
+}
+
+func TestUpdateProgressReturnsTheMergedRecord(t *testing.T) {
+	userID, itemID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name     string
+		before   []driver.Value // stored before the update, nil for none
+		merged   []driver.Value // what the database made of the update
+		force    bool
+		finished bool // whether finishing the item goes on the timeline
+	}{
+		{"device that's behind", progressRow(userID, itemID, false, 80, 40), progressRow(userID, itemID, false, 80, 15), false, false},
+		{"forced rewind", progressRow(userID, itemID, false, 80, 40), progressRow(userID, itemID, false, 30, 15), true, false},
+		{"another device finished it", progressRow(userID, itemID, false, 40, 20), progressRow(userID, itemID, true, 100, 15), false, true},
+		{"finished before", progressRow(userID, itemID, true, 100, 50), progressRow(userID, itemID, true, 100, 15), false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, conn, queries := newFakeDB(t, map[string]fakeAnswer{
+				"GetUserProgressByContentItem": rows(tt.before),
+				"UpsertUserProgress":           rows(tt.merged),
+			})
+			s := &CourseService{DB: queries, Conn: conn, Location: time.UTC}
+
+			progress, err := s.UpdateContentItemProgress(context.Background(), userID, itemID, 30, 15, tt.force)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			upserts := db.called("UpsertUserProgress")
+			if len(upserts) != 1 {
+				t.Fatalf("progress saved %d times", len(upserts))
+			}
+			if force := upserts[0].args[6]; force != tt.force {
+				t.Errorf("saved with force %v, want %v", force, tt.force)
+			}
+			if progress.ProgressPct != float32(tt.merged[4].(float64)) || progress.Completed != tt.merged[3] {
+				t.Errorf("got %v%% completed %v, want the merged record", progress.ProgressPct, progress.Completed)
+			}
+			if progress.LastPosition != 15 {
+				t.Errorf("resume position %d, want the latest one", progress.LastPosition)
+			}
+			// finishing looks the item up for the timeline, nothing else does here
+			if finished := len(db.called("GetContentItemLocation")) > 0; finished != tt.finished {
+				t.Errorf("finished %v, want %v", finished, tt.finished)
+			}
+		})
+	}
+}
+
+func TestProgressOnlyForcedWhenAsked(t *testing.T) {
+	userID, itemID := uuid.New(), uuid.New()
+	db, conn, queries := newFakeDB(t, map[string]fakeAnswer{
+		"GetUserProgressByContentItem": rows(progressRow(userID, itemID, true, 100, 0)),
+		"UpsertUserProgress":           rows(progressRow(userID, itemID, true, 100, 0)),
+	})
+	s := &CourseService{DB: queries, Conn: conn, Location: time.UTC}
+
+	if _, err := s.MarkContentItemCompleted(context.Background(), userID, itemID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.TrackUserProgress(context.Background(), userID, itemID, false, 10, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	upserts := db.called("UpsertUserProgress")
+	if len(upserts) != 2 {
+		t.Fatalf("progress saved %d times, want 2", len(upserts))
+	}
+	for i, call := range upserts {
+		if call.args[6] != false {
+			t.Errorf("save %d was forced", i)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	mailer "github.com/NeroQue/course-management-backend/pkg/mail"
+	"github.com/NeroQue/course-management-backend/pkg/secret"
+	"github.com/NeroQue/course-management-backend/pkg/util"
+	"github.com/google/uuid"
+)
+
+// email verification limits
+const (
+	verificationTokenBytes = 32
+	maxEmailLength         = 254
+	verificationResendWait = time.Minute // between verification emails to one profile
+)
+
+// contact email errors, the handler maps each to its own status
+var (
+	ErrInvalidEmail        = errors.New("invalid email address")
+	ErrNoEmail             = errors.New("profile has no email address")
+	ErrEmailVerified       = errors.New("email address is already verified")
+	ErrVerificationTooSoon = errors.New("a verification email was sent moments ago")
+	ErrInvalidVerification = errors.New("verification link is invalid or has expired")
+	ErrVerificationNotSent = errors.New("verification email could not be sent")
+)
+
+// EmailService keeps profiles' contact addresses. An address only counts once its owner
+// followed the link emailed to it, reminders and summaries go to verified ones only.
+type EmailService struct {
+	DB        *database.Queries // database access
+	Mailer    mailer.Mailer     // sends the verification emails
+	VerifyURL string            // EMAIL_VERIFY_URL, the page that confirms a token, gets ?token=
+	TTL       time.Duration     // EMAIL_VERIFY_TTL, how long a verification link works
+}
+
+// NewEmailService creates service with its dependencies, reading the rest from EMAIL_*
+func NewEmailService(db *database.Queries, mail mailer.Mailer) *EmailService {
+	return &EmailService{
+		DB:        db,
+		Mailer:    mail,
+		VerifyURL: os.Getenv("EMAIL_VERIFY_URL"),
+		TTL:       util.GetEnvDuration("EMAIL_VERIFY_TTL", 24*time.Hour),
+	}
+}
+
+// GetEmail returns a profile's contact address and whether it's verified
+func (s *EmailService) GetEmail(ctx context.Context, profileID uuid.UUID) (*models.ProfileEmail, error) {
+	profile, err := s.DB.GetProfileById(ctx, profileID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("profile not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving profile: %w", err)
+	}
+	return s.toProfileEmail(ctx, profile)
+}
+
+// SetEmail changes a profile's contact address and emails it a verification link. An
+// empty address removes it. Setting the address it already has changes nothing.
+func (s *EmailService) SetEmail(ctx context.Context, profileID uuid.UUID, address string) (*models.ProfileEmail, error) {
+	address = strings.TrimSpace(address)
+	if address != "" {
+		parsed, err := mail.ParseAddress(address)
+		if err != nil || parsed.Address != address || len(address) > maxEmailLength {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidEmail, address)
+		}
+	}
+
+	profile, err := s.DB.GetProfileById(ctx, profileID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("profile not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving profile: %w", err)
+	}
+	if profile.IsGuest && address != "" {
+		return nil, fmt.Errorf("%w: guest profiles can't have one", ErrInvalidEmail)
+	}
+	if profile.Email.String == address {
+		return s.toProfileEmail(ctx, profile)
+	}
+
+	profile, err = s.DB.SetProfileEmail(ctx, database.SetProfileEmailParams{
+		ID:    profileID,
+		Email: sql.NullString{String: address, Valid: address != ""},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error saving email: %w", err)
+	}
+	// a link sent to the old address mustn't verify the new one
+	if err := s.DB.DeleteEmailVerification(ctx, profileID); err != nil {
+		return nil, fmt.Errorf("error removing pending verification: %w", err)
+	}
+
+	if address != "" {
+		if err := s.sendVerification(ctx, profile); err != nil {
+			return nil, err
+		}
+	}
+	return s.toProfileEmail(ctx, profile)
+}
+
+// ResendVerification emails a new verification link for the profile's unverified address,
+// the old one stops working
+func (s *EmailService) ResendVerification(ctx context.Context, profileID uuid.UUID) (*models.ProfileEmail, error) {
+	profile, err := s.DB.GetProfileById(ctx, profileID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("profile not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving profile: %w", err)
+	}
+	if profile.Email.String == "" {
+		return nil, ErrNoEmail
+	}
+	if profile.EmailVerifiedAt.Valid {
+		return nil, ErrEmailVerified
+	}
+
+	// don't let anyone flood an inbox
+	pending, err := s.DB.GetEmailVerification(ctx, profileID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("error retrieving pending verification: %w", err)
+	}
+	if err == nil && time.Since(pending.CreatedAt) < verificationResendWait {
+		return nil, fmt.Errorf("%w, wait a minute before asking for another", ErrVerificationTooSoon)
+	}
+
+	if err := s.sendVerification(ctx, profile); err != nil {
+		return nil, err
+	}
+	return s.toProfileEmail(ctx, profile)
+}
+
+// VerifyEmail confirms the address a token was emailed to and returns the profile's
+// contact details. The token only works once, and only while the profile still has
+// that address.
+func (s *EmailService) VerifyEmail(ctx context.Context, token string) (*models.ProfileEmail, error) {
+	verification, err := s.DB.GetEmailVerificationByHash(ctx, secret.HashToken(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidVerification
+		}
+		return nil, fmt.Errorf("error retrieving verification: %w", err)
+	}
+	if !time.Now().Before(verification.ExpiresAt) {
+		return nil, ErrInvalidVerification
+	}
+
+	profile, err := s.DB.SetProfileEmailVerified(ctx, database.SetProfileEmailVerifiedParams{
+		ID:    verification.ProfileID,
+		Email: sql.NullString{String: verification.Email, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidVerification
+		}
+		return nil, fmt.Errorf("error verifying email: %w", err)
+	}
+	if err := s.DB.DeleteEmailVerification(ctx, verification.ProfileID); err != nil {
+		return nil, fmt.Errorf("error removing used verification: %w", err)
+	}
+	return s.toProfileEmail(ctx, profile)
+}
+
+// sendVerification stores a new token for the profile's address and emails it there
+func (s *EmailService) sendVerification(ctx context.Context, profile database.Profile) error {
+	token, err := secret.NewToken(verificationTokenBytes)
+	if err != nil {
+		return err
+	}
+	_, err = s.DB.UpsertEmailVerification(ctx, database.UpsertEmailVerificationParams{
+		ProfileID: profile.ID,
+		Email:     profile.Email.String,
+		TokenHash: secret.HashToken(token),
+		ExpiresAt: time.Now().Add(s.TTL),
+	})
+	if err != nil {
+		return fmt.Errorf("error saving verification: %w", err)
+	}
+
+	var body strings.Builder
+	body.WriteString("Hi " + profile.Name + ",\n\n")
+	body.WriteString("Please confirm that this is your email address")
+	if s.VerifyURL != "" {
+		body.WriteString(" by opening\n\n" + s.VerifyURL + "?token=" + url.QueryEscape(token) + "\n\nor entering this code:")
+	} else {
+		body.WriteString(" by entering this code:")
+	}
+	body.WriteString("\n\n" + token + "\n\n")
+	validFor := strconv.Itoa(int(s.TTL.Minutes())) + " minutes"
+	if s.TTL >= time.Hour {
+		validFor = strconv.Itoa(int(s.TTL.Hours())) + " hours"
+	}
+	body.WriteString("It works for " + validFor + ". If you didn't ask for this, you can ignore this email.\n")
+
+	err = s.Mailer.Send(ctx, mailer.Message{
+		To:      profile.Email.String,
+		Subject: "Confirm your email address",
+		Body:    body.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrVerificationNotSent, err)
+	}
+	return nil
+}
+
+// toProfileEmail describes a profile's address, with when its pending verification was sent
+func (s *EmailService) toProfileEmail(ctx context.Context, profile database.Profile) (*models.ProfileEmail, error) {
+	email := &models.ProfileEmail{
+		ProfileID:  profile.ID,
+		Email:      profile.Email.String,
+		Verified:   profile.EmailVerifiedAt.Valid,
+		VerifiedAt: profile.EmailVerifiedAt,
+	}
+	if email.Email == "" || email.Verified {
+		return email, nil
+	}
+
+	pending, err := s.DB.GetEmailVerification(ctx, profile.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return email, nil
+		}
+		return nil, fmt.Errorf("error retrieving pending verification: %w", err)
+	}
+	email.VerificationSentAt = sql.NullTime{Time: pending.CreatedAt, Valid: true}
+	return email, nil
+}
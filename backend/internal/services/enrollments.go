@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// ValidateEnrollProfiles makes sure every profile in the list exists
+// Done before an import so a typo doesn't leave a half-configured course behind.
+func (s *CourseService) ValidateEnrollProfiles(ctx context.Context, profileIDs []uuid.UUID) error {
+	for _, profileID := range profileIDs {
+		if _, err := s.DB.GetProfileById(ctx, profileID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("profile to enroll not found: %s", profileID)
+			}
+			return fmt.Errorf("error checking profile to enroll: %w", err)
+		}
+	}
+	return nil
+}
+
+// EnrollProfiles enrolls profiles in a course, ignoring ones already enrolled
+func (s *CourseService) EnrollProfiles(ctx context.Context, courseID uuid.UUID, profileIDs []uuid.UUID) ([]uuid.UUID, error) {
+	for _, profileID := range profileIDs {
+		err := s.DB.EnrollProfile(ctx, database.EnrollProfileParams{
+			CourseID: courseID,
+			UserID:   profileID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error enrolling profile %s: %w", profileID, err)
+		}
+	}
+
+	enrolled, err := s.DB.ListCourseEnrollments(ctx, courseID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving course enrollments: %w", err)
+	}
+	return enrolled, nil
+}
+
+// FilterEnrolledCourses keeps only the courses the profile is enrolled in
+func (s *CourseService) FilterEnrolledCourses(ctx context.Context, userID uuid.UUID, courses []*models.Course) ([]*models.Course, error) {
+	courseIDs, err := s.DB.ListEnrolledCourseIDs(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving enrolled courses: %w", err)
+	}
+
+	enrolled := make(map[uuid.UUID]bool, len(courseIDs))
+	for _, id := range courseIDs {
+		enrolled[id] = true
+	}
+
+	filtered := []*models.Course{}
+	for _, course := range courses {
+		if enrolled[course.ID] {
+			filtered = append(filtered, course)
+		}
+	}
+	return filtered, nil
+}
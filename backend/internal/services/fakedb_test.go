@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+)
+
+// fakeAnswer is what a fakeDB query returns. For statements that don't return rows, the
+// number of rows is reported as the rows affected.
+type fakeAnswer func(args []driver.Value) ([][]driver.Value, error)
+
+// fakeCall is a query the code under test ran
+type fakeCall struct {
+	name string // the sqlc query name, like GetProfileById
+	args []driver.Value
+}
+
+// fakeDB is a database/sql driver that answers sqlc queries by name, for testing service
+// logic around queries that only Postgres could run. Queries without an answer fail.
+type fakeDB struct {
+	mu        sync.Mutex
+	answers   map[string]fakeAnswer
+	calls     []fakeCall
+	commits   int
+	rollbacks int
+}
+
+// newFakeDB returns the fake with a connection and queries on top of it
+func newFakeDB(t *testing.T, answers map[string]fakeAnswer) (*fakeDB, *sql.DB, *database.Queries) {
+	t.Helper()
+	db := &fakeDB{answers: answers}
+	conn := sql.OpenDB(db)
+	t.Cleanup(func() { conn.Close() })
+	return db, conn, database.New(conn)
+}
+
+// called returns the calls of the named query
+func (db *fakeDB) called(name string) []fakeCall {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	var calls []fakeCall
+	for _, call := range db.calls {
+		if call.name == name {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// answer records a call and runs its answer
+func (db *fakeDB) answer(query string, args []driver.Value) ([][]driver.Value, error) {
+	name := query
+	if rest, ok := strings.CutPrefix(query, "-- name: "); ok {
+		name, _, _ = strings.Cut(rest, " ")
+	}
+
+	db.mu.Lock()
+	db.calls = append(db.calls, fakeCall{name: name, args: args})
+	answer, ok := db.answers[name]
+	db.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("fakedb: no answer for %s", name)
+	}
+	return answer(args)
+}
+
+func (db *fakeDB) Connect(context.Context) (driver.Conn, error) { return fakeConn{db}, nil }
+func (db *fakeDB) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct{ db *fakeDB }
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{c.db, query}, nil }
+func (c fakeConn) Close() error                              { return nil }
+func (c fakeConn) Begin() (driver.Tx, error)                 { return fakeTx{c.db}, nil }
+
+type fakeTx struct{ db *fakeDB }
+
+func (tx fakeTx) Commit() error {
+	tx.db.mu.Lock()
+	defer tx.db.mu.Unlock()
+	tx.db.commits++
+	return nil
+}
+
+func (tx fakeTx) Rollback() error {
+	tx.db.mu.Lock()
+	defer tx.db.mu.Unlock()
+	tx.db.rollbacks++
+	return nil
+}
+
+type fakeStmt struct {
+	db    *fakeDB
+	query string
+}
+
+func (s fakeStmt) Close() error  { return nil }
+func (s fakeStmt) NumInput() int { return -1 }
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	rows, err := s.db.answer(s.query, args)
+	if err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(len(rows)), nil
+}
+
+func (s fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	rows, err := s.db.answer(s.query, args)
+	if err != nil {
+		return nil, err
+	}
+	return &fakeRows{rows: rows}, nil
+}
+
+type fakeRows struct {
+	rows [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string {
+	if len(r.rows) == 0 {
+		return nil
+	}
+	return make([]string, len(r.rows[0]))
+}
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+// rows answers every call with the same rows
+func rows(values ...[]driver.Value) fakeAnswer {
+	return func([]driver.Value) ([][]driver.Value, error) { return values, nil }
+}
+
+// fails answers every call with err
+func fails(err error) fakeAnswer {
+	return func([]driver.Value) ([][]driver.Value, error) { return nil, err }
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// FavoriteCourse pins a course for a profile - favoriting twice is fine
+func (s *CourseService) FavoriteCourse(ctx context.Context, userID, courseID uuid.UUID) error {
+	if _, err := s.DB.GetCourse(ctx, courseID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("course not found: %w", err)
+		}
+		return fmt.Errorf("error retrieving course: %w", err)
+	}
+
+	err := s.DB.AddCourseFavorite(ctx, database.AddCourseFavoriteParams{
+		CourseID: courseID,
+		UserID:   userID,
+	})
+	if err != nil {
+		return fmt.Errorf("error adding favorite: %w", err)
+	}
+	return nil
+}
+
+// UnfavoriteCourse unpins a course for a profile
+func (s *CourseService) UnfavoriteCourse(ctx context.Context, userID, courseID uuid.UUID) error {
+	err := s.DB.RemoveCourseFavorite(ctx, database.RemoveCourseFavoriteParams{
+		CourseID: courseID,
+		UserID:   userID,
+	})
+	if err != nil {
+		return fmt.Errorf("error removing favorite: %w", err)
+	}
+	return nil
+}
+
+// AttachFavorites marks which of the courses the profile has favorited
+func (s *CourseService) AttachFavorites(ctx context.Context, userID uuid.UUID, courses []*models.Course) error {
+	courseIDs, err := s.DB.ListFavoriteCourseIDs(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("error retrieving favorites: %w", err)
+	}
+
+	favorites := make(map[uuid.UUID]bool, len(courseIDs))
+	for _, id := range courseIDs {
+		favorites[id] = true
+	}
+
+	for _, course := range courses {
+		course.IsFavorite = favorites[course.ID]
+	}
+	return nil
+}
+
+// FilterFavoriteCourses keeps only courses marked as favorites by AttachFavorites
+func FilterFavoriteCourses(courses []*models.Course) []*models.Course {
+	filtered := []*models.Course{}
+	for _, course := range courses {
+		if course.IsFavorite {
+			filtered = append(filtered, course)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/events"
+	"github.com/NeroQue/course-management-backend/pkg/rewards"
+	"github.com/google/uuid"
+)
+
+// xpSourceEvents is the completion event behind each kind of XP award
+var xpSourceEvents = map[string]string{
+	models.XPSourceContent: events.ContentCompleted,
+	models.XPSourceModule:  events.ModuleCompleted,
+	models.XPSourceCourse:  events.CourseCompleted,
+}
+
+// GamificationService awards experience points for completing content and announces the
+// levels they add up to
+type GamificationService struct {
+	DB      *database.Queries // database access
+	Conn    *sql.DB           // raw connection for transactions
+	Courses *CourseService    // completion state, study time estimates and the event bus
+	Rules   *rewards.Rules    // XP per completion and the level curve
+}
+
+// NewGamificationService creates service with its dependencies
+func NewGamificationService(db *database.Queries, conn *sql.DB, courses *CourseService, rules *rewards.Rules) *GamificationService {
+	return &GamificationService{
+		DB:      db,
+		Conn:    conn,
+		Courses: courses,
+		Rules:   rules,
+	}
+}
+
+// AwardCompletion grants the XP a profile earned by finishing an item: for the item itself,
+// and for its module and course when this finished those too. Everything pays out once per
+// profile, so completing an item again after a progress reset earns nothing new. The awards
+// and the profile's total are updated together.
+func (s *GamificationService) AwardCompletion(ctx context.Context, profileID, itemID uuid.UUID) (*models.XPAward, error) {
+	location, err := s.DB.GetContentItemLocation(ctx, itemID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("content item not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving content item: %w", err)
+	}
+
+	// hidden items aren't part of the module, so they don't earn anything
+	items, err := s.Courses.moduleItems(ctx, profileID, location.ModuleID)
+	if err != nil {
+		return nil, err
+	}
+	var candidates []models.XPAwardItem
+	for _, entry := range items {
+		if entry.item.ID == itemID && entry.progress != nil && entry.progress.Completed {
+			total, _ := s.Courses.itemStudyTime(entry.item, nil)
+			candidates = append(candidates, s.xpAwardFor(models.XPSourceContent, itemID, location.Title, s.Rules.XP.Content, total))
+		}
+	}
+	if len(candidates) == 0 {
+		return s.noAward(ctx, profileID)
+	}
+
+	moduleProgress, moduleStudy := s.Courses.summarizeModule(profileID, location.ModuleID, items, s.Courses.Weighting)
+	if moduleProgress.IsCompleted {
+		module, err := s.DB.GetModule(ctx, location.ModuleID)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving module: %w", err)
+		}
+		candidates = append(candidates, s.xpAwardFor(models.XPSourceModule, module.ID, module.Title, s.Rules.XP.Module, moduleStudy.total))
+
+		courses, studies, err := s.Courses.coursesProgress(ctx, profileID, []uuid.UUID{location.CourseID}, s.Courses.Weighting)
+		if err != nil {
+			return nil, err
+		}
+		if courses[location.CourseID].IsCompleted {
+			course, err := s.DB.GetCourse(ctx, location.CourseID)
+			if err != nil {
+				return nil, fmt.Errorf("error retrieving course: %w", err)
+			}
+			candidates = append(candidates, s.xpAwardFor(models.XPSourceCourse, course.ID, course.Title, s.Rules.XP.Course, studies[course.ID].total))
+		}
+	}
+
+	award := &models.XPAward{Awards: []models.XPAwardItem{}}
+	err = runInTx(ctx, s.Conn, s.DB, func(q *database.Queries) error {
+		for _, candidate := range candidates {
+			created, err := q.CreateXPAward(ctx, database.CreateXPAwardParams{
+				ProfileID: profileID,
+				Source:    candidate.Source,
+				SubjectID: candidate.SubjectID,
+				Xp:        int32(candidate.XP),
+			})
+			if err != nil {
+				return fmt.Errorf("error saving XP award: %w", err)
+			}
+			if created == 0 {
+				continue // paid out before
+			}
+			award.Total += candidate.XP
+			award.Awards = append(award.Awards, candidate)
+		}
+		if award.Total == 0 {
+			return nil
+		}
+
+		experience, err := q.AddProfileExperience(ctx, database.AddProfileExperienceParams{
+			ID: profileID,
+			Xp: int32(award.Total),
+		})
+		if err != nil {
+			return fmt.Errorf("error updating experience: %w", err)
+		}
+		award.Experience = int(experience)
+
+		balance := award.Experience - award.Total
+		for _, item := range award.Awards {
+			balance += item.XP
+			err := recordLedgerEntry(ctx, q, profileID, ledgerEntry{
+				stat:        models.StatXP,
+				amount:      item.XP,
+				balance:     balance,
+				reason:      models.LedgerReasonCompletion,
+				subject:     item.SubjectID.String(),
+				sourceEvent: xpSourceEvents[item.Source],
+				description: item.Title,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if award.Total == 0 {
+		return s.noAward(ctx, profileID)
+	}
+
+	award.Level, award.LevelUp = s.announceLevel(profileID, award.Experience, award.Total)
+	return award, nil
+}
+
+// announceLevel works out the level a profile is at after gaining XP and publishes
+// level.up when the gain took it to a new one
+func (s *GamificationService) announceLevel(profileID uuid.UUID, experience, gained int) (level int, up bool) {
+	previous, _ := s.Rules.Levels.Level(experience - gained)
+	level, _ = s.Rules.Levels.Level(experience)
+	if level <= previous {
+		return level, false
+	}
+
+	s.Courses.Events.Publish(events.Event{
+		Type:      events.LevelUp,
+		ProfileID: profileID,
+		Title:     "Level " + strconv.Itoa(level),
+		Level:     level,
+	})
+	return level, true
+}
+
+// earnXP adds bonus experience to a profile outside of completions and notes why in its
+// ledger, returning the new total. Like earnGems a 0 amount is skipped.
+func earnXP(ctx context.Context, q *database.Queries, profileID uuid.UUID, amount int, entry ledgerEntry) (int, error) {
+	if amount == 0 {
+		return 0, nil
+	}
+	experience, err := q.AddProfileExperience(ctx, database.AddProfileExperienceParams{
+		ID: profileID,
+		Xp: int32(amount),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error updating experience: %w", err)
+	}
+	entry.stat, entry.amount, entry.balance = models.StatXP, amount, int(experience)
+	return int(experience), recordLedgerEntry(ctx, q, profileID, entry)
+}
+
+// noAward is the answer for a completion that earned nothing, with the profile's current XP
+func (s *GamificationService) noAward(ctx context.Context, profileID uuid.UUID) (*models.XPAward, error) {
+	profile, err := s.DB.GetProfileById(ctx, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving profile: %w", err)
+	}
+	level, _ := s.Rules.Levels.Level(int(profile.Experience))
+	return &models.XPAward{Experience: int(profile.Experience), Level: level, Awards: []models.XPAwardItem{}}, nil
+}
+
+// xpAwardFor works out the XP for finishing something that takes studyTime to study. The
+// study time part means a long lecture is worth more than a one-page handout and a big
+// course more than a short one.
+func (s *GamificationService) xpAwardFor(source string, subjectID uuid.UUID, title string, base int, studyTime time.Duration) models.XPAwardItem {
+	return models.XPAwardItem{
+		Source:    source,
+		SubjectID: subjectID,
+		Title:     title,
+		XP:        base + minutes(studyTime)*s.Rules.XP.PerStudyMinute,
+	}
+}
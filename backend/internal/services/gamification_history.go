@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// history paging
+const (
+	DefaultHistoryLimit = 50
+	MaxHistoryLimit     = 200
+)
+
+// ErrInvalidHistoryStat is returned for filtering the history by a stat it doesn't track
+var ErrInvalidHistoryStat = errors.New("invalid gamification stat")
+
+// ledgerEntry is a change to one of a profile's stats, about to go into the ledger
+type ledgerEntry struct {
+	stat        string // models.Stat*
+	amount      int
+	balance     int
+	reason      string // models.LedgerReason*
+	subject     string
+	sourceEvent string // an events.* type, or models.LedgerSource*
+	description string
+}
+
+// GetHistory returns a page of the changes to a profile's XP, gems and streaks, newest
+// first, optionally only those of one stat
+func (s *GamificationService) GetHistory(ctx context.Context, profileID uuid.UUID, stat string, limit, offset int) (*models.GamificationHistory, error) {
+	if stat != "" && !slices.Contains(models.GamificationStats, stat) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidHistoryStat, stat)
+	}
+	return listLedger(ctx, s.DB, profileID, stat, limit, offset)
+}
+
+// listLedger reads a page of a profile's ledger, all stats when stat is empty
+func listLedger(ctx context.Context, q *database.Queries, profileID uuid.UUID, stat string, limit, offset int) (*models.GamificationHistory, error) {
+	// one extra row tells whether there's another page
+	rows, err := q.ListLedgerEntries(ctx, database.ListLedgerEntriesParams{
+		ProfileID:  profileID,
+		Stat:       stat,
+		MaxResults: int32(limit + 1),
+		Skip:       int32(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving gamification history: %w", err)
+	}
+
+	history := &models.GamificationHistory{
+		Entries: make([]models.LedgerEntry, 0, min(len(rows), limit)),
+		Limit:   limit,
+		Offset:  offset,
+		HasMore: len(rows) > limit,
+	}
+	for _, row := range rows[:min(len(rows), limit)] {
+		history.Entries = append(history.Entries, models.LedgerEntry{
+			ID:          row.ID,
+			Stat:        row.Stat,
+			Amount:      int(row.Amount),
+			Balance:     int(row.Balance),
+			Reason:      row.Reason,
+			Subject:     row.Subject,
+			SourceEvent: row.SourceEvent,
+			Description: row.Description,
+			CreatedAt:   row.CreatedAt,
+		})
+	}
+	return history, nil
+}
+
+// recordLedgerEntry adds a change to a profile's ledger. The ledger is only ever appended
+// to, so it should be written in the same transaction as the change itself.
+func recordLedgerEntry(ctx context.Context, q *database.Queries, profileID uuid.UUID, entry ledgerEntry) error {
+	err := q.CreateLedgerEntry(ctx, database.CreateLedgerEntryParams{
+		ProfileID:   profileID,
+		Stat:        entry.stat,
+		Amount:      int32(entry.amount),
+		Balance:     int32(entry.balance),
+		Reason:      entry.reason,
+		Subject:     entry.subject,
+		SourceEvent: entry.sourceEvent,
+		Description: entry.description,
+	})
+	if err != nil {
+		return fmt.Errorf("error recording %s change: %w", entry.stat, err)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/events"
+	"github.com/NeroQue/course-management-backend/pkg/rewards"
+	"github.com/google/uuid"
+)
+
+// gem errors, the handler maps each to its own status
+var (
+	ErrNotEnoughGems  = errors.New("not enough gems")
+	ErrUnknownGemItem = errors.New("unknown gem item")
+	ErrAlreadyOwned   = errors.New("item is already unlocked")
+)
+
+// profile themes the shop sells, by item ID. They all cost the rules' theme_cost.
+var gemThemes = []models.GemItem{
+	{ID: "theme_ocean", Kind: models.GemItemTheme, Title: "Ocean theme"},
+	{ID: "theme_forest", Kind: models.GemItemTheme, Title: "Forest theme"},
+	{ID: "theme_sunset", Kind: models.GemItemTheme, Title: "Sunset theme"},
+	{ID: "theme_midnight", Kind: models.GemItemTheme, Title: "Midnight theme"},
+}
+
+// GemService runs the gem economy: gems come in for met goals and new levels and go out
+// in the shop. Every change lands in the profile's gamification ledger.
+type GemService struct {
+	DB    *database.Queries // database access
+	Conn  *sql.DB           // raw connection for transactions
+	Rules *rewards.Rules    // what's earned and what things cost
+}
+
+// NewGemService creates service with its dependencies
+func NewGemService(db *database.Queries, conn *sql.DB, rules *rewards.Rules) *GemService {
+	return &GemService{
+		DB:    db,
+		Conn:  conn,
+		Rules: rules,
+	}
+}
+
+// GetWallet returns a profile's gems and the shop, with what it owns and can afford
+func (s *GemService) GetWallet(ctx context.Context, profileID uuid.UUID) (*models.GemWallet, error) {
+	profile, err := s.DB.GetProfileById(ctx, profileID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("profile not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving profile: %w", err)
+	}
+
+	unlocks, err := s.DB.ListProfileUnlocks(ctx, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving unlocks: %w", err)
+	}
+	unlocked := make(map[string]bool, len(unlocks))
+	for _, unlock := range unlocks {
+		unlocked[unlock.Item] = true
+	}
+
+	gems := int(profile.Gems)
+	wallet := &models.GemWallet{
+		Gems: gems,
+		Items: []models.GemItem{{
+			ID:        models.GemItemStreakFreeze,
+			Kind:      models.GemItemStreakFreeze,
+			Title:     "Streak freeze",
+			Cost:      s.Rules.Streaks.FreezeCost,
+			Owned:     int(profile.StreakFreezes),
+			Available: gems >= s.Rules.Streaks.FreezeCost && int(profile.StreakFreezes) < s.Rules.Streaks.MaxFreezes,
+		}},
+	}
+	for _, theme := range gemThemes {
+		theme.Cost = s.Rules.Gems.ThemeCost
+		if unlocked[theme.ID] {
+			theme.Owned = 1
+		}
+		theme.Available = theme.Owned == 0 && gems >= theme.Cost
+		wallet.Items = append(wallet.Items, theme)
+	}
+	return wallet, nil
+}
+
+// Redeem spends gems on an item from the shop and returns the wallet after
+func (s *GemService) Redeem(ctx context.Context, profileID uuid.UUID, item string) (*models.GemWallet, error) {
+	if item == models.GemItemStreakFreeze {
+		err := runInTx(ctx, s.Conn, s.DB, func(q *database.Queries) error {
+			_, err := buyStreakFreeze(ctx, q, profileID, s.Rules)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return s.GetWallet(ctx, profileID)
+	}
+
+	var theme *models.GemItem
+	for _, candidate := range gemThemes {
+		if candidate.ID == item {
+			candidate.Cost = s.Rules.Gems.ThemeCost
+			theme = &candidate
+		}
+	}
+	if theme == nil {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownGemItem, item)
+	}
+
+	err := runInTx(ctx, s.Conn, s.DB, func(q *database.Queries) error {
+		created, err := q.CreateProfileUnlock(ctx, database.CreateProfileUnlockParams{
+			ProfileID: profileID,
+			Item:      theme.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("error saving unlock: %w", err)
+		}
+		if created == 0 {
+			return fmt.Errorf("%w: %s", ErrAlreadyOwned, theme.Title)
+		}
+
+		balance, err := q.SpendProfileGems(ctx, database.SpendProfileGemsParams{ID: profileID, Cost: int32(theme.Cost)})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("%w: %d needed", ErrNotEnoughGems, theme.Cost)
+			}
+			return fmt.Errorf("error spending gems: %w", err)
+		}
+		return recordLedgerEntry(ctx, q, profileID, ledgerEntry{
+			stat:        models.StatGems,
+			amount:      -theme.Cost,
+			balance:     int(balance),
+			reason:      models.LedgerReasonRedeem,
+			subject:     theme.ID,
+			sourceEvent: models.LedgerSourceRedemption,
+			description: theme.Title,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetWallet(ctx, profileID)
+}
+
+// ListTransactions returns a page of a profile's gems earned and spent, newest first
+func (s *GemService) ListTransactions(ctx context.Context, profileID uuid.UUID, limit, offset int) (*models.GamificationHistory, error) {
+	return listLedger(ctx, s.DB, profileID, models.StatGems, limit, offset)
+}
+
+// AwardLevelUp pays out gems for a level.up event
+func (s *GemService) AwardLevelUp(ctx context.Context, event events.Event) error {
+	level := strconv.Itoa(event.Level)
+	return runInTx(ctx, s.Conn, s.DB, func(q *database.Queries) error {
+		return earnGems(ctx, q, event.ProfileID, s.Rules.Gems.LevelUp, ledgerEntry{
+			reason:      models.LedgerReasonLevelUp,
+			subject:     level,
+			sourceEvent: events.LevelUp,
+			description: "Reached level " + level,
+		})
+	})
+}
+
+// earnGems adds gems to a profile and notes why in its ledger; entry says why, the
+// rest is filled in here. Rules can switch a payout off by setting it to 0.
+func earnGems(ctx context.Context, q *database.Queries, profileID uuid.UUID, amount int, entry ledgerEntry) error {
+	if amount == 0 {
+		return nil
+	}
+	balance, err := q.AddProfileGems(ctx, database.AddProfileGemsParams{ID: profileID, Gems: int32(amount)})
+	if err != nil {
+		return fmt.Errorf("error awarding gems: %w", err)
+	}
+	entry.stat, entry.amount, entry.balance = models.StatGems, amount, int(balance)
+	return recordLedgerEntry(ctx, q, profileID, entry)
+}
@@ -0,0 +1,543 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/rewards"
+	"github.com/google/uuid"
+)
+
+// goal limits
+const (
+	maxDailyGoalMinutes = 24 * 60
+	maxDailyGoalItems   = 100
+	maxGoalCatchUpDays  = 366 // days judged in one go after the evaluator was down
+	recentGoalDays      = 30  // verdicts shown with a single goal
+)
+
+// goal errors, the handler maps each to its own status
+var (
+	ErrInvalidGoal  = errors.New("invalid goal")
+	ErrGoalNotFound = errors.New("goal not found")
+)
+
+// GoalService keeps the goals profiles set themselves and judges them once a day is over.
+// Met goals go on the profile's timeline and earn gems, which buy streak freezes among others.
+type GoalService struct {
+	DB         *database.Queries  // database access
+	Conn       *sql.DB            // raw connection for transactions
+	Courses    *CourseService     // course completion
+	Visibility *VisibilityService // goals only on courses the profile can see
+	Location   *time.Location     // where a day starts, same as for streaks
+	Rules      *rewards.Rules     // gems for met goals, streak freeze limits
+}
+
+// NewGoalService creates service with its dependencies
+func NewGoalService(db *database.Queries, conn *sql.DB, courses *CourseService, visibility *VisibilityService, rules *rewards.Rules) *GoalService {
+	return &GoalService{
+		DB:         db,
+		Conn:       conn,
+		Courses:    courses,
+		Visibility: visibility,
+		Location:   activityLocation(),
+		Rules:      rules,
+	}
+}
+
+// CreateGoal sets a new goal for a profile. Daily goals are judged from today on.
+func (s *GoalService) CreateGoal(ctx context.Context, profileID uuid.UUID, input models.CreateGoalInput) (*models.LearningGoal, error) {
+	today := activityDay(time.Now(), s.Location)
+	params := database.CreateLearningGoalParams{
+		ProfileID:        profileID,
+		GoalType:         input.Type,
+		EvaluatedThrough: sql.NullTime{Time: today.AddDate(0, 0, -1), Valid: true},
+	}
+
+	switch input.Type {
+	case models.GoalDailyMinutes:
+		if err := validateGoalMinutes(input.TargetMinutes); err != nil {
+			return nil, err
+		}
+		params.TargetMinutes = sql.NullInt32{Int32: int32(input.TargetMinutes), Valid: true}
+
+	case models.GoalDailyItems:
+		if err := validateGoalItems(input.TargetItems); err != nil {
+			return nil, err
+		}
+		params.TargetItems = sql.NullInt32{Int32: int32(input.TargetItems), Valid: true}
+
+	case models.GoalCourseCompletion:
+		if input.CourseID == uuid.Nil {
+			return nil, fmt.Errorf("%w: course_id is required", ErrInvalidGoal)
+		}
+		if _, err := s.DB.GetCourse(ctx, input.CourseID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, fmt.Errorf("%w: course not found", ErrInvalidGoal)
+			}
+			return nil, fmt.Errorf("error retrieving course: %w", err)
+		}
+		// hidden courses look the same as missing ones
+		visible, err := s.Visibility.CanSeeCourse(ctx, profileID, input.CourseID)
+		if err != nil {
+			return nil, fmt.Errorf("error checking course visibility: %w", err)
+		}
+		if !visible {
+			return nil, fmt.Errorf("%w: course not found", ErrInvalidGoal)
+		}
+		due, err := s.parseDueDate(input.DueDate)
+		if err != nil {
+			return nil, err
+		}
+		params.CourseID = uuid.NullUUID{UUID: input.CourseID, Valid: true}
+		params.DueDate = sql.NullTime{Time: due, Valid: true}
+
+	default:
+		return nil, fmt.Errorf("%w: type must be %s, %s or %s", ErrInvalidGoal,
+			models.GoalDailyMinutes, models.GoalDailyItems, models.GoalCourseCompletion)
+	}
+
+	row, err := s.DB.CreateLearningGoal(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("error saving goal: %w", err)
+	}
+	return s.toGoalModel(ctx, row)
+}
+
+// ListGoals returns a profile's goals, oldest first
+func (s *GoalService) ListGoals(ctx context.Context, profileID uuid.UUID) ([]*models.LearningGoal, error) {
+	rows, err := s.DB.ListLearningGoals(ctx, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving goals: %w", err)
+	}
+
+	goals := make([]*models.LearningGoal, 0, len(rows))
+	for _, row := range rows {
+		goal, err := s.toGoalModel(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		goals = append(goals, goal)
+	}
+	return goals, nil
+}
+
+// GetGoal returns one of a profile's goals with its recent daily verdicts
+func (s *GoalService) GetGoal(ctx context.Context, profileID, goalID uuid.UUID) (*models.LearningGoal, error) {
+	row, err := s.ownGoal(ctx, profileID, goalID)
+	if err != nil {
+		return nil, err
+	}
+
+	goal, err := s.toGoalModel(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluations, err := s.DB.ListGoalEvaluations(ctx, database.ListGoalEvaluationsParams{
+		GoalID: goalID,
+		Limit:  recentGoalDays,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving goal history: %w", err)
+	}
+	for _, evaluation := range evaluations {
+		goal.RecentDays = append(goal.RecentDays, models.GoalEvaluation{
+			Day:     evaluation.Day.Format(time.DateOnly),
+			Met:     evaluation.Met,
+			Minutes: int(evaluation.Minutes),
+			Items:   int(evaluation.Items),
+			Frozen:  evaluation.Frozen,
+		})
+	}
+	return goal, nil
+}
+
+// UpdateGoal changes a goal's daily target or due date. A missed course goal given a new
+// due date is back on.
+func (s *GoalService) UpdateGoal(ctx context.Context, profileID, goalID uuid.UUID, input models.UpdateGoalInput) (*models.LearningGoal, error) {
+	row, err := s.ownGoal(ctx, profileID, goalID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := database.UpdateLearningGoalParams{
+		ID:            goalID,
+		TargetMinutes: row.TargetMinutes,
+		TargetItems:   row.TargetItems,
+		DueDate:       row.DueDate,
+		Status:        row.Status,
+	}
+
+	if input.TargetMinutes != nil {
+		if row.GoalType != models.GoalDailyMinutes {
+			return nil, fmt.Errorf("%w: only daily_minutes goals have a target in minutes", ErrInvalidGoal)
+		}
+		if err := validateGoalMinutes(*input.TargetMinutes); err != nil {
+			return nil, err
+		}
+		params.TargetMinutes = sql.NullInt32{Int32: int32(*input.TargetMinutes), Valid: true}
+	}
+
+	if input.TargetItems != nil {
+		if row.GoalType != models.GoalDailyItems {
+			return nil, fmt.Errorf("%w: only daily_items goals have a target in items", ErrInvalidGoal)
+		}
+		if err := validateGoalItems(*input.TargetItems); err != nil {
+			return nil, err
+		}
+		params.TargetItems = sql.NullInt32{Int32: int32(*input.TargetItems), Valid: true}
+	}
+
+	if input.DueDate != nil {
+		if row.GoalType != models.GoalCourseCompletion {
+			return nil, fmt.Errorf("%w: only course goals have a due date", ErrInvalidGoal)
+		}
+		due, err := s.parseDueDate(*input.DueDate)
+		if err != nil {
+			return nil, err
+		}
+		params.DueDate = sql.NullTime{Time: due, Valid: true}
+		if row.Status == models.GoalMissed {
+			params.Status = models.GoalActive
+		}
+	}
+
+	updated, err := s.DB.UpdateLearningGoal(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("error saving goal: %w", err)
+	}
+	return s.toGoalModel(ctx, updated)
+}
+
+// DeleteGoal removes one of a profile's goals along with its history
+func (s *GoalService) DeleteGoal(ctx context.Context, profileID, goalID uuid.UUID) error {
+	if _, err := s.ownGoal(ctx, profileID, goalID); err != nil {
+		return err
+	}
+	if err := s.DB.DeleteLearningGoal(ctx, goalID); err != nil {
+		return fmt.Errorf("error deleting goal: %w", err)
+	}
+	return nil
+}
+
+// EvaluationRoutine judges active goals every interval. Days are only judged once they're
+// over, so running more often than daily just catches midnight sooner.
+func (s *GoalService) EvaluationRoutine(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if _, err := s.EvaluateGoals(context.Background(), time.Now()); err != nil {
+			log.Printf("Warning: goal evaluation failed: %v", err)
+		}
+	}
+}
+
+// EvaluateGoals judges every active goal up to the day before now and returns how many
+// goals it looked at. One goal failing doesn't stop the rest.
+func (s *GoalService) EvaluateGoals(ctx context.Context, now time.Time) (int, error) {
+	goals, err := s.DB.ListActiveLearningGoals(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error retrieving goals: %w", err)
+	}
+
+	yesterday := activityDay(now, s.Location).AddDate(0, 0, -1)
+	evaluated := 0
+	for _, goal := range goals {
+		if goal.EvaluatedThrough.Valid && !goal.EvaluatedThrough.Time.Before(yesterday) {
+			continue
+		}
+
+		var err error
+		switch goal.GoalType {
+		case models.GoalDailyMinutes, models.GoalDailyItems:
+			err = s.evaluateDaily(ctx, goal, yesterday)
+		case models.GoalCourseCompletion:
+			err = s.evaluateCourse(ctx, goal, yesterday)
+		}
+		if err != nil {
+			log.Printf("Warning: could not evaluate goal %s: %v", goal.ID, err)
+			continue
+		}
+		evaluated++
+	}
+	return evaluated, nil
+}
+
+// evaluateDaily judges each day since the last evaluation through yesterday, keeping the
+// goal's streak of days met. Each day met earns gems; a missed day costs the streak unless
+// the profile has a streak freeze to spend on it.
+func (s *GoalService) evaluateDaily(ctx context.Context, goal database.LearningGoal, yesterday time.Time) error {
+	day := yesterday
+	if goal.EvaluatedThrough.Valid {
+		day = goal.EvaluatedThrough.Time.AddDate(0, 0, 1)
+	}
+	if earliest := yesterday.AddDate(0, 0, -maxGoalCatchUpDays+1); day.Before(earliest) {
+		day = earliest
+	}
+
+	streak, longest := int(goal.Streak), int(goal.LongestStreak)
+	var metDays []time.Time
+	title := "Studied " + strconv.Itoa(int(goal.TargetMinutes.Int32)) + " minutes a day"
+	if goal.GoalType == models.GoalDailyItems {
+		title = "Completed " + strconv.Itoa(int(goal.TargetItems.Int32)) + " items a day"
+	}
+
+	err := runInTx(ctx, s.Conn, s.DB, func(q *database.Queries) error {
+		for ; !day.After(yesterday); day = day.AddDate(0, 0, 1) {
+			minutes, err := s.minutesOn(ctx, q, goal.ProfileID, day)
+			if err != nil {
+				return err
+			}
+			items, err := s.itemsOn(ctx, q, goal.ProfileID, day)
+			if err != nil {
+				return err
+			}
+
+			met := minutes >= int(goal.TargetMinutes.Int32)
+			if goal.GoalType == models.GoalDailyItems {
+				met = items >= int(goal.TargetItems.Int32)
+			}
+
+			// what happened that day, for the ledger
+			change := ledgerEntry{
+				subject:     goal.ID.String(),
+				sourceEvent: models.LedgerSourceEvaluation,
+				description: title + " on " + day.Format(time.DateOnly),
+			}
+
+			frozen := false
+			previous := streak
+			switch {
+			case met:
+				streak++
+				longest = max(longest, streak)
+				metDays = append(metDays, day)
+				change.reason, change.sourceEvent = models.LedgerReasonGoalMet, models.ActivityGoalMet
+				if err := earnGems(ctx, q, goal.ProfileID, s.Rules.Gems.GoalDay, change); err != nil {
+					return err
+				}
+			case streak > 0:
+				// a freeze keeps the streak as it is, it doesn't add a day
+				frozen, err = s.freezeDay(ctx, q, goal.ProfileID, day)
+				if err != nil {
+					return err
+				}
+				if !frozen {
+					streak = 0
+					change.reason = models.LedgerReasonGoalMissed
+				}
+			}
+
+			if streak != previous {
+				change.stat, change.amount, change.balance = models.StatGoalStreak, streak-previous, streak
+				if err := recordLedgerEntry(ctx, q, goal.ProfileID, change); err != nil {
+					return err
+				}
+			}
+
+			err = q.CreateGoalEvaluation(ctx, database.CreateGoalEvaluationParams{
+				GoalID:  goal.ID,
+				Day:     day,
+				Met:     met,
+				Minutes: int32(minutes),
+				Items:   int32(items),
+				Frozen:  frozen,
+			})
+			if err != nil {
+				return fmt.Errorf("error saving goal verdict: %w", err)
+			}
+		}
+
+		return q.SetLearningGoalEvaluation(ctx, database.SetLearningGoalEvaluationParams{
+			ID:               goal.ID,
+			Status:           models.GoalActive,
+			Streak:           int32(streak),
+			LongestStreak:    int32(longest),
+			EvaluatedThrough: sql.NullTime{Time: yesterday, Valid: true},
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	for range metDays {
+		if err := recordActivityEvent(ctx, s.DB, goal.ProfileID, models.ActivityGoalMet, uuid.Nil, uuid.Nil, title); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+	return nil
+}
+
+// evaluateCourse marks a course goal met once the course is complete, or missed when its
+// due date went by first
+func (s *GoalService) evaluateCourse(ctx context.Context, goal database.LearningGoal, yesterday time.Time) error {
+	progress, err := s.Courses.CalculateCourseProgress(ctx, goal.ProfileID, goal.CourseID.UUID)
+	if err != nil {
+		return fmt.Errorf("error calculating course progress: %w", err)
+	}
+
+	status := models.GoalActive
+	eventType := ""
+	switch {
+	case progress.IsCompleted && progress.TotalItems > 0:
+		status, eventType = models.GoalMet, models.ActivityGoalMet
+	case goal.DueDate.Valid && !goal.DueDate.Time.After(yesterday):
+		status, eventType = models.GoalMissed, models.ActivityGoalMissed
+	}
+
+	title := "Finish the course"
+	if course, err := s.DB.GetCourse(ctx, goal.CourseID.UUID); err == nil {
+		title = "Finish " + course.Title
+	}
+
+	err = runInTx(ctx, s.Conn, s.DB, func(q *database.Queries) error {
+		err := q.SetLearningGoalEvaluation(ctx, database.SetLearningGoalEvaluationParams{
+			ID:               goal.ID,
+			Status:           status,
+			EvaluatedThrough: sql.NullTime{Time: yesterday, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("error saving goal verdict: %w", err)
+		}
+		if status != models.GoalMet {
+			return nil
+		}
+		return earnGems(ctx, q, goal.ProfileID, s.Rules.Gems.CourseGoal, ledgerEntry{
+			reason:      models.LedgerReasonGoalMet,
+			subject:     goal.ID.String(),
+			sourceEvent: models.ActivityGoalMet,
+			description: title,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	if eventType != "" {
+		if err := recordActivityEvent(ctx, s.DB, goal.ProfileID, eventType, goal.CourseID.UUID, uuid.Nil, title); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+	return nil
+}
+
+// minutesOn returns the minutes of watch time a profile was credited with on a day
+func (s *GoalService) minutesOn(ctx context.Context, q *database.Queries, profileID uuid.UUID, day time.Time) (int, error) {
+	// watch sessions are stamped in UTC
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, s.Location)
+	seconds, err := q.GetWatchSecondsBetween(ctx, database.GetWatchSecondsBetweenParams{
+		ProfileID: profileID,
+		FromTime:  start.UTC(),
+		ToTime:    start.AddDate(0, 0, 1).UTC(),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error retrieving watch time: %w", err)
+	}
+	return int(seconds / 60), nil
+}
+
+// itemsOn returns how many items a profile completed on a day
+func (s *GoalService) itemsOn(ctx context.Context, q *database.Queries, profileID uuid.UUID, day time.Time) (int, error) {
+	// completions are stamped with now() in the database's local time
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, s.Location)
+	count, err := q.CountCompletionsBetween(ctx, database.CountCompletionsBetweenParams{
+		UserID:   profileID,
+		FromTime: start.In(time.Local),
+		ToTime:   start.AddDate(0, 0, 1).In(time.Local),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error retrieving completions: %w", err)
+	}
+	return int(count), nil
+}
+
+// ownGoal loads a goal, treating other profiles' goals as missing
+func (s *GoalService) ownGoal(ctx context.Context, profileID, goalID uuid.UUID) (database.LearningGoal, error) {
+	goal, err := s.DB.GetLearningGoal(ctx, goalID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return goal, ErrGoalNotFound
+		}
+		return goal, fmt.Errorf("error retrieving goal: %w", err)
+	}
+	if goal.ProfileID != profileID {
+		return goal, ErrGoalNotFound
+	}
+	return goal, nil
+}
+
+// parseDueDate reads a due date, which can't be in the past
+func (s *GoalService) parseDueDate(value string) (time.Time, error) {
+	due, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: due_date must be a date (YYYY-MM-DD)", ErrInvalidGoal)
+	}
+	if due.Before(activityDay(time.Now(), s.Location)) {
+		return time.Time{}, fmt.Errorf("%w: due_date can't be in the past", ErrInvalidGoal)
+	}
+	return due, nil
+}
+
+// validateGoalMinutes checks a daily target
+func validateGoalMinutes(minutes int) error {
+	if minutes < 1 || minutes > maxDailyGoalMinutes {
+		return fmt.Errorf("%w: target_minutes must be between 1 and %d", ErrInvalidGoal, maxDailyGoalMinutes)
+	}
+	return nil
+}
+
+// validateGoalItems checks a daily target of items
+func validateGoalItems(items int) error {
+	if items < 1 || items > maxDailyGoalItems {
+		return fmt.Errorf("%w: target_items must be between 1 and %d", ErrInvalidGoal, maxDailyGoalItems)
+	}
+	return nil
+}
+
+// toGoalModel converts the db row, adding today's minutes or items to daily goals
+func (s *GoalService) toGoalModel(ctx context.Context, row database.LearningGoal) (*models.LearningGoal, error) {
+	goal := &models.LearningGoal{
+		ID:            row.ID,
+		ProfileID:     row.ProfileID,
+		Type:          row.GoalType,
+		TargetMinutes: int(row.TargetMinutes.Int32),
+		TargetItems:   int(row.TargetItems.Int32),
+		CourseID:      row.CourseID.UUID,
+		Status:        row.Status,
+		Streak:        int(row.Streak),
+		LongestStreak: int(row.LongestStreak),
+		CreatedAt:     row.CreatedAt.Time,
+	}
+	if row.DueDate.Valid {
+		goal.DueDate = row.DueDate.Time.Format(time.DateOnly)
+	}
+	if row.EvaluatedThrough.Valid {
+		goal.EvaluatedThrough = row.EvaluatedThrough.Time.Format(time.DateOnly)
+	}
+
+	today := activityDay(time.Now(), s.Location)
+	switch row.GoalType {
+	case models.GoalDailyMinutes:
+		minutes, err := s.minutesOn(ctx, s.DB, row.ProfileID, today)
+		if err != nil {
+			return nil, err
+		}
+		goal.TodayMinutes = minutes
+	case models.GoalDailyItems:
+		items, err := s.itemsOn(ctx, s.DB, row.ProfileID, today)
+		if err != nil {
+			return nil, err
+		}
+		goal.TodayItems = items
+	}
+	return goal, nil
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// guestName is what every guest profile is called
+const guestName = "Guest"
+
+// CreateGuestProfile makes a profile for a visitor to try the library with. Guests are
+// viewers, don't show up in the profile list or on leaderboards, and are deleted with
+// everything they did once their session ends - see EndGuestProfile and PruneGuestProfiles.
+func (s *ProfileService) CreateGuestProfile(ctx context.Context) (models.Profile, error) {
+	profile, err := s.DB.CreateGuestProfile(ctx, database.CreateGuestProfileParams{
+		ID:   uuid.New(),
+		Name: guestName,
+	})
+	if err != nil {
+		return models.Profile{}, fmt.Errorf("failed to create guest profile: %w", err)
+	}
+	return s.toProfileModel(profile), nil
+}
+
+// EndGuestProfile deletes userID if it's a guest, reporting whether it was. Called when
+// the guest logs out; real profiles are left alone.
+func (s *ProfileService) EndGuestProfile(ctx context.Context, userID uuid.UUID) (bool, error) {
+	deleted, err := s.DB.DeleteGuestProfile(ctx, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete guest profile: %w", err)
+	}
+	if deleted == 0 {
+		return false, nil
+	}
+
+	if err := s.removeAvatar(userID); err != nil {
+		log.Printf("Error removing avatar of guest profile %s: %v", userID, err)
+	}
+	return true, nil
+}
+
+// PruneGuestProfiles deletes the guests whose session is gone - expired, revoked or
+// replaced by selecting another profile - and returns how many went
+func (s *ProfileService) PruneGuestProfiles(ctx context.Context) (int, error) {
+	ids, err := s.DB.DeleteAbandonedGuestProfiles(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune guest profiles: %w", err)
+	}
+
+	for _, id := range ids {
+		if err := s.removeAvatar(id); err != nil {
+			log.Printf("Error removing avatar of guest profile %s: %v", id, err)
+		}
+	}
+	return len(ids), nil
+}
+
+// GuestPruneRoutine clears out abandoned guest profiles every interval - meant to run in
+// its own goroutine
+func (s *ProfileService) GuestPruneRoutine(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		pruned, err := s.PruneGuestProfiles(context.Background())
+		if err != nil {
+			log.Printf("Error pruning guest profiles: %v", err)
+			continue
+		}
+		if pruned > 0 {
+			log.Printf("Pruned %d guest profiles", pruned)
+		}
+	}
+}
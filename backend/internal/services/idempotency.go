@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/util"
+	"github.com/google/uuid"
+)
+
+// MaxIdempotencyKeyLength is the longest Idempotency-Key header we accept
+const MaxIdempotencyKeyLength = 255
+
+// idempotency errors, the middleware maps each to its own status
+var (
+	ErrIdempotencyKeyInUse  = errors.New("a request with this idempotency key is still being processed")
+	ErrIdempotencyKeyReused = errors.New("idempotency key was already used for a different request")
+)
+
+// IdempotencyService remembers responses to requests sent with an Idempotency-Key,
+// so a retried import doesn't create the course or start the task a second time
+type IdempotencyService struct {
+	DB  *database.Queries // database access
+	TTL time.Duration     // how long a key is remembered
+}
+
+// NewIdempotencyService creates service with database dependencies, IDEMPOTENCY_TTL sets how long keys are kept
+func NewIdempotencyService(db *database.Queries) *IdempotencyService {
+	return &IdempotencyService{
+		DB:  db,
+		TTL: util.GetEnvDuration("IDEMPOTENCY_TTL", 24*time.Hour),
+	}
+}
+
+// Begin claims a key for a request. It returns nil if the caller should go ahead and run the request,
+// or the stored response if the same request already finished under this key.
+func (s *IdempotencyService) Begin(ctx context.Context, profileID uuid.UUID, route, key, requestHash string) (*models.IdempotentResponse, error) {
+	// expired keys go first, otherwise they'd block their key from being used again
+	if _, err := s.DB.DeleteExpiredIdempotencyKeys(ctx, time.Now().Add(-s.TTL)); err != nil {
+		log.Printf("Warning: could not delete expired idempotency keys: %v", err)
+	}
+
+	claimed, err := s.DB.ClaimIdempotencyKey(ctx, database.ClaimIdempotencyKeyParams{
+		ProfileID:   profileID,
+		Route:       route,
+		Key:         key,
+		RequestHash: requestHash,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error claiming idempotency key: %w", err)
+	}
+	if claimed > 0 {
+		return nil, nil
+	}
+
+	existing, err := s.DB.GetIdempotencyKey(ctx, database.GetIdempotencyKeyParams{
+		ProfileID: profileID,
+		Route:     route,
+		Key:       key,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// released by a failed attempt between our claim and this read
+			return nil, ErrIdempotencyKeyInUse
+		}
+		return nil, fmt.Errorf("error retrieving idempotency key: %w", err)
+	}
+
+	if existing.RequestHash != requestHash {
+		return nil, ErrIdempotencyKeyReused
+	}
+	if !existing.StatusCode.Valid {
+		return nil, ErrIdempotencyKeyInUse
+	}
+
+	return &models.IdempotentResponse{
+		StatusCode: int(existing.StatusCode.Int32),
+		Body:       []byte(existing.ResponseBody.String),
+	}, nil
+}
+
+// Complete stores the response to a claimed request so retries get it back
+func (s *IdempotencyService) Complete(ctx context.Context, profileID uuid.UUID, route, key string, response models.IdempotentResponse) error {
+	err := s.DB.CompleteIdempotencyKey(ctx, database.CompleteIdempotencyKeyParams{
+		ProfileID:    profileID,
+		Route:        route,
+		Key:          key,
+		StatusCode:   sql.NullInt32{Int32: int32(response.StatusCode), Valid: true},
+		ResponseBody: sql.NullString{String: string(response.Body), Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("error storing idempotent response: %w", err)
+	}
+	return nil
+}
+
+// Release forgets a claimed key, for requests that failed on our side and are worth retrying
+func (s *IdempotencyService) Release(ctx context.Context, profileID uuid.UUID, route, key string) error {
+	err := s.DB.ReleaseIdempotencyKey(ctx, database.ReleaseIdempotencyKeyParams{
+		ProfileID: profileID,
+		Route:     route,
+		Key:       key,
+	})
+	if err != nil {
+		return fmt.Errorf("error releasing idempotency key: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/NeroQue/course-management-backend/pkg/artifacts"
+	"github.com/NeroQue/course-management-backend/pkg/thumbnail"
+	"github.com/google/uuid"
+)
+
+// imageSizes are the box sizes resized images snap up to, so arbitrary w/h values
+// don't fill the cache with near-identical copies
+var imageSizes = []int{160, 320, 480, 640, 800, 1024, 1280, 1600, 1920, 2560}
+
+// DefaultImageWidth is used when neither a width nor a height is asked for
+const DefaultImageWidth = 1280
+
+// SnapImageSize rounds a requested dimension up to the next cached size. 0 stays 0 (no limit).
+func SnapImageSize(size int) int {
+	if size <= 0 {
+		return 0
+	}
+	for _, s := range imageSizes {
+		if size <= s {
+			return s
+		}
+	}
+	return imageSizes[len(imageSizes)-1]
+}
+
+// GetImage returns the path of an image content item resized to fit in width x height,
+// creating it on first request. Either may be 0 for no limit. The file name holds the
+// source checksum and the box size, so a replaced image gets new copies.
+func (s *ThumbnailService) GetImage(ctx context.Context, itemID, profileID uuid.UUID, width, height int) (string, error) {
+	location, err := s.locate(ctx, itemID, profileID)
+	if err != nil {
+		return "", err
+	}
+	if location.ContentType != "image" {
+		return "", fmt.Errorf("%w: %s is not an image", thumbnail.ErrUnsupported, location.ContentType)
+	}
+
+	width, height = SnapImageSize(width), SnapImageSize(height)
+	if width == 0 && height == 0 {
+		width = DefaultImageWidth
+	}
+
+	src, checksum, err := s.sourceFile(ctx, location)
+	if err != nil {
+		return "", err
+	}
+
+	name := checksum + "-" + strconv.Itoa(width) + "x" + strconv.Itoa(height) + ".jpg"
+	path := filepath.Join(s.Store.ItemDir(artifacts.Images, location.CourseID, itemID), name)
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+
+	s.generating.Lock()
+	defer s.generating.Unlock()
+
+	// another request may have made it while we waited
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("error creating image folder: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := thumbnail.Resize(src, tmp, width, height, 85); err != nil {
+		os.Remove(tmp)
+		return "", fmt.Errorf("%w: %v", ErrThumbnailUnavailable, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return "", fmt.Errorf("error saving resized image: %w", err)
+	}
+	return path, nil
+}
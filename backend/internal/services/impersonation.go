@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/session"
+	"github.com/google/uuid"
+)
+
+// impersonation time box
+const (
+	DefaultImpersonationMinutes = 30
+	MaxImpersonationMinutes     = 240
+)
+
+// ErrInvalidImpersonation is returned for impersonation requests that can't be honoured
+var ErrInvalidImpersonation = errors.New("invalid impersonation")
+
+// ImpersonationService lets admins act as another profile for a limited time.
+// Starting, stopping and every change made in between go to the audit log.
+type ImpersonationService struct {
+	DB *database.Queries // database access
+}
+
+// NewImpersonationService creates service with database dependencies
+func NewImpersonationService(db *database.Queries) *ImpersonationService {
+	return &ImpersonationService{DB: db}
+}
+
+// Start makes the admin's session act as input.ProfileID. The caller must have checked adminID is an admin.
+func (s *ImpersonationService) Start(ctx context.Context, adminID uuid.UUID, input models.StartImpersonationInput) (*models.ImpersonationStatus, error) {
+	if session.CurrentImpersonation(ctx) != nil {
+		return nil, fmt.Errorf("%w: already impersonating a profile, stop that first", ErrInvalidImpersonation)
+	}
+	if input.ProfileID == uuid.Nil || input.ProfileID == adminID {
+		return nil, fmt.Errorf("%w: profile_id must be another profile", ErrInvalidImpersonation)
+	}
+
+	minutes := input.Minutes
+	if minutes == 0 {
+		minutes = DefaultImpersonationMinutes
+	}
+	if minutes < 1 || minutes > MaxImpersonationMinutes {
+		return nil, fmt.Errorf("%w: minutes must be between 1 and %d", ErrInvalidImpersonation, MaxImpersonationMinutes)
+	}
+
+	if _, err := s.DB.GetProfileById(ctx, input.ProfileID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: profile %s does not exist", ErrInvalidImpersonation, input.ProfileID)
+		}
+		return nil, fmt.Errorf("error retrieving profile: %w", err)
+	}
+
+	expiresAt := time.Now().Add(time.Duration(minutes) * time.Minute)
+
+	// audit first - an impersonation we couldn't record shouldn't happen
+	err := recordAudit(ctx, s.DB, models.AuditEntry{
+		ActorID:    adminID,
+		SubjectID:  input.ProfileID,
+		Action:     models.AuditImpersonationStart,
+		EntityType: "profile",
+		EntityID:   input.ProfileID,
+		Details:    "for " + strconv.Itoa(minutes) + " minutes, until " + expiresAt.Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := session.StartImpersonation(ctx, input.ProfileID, expiresAt); err != nil {
+		return nil, err
+	}
+	return s.Status(ctx), nil
+}
+
+// Stop ends impersonation on the current session, whether or not it already expired
+func (s *ImpersonationService) Stop(ctx context.Context) (*models.ImpersonationStatus, error) {
+	stopped, err := session.StopImpersonation(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if stopped == nil {
+		return nil, fmt.Errorf("%w: not impersonating anyone", ErrInvalidImpersonation)
+	}
+
+	details := "ended early"
+	if stopped.Expired() {
+		details = "ended after expiring at " + stopped.ExpiresAt.Format(time.RFC3339)
+	}
+	err = recordAudit(ctx, s.DB, models.AuditEntry{
+		ActorID:    stopped.AdminID,
+		SubjectID:  stopped.ProfileID,
+		Action:     models.AuditImpersonationStop,
+		EntityType: "profile",
+		EntityID:   stopped.ProfileID,
+		Details:    details,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.Status(ctx), nil
+}
+
+// Status reports the impersonation of the request's session
+func (s *ImpersonationService) Status(ctx context.Context) *models.ImpersonationStatus {
+	impersonation := session.CurrentImpersonation(ctx)
+	if impersonation == nil {
+		return &models.ImpersonationStatus{Active: false}
+	}
+
+	expiresAt := impersonation.ExpiresAt
+	return &models.ImpersonationStatus{
+		Active:           true,
+		AdminID:          impersonation.AdminID,
+		ProfileID:        impersonation.ProfileID,
+		ExpiresAt:        &expiresAt,
+		RemainingSeconds: int(time.Until(expiresAt).Seconds()),
+	}
+}
+
+// RecordRequest notes a change made while impersonating, so it can be told apart from the user's own
+func (s *ImpersonationService) RecordRequest(ctx context.Context, impersonation *session.Impersonation, method, path string) error {
+	return recordAudit(ctx, s.DB, models.AuditEntry{
+		ActorID:   impersonation.AdminID,
+		SubjectID: impersonation.ProfileID,
+		Action:    models.AuditImpersonationRequest,
+		Details:   method + " " + path,
+	})
+}
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+)
+
+// contentWarnings looks over a parsed course for things the user should double-check
+func contentWarnings(course *models.Course) []models.ImportWarning {
+	var warnings []models.ImportWarning
+	missingDurations := 0
+
+	for _, module := range course.Modules {
+		if len(module.ContentItems) == 0 {
+			warnings = append(warnings, models.ImportWarning{
+				Code:    models.WarnEmptyModule,
+				Path:    module.RelativePath,
+				Message: fmt.Sprintf("Module %q has no content", module.Title),
+			})
+			continue
+		}
+
+		for _, item := range module.ContentItems {
+			switch {
+			case item.ContentType == "unknown":
+				warnings = append(warnings, models.ImportWarning{
+					Code:    models.WarnUnknownExtension,
+					Path:    item.RelativePath,
+					Message: fmt.Sprintf("Unrecognised file type %q, it will be listed but can't be previewed", filepath.Ext(item.RelativePath)),
+				})
+			case item.ContentType == "video" && item.Duration == 0:
+				missingDurations++
+			}
+		}
+	}
+
+	// one warning per course, listing every video would drown out everything else
+	if missingDurations > 0 {
+		warnings = append(warnings, models.ImportWarning{
+			Code:    models.WarnMissingDuration,
+			Path:    course.RelativePath,
+			Message: fmt.Sprintf("%d video(s) have no duration, course length and time estimates will be incomplete", missingDurations),
+		})
+	}
+
+	return warnings
+}
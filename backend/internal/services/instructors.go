@@ -0,0 +1,301 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// maxInstructorNameLength keeps names usable in lists and filters
+const maxInstructorNameLength = 200
+
+// errors the instructor handlers map to 4xx responses
+var (
+	ErrInvalidInstructor   = errors.New("invalid instructor")
+	ErrDuplicateInstructor = errors.New("an instructor with that name already exists")
+)
+
+// InstructorService manages instructors and which courses they teach
+type InstructorService struct {
+	DB   *database.Queries // database access
+	Conn *sql.DB           // raw connection for transactions
+}
+
+// NewInstructorService creates service with database dependencies
+func NewInstructorService(db *database.Queries, conn *sql.DB) *InstructorService {
+	return &InstructorService{
+		DB:   db,
+		Conn: conn,
+	}
+}
+
+// ListInstructors returns every instructor with how many courses they teach
+func (s *InstructorService) ListInstructors(ctx context.Context) ([]*models.Instructor, error) {
+	rows, err := s.DB.ListInstructors(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving instructors: %w", err)
+	}
+
+	instructors := make([]*models.Instructor, 0, len(rows))
+	for _, row := range rows {
+		instructor := toInstructorModel(database.Instructor{
+			ID:        row.ID,
+			Name:      row.Name,
+			Bio:       row.Bio,
+			AvatarUrl: row.AvatarUrl,
+			CreatedAt: row.CreatedAt,
+			UpdatedAt: row.UpdatedAt,
+		})
+		instructor.CourseCount = int(row.CourseCount)
+		instructors = append(instructors, instructor)
+	}
+	return instructors, nil
+}
+
+// GetInstructor returns one instructor with the courses they teach
+func (s *InstructorService) GetInstructor(ctx context.Context, id uuid.UUID) (*models.Instructor, error) {
+	dbInstructor, err := s.DB.GetInstructor(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("instructor not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving instructor: %w", err)
+	}
+
+	courses, err := s.DB.ListInstructorCourses(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving instructor courses: %w", err)
+	}
+
+	instructor := toInstructorModel(dbInstructor)
+	instructor.CourseCount = len(courses)
+	for _, course := range courses {
+		instructor.Courses = append(instructor.Courses, models.InstructorCourse{
+			CourseID: course.ID,
+			Title:    course.Title,
+		})
+	}
+	return instructor, nil
+}
+
+// CreateInstructor adds an instructor; names are unique regardless of case
+func (s *InstructorService) CreateInstructor(ctx context.Context, input models.CreateInstructorInput) (*models.Instructor, error) {
+	name, err := normalizeInstructorName(input.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkNameFree(ctx, name, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	created, err := s.DB.CreateInstructor(ctx, database.CreateInstructorParams{
+		ID:        uuid.New(),
+		Name:      name,
+		Bio:       trimmedNullString(input.Bio),
+		AvatarUrl: trimmedNullString(input.AvatarURL),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating instructor: %w", err)
+	}
+	return toInstructorModel(created), nil
+}
+
+// UpdateInstructor applies a partial update
+func (s *InstructorService) UpdateInstructor(ctx context.Context, id uuid.UUID, input models.UpdateInstructorInput) (*models.Instructor, error) {
+	existing, err := s.DB.GetInstructor(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("instructor not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving instructor: %w", err)
+	}
+
+	params := database.UpdateInstructorParams{
+		ID:        id,
+		Name:      existing.Name,
+		Bio:       existing.Bio,
+		AvatarUrl: existing.AvatarUrl,
+	}
+	if input.Name != nil {
+		if params.Name, err = normalizeInstructorName(*input.Name); err != nil {
+			return nil, err
+		}
+		if err := s.checkNameFree(ctx, params.Name, id); err != nil {
+			return nil, err
+		}
+	}
+	if input.Bio != nil {
+		params.Bio = trimmedNullString(*input.Bio)
+	}
+	if input.AvatarURL != nil {
+		params.AvatarUrl = trimmedNullString(*input.AvatarURL)
+	}
+
+	if _, err := s.DB.UpdateInstructor(ctx, params); err != nil {
+		return nil, fmt.Errorf("error updating instructor: %w", err)
+	}
+	return s.GetInstructor(ctx, id)
+}
+
+// DeleteInstructor removes an instructor from every course they were linked to
+func (s *InstructorService) DeleteInstructor(ctx context.Context, id uuid.UUID) error {
+	deleted, err := s.DB.DeleteInstructor(ctx, id)
+	if err != nil {
+		return fmt.Errorf("error deleting instructor: %w", err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("instructor not found: %w", sql.ErrNoRows)
+	}
+	return nil
+}
+
+// CourseCreator returns who created a course, for permission checks
+func (s *InstructorService) CourseCreator(ctx context.Context, courseID uuid.UUID) (uuid.UUID, error) {
+	course, err := s.DB.GetCourse(ctx, courseID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, fmt.Errorf("course not found: %w", err)
+		}
+		return uuid.Nil, fmt.Errorf("error retrieving course: %w", err)
+	}
+	return course.CreatorID.UUID, nil
+}
+
+// SetCourseInstructors replaces who teaches a course, keeping the given order
+func (s *InstructorService) SetCourseInstructors(ctx context.Context, courseID uuid.UUID, instructorIDs []uuid.UUID) ([]models.Instructor, error) {
+	seen := make(map[uuid.UUID]bool, len(instructorIDs))
+	for _, id := range instructorIDs {
+		if seen[id] {
+			return nil, fmt.Errorf("%w: instructor %s is listed twice", ErrInvalidInstructor, id)
+		}
+		seen[id] = true
+
+		if _, err := s.DB.GetInstructor(ctx, id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, fmt.Errorf("%w: instructor %s does not exist", ErrInvalidInstructor, id)
+			}
+			return nil, fmt.Errorf("error retrieving instructor: %w", err)
+		}
+	}
+
+	err := runInTx(ctx, s.Conn, s.DB, func(q *database.Queries) error {
+		if err := q.ClearCourseInstructors(ctx, courseID); err != nil {
+			return fmt.Errorf("error clearing course instructors: %w", err)
+		}
+		for i, id := range instructorIDs {
+			err := q.AddCourseInstructor(ctx, database.AddCourseInstructorParams{
+				CourseID:     courseID,
+				InstructorID: id,
+				Position:     int32(i),
+			})
+			if err != nil {
+				return fmt.Errorf("error adding course instructor: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return listCourseInstructors(ctx, s.DB, courseID)
+}
+
+// checkNameFree makes sure no other instructor (than exceptID) uses name
+func (s *InstructorService) checkNameFree(ctx context.Context, name string, exceptID uuid.UUID) error {
+	existing, err := s.DB.GetInstructorByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("error checking instructor name: %w", err)
+	}
+	if existing.ID != exceptID {
+		return ErrDuplicateInstructor
+	}
+	return nil
+}
+
+// linkImportedInstructors links the instructors a manifest named to a new course,
+// creating the ones we don't know yet. Known instructors are matched by name and left as they are.
+func linkImportedInstructors(ctx context.Context, q *database.Queries, courseID uuid.UUID, instructors []models.Instructor) error {
+	for i, instructor := range instructors {
+		name, err := normalizeInstructorName(instructor.Name)
+		if err != nil {
+			return err
+		}
+
+		existing, err := q.GetInstructorByName(ctx, name)
+		if errors.Is(err, sql.ErrNoRows) {
+			existing, err = q.CreateInstructor(ctx, database.CreateInstructorParams{
+				ID:        uuid.New(),
+				Name:      name,
+				Bio:       trimmedNullString(instructor.Bio),
+				AvatarUrl: trimmedNullString(instructor.AvatarURL),
+			})
+		}
+		if err != nil {
+			return fmt.Errorf("error saving instructor %q: %w", name, err)
+		}
+
+		err = q.AddCourseInstructor(ctx, database.AddCourseInstructorParams{
+			CourseID:     courseID,
+			InstructorID: existing.ID,
+			Position:     int32(i),
+		})
+		if err != nil {
+			return fmt.Errorf("error adding course instructor: %w", err)
+		}
+	}
+	return nil
+}
+
+// listCourseInstructors returns who teaches a course, in display order
+func listCourseInstructors(ctx context.Context, q *database.Queries, courseID uuid.UUID) ([]models.Instructor, error) {
+	dbInstructors, err := q.ListCourseInstructors(ctx, courseID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving course instructors: %w", err)
+	}
+
+	instructors := make([]models.Instructor, 0, len(dbInstructors))
+	for _, dbInstructor := range dbInstructors {
+		instructors = append(instructors, *toInstructorModel(dbInstructor))
+	}
+	return instructors, nil
+}
+
+// normalizeInstructorName trims a name and checks it's usable
+func normalizeInstructorName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("%w: name is required", ErrInvalidInstructor)
+	}
+	if len(name) > maxInstructorNameLength {
+		return "", fmt.Errorf("%w: name is longer than %d characters", ErrInvalidInstructor, maxInstructorNameLength)
+	}
+	return name, nil
+}
+
+// toInstructorModel converts the db row to the app model
+func toInstructorModel(dbInstructor database.Instructor) *models.Instructor {
+	return &models.Instructor{
+		ID:        dbInstructor.ID,
+		Name:      dbInstructor.Name,
+		Bio:       dbInstructor.Bio.String,
+		AvatarURL: dbInstructor.AvatarUrl.String,
+		CreatedAt: dbInstructor.CreatedAt,
+		UpdatedAt: dbInstructor.UpdatedAt,
+	}
+}
+
+// trimmedNullString trims a value and treats empty as NULL
+func trimmedNullString(value string) sql.NullString {
+	value = strings.TrimSpace(value)
+	return sql.NullString{String: value, Valid: value != ""}
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+)
+
+// leaderboard periods and metrics
+const (
+	LeaderboardWeek  = "week"  // since Monday
+	LeaderboardMonth = "month" // since the 1st
+	LeaderboardAll   = "all"
+
+	LeaderboardXP      = "xp"
+	LeaderboardMinutes = "minutes" // credited watch time
+
+	DefaultLeaderboardLimit = 20
+	MaxLeaderboardLimit     = 100
+)
+
+// leaderboard errors, the handler maps each to a 400
+var (
+	ErrInvalidLeaderboardPeriod = errors.New("period must be week, month or all")
+	ErrInvalidLeaderboardMetric = errors.New("metric must be xp or minutes")
+)
+
+// GetLeaderboard ranks every profile by XP earned or minutes watched in the current week,
+// month or all time. Profiles that did nothing in the period are listed with 0 at the end.
+func (s *StatsService) GetLeaderboard(ctx context.Context, period, metric string, limit, offset int) (*models.Leaderboard, error) {
+	today := activityDay(time.Now(), s.Location)
+
+	board := &models.Leaderboard{Period: period, Metric: metric, Limit: limit, Offset: offset}
+	var first time.Time
+	switch period {
+	case LeaderboardWeek:
+		first = weekStart(today)
+	case LeaderboardMonth:
+		first = today.AddDate(0, 0, 1-today.Day())
+	case LeaderboardAll:
+	default:
+		return nil, ErrInvalidLeaderboardPeriod
+	}
+
+	// the period starts at midnight in the activity zone
+	since := time.Unix(0, 0)
+	if !first.IsZero() {
+		since = time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, s.Location)
+		board.Since = &since
+	}
+
+	var entries []models.LeaderboardEntry
+
+	// one extra row tells whether there's another page
+	switch metric {
+	case LeaderboardXP:
+		// awards are stamped with now() in the database's local time
+		rows, err := s.DB.ListXPLeaderboard(ctx, database.ListXPLeaderboardParams{
+			Since:      since.In(time.Local),
+			MaxResults: int32(limit + 1),
+			Skip:       int32(offset),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving leaderboard: %w", err)
+		}
+		for _, row := range rows {
+			entries = append(entries, models.LeaderboardEntry{
+				Rank:      int(row.Rank),
+				ProfileID: row.ID,
+				Name:      row.Name,
+				Score:     int(row.Score),
+			})
+		}
+	case LeaderboardMinutes:
+		// watch sessions are in UTC
+		rows, err := s.DB.ListWatchLeaderboard(ctx, database.ListWatchLeaderboardParams{
+			Since:      since.UTC(),
+			MaxResults: int32(limit + 1),
+			Skip:       int32(offset),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving leaderboard: %w", err)
+		}
+		for _, row := range rows {
+			entries = append(entries, models.LeaderboardEntry{
+				Rank:      int(row.Rank),
+				ProfileID: row.ID,
+				Name:      row.Name,
+				Score:     minutes(time.Duration(row.Score) * time.Second),
+			})
+		}
+	default:
+		return nil, ErrInvalidLeaderboardMetric
+	}
+
+	board.HasMore = len(entries) > limit
+	board.Entries = append([]models.LeaderboardEntry{}, entries[:min(len(entries), limit)]...)
+	return board, nil
+}
@@ -0,0 +1,274 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidLearningPath is returned for missing titles, unknown courses and duplicates
+var ErrInvalidLearningPath = errors.New("invalid learning path")
+
+// LearningPathService manages ordered sequences of courses
+type LearningPathService struct {
+	DB      *database.Queries // database access
+	Conn    *sql.DB           // raw connection for transactions
+	Courses *CourseService    // for progress across the path
+}
+
+// NewLearningPathService creates service with database and course dependencies
+func NewLearningPathService(db *database.Queries, conn *sql.DB, courses *CourseService) *LearningPathService {
+	return &LearningPathService{
+		DB:      db,
+		Conn:    conn,
+		Courses: courses,
+	}
+}
+
+// ListLearningPaths returns every learning path with its courses
+func (s *LearningPathService) ListLearningPaths(ctx context.Context) ([]*models.LearningPath, error) {
+	dbPaths, err := s.DB.ListLearningPaths(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving learning paths: %w", err)
+	}
+
+	steps, err := s.DB.ListAllLearningPathCourses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving learning path courses: %w", err)
+	}
+
+	coursesByPath := make(map[uuid.UUID][]models.LearningPathCourse)
+	for _, step := range steps {
+		coursesByPath[step.PathID] = append(coursesByPath[step.PathID], models.LearningPathCourse{
+			CourseID: step.CourseID,
+			Title:    step.Title,
+			Position: len(coursesByPath[step.PathID]) + 1,
+		})
+	}
+
+	paths := make([]*models.LearningPath, 0, len(dbPaths))
+	for _, dbPath := range dbPaths {
+		path := toLearningPathModel(dbPath)
+		if courses, ok := coursesByPath[dbPath.ID]; ok {
+			path.Courses = courses
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// GetLearningPath returns one learning path with its courses in order
+func (s *LearningPathService) GetLearningPath(ctx context.Context, id uuid.UUID) (*models.LearningPath, error) {
+	dbPath, err := s.DB.GetLearningPath(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("learning path not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving learning path: %w", err)
+	}
+
+	steps, err := s.DB.ListLearningPathCourses(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving learning path courses: %w", err)
+	}
+
+	path := toLearningPathModel(dbPath)
+	for i, step := range steps {
+		path.Courses = append(path.Courses, models.LearningPathCourse{
+			CourseID: step.CourseID,
+			Title:    step.Title,
+			Position: i + 1,
+		})
+	}
+	return path, nil
+}
+
+// CreateLearningPath creates a path owned by creatorID
+func (s *LearningPathService) CreateLearningPath(ctx context.Context, creatorID uuid.UUID, input models.CreateLearningPathInput) (*models.LearningPath, error) {
+	title := strings.TrimSpace(input.Title)
+	if title == "" {
+		return nil, fmt.Errorf("%w: title is required", ErrInvalidLearningPath)
+	}
+
+	if err := s.checkCourses(ctx, input.CourseIDs); err != nil {
+		return nil, err
+	}
+
+	pathID := uuid.New()
+	err := runInTx(ctx, s.Conn, s.DB, func(q *database.Queries) error {
+		_, err := q.CreateLearningPath(ctx, database.CreateLearningPathParams{
+			ID:          pathID,
+			Title:       title,
+			Description: sql.NullString{String: input.Description, Valid: input.Description != ""},
+			CreatorID:   toNullUUID(creatorID),
+		})
+		if err != nil {
+			return fmt.Errorf("error creating learning path: %w", err)
+		}
+		return setPathCourses(ctx, q, pathID, input.CourseIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetLearningPath(ctx, pathID)
+}
+
+// UpdateLearningPath applies a partial update, replacing the course sequence if given
+func (s *LearningPathService) UpdateLearningPath(ctx context.Context, id uuid.UUID, input models.UpdateLearningPathInput) (*models.LearningPath, error) {
+	existing, err := s.DB.GetLearningPath(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("learning path not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving learning path: %w", err)
+	}
+
+	params := database.UpdateLearningPathParams{
+		ID:          id,
+		Title:       existing.Title,
+		Description: existing.Description,
+	}
+	if input.Title != nil {
+		params.Title = strings.TrimSpace(*input.Title)
+		if params.Title == "" {
+			return nil, fmt.Errorf("%w: title can't be empty", ErrInvalidLearningPath)
+		}
+	}
+	if input.Description != nil {
+		params.Description = sql.NullString{String: *input.Description, Valid: *input.Description != ""}
+	}
+
+	if input.CourseIDs != nil {
+		if err := s.checkCourses(ctx, *input.CourseIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	err = runInTx(ctx, s.Conn, s.DB, func(q *database.Queries) error {
+		if _, err := q.UpdateLearningPath(ctx, params); err != nil {
+			return fmt.Errorf("error updating learning path: %w", err)
+		}
+		if input.CourseIDs == nil {
+			return nil
+		}
+		if err := q.ClearLearningPathCourses(ctx, id); err != nil {
+			return fmt.Errorf("error clearing learning path courses: %w", err)
+		}
+		return setPathCourses(ctx, q, id, *input.CourseIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetLearningPath(ctx, id)
+}
+
+// DeleteLearningPath removes a path - the courses themselves are untouched
+func (s *LearningPathService) DeleteLearningPath(ctx context.Context, id uuid.UUID) error {
+	deleted, err := s.DB.DeleteLearningPath(ctx, id)
+	if err != nil {
+		return fmt.Errorf("error deleting learning path: %w", err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("learning path not found: %w", sql.ErrNoRows)
+	}
+	return nil
+}
+
+// GetProgress aggregates a profile's progress over every course of the path
+func (s *LearningPathService) GetProgress(ctx context.Context, pathID, userID uuid.UUID) (*models.LearningPathProgress, error) {
+	path, err := s.GetLearningPath(ctx, pathID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.LearningPathProgress{
+		PathID:       pathID,
+		UserID:       userID,
+		TotalCourses: len(path.Courses),
+		Courses:      []*models.CourseProgress{},
+	}
+
+	courseIDs := make([]uuid.UUID, 0, len(path.Courses))
+	for _, step := range path.Courses {
+		courseIDs = append(courseIDs, step.CourseID)
+	}
+	courses, err := s.Courses.CalculateCoursesProgress(ctx, userID, courseIDs, s.Courses.Weighting)
+	if err != nil {
+		return nil, fmt.Errorf("error calculating course progress: %w", err)
+	}
+
+	for _, step := range path.Courses {
+		progress := courses[step.CourseID]
+		result.Courses = append(result.Courses, progress)
+		result.CompletedItems += progress.CompletedItems
+		result.TotalItems += progress.TotalItems
+		if progress.IsCompleted {
+			result.CompletedCourses++
+		} else if result.NextCourseID == nil {
+			next := step.CourseID
+			result.NextCourseID = &next
+		}
+	}
+
+	if result.TotalItems > 0 {
+		result.CompletionPct = float32(result.CompletedItems) / float32(result.TotalItems) * 100
+	}
+	result.IsCompleted = result.TotalCourses > 0 && result.CompletedCourses == result.TotalCourses
+
+	return result, nil
+}
+
+// checkCourses makes sure every course exists and appears only once
+func (s *LearningPathService) checkCourses(ctx context.Context, courseIDs []uuid.UUID) error {
+	seen := make(map[uuid.UUID]bool, len(courseIDs))
+	for _, courseID := range courseIDs {
+		if seen[courseID] {
+			return fmt.Errorf("%w: course %s is listed twice", ErrInvalidLearningPath, courseID)
+		}
+		seen[courseID] = true
+
+		if _, err := s.DB.GetCourse(ctx, courseID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("%w: course %s does not exist", ErrInvalidLearningPath, courseID)
+			}
+			return fmt.Errorf("error retrieving course: %w", err)
+		}
+	}
+	return nil
+}
+
+// setPathCourses writes the course sequence of a path, positions follow the slice order
+func setPathCourses(ctx context.Context, q *database.Queries, pathID uuid.UUID, courseIDs []uuid.UUID) error {
+	for i, courseID := range courseIDs {
+		err := q.AddLearningPathCourse(ctx, database.AddLearningPathCourseParams{
+			PathID:   pathID,
+			CourseID: courseID,
+			Position: int32(i + 1),
+		})
+		if err != nil {
+			return fmt.Errorf("error adding course to learning path: %w", err)
+		}
+	}
+	return nil
+}
+
+// toLearningPathModel converts a database learning path to the API model, without courses
+func toLearningPathModel(dbPath database.LearningPath) *models.LearningPath {
+	return &models.LearningPath{
+		ID:          dbPath.ID,
+		Title:       dbPath.Title,
+		Description: dbPath.Description.String,
+		CreatorID:   dbPath.CreatorID.UUID,
+		Courses:     []models.LearningPathCourse{},
+		CreatedAt:   dbPath.CreatedAt,
+		UpdatedAt:   dbPath.UpdatedAt,
+	}
+}
@@ -0,0 +1,258 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidModuleOrder is returned when a reorder list doesn't match the course's modules
+var ErrInvalidModuleOrder = errors.New("invalid module order")
+
+// ModuleService handles editing modules after import
+type ModuleService struct {
+	DB   *database.Queries // database access
+	Conn *sql.DB           // raw connection for transactions
+}
+
+// NewModuleService creates service with database dependencies
+func NewModuleService(db *database.Queries, conn *sql.DB) *ModuleService {
+	return &ModuleService{
+		DB:   db,
+		Conn: conn,
+	}
+}
+
+// UpdateModule changes a module's title and/or description
+func (s *ModuleService) UpdateModule(ctx context.Context, moduleID uuid.UUID, input models.UpdateModuleInput) (*models.Module, error) {
+	existing, err := s.DB.GetModule(ctx, moduleID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("module not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving module: %w", err)
+	}
+
+	title := existing.Title
+	if input.Title != nil {
+		title = strings.TrimSpace(*input.Title)
+		if title == "" {
+			return nil, errors.New("module title cannot be empty")
+		}
+	}
+
+	description := existing.Description
+	if input.Description != nil {
+		trimmed := strings.TrimSpace(*input.Description)
+		description = sql.NullString{String: trimmed, Valid: trimmed != ""}
+	}
+
+	updated, err := s.DB.UpdateModule(ctx, database.UpdateModuleParams{
+		ID:          moduleID,
+		Title:       title,
+		Description: description,
+		Order:       existing.Order,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error updating module: %w", err)
+	}
+
+	return toModuleModel(updated), nil
+}
+
+// ReorderModules sets the order of a course's modules in one transaction
+// moduleIDs must contain every module of the course exactly once.
+func (s *ModuleService) ReorderModules(ctx context.Context, courseID uuid.UUID, moduleIDs []uuid.UUID) ([]*models.Module, error) {
+	if _, err := s.DB.GetCourse(ctx, courseID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("course not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving course: %w", err)
+	}
+
+	err := runInTx(ctx, s.Conn, s.DB, func(q *database.Queries) error {
+		current, err := q.ListModulesByCourse(ctx, courseID)
+		if err != nil {
+			return fmt.Errorf("error retrieving modules: %w", err)
+		}
+
+		if len(moduleIDs) != len(current) {
+			return fmt.Errorf("%w: expected %d module IDs, got %d", ErrInvalidModuleOrder, len(current), len(moduleIDs))
+		}
+
+		belongs := make(map[uuid.UUID]bool, len(current))
+		for _, module := range current {
+			belongs[module.ID] = true
+		}
+
+		seen := make(map[uuid.UUID]bool, len(moduleIDs))
+		for _, id := range moduleIDs {
+			if !belongs[id] {
+				return fmt.Errorf("%w: module %s is not part of this course", ErrInvalidModuleOrder, id)
+			}
+			if seen[id] {
+				return fmt.Errorf("%w: module %s listed twice", ErrInvalidModuleOrder, id)
+			}
+			seen[id] = true
+		}
+
+		for i, id := range moduleIDs {
+			if err := q.UpdateModuleOrder(ctx, database.UpdateModuleOrderParams{
+				ID:    id,
+				Order: int32(i),
+			}); err != nil {
+				return fmt.Errorf("error updating module order: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reordered, err := s.DB.ListModulesByCourse(ctx, courseID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving modules: %w", err)
+	}
+
+	modules := make([]*models.Module, 0, len(reordered))
+	for _, module := range reordered {
+		modules = append(modules, toModuleModel(module))
+	}
+	return modules, nil
+}
+
+// MergeModules folds two or more modules of a course into the first one listed
+// Content items keep their IDs, so progress records carry over untouched.
+func (s *ModuleService) MergeModules(ctx context.Context, courseID uuid.UUID, input models.MergeModulesInput) (*models.Module, error) {
+	if len(input.ModuleIDs) < 2 {
+		return nil, fmt.Errorf("%w: at least two modules are needed to merge", ErrInvalidModuleOrder)
+	}
+
+	if _, err := s.DB.GetCourse(ctx, courseID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("course not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving course: %w", err)
+	}
+
+	targetID := input.ModuleIDs[0]
+	err := runInTx(ctx, s.Conn, s.DB, func(q *database.Queries) error {
+		current, err := q.ListModulesByCourse(ctx, courseID)
+		if err != nil {
+			return fmt.Errorf("error retrieving modules: %w", err)
+		}
+
+		belongs := make(map[uuid.UUID]database.Module, len(current))
+		for _, module := range current {
+			belongs[module.ID] = module
+		}
+
+		seen := make(map[uuid.UUID]bool, len(input.ModuleIDs))
+		for _, id := range input.ModuleIDs {
+			if _, ok := belongs[id]; !ok {
+				return fmt.Errorf("%w: module %s is not part of this course", ErrInvalidModuleOrder, id)
+			}
+			if seen[id] {
+				return fmt.Errorf("%w: module %s listed twice", ErrInvalidModuleOrder, id)
+			}
+			seen[id] = true
+		}
+
+		targetItems, err := q.ListContentItemsByModule(ctx, targetID)
+		if err != nil {
+			return fmt.Errorf("error retrieving content items: %w", err)
+		}
+		nextOrder := int32(len(targetItems))
+
+		// move items across before deleting, the delete cascades to anything left behind
+		for _, sourceID := range input.ModuleIDs[1:] {
+			items, err := q.ListContentItemsByModule(ctx, sourceID)
+			if err != nil {
+				return fmt.Errorf("error retrieving content items: %w", err)
+			}
+			for _, item := range items {
+				if err := q.MoveContentItem(ctx, database.MoveContentItemParams{
+					ID:       item.ID,
+					ModuleID: targetID,
+					Order:    nextOrder,
+				}); err != nil {
+					return fmt.Errorf("error moving content item: %w", err)
+				}
+				nextOrder++
+			}
+
+			if err := q.DeleteModule(ctx, sourceID); err != nil {
+				return fmt.Errorf("error deleting merged module: %w", err)
+			}
+		}
+
+		if title := strings.TrimSpace(input.Title); title != "" {
+			target := belongs[targetID]
+			if _, err := q.UpdateModule(ctx, database.UpdateModuleParams{
+				ID:          targetID,
+				Title:       title,
+				Description: target.Description,
+				Order:       target.Order,
+			}); err != nil {
+				return fmt.Errorf("error renaming merged module: %w", err)
+			}
+		}
+
+		// close the gaps the deleted modules left in the course order
+		remaining, err := q.ListModulesByCourse(ctx, courseID)
+		if err != nil {
+			return fmt.Errorf("error retrieving modules: %w", err)
+		}
+		for i, module := range remaining {
+			if module.Order == int32(i) {
+				continue
+			}
+			if err := q.UpdateModuleOrder(ctx, database.UpdateModuleOrderParams{
+				ID:    module.ID,
+				Order: int32(i),
+			}); err != nil {
+				return fmt.Errorf("error updating module order: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	merged, err := s.DB.GetModule(ctx, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving merged module: %w", err)
+	}
+
+	module := toModuleModel(merged)
+	items, err := s.DB.ListContentItemsByModule(ctx, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving content items: %w", err)
+	}
+	for _, item := range items {
+		module.ContentItems = append(module.ContentItems, toContentItemModel(item))
+	}
+	return module, nil
+}
+
+// toModuleModel converts a database module to the API model
+func toModuleModel(dbModule database.Module) *models.Module {
+	return &models.Module{
+		ID:           dbModule.ID,
+		CourseID:     dbModule.CourseID,
+		Title:        dbModule.Title,
+		Description:  dbModule.Description.String,
+		RelativePath: dbModule.RelativePath,
+		Order:        int(dbModule.Order),
+		CreatedAt:    dbModule.CreatedAt,
+		UpdatedAt:    dbModule.UpdatedAt,
+	}
+}
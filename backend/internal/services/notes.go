@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// maxNoteLength keeps notes from turning into file storage
+const maxNoteLength = 100000
+
+// NoteService handles notes profiles keep on their courses and content items
+type NoteService struct {
+	DB         *database.Queries  // database access
+	Visibility *VisibilityService // restricted profiles only see assigned courses
+}
+
+// NewNoteService creates service with its dependencies
+func NewNoteService(db *database.Queries, visibility *VisibilityService) *NoteService {
+	return &NoteService{
+		DB:         db,
+		Visibility: visibility,
+	}
+}
+
+// GetCourseNote returns the user's note for a course, or nil if they haven't written one
+func (s *NoteService) GetCourseNote(ctx context.Context, userID, courseID uuid.UUID) (*models.CourseNote, error) {
+	dbNote, err := s.DB.GetCourseNote(ctx, database.GetCourseNoteParams{
+		CourseID: courseID,
+		UserID:   userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error retrieving course note: %w", err)
+	}
+
+	return toCourseNoteModel(dbNote), nil
+}
+
+// SaveCourseNote creates or replaces the user's note for a course
+func (s *NoteService) SaveCourseNote(ctx context.Context, userID, courseID uuid.UUID, content string) (*models.CourseNote, error) {
+	if strings.TrimSpace(content) == "" {
+		return nil, errors.New("note content cannot be empty")
+	}
+	if len(content) > maxNoteLength {
+		return nil, fmt.Errorf("note content cannot be longer than %d characters", maxNoteLength)
+	}
+
+	dbNote, err := s.DB.UpsertCourseNote(ctx, database.UpsertCourseNoteParams{
+		CourseID: courseID,
+		UserID:   userID,
+		Content:  content,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error saving course note: %w", err)
+	}
+
+	return toCourseNoteModel(dbNote), nil
+}
+
+// DeleteCourseNote removes the user's note for a course
+func (s *NoteService) DeleteCourseNote(ctx context.Context, userID, courseID uuid.UUID) error {
+	err := s.DB.DeleteCourseNote(ctx, database.DeleteCourseNoteParams{
+		CourseID: courseID,
+		UserID:   userID,
+	})
+	if err != nil {
+		return fmt.Errorf("error deleting course note: %w", err)
+	}
+	return nil
+}
+
+// AttachCourseNotes fills in Note on each course for the given user with a single query
+func (s *NoteService) AttachCourseNotes(ctx context.Context, userID uuid.UUID, courses []*models.Course) error {
+	dbNotes, err := s.DB.ListCourseNotesByUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("error retrieving course notes: %w", err)
+	}
+
+	notesByCourse := make(map[uuid.UUID]*models.CourseNote, len(dbNotes))
+	for _, dbNote := range dbNotes {
+		notesByCourse[dbNote.CourseID] = toCourseNoteModel(dbNote)
+	}
+
+	for _, course := range courses {
+		course.Note = notesByCourse[course.ID]
+	}
+	return nil
+}
+
+// toCourseNoteModel converts the db row to the app model
+func toCourseNoteModel(dbNote database.CourseNote) *models.CourseNote {
+	return &models.CourseNote{
+		ID:        dbNote.ID,
+		CourseID:  dbNote.CourseID,
+		UserID:    dbNote.UserID,
+		Content:   dbNote.Content,
+		CreatedAt: dbNote.CreatedAt,
+		UpdatedAt: dbNote.UpdatedAt,
+	}
+}
@@ -0,0 +1,244 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/events"
+	"github.com/NeroQue/course-management-backend/pkg/notify"
+	"github.com/google/uuid"
+)
+
+// NotificationService queues events and delivers them according to each profile's digest settings
+type NotificationService struct {
+	DB     *database.Queries // database access
+	Sender notify.Sender     // where messages go
+}
+
+// NewNotificationService creates service with db dependency and a sender
+func NewNotificationService(db *database.Queries, sender notify.Sender) *NotificationService {
+	return &NotificationService{
+		DB:     db,
+		Sender: sender,
+	}
+}
+
+// Notify queues an event for a profile and sends it right away if they want it immediately
+func (s *NotificationService) Notify(ctx context.Context, profileID uuid.UUID, category, title, body string) error {
+	if !slices.Contains(models.NotificationCategories, category) {
+		return fmt.Errorf("unknown notification category: %s", category)
+	}
+
+	created, err := s.DB.CreateNotification(ctx, database.CreateNotificationParams{
+		ProfileID: profileID,
+		Category:  category,
+		Title:     title,
+		Body:      sql.NullString{String: body, Valid: body != ""},
+	})
+	if err != nil {
+		return fmt.Errorf("error queueing notification: %w", err)
+	}
+
+	preferences, err := s.GetPreferences(ctx, profileID)
+	if err != nil {
+		return err
+	}
+
+	for _, preference := range preferences {
+		if preference.Category == category && preference.Frequency == notify.Immediate {
+			return s.deliver(ctx, profileID, category, notify.Immediate, []database.Notification{created})
+		}
+	}
+	return nil // waits for the next digest
+}
+
+// NotifyCourseCompleted sends a progress notification for a course.completed event
+func (s *NotificationService) NotifyCourseCompleted(ctx context.Context, event events.Event) error {
+	return s.Notify(ctx, event.ProfileID, models.NotifyProgress, "Course completed", "You finished "+event.Title)
+}
+
+// NotifyLevelUp sends a progress notification for a level.up event
+func (s *NotificationService) NotifyLevelUp(ctx context.Context, event events.Event) error {
+	return s.Notify(ctx, event.ProfileID, models.NotifyProgress, "Level up", "You reached level "+strconv.Itoa(event.Level))
+}
+
+// GetPreferences returns the frequency for every category, defaulting to immediate
+func (s *NotificationService) GetPreferences(ctx context.Context, profileID uuid.UUID) ([]models.NotificationPreference, error) {
+	rows, err := s.DB.ListNotificationPreferences(ctx, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving notification preferences: %w", err)
+	}
+
+	stored := make(map[string]database.NotificationPreference, len(rows))
+	for _, row := range rows {
+		stored[row.Category] = row
+	}
+
+	preferences := make([]models.NotificationPreference, 0, len(models.NotificationCategories))
+	for _, category := range models.NotificationCategories {
+		preference := models.NotificationPreference{Category: category, Frequency: notify.Immediate}
+		if row, ok := stored[category]; ok {
+			preference.Frequency = row.Frequency
+			preference.LastDigestAt = row.LastDigestAt
+		}
+		preferences = append(preferences, preference)
+	}
+	return preferences, nil
+}
+
+// SetPreferences updates the frequency of the given categories
+func (s *NotificationService) SetPreferences(ctx context.Context, profileID uuid.UUID, input models.SetNotificationPreferencesInput) ([]models.NotificationPreference, error) {
+	for category, frequency := range input.Preferences {
+		if !slices.Contains(models.NotificationCategories, category) {
+			return nil, fmt.Errorf("unknown notification category: %s", category)
+		}
+		if !notify.ValidFrequency(frequency) {
+			return nil, fmt.Errorf("frequency for %s must be immediate, daily or weekly", category)
+		}
+	}
+
+	for category, frequency := range input.Preferences {
+		_, err := s.DB.UpsertNotificationPreference(ctx, database.UpsertNotificationPreferenceParams{
+			ProfileID: profileID,
+			Category:  category,
+			Frequency: frequency,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error saving notification preference: %w", err)
+		}
+	}
+
+	return s.GetPreferences(ctx, profileID)
+}
+
+// ListNotifications returns a profile's most recent notifications, delivered or still pending
+func (s *NotificationService) ListNotifications(ctx context.Context, profileID uuid.UUID, limit int) ([]models.Notification, error) {
+	rows, err := s.DB.ListNotificationsByProfile(ctx, database.ListNotificationsByProfileParams{
+		ProfileID: profileID,
+		Limit:     int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving notifications: %w", err)
+	}
+
+	notifications := make([]models.Notification, 0, len(rows))
+	for _, row := range rows {
+		notifications = append(notifications, models.Notification{
+			ID:          row.ID,
+			Category:    row.Category,
+			Title:       row.Title,
+			Body:        row.Body.String,
+			DeliveredAt: row.DeliveredAt,
+			CreatedAt:   row.CreatedAt,
+		})
+	}
+	return notifications, nil
+}
+
+// DispatchDigests sends every pending batch whose digest is due and returns how many messages went out
+func (s *NotificationService) DispatchDigests(ctx context.Context, now time.Time) (int, error) {
+	pending, err := s.DB.ListPendingNotifications(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error retrieving pending notifications: %w", err)
+	}
+
+	// rows are sorted by profile and category, so batches are contiguous
+	sent := 0
+	preferences := make(map[uuid.UUID][]models.NotificationPreference)
+	for start := 0; start < len(pending); {
+		end := start + 1
+		for end < len(pending) && pending[end].ProfileID == pending[start].ProfileID &&
+			pending[end].Category == pending[start].Category {
+			end++
+		}
+		batch := pending[start:end]
+		start = end
+
+		profileID, category := batch[0].ProfileID, batch[0].Category
+		if _, ok := preferences[profileID]; !ok {
+			profilePreferences, err := s.GetPreferences(ctx, profileID)
+			if err != nil {
+				return sent, err
+			}
+			preferences[profileID] = profilePreferences
+		}
+
+		frequency, lastSent := notify.Immediate, time.Time{}
+		for _, preference := range preferences[profileID] {
+			if preference.Category == category {
+				frequency = preference.Frequency
+				lastSent = preference.LastDigestAt.Time
+			}
+		}
+
+		if !notify.DigestDue(frequency, lastSent, now) {
+			continue
+		}
+
+		if err := s.deliver(ctx, profileID, category, frequency, batch); err != nil {
+			log.Printf("Warning: could not deliver %s digest to %s: %v", category, profileID, err)
+			continue
+		}
+		sent++
+	}
+
+	return sent, nil
+}
+
+// DispatcherRoutine checks for due digests every interval - meant to run in its own goroutine
+func (s *NotificationService) DispatcherRoutine(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if _, err := s.DispatchDigests(context.Background(), time.Now()); err != nil {
+			log.Printf("Warning: notification dispatch failed: %v", err)
+		}
+	}
+}
+
+// deliver hands a batch to the sender and marks it delivered
+func (s *NotificationService) deliver(ctx context.Context, profileID uuid.UUID, category, frequency string, batch []database.Notification) error {
+	message := notify.Message{
+		ProfileID: profileID,
+		Category:  category,
+		Frequency: frequency,
+	}
+	ids := make([]uuid.UUID, 0, len(batch))
+	for _, notification := range batch {
+		message.Items = append(message.Items, notify.Item{
+			Title:     notification.Title,
+			Body:      notification.Body.String,
+			CreatedAt: notification.CreatedAt.Time,
+		})
+		ids = append(ids, notification.ID)
+	}
+
+	if err := s.Sender.Send(ctx, message); err != nil {
+		return fmt.Errorf("error sending notification: %w", err)
+	}
+
+	if err := s.DB.MarkNotificationsDelivered(ctx, ids); err != nil {
+		return fmt.Errorf("error marking notifications delivered: %w", err)
+	}
+
+	if frequency != notify.Immediate {
+		err := s.DB.MarkDigestSent(ctx, database.MarkDigestSentParams{
+			ProfileID:    profileID,
+			Category:     category,
+			Frequency:    frequency,
+			LastDigestAt: sql.NullTime{Time: time.Now(), Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("error recording digest time: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/secret"
+	"github.com/NeroQue/course-management-backend/pkg/tiering"
+	"github.com/NeroQue/course-management-backend/pkg/util"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidOfflineURL is returned for a download URL that was tampered with or has expired
+var ErrInvalidOfflineURL = errors.New("offline download link is invalid or has expired")
+
+// OfflineService builds download manifests for studying a course offline and checks the
+// signed URLs in them. URLs carry their own signature, so a download client doesn't need
+// the session of the profile that asked for the manifest.
+type OfflineService struct {
+	DB         *database.Queries  // database access
+	Tiering    *TieringService    // cold files come back when they're downloaded
+	Visibility *VisibilityService // restricted profiles only see assigned courses
+	Key        []byte             // signs download URLs, OFFLINE_URL_SECRET
+	TTL        time.Duration      // how long a manifest's URLs work
+}
+
+// NewOfflineService creates service with its dependencies. Without OFFLINE_URL_SECRET a
+// random key is used, which means download URLs stop working when the server restarts.
+func NewOfflineService(db *database.Queries, tiering *TieringService, visibility *VisibilityService) *OfflineService {
+	key := []byte(os.Getenv("OFFLINE_URL_SECRET"))
+	if len(key) == 0 {
+		token, err := secret.NewToken(32)
+		if err != nil {
+			log.Fatalf("Failed to generate offline URL key: %v", err)
+		}
+		key = []byte(token)
+		log.Printf("Warning: OFFLINE_URL_SECRET is not set, offline download URLs won't survive a restart")
+	}
+
+	return &OfflineService{
+		DB:         db,
+		Tiering:    tiering,
+		Visibility: visibility,
+		Key:        key,
+		TTL:        util.GetEnvDuration("OFFLINE_URL_TTL", 72*time.Hour),
+	}
+}
+
+// GetManifest lists a course's content files with sizes, SHA-256 checksums and signed
+// download URLs. Checksums are computed on first use and kept until the file changes,
+// so the first manifest of a big course takes a while.
+func (s *OfflineService) GetManifest(ctx context.Context, courseID, profileID uuid.UUID) (*models.OfflineManifest, error) {
+	course, err := s.DB.GetCourse(ctx, courseID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("course not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving course: %w", err)
+	}
+
+	visible, err := s.Visibility.CanSeeCourse(ctx, profileID, courseID)
+	if err != nil {
+		return nil, fmt.Errorf("error checking course visibility: %w", err)
+	}
+	if !visible {
+		return nil, ErrContentNotVisible
+	}
+
+	items, err := s.DB.ListCourseContentItems(ctx, courseID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving content items: %w", err)
+	}
+
+	rows, err := s.DB.ListCourseContentChecksums(ctx, courseID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving checksums: %w", err)
+	}
+	checksums := make(map[uuid.UUID]database.ContentChecksum, len(rows))
+	for _, row := range rows {
+		checksums[row.ContentItemID] = row
+	}
+
+	now := time.Now()
+	manifest := &models.OfflineManifest{
+		CourseID:    courseID,
+		Title:       course.Title,
+		GeneratedAt: now,
+		ExpiresAt:   now.Add(s.TTL),
+		Files:       []models.OfflineFile{},
+	}
+
+	for _, item := range items {
+		file := models.OfflineFile{
+			ContentItemID: item.ID,
+			ModuleID:      item.ModuleID,
+			Title:         item.Title,
+			ContentType:   item.ContentType,
+			Path:          coursePath(course.RelativePath, item.RelativePath),
+			Size:          item.Size.Int64,
+			URL:           s.signedURL(item.ID, profileID, manifest.ExpiresAt),
+		}
+
+		sum, size, err := s.checksum(ctx, item, checksums[item.ID])
+		if err != nil {
+			return nil, err
+		}
+		file.SHA256 = sum
+		if size > 0 {
+			file.Size = size
+		}
+
+		manifest.TotalBytes += file.Size
+		manifest.Files = append(manifest.Files, file)
+	}
+	return manifest, nil
+}
+
+// checksum returns the SHA-256 and size of an item's file, from the stored checksum
+// when the file hasn't changed since. Cold files aren't restored just to hash them -
+// they get the stored checksum if there is one, or none.
+func (s *OfflineService) checksum(ctx context.Context, item database.ContentItem, stored database.ContentChecksum) (string, int64, error) {
+	if state, err := s.DB.GetContentTiering(ctx, item.ID); err == nil && state.Tier == tiering.Cold {
+		return stored.Sha256, stored.Size, nil
+	}
+
+	path, err := resolveContentPath(item.RelativePath)
+	if err != nil {
+		return "", 0, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		// a file gone missing shouldn't take the whole manifest down, the download will 404
+		log.Printf("Warning: content file %s is not available for the offline manifest: %v", item.RelativePath, err)
+		return "", 0, nil
+	}
+
+	modified := info.ModTime().UnixNano()
+	if stored.Sha256 != "" && stored.Size == info.Size() && stored.ModifiedNs == modified {
+		return stored.Sha256, stored.Size, nil
+	}
+
+	sum, err := hashFile(path)
+	if err != nil {
+		return "", 0, fmt.Errorf("error hashing %s: %w", item.RelativePath, err)
+	}
+
+	err = s.DB.UpsertContentChecksum(ctx, database.UpsertContentChecksumParams{
+		ContentItemID: item.ID,
+		Size:          info.Size(),
+		ModifiedNs:    modified,
+		Sha256:        sum,
+	})
+	if err != nil {
+		return "", 0, fmt.Errorf("error saving checksum: %w", err)
+	}
+	return sum, info.Size(), nil
+}
+
+// signedURL builds the download URL of a content item for a profile, valid until expires
+func (s *OfflineService) signedURL(itemID, profileID uuid.UUID, expires time.Time) string {
+	expiry := strconv.FormatInt(expires.Unix(), 10)
+	query := url.Values{
+		"profile": {profileID.String()},
+		"expires": {expiry},
+		"sig":     {secret.Sign(s.Key, itemID.String(), profileID.String(), expiry)},
+	}
+	return "/api/offline/" + itemID.String() + "?" + query.Encode()
+}
+
+// verifySignedURL checks the query of a download URL from signedURL and returns the
+// profile it was signed for
+func (s *OfflineService) verifySignedURL(itemID uuid.UUID, profile, expires, signature string, now time.Time) (uuid.UUID, error) {
+	if !secret.Verify(s.Key, signature, itemID.String(), profile, expires) {
+		return uuid.Nil, ErrInvalidOfflineURL
+	}
+	expiry, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || now.Unix() > expiry {
+		return uuid.Nil, ErrInvalidOfflineURL
+	}
+	profileID, err := uuid.Parse(profile)
+	if err != nil {
+		return uuid.Nil, ErrInvalidOfflineURL
+	}
+	return profileID, nil
+}
+
+// OpenSignedContent checks a signed download URL and returns the file it points at,
+// restoring it from cold storage if needed. The profile still has to be able to see
+// the course, so taking a course away also stops its downloads.
+func (s *OfflineService) OpenSignedContent(ctx context.Context, itemID uuid.UUID, profile, expires, signature string) (*models.StreamFile, error) {
+	profileID, err := s.verifySignedURL(itemID, profile, expires, signature, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := s.DB.GetContentItemLocation(ctx, itemID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("content item not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving content item: %w", err)
+	}
+
+	visible, err := s.Visibility.CanSeeCourse(ctx, profileID, location.CourseID)
+	if err != nil {
+		return nil, fmt.Errorf("error checking course visibility: %w", err)
+	}
+	if !visible {
+		return nil, ErrContentNotVisible
+	}
+
+	path, err := resolveContentPath(location.RelativePath)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Tiering.RecordAccess(ctx, itemID); err != nil {
+		return nil, fmt.Errorf("error preparing content for download: %w", err)
+	}
+
+	return &models.StreamFile{
+		ContentItemID: itemID,
+		CourseID:      location.CourseID,
+		ContentType:   location.ContentType,
+		Path:          path,
+		Filename:      filepath.Base(location.RelativePath),
+	}, nil
+}
+
+// coursePath returns where a content file sits inside its course folder
+func coursePath(courseRelative, itemRelative string) string {
+	rel, err := filepath.Rel(courseRelative, itemRelative)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		rel = filepath.Base(itemRelative)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// hashFile returns the hex SHA-256 of a whole file
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
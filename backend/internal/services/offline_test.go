@@ -0,0 +1,82 @@
+package services
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/pkg/secret"
+	"github.com/google/uuid"
+)
+
+func TestVerifySignedURL(t *testing.T) {
+	s := &OfflineService{Key: []byte("offline-test-key-offline-test-key")}
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	itemID, profileID := uuid.New(), uuid.New()
+	expires := now.Add(time.Hour)
+
+	signed, err := url.Parse(s.signedURL(itemID, profileID, expires))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signed.Path != "/api/offline/"+itemID.String() {
+		t.Fatalf("signed URL has path %s", signed.Path)
+	}
+	query := signed.Query()
+	profile, expiry, sig := query.Get("profile"), query.Get("expires"), query.Get("sig")
+
+	otherKey := (&OfflineService{Key: []byte("another-key-another-key-another-k")}).signedURL(itemID, profileID, expires)
+	otherSig, _ := url.Parse(otherKey)
+	later := strconv.FormatInt(expires.Add(24*time.Hour).Unix(), 10)
+
+	tests := []struct {
+		name    string
+		itemID  uuid.UUID
+		profile string
+		expires string
+		sig     string
+		at      time.Time
+		valid   bool
+	}{
+		{"valid", itemID, profile, expiry, sig, now, true},
+		{"valid at its expiry", itemID, profile, expiry, sig, expires, true},
+		{"expired", itemID, profile, expiry, sig, expires.Add(time.Second), false},
+		{"other item", uuid.New(), profile, expiry, sig, now, false},
+		{"other profile", itemID, uuid.New().String(), expiry, sig, now, false},
+		{"extended expiry", itemID, profile, later, sig, now, false},
+		{"changed signature", itemID, profile, expiry, flipFirst(sig), now, false},
+		{"no signature", itemID, profile, expiry, "", now, false},
+		{"signed with another key", itemID, profile, expiry, otherSig.Query().Get("sig"), now, false},
+		{"separator inside a field", itemID, profile + "\n" + expiry, "", secret.Sign(s.Key, itemID.String(), profile, expiry), now, false},
+		{"signed but not a number", itemID, profile, "soon", secret.Sign(s.Key, itemID.String(), profile, "soon"), now, false},
+		{"signed but not a profile", itemID, "admin", expiry, secret.Sign(s.Key, itemID.String(), "admin", expiry), now, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.verifySignedURL(tt.itemID, tt.profile, tt.expires, tt.sig, tt.at)
+			if !tt.valid {
+				if !errors.Is(err, ErrInvalidOfflineURL) {
+					t.Fatalf("got %v, want ErrInvalidOfflineURL", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("valid URL refused: %v", err)
+			}
+			if got != profileID {
+				t.Errorf("got profile %s, want %s", got, profileID)
+			}
+		})
+	}
+}
+
+// flipFirst changes the first character of a base64url string to another valid one
+func flipFirst(s string) string {
+	if s[0] == 'A' {
+		return "B" + s[1:]
+	}
+	return "A" + s[1:]
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/oidc"
+	"github.com/NeroQue/course-management-backend/pkg/secret"
+	"github.com/NeroQue/course-management-backend/pkg/util"
+	"github.com/google/uuid"
+)
+
+// OIDC login errors
+var (
+	ErrOIDCDisabled    = errors.New("OIDC login is not configured")
+	ErrIdentityLinked  = errors.New("this account is already linked to another profile")
+	ErrNoLinkedProfile = errors.New("no profile is linked to this account")
+)
+
+// OIDCLogin is a login in progress at the provider. The browser keeps it until it comes
+// back, and the callback only counts when it brings the same state.
+type OIDCLogin struct {
+	State    string // round trip through the provider, ties the callback to this browser
+	Nonce    string // comes back inside the ID token
+	Verifier string // PKCE, proves the code is redeemed by whoever asked for it
+	Link     bool   // link the account to the logged in profile instead of logging in with it
+}
+
+// OIDCService lets people log in through an OpenID Connect provider (Authelia, Keycloak...)
+// instead of picking a profile. Each account at the provider maps to one profile: one it
+// was linked to, or one created on its first login. Picking a profile is switched off
+// while it's on, see OIDC_DISABLE_PROFILE_SELECT and ProfileService.CheckSelection.
+type OIDCService struct {
+	DB           *database.Queries // database access
+	Profiles     *ProfileService   // creates profiles for new accounts
+	Provider     *oidc.Provider    // nil when OIDC login is off
+	AutoCreate   bool              // OIDC_AUTO_CREATE, new accounts get a profile instead of being refused
+	PostLoginURL string            // OIDC_POST_LOGIN_URL, where the browser goes once logged in
+}
+
+// NewOIDCService creates service with its dependencies, reading the provider from OIDC_*
+func NewOIDCService(db *database.Queries, profiles *ProfileService) *OIDCService {
+	service := &OIDCService{
+		DB:           db,
+		Profiles:     profiles,
+		AutoCreate:   util.GetEnvBool("OIDC_AUTO_CREATE", true),
+		PostLoginURL: os.Getenv("OIDC_POST_LOGIN_URL"),
+	}
+	if service.PostLoginURL == "" {
+		service.PostLoginURL = "/"
+	}
+	if config := oidc.LoadConfig(); config != nil {
+		service.Provider = oidc.NewProvider(*config)
+		log.Printf("OIDC login enabled with %s", config.Issuer)
+	}
+	return service
+}
+
+// Status tells the login screen whether to offer OIDC login
+func (s *OIDCService) Status() models.OIDCStatus {
+	status := models.OIDCStatus{Enabled: s.Provider != nil, AutoCreate: s.AutoCreate}
+	if status.Enabled {
+		status.LoginURL = "/api/auth/oidc/login"
+	}
+	return status
+}
+
+// StartLogin returns where to send the browser and the login to remember until it comes back
+func (s *OIDCService) StartLogin(ctx context.Context, link bool) (string, *OIDCLogin, error) {
+	if s.Provider == nil {
+		return "", nil, ErrOIDCDisabled
+	}
+
+	login := &OIDCLogin{Link: link}
+	for _, value := range []*string{&login.State, &login.Nonce, &login.Verifier} {
+		token, err := secret.NewToken(32)
+		if err != nil {
+			return "", nil, err
+		}
+		*value = token
+	}
+
+	authURL, err := s.Provider.AuthURL(ctx, login.State, login.Nonce, login.Verifier)
+	if err != nil {
+		return "", nil, err
+	}
+	return authURL, login, nil
+}
+
+// FinishLogin redeems the code the provider sent the browser back with and returns the
+// profile to log in as. When the login was started to link an account, currentUser gets it.
+func (s *OIDCService) FinishLogin(ctx context.Context, login *OIDCLogin, code string, currentUser uuid.UUID) (uuid.UUID, error) {
+	if s.Provider == nil {
+		return uuid.Nil, ErrOIDCDisabled
+	}
+
+	idToken, err := s.Provider.Exchange(ctx, code, login.Verifier)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	claims, err := s.Provider.Verify(ctx, idToken, login.Nonce, time.Now())
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	linkTo := uuid.Nil
+	if login.Link {
+		linkTo = currentUser
+	}
+	return s.profileFor(ctx, claims, linkTo)
+}
+
+// profileFor finds the profile an account logs in as, linking it to linkTo or a new
+// profile when it has none yet
+func (s *OIDCService) profileFor(ctx context.Context, claims *oidc.Claims, linkTo uuid.UUID) (uuid.UUID, error) {
+	email := sql.NullString{String: claims.Email, Valid: claims.Email != "" && claims.EmailVerified}
+	key := database.GetProfileIdentityParams{Issuer: claims.Issuer, Subject: claims.Subject}
+
+	identity, err := s.DB.GetProfileIdentity(ctx, key)
+	if err == nil {
+		if linkTo != uuid.Nil && identity.ProfileID != linkTo {
+			return uuid.Nil, ErrIdentityLinked
+		}
+		err := s.DB.TouchProfileIdentity(ctx, database.TouchProfileIdentityParams{
+			Issuer:  claims.Issuer,
+			Subject: claims.Subject,
+			Email:   email,
+		})
+		if err != nil {
+			log.Printf("Error recording login of %s at %s: %v", claims.Subject, claims.Issuer, err)
+		}
+		return identity.ProfileID, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return uuid.Nil, fmt.Errorf("error retrieving linked profile: %w", err)
+	}
+
+	profileID := linkTo
+	if profileID == uuid.Nil {
+		if !s.AutoCreate {
+			return uuid.Nil, ErrNoLinkedProfile
+		}
+		profile, err := s.Profiles.CreateProfile(ctx, models.Profile{Name: claims.DisplayName()})
+		if err != nil {
+			return uuid.Nil, err
+		}
+		profileID = profile.ID
+		log.Printf("Created profile %s for %s at %s", profileID, claims.Subject, claims.Issuer)
+	}
+
+	created, err := s.DB.CreateProfileIdentity(ctx, database.CreateProfileIdentityParams{
+		Issuer:    claims.Issuer,
+		Subject:   claims.Subject,
+		ProfileID: profileID,
+		Email:     email,
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("error linking profile: %w", err)
+	}
+	if created == 0 {
+		// a login racing this one linked the account first, go with that
+		identity, err := s.DB.GetProfileIdentity(ctx, key)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("error retrieving linked profile: %w", err)
+		}
+		if linkTo == uuid.Nil {
+			if err := s.Profiles.DeleteProfileByID(ctx, profileID); err != nil {
+				log.Printf("Error removing profile %s created by a racing login: %v", profileID, err)
+			}
+		} else if identity.ProfileID != linkTo {
+			return uuid.Nil, ErrIdentityLinked
+		}
+		return identity.ProfileID, nil
+	}
+	return profileID, nil
+}
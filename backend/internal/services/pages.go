@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/NeroQue/course-management-backend/pkg/artifacts"
+	"github.com/NeroQue/course-management-backend/pkg/pdf"
+	"github.com/NeroQue/course-management-backend/pkg/thumbnail"
+	"github.com/google/uuid"
+)
+
+// PageWidth of rendered PDF pages - readable full screen on a laptop, sharp enough to zoom a bit
+const PageWidth = 1200
+
+// ErrPageNotFound is returned for a page number outside the PDF
+var ErrPageNotFound = errors.New("page not found")
+
+// GetPage returns the path and checksum of one rendered page (1-based) of a PDF content item,
+// rendering it on first request. Pages are keyed by the file's checksum like thumbnails.
+func (s *ThumbnailService) GetPage(ctx context.Context, itemID, profileID uuid.UUID, page int) (string, string, error) {
+	location, err := s.locate(ctx, itemID, profileID)
+	if err != nil {
+		return "", "", err
+	}
+	if location.ContentType != "pdf" {
+		return "", "", fmt.Errorf("%w: no pages for %s", thumbnail.ErrUnsupported, location.ContentType)
+	}
+
+	item, err := s.DB.GetContentItem(ctx, itemID)
+	if err != nil {
+		return "", "", fmt.Errorf("error retrieving content item: %w", err)
+	}
+	// page_count is missing when it couldn't be read at import - pdftoppm finds out then
+	if page < 1 || (item.PageCount.Valid && page > int(item.PageCount.Int32)) {
+		return "", "", fmt.Errorf("%w: %d", ErrPageNotFound, page)
+	}
+
+	src, checksum, err := s.sourceFile(ctx, location)
+	if err != nil {
+		return "", "", err
+	}
+
+	dir := filepath.Join(s.Store.ItemDir(artifacts.Pages, location.CourseID, itemID), checksum)
+	path := filepath.Join(dir, strconv.Itoa(page)+".jpg")
+	if _, err := os.Stat(path); err == nil {
+		return path, checksum, nil
+	}
+
+	s.generating.Lock()
+	defer s.generating.Unlock()
+
+	// another request may have made it while we waited
+	if _, err := os.Stat(path); err == nil {
+		return path, checksum, nil
+	}
+
+	if err := pdf.RenderPage(ctx, src, page, PageWidth, path); err != nil {
+		if !item.PageCount.Valid && !errors.Is(err, pdf.ErrToolMissing) {
+			// most likely past the last page of a PDF we couldn't count
+			return "", "", fmt.Errorf("%w: %d: %v", ErrPageNotFound, page, err)
+		}
+		return "", "", err
+	}
+	return path, checksum, nil
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/artifacts"
+	"github.com/NeroQue/course-management-backend/pkg/task"
+	"github.com/NeroQue/course-management-backend/pkg/tiering"
+	"github.com/NeroQue/course-management-backend/pkg/util"
+	"github.com/google/uuid"
+)
+
+// prefetch limits
+const (
+	DefaultPrefetchCount = 3
+	MaxPrefetchCount     = 10
+)
+
+// PrefetchService tells players what comes next and can warm it up ahead of time
+type PrefetchService struct {
+	DB        *database.Queries // database access
+	Tiering   *TieringService   // cold items get restored when warming
+	Artifacts *artifacts.Store  // where transcoded renditions live
+	BasePath  string            // courses directory
+	WarmBytes int64             // how much of each original file to read when warming
+}
+
+// NewPrefetchService creates service with its dependencies, PREFETCH_WARM_MB sets how much gets read ahead
+func NewPrefetchService(db *database.Queries, tiering *TieringService, basePath string) *PrefetchService {
+	s := &PrefetchService{
+		DB:        db,
+		Tiering:   tiering,
+		Artifacts: artifacts.NewStore(),
+		BasePath:  basePath,
+		WarmBytes: int64(util.GetEnvInt("PREFETCH_WARM_MB", 8)) << 20,
+	}
+	task.Register("prefetch_warm", s.warmWork)
+	return s
+}
+
+// NextItems returns up to count items following itemID in course order, across module boundaries
+func (s *PrefetchService) NextItems(ctx context.Context, itemID uuid.UUID, count int) (*models.PrefetchResult, error) {
+	location, err := s.DB.GetContentItemLocation(ctx, itemID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("content item not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving content item: %w", err)
+	}
+
+	items, err := s.DB.ListCourseContentItems(ctx, location.CourseID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving course content: %w", err)
+	}
+
+	result := &models.PrefetchResult{ContentItemID: itemID, CourseID: location.CourseID, Items: []models.PrefetchItem{}}
+	found := false
+	for _, item := range items {
+		if !found {
+			found = item.ID == itemID
+			continue
+		}
+		if len(result.Items) == count {
+			break
+		}
+		if item.Hidden {
+			continue
+		}
+
+		next, err := s.toPrefetchItem(ctx, item)
+		if err != nil {
+			return nil, err
+		}
+		result.Items = append(result.Items, next)
+	}
+
+	return result, nil
+}
+
+// warmInput is what a prefetch warm task runs on
+type warmInput struct {
+	CourseID uuid.UUID             `json:"course_id"`
+	Items    []models.PrefetchItem `json:"items"`
+}
+
+// StartWarmTask warms the given items in the background and returns the task ID
+func (s *PrefetchService) StartWarmTask(courseID uuid.UUID, items []models.PrefetchItem) string {
+	return task.Submit("prefetch_warm", warmInput{CourseID: courseID, Items: items})
+}
+
+// warmWork warms the items of a prefetch warm task, skipping the ones that fail
+func (s *PrefetchService) warmWork(input warmInput) task.Work {
+	return func(ctx context.Context, taskID string) (interface{}, error) {
+		task.SetTaskMessage(taskID, fmt.Sprintf("Warming %d upcoming items", len(input.Items)))
+
+		warmed := 0
+		for _, item := range input.Items {
+			if err := s.warmItem(ctx, input.CourseID, item.ID); err != nil {
+				log.Printf("Warning: could not warm content %s: %v", item.ID, err)
+				task.Warnf(taskID, "Could not warm %s: %v", item.Title, err)
+				continue
+			}
+			warmed++
+		}
+		return map[string]int{"items_warmed": warmed}, nil
+	}
+}
+
+// warmItem brings an item back from cold storage and reads the start of the original
+// plus any HLS renditions so the first request is served from the page cache
+func (s *PrefetchService) warmItem(ctx context.Context, courseID, itemID uuid.UUID) error {
+	item, err := s.DB.GetContentItem(ctx, itemID)
+	if err != nil {
+		return fmt.Errorf("error retrieving content item: %w", err)
+	}
+
+	state, err := s.DB.GetContentTiering(ctx, itemID)
+	if err == nil && state.Tier == tiering.Cold {
+		if err := s.Tiering.restore(ctx, itemID); err != nil {
+			return err
+		}
+	}
+
+	if err := readAhead(filepath.Join(s.BasePath, item.RelativePath), s.WarmBytes); err != nil {
+		return err
+	}
+
+	// renditions are optional - most items won't have any
+	renditions := s.Artifacts.ItemDir(artifacts.HLS, courseID, itemID)
+	return filepath.WalkDir(renditions, func(path string, entry os.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return nil
+		}
+		return readAhead(path, s.WarmBytes)
+	})
+}
+
+// toPrefetchItem converts a database content item to what the player needs
+func (s *PrefetchService) toPrefetchItem(ctx context.Context, item database.ContentItem) (models.PrefetchItem, error) {
+	ext := filepath.Ext(item.RelativePath)
+	next := models.PrefetchItem{
+		ID:          item.ID,
+		ModuleID:    item.ModuleID,
+		Title:       item.Title,
+		ContentType: item.ContentType,
+		Format:      strings.TrimPrefix(strings.ToLower(ext), "."),
+		MimeType:    mime.TypeByExtension(strings.ToLower(ext)),
+		Size:        item.Size.Int64,
+		Duration:    int(item.Duration.Int32),
+		StreamURL:   "/api/content/" + item.ID.String() + "/stream",
+	}
+
+	state, err := s.DB.GetContentTiering(ctx, item.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return next, fmt.Errorf("error retrieving content tier: %w", err)
+	}
+	next.Cold = err == nil && state.Tier == tiering.Cold
+	return next, nil
+}
+
+// readAhead reads up to limit bytes of a file and throws them away, leaving them in the page cache
+func readAhead(path string, limit int64) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("error opening %s: %w", path, err)
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(io.Discard, io.LimitReader(f, limit)); err != nil {
+		return fmt.Errorf("error reading %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidPrerequisite is returned for self references, unknown courses and cycles
+var ErrInvalidPrerequisite = errors.New("invalid prerequisite")
+
+// PrerequisiteService manages which courses have to be finished before others
+type PrerequisiteService struct {
+	DB      *database.Queries // database access
+	Conn    *sql.DB           // raw connection for transactions
+	Courses *CourseService    // for completion checks
+}
+
+// NewPrerequisiteService creates service with database and course dependencies
+func NewPrerequisiteService(db *database.Queries, conn *sql.DB, courses *CourseService) *PrerequisiteService {
+	return &PrerequisiteService{
+		DB:      db,
+		Conn:    conn,
+		Courses: courses,
+	}
+}
+
+// GetPrerequisites lists a course's prerequisites, with completion for userID if given
+func (s *PrerequisiteService) GetPrerequisites(ctx context.Context, courseID, userID uuid.UUID) (*models.CoursePrerequisites, error) {
+	if _, err := s.DB.GetCourse(ctx, courseID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("course not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving course: %w", err)
+	}
+
+	prerequisiteIDs, err := s.DB.ListCoursePrerequisites(ctx, courseID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving prerequisites: %w", err)
+	}
+
+	result := &models.CoursePrerequisites{
+		CourseID:      courseID,
+		Prerequisites: []models.PrerequisiteStatus{},
+	}
+	locked := false
+
+	for _, prerequisiteID := range prerequisiteIDs {
+		prerequisite, err := s.DB.GetCourse(ctx, prerequisiteID)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving prerequisite course: %w", err)
+		}
+
+		status := models.PrerequisiteStatus{CourseID: prerequisite.ID, Title: prerequisite.Title}
+		if userID != uuid.Nil {
+			completed, err := s.isCompleted(ctx, userID, prerequisiteID)
+			if err != nil {
+				return nil, err
+			}
+			status.Completed = &completed
+			locked = locked || !completed
+		}
+		result.Prerequisites = append(result.Prerequisites, status)
+	}
+
+	if userID != uuid.Nil {
+		result.Locked = &locked
+	}
+	return result, nil
+}
+
+// SetPrerequisites replaces a course's prerequisites, refusing anything that would form a cycle
+func (s *PrerequisiteService) SetPrerequisites(ctx context.Context, courseID uuid.UUID, prerequisiteIDs []uuid.UUID) (*models.CoursePrerequisites, error) {
+	if _, err := s.DB.GetCourse(ctx, courseID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("course not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving course: %w", err)
+	}
+
+	seen := make(map[uuid.UUID]bool, len(prerequisiteIDs))
+	unique := make([]uuid.UUID, 0, len(prerequisiteIDs))
+	for _, prerequisiteID := range prerequisiteIDs {
+		if prerequisiteID == courseID {
+			return nil, fmt.Errorf("%w: a course can't require itself", ErrInvalidPrerequisite)
+		}
+		if seen[prerequisiteID] {
+			continue
+		}
+		seen[prerequisiteID] = true
+
+		if _, err := s.DB.GetCourse(ctx, prerequisiteID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, fmt.Errorf("%w: course %s does not exist", ErrInvalidPrerequisite, prerequisiteID)
+			}
+			return nil, fmt.Errorf("error retrieving prerequisite course: %w", err)
+		}
+		unique = append(unique, prerequisiteID)
+	}
+
+	if err := s.checkForCycle(ctx, courseID, unique); err != nil {
+		return nil, err
+	}
+
+	err := runInTx(ctx, s.Conn, s.DB, func(q *database.Queries) error {
+		if err := q.ClearCoursePrerequisites(ctx, courseID); err != nil {
+			return fmt.Errorf("error clearing prerequisites: %w", err)
+		}
+		for _, prerequisiteID := range unique {
+			err := q.AddCoursePrerequisite(ctx, database.AddCoursePrerequisiteParams{
+				CourseID:       courseID,
+				PrerequisiteID: prerequisiteID,
+			})
+			if err != nil {
+				return fmt.Errorf("error adding prerequisite: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetPrerequisites(ctx, courseID, uuid.Nil)
+}
+
+// checkForCycle makes sure none of the new prerequisites (transitively) require courseID
+func (s *PrerequisiteService) checkForCycle(ctx context.Context, courseID uuid.UUID, prerequisiteIDs []uuid.UUID) error {
+	graph, err := s.prerequisiteGraph(ctx)
+	if err != nil {
+		return err
+	}
+	graph[courseID] = prerequisiteIDs // the edges we're about to write
+
+	visited := make(map[uuid.UUID]bool)
+	var reaches func(from uuid.UUID) bool
+	reaches = func(from uuid.UUID) bool {
+		if from == courseID {
+			return true
+		}
+		if visited[from] {
+			return false
+		}
+		visited[from] = true
+		for _, next := range graph[from] {
+			if reaches(next) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, prerequisiteID := range prerequisiteIDs {
+		if reaches(prerequisiteID) {
+			return fmt.Errorf("%w: course %s already depends on this course", ErrInvalidPrerequisite, prerequisiteID)
+		}
+	}
+	return nil
+}
+
+// prerequisiteGraph loads every course -> prerequisites edge
+func (s *PrerequisiteService) prerequisiteGraph(ctx context.Context) (map[uuid.UUID][]uuid.UUID, error) {
+	rows, err := s.DB.ListAllCoursePrerequisites(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving prerequisites: %w", err)
+	}
+
+	graph := make(map[uuid.UUID][]uuid.UUID)
+	for _, row := range rows {
+		graph[row.CourseID] = append(graph[row.CourseID], row.PrerequisiteID)
+	}
+	return graph, nil
+}
+
+// AttachPrerequisites fills in Prerequisites on each course and, for a profile, Locked and UnmetPrerequisites
+func (s *PrerequisiteService) AttachPrerequisites(ctx context.Context, userID uuid.UUID, courses []*models.Course) error {
+	graph, err := s.prerequisiteGraph(ctx)
+	if err != nil {
+		return err
+	}
+
+	// each prerequisite is only checked once no matter how many courses need it
+	completed := make(map[uuid.UUID]bool)
+	checked := make(map[uuid.UUID]bool)
+
+	for _, course := range courses {
+		course.Prerequisites = graph[course.ID]
+		if userID == uuid.Nil {
+			continue
+		}
+
+		course.UnmetPrerequisites = nil
+		for _, prerequisiteID := range course.Prerequisites {
+			if !checked[prerequisiteID] {
+				done, err := s.isCompleted(ctx, userID, prerequisiteID)
+				if err != nil {
+					return err
+				}
+				completed[prerequisiteID] = done
+				checked[prerequisiteID] = true
+			}
+			if !completed[prerequisiteID] {
+				course.UnmetPrerequisites = append(course.UnmetPrerequisites, prerequisiteID)
+			}
+		}
+		course.Locked = len(course.UnmetPrerequisites) > 0
+	}
+	return nil
+}
+
+// isCompleted reports whether the profile has finished every item in a course
+func (s *PrerequisiteService) isCompleted(ctx context.Context, userID, courseID uuid.UUID) (bool, error) {
+	progress, err := s.Courses.CalculateCourseProgress(ctx, userID, courseID)
+	if err != nil {
+		return false, fmt.Errorf("error checking prerequisite progress: %w", err)
+	}
+	return progress.IsCompleted, nil
+}
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidMerge is returned for a merge that names the same profile twice, or none
+var ErrInvalidMerge = errors.New("invalid profile merge")
+
+// ProfileMergeService folds duplicate profiles together, like the one someone made before
+// finding their old one. Everything the source did ends up on the target, then the source
+// is deleted.
+type ProfileMergeService struct {
+	DB       *database.Queries // database access
+	Conn     *sql.DB           // raw connection for transactions
+	Profiles *ProfileService   // avatars and the merged profile
+}
+
+// NewProfileMergeService creates service with its dependencies
+func NewProfileMergeService(db *database.Queries, conn *sql.DB, profiles *ProfileService) *ProfileMergeService {
+	return &ProfileMergeService{DB: db, Conn: conn, Profiles: profiles}
+}
+
+// MergeProfiles moves sourceID's progress, notes, favorites and gamification stats to
+// targetID and deletes sourceID. Where both have progress on an item the furthest wins,
+// course notes are appended to the target's, and XP both earned for the same thing only
+// counts once. The streak is worked out again from the combined activity.
+func (s *ProfileMergeService) MergeProfiles(ctx context.Context, sourceID, targetID uuid.UUID) (*models.ProfileMerge, error) {
+	if sourceID == uuid.Nil || targetID == uuid.Nil {
+		return nil, fmt.Errorf("%w: source_id and target_id are required", ErrInvalidMerge)
+	}
+	if sourceID == targetID {
+		return nil, fmt.Errorf("%w: a profile can't be merged into itself", ErrInvalidMerge)
+	}
+
+	merge := &models.ProfileMerge{}
+	err := runInTx(ctx, s.Conn, s.DB, func(q *database.Queries) error {
+		source, err := q.GetProfileById(ctx, sourceID)
+		if err != nil {
+			return fmt.Errorf("error retrieving source profile: %w", err)
+		}
+		target, err := q.GetProfileById(ctx, targetID)
+		if err != nil {
+			return fmt.Errorf("error retrieving target profile: %w", err)
+		}
+
+		// before the awards move, afterwards they'd all overlap
+		overlap, err := q.GetOverlappingXP(ctx, database.GetOverlappingXPParams{SourceID: sourceID, TargetID: targetID})
+		if err != nil {
+			return fmt.Errorf("error comparing XP awards: %w", err)
+		}
+
+		counts := []struct {
+			count *int
+			what  string
+			merge func() (int64, error)
+		}{
+			{&merge.Progress, "progress", func() (int64, error) {
+				return q.MergeProfileProgress(ctx, database.MergeProfileProgressParams{TargetID: targetID, SourceID: sourceID})
+			}},
+			{&merge.CourseNotes, "course notes", func() (int64, error) {
+				return q.MergeProfileCourseNotes(ctx, database.MergeProfileCourseNotesParams{TargetID: targetID, SourceID: sourceID})
+			}},
+			{&merge.ContentNotes, "content notes", func() (int64, error) {
+				return q.MoveProfileContentNotes(ctx, database.MoveProfileContentNotesParams{TargetID: targetID, SourceID: sourceID})
+			}},
+			{&merge.Bookmarks, "bookmarks", func() (int64, error) {
+				return q.MoveProfileBookmarks(ctx, database.MoveProfileBookmarksParams{TargetID: targetID, SourceID: sourceID})
+			}},
+			{&merge.Favorites, "favorites", func() (int64, error) {
+				return q.MergeProfileFavorites(ctx, database.MergeProfileFavoritesParams{TargetID: targetID, SourceID: sourceID})
+			}},
+		}
+		for _, c := range counts {
+			n, err := c.merge()
+			if err != nil {
+				return fmt.Errorf("error merging %s: %w", c.what, err)
+			}
+			*c.count = int(n)
+		}
+
+		// kept so completions the source already earned don't pay out again, and the
+		// streak below counts both profiles' days
+		if err := q.MergeProfileXPAwards(ctx, database.MergeProfileXPAwardsParams{TargetID: targetID, SourceID: sourceID}); err != nil {
+			return fmt.Errorf("error merging XP awards: %w", err)
+		}
+		if err := q.MergeProfileActivity(ctx, database.MergeProfileActivityParams{TargetID: targetID, SourceID: sourceID}); err != nil {
+			return fmt.Errorf("error merging activity: %w", err)
+		}
+		if err := q.MergeProfileFreezeDays(ctx, database.MergeProfileFreezeDaysParams{TargetID: targetID, SourceID: sourceID}); err != nil {
+			return fmt.Errorf("error merging streak freezes: %w", err)
+		}
+		if err := q.MergeProfileUnlocks(ctx, database.MergeProfileUnlocksParams{TargetID: targetID, SourceID: sourceID}); err != nil {
+			return fmt.Errorf("error merging unlocks: %w", err)
+		}
+		// courses would otherwise keep the source from being deleted
+		err = q.MoveProfileCourses(ctx, database.MoveProfileCoursesParams{
+			TargetID: uuid.NullUUID{UUID: targetID, Valid: true},
+			SourceID: uuid.NullUUID{UUID: sourceID, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("error moving courses: %w", err)
+		}
+		// logging in with the source's account lands on the target from now on
+		if err := q.MoveProfileIdentities(ctx, database.MoveProfileIdentitiesParams{TargetID: targetID, SourceID: sourceID}); err != nil {
+			return fmt.Errorf("error moving linked accounts: %w", err)
+		}
+
+		description := "Merged from profile " + source.Name
+		entry := ledgerEntry{reason: models.LedgerReasonMerge, subject: sourceID.String(), sourceEvent: models.LedgerSourceMerge, description: description}
+
+		// no level up announcement: the source was already paid for its levels
+		merge.Experience = max(int(source.Experience-overlap), 0)
+		if _, err := earnXP(ctx, q, targetID, merge.Experience, entry); err != nil {
+			return err
+		}
+		merge.Gems = int(source.Gems)
+		if err := earnGems(ctx, q, targetID, merge.Gems, entry); err != nil {
+			return err
+		}
+		if merge.StreakFreezes = int(source.StreakFreezes); merge.StreakFreezes > 0 {
+			freezes, err := q.AddProfileStreakFreezes(ctx, database.AddProfileStreakFreezesParams{ID: targetID, Freezes: source.StreakFreezes})
+			if err != nil {
+				return fmt.Errorf("error adding streak freezes: %w", err)
+			}
+			entry.stat, entry.amount, entry.balance = models.StatStreakFreezes, merge.StreakFreezes, int(freezes)
+			if err := recordLedgerEntry(ctx, q, targetID, entry); err != nil {
+				return err
+			}
+		}
+
+		if err := mergeStreak(ctx, q, target, source, activityDay(time.Now(), s.Profiles.Location), entry); err != nil {
+			return err
+		}
+
+		if err := q.DeleteProfile(ctx, sourceID); err != nil {
+			return fmt.Errorf("error deleting source profile: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the source is gone either way, a leftover picture just wastes space
+	if err := s.Profiles.removeAvatar(sourceID); err != nil {
+		log.Printf("Error removing avatar of merged profile %s: %v", sourceID, err)
+	}
+
+	merge.Profile, err = s.Profiles.GetProfileByID(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+	return merge, nil
+}
+
+// mergeStreak works the target's streak out again from the activity both profiles now
+// share. The longest streak is the longest either of them had, the runs may not overlap.
+func mergeStreak(ctx context.Context, q *database.Queries, target, source database.Profile, today time.Time, entry ledgerEntry) error {
+	days, err := q.ListActivityDays(ctx, target.ID)
+	if err != nil {
+		return fmt.Errorf("error retrieving activity: %w", err)
+	}
+
+	current, longest := calculateStreaks(days, today)
+	longest = max(longest, int(target.LongestStreak), int(source.LongestStreak))
+	lastActive := sql.NullTime{}
+	if len(days) > 0 {
+		lastActive = sql.NullTime{Time: days[0], Valid: true}
+	}
+
+	err = q.SetProfileStreak(ctx, database.SetProfileStreakParams{
+		ID:             target.ID,
+		Streak:         int32(current),
+		LongestStreak:  int32(longest),
+		LastActiveDate: lastActive,
+	})
+	if err != nil {
+		return fmt.Errorf("error saving streak: %w", err)
+	}
+
+	if current == int(target.Streak) {
+		return nil
+	}
+	entry.stat, entry.amount, entry.balance = models.StatStreak, current-int(target.Streak), current
+	return recordLedgerEntry(ctx, q, target.ID, entry)
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/google/uuid"
+)
+
+// profileRow is a profiles row as GetProfileById returns it
+func profileRow(id uuid.UUID, name string, experience, gems int64) []driver.Value {
+	now := time.Now()
+	return []driver.Value{id.String(), name, now, now, false, int64(0), int64(0), nil, experience, gems, int64(0), "viewer", nil, nil, nil, false}
+}
+
+func TestMergeProfilesRefusesBadInput(t *testing.T) {
+	id := uuid.New()
+	// every case fails before the database is asked, there is none behind it
+	s := &ProfileMergeService{}
+
+	tests := []struct {
+		name             string
+		source, targetID uuid.UUID
+	}{
+		{"no source", uuid.Nil, id},
+		{"no target", id, uuid.Nil},
+		{"into itself", id, id},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.MergeProfiles(context.Background(), tt.source, tt.targetID); !errors.Is(err, ErrInvalidMerge) {
+				t.Errorf("got %v, want ErrInvalidMerge", err)
+			}
+		})
+	}
+}
+
+func TestMergeProfilesCountsSharedXPOnce(t *testing.T) {
+	sourceID, targetID := uuid.New(), uuid.New()
+	failure := errors.New("disk full")
+
+	tests := []struct {
+		name    string
+		overlap int64
+		xp      driver.Value // what the target gets, nil for nothing
+	}{
+		{"some awards shared", 50, int64(70)},
+		{"all awards shared", 120, nil},
+		{"more shared than the source has", 200, nil}, // the source lost XP since, say by a reset
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, conn, queries := newFakeDB(t, map[string]fakeAnswer{
+				"GetProfileById": func(args []driver.Value) ([][]driver.Value, error) {
+					if args[0] == sourceID.String() {
+						return [][]driver.Value{profileRow(sourceID, "old", 120, 3)}, nil
+					}
+					return [][]driver.Value{profileRow(targetID, "new", 500, 10)}, nil
+				},
+				"GetOverlappingXP":        rows([]driver.Value{tt.overlap}),
+				"MergeProfileProgress":    rows(),
+				"MergeProfileCourseNotes": rows(),
+				"MoveProfileContentNotes": rows(),
+				"MoveProfileBookmarks":    rows(),
+				"MergeProfileFavorites":   rows(),
+				"MergeProfileXPAwards":    rows(),
+				"MergeProfileActivity":    rows(),
+				"MergeProfileFreezeDays":  rows(),
+				"MergeProfileUnlocks":     rows(),
+				"MoveProfileCourses":      rows(),
+				"MoveProfileIdentities":   rows(),
+				"AddProfileExperience":    rows([]driver.Value{int64(570)}),
+				"CreateLedgerEntry":       rows(),
+				// stop the merge after the XP to see it rolled back
+				"AddProfileGems": fails(failure),
+			})
+			s := &ProfileMergeService{DB: queries, Conn: conn}
+
+			if _, err := s.MergeProfiles(context.Background(), sourceID, targetID); !errors.Is(err, failure) {
+				t.Fatalf("got %v, want the gems failure", err)
+			}
+
+			var xp driver.Value
+			if added := db.called("AddProfileExperience"); len(added) > 0 {
+				xp = added[0].args[0]
+			}
+			if xp != tt.xp {
+				t.Errorf("target got %v XP, want %v", xp, tt.xp)
+			}
+			if len(db.called("DeleteProfile")) > 0 {
+				t.Error("source was deleted by a merge that failed")
+			}
+			if db.commits != 0 || db.rollbacks != 1 {
+				t.Errorf("%d commits and %d rollbacks, want the merge rolled back", db.commits, db.rollbacks)
+			}
+		})
+	}
+}
+
+func TestMergeStreak(t *testing.T) {
+	today := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	day := func(ago int) []driver.Value { return []driver.Value{today.AddDate(0, 0, -ago)} }
+	target := database.Profile{ID: uuid.New(), Streak: 1, LongestStreak: 3}
+	source := database.Profile{ID: uuid.New(), Streak: 2, LongestStreak: 9}
+
+	// the target studied today and four days ago, the source yesterday and the day before
+	db, _, queries := newFakeDB(t, map[string]fakeAnswer{
+		"ListActivityDays":  rows(day(0), day(1), day(2), day(4)),
+		"SetProfileStreak":  rows(),
+		"CreateLedgerEntry": rows(),
+	})
+
+	if err := mergeStreak(context.Background(), queries, target, source, today, ledgerEntry{}); err != nil {
+		t.Fatal(err)
+	}
+
+	saved := db.called("SetProfileStreak")
+	if len(saved) != 1 {
+		t.Fatalf("streak saved %d times", len(saved))
+	}
+	if streak, longest := saved[0].args[1], saved[0].args[2]; streak != int64(3) || longest != int64(9) {
+		t.Errorf("saved streak %v and longest %v, want 3 and 9", streak, longest)
+	}
+	ledger := db.called("CreateLedgerEntry")
+	if len(ledger) != 1 {
+		t.Fatalf("%d ledger entries, want one for the streak", len(ledger))
+	}
+	if amount := ledger[0].args[2]; amount != int64(2) {
+		t.Errorf("ledger has the streak change as %v, want 2", amount)
+	}
+}
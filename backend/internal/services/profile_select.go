@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/access"
+	"github.com/NeroQue/course-management-backend/pkg/secret"
+)
+
+// profile selection errors
+var (
+	// ErrSelectionSecret is returned for selecting an editor or admin profile from another
+	// machine without the selection secret
+	ErrSelectionSecret = errors.New("this profile can only be selected on the server itself or with the selection secret")
+	// ErrSelectionSSO is returned for selecting a profile that has to log in through OIDC
+	ErrSelectionSSO = errors.New("this profile logs in through single sign-on")
+)
+
+// CheckSelection decides whether a client may select profile without logging in. Viewers
+// can be picked by anyone who can reach the instance, like on a shared TV. Editors and
+// admins can change the library and the instance, so they need the request to come from
+// the server itself (local) or to bring PROFILE_SELECT_SECRET. With OIDC login on
+// (SSOOnly), editors, admins and profiles linked to an account only log in through it.
+func (s *ProfileService) CheckSelection(ctx context.Context, profile models.Profile, selectSecret string, local bool) error {
+	if s.SSOOnly {
+		if profile.Role != access.RoleViewer {
+			return ErrSelectionSSO
+		}
+		linked, err := s.DB.CountProfileIdentities(ctx, profile.ID)
+		if err != nil {
+			return fmt.Errorf("error checking linked accounts: %w", err)
+		}
+		if linked > 0 {
+			return ErrSelectionSSO
+		}
+	}
+
+	if profile.Role == access.RoleViewer || local {
+		return nil
+	}
+	if s.SelectSecret != "" && selectSecret != "" &&
+		subtle.ConstantTimeCompare([]byte(secret.HashToken(selectSecret)), []byte(secret.HashToken(s.SelectSecret))) == 1 {
+		return nil
+	}
+	return ErrSelectionSecret
+}
@@ -2,25 +2,52 @@ package services
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"log"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/NeroQue/course-management-backend/internal/database"
 	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/access"
+	"github.com/NeroQue/course-management-backend/pkg/rewards"
+	"github.com/NeroQue/course-management-backend/pkg/session"
+	"github.com/NeroQue/course-management-backend/pkg/util"
 	"github.com/google/uuid"
 )
 
+// ErrInvalidRole is returned for setting a role profiles can't have
+var ErrInvalidRole = errors.New("invalid role")
+
 // ProfileService handles all the profile business logic
 type ProfileService struct {
-	DB *database.Queries // database access layer
+	DB        *database.Queries // database access layer
+	Conn      *sql.DB           // raw connection for transactions
+	Location  *time.Location    // where a day starts for streaks, STREAK_TIMEZONE
+	Rules     *rewards.Rules    // the level curve
+	AvatarDir string            // where profile pictures are kept, under DATA_DIR
+
+	SelectSecret string // PROFILE_SELECT_SECRET, lets editors and admins be selected from other machines
+	SSOOnly      bool   // set when OIDC login is on, see CheckSelection
 }
 
 // NewProfileService creates service with db dependency
-func NewProfileService(db *database.Queries) *ProfileService {
+func NewProfileService(db *database.Queries, conn *sql.DB, rules *rewards.Rules) *ProfileService {
+	if os.Getenv("PROFILE_SELECT_SECRET") == "" {
+		log.Printf("PROFILE_SELECT_SECRET is not set, editor and admin profiles can only be selected from this machine")
+	}
 	return &ProfileService{
-		DB: db,
+		DB:        db,
+		Conn:      conn,
+		Location:  activityLocation(),
+		Rules:     rules,
+		AvatarDir: filepath.Join(util.GetDataDirectory(), "avatars"),
+
+		SelectSecret: os.Getenv("PROFILE_SELECT_SECRET"),
 	}
 }
 
@@ -35,46 +62,52 @@ func (s *ProfileService) GetAllProfiles(ctx context.Context) ([]models.Profile,
 	// convert db models to app models
 	modelProfiles := make([]models.Profile, len(profiles))
 	for i, p := range profiles {
-		modelProfiles[i] = models.Profile{
-			ID:        p.ID,
-			Name:      p.Name,
-			CreatedAt: p.CreatedAt,
-			UpdatedAt: p.UpdatedAt,
-		}
+		modelProfiles[i] = s.toProfileModel(p)
 	}
 
 	return modelProfiles, nil
 }
 
-// CreateProfile makes a new profile with validation
+// CreateProfile makes a new profile with validation. The ID is always generated here.
 func (s *ProfileService) CreateProfile(ctx context.Context, profile models.Profile) (models.Profile, error) {
 	// basic validation - name can't be empty
 	if strings.TrimSpace(profile.Name) == "" {
 		return models.Profile{}, errors.New("profile name cannot be empty")
 	}
 
-	// generate UUID if not provided
-	if profile.ID == uuid.Nil {
-		profile.ID = uuid.New()
-	}
+	// the very first profile becomes the admin so someone can manage the instance. The table
+	// stays locked until the insert commits, so two first profiles can't both be it.
+	var createdProfile database.Profile
+	err := runInTx(ctx, s.Conn, s.DB, func(q *database.Queries) error {
+		if err := q.LockProfiles(ctx); err != nil {
+			return err
+		}
+		count, err := q.GetProfilesCount(ctx)
+		if err != nil {
+			return err
+		}
 
-	// let database handle the creation
-	createdProfile, err := s.DB.CreateProfile(ctx, database.CreateProfileParams{
-		ID:   profile.ID,
-		Name: profile.Name,
+		role := access.RoleViewer
+		if count == 0 {
+			role = access.RoleAdmin
+		}
+		createdProfile, err = q.CreateProfile(ctx, database.CreateProfileParams{
+			ID:   uuid.New(),
+			Name: profile.Name,
+			Role: role,
+		})
+		return err
 	})
 	if err != nil {
 		log.Printf("Error creating profile: %v", err)
 		return models.Profile{}, fmt.Errorf("failed to create profile: %w", err)
 	}
+	if createdProfile.Role == access.RoleAdmin {
+		log.Printf("Profile %s is the first profile and was made admin", createdProfile.ID)
+	}
 
 	// convert back to app model
-	return models.Profile{
-		ID:        createdProfile.ID,
-		Name:      createdProfile.Name,
-		CreatedAt: createdProfile.CreatedAt,
-		UpdatedAt: createdProfile.UpdatedAt,
-	}, nil
+	return s.toProfileModel(createdProfile), nil
 }
 
 // UpdateProfileName updates profile name by user ID (changed from name-based to ID-based for safety)
@@ -99,12 +132,7 @@ func (s *ProfileService) UpdateProfileName(ctx context.Context, userID uuid.UUID
 	}
 
 	// convert back to app model
-	return models.Profile{
-		ID:        updatedProfile.ID,
-		Name:      updatedProfile.Name,
-		CreatedAt: updatedProfile.CreatedAt,
-		UpdatedAt: updatedProfile.UpdatedAt,
-	}, nil
+	return s.toProfileModel(updatedProfile), nil
 }
 
 // GetProfileByID retrieves a profile by its ID
@@ -117,12 +145,7 @@ func (s *ProfileService) GetProfileByID(ctx context.Context, id uuid.UUID) (mode
 	}
 
 	// convert back to app model
-	return models.Profile{
-		ID:        dbProfile.ID,
-		Name:      dbProfile.Name,
-		CreatedAt: dbProfile.CreatedAt,
-		UpdatedAt: dbProfile.UpdatedAt,
-	}, nil
+	return s.toProfileModel(dbProfile), nil
 }
 
 // DeleteProfileByID deletes a profile by user ID (safer than name-based deletion)
@@ -138,5 +161,100 @@ func (s *ProfileService) DeleteProfileByID(ctx context.Context, userID uuid.UUID
 		return fmt.Errorf("failed to delete profile: %w", err)
 	}
 
+	// the profile is gone either way, a leftover picture just wastes space
+	if err := s.removeAvatar(userID); err != nil {
+		log.Printf("Error removing avatar of deleted profile %s: %v", userID, err)
+	}
+
 	return nil
 }
+
+// IsAdmin checks whether the given profile has admin rights
+func (s *ProfileService) IsAdmin(ctx context.Context, userID uuid.UUID) (bool, error) {
+	role, err := s.GetRole(ctx, userID)
+	return role == access.RoleAdmin, err
+}
+
+// GetRole returns the role of the given profile, empty for no profile. For the profile
+// of a request made with an API token that's capped at the token's scope.
+func (s *ProfileService) GetRole(ctx context.Context, userID uuid.UUID) (string, error) {
+	if userID == uuid.Nil {
+		return "", nil
+	}
+
+	profile, err := s.DB.GetProfileById(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get profile by ID: %w", err)
+	}
+
+	return scopedRole(ctx, userID, profile.Role), nil
+}
+
+// scopedRole caps a profile's role at the scope of the API token the request was made with
+func scopedRole(ctx context.Context, userID uuid.UUID, role string) string {
+	if token := session.CurrentAPIToken(ctx); token != nil && token.ProfileID == userID && access.Allows(token.Scope, role) {
+		return token.Scope
+	}
+	return role
+}
+
+// SetRole changes what a profile may do, see access.ProfileRoles
+func (s *ProfileService) SetRole(ctx context.Context, userID uuid.UUID, role string) (models.Profile, error) {
+	if userID == uuid.Nil {
+		return models.Profile{}, errors.New("user ID cannot be empty")
+	}
+	if !access.ValidProfileRole(role) {
+		return models.Profile{}, fmt.Errorf("%w: %q, must be one of %s", ErrInvalidRole, role, strings.Join(access.ProfileRoles, ", "))
+	}
+
+	profile, err := s.DB.GetProfileById(ctx, userID)
+	if err != nil {
+		return models.Profile{}, fmt.Errorf("failed to get profile by ID: %w", err)
+	}
+	if profile.IsGuest && role != access.RoleViewer {
+		return models.Profile{}, fmt.Errorf("%w: guest profiles stay viewers", ErrInvalidRole)
+	}
+
+	updatedProfile, err := s.DB.SetProfileRole(ctx, database.SetProfileRoleParams{
+		ID:   userID,
+		Role: role,
+	})
+	if err != nil {
+		log.Printf("Error updating role: %v", err)
+		return models.Profile{}, fmt.Errorf("failed to update role: %w", err)
+	}
+
+	return s.toProfileModel(updatedProfile), nil
+}
+
+// SetAdmin grants or revokes admin rights for a profile. Revoking leaves a viewer, use
+// SetRole to make it an editor instead.
+func (s *ProfileService) SetAdmin(ctx context.Context, userID uuid.UUID, isAdmin bool) (models.Profile, error) {
+	role := access.RoleViewer
+	if isAdmin {
+		role = access.RoleAdmin
+	}
+	return s.SetRole(ctx, userID, role)
+}
+
+// toProfileModel converts the db row, working out the level from the profile's XP
+func (s *ProfileService) toProfileModel(p database.Profile) models.Profile {
+	level, toNext := s.Rules.Levels.Level(int(p.Experience))
+	return models.Profile{
+		ID:             p.ID,
+		Name:           p.Name,
+		AvatarURL:      avatarURL(p.ID, p.AvatarUpdatedAt),
+		CreatedAt:      p.CreatedAt,
+		UpdatedAt:      p.UpdatedAt,
+		Role:           p.Role,
+		IsAdmin:        p.Role == access.RoleAdmin,
+		IsGuest:        p.IsGuest,
+		Experience:     int(p.Experience),
+		Level:          level,
+		XPToNextLevel:  toNext,
+		Gems:           int(p.Gems),
+		StreakFreezes:  int(p.StreakFreezes),
+		Streak:         currentStreak(p.Streak, p.LastActiveDate, s.Location),
+		LastActiveDate: p.LastActiveDate,
+	}
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/access"
+	"github.com/NeroQue/course-management-backend/pkg/rewards"
+	"github.com/NeroQue/course-management-backend/pkg/session"
+	"github.com/google/uuid"
+)
+
+func TestScopedRole(t *testing.T) {
+	userID, otherID := uuid.New(), uuid.New()
+	withToken := func(profileID uuid.UUID, scope string) context.Context {
+		return session.WithAPIToken(context.Background(), &session.APIToken{ID: uuid.New(), ProfileID: profileID, Scope: scope})
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		role string
+		want string
+	}{
+		{"session keeps the profile's role", session.WithSession(context.Background(), nil, ""), access.RoleAdmin, access.RoleAdmin},
+		{"viewer token on an admin", withToken(userID, access.RoleViewer), access.RoleAdmin, access.RoleViewer},
+		{"editor token on an admin", withToken(userID, access.RoleEditor), access.RoleAdmin, access.RoleEditor},
+		{"token as high as the profile", withToken(userID, access.RoleEditor), access.RoleEditor, access.RoleEditor},
+		{"admin token on a demoted profile", withToken(userID, access.RoleAdmin), access.RoleViewer, access.RoleViewer},
+		{"token of another profile", withToken(otherID, access.RoleViewer), access.RoleAdmin, access.RoleAdmin},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scopedRole(tt.ctx, userID, tt.role); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetRole(t *testing.T) {
+	editorID, missingID := uuid.New(), uuid.New()
+	_, _, queries := newFakeDB(t, map[string]fakeAnswer{
+		"GetProfileById": func(args []driver.Value) ([][]driver.Value, error) {
+			if args[0] != editorID.String() {
+				return nil, nil
+			}
+			row := profileRow(editorID, "editor", 0, 0)
+			row[11] = access.RoleEditor
+			return [][]driver.Value{row}, nil
+		},
+	})
+	s := &ProfileService{DB: queries}
+	withToken := func(scope string) context.Context {
+		return session.WithAPIToken(context.Background(), &session.APIToken{ID: uuid.New(), ProfileID: editorID, Scope: scope})
+	}
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		userID uuid.UUID
+		want   string
+		err    error
+	}{
+		{"nobody", context.Background(), uuid.Nil, "", nil},
+		{"profile", context.Background(), editorID, access.RoleEditor, nil},
+		{"viewer token", withToken(access.RoleViewer), editorID, access.RoleViewer, nil},
+		{"admin token", withToken(access.RoleAdmin), editorID, access.RoleEditor, nil},
+		{"deleted profile", context.Background(), missingID, "", sql.ErrNoRows},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role, err := s.GetRole(tt.ctx, tt.userID)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("got error %v, want %v", err, tt.err)
+			}
+			if role != tt.want {
+				t.Errorf("got %q, want %q", role, tt.want)
+			}
+		})
+	}
+
+	if admin, err := s.IsAdmin(withToken(access.RoleAdmin), editorID); admin || err != nil {
+		t.Errorf("editor with an admin token is admin %v, %v", admin, err)
+	}
+}
+
+func TestCreateProfileFirstIsAdmin(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing int64
+		want     string
+	}{
+		{"first profile", 0, access.RoleAdmin},
+		{"any later one", 1, access.RoleViewer},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, conn, queries := newFakeDB(t, map[string]fakeAnswer{
+				"LockProfiles":     rows(),
+				"GetProfilesCount": rows([]driver.Value{tt.existing}),
+				"CreateProfile": func(args []driver.Value) ([][]driver.Value, error) {
+					id, _ := uuid.Parse(args[0].(string))
+					row := profileRow(id, args[1].(string), 0, 0)
+					row[11] = args[2]
+					return [][]driver.Value{row}, nil
+				},
+			})
+			s := &ProfileService{DB: queries, Conn: conn, Location: time.UTC, Rules: rewards.Default()}
+
+			// an ID from the client is ignored
+			asked := uuid.New()
+			profile, err := s.CreateProfile(context.Background(), models.Profile{ID: asked, Name: "Ada"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if profile.Role != tt.want {
+				t.Errorf("got role %q, want %q", profile.Role, tt.want)
+			}
+			if profile.ID == asked || profile.ID == uuid.Nil {
+				t.Errorf("profile got ID %s, want a new one", profile.ID)
+			}
+
+			// the count only means something with the table locked
+			var order []string
+			for _, call := range db.calls {
+				order = append(order, call.name)
+			}
+			if len(order) != 3 || order[0] != "LockProfiles" || order[1] != "GetProfilesCount" {
+				t.Errorf("ran %v, want the lock first", order)
+			}
+			if db.commits != 1 {
+				t.Errorf("%d commits, want 1", db.commits)
+			}
+		})
+	}
+}
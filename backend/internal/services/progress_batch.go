@@ -0,0 +1,231 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// ResetCourseProgress deletes all of a profile's progress on a course's items so it can
+// take the course again from the start. Watch time, streaks and the activity timeline
+// are history and stay; the timeline gets a course.reset entry.
+func (s *CourseService) ResetCourseProgress(ctx context.Context, userID, courseID uuid.UUID) (*models.ProgressReset, error) {
+	course, err := s.DB.GetCourse(ctx, courseID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("course not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving course: %w", err)
+	}
+
+	reset := &models.ProgressReset{CourseID: courseID, UserID: userID}
+	err = runInTx(ctx, s.Conn, s.DB, func(q *database.Queries) error {
+		deleted, err := q.DeleteUserCourseProgress(ctx, database.DeleteUserCourseProgressParams{
+			UserID:   userID,
+			CourseID: courseID,
+		})
+		if err != nil {
+			return fmt.Errorf("error deleting progress: %w", err)
+		}
+		reset.ItemsReset = deleted
+
+		return recordActivityEvent(ctx, q, userID, models.ActivityCourseReset, course.ID, uuid.Nil, course.Title)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reset, nil
+}
+
+// progress transfer errors
+var (
+	ErrInvalidTransfer         = errors.New("invalid progress transfer") // same profile twice or an unknown mode
+	ErrTransferProfileNotFound = errors.New("profile not found")
+)
+
+// TransferCourseProgress copies a course's progress from one profile to another, like when
+// someone used the wrong profile for a while. Where both have progress on an item the
+// furthest wins. A move also deletes the source's progress in the course. XP and streaks
+// stay where they were earned, and neither timeline changes.
+func (s *CourseService) TransferCourseProgress(ctx context.Context, courseID uuid.UUID, input models.TransferProgressInput) (*models.ProgressTransfer, error) {
+	if input.Mode == "" {
+		input.Mode = models.TransferCopy
+	}
+	if input.Mode != models.TransferCopy && input.Mode != models.TransferMove {
+		return nil, fmt.Errorf("%w: mode must be copy or move", ErrInvalidTransfer)
+	}
+	if input.SourceID == uuid.Nil || input.TargetID == uuid.Nil {
+		return nil, fmt.Errorf("%w: source_id and target_id are required", ErrInvalidTransfer)
+	}
+	if input.SourceID == input.TargetID {
+		return nil, fmt.Errorf("%w: source and target are the same profile", ErrInvalidTransfer)
+	}
+
+	if _, err := s.DB.GetCourse(ctx, courseID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("course not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving course: %w", err)
+	}
+
+	transfer := &models.ProgressTransfer{
+		CourseID: courseID,
+		SourceID: input.SourceID,
+		TargetID: input.TargetID,
+		Mode:     input.Mode,
+	}
+	err := runInTx(ctx, s.Conn, s.DB, func(q *database.Queries) error {
+		for _, id := range []uuid.UUID{input.SourceID, input.TargetID} {
+			if _, err := q.GetProfileById(ctx, id); err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return fmt.Errorf("%w: %s", ErrTransferProfileNotFound, id)
+				}
+				return fmt.Errorf("error retrieving profile %s: %w", id, err)
+			}
+		}
+
+		copied, err := q.CopyCourseProgress(ctx, database.CopyCourseProgressParams{
+			TargetID: input.TargetID,
+			SourceID: input.SourceID,
+			CourseID: courseID,
+		})
+		if err != nil {
+			return fmt.Errorf("error copying progress: %w", err)
+		}
+		transfer.ItemsTransferred = copied
+
+		if input.Mode == models.TransferMove {
+			removed, err := q.DeleteUserCourseProgress(ctx, database.DeleteUserCourseProgressParams{
+				UserID:   input.SourceID,
+				CourseID: courseID,
+			})
+			if err != nil {
+				return fmt.Errorf("error deleting source progress: %w", err)
+			}
+			transfer.ItemsRemoved = removed
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// CompleteModule marks every visible item of a module complete for a profile in one statement
+func (s *CourseService) CompleteModule(ctx context.Context, userID, moduleID uuid.UUID) (*models.BulkCompletion, error) {
+	module, err := s.DB.GetModule(ctx, moduleID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("module not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving module: %w", err)
+	}
+
+	result, err := s.completeItems(ctx, userID, module.CourseID, func() (int64, error) {
+		return s.DB.CompleteModuleItems(ctx, database.CompleteModuleItemsParams{
+			UserID:   userID,
+			ModuleID: moduleID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.ModuleID = moduleID
+
+	if result.ItemsCompleted > 0 {
+		s.publishModuleCompleted(ctx, userID, moduleID)
+	}
+	return result, nil
+}
+
+// CompleteCourse marks every visible item of a course complete for a profile in one statement
+func (s *CourseService) CompleteCourse(ctx context.Context, userID, courseID uuid.UUID) (*models.BulkCompletion, error) {
+	if _, err := s.DB.GetCourse(ctx, courseID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("course not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving course: %w", err)
+	}
+
+	return s.completeItems(ctx, userID, courseID, func() (int64, error) {
+		return s.DB.CompleteCourseItems(ctx, database.CompleteCourseItemsParams{
+			UserID:   userID,
+			CourseID: courseID,
+		})
+	})
+}
+
+// completeItems runs a bulk completion and does the bookkeeping single completions do:
+// the streak, and course.completed on the timeline and the event bus when this finished
+// the course. Items don't get a timeline entry or event each, a whole course of them
+// would bury everything else.
+func (s *CourseService) completeItems(ctx context.Context, userID, courseID uuid.UUID, complete func() (int64, error)) (*models.BulkCompletion, error) {
+	before, err := s.CalculateCourseProgress(ctx, userID, courseID)
+	if err != nil {
+		return nil, fmt.Errorf("error calculating course progress: %w", err)
+	}
+
+	completed, err := complete()
+	if err != nil {
+		return nil, fmt.Errorf("error completing content items: %w", err)
+	}
+
+	result := &models.BulkCompletion{
+		CourseID:       courseID,
+		UserID:         userID,
+		ItemsCompleted: completed,
+	}
+	if completed == 0 {
+		result.CourseCompleted = before.IsCompleted
+		return result, nil
+	}
+	s.recordActivity(ctx, userID)
+
+	after, err := s.CalculateCourseProgress(ctx, userID, courseID)
+	if err != nil {
+		return nil, fmt.Errorf("error calculating course progress: %w", err)
+	}
+	result.CourseCompleted = after.IsCompleted
+
+	if after.IsCompleted && !before.IsCompleted {
+		s.recordCourseCompleted(ctx, userID, courseID)
+	}
+	return result, nil
+}
+
+// ListProgressRecords returns every progress record of a profile across the library, by
+// course and then in course order, for exporting
+func (s *CourseService) ListProgressRecords(ctx context.Context, userID uuid.UUID) ([]models.ProgressRecord, error) {
+	rows, err := s.DB.ListUserProgressRecords(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving progress: %w", err)
+	}
+
+	records := make([]models.ProgressRecord, 0, len(rows))
+	for _, row := range rows {
+		record := models.ProgressRecord{
+			CourseID:      row.CourseID,
+			CourseTitle:   row.CourseTitle,
+			ModuleTitle:   row.ModuleTitle,
+			ContentItemID: row.ContentItemID,
+			ContentTitle:  row.ContentTitle,
+			ContentType:   row.ContentType,
+			ProgressPct:   row.ProgressPct,
+			Completed:     row.Completed,
+		}
+		if row.CompletedAt.Valid {
+			record.CompletedAt = &row.CompletedAt.Time
+		}
+		if row.LastAccessed.Valid {
+			record.LastAccessed = &row.LastAccessed.Time
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
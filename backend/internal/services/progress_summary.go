@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// itemProgress is a visible content item with the profile's progress on it, nil when not started
+type itemProgress struct {
+	item     *models.ContentItem
+	progress *database.UserProgress
+}
+
+// newItemProgress builds an itemProgress out of a row of the progress queries, whose
+// progress columns are all null for items that weren't started
+func newItemProgress(itemID uuid.UUID, contentType string, duration sql.NullInt32, size sql.NullInt64,
+	completed sql.NullBool, pct sql.NullFloat64, position sql.NullInt32, lastAccessed sql.NullTime) itemProgress {
+	entry := itemProgress{item: &models.ContentItem{
+		ID:          itemID,
+		ContentType: contentType,
+		Duration:    int(duration.Int32),
+		Size:        size.Int64,
+	}}
+	if completed.Valid {
+		entry.progress = &database.UserProgress{
+			ContentItemID: itemID,
+			Completed:     completed.Bool,
+			ProgressPct:   float32(pct.Float64),
+			LastPosition:  position,
+			LastAccessed:  lastAccessed,
+		}
+	}
+	return entry
+}
+
+// moduleItems returns a module's visible items with the profile's progress on them, in one query
+func (s *CourseService) moduleItems(ctx context.Context, userID, moduleID uuid.UUID) ([]itemProgress, error) {
+	rows, err := s.DB.ListModuleItemProgress(ctx, database.ListModuleItemProgressParams{
+		UserID:   userID,
+		ModuleID: moduleID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get module progress: %w", err)
+	}
+
+	items := make([]itemProgress, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, newItemProgress(row.ContentItemID, row.ContentType, row.Duration, row.Size,
+			row.Completed, row.ProgressPct, row.LastPosition, row.LastAccessed))
+	}
+	return items, nil
+}
+
+// CalculateCoursesProgress computes progress on several courses at once, from a single
+// query over their modules, items and the profile's progress - so a dashboard listing
+// many courses costs one round trip instead of one per item. Every course asked for is
+// in the result; unknown ones come back empty, and so complete.
+func (s *CourseService) CalculateCoursesProgress(ctx context.Context, userID uuid.UUID, courseIDs []uuid.UUID, weighting string) (map[uuid.UUID]*models.CourseProgress, error) {
+	progress, _, err := s.coursesProgress(ctx, userID, courseIDs, weighting)
+	return progress, err
+}
+
+// coursesProgress computes progress on several courses along with each one's total and
+// remaining study time
+func (s *CourseService) coursesProgress(ctx context.Context, userID uuid.UUID, courseIDs []uuid.UUID, weighting string) (map[uuid.UUID]*models.CourseProgress, map[uuid.UUID]studyTime, error) {
+	rows, err := s.DB.ListCoursesItemProgress(ctx, database.ListCoursesItemProgressParams{
+		UserID:    userID,
+		CourseIds: courseIDs,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get course progress: %w", err)
+	}
+
+	// rows come grouped by course and then module, in order; a module without visible
+	// items is one row without an item
+	type moduleItems struct {
+		id    uuid.UUID
+		items []itemProgress
+	}
+	courses := make(map[uuid.UUID][]*moduleItems, len(courseIDs))
+	for _, row := range rows {
+		modules := courses[row.CourseID]
+		if len(modules) == 0 || modules[len(modules)-1].id != row.ModuleID {
+			modules = append(modules, &moduleItems{id: row.ModuleID})
+			courses[row.CourseID] = modules
+		}
+		if !row.ContentItemID.Valid {
+			continue
+		}
+		module := modules[len(modules)-1]
+		module.items = append(module.items, newItemProgress(row.ContentItemID.UUID, row.ContentType.String,
+			row.Duration, row.Size, row.Completed, row.ProgressPct, row.LastPosition, row.LastAccessed))
+	}
+
+	result := make(map[uuid.UUID]*models.CourseProgress, len(courseIDs))
+	studies := make(map[uuid.UUID]studyTime, len(courseIDs))
+	for _, courseID := range courseIDs {
+		progress := &models.CourseProgress{
+			CourseID:  courseID,
+			UserID:    userID,
+			Weighting: weighting,
+		}
+
+		var study studyTime
+		for _, module := range courses[courseID] {
+			moduleProgress, moduleStudy := s.summarizeModule(userID, module.id, module.items, weighting)
+			if moduleProgress.IsCompleted {
+				progress.CompletedModules++
+			}
+			progress.TotalModules++
+			progress.CompletedItems += moduleProgress.CompletedItems
+			progress.TotalItems += moduleProgress.TotalItems
+			study.add(moduleStudy.total, moduleStudy.left)
+
+			if at := moduleProgress.LastAccessedAt; at != nil && (progress.LastAccessedAt == nil || at.After(*progress.LastAccessedAt)) {
+				progress.LastAccessedAt = at
+			}
+		}
+
+		if progress.TotalItems > 0 {
+			progress.CompletionPct = float32(progress.CompletedItems) / float32(progress.TotalItems) * 100
+		}
+		if weighting == WeightByDuration {
+			progress.CompletionPct = study.percent()
+		}
+		progress.IsCompleted = progress.CompletedModules == progress.TotalModules // empty course is considered complete
+		progress.EstimatedTimeLeft = minutes(study.left)
+		result[courseID] = progress
+		studies[courseID] = study
+	}
+	return result, studies, nil
+}
+
+// summarizeModule works a module's progress out of its visible items, along with its total
+// and remaining study time
+func (s *CourseService) summarizeModule(userID, moduleID uuid.UUID, items []itemProgress, weighting string) (*models.ModuleProgress, studyTime) {
+	progress := &models.ModuleProgress{
+		ModuleID:   moduleID,
+		UserID:     userID,
+		TotalItems: len(items),
+		Weighting:  weighting,
+	}
+
+	var study studyTime
+	for _, entry := range items {
+		study.add(s.itemStudyTime(entry.item, entry.progress))
+		if entry.progress == nil {
+			continue
+		}
+
+		if entry.progress.Completed {
+			progress.CompletedItems++
+		}
+		// track most recent access time
+		if entry.progress.LastAccessed.Valid {
+			accessTime := entry.progress.LastAccessed.Time
+			if progress.LastAccessedAt == nil || accessTime.After(*progress.LastAccessedAt) {
+				progress.LastAccessedAt = &accessTime
+			}
+		}
+	}
+
+	if len(items) > 0 {
+		progress.CompletionPct = float32(progress.CompletedItems) / float32(len(items)) * 100
+		if weighting == WeightByDuration {
+			progress.CompletionPct = study.percent()
+		}
+	}
+	progress.IsCompleted = progress.CompletedItems == len(items) // empty module is considered complete
+	progress.EstimatedTimeLeft = minutes(study.left)
+	return progress, study
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// maxSyncUpdates keeps one sync request to a sane size, clients can send several
+const maxSyncUpdates = 1000
+
+// clientClockSkew is how far in the future a client timestamp may be before it's distrusted
+const clientClockSkew = 5 * time.Minute
+
+// ErrInvalidSync is returned for an empty or oversized sync batch
+var ErrInvalidSync = errors.New("invalid progress sync")
+
+// SyncProgress applies progress a client recorded while offline. Updates only ever move
+// progress forward - the furthest progress per item wins, whatever order the updates
+// arrive in - so sending the same batch twice changes nothing. Updates for unknown items
+// or with impossible values are skipped and reported, the rest go in one transaction.
+func (s *CourseService) SyncProgress(ctx context.Context, userID uuid.UUID, updates []models.ProgressSyncUpdate) (*models.ProgressSyncResult, error) {
+	if len(updates) == 0 {
+		return nil, fmt.Errorf("%w: updates are required", ErrInvalidSync)
+	}
+	if len(updates) > maxSyncUpdates {
+		return nil, fmt.Errorf("%w: at most %d updates per sync", ErrInvalidSync, maxSyncUpdates)
+	}
+
+	result := &models.ProgressSyncResult{Progress: []*models.UserProgress{}}
+	now := time.Now()
+
+	// several updates for one item collapse into the furthest of them
+	var order []uuid.UUID
+	furthest := make(map[uuid.UUID]models.ProgressSyncUpdate)
+	for _, update := range updates {
+		if update.ProgressPct < 0 || update.ProgressPct > 100 || update.LastPosition < 0 {
+			result.Skipped = append(result.Skipped, models.ProgressSyncSkip{
+				ContentItemID: update.ContentItemID,
+				Reason:        "progress_pct must be between 0 and 100 and last_position can't be negative",
+			})
+			continue
+		}
+		if update.ClientTimestamp.IsZero() || update.ClientTimestamp.After(now.Add(clientClockSkew)) {
+			update.ClientTimestamp = now
+		}
+
+		current, seen := furthest[update.ContentItemID]
+		if !seen {
+			order = append(order, update.ContentItemID)
+		}
+		if !seen || isFurther(update, current) {
+			furthest[update.ContentItemID] = update
+		}
+	}
+
+	type pending struct {
+		update models.ProgressSyncUpdate
+		before *database.UserProgress
+	}
+	var apply []pending
+	for _, itemID := range order {
+		if _, err := s.DB.GetContentItem(ctx, itemID); err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				return nil, fmt.Errorf("error retrieving content item: %w", err)
+			}
+			result.Skipped = append(result.Skipped, models.ProgressSyncSkip{
+				ContentItemID: itemID,
+				Reason:        "content item not found",
+			})
+			continue
+		}
+		apply = append(apply, pending{update: furthest[itemID], before: s.progressBefore(ctx, userID, itemID)})
+	}
+
+	rows := make([]database.UserProgress, 0, len(apply))
+	err := runInTx(ctx, s.Conn, s.DB, func(q *database.Queries) error {
+		for _, p := range apply {
+			row, err := q.SyncUserProgress(ctx, database.SyncUserProgressParams{
+				UserID:        userID,
+				ContentItemID: p.update.ContentItemID,
+				Completed:     p.update.Completed,
+				ProgressPct:   p.update.ProgressPct,
+				LastPosition:  sql.NullInt32{Int32: int32(p.update.LastPosition), Valid: p.update.LastPosition > 0},
+				// zoneless column, stored as server time like every other progress write
+				LastAccessed: sql.NullTime{Time: p.update.ClientTimestamp.In(time.Local), Valid: true},
+			})
+			if err != nil {
+				return fmt.Errorf("error syncing progress: %w", err)
+			}
+			rows = append(rows, row)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the days the client was active count for the streak, not just today
+	days := make(map[time.Time]bool)
+	for i, p := range apply {
+		days[activityDay(p.update.ClientTimestamp, s.Location)] = true
+		s.recordProgressEvents(ctx, userID, p.update.ContentItemID, p.before, rows[i].Completed)
+		result.Progress = append(result.Progress, toUserProgressModel(rows[i]))
+	}
+	for day := range days {
+		if _, err := s.DB.RecordDailyActivity(ctx, database.RecordDailyActivityParams{ProfileID: userID, Day: day}); err != nil {
+			log.Printf("Warning: could not record activity for profile %s: %v", userID, err)
+		}
+	}
+	if len(days) > 0 {
+		if _, _, err := s.syncStreak(ctx, userID); err != nil {
+			log.Printf("Warning: could not update streak for profile %s: %v", userID, err)
+		}
+	}
+
+	result.Applied = len(rows)
+	return result, nil
+}
+
+// isFurther reports whether update a got further through an item than b, the later one
+// winning a tie
+func isFurther(a, b models.ProgressSyncUpdate) bool {
+	if a.Completed != b.Completed {
+		return a.Completed
+	}
+	if a.ProgressPct != b.ProgressPct {
+		return a.ProgressPct > b.ProgressPct
+	}
+	return a.ClientTimestamp.After(b.ClientTimestamp)
+}
+
+// toUserProgressModel converts the db row to the app model
+func toUserProgressModel(row database.UserProgress) *models.UserProgress {
+	return &models.UserProgress{
+		ID:            row.ID,
+		UserID:        row.UserID,
+		ContentItemID: row.ContentItemID,
+		Completed:     row.Completed,
+		ProgressPct:   row.ProgressPct,
+		LastPosition:  int(row.LastPosition.Int32),
+		LastAccessed:  row.LastAccessed,
+		CompletedAt:   row.CompletedAt,
+		CreatedAt:     row.CreatedAt,
+		UpdatedAt:     row.UpdatedAt,
+	}
+}
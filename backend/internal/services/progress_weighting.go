@@ -0,0 +1,74 @@
+package services
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+)
+
+// what completion percentages count
+const (
+	WeightByItems    = "items"    // every item counts the same
+	WeightByDuration = "duration" // items count by how long they take to study
+)
+
+// ValidWeighting reports whether w names a completion weighting
+func ValidWeighting(w string) bool {
+	return w == WeightByItems || w == WeightByDuration
+}
+
+// progressWeighting is the deployment's default weighting, PROGRESS_WEIGHTING. Counting
+// items stays the default, it's what percentages have always meant.
+func progressWeighting() string {
+	weighting := os.Getenv("PROGRESS_WEIGHTING")
+	if weighting == "" {
+		return WeightByItems
+	}
+	if !ValidWeighting(weighting) {
+		log.Printf("Warning: invalid PROGRESS_WEIGHTING %q, counting items", weighting)
+		return WeightByItems
+	}
+	return weighting
+}
+
+// studyTime adds up how long items take to study and how much of that is left
+type studyTime struct {
+	total time.Duration
+	left  time.Duration
+}
+
+func (t *studyTime) add(total, left time.Duration) {
+	t.total += total
+	t.left += left
+}
+
+// percent is how much of the study time is done, 0-100
+func (t studyTime) percent() float32 {
+	if t.total <= 0 {
+		return 0
+	}
+	return float32(t.total-t.left) / float32(t.total) * 100
+}
+
+// itemStudyTime estimates how long an item takes to study and how much of that is left.
+// Videos with a known duration go by where playback stopped, everything else by its
+// progress percentage; progress is nil when the item wasn't started.
+func (s *CourseService) itemStudyTime(item *models.ContentItem, progress *database.UserProgress) (total, left time.Duration) {
+	total = s.Heuristics.Item(item.ContentType, item.Size, item.Duration).Duration
+	if progress == nil {
+		return total, total
+	}
+	if progress.Completed {
+		return total, 0
+	}
+
+	done := float64(progress.ProgressPct) / 100
+	if item.ContentType == "video" && item.Duration > 0 && progress.LastPosition.Int32 > 0 {
+		done = float64(progress.LastPosition.Int32) / float64(item.Duration)
+	}
+	done = min(max(done, 0), 1)
+	return total, time.Duration(float64(total) * (1 - done))
+}
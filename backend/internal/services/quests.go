@@ -0,0 +1,314 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/events"
+	"github.com/NeroQue/course-management-backend/pkg/rewards"
+	"github.com/google/uuid"
+)
+
+// weekly quest targets
+const (
+	questItems   = 10
+	questVideos  = 5
+	questMinutes = 120
+	questModules = 1
+)
+
+// QuestService hands each profile a few challenges every week, picked from a rotation so
+// consecutive weeks differ, and pays a bonus in XP and gems for each one finished.
+// Progress is counted from what the profile did since Monday, so quests handed out midweek
+// start with whatever already counts.
+type QuestService struct {
+	DB           *database.Queries    // database access
+	Conn         *sql.DB              // raw connection for transactions
+	Courses      *CourseService       // course completion for module quests
+	Gamification *GamificationService // announces levels reached with quest XP
+	Location     *time.Location       // where a week starts, same as for streaks
+	Rules        *rewards.Rules       // quests per week and their rewards
+}
+
+// NewQuestService creates service with its dependencies
+func NewQuestService(db *database.Queries, conn *sql.DB, courses *CourseService, gamification *GamificationService, rules *rewards.Rules) *QuestService {
+	return &QuestService{
+		DB:           db,
+		Conn:         conn,
+		Courses:      courses,
+		Gamification: gamification,
+		Location:     activityLocation(),
+		Rules:        rules,
+	}
+}
+
+// GetQuests returns a profile's quests for this week, handing them out on the first call
+// of the week
+func (s *QuestService) GetQuests(ctx context.Context, profileID uuid.UUID) (*models.QuestBoard, error) {
+	week := weekStart(activityDay(time.Now(), s.Location))
+	if err := s.handOut(ctx, profileID, week); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.refresh(ctx, profileID, week)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := s.weekBounds(week)
+	board := &models.QuestBoard{
+		Week:   start,
+		EndsAt: end,
+		Quests: make([]models.Quest, 0, len(rows)),
+	}
+	for _, row := range rows {
+		board.Quests = append(board.Quests, toQuestModel(row))
+	}
+	return board, nil
+}
+
+// TrackProgress brings a profile's quests up to date after a completion event. Profiles
+// that haven't looked at their quests this week have none yet; theirs catch up when they do.
+func (s *QuestService) TrackProgress(ctx context.Context, event events.Event) error {
+	week := weekStart(activityDay(time.Now(), s.Location))
+	_, err := s.refresh(ctx, event.ProfileID, week)
+	return err
+}
+
+// handOut gives a profile its quests for a week unless it has them already. Which ones
+// is decided by hashing the profile and week, so the pick is stable within the week and
+// rotates from one to the next.
+func (s *QuestService) handOut(ctx context.Context, profileID uuid.UUID, week time.Time) error {
+	existing, err := s.DB.ListQuests(ctx, database.ListQuestsParams{ProfileID: profileID, Week: week})
+	if err != nil {
+		return fmt.Errorf("error retrieving quests: %w", err)
+	}
+	if len(existing) > 0 || s.Rules.Quests.PerWeek == 0 {
+		return nil
+	}
+
+	candidates := []database.CreateQuestParams{
+		{QuestType: models.QuestCompleteItems, Title: fmt.Sprintf("Complete %d items", questItems), Target: questItems},
+		{QuestType: models.QuestCompleteVideos, Title: fmt.Sprintf("Complete %d videos", questVideos), Target: questVideos},
+		{QuestType: models.QuestStudyMinutes, Title: fmt.Sprintf("Study for %d minutes", questMinutes), Target: questMinutes},
+	}
+	course, err := s.unfinishedCourse(ctx, profileID)
+	if err != nil {
+		return err
+	}
+	if course != nil {
+		candidates = append(candidates, database.CreateQuestParams{
+			QuestType: models.QuestFinishModule,
+			Title:     "Finish a module of " + course.Title,
+			Target:    questModules,
+			CourseID:  uuid.NullUUID{UUID: course.ID, Valid: true},
+		})
+	}
+
+	rank := func(questType string) uint64 {
+		h := fnv.New64a()
+		h.Write(profileID[:])
+		h.Write([]byte(week.Format(time.DateOnly) + questType))
+		return h.Sum64()
+	}
+	slices.SortFunc(candidates, func(a, b database.CreateQuestParams) int {
+		ra, rb := rank(a.QuestType), rank(b.QuestType)
+		switch {
+		case ra < rb:
+			return -1
+		case ra > rb:
+			return 1
+		}
+		return 0
+	})
+
+	// two requests racing to hand out the same week both land on the same quests,
+	// the unique key drops the second set
+	for _, quest := range candidates[:min(len(candidates), s.Rules.Quests.PerWeek)] {
+		quest.ProfileID, quest.Week = profileID, week
+		quest.RewardXp, quest.RewardGems = int32(s.Rules.Quests.XP), int32(s.Rules.Quests.Gems)
+		if _, err := s.DB.CreateQuest(ctx, quest); err != nil {
+			return fmt.Errorf("error saving quest: %w", err)
+		}
+	}
+	return nil
+}
+
+// unfinishedCourse returns the most recently studied course the profile hasn't finished,
+// nil when there's none
+func (s *QuestService) unfinishedCourse(ctx context.Context, profileID uuid.UUID) (*database.Course, error) {
+	courseIDs, err := s.DB.ListStartedCourseIDs(ctx, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving started courses: %w", err)
+	}
+	if len(courseIDs) == 0 {
+		return nil, nil
+	}
+
+	progress, _, err := s.Courses.coursesProgress(ctx, profileID, courseIDs, s.Courses.Weighting)
+	if err != nil {
+		return nil, err
+	}
+	for _, courseID := range courseIDs {
+		if progress[courseID] == nil || progress[courseID].IsCompleted {
+			continue
+		}
+		course, err := s.DB.GetCourse(ctx, courseID)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving course: %w", err)
+		}
+		return &course, nil
+	}
+	return nil, nil
+}
+
+// refresh recounts the progress of a profile's quests for a week, paying out the ones
+// that are finished now, and returns them
+func (s *QuestService) refresh(ctx context.Context, profileID uuid.UUID, week time.Time) ([]database.Quest, error) {
+	rows, err := s.DB.ListQuests(ctx, database.ListQuestsParams{ProfileID: profileID, Week: week})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving quests: %w", err)
+	}
+
+	for i, row := range rows {
+		if row.CompletedAt.Valid {
+			continue
+		}
+		progress, err := s.progress(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		progress = min(progress, int(row.Target))
+		if progress == int(row.Progress) {
+			continue
+		}
+
+		if progress < int(row.Target) {
+			err := s.DB.SetQuestProgress(ctx, database.SetQuestProgressParams{ID: row.ID, Progress: int32(progress)})
+			if err != nil {
+				return nil, fmt.Errorf("error saving quest progress: %w", err)
+			}
+			rows[i].Progress = int32(progress)
+			continue
+		}
+
+		if err := s.complete(ctx, row); err != nil {
+			return nil, err
+		}
+		rows[i].Progress = row.Target
+		rows[i].CompletedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	}
+	return rows, nil
+}
+
+// progress counts what the profile did towards a quest during its week
+func (s *QuestService) progress(ctx context.Context, quest database.Quest) (int, error) {
+	start, end := s.weekBounds(quest.Week)
+
+	var count int64
+	var err error
+	switch quest.QuestType {
+	case models.QuestCompleteItems:
+		// completions are stamped with now() in the database's local time
+		count, err = s.DB.CountCompletionsBetween(ctx, database.CountCompletionsBetweenParams{
+			UserID:   quest.ProfileID,
+			FromTime: start.In(time.Local),
+			ToTime:   end.In(time.Local),
+		})
+	case models.QuestCompleteVideos:
+		count, err = s.DB.CountCompletionsOfTypeBetween(ctx, database.CountCompletionsOfTypeBetweenParams{
+			UserID:      quest.ProfileID,
+			ContentType: "video",
+			FromTime:    start.In(time.Local),
+			ToTime:      end.In(time.Local),
+		})
+	case models.QuestStudyMinutes:
+		// watch sessions are stamped in UTC
+		count, err = s.DB.GetWatchSecondsBetween(ctx, database.GetWatchSecondsBetweenParams{
+			ProfileID: quest.ProfileID,
+			FromTime:  start.UTC(),
+			ToTime:    end.UTC(),
+		})
+		count /= 60
+	case models.QuestFinishModule:
+		count, err = s.DB.CountModulesFinishedBetween(ctx, database.CountModulesFinishedBetweenParams{
+			UserID:   quest.ProfileID,
+			CourseID: quest.CourseID.UUID,
+			FromTime: start.In(time.Local),
+			ToTime:   end.In(time.Local),
+		})
+	default:
+		log.Printf("Warning: quest %s has unknown type %q", quest.ID, quest.QuestType)
+		return int(quest.Progress), nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("error counting %s quest progress: %w", quest.QuestType, err)
+	}
+	return int(count), nil
+}
+
+// complete marks a quest finished and pays its rewards. Only the call that marks it pays,
+// so a quest refreshed from two places at once still pays once.
+func (s *QuestService) complete(ctx context.Context, quest database.Quest) error {
+	experience := 0
+	err := runInTx(ctx, s.Conn, s.DB, func(q *database.Queries) error {
+		completed, err := q.CompleteQuest(ctx, quest.ID)
+		if err != nil {
+			return fmt.Errorf("error completing quest: %w", err)
+		}
+		if completed == 0 {
+			return nil
+		}
+
+		entry := ledgerEntry{
+			reason:      models.LedgerReasonQuest,
+			subject:     quest.ID.String(),
+			sourceEvent: models.LedgerSourceQuest,
+			description: quest.Title,
+		}
+		if experience, err = earnXP(ctx, q, quest.ProfileID, int(quest.RewardXp), entry); err != nil {
+			return err
+		}
+		if err := earnGems(ctx, q, quest.ProfileID, int(quest.RewardGems), entry); err != nil {
+			return err
+		}
+		return recordActivityEvent(ctx, q, quest.ProfileID, models.ActivityQuestCompleted, quest.CourseID.UUID, uuid.Nil, quest.Title)
+	})
+	if err != nil {
+		return err
+	}
+
+	if experience > 0 {
+		s.Gamification.announceLevel(quest.ProfileID, experience, int(quest.RewardXp))
+	}
+	return nil
+}
+
+// weekBounds returns when a week starts and ends in the activity timezone
+func (s *QuestService) weekBounds(week time.Time) (time.Time, time.Time) {
+	start := time.Date(week.Year(), week.Month(), week.Day(), 0, 0, 0, 0, s.Location)
+	return start, start.AddDate(0, 0, 7)
+}
+
+// toQuestModel converts a quest row to its API model
+func toQuestModel(row database.Quest) models.Quest {
+	return models.Quest{
+		ID:          row.ID,
+		Type:        row.QuestType,
+		Title:       row.Title,
+		Target:      int(row.Target),
+		Progress:    int(row.Progress),
+		CourseID:    row.CourseID.UUID,
+		RewardXP:    int(row.RewardXp),
+		RewardGems:  int(row.RewardGems),
+		Completed:   row.CompletedAt.Valid,
+		CompletedAt: row.CompletedAt,
+	}
+}
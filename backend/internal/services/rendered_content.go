@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/markdown"
+	"github.com/google/uuid"
+)
+
+// MaxRenderedTextBytes caps the size of text files rendered on the server
+const MaxRenderedTextBytes = 2 << 20
+
+// rendering errors, the handler maps each to its own status
+var (
+	ErrNotTextContent = errors.New("content item is not text")
+	ErrTextTooLarge   = errors.New("text file is too large to render")
+)
+
+// markdownExtensions are rendered as markdown, other text files as plain paragraphs
+var markdownExtensions = map[string]bool{".md": true, ".markdown": true, ".mdown": true}
+
+// RenderText converts a text content item to sanitized HTML, with its headings and code blocks
+// listed so the frontend can build a table of contents and highlight code
+func (s *StreamService) RenderText(ctx context.Context, itemID, profileID uuid.UUID) (*models.RenderedContent, error) {
+	// checked up front so asking for a video here doesn't restore it from cold storage
+	location, err := s.DB.GetContentItemLocation(ctx, itemID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("content item not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving content item: %w", err)
+	}
+	if location.ContentType != "text" {
+		return nil, fmt.Errorf("%w: %s is %s", ErrNotTextContent, location.Title, location.ContentType)
+	}
+
+	file, err := s.OpenContent(ctx, itemID, profileID, "", true)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := readText(file.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	rendered := &models.RenderedContent{
+		ContentItemID: itemID,
+		Format:        "plain",
+		Headings:      []models.RenderedHeading{},
+		CodeBlocks:    []models.RenderedCodeBlock{},
+	}
+
+	var doc markdown.Document
+	if markdownExtensions[strings.ToLower(filepath.Ext(file.Path))] {
+		rendered.Format = "markdown"
+		doc = markdown.Render(source)
+	} else {
+		doc = markdown.RenderPlain(source)
+	}
+
+	rendered.HTML = doc.HTML
+	for _, heading := range doc.Headings {
+		rendered.Headings = append(rendered.Headings, models.RenderedHeading{Level: heading.Level, Text: heading.Text, ID: heading.ID})
+	}
+	for _, block := range doc.CodeBlocks {
+		rendered.CodeBlocks = append(rendered.CodeBlocks, models.RenderedCodeBlock{Index: block.Index, Language: block.Language, Lines: block.Lines})
+	}
+	return rendered, nil
+}
+
+// readText reads a text file up to the render limit, dropping a BOM and invalid UTF-8
+func readText(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("error opening text file: %w", err)
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, MaxRenderedTextBytes+1))
+	if err != nil {
+		return "", fmt.Errorf("error reading text file: %w", err)
+	}
+	if len(data) > MaxRenderedTextBytes {
+		return "", fmt.Errorf("%w: limit is %d MB", ErrTextTooLarge, MaxRenderedTextBytes>>20)
+	}
+
+	text := strings.TrimPrefix(string(data), "\ufeff")
+	return strings.ToValidUTF8(text, "\ufffd"), nil
+}
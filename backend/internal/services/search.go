@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// search limits
+const (
+	DefaultSearchLimit   = 20
+	MaxSearchLimit       = 50
+	MaxSearchQueryLength = 200
+	searchSnippetRadius  = 80 // characters of transcript shown either side of the match
+)
+
+// ErrInvalidSearch is returned for an empty or oversized query
+var ErrInvalidSearch = errors.New("invalid search query")
+
+// likeEscaper escapes the characters ILIKE treats as wildcards
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// SearchService finds courses and content items by title, description and transcript
+type SearchService struct {
+	DB         *database.Queries  // database access
+	Visibility *VisibilityService // restricted profiles only see assigned courses
+}
+
+// NewSearchService creates service with its dependencies
+func NewSearchService(db *database.Queries, visibility *VisibilityService) *SearchService {
+	return &SearchService{
+		DB:         db,
+		Visibility: visibility,
+	}
+}
+
+// Search matches courses on title and description, and content items on title or on
+// what's said in their transcript. Content matched on its transcript comes with a snippet
+// around the match. profileID may be nil for anonymous requests.
+func (s *SearchService) Search(ctx context.Context, query string, limit int, profileID uuid.UUID) (*models.SearchResults, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("%w: query is required", ErrInvalidSearch)
+	}
+	if utf8.RuneCountInString(query) > MaxSearchQueryLength {
+		return nil, fmt.Errorf("%w: query must be at most %d characters", ErrInvalidSearch, MaxSearchQueryLength)
+	}
+	if limit <= 0 || limit > MaxSearchLimit {
+		limit = DefaultSearchLimit
+	}
+
+	var visible map[uuid.UUID]bool
+	if profileID != uuid.Nil {
+		var err error
+		visible, err = s.Visibility.visibleCourseIDs(ctx, profileID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	canSee := func(courseID uuid.UUID) bool {
+		return visible == nil || visible[courseID]
+	}
+
+	// restricted profiles lose some rows to the filter, ask for more so the page still fills
+	fetch := limit
+	if visible != nil {
+		fetch = limit * 4
+	}
+	pattern := "%" + likeEscaper.Replace(query) + "%"
+
+	courses, err := s.DB.SearchCourses(ctx, database.SearchCoursesParams{
+		Pattern:    pattern,
+		MaxResults: int32(fetch),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error searching courses: %w", err)
+	}
+
+	items, err := s.DB.SearchContentItems(ctx, database.SearchContentItemsParams{
+		Query:      query,
+		Pattern:    pattern,
+		MaxResults: int32(fetch),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error searching content: %w", err)
+	}
+
+	results := &models.SearchResults{
+		Query:   query,
+		Courses: []models.SearchCourseHit{},
+		Content: []models.SearchContentHit{},
+	}
+	for _, course := range courses {
+		if len(results.Courses) == limit {
+			break
+		}
+		if !canSee(course.ID) {
+			continue
+		}
+		results.Courses = append(results.Courses, models.SearchCourseHit{
+			ID:          course.ID,
+			Title:       course.Title,
+			Description: course.Description.String,
+		})
+	}
+
+	for _, item := range items {
+		if len(results.Content) == limit {
+			break
+		}
+		if !canSee(item.CourseID) {
+			continue
+		}
+		hit := models.SearchContentHit{
+			ID:              item.ID,
+			Title:           item.Title,
+			ContentType:     item.ContentType,
+			ModuleID:        item.ModuleID,
+			CourseID:        item.CourseID,
+			CourseTitle:     item.CourseTitle,
+			TranscriptMatch: item.TranscriptMatch,
+		}
+		if item.TranscriptMatch {
+			hit.Snippet = snippet(item.Transcript, query)
+		}
+		results.Content = append(results.Content, hit)
+	}
+	return results, nil
+}
+
+// snippet cuts the part of a transcript around the first query word it contains,
+// on word boundaries and on one line
+func snippet(text, query string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	lower := strings.ToLower(text)
+
+	at := -1
+	for _, word := range strings.Fields(strings.ToLower(query)) {
+		if i := strings.Index(lower, word); i >= 0 && (at < 0 || i < at) {
+			at = i
+		}
+	}
+	if at < 0 {
+		at = 0
+	}
+
+	start := max(at-searchSnippetRadius, 0)
+	end := min(at+searchSnippetRadius, len(text))
+	if start > 0 {
+		if i := strings.IndexByte(text[start:at], ' '); i >= 0 {
+			start += i + 1
+		}
+	}
+	if end < len(text) {
+		if i := strings.LastIndexByte(text[at:end], ' '); i > 0 {
+			end = at + i
+		}
+	}
+
+	result := text[start:end]
+	if start > 0 {
+		result = "…" + result
+	}
+	if end < len(text) {
+		result += "…"
+	}
+	return result
+}
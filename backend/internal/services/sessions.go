@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// ErrSessionNotFound is returned for a session that doesn't exist or has expired
+var ErrSessionNotFound = errors.New("session not found")
+
+// SessionService shows profiles where they're logged in and lets them end sessions they
+// don't recognise. Starting and checking sessions is pkg/session's job.
+type SessionService struct {
+	DB *database.Queries // database access
+}
+
+// NewSessionService creates service with database dependency
+func NewSessionService(db *database.Queries) *SessionService {
+	return &SessionService{DB: db}
+}
+
+// ListSessions returns a profile's active sessions, most recently used first. currentID
+// is the session asking, it gets marked.
+func (s *SessionService) ListSessions(ctx context.Context, profileID, currentID uuid.UUID) ([]models.Session, error) {
+	rows, err := s.DB.ListSessionsByProfile(ctx, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving sessions: %w", err)
+	}
+
+	now := time.Now()
+	sessions := make([]models.Session, 0, len(rows))
+	for _, row := range rows {
+		// pruning only comes round now and then
+		if !now.Before(row.ExpiresAt) {
+			continue
+		}
+		sessions = append(sessions, models.Session{
+			ID:         row.ID,
+			Device:     row.UserAgent,
+			CreatedAt:  row.CreatedAt.Time,
+			LastSeenAt: row.LastSeenAt,
+			ExpiresAt:  row.ExpiresAt,
+			Current:    row.ID == currentID,
+		})
+	}
+	return sessions, nil
+}
+
+// GetSessionOwner returns the profile an active session belongs to
+func (s *SessionService) GetSessionOwner(ctx context.Context, sessionID uuid.UUID) (uuid.UUID, error) {
+	row, err := s.DB.GetSessionByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, fmt.Errorf("%w: %s", ErrSessionNotFound, sessionID)
+		}
+		return uuid.Nil, fmt.Errorf("error retrieving session: %w", err)
+	}
+	if !time.Now().Before(row.ExpiresAt) {
+		return uuid.Nil, fmt.Errorf("%w: %s has expired", ErrSessionNotFound, sessionID)
+	}
+	return row.UserID, nil
+}
+
+// RevokeSession ends a session, its token stops working straight away
+func (s *SessionService) RevokeSession(ctx context.Context, sessionID uuid.UUID) error {
+	if err := s.DB.DeleteSession(ctx, sessionID); err != nil {
+		return fmt.Errorf("error deleting session: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,296 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/secret"
+	"github.com/NeroQue/course-management-backend/pkg/util"
+	"github.com/google/uuid"
+)
+
+// share link limits
+const (
+	defaultShareLinkHours = 7 * 24
+	shareLinkTokenBytes   = 32
+	sharePasswordAttempts = 5                // wrong passwords per link and address...
+	sharePasswordWindow   = 15 * time.Minute // ...before it has to wait this long
+)
+
+// share link errors, the handler maps each to its own status
+var (
+	ErrInvalidShareLink      = errors.New("invalid share link")
+	ErrShareLinkNotFound     = errors.New("share link not found")
+	ErrShareLinkExpired      = errors.New("share link has expired or was revoked")
+	ErrSharePasswordRequired = errors.New("share link needs a password")
+	ErrShareTooManyAttempts  = errors.New("too many wrong passwords for this share link, try again later")
+)
+
+// ShareLinkService manages public links to single content items. Checking a link's
+// password is slow on purpose, so a correct one is traded for a signed access token
+// that the player's range requests carry instead.
+type ShareLinkService struct {
+	DB        *database.Queries // database access
+	Tiering   *TieringService   // shared files may have to come back from cold storage
+	MaxHours  int               // longest a link may live
+	Key       []byte            // signs access tokens, new on every start
+	AccessTTL time.Duration     // how long an access token works
+
+	mu       sync.Mutex
+	failures map[string]*shareFailures // wrong passwords by link and address
+}
+
+// shareFailures counts wrong passwords since the window started
+type shareFailures struct {
+	count int
+	since time.Time
+}
+
+// NewShareLinkService creates service with its dependencies, SHARE_LINK_MAX_HOURS caps link
+// lifetime and SHARE_ACCESS_TTL how long a password is remembered
+func NewShareLinkService(db *database.Queries, tiering *TieringService) *ShareLinkService {
+	key, err := secret.NewToken(32)
+	if err != nil {
+		log.Fatalf("Failed to generate share link access key: %v", err)
+	}
+
+	return &ShareLinkService{
+		DB:        db,
+		Tiering:   tiering,
+		MaxHours:  util.GetEnvInt("SHARE_LINK_MAX_HOURS", 30*24),
+		Key:       []byte(key),
+		AccessTTL: util.GetEnvDuration("SHARE_ACCESS_TTL", time.Hour),
+		failures:  make(map[string]*shareFailures),
+	}
+}
+
+// ContentOwner returns the creator of the course a content item belongs to
+func (s *ShareLinkService) ContentOwner(ctx context.Context, itemID uuid.UUID) (uuid.UUID, error) {
+	location, err := s.DB.GetContentItemLocation(ctx, itemID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, fmt.Errorf("content item not found: %w", err)
+		}
+		return uuid.Nil, fmt.Errorf("error retrieving content item: %w", err)
+	}
+	return location.CreatorID.UUID, nil
+}
+
+// CreateShareLink creates a link for a content item and returns it with its token
+func (s *ShareLinkService) CreateShareLink(ctx context.Context, itemID, actorID uuid.UUID, input models.CreateShareLinkInput) (*models.CreatedShareLink, error) {
+	hours := input.ExpiresInHours
+	if hours == 0 {
+		hours = defaultShareLinkHours
+	}
+	if hours < 1 || hours > s.MaxHours {
+		return nil, fmt.Errorf("%w: expires_in_hours must be between 1 and %d", ErrInvalidShareLink, s.MaxHours)
+	}
+
+	if _, err := s.DB.GetContentItem(ctx, itemID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("content item not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving content item: %w", err)
+	}
+
+	token, err := secret.NewToken(shareLinkTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	passwordHash := sql.NullString{}
+	if input.Password != "" {
+		hash, err := secret.HashPassword(input.Password)
+		if err != nil {
+			return nil, err
+		}
+		passwordHash = sql.NullString{String: hash, Valid: true}
+	}
+
+	link, err := s.DB.CreateShareLink(ctx, database.CreateShareLinkParams{
+		ID:            uuid.New(),
+		TokenHash:     secret.HashToken(token),
+		ContentItemID: itemID,
+		CreatedBy:     toNullUUID(actorID),
+		PasswordHash:  passwordHash,
+		ExpiresAt:     time.Now().Add(time.Duration(hours) * time.Hour),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating share link: %w", err)
+	}
+
+	return &models.CreatedShareLink{
+		ShareLink: toShareLinkModel(link, time.Now()),
+		Token:     token,
+		URL:       "/api/share/" + token,
+	}, nil
+}
+
+// ListShareLinks returns every link made for a content item, newest first
+func (s *ShareLinkService) ListShareLinks(ctx context.Context, itemID uuid.UUID) ([]models.ShareLink, error) {
+	links, err := s.DB.ListShareLinksByContentItem(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving share links: %w", err)
+	}
+
+	now := time.Now()
+	result := make([]models.ShareLink, 0, len(links))
+	for _, link := range links {
+		result = append(result, toShareLinkModel(link, now))
+	}
+	return result, nil
+}
+
+// GetShareLink returns one link by ID
+func (s *ShareLinkService) GetShareLink(ctx context.Context, linkID uuid.UUID) (*models.ShareLink, error) {
+	link, err := s.DB.GetShareLink(ctx, linkID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("share link not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving share link: %w", err)
+	}
+	result := toShareLinkModel(link, time.Now())
+	return &result, nil
+}
+
+// RevokeShareLink stops a link from working - revoking twice is fine
+func (s *ShareLinkService) RevokeShareLink(ctx context.Context, linkID uuid.UUID) (*models.ShareLink, error) {
+	if _, err := s.DB.RevokeShareLink(ctx, linkID); err != nil {
+		return nil, fmt.Errorf("error revoking share link: %w", err)
+	}
+	return s.GetShareLink(ctx, linkID)
+}
+
+// OpenSharedContent checks a token and returns the file it points at. A protected link
+// takes either an access token from an earlier open or the password, which is checked at
+// most sharePasswordAttempts times per window for each address. A correct password comes
+// back with a fresh access token.
+func (s *ShareLinkService) OpenSharedContent(ctx context.Context, token, password, access, address string) (*models.SharedFile, error) {
+	link, err := s.DB.GetShareLinkByTokenHash(ctx, secret.HashToken(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrShareLinkNotFound
+		}
+		return nil, fmt.Errorf("error retrieving share link: %w", err)
+	}
+
+	now := time.Now()
+	if link.RevokedAt.Valid || now.After(link.ExpiresAt) {
+		return nil, ErrShareLinkExpired
+	}
+
+	shared := &models.SharedFile{LinkID: link.ID}
+	if link.PasswordHash.Valid && !s.verifyAccess(link.ID, access, now) {
+		if err := s.checkPassword(link, password, address, now); err != nil {
+			return nil, err
+		}
+		shared.AccessExpires = now.Add(s.AccessTTL)
+		shared.Access = s.accessToken(link.ID, shared.AccessExpires)
+	}
+
+	item, err := s.DB.GetContentItem(ctx, link.ContentItemID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving shared content item: %w", err)
+	}
+
+	path, err := resolveContentPath(item.RelativePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// brings the file back from cold storage if it was parked there
+	if err := s.Tiering.RecordAccess(ctx, item.ID); err != nil {
+		return nil, fmt.Errorf("error preparing shared content: %w", err)
+	}
+
+	shared.Path = path
+	shared.Filename = filepath.Base(item.RelativePath)
+	return shared, nil
+}
+
+// checkPassword checks a protected link's password, refusing to even try once an
+// address has guessed wrong too often
+func (s *ShareLinkService) checkPassword(link database.ContentShareLink, password, address string, now time.Time) error {
+	if password == "" {
+		return ErrSharePasswordRequired
+	}
+
+	key := link.ID.String() + " " + address
+	s.mu.Lock()
+	failed := s.failures[key]
+	if failed != nil && now.Sub(failed.since) > sharePasswordWindow {
+		delete(s.failures, key)
+		failed = nil
+	}
+	s.mu.Unlock()
+	if failed != nil && failed.count >= sharePasswordAttempts {
+		return ErrShareTooManyAttempts
+	}
+
+	if secret.CheckPassword(link.PasswordHash.String, password) {
+		s.mu.Lock()
+		delete(s.failures, key)
+		s.mu.Unlock()
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if failed = s.failures[key]; failed == nil {
+		failed = &shareFailures{since: now}
+		s.failures[key] = failed
+	}
+	failed.count++
+	return ErrSharePasswordRequired
+}
+
+// accessToken signs a link ID and expiry, "expiry.signature"
+func (s *ShareLinkService) accessToken(linkID uuid.UUID, expires time.Time) string {
+	expiry := strconv.FormatInt(expires.Unix(), 10)
+	return expiry + "." + secret.Sign(s.Key, linkID.String(), expiry)
+}
+
+// verifyAccess reports whether an access token from accessToken is for this link and still valid
+func (s *ShareLinkService) verifyAccess(linkID uuid.UUID, access string, now time.Time) bool {
+	expiry, signature, found := strings.Cut(access, ".")
+	if !found || !secret.Verify(s.Key, signature, linkID.String(), expiry) {
+		return false
+	}
+	expires, err := strconv.ParseInt(expiry, 10, 64)
+	return err == nil && now.Unix() <= expires
+}
+
+// RecordView counts one view of a share link
+func (s *ShareLinkService) RecordView(ctx context.Context, linkID uuid.UUID) error {
+	if err := s.DB.RecordShareLinkView(ctx, linkID); err != nil {
+		return fmt.Errorf("error recording share link view: %w", err)
+	}
+	return nil
+}
+
+// toShareLinkModel converts a database share link to the API model
+func toShareLinkModel(link database.ContentShareLink, now time.Time) models.ShareLink {
+	return models.ShareLink{
+		ID:            link.ID,
+		ContentItemID: link.ContentItemID,
+		CreatedBy:     link.CreatedBy.UUID,
+		HasPassword:   link.PasswordHash.Valid,
+		ExpiresAt:     link.ExpiresAt,
+		RevokedAt:     link.RevokedAt,
+		Active:        !link.RevokedAt.Valid && now.Before(link.ExpiresAt),
+		ViewCount:     int(link.ViewCount),
+		LastViewedAt:  link.LastViewedAt,
+		CreatedAt:     link.CreatedAt,
+	}
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestVerifyShareAccess(t *testing.T) {
+	s := &ShareLinkService{Key: []byte("share-test-key-share-test-key-sh")}
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	linkID := uuid.New()
+	expires := now.Add(time.Hour)
+	access := s.accessToken(linkID, expires)
+	expiry, signature, _ := strings.Cut(access, ".")
+	other := (&ShareLinkService{Key: []byte("another-key-another-key-another-k")}).accessToken(linkID, expires)
+
+	tests := []struct {
+		name   string
+		linkID uuid.UUID
+		access string
+		at     time.Time
+		valid  bool
+	}{
+		{"valid", linkID, access, now, true},
+		{"valid at its expiry", linkID, access, expires, true},
+		{"expired", linkID, access, expires.Add(time.Second), false},
+		{"other link", uuid.New(), access, now, false},
+		{"extended expiry", linkID, "9999999999." + signature, now, false},
+		{"signed with another key", linkID, other, now, false},
+		{"no signature", linkID, expiry, now, false},
+		{"empty", linkID, "", now, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.verifyAccess(tt.linkID, tt.access, tt.at); got != tt.valid {
+				t.Errorf("verifyAccess = %v, want %v", got, tt.valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/maintenance"
+	"github.com/google/uuid"
+)
+
+// SnapshotService saves and restores the library's metadata using the course bundle format
+type SnapshotService struct {
+	DB      *database.Queries // database access
+	Conn    *sql.DB           // raw connection for transactions
+	Courses *CourseService    // builds the per-course bundles
+}
+
+// NewSnapshotService creates service with database dependencies
+func NewSnapshotService(db *database.Queries, conn *sql.DB, courses *CourseService) *SnapshotService {
+	return &SnapshotService{
+		DB:      db,
+		Conn:    conn,
+		Courses: courses,
+	}
+}
+
+// CreateSnapshot saves every course, module and content item (no progress, no media)
+func (s *SnapshotService) CreateSnapshot(ctx context.Context, label string, actorID uuid.UUID) (*models.LibrarySnapshot, error) {
+	label = strings.TrimSpace(label)
+	if label == "" {
+		label = "Snapshot " + time.Now().UTC().Format(time.RFC3339)
+	}
+
+	// no edits while we read the library, or the snapshot could be half before, half after
+	defer maintenance.Enter("taking library snapshot")()
+
+	dbCourses, err := s.DB.ListCourses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving courses: %w", err)
+	}
+
+	bundles := make([]*models.CourseBundle, 0, len(dbCourses))
+	for _, dbCourse := range dbCourses {
+		bundle, err := s.Courses.ExportCourse(ctx, dbCourse.ID, uuid.NullUUID{})
+		if err != nil {
+			return nil, fmt.Errorf("error exporting course %s: %w", dbCourse.ID, err)
+		}
+		bundles = append(bundles, bundle)
+	}
+
+	data, err := json.Marshal(bundles)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding snapshot: %w", err)
+	}
+
+	row, err := s.DB.CreateLibrarySnapshot(ctx, database.CreateLibrarySnapshotParams{
+		Label:       label,
+		CreatedBy:   toNullUUID(actorID),
+		CourseCount: int32(len(bundles)),
+		Data:        string(data),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error saving snapshot: %w", err)
+	}
+
+	return &models.LibrarySnapshot{
+		ID:          row.ID,
+		Label:       row.Label,
+		CreatedBy:   row.CreatedBy.UUID,
+		CourseCount: int(row.CourseCount),
+		RestoredAt:  row.RestoredAt,
+		CreatedAt:   row.CreatedAt,
+	}, nil
+}
+
+// ListSnapshots returns every snapshot, newest first
+func (s *SnapshotService) ListSnapshots(ctx context.Context) ([]models.LibrarySnapshot, error) {
+	rows, err := s.DB.ListLibrarySnapshots(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving snapshots: %w", err)
+	}
+
+	snapshots := make([]models.LibrarySnapshot, 0, len(rows))
+	for _, row := range rows {
+		snapshots = append(snapshots, models.LibrarySnapshot{
+			ID:          row.ID,
+			Label:       row.Label,
+			CreatedBy:   row.CreatedBy.UUID,
+			CourseCount: int(row.CourseCount),
+			RestoredAt:  row.RestoredAt,
+			CreatedAt:   row.CreatedAt,
+		})
+	}
+	return snapshots, nil
+}
+
+// DeleteSnapshot removes a snapshot, returning sql.ErrNoRows if it doesn't exist
+func (s *SnapshotService) DeleteSnapshot(ctx context.Context, snapshotID uuid.UUID) error {
+	deleted, err := s.DB.DeleteLibrarySnapshot(ctx, snapshotID)
+	if err != nil {
+		return fmt.Errorf("error deleting snapshot: %w", err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("snapshot not found: %w", sql.ErrNoRows)
+	}
+	return nil
+}
+
+// Rollback puts every course in the snapshot back the way it was, keeping the original IDs
+// so progress on surviving items still lines up. With prune set, courses created after the
+// snapshot are deleted too. Everything happens in one transaction.
+func (s *SnapshotService) Rollback(ctx context.Context, snapshotID uuid.UUID, prune bool) (*models.SnapshotRollbackResult, error) {
+	snapshot, err := s.DB.GetLibrarySnapshot(ctx, snapshotID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("snapshot not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving snapshot: %w", err)
+	}
+
+	var bundles []*models.CourseBundle
+	if err := json.Unmarshal([]byte(snapshot.Data), &bundles); err != nil {
+		return nil, fmt.Errorf("snapshot data is corrupt: %w", err)
+	}
+
+	defer maintenance.Enter("restoring library snapshot")()
+
+	result := &models.SnapshotRollbackResult{SnapshotID: snapshotID, RemovedCourses: []uuid.UUID{}}
+	err = runInTx(ctx, s.Conn, s.DB, func(q *database.Queries) error {
+		inSnapshot := make(map[uuid.UUID]bool, len(bundles))
+		for _, bundle := range bundles {
+			inSnapshot[bundle.Course.ID] = true
+		}
+
+		// prune first so re-imported paths don't clash with the restored courses
+		if prune {
+			current, err := q.ListCourses(ctx)
+			if err != nil {
+				return fmt.Errorf("error retrieving courses: %w", err)
+			}
+			for _, course := range current {
+				if inSnapshot[course.ID] {
+					continue
+				}
+				if err := q.DeleteCourse(ctx, course.ID); err != nil {
+					return fmt.Errorf("error removing course %s: %w", course.ID, err)
+				}
+				result.RemovedCourses = append(result.RemovedCourses, course.ID)
+			}
+		}
+
+		for _, bundle := range bundles {
+			if err := restoreCourse(ctx, q, bundle.Course); err != nil {
+				return err
+			}
+			result.RestoredCourses++
+		}
+
+		return q.MarkLibrarySnapshotRestored(ctx, snapshotID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// restoreCourse upserts a course tree with its original IDs and drops anything added since
+func restoreCourse(ctx context.Context, q *database.Queries, course *models.Course) error {
+	// creator may have been deleted since - the FK would refuse the row
+	creatorID := toNullUUID(course.CreatorID)
+	if creatorID.Valid {
+		if _, err := q.GetProfileById(ctx, creatorID.UUID); err != nil {
+			creatorID = uuid.NullUUID{}
+		}
+	}
+
+	err := q.RestoreCourse(ctx, database.RestoreCourseParams{
+		ID:           course.ID,
+		Title:        course.Title,
+		Description:  sql.NullString{String: course.Description, Valid: course.Description != ""},
+		CreatorID:    creatorID,
+		RelativePath: course.RelativePath,
+	})
+	if err != nil {
+		return fmt.Errorf("error restoring course %s: %w", course.ID, err)
+	}
+
+	moduleIDs := make([]uuid.UUID, 0, len(course.Modules))
+	for _, module := range course.Modules {
+		moduleIDs = append(moduleIDs, module.ID)
+		err := q.RestoreModule(ctx, database.RestoreModuleParams{
+			ID:           module.ID,
+			CourseID:     course.ID,
+			Title:        module.Title,
+			Description:  sql.NullString{String: module.Description, Valid: module.Description != ""},
+			RelativePath: module.RelativePath,
+			Order:        int32(module.Order),
+		})
+		if err != nil {
+			return fmt.Errorf("error restoring module %s: %w", module.ID, err)
+		}
+	}
+
+	// items first: ones that moved modules get pulled back before old modules are dropped
+	for _, module := range course.Modules {
+		itemIDs := make([]uuid.UUID, 0, len(module.ContentItems))
+		for _, item := range module.ContentItems {
+			itemIDs = append(itemIDs, item.ID)
+			err := q.RestoreContentItem(ctx, database.RestoreContentItemParams{
+				ID:           item.ID,
+				ModuleID:     module.ID,
+				Title:        item.Title,
+				Description:  sql.NullString{String: item.Description, Valid: item.Description != ""},
+				RelativePath: item.RelativePath,
+				ContentType:  item.ContentType,
+				Duration:     sql.NullInt32{Int32: int32(item.Duration), Valid: item.Duration > 0},
+				Size:         sql.NullInt64{Int64: item.Size, Valid: item.Size > 0},
+				Order:        int32(item.Order),
+				PageCount:    sql.NullInt32{Int32: int32(item.PageCount), Valid: item.PageCount > 0},
+			})
+			if err != nil {
+				return fmt.Errorf("error restoring content item %s: %w", item.ID, err)
+			}
+		}
+
+		if err := q.DeleteContentItemsNotIn(ctx, database.DeleteContentItemsNotInParams{
+			ModuleID: module.ID,
+			KeepIds:  itemIDs,
+		}); err != nil {
+			return fmt.Errorf("error removing content added after snapshot: %w", err)
+		}
+	}
+
+	if err := q.DeleteModulesNotIn(ctx, database.DeleteModulesNotInParams{
+		CourseID: course.ID,
+		KeepIds:  moduleIDs,
+	}); err != nil {
+		return fmt.Errorf("error removing modules added after snapshot: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/pkg/artifacts"
+	"github.com/NeroQue/course-management-backend/pkg/task"
+	"github.com/NeroQue/course-management-backend/pkg/thumbnail"
+	"github.com/google/uuid"
+)
+
+// sprite errors, the handler maps each to its own status
+var (
+	ErrSpriteNotFound = errors.New("sprite file not found")
+	ErrSpritesPending = errors.New("preview sprites are still being generated")
+)
+
+// spriteFileName matches the files GenerateSprites writes, nothing else is ever served
+var spriteFileName = regexp.MustCompile(`^(index\.vtt|sheet-\d{3,}\.jpg)$`)
+
+// GetSpriteFile returns the path of a scrubbing preview file of a video - the WebVTT index
+// or one of the sheets it points at. The first request for a video starts generating them
+// in the background and gets ErrSpritesPending with the task ID to poll.
+func (s *ThumbnailService) GetSpriteFile(ctx context.Context, itemID, profileID uuid.UUID, name string) (string, string, error) {
+	if !spriteFileName.MatchString(name) {
+		return "", "", fmt.Errorf("%w: %s", ErrSpriteNotFound, name)
+	}
+
+	location, err := s.locate(ctx, itemID, profileID)
+	if err != nil {
+		return "", "", err
+	}
+	if location.ContentType != "video" {
+		return "", "", fmt.Errorf("%w: no preview sprites for %s", thumbnail.ErrUnsupported, location.ContentType)
+	}
+
+	// without the tools every request would start a task that fails straight away
+	if err := thumbnail.CheckSpriteTools(); err != nil {
+		return "", "", err
+	}
+
+	_, checksum, err := s.sourceFile(ctx, location)
+	if err != nil {
+		return "", "", err
+	}
+
+	// keyed by checksum like thumbnails, so a replaced video gets new sprites
+	itemDir := s.Store.ItemDir(artifacts.Sprites, location.CourseID, itemID)
+	dir := filepath.Join(itemDir, checksum)
+
+	index, err := os.ReadFile(filepath.Join(dir, thumbnail.SpriteIndex))
+	if err != nil {
+		return "", s.startSprites(itemID), ErrSpritesPending
+	}
+
+	path := filepath.Join(dir, name)
+	if _, err := os.Stat(path); err == nil {
+		return path, "", nil
+	}
+
+	// the artifact janitor can remove single sheets - if the index still needs one, start over
+	if strings.Contains(string(index), name+"#") {
+		return "", s.startSprites(itemID), ErrSpritesPending
+	}
+	return "", "", fmt.Errorf("%w: %s", ErrSpriteNotFound, name)
+}
+
+// spriteInput is what a sprite generation task runs on
+type spriteInput struct {
+	ContentItemID uuid.UUID `json:"content_item_id"`
+}
+
+// startSprites generates a video's sprites in a background task and returns its ID.
+// A request while one is already running for the item gets that task instead.
+func (s *ThumbnailService) startSprites(itemID uuid.UUID) string {
+	s.spriteMu.Lock()
+	defer s.spriteMu.Unlock()
+
+	if taskID, ok := s.spriteTasks[itemID]; ok {
+		return taskID
+	}
+
+	// sprites take minutes, the task workers queue them separately so thumbnails and pages don't wait
+	taskID := task.Submit("sprite_generation", spriteInput{ContentItemID: itemID})
+	s.spriteTasks[itemID] = taskID
+
+	return taskID
+}
+
+// spriteWork generates the sprites of a sprite generation task's video. The file and its
+// checksum are looked up on each run, a retry may come after the video was replaced.
+func (s *ThumbnailService) spriteWork(input spriteInput) task.Work {
+	itemID := input.ContentItemID
+	return func(ctx context.Context, taskID string) (interface{}, error) {
+		defer func() {
+			s.spriteMu.Lock()
+			if s.spriteTasks[itemID] == taskID {
+				delete(s.spriteTasks, itemID)
+			}
+			s.spriteMu.Unlock()
+		}()
+
+		location, err := s.DB.GetContentItemLocation(ctx, itemID)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving content item: %w", err)
+		}
+		src, checksum, err := s.sourceFile(ctx, location)
+		if err != nil {
+			return nil, err
+		}
+		itemDir := s.Store.ItemDir(artifacts.Sprites, location.CourseID, itemID)
+
+		task.SetTaskMessage(taskID, "Generating preview sprites for "+filepath.Base(src))
+		if err := thumbnail.GenerateSprites(ctx, src, filepath.Join(itemDir, checksum), s.SpriteInterval); err != nil {
+			log.Printf("Error generating sprites for content %s: %v", itemID, err)
+			return nil, err
+		}
+
+		// sprites of an earlier version of the file are no use any more
+		if entries, err := os.ReadDir(itemDir); err == nil {
+			for _, entry := range entries {
+				if entry.Name() != checksum {
+					os.RemoveAll(filepath.Join(itemDir, entry.Name()))
+				}
+			}
+		}
+
+		return map[string]string{"content_item_id": itemID.String()}, nil
+	}
+}
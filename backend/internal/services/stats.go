@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// stats periods
+const (
+	StatsPeriodWeek  = "week"  // the last 7 days, by day
+	StatsPeriodMonth = "month" // the last 5 weeks, by week starting Monday
+)
+
+// stats errors, the handler maps each to a 400
+var (
+	ErrInvalidStatsPeriod = errors.New("period must be week or month")
+	ErrInvalidHeatmapYear = errors.New("year must be between 2000 and next year")
+)
+
+// StatsService aggregates completions, watch time and course activity for progress charts
+type StatsService struct {
+	DB       *database.Queries // database access
+	Location *time.Location    // where a day starts, same as for streaks
+}
+
+// NewStatsService creates service with db dependency
+func NewStatsService(db *database.Queries) *StatsService {
+	return &StatsService{
+		DB:       db,
+		Location: activityLocation(),
+	}
+}
+
+// GetStats returns a profile's items completed, minutes watched and courses touched over
+// the period, per day for a week and per week for a month
+func (s *StatsService) GetStats(ctx context.Context, userID uuid.UUID, period string) (*models.LearningStats, error) {
+	today := activityDay(time.Now(), s.Location)
+
+	stats := &models.LearningStats{UserID: userID, Period: period, To: today}
+	var starts []time.Time
+	switch period {
+	case StatsPeriodWeek:
+		stats.Bucket = "day"
+		for i := 6; i >= 0; i-- {
+			starts = append(starts, today.AddDate(0, 0, -i))
+		}
+	case StatsPeriodMonth:
+		stats.Bucket = "week"
+		monday := weekStart(today)
+		for i := 4; i >= 0; i-- {
+			starts = append(starts, monday.AddDate(0, 0, -7*i))
+		}
+	default:
+		return nil, ErrInvalidStatsPeriod
+	}
+	stats.From = starts[0]
+
+	bucketOf := func(t time.Time) int {
+		day := activityDay(t, s.Location)
+		if period == StatsPeriodMonth {
+			day = weekStart(day)
+		}
+		for i, start := range starts {
+			if start.Equal(day) {
+				return i
+			}
+		}
+		return -1
+	}
+
+	// the day's start in the profile's zone, a bit early is fine since rows get bucketed anyway
+	since := time.Date(stats.From.Year(), stats.From.Month(), stats.From.Day(), 0, 0, 0, 0, s.Location)
+
+	buckets := make([]models.StatsBucket, len(starts))
+	seconds := make([]int64, len(starts))
+	courses := make([]map[uuid.UUID]bool, len(starts))
+	for i := range starts {
+		buckets[i].Start = starts[i]
+		courses[i] = make(map[uuid.UUID]bool)
+	}
+	allCourses := make(map[uuid.UUID]bool)
+	touch := func(i int, courseID uuid.UUID) {
+		courses[i][courseID] = true
+		allCourses[courseID] = true
+	}
+
+	// completions and events are stamped with now() in the database's local time,
+	// watch sessions in UTC
+	completions, err := s.DB.ListCompletionsSince(ctx, database.ListCompletionsSinceParams{
+		UserID: userID,
+		Since:  since.In(time.Local),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving completions: %w", err)
+	}
+	for _, row := range completions {
+		if i := bucketOf(localTimestamp(row.CompletedAt.Time)); i >= 0 {
+			buckets[i].ItemsCompleted++
+			touch(i, row.CourseID)
+		}
+	}
+
+	sessions, err := s.DB.ListWatchSessionsSince(ctx, database.ListWatchSessionsSinceParams{
+		ProfileID: userID,
+		Since:     since.UTC(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving watch time: %w", err)
+	}
+	for _, row := range sessions {
+		if i := bucketOf(row.StartedAt); i >= 0 {
+			seconds[i] += int64(row.Seconds)
+			if row.Seconds > 0 {
+				touch(i, row.CourseID)
+			}
+		}
+	}
+
+	events, err := s.DB.ListActivityCoursesSince(ctx, database.ListActivityCoursesSinceParams{
+		ProfileID: userID,
+		Since:     since.In(time.Local),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving activity: %w", err)
+	}
+	for _, row := range events {
+		if i := bucketOf(localTimestamp(row.CreatedAt)); i >= 0 {
+			touch(i, row.CourseID.UUID)
+		}
+	}
+
+	var totalSeconds int64
+	for i := range buckets {
+		buckets[i].MinutesSpent = minutes(time.Duration(seconds[i]) * time.Second)
+		buckets[i].CoursesTouched = len(courses[i])
+		stats.Total.ItemsCompleted += buckets[i].ItemsCompleted
+		totalSeconds += seconds[i]
+	}
+	stats.Total.Start = stats.From
+	stats.Total.MinutesSpent = minutes(time.Duration(totalSeconds) * time.Second)
+	stats.Total.CoursesTouched = len(allCourses)
+	stats.Buckets = buckets
+	return stats, nil
+}
+
+// GetHeatmap returns how many progress updates a profile made on each day of a year,
+// from the daily activity that also drives streaks
+func (s *StatsService) GetHeatmap(ctx context.Context, userID uuid.UUID, year int) (*models.Heatmap, error) {
+	if year < 2000 || year > time.Now().In(s.Location).Year()+1 {
+		return nil, ErrInvalidHeatmapYear
+	}
+
+	first := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	last := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+	rows, err := s.DB.ListDailyActivityBetween(ctx, database.ListDailyActivityBetweenParams{
+		ProfileID: userID,
+		FirstDay:  first,
+		LastDay:   last,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving activity: %w", err)
+	}
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Day.Format(time.DateOnly)] = int(row.Updates)
+	}
+
+	heatmap := &models.Heatmap{UserID: userID, Year: year}
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		date := day.Format(time.DateOnly)
+		count := counts[date]
+		heatmap.Days = append(heatmap.Days, models.HeatmapDay{Date: date, Count: count})
+
+		heatmap.Total += count
+		heatmap.MaxCount = max(heatmap.MaxCount, count)
+		if count > 0 {
+			heatmap.ActiveDays++
+		}
+	}
+	return heatmap, nil
+}
+
+// weekStart returns the Monday of day's week
+func weekStart(day time.Time) time.Time {
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
+
+// localTimestamp reads a zoneless timestamp written with now() as the server's local time
+func localTimestamp(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.Local)
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/rewards"
+	"github.com/google/uuid"
+)
+
+// ErrTooManyStreakFreezes is returned for buying a freeze while holding as many as allowed
+var ErrTooManyStreakFreezes = errors.New("already holding as many streak freezes as allowed")
+
+// GetStreakFreezes returns a profile's gems and the streak freezes it holds
+func (s *GoalService) GetStreakFreezes(ctx context.Context, profileID uuid.UUID) (*models.StreakFreezes, error) {
+	profile, err := s.DB.GetProfileById(ctx, profileID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("profile not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving profile: %w", err)
+	}
+	return toStreakFreezesModel(profile.Gems, profile.StreakFreezes, s.Rules), nil
+}
+
+// BuyStreakFreeze spends gems on one streak freeze, same as redeeming one in the gem shop
+func (s *GoalService) BuyStreakFreeze(ctx context.Context, profileID uuid.UUID) (*models.StreakFreezes, error) {
+	var row database.BuyStreakFreezeRow
+	err := runInTx(ctx, s.Conn, s.DB, func(q *database.Queries) error {
+		var err error
+		row, err = buyStreakFreeze(ctx, q, profileID, s.Rules)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toStreakFreezesModel(row.Gems, row.StreakFreezes, s.Rules), nil
+}
+
+// buyStreakFreeze takes the gems for a freeze and records it in the ledger. The check and
+// the purchase are a single update, so two purchases at once can't overspend.
+func buyStreakFreeze(ctx context.Context, q *database.Queries, profileID uuid.UUID, rules *rewards.Rules) (database.BuyStreakFreezeRow, error) {
+	row, err := q.BuyStreakFreeze(ctx, database.BuyStreakFreezeParams{
+		ID:         profileID,
+		Cost:       int32(rules.Streaks.FreezeCost),
+		MaxFreezes: int32(rules.Streaks.MaxFreezes),
+	})
+	if err == nil {
+		for _, entry := range []ledgerEntry{
+			{stat: models.StatGems, amount: -rules.Streaks.FreezeCost, balance: int(row.Gems)},
+			{stat: models.StatStreakFreezes, amount: 1, balance: int(row.StreakFreezes)},
+		} {
+			entry.reason, entry.subject = models.LedgerReasonRedeem, models.GemItemStreakFreeze
+			entry.sourceEvent, entry.description = models.LedgerSourceRedemption, "Streak freeze"
+			if err := recordLedgerEntry(ctx, q, profileID, entry); err != nil {
+				return row, err
+			}
+		}
+		return row, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return row, fmt.Errorf("error buying streak freeze: %w", err)
+	}
+
+	// nothing was bought, find out why
+	profile, err := q.GetProfileById(ctx, profileID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return row, fmt.Errorf("profile not found: %w", err)
+		}
+		return row, fmt.Errorf("error retrieving profile: %w", err)
+	}
+	if int(profile.StreakFreezes) >= rules.Streaks.MaxFreezes {
+		return row, fmt.Errorf("%w: %d held", ErrTooManyStreakFreezes, profile.StreakFreezes)
+	}
+	return row, fmt.Errorf("%w: %d needed, %d held", ErrNotEnoughGems, rules.Streaks.FreezeCost, profile.Gems)
+}
+
+// freezeDay spends a streak freeze on a missed day, reporting whether the day is covered.
+// One freeze covers the day for all of the profile's daily goals, so a day another goal
+// already froze is covered for free.
+func (s *GoalService) freezeDay(ctx context.Context, q *database.Queries, profileID uuid.UUID, day time.Time) (bool, error) {
+	_, err := q.GetStreakFreezeDay(ctx, database.GetStreakFreezeDayParams{ProfileID: profileID, Day: day})
+	if err == nil {
+		return true, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return false, fmt.Errorf("error retrieving streak freezes: %w", err)
+	}
+
+	left, err := q.UseStreakFreeze(ctx, profileID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil // none left
+		}
+		return false, fmt.Errorf("error using streak freeze: %w", err)
+	}
+
+	err = q.CreateStreakFreezeDay(ctx, database.CreateStreakFreezeDayParams{ProfileID: profileID, Day: day})
+	if err != nil {
+		return false, fmt.Errorf("error saving streak freeze: %w", err)
+	}
+	return true, recordLedgerEntry(ctx, q, profileID, ledgerEntry{
+		stat:        models.StatStreakFreezes,
+		amount:      -1,
+		balance:     int(left),
+		reason:      models.LedgerReasonFrozen,
+		subject:     day.Format(time.DateOnly),
+		sourceEvent: models.LedgerSourceEvaluation,
+		description: "Streak freeze used on " + day.Format(time.DateOnly),
+	})
+}
+
+// toStreakFreezesModel fills in the rules around a profile's balance
+func toStreakFreezesModel(gems, freezes int32, rules *rewards.Rules) *models.StreakFreezes {
+	return &models.StreakFreezes{
+		Gems:       int(gems),
+		Freezes:    int(freezes),
+		MaxFreezes: rules.Streaks.MaxFreezes,
+		Cost:       rules.Streaks.FreezeCost,
+	}
+}
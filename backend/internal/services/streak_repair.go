@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// streak repair errors, the handler maps them to 409
+var (
+	ErrNoStreakToRepair   = errors.New("no broken streak to repair")
+	ErrRepairWindowPassed = errors.New("streak broke too long ago to repair")
+)
+
+// RepairStreak spends gems to buy back the days that broke a profile's streak, joining
+// the run before the gap to today's. Only the most recent gap can be repaired, and only
+// within the rules' grace window of it starting. The gap comes from the activity log, so
+// there has to have been a streak before it.
+func (s *GoalService) RepairStreak(ctx context.Context, profileID uuid.UUID) (*models.StreakRepair, error) {
+	days, err := s.DB.ListActivityDays(ctx, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving activity: %w", err)
+	}
+
+	today := activityDay(time.Now(), s.Location)
+	missed, err := streakGap(days, today)
+	if err != nil {
+		return nil, err
+	}
+	if window := s.Rules.Streaks.RepairWindowDays; missed[0].Before(today.AddDate(0, 0, -window)) {
+		return nil, fmt.Errorf("%w: missed %s, repairs allowed for %d days", ErrRepairWindowPassed, missed[0].Format(time.DateOnly), window)
+	}
+
+	cost := len(missed) * s.Rules.Streaks.RepairCost
+	repair := &models.StreakRepair{Cost: cost}
+	for _, day := range missed {
+		repair.RepairedDays = append(repair.RepairedDays, day.Format(time.DateOnly))
+	}
+
+	// the repaired days go into the history the streak is worked out from
+	days = append(days, missed...)
+	slices.SortFunc(days, func(a, b time.Time) int { return b.Compare(a) })
+	repair.Streak, repair.LongestStreak = calculateStreaks(days, today)
+
+	err = runInTx(ctx, s.Conn, s.DB, func(q *database.Queries) error {
+		profile, err := q.GetProfileById(ctx, profileID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("profile not found: %w", err)
+			}
+			return fmt.Errorf("error retrieving profile: %w", err)
+		}
+
+		for _, day := range missed {
+			created, err := q.CreateRepairedActivityDay(ctx, database.CreateRepairedActivityDayParams{
+				ProfileID: profileID,
+				Day:       day,
+			})
+			if err != nil {
+				return fmt.Errorf("error saving repaired day: %w", err)
+			}
+			if created == 0 {
+				// a repair or some activity beat us to it
+				return fmt.Errorf("%w: %s is no longer missed", ErrNoStreakToRepair, day.Format(time.DateOnly))
+			}
+		}
+
+		balance, err := q.SpendProfileGems(ctx, database.SpendProfileGemsParams{ID: profileID, Cost: int32(cost)})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("%w: %d needed, %d held", ErrNotEnoughGems, cost, profile.Gems)
+			}
+			return fmt.Errorf("error spending gems: %w", err)
+		}
+		repair.Gems = int(balance)
+
+		err = q.SetProfileStreak(ctx, database.SetProfileStreakParams{
+			ID:             profileID,
+			Streak:         int32(repair.Streak),
+			LongestStreak:  int32(repair.LongestStreak),
+			LastActiveDate: sql.NullTime{Time: days[0], Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("error saving streak: %w", err)
+		}
+
+		description := "Streak repaired over " + strconv.Itoa(len(missed)) + " missed days"
+		if len(missed) == 1 {
+			description = "Streak repaired over " + repair.RepairedDays[0]
+		}
+		for _, entry := range []ledgerEntry{
+			{stat: models.StatGems, amount: -cost, balance: repair.Gems},
+			{stat: models.StatStreak, amount: repair.Streak - int(profile.Streak), balance: repair.Streak},
+		} {
+			entry.reason, entry.subject = models.LedgerReasonRepair, repair.RepairedDays[0]
+			entry.sourceEvent, entry.description = models.LedgerSourceRedemption, description
+			if err := recordLedgerEntry(ctx, q, profileID, entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return repair, nil
+}
+
+// streakGap returns the most recent run of missed days between two active ones, oldest
+// first. days is the activity history, newest first. A gap reaching up to yesterday counts
+// too: it broke the streak, and today's activity would only start a new one.
+func streakGap(days []time.Time, today time.Time) ([]time.Time, error) {
+	yesterday := today.AddDate(0, 0, -1)
+	if len(days) == 0 {
+		return nil, fmt.Errorf("%w: no activity yet", ErrNoStreakToRepair)
+	}
+
+	// after the gap: today, when nothing since; or the start of the current run
+	after := today
+	before := days[0]
+	if !days[0].Before(yesterday) {
+		i := 1
+		for i < len(days) && days[i].Equal(days[i-1].AddDate(0, 0, -1)) {
+			i++
+		}
+		if i == len(days) {
+			return nil, fmt.Errorf("%w: the streak is unbroken", ErrNoStreakToRepair)
+		}
+		after, before = days[i-1], days[i]
+	}
+
+	var missed []time.Time
+	for day := before.AddDate(0, 0, 1); day.Before(after); day = day.AddDate(0, 0, 1) {
+		missed = append(missed, day)
+	}
+	return missed, nil
+}
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/artifacts"
+	"github.com/NeroQue/course-management-backend/pkg/tiering"
+	"github.com/NeroQue/course-management-backend/pkg/util"
+	"github.com/google/uuid"
+)
+
+// streaming errors, the handler maps each to its own status
+var (
+	ErrUnsafeContentPath  = errors.New("content file is outside the courses directory")
+	ErrVariantNotFound    = errors.New("content variant not found")
+	ErrContentNotVisible  = errors.New("content belongs to a course hidden from this profile")
+	ErrPlaybackNotAllowed = errors.New("viewing time limit reached")
+)
+
+// StreamService resolves content items to files on disk for playback
+type StreamService struct {
+	DB         *database.Queries  // database access
+	Tiering    *TieringService    // cold items get restored before they're served
+	TimeLimits *TimeLimitService  // profiles over their limit can't start playback
+	Visibility *VisibilityService // restricted profiles only see assigned courses
+	Artifacts  *artifacts.Store   // chapter lists are cached under <artifacts>/chapters/
+}
+
+// NewStreamService creates service with its dependencies
+func NewStreamService(db *database.Queries, tiering *TieringService, timeLimits *TimeLimitService, visibility *VisibilityService) *StreamService {
+	return &StreamService{
+		DB:         db,
+		Tiering:    tiering,
+		TimeLimits: timeLimits,
+		Visibility: visibility,
+		Artifacts:  artifacts.NewStore(),
+	}
+}
+
+// OpenContent returns the file to stream for a content item, or one of its variants when
+// variant names a label or variant ID. Anonymous requests (a nil profileID) are refused
+// like a hidden course, share links and offline URLs have their own way in.
+// countAccess is set for the request that starts playback - later range requests only
+// make sure the file is back from cold storage.
+func (s *StreamService) OpenContent(ctx context.Context, itemID, profileID uuid.UUID, variant string, countAccess bool) (*models.StreamFile, error) {
+	location, err := s.locate(ctx, itemID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := s.TimeLimits.GetStatus(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+	if !status.PlaybackAllowed {
+		return nil, fmt.Errorf("%w: %s", ErrPlaybackNotAllowed, status.Message)
+	}
+
+	relativePath := location.RelativePath
+	if variant != "" {
+		relativePath, err = s.variantPath(ctx, itemID, variant)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	path, err := resolveContentPath(relativePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if countAccess {
+		err = s.Tiering.RecordAccess(ctx, itemID)
+	} else if state, stateErr := s.DB.GetContentTiering(ctx, itemID); stateErr == nil && state.Tier == tiering.Cold {
+		err = s.Tiering.restore(ctx, itemID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error preparing content for playback: %w", err)
+	}
+
+	return &models.StreamFile{
+		ContentItemID: itemID,
+		CourseID:      location.CourseID,
+		ContentType:   location.ContentType,
+		Path:          path,
+		Filename:      filepath.Base(relativePath),
+	}, nil
+}
+
+// locate looks up where a content item's file is, refusing items in courses the profile
+// can't see. Without a profile there's nothing to check restrictions against, so
+// anonymous requests see no courses at all.
+func (s *StreamService) locate(ctx context.Context, itemID, profileID uuid.UUID) (database.GetContentItemLocationRow, error) {
+	location, err := s.DB.GetContentItemLocation(ctx, itemID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return location, fmt.Errorf("content item not found: %w", err)
+		}
+		return location, fmt.Errorf("error retrieving content item: %w", err)
+	}
+	if profileID == uuid.Nil {
+		return location, ErrContentNotVisible
+	}
+
+	visible, err := s.Visibility.CanSeeCourse(ctx, profileID, location.CourseID)
+	if err != nil {
+		return location, fmt.Errorf("error checking course visibility: %w", err)
+	}
+	if !visible {
+		return location, ErrContentNotVisible
+	}
+	return location, nil
+}
+
+// variantPath finds the file of a content item variant by label (case-insensitive) or ID
+func (s *StreamService) variantPath(ctx context.Context, itemID uuid.UUID, variant string) (string, error) {
+	variants, err := s.DB.ListContentItemVariants(ctx, itemID)
+	if err != nil {
+		return "", fmt.Errorf("error retrieving content variants: %w", err)
+	}
+
+	for _, v := range variants {
+		if strings.EqualFold(v.Label, variant) || v.ID.String() == variant {
+			return v.RelativePath, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrVariantNotFound, variant)
+}
+
+// resolveContentPath turns a stored relative path into an absolute one and makes sure
+// it is strictly inside the courses directory, so a tampered path can't serve other files
+func resolveContentPath(relativePath string) (string, error) {
+	base, err := filepath.Abs(util.GetCoursesDirectory())
+	if err != nil {
+		return "", fmt.Errorf("error resolving courses directory: %w", err)
+	}
+
+	path, err := filepath.Abs(util.ResolveCourseFilePath(relativePath))
+	if err != nil {
+		return "", fmt.Errorf("error resolving content path: %w", err)
+	}
+
+	rel, err := filepath.Rel(base, path)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: %s", ErrUnsafeContentPath, relativePath)
+	}
+	return path, nil
+}
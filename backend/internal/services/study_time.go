@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/studytime"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidPace is returned when a pacing request asks for a non-positive weekly pace
+var ErrInvalidPace = errors.New("hours per week must be greater than zero")
+
+// StudyTimeService estimates how long courses take and when a profile will finish them
+type StudyTimeService struct {
+	Courses    *CourseService       // course tree and progress
+	Heuristics studytime.Heuristics // minutes per item guesses
+}
+
+// NewStudyTimeService creates service with course dependency and heuristics from env
+func NewStudyTimeService(courses *CourseService) *StudyTimeService {
+	return &StudyTimeService{
+		Courses:    courses,
+		Heuristics: studytime.LoadHeuristics(),
+	}
+}
+
+// EstimateCourse estimates study time per module. With a userID, remaining time
+// takes that profile's progress into account; without one it equals the total.
+func (s *StudyTimeService) EstimateCourse(ctx context.Context, courseID, userID uuid.UUID) (*models.CourseStudyEstimate, error) {
+	course, err := s.Courses.GetCourse(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	// how far through each item the profile is, 0-1
+	done := make(map[uuid.UUID]float64)
+	if userID != uuid.Nil {
+		progress, err := s.Courses.GetUserCourseProgress(ctx, userID, courseID)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving progress: %w", err)
+		}
+		for _, p := range progress {
+			if p.Completed {
+				done[p.ContentItemID] = 1
+			} else {
+				done[p.ContentItemID] = float64(p.ProgressPct) / 100
+			}
+		}
+	}
+
+	estimate := &models.CourseStudyEstimate{
+		CourseID: courseID,
+		UserID:   userID,
+		Modules:  []models.ModuleStudyEstimate{},
+	}
+
+	for _, module := range course.Modules {
+		var total, remaining time.Duration
+		moduleEstimate := models.ModuleStudyEstimate{
+			ModuleID: module.ID,
+			Title:    module.Title,
+			Order:    module.Order,
+		}
+
+		for _, item := range module.ContentItems {
+			if item.Hidden {
+				continue
+			}
+			moduleEstimate.Items++
+			itemEstimate := s.Heuristics.Item(item.ContentType, item.Size, item.Duration)
+			if itemEstimate.Guessed {
+				moduleEstimate.GuessedItems++
+			}
+			total += itemEstimate.Duration
+			remaining += time.Duration(float64(itemEstimate.Duration) * (1 - done[item.ID]))
+		}
+
+		moduleEstimate.TotalMinutes = minutes(total)
+		moduleEstimate.RemainingMinutes = minutes(remaining)
+
+		estimate.TotalMinutes += moduleEstimate.TotalMinutes
+		estimate.RemainingMinutes += moduleEstimate.RemainingMinutes
+		estimate.GuessedItems += moduleEstimate.GuessedItems
+		estimate.Modules = append(estimate.Modules, moduleEstimate)
+	}
+
+	return estimate, nil
+}
+
+// Pacing works out when the profile finishes each module and the whole course
+// studying hoursPerWeek, going through modules in order from start
+func (s *StudyTimeService) Pacing(ctx context.Context, courseID, userID uuid.UUID, hoursPerWeek float64, start time.Time) (*models.CoursePacing, error) {
+	if hoursPerWeek <= 0 {
+		return nil, ErrInvalidPace
+	}
+
+	estimate, err := s.EstimateCourse(ctx, courseID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	perWeek := time.Duration(hoursPerWeek * float64(time.Hour))
+	var elapsed time.Duration
+	for i := range estimate.Modules {
+		elapsed += time.Duration(estimate.Modules[i].RemainingMinutes) * time.Minute
+		finishBy := studytime.FinishDate(start, elapsed, perWeek)
+		estimate.Modules[i].FinishBy = &finishBy
+	}
+
+	remaining := time.Duration(estimate.RemainingMinutes) * time.Minute
+	finish := studytime.FinishDate(start, remaining, perWeek)
+
+	pacing := &models.CoursePacing{
+		CourseStudyEstimate: *estimate,
+		HoursPerWeek:        hoursPerWeek,
+		StartDate:           start,
+		FinishDate:          finish,
+		Weeks:               float64(remaining) / float64(perWeek),
+	}
+
+	pace := strconv.FormatFloat(hoursPerWeek, 'f', -1, 64)
+	if estimate.RemainingMinutes == 0 {
+		pacing.Summary = "Nothing left to study in this course"
+	} else {
+		pacing.Summary = fmt.Sprintf("At %sh/week you'll finish on %s", pace, finish.Format("January 2, 2006"))
+	}
+
+	return pacing, nil
+}
+
+// minutes rounds a duration to whole minutes
+func minutes(d time.Duration) int {
+	return int(d.Round(time.Minute) / time.Minute)
+}
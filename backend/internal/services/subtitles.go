@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/parser"
+	"github.com/NeroQue/course-management-backend/pkg/subtitles"
+	"github.com/google/uuid"
+)
+
+// MaxSubtitleBytes caps the size of caption files converted on the fly
+const MaxSubtitleBytes = 5 << 20
+
+// ErrSubtitleNotFound is returned for a track that isn't one of the item's caption files
+var ErrSubtitleNotFound = errors.New("subtitle track not found")
+
+// ListSubtitles lists the caption files that go with a video content item.
+// Other content types have none, so they get an empty list.
+func (s *StreamService) ListSubtitles(ctx context.Context, itemID, profileID uuid.UUID) ([]models.SubtitleTrack, error) {
+	files, err := s.subtitleFiles(ctx, itemID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	tracks := make([]models.SubtitleTrack, 0, len(files))
+	for _, f := range files {
+		label := "Subtitles"
+		if f.Language != "" {
+			label = strings.ToUpper(f.Language)
+		}
+		tracks = append(tracks, models.SubtitleTrack{
+			ID:       f.Name,
+			Language: f.Language,
+			Label:    label,
+			Format:   f.Format,
+			URL:      "/api/content/" + itemID.String() + "/subtitles/" + url.PathEscape(f.Name),
+		})
+	}
+	return tracks, nil
+}
+
+// OpenSubtitle returns a caption track of a content item converted to WebVTT.
+// track has to be one of the listed track IDs - it is never used as a path itself.
+func (s *StreamService) OpenSubtitle(ctx context.Context, itemID, profileID uuid.UUID, track string) ([]byte, error) {
+	files, err := s.subtitleFiles(ctx, itemID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range files {
+		if f.Name != track {
+			continue
+		}
+
+		info, err := os.Stat(f.Path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading subtitle file: %w", err)
+		}
+		if info.Size() > MaxSubtitleBytes {
+			return nil, fmt.Errorf("subtitle file %s is larger than %d bytes", f.Name, MaxSubtitleBytes)
+		}
+
+		data, err := os.ReadFile(f.Path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading subtitle file: %w", err)
+		}
+		return subtitles.ToWebVTT(data, f.Format), nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrSubtitleNotFound, track)
+}
+
+// subtitleFiles finds the caption files next to a video item's file on disk
+func (s *StreamService) subtitleFiles(ctx context.Context, itemID, profileID uuid.UUID) ([]parser.SubtitleFile, error) {
+	location, err := s.locate(ctx, itemID, profileID)
+	if err != nil {
+		return nil, err
+	}
+	if location.ContentType != "video" {
+		return nil, nil
+	}
+
+	path, err := resolveContentPath(location.RelativePath)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := parser.FindSubtitles(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error looking for subtitle files: %w", err)
+	}
+	return files, nil
+}
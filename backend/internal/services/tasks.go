@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/pkg/task"
+	"github.com/google/uuid"
+)
+
+// TaskStore keeps background tasks in the database, so failed ones can be retried after
+// a restart. It's the task.Store the task workers are set up with.
+type TaskStore struct {
+	DB *database.Queries // database access
+}
+
+// NewTaskStore creates store with its dependencies
+func NewTaskStore(db *database.Queries) *TaskStore {
+	return &TaskStore{DB: db}
+}
+
+// SaveTask writes a task, replacing what was stored for it before
+func (s *TaskStore) SaveTask(ctx context.Context, t *task.Task) error {
+	id, err := uuid.Parse(t.ID)
+	if err != nil {
+		return fmt.Errorf("invalid task ID %q: %w", t.ID, err)
+	}
+
+	result, err := encodeTaskJSON(t.Result)
+	if err != nil {
+		return fmt.Errorf("error encoding task result: %w", err)
+	}
+	logs, err := encodeTaskJSON(t.Logs)
+	if err != nil {
+		return fmt.Errorf("error encoding task logs: %w", err)
+	}
+
+	err = s.DB.SaveTask(ctx, database.SaveTaskParams{
+		ID:           id,
+		Type:         t.Type,
+		Status:       string(t.Status),
+		Input:        string(t.Input),
+		Result:       result,
+		Message:      t.Message,
+		ErrorMessage: t.ErrorMessage,
+		Retries:      int32(t.Retries),
+		Logs:         logs,
+		CreatedAt:    t.CreatedAt,
+		StartedAt:    sql.NullTime{Time: t.StartedAt, Valid: !t.StartedAt.IsZero()},
+		CompletedAt:  sql.NullTime{Time: t.CompletedAt, Valid: !t.CompletedAt.IsZero()},
+	})
+	if err != nil {
+		return fmt.Errorf("error saving task: %w", err)
+	}
+	return nil
+}
+
+// LoadTasks returns every stored task that didn't complete, oldest first
+func (s *TaskStore) LoadTasks(ctx context.Context) ([]*task.Task, error) {
+	rows, err := s.DB.ListUnfinishedTasks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving tasks: %w", err)
+	}
+
+	tasks := make([]*task.Task, 0, len(rows))
+	for _, row := range rows {
+		t := &task.Task{
+			ID:           row.ID.String(),
+			Type:         row.Type,
+			Status:       task.Status(row.Status),
+			CreatedAt:    row.CreatedAt,
+			StartedAt:    row.StartedAt.Time,
+			CompletedAt:  row.CompletedAt.Time,
+			Message:      row.Message,
+			ErrorMessage: row.ErrorMessage,
+			Retries:      int(row.Retries),
+		}
+		if row.Input != "" {
+			t.Input = json.RawMessage(row.Input)
+		}
+		if row.Result != "" {
+			t.Result = json.RawMessage(row.Result)
+		}
+		// lost logs are no reason to lose the task
+		if row.Logs != "" {
+			if err := json.Unmarshal([]byte(row.Logs), &t.Logs); err != nil {
+				log.Printf("Warning: could not read logs of task %s: %v", t.ID, err)
+			}
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks, nil
+}
+
+// DeleteTasksBefore removes completed and failed tasks that finished before cutoff
+func (s *TaskStore) DeleteTasksBefore(ctx context.Context, cutoff time.Time) error {
+	if _, err := s.DB.DeleteFinishedTasksBefore(ctx, sql.NullTime{Time: cutoff, Valid: true}); err != nil {
+		return fmt.Errorf("error deleting old tasks: %w", err)
+	}
+	return nil
+}
+
+// encodeTaskJSON stores a result or logs as the JSON the tasks API shows, nothing stays empty
+func encodeTaskJSON(value interface{}) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/pkg/artifacts"
+	"github.com/NeroQue/course-management-backend/pkg/task"
+	"github.com/NeroQue/course-management-backend/pkg/thumbnail"
+	"github.com/NeroQue/course-management-backend/pkg/tiering"
+	"github.com/NeroQue/course-management-backend/pkg/util"
+	"github.com/google/uuid"
+)
+
+// ErrThumbnailUnavailable is returned when the file a thumbnail would be made from isn't on disk
+var ErrThumbnailUnavailable = errors.New("content file is not available for a thumbnail")
+
+// ThumbnailService makes preview images for content items and caches them as artifacts
+type ThumbnailService struct {
+	DB         *database.Queries  // database access
+	Store      *artifacts.Store   // thumbnails live under <artifacts>/thumbnails/<course id>/
+	Visibility *VisibilityService // restricted profiles only see assigned courses
+
+	SpriteInterval int // seconds between frames on the scrubbing sprite sheets
+
+	generating  sync.Mutex // one ffmpeg/pdftoppm at a time, and no duplicate work for the same file
+	spriteMu    sync.Mutex
+	spriteTasks map[uuid.UUID]string // sprite generation queued or running per content item, by task ID
+}
+
+// NewThumbnailService creates service with its dependencies
+func NewThumbnailService(db *database.Queries, visibility *VisibilityService) *ThumbnailService {
+	s := &ThumbnailService{
+		DB:         db,
+		Store:      artifacts.NewStore(),
+		Visibility: visibility,
+
+		SpriteInterval: util.GetEnvInt("SPRITE_INTERVAL", 10),
+		spriteTasks:    make(map[uuid.UUID]string),
+	}
+	task.Register("sprite_generation", s.spriteWork)
+	return s
+}
+
+// GetThumbnail returns the path and checksum of a content item's thumbnail, generating it on first use.
+// Thumbnails are keyed by the file's checksum, so replacing the file makes a new one.
+func (s *ThumbnailService) GetThumbnail(ctx context.Context, itemID, profileID uuid.UUID) (string, string, error) {
+	location, err := s.locate(ctx, itemID, profileID)
+	if err != nil {
+		return "", "", err
+	}
+
+	if !thumbnail.Supported(location.ContentType) {
+		return "", "", fmt.Errorf("%w: %s", thumbnail.ErrUnsupported, location.ContentType)
+	}
+
+	src, checksum, err := s.sourceFile(ctx, location)
+	if err != nil {
+		return "", "", err
+	}
+
+	path := filepath.Join(s.Store.CourseDir(artifacts.Thumbnails, location.CourseID), checksum+".jpg")
+	if _, err := os.Stat(path); err == nil {
+		return path, checksum, nil
+	}
+
+	s.generating.Lock()
+	defer s.generating.Unlock()
+
+	// another request may have made it while we waited
+	if _, err := os.Stat(path); err == nil {
+		return path, checksum, nil
+	}
+
+	item, err := s.DB.GetContentItem(ctx, itemID)
+	if err != nil {
+		return "", "", fmt.Errorf("error retrieving content item: %w", err)
+	}
+
+	// a tenth of the way in skips black intro frames without landing in the middle of a slide
+	seek := min(int(item.Duration.Int32)/10, 30)
+	if err := thumbnail.Generate(ctx, src, location.ContentType, path, seek); err != nil {
+		return "", "", err
+	}
+	return path, checksum, nil
+}
+
+// locate looks up a content item, refusing items in courses the profile can't see.
+// Anonymous requests see no courses, same as StreamService.locate.
+func (s *ThumbnailService) locate(ctx context.Context, itemID, profileID uuid.UUID) (database.GetContentItemLocationRow, error) {
+	location, err := s.DB.GetContentItemLocation(ctx, itemID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return location, fmt.Errorf("content item not found: %w", err)
+		}
+		return location, fmt.Errorf("error retrieving content item: %w", err)
+	}
+
+	if profileID == uuid.Nil {
+		return location, ErrContentNotVisible
+	}
+
+	visible, err := s.Visibility.CanSeeCourse(ctx, profileID, location.CourseID)
+	if err != nil {
+		return location, fmt.Errorf("error checking course visibility: %w", err)
+	}
+	if !visible {
+		return location, ErrContentNotVisible
+	}
+	return location, nil
+}
+
+// sourceFile returns the path and checksum of the file previews are made from
+func (s *ThumbnailService) sourceFile(ctx context.Context, location database.GetContentItemLocationRow) (string, string, error) {
+	// cold files stay where they are - a preview isn't worth restoring a whole video
+	state, err := s.DB.GetContentTiering(ctx, location.ID)
+	if err == nil && state.Tier == tiering.Cold {
+		return "", "", fmt.Errorf("%w: %s is in cold storage", ErrThumbnailUnavailable, location.RelativePath)
+	}
+
+	src, err := resolveContentPath(location.RelativePath)
+	if err != nil {
+		return "", "", err
+	}
+
+	checksum, err := thumbnail.Checksum(src)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %v", ErrThumbnailUnavailable, err)
+	}
+	return src, checksum, nil
+}
@@ -0,0 +1,236 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/task"
+	"github.com/NeroQue/course-management-backend/pkg/tiering"
+	"github.com/google/uuid"
+)
+
+// maxColdItemsListed caps the cold item list in the status report
+const maxColdItemsListed = 100
+
+// ErrTieringDisabled is returned when no cold backend is configured
+var ErrTieringDisabled = errors.New("storage tiering is not configured")
+
+// TieringService moves rarely used course media to cold storage and brings it back on access
+type TieringService struct {
+	DB       *database.Queries // database access
+	Backend  tiering.Backend   // nil when tiering is off
+	Policy   tiering.Policy    // what counts as rarely used
+	BasePath string            // courses directory, where hot files live
+
+	moving sync.Mutex // files are moved one at a time so a restore can't race a demotion
+}
+
+// NewTieringService creates service with the configured backend and policy
+func NewTieringService(db *database.Queries, basePath string) *TieringService {
+	s := &TieringService{
+		DB:       db,
+		Backend:  tiering.NewBackend(),
+		Policy:   tiering.LoadPolicy(),
+		BasePath: basePath,
+	}
+	task.Register("storage_tiering", s.policyWork)
+	return s
+}
+
+// Enabled reports whether a cold backend is configured
+func (s *TieringService) Enabled() bool {
+	return s.Backend != nil
+}
+
+// RecordAccess counts an access to a content item and restores it first if it's cold
+func (s *TieringService) RecordAccess(ctx context.Context, itemID uuid.UUID) error {
+	row, err := s.DB.RecordContentAccess(ctx, itemID)
+	if err != nil {
+		return fmt.Errorf("error recording content access: %w", err)
+	}
+
+	if row.Tier != tiering.Cold {
+		return nil
+	}
+	return s.restore(ctx, itemID)
+}
+
+// restore moves a cold item back into the courses directory
+func (s *TieringService) restore(ctx context.Context, itemID uuid.UUID) error {
+	s.moving.Lock()
+	defer s.moving.Unlock()
+
+	// another request may have restored it while we waited
+	row, err := s.DB.GetContentTiering(ctx, itemID)
+	if err != nil {
+		return fmt.Errorf("error retrieving content tier: %w", err)
+	}
+	if row.Tier != tiering.Cold {
+		return nil
+	}
+
+	if !s.Enabled() {
+		return fmt.Errorf("%w: content item %s is in cold storage", ErrTieringDisabled, itemID)
+	}
+
+	item, err := s.DB.GetContentItem(ctx, itemID)
+	if err != nil {
+		return fmt.Errorf("error retrieving content item: %w", err)
+	}
+
+	fullPath := filepath.Join(s.BasePath, item.RelativePath)
+	if err := s.Backend.Restore(row.ColdKey.String, fullPath); err != nil {
+		return fmt.Errorf("error restoring %s from cold storage: %w", item.RelativePath, err)
+	}
+
+	// clones point at the same file, so every item parked under this key is hot again
+	if err := s.DB.MarkColdKeyHot(ctx, row.ColdKey); err != nil {
+		return fmt.Errorf("error marking content hot: %w", err)
+	}
+
+	log.Printf("Restored %s from cold storage", item.RelativePath)
+	return nil
+}
+
+// RunPolicy moves one batch of rarely used items to cold storage
+func (s *TieringService) RunPolicy(ctx context.Context) (models.TieringRunResult, error) {
+	result := models.TieringRunResult{}
+	if !s.Enabled() {
+		return result, ErrTieringDisabled
+	}
+
+	candidates, err := s.DB.ListColdCandidates(ctx, database.ListColdCandidatesParams{
+		Cutoff:      time.Now().Add(-s.Policy.ColdAfter),
+		MaxAccesses: int32(s.Policy.MaxAccesses),
+		MinBytes:    s.Policy.MinBytes,
+		MaxItems:    int32(s.Policy.BatchSize),
+	})
+	if err != nil {
+		return result, fmt.Errorf("error finding cold candidates: %w", err)
+	}
+
+	for _, candidate := range candidates {
+		if err := s.demote(ctx, candidate); err != nil {
+			result.Errors = append(result.Errors, err.Error())
+			continue
+		}
+		result.ItemsMoved++
+		result.BytesMoved += candidate.Size.Int64
+	}
+
+	log.Printf("Tiering pass moved %d items (%d bytes) to cold storage, %d errors",
+		result.ItemsMoved, result.BytesMoved, len(result.Errors))
+	return result, nil
+}
+
+// demote moves one item's file to the cold backend and records where it went
+func (s *TieringService) demote(ctx context.Context, candidate database.ListColdCandidatesRow) error {
+	s.moving.Lock()
+	defer s.moving.Unlock()
+
+	fullPath := filepath.Join(s.BasePath, candidate.RelativePath)
+	if _, err := os.Stat(fullPath); err != nil {
+		return fmt.Errorf("%s: %w", candidate.RelativePath, err)
+	}
+
+	// keyed by item ID so renames and moves in the library don't matter
+	key := candidate.ID.String() + filepath.Ext(candidate.RelativePath)
+	if err := s.Backend.Store(key, fullPath); err != nil {
+		return fmt.Errorf("error moving %s to cold storage: %w", candidate.RelativePath, err)
+	}
+
+	err := s.DB.MarkContentCold(ctx, database.MarkContentColdParams{
+		ContentItemID: candidate.ID,
+		ColdKey:       sql.NullString{String: key, Valid: true},
+	})
+	if err != nil {
+		// put the file back so the library doesn't lose track of it
+		if restoreErr := s.Backend.Restore(key, fullPath); restoreErr != nil {
+			log.Printf("Error putting %s back after failed demotion: %v", candidate.RelativePath, restoreErr)
+		}
+		return fmt.Errorf("error marking %s cold: %w", candidate.RelativePath, err)
+	}
+
+	return nil
+}
+
+// GetStatus returns the policy, usage per tier and the items currently in cold storage
+func (s *TieringService) GetStatus(ctx context.Context) (*models.TieringStatus, error) {
+	status := &models.TieringStatus{
+		Enabled: s.Enabled(),
+		Policy: models.TieringPolicy{
+			ColdAfterSeconds: int64(s.Policy.ColdAfter.Seconds()),
+			MaxAccesses:      s.Policy.MaxAccesses,
+			MinBytes:         s.Policy.MinBytes,
+			BatchSize:        s.Policy.BatchSize,
+		},
+		Tiers:     []models.TierUsage{},
+		ColdItems: []models.ColdContentItem{},
+	}
+	if s.Enabled() {
+		status.Backend = s.Backend.Name()
+	}
+
+	tiers, err := s.DB.CountContentByTier(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error counting content by tier: %w", err)
+	}
+	for _, tier := range tiers {
+		status.Tiers = append(status.Tiers, models.TierUsage{Tier: tier.Tier, Items: tier.Items, Bytes: tier.Bytes})
+	}
+
+	cold, err := s.DB.ListColdContent(ctx, maxColdItemsListed)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving cold content: %w", err)
+	}
+	for _, item := range cold {
+		status.ColdItems = append(status.ColdItems, models.ColdContentItem{
+			ID:             item.ID,
+			Title:          item.Title,
+			RelativePath:   item.RelativePath,
+			Size:           item.Size.Int64,
+			AccessCount:    int(item.AccessCount),
+			LastAccessedAt: item.LastAccessedAt,
+			TieredAt:       item.TieredAt,
+		})
+	}
+
+	return status, nil
+}
+
+// StartPolicyTask runs a tiering pass as a background task and returns the task ID
+func (s *TieringService) StartPolicyTask() string {
+	return task.Submit("storage_tiering", nil)
+}
+
+// policyWork is a tiering pass, it takes no input
+func (s *TieringService) policyWork(struct{}) task.Work {
+	return func(ctx context.Context, taskID string) (interface{}, error) {
+		task.SetTaskMessage(taskID, "Moving rarely used content to cold storage")
+		return s.RunPolicy(ctx)
+	}
+}
+
+// PolicyRoutine runs a tiering task every interval - meant to run in its own goroutine
+func (s *TieringService) PolicyRoutine(interval time.Duration) {
+	if !s.Enabled() {
+		log.Printf("Storage tiering disabled, set COLD_STORAGE_DIR to enable it")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.StartPolicyTask()
+	}
+}
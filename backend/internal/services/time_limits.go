@@ -0,0 +1,203 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// defaultWarningPct is when we start warning if the admin didn't pick a threshold
+const defaultWarningPct = 80
+
+// TimeLimitService handles per-profile viewing time limits
+type TimeLimitService struct {
+	DB *database.Queries // database access
+}
+
+// NewTimeLimitService creates service with db dependency
+func NewTimeLimitService(db *database.Queries) *TimeLimitService {
+	return &TimeLimitService{
+		DB: db,
+	}
+}
+
+// GetLimits returns the configured limits for a profile, or nil if there are none
+func (s *TimeLimitService) GetLimits(ctx context.Context, userID uuid.UUID) (*models.TimeLimits, error) {
+	dbLimits, err := s.DB.GetProfileTimeLimits(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error retrieving time limits: %w", err)
+	}
+
+	return toTimeLimitsModel(dbLimits), nil
+}
+
+// SetLimits creates or replaces the limits for a profile
+func (s *TimeLimitService) SetLimits(ctx context.Context, userID uuid.UUID, input models.SetTimeLimitsInput) (*models.TimeLimits, error) {
+	if input.DailyMinutes != nil && *input.DailyMinutes < 0 {
+		return nil, errors.New("daily limit cannot be negative")
+	}
+	if input.WeeklyMinutes != nil && *input.WeeklyMinutes < 0 {
+		return nil, errors.New("weekly limit cannot be negative")
+	}
+
+	warningPct := defaultWarningPct
+	if input.WarningPct != nil {
+		if *input.WarningPct < 1 || *input.WarningPct > 100 {
+			return nil, errors.New("warning percentage must be between 1 and 100")
+		}
+		warningPct = *input.WarningPct
+	}
+
+	dbLimits, err := s.DB.UpsertProfileTimeLimits(ctx, database.UpsertProfileTimeLimitsParams{
+		ProfileID:     userID,
+		DailyMinutes:  toNullInt32(input.DailyMinutes),
+		WeeklyMinutes: toNullInt32(input.WeeklyMinutes),
+		WarningPct:    int32(warningPct),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error saving time limits: %w", err)
+	}
+
+	return toTimeLimitsModel(dbLimits), nil
+}
+
+// ClearLimits removes all limits for a profile
+func (s *TimeLimitService) ClearLimits(ctx context.Context, userID uuid.UUID) error {
+	if err := s.DB.DeleteProfileTimeLimits(ctx, userID); err != nil {
+		return fmt.Errorf("error removing time limits: %w", err)
+	}
+	return nil
+}
+
+// RecordViewingTime adds watched seconds to today's usage for a profile.
+// Playback code calls this with the time it actually credited.
+func (s *TimeLimitService) RecordViewingTime(ctx context.Context, userID uuid.UUID, seconds int) error {
+	if seconds <= 0 {
+		return nil
+	}
+
+	err := s.DB.AddViewingTime(ctx, database.AddViewingTimeParams{
+		ProfileID: userID,
+		Day:       startOfDay(time.Now()),
+		Seconds:   int32(seconds),
+	})
+	if err != nil {
+		return fmt.Errorf("error recording viewing time: %w", err)
+	}
+	return nil
+}
+
+// GetStatus compares today's and this week's usage against the limits.
+// Playback should be denied when PlaybackAllowed is false.
+func (s *TimeLimitService) GetStatus(ctx context.Context, userID uuid.UUID) (*models.TimeLimitStatus, error) {
+	now := time.Now()
+
+	todaySeconds, err := s.DB.GetViewingSecondsSince(ctx, database.GetViewingSecondsSinceParams{
+		ProfileID: userID,
+		Day:       startOfDay(now),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving today's viewing time: %w", err)
+	}
+
+	weekSeconds, err := s.DB.GetViewingSecondsSince(ctx, database.GetViewingSecondsSinceParams{
+		ProfileID: userID,
+		Day:       startOfWeek(now),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving this week's viewing time: %w", err)
+	}
+
+	limits, err := s.GetLimits(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &models.TimeLimitStatus{
+		ProfileID:        userID,
+		Limits:           limits,
+		UsedTodayMinutes: int(todaySeconds / 60),
+		UsedWeekMinutes:  int(weekSeconds / 60),
+		State:            models.TimeLimitOK,
+		PlaybackAllowed:  true,
+	}
+
+	if limits == nil {
+		return status, nil
+	}
+
+	// check each limit, the tightest one wins
+	check := func(label string, limit *int, used int) {
+		if limit == nil {
+			return
+		}
+
+		remaining := max(*limit-used, 0)
+		if status.RemainingMinutes == nil || remaining < *status.RemainingMinutes {
+			status.RemainingMinutes = &remaining
+		}
+
+		switch {
+		case used >= *limit:
+			status.State = models.TimeLimitExceeded
+			status.PlaybackAllowed = false
+			status.Message = fmt.Sprintf("%s limit of %d minutes reached", label, *limit)
+		case used*100 >= *limit*limits.WarningPct && status.State == models.TimeLimitOK:
+			status.State = models.TimeLimitWarning
+			status.Message = fmt.Sprintf("%d minutes left of the %s limit", remaining, label)
+		}
+	}
+
+	check("daily", limits.DailyMinutes, status.UsedTodayMinutes)
+	check("weekly", limits.WeeklyMinutes, status.UsedWeekMinutes)
+
+	return status, nil
+}
+
+// toTimeLimitsModel converts the db row to the app model
+func toTimeLimitsModel(dbLimits database.ProfileTimeLimit) *models.TimeLimits {
+	limits := &models.TimeLimits{
+		ProfileID:  dbLimits.ProfileID,
+		WarningPct: int(dbLimits.WarningPct),
+		CreatedAt:  dbLimits.CreatedAt,
+		UpdatedAt:  dbLimits.UpdatedAt,
+	}
+	if dbLimits.DailyMinutes.Valid {
+		daily := int(dbLimits.DailyMinutes.Int32)
+		limits.DailyMinutes = &daily
+	}
+	if dbLimits.WeeklyMinutes.Valid {
+		weekly := int(dbLimits.WeeklyMinutes.Int32)
+		limits.WeeklyMinutes = &weekly
+	}
+	return limits
+}
+
+// toNullInt32 turns an optional int into a nullable db value
+func toNullInt32(value *int) sql.NullInt32 {
+	if value == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: int32(*value), Valid: true}
+}
+
+// startOfDay truncates a time to local midnight
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// startOfWeek returns local midnight on the Monday of t's week
+func startOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7 // Monday = 0
+	return startOfDay(t).AddDate(0, 0, -offset)
+}
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// PreviewTitleRename shows which titles a regex rename would change without touching anything
+func (s *AdminService) PreviewTitleRename(ctx context.Context, input models.TitleRenameInput) (*models.TitleRenameResult, error) {
+	changes, err := s.findTitleChanges(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TitleRenameResult{
+		DryRun:  true,
+		Count:   len(changes),
+		Changes: changes,
+	}, nil
+}
+
+// ApplyTitleRename renames all matching titles in one transaction and records
+// every change in the change history under a single batch ID
+func (s *AdminService) ApplyTitleRename(ctx context.Context, input models.TitleRenameInput, actorID uuid.UUID) (*models.TitleRenameResult, error) {
+	changes, err := s.findTitleChanges(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.TitleRenameResult{Count: len(changes), Changes: changes}
+	if len(changes) == 0 {
+		return result, nil
+	}
+
+	batchID := uuid.New()
+	err = runInTx(ctx, s.Conn, s.DB, func(q *database.Queries) error {
+		for _, change := range changes {
+			if err := setEntityTitle(ctx, q, change.EntityType, change.EntityID, change.NewTitle); err != nil {
+				return err
+			}
+
+			_, err := q.CreateChangeRecord(ctx, database.CreateChangeRecordParams{
+				BatchID:    batchID,
+				EntityType: change.EntityType,
+				EntityID:   change.EntityID,
+				Field:      "title",
+				OldValue:   sql.NullString{String: change.OldTitle, Valid: true},
+				NewValue:   sql.NullString{String: change.NewTitle, Valid: true},
+				ChangedBy:  uuid.NullUUID{UUID: actorID, Valid: actorID != uuid.Nil},
+			})
+			if err != nil {
+				return fmt.Errorf("failed to record change: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Title rename batch %s applied to %d titles", batchID, len(changes))
+	result.BatchID = &batchID
+	return result, nil
+}
+
+// UndoChangeBatch restores the old values of every change in a batch.
+// Titles edited again since the batch are left alone and reported as skipped.
+func (s *AdminService) UndoChangeBatch(ctx context.Context, batchID uuid.UUID) ([]models.ChangeRecord, []string, error) {
+	dbChanges, err := s.DB.ListChangesByBatch(ctx, batchID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving change batch: %w", err)
+	}
+	if len(dbChanges) == 0 {
+		return nil, nil, fmt.Errorf("change batch not found: %w", sql.ErrNoRows)
+	}
+	if dbChanges[0].RevertedAt.Valid {
+		return nil, nil, errors.New("change batch has already been undone")
+	}
+
+	var reverted []models.ChangeRecord
+	var skipped []string
+
+	err = runInTx(ctx, s.Conn, s.DB, func(q *database.Queries) error {
+		for _, change := range dbChanges {
+			if change.Field != "title" {
+				skipped = append(skipped, fmt.Sprintf("%s %s: field %s cannot be undone", change.EntityType, change.EntityID, change.Field))
+				continue
+			}
+
+			current, err := currentEntityTitle(ctx, q, change.EntityType, change.EntityID)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					skipped = append(skipped, fmt.Sprintf("%s %s no longer exists", change.EntityType, change.EntityID))
+					continue
+				}
+				return err
+			}
+
+			// someone changed it again after the rename - don't clobber their edit
+			if current != change.NewValue.String {
+				skipped = append(skipped, fmt.Sprintf("%s %s was changed since (now %q)", change.EntityType, change.EntityID, current))
+				continue
+			}
+
+			if err := setEntityTitle(ctx, q, change.EntityType, change.EntityID, change.OldValue.String); err != nil {
+				return err
+			}
+			reverted = append(reverted, toChangeRecordModel(change))
+		}
+
+		return q.MarkBatchReverted(ctx, batchID)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	log.Printf("Change batch %s undone: %d reverted, %d skipped", batchID, len(reverted), len(skipped))
+	return reverted, skipped, nil
+}
+
+// findTitleChanges runs the regex over every title in scope and collects the ones that change
+func (s *AdminService) findTitleChanges(ctx context.Context, input models.TitleRenameInput) ([]models.TitleChange, error) {
+	if input.Pattern == "" {
+		return nil, errors.New("pattern is required")
+	}
+
+	re, err := regexp.Compile(input.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern: %w", err)
+	}
+
+	for _, scope := range input.Scopes {
+		if scope != models.EntityCourse && scope != models.EntityModule && scope != models.EntityContentItem {
+			return nil, fmt.Errorf("unknown scope %q (expected course, module or content_item)", scope)
+		}
+	}
+	inScope := func(scope string) bool {
+		return len(input.Scopes) == 0 || slices.Contains(input.Scopes, scope)
+	}
+	inCourse := func(courseID uuid.UUID) bool {
+		return input.CourseID == uuid.Nil || input.CourseID == courseID
+	}
+
+	rename := func(title string) string {
+		renamed := re.ReplaceAllString(title, input.Replacement)
+		if input.TrimSpace {
+			renamed = strings.Join(strings.Fields(renamed), " ")
+		}
+		return renamed
+	}
+
+	changes := []models.TitleChange{}
+	addChange := func(entityType string, entityID, courseID uuid.UUID, title string) {
+		renamed := rename(title)
+		// never rename something to an empty title
+		if renamed == title || strings.TrimSpace(renamed) == "" {
+			return
+		}
+		changes = append(changes, models.TitleChange{
+			EntityType: entityType,
+			EntityID:   entityID,
+			CourseID:   courseID,
+			OldTitle:   title,
+			NewTitle:   renamed,
+		})
+	}
+
+	if inScope(models.EntityCourse) {
+		courses, err := s.DB.ListCourses(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving courses: %w", err)
+		}
+		for _, course := range courses {
+			if inCourse(course.ID) {
+				addChange(models.EntityCourse, course.ID, course.ID, course.Title)
+			}
+		}
+	}
+
+	if inScope(models.EntityModule) {
+		modules, err := s.DB.ListAllModules(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving modules: %w", err)
+		}
+		for _, module := range modules {
+			if inCourse(module.CourseID) {
+				addChange(models.EntityModule, module.ID, module.CourseID, module.Title)
+			}
+		}
+	}
+
+	if inScope(models.EntityContentItem) {
+		items, err := s.DB.ListAllContentItemsWithCourse(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving content items: %w", err)
+		}
+		for _, item := range items {
+			if inCourse(item.CourseID) {
+				addChange(models.EntityContentItem, item.ID, item.CourseID, item.Title)
+			}
+		}
+	}
+
+	return changes, nil
+}
+
+// setEntityTitle updates the title of a course, module or content item
+func setEntityTitle(ctx context.Context, q *database.Queries, entityType string, id uuid.UUID, title string) error {
+	var err error
+	switch entityType {
+	case models.EntityCourse:
+		err = q.UpdateCourseTitle(ctx, database.UpdateCourseTitleParams{ID: id, Title: title})
+	case models.EntityModule:
+		err = q.UpdateModuleTitle(ctx, database.UpdateModuleTitleParams{ID: id, Title: title})
+	case models.EntityContentItem:
+		err = q.UpdateContentItemTitle(ctx, database.UpdateContentItemTitleParams{ID: id, Title: title})
+	default:
+		return fmt.Errorf("unknown entity type %q", entityType)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to rename %s %s: %w", entityType, id, err)
+	}
+	return nil
+}
+
+// currentEntityTitle looks up the current title of a course, module or content item
+func currentEntityTitle(ctx context.Context, q *database.Queries, entityType string, id uuid.UUID) (string, error) {
+	switch entityType {
+	case models.EntityCourse:
+		course, err := q.GetCourse(ctx, id)
+		return course.Title, err
+	case models.EntityModule:
+		module, err := q.GetModule(ctx, id)
+		return module.Title, err
+	case models.EntityContentItem:
+		item, err := q.GetContentItem(ctx, id)
+		return item.Title, err
+	}
+	return "", fmt.Errorf("unknown entity type %q", entityType)
+}
+
+// toChangeRecordModel converts the db row to the app model
+func toChangeRecordModel(dbChange database.ChangeHistory) models.ChangeRecord {
+	return models.ChangeRecord{
+		ID:         dbChange.ID,
+		BatchID:    dbChange.BatchID,
+		EntityType: dbChange.EntityType,
+		EntityID:   dbChange.EntityID,
+		Field:      dbChange.Field,
+		OldValue:   dbChange.OldValue.String,
+		NewValue:   dbChange.NewValue.String,
+		ChangedBy:  dbChange.ChangedBy.UUID,
+		RevertedAt: dbChange.RevertedAt,
+		CreatedAt:  dbChange.CreatedAt,
+	}
+}
@@ -0,0 +1,222 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/metrics"
+	"github.com/NeroQue/course-management-backend/pkg/task"
+	"github.com/NeroQue/course-management-backend/pkg/tiering"
+	"github.com/NeroQue/course-management-backend/pkg/transcribe"
+	"github.com/google/uuid"
+)
+
+// transcript errors, the handler maps each to its own status
+var (
+	ErrTranscriptionDisabled = errors.New("no transcription provider is configured")
+	ErrTranscriptNotFound    = errors.New("content item has no transcript")
+)
+
+// TranscriptService makes speech-to-text transcripts of audio and video items with the
+// configured provider and keeps them in the database, where search can find them
+type TranscriptService struct {
+	DB          *database.Queries      // database access
+	Tiering     *TieringService        // cold items get restored before they're transcribed
+	Visibility  *VisibilityService     // restricted profiles only see assigned courses
+	Transcriber transcribe.Transcriber // nil when TRANSCRIBE_PROVIDER isn't set
+
+	mu    sync.Mutex
+	tasks map[uuid.UUID]string // transcription queued or running per content item, by task ID
+}
+
+// NewTranscriptService creates service with its dependencies, reading the provider from the environment
+func NewTranscriptService(db *database.Queries, tiering *TieringService, visibility *VisibilityService) *TranscriptService {
+	transcriber := transcribe.FromEnv()
+	if transcriber != nil {
+		log.Printf("Transcription enabled with %s", transcriber.Name())
+	}
+
+	s := &TranscriptService{
+		DB:          db,
+		Tiering:     tiering,
+		Visibility:  visibility,
+		Transcriber: transcriber,
+		tasks:       make(map[uuid.UUID]string),
+	}
+	task.Register("transcription", s.transcriptionWork)
+	return s
+}
+
+// transcriptionInput is what a transcription task runs on
+type transcriptionInput struct {
+	ContentItemID uuid.UUID `json:"content_item_id"`
+	Language      string    `json:"language"`
+}
+
+// StartTranscription transcribes an audio or video item in a background task and returns
+// its ID. A request while one is already running for the item gets that task instead.
+// An existing transcript is replaced when the new one is done.
+func (s *TranscriptService) StartTranscription(ctx context.Context, itemID, profileID uuid.UUID) (string, error) {
+	if s.Transcriber == nil {
+		return "", ErrTranscriptionDisabled
+	}
+
+	location, err := s.locate(ctx, itemID, profileID)
+	if err != nil {
+		return "", err
+	}
+	if location.ContentType != "audio" && location.ContentType != "video" {
+		return "", fmt.Errorf("%w: %s is %s", ErrNotMediaContent, location.Title, location.ContentType)
+	}
+
+	if _, err := resolveContentPath(location.RelativePath); err != nil {
+		return "", err
+	}
+
+	// the course language helps the model, "pt-br" becomes "pt"
+	language := ""
+	if course, err := s.DB.GetCourse(ctx, location.CourseID); err == nil && len(course.Language.String) >= 2 {
+		language = strings.ToLower(course.Language.String[:2])
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if taskID, ok := s.tasks[itemID]; ok {
+		return taskID, nil
+	}
+
+	// the task workers run one transcription at a time, they're heavy on CPU or on the API bill
+	taskID := task.Submit("transcription", transcriptionInput{ContentItemID: itemID, Language: language})
+	s.tasks[itemID] = taskID
+
+	return taskID, nil
+}
+
+// transcriptionWork transcribes the item of a transcription task. The file is looked up
+// again on each run, a retry may come after the item moved.
+func (s *TranscriptService) transcriptionWork(input transcriptionInput) task.Work {
+	itemID := input.ContentItemID
+	return func(ctx context.Context, taskID string) (interface{}, error) {
+		defer func() {
+			s.mu.Lock()
+			if s.tasks[itemID] == taskID {
+				delete(s.tasks, itemID)
+			}
+			s.mu.Unlock()
+		}()
+
+		location, err := s.DB.GetContentItemLocation(ctx, itemID)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving content item: %w", err)
+		}
+		path, err := resolveContentPath(location.RelativePath)
+		if err != nil {
+			return nil, err
+		}
+
+		transcript, err := s.transcribe(ctx, taskID, itemID, path, input.Language)
+		if err != nil {
+			log.Printf("Error transcribing content %s: %v", itemID, err)
+			return nil, err
+		}
+
+		log.Printf("Transcribed %s with %s", location.RelativePath, transcript.Provider)
+		return transcript, nil
+	}
+}
+
+// transcribe runs the provider on one file and stores the result
+func (s *TranscriptService) transcribe(ctx context.Context, taskID string, itemID uuid.UUID, path, language string) (*models.ContentTranscript, error) {
+	// a cold file has to come back first, the provider needs the whole thing
+	if state, err := s.DB.GetContentTiering(ctx, itemID); err == nil && state.Tier == tiering.Cold {
+		task.SetTaskMessage(taskID, "Restoring "+filepath.Base(path)+" from cold storage")
+		if err := s.Tiering.restore(ctx, itemID); err != nil {
+			return nil, err
+		}
+	}
+
+	task.SetTaskMessage(taskID, "Transcribing "+filepath.Base(path)+" with "+s.Transcriber.Name())
+	vtt, err := s.Transcriber.Transcribe(ctx, path, language)
+	if err != nil {
+		metrics.IncCounter(metrics.FailedTranscodes, "transcriber")
+		return nil, err
+	}
+
+	text := transcribe.PlainText(vtt)
+	if text == "" {
+		return nil, fmt.Errorf("%s returned an empty transcript", s.Transcriber.Name())
+	}
+
+	row, err := s.DB.UpsertContentTranscript(ctx, database.UpsertContentTranscriptParams{
+		ContentItemID: itemID,
+		Provider:      s.Transcriber.Name(),
+		Language:      sql.NullString{String: language, Valid: language != ""},
+		Vtt:           vtt,
+		Text:          text,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error saving transcript: %w", err)
+	}
+	return toTranscriptModel(row), nil
+}
+
+// GetTranscript returns a content item's transcript along with its WebVTT version
+func (s *TranscriptService) GetTranscript(ctx context.Context, itemID, profileID uuid.UUID) (*models.ContentTranscript, string, error) {
+	if _, err := s.locate(ctx, itemID, profileID); err != nil {
+		return nil, "", err
+	}
+
+	row, err := s.DB.GetContentTranscript(ctx, itemID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, "", ErrTranscriptNotFound
+		}
+		return nil, "", fmt.Errorf("error retrieving transcript: %w", err)
+	}
+	return toTranscriptModel(row), row.Vtt, nil
+}
+
+// locate looks up a content item, refusing items in courses the profile can't see.
+// Anonymous requests see no courses, same as StreamService.locate.
+func (s *TranscriptService) locate(ctx context.Context, itemID, profileID uuid.UUID) (database.GetContentItemLocationRow, error) {
+	location, err := s.DB.GetContentItemLocation(ctx, itemID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return location, fmt.Errorf("content item not found: %w", err)
+		}
+		return location, fmt.Errorf("error retrieving content item: %w", err)
+	}
+
+	if profileID == uuid.Nil {
+		return location, ErrContentNotVisible
+	}
+
+	visible, err := s.Visibility.CanSeeCourse(ctx, profileID, location.CourseID)
+	if err != nil {
+		return location, fmt.Errorf("error checking course visibility: %w", err)
+	}
+	if !visible {
+		return location, ErrContentNotVisible
+	}
+	return location, nil
+}
+
+// toTranscriptModel converts a transcript row, leaving the VTT out
+func toTranscriptModel(row database.ContentTranscript) *models.ContentTranscript {
+	return &models.ContentTranscript{
+		ContentItemID: row.ContentItemID,
+		Provider:      row.Provider,
+		Language:      row.Language.String,
+		Text:          row.Text,
+		UpdatedAt:     row.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+)
+
+// runInTx runs fn inside a database transaction and rolls back if it returns an error
+func runInTx(ctx context.Context, conn *sql.DB, queries *database.Queries, fn func(q *database.Queries) error) error {
+	if conn == nil {
+		return errors.New("transactions not available: no database connection configured")
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	if err := fn(queries.WithTx(tx)); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("%w (rollback also failed: %v)", err, rollbackErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
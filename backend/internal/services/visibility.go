@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/access"
+	"github.com/google/uuid"
+)
+
+// ErrUnknownCourse is returned when a course assignment names a course that doesn't exist
+var ErrUnknownCourse = errors.New("unknown course")
+
+// VisibilityService decides which courses each profile gets to see
+type VisibilityService struct {
+	DB   *database.Queries // database access
+	Conn *sql.DB           // raw connection for transactions
+}
+
+// NewVisibilityService creates service with database dependencies
+func NewVisibilityService(db *database.Queries, conn *sql.DB) *VisibilityService {
+	return &VisibilityService{
+		DB:   db,
+		Conn: conn,
+	}
+}
+
+// GetVisibility returns a profile's restriction flag and assigned courses
+func (s *VisibilityService) GetVisibility(ctx context.Context, profileID uuid.UUID) (*models.CourseVisibility, error) {
+	profile, err := s.DB.GetProfileById(ctx, profileID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("profile not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving profile: %w", err)
+	}
+
+	courseIDs, err := s.DB.ListAssignedCourseIDs(ctx, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving course assignments: %w", err)
+	}
+	if courseIDs == nil {
+		courseIDs = []uuid.UUID{}
+	}
+
+	return &models.CourseVisibility{
+		ProfileID:  profileID,
+		Restricted: profile.CoursesRestricted,
+		CourseIDs:  courseIDs,
+	}, nil
+}
+
+// SetVisibility replaces a profile's assigned courses and restriction flag in one go
+func (s *VisibilityService) SetVisibility(ctx context.Context, profileID, actorID uuid.UUID, input models.SetCourseVisibilityInput) (*models.CourseVisibility, error) {
+	// check everything up front so a typo doesn't leave a kid with an empty library
+	seen := make(map[uuid.UUID]bool, len(input.CourseIDs))
+	courseIDs := make([]uuid.UUID, 0, len(input.CourseIDs))
+	for _, courseID := range input.CourseIDs {
+		if seen[courseID] {
+			continue
+		}
+		seen[courseID] = true
+
+		if _, err := s.DB.GetCourse(ctx, courseID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, fmt.Errorf("%w: %s", ErrUnknownCourse, courseID)
+			}
+			return nil, fmt.Errorf("error retrieving course: %w", err)
+		}
+		courseIDs = append(courseIDs, courseID)
+	}
+
+	err := runInTx(ctx, s.Conn, s.DB, func(q *database.Queries) error {
+		if err := q.ClearCourseAssignments(ctx, profileID); err != nil {
+			return fmt.Errorf("error clearing course assignments: %w", err)
+		}
+
+		for _, courseID := range courseIDs {
+			err := q.AssignCourse(ctx, database.AssignCourseParams{
+				CourseID:   courseID,
+				ProfileID:  profileID,
+				AssignedBy: toNullUUID(actorID),
+			})
+			if err != nil {
+				return fmt.Errorf("error assigning course: %w", err)
+			}
+		}
+
+		err := q.SetProfileCoursesRestricted(ctx, database.SetProfileCoursesRestrictedParams{
+			ID:                profileID,
+			CoursesRestricted: input.Restricted,
+		})
+		if err != nil {
+			return fmt.Errorf("error updating profile restriction: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetVisibility(ctx, profileID)
+}
+
+// visibleCourseIDs returns the courses a profile may see, or nil when it may see everything.
+// Admins and unrestricted profiles see the whole library.
+func (s *VisibilityService) visibleCourseIDs(ctx context.Context, profileID uuid.UUID) (map[uuid.UUID]bool, error) {
+	profile, err := s.DB.GetProfileById(ctx, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving profile: %w", err)
+	}
+	if profile.Role == access.RoleAdmin || !profile.CoursesRestricted {
+		return nil, nil
+	}
+
+	courseIDs, err := s.DB.ListAssignedCourseIDs(ctx, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving course assignments: %w", err)
+	}
+
+	visible := make(map[uuid.UUID]bool, len(courseIDs))
+	for _, id := range courseIDs {
+		visible[id] = true
+	}
+	return visible, nil
+}
+
+// FilterVisibleCourses drops the courses the profile isn't allowed to see
+func (s *VisibilityService) FilterVisibleCourses(ctx context.Context, profileID uuid.UUID, courses []*models.Course) ([]*models.Course, error) {
+	visible, err := s.visibleCourseIDs(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+	if visible == nil {
+		return courses, nil
+	}
+
+	filtered := []*models.Course{}
+	for _, course := range courses {
+		if visible[course.ID] {
+			filtered = append(filtered, course)
+		}
+	}
+	return filtered, nil
+}
+
+// CanSeeCourse reports whether the profile may see a single course
+func (s *VisibilityService) CanSeeCourse(ctx context.Context, profileID, courseID uuid.UUID) (bool, error) {
+	visible, err := s.visibleCourseIDs(ctx, profileID)
+	if err != nil {
+		return false, err
+	}
+	return visible == nil || visible[courseID], nil
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/heartbeat"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidHeartbeat is returned for a heartbeat with a nonsense delta
+var ErrInvalidHeartbeat = errors.New("invalid heartbeat")
+
+// WatchTimeService turns player heartbeats into credited watch time, grouped into sessions
+type WatchTimeService struct {
+	DB         *database.Queries  // database access
+	TimeLimits *TimeLimitService  // credited time counts against viewing limits
+	Visibility *VisibilityService // restricted profiles only see assigned courses
+	Policy     heartbeat.Policy   // how much a heartbeat is worth, HEARTBEAT_* env vars
+
+	// heartbeats from one player can overlap and sessions are read-modify-write, so beats
+	// for the same profile and item take turns. Other players don't wait on them.
+	mu    sync.Mutex
+	locks map[watchKey]*watchLock
+}
+
+// watchKey is what a watch session belongs to
+type watchKey struct {
+	profileID uuid.UUID
+	itemID    uuid.UUID
+}
+
+// watchLock serializes heartbeats for one key, users counts who holds or waits for it
+type watchLock struct {
+	sync.Mutex
+	users int
+}
+
+// NewWatchTimeService creates service with its dependencies
+func NewWatchTimeService(db *database.Queries, timeLimits *TimeLimitService, visibility *VisibilityService) *WatchTimeService {
+	return &WatchTimeService{
+		DB:         db,
+		TimeLimits: timeLimits,
+		Visibility: visibility,
+		Policy:     heartbeat.LoadPolicy(),
+		locks:      make(map[watchKey]*watchLock),
+	}
+}
+
+// lockSession waits for other heartbeats for the same profile and item to finish and
+// returns the function that lets the next one in
+func (s *WatchTimeService) lockSession(key watchKey) func() {
+	s.mu.Lock()
+	lock := s.locks[key]
+	if lock == nil {
+		lock = &watchLock{}
+		s.locks[key] = lock
+	}
+	lock.users++
+	s.mu.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+		s.mu.Lock()
+		if lock.users--; lock.users == 0 {
+			delete(s.locks, key)
+		}
+		s.mu.Unlock()
+	}
+}
+
+// RecordHeartbeat credits the watch time a player reports for a content item. The policy
+// caps what one beat is worth; a beat after a long silence starts a new session instead.
+func (s *WatchTimeService) RecordHeartbeat(ctx context.Context, itemID, profileID uuid.UUID, input models.HeartbeatInput) (*models.HeartbeatResult, error) {
+	if input.Seconds < 0 || math.IsNaN(input.Seconds) || math.IsInf(input.Seconds, 0) {
+		return nil, fmt.Errorf("%w: seconds must be zero or more", ErrInvalidHeartbeat)
+	}
+
+	location, err := s.DB.GetContentItemLocation(ctx, itemID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("content item not found: %w", err)
+		}
+		return nil, fmt.Errorf("error retrieving content item: %w", err)
+	}
+	visible, err := s.Visibility.CanSeeCourse(ctx, profileID, location.CourseID)
+	if err != nil {
+		return nil, fmt.Errorf("error checking course visibility: %w", err)
+	}
+	if !visible {
+		return nil, ErrContentNotVisible
+	}
+
+	unlock := s.lockSession(watchKey{profileID: profileID, itemID: itemID})
+	defer unlock()
+
+	// TIMESTAMP columns have no zone, UTC keeps the gaps right whatever the server's zone is
+	now := time.Now().UTC()
+	reported := time.Duration(input.Seconds * float64(time.Second))
+
+	session, err := s.DB.GetLatestWatchSession(ctx, database.GetLatestWatchSessionParams{
+		ProfileID:     profileID,
+		ContentItemID: itemID,
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("error retrieving watch session: %w", err)
+	}
+
+	var verdict heartbeat.Result
+	paused, pauseReason := false, ""
+	if err == nil {
+		verdict = s.Policy.Evaluate(session.LastBeatAt, now, reported, time.Duration(session.Seconds)*time.Second)
+		paused, pauseReason = verdict.Paused, verdict.Reason
+	}
+	if err != nil || paused {
+		// nothing to continue - this beat opens a new session
+		verdict = s.Policy.Evaluate(time.Time{}, now, reported, 0)
+		session, err = s.DB.CreateWatchSession(ctx, database.CreateWatchSessionParams{
+			ID:            uuid.New(),
+			ProfileID:     profileID,
+			ContentItemID: itemID,
+			StartedAt:     now,
+			LastBeatAt:    now,
+			Seconds:       int32(verdict.Credited.Round(time.Second).Seconds()),
+			Suspicious:    verdict.Suspicious,
+			FlagReason:    sql.NullString{String: verdict.Reason, Valid: verdict.Suspicious},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error starting watch session: %w", err)
+		}
+	} else {
+		flagReason := session.FlagReason
+		if verdict.Suspicious {
+			flagReason = sql.NullString{String: verdict.Reason, Valid: true}
+		}
+		session, err = s.DB.UpdateWatchSession(ctx, database.UpdateWatchSessionParams{
+			ID:         session.ID,
+			LastBeatAt: now,
+			Seconds:    int32(verdict.Session.Round(time.Second).Seconds()),
+			Suspicious: session.Suspicious || verdict.Suspicious,
+			FlagReason: flagReason,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error updating watch session: %w", err)
+		}
+	}
+
+	credited := int(verdict.Credited.Round(time.Second).Seconds())
+	if err := s.TimeLimits.RecordViewingTime(ctx, profileID, credited); err != nil {
+		return nil, err
+	}
+
+	status, err := s.TimeLimits.GetStatus(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.HeartbeatResult{
+		SessionID:       session.ID,
+		CreditedSeconds: credited,
+		SessionSeconds:  int(session.Seconds),
+		Paused:          paused,
+		Suspicious:      verdict.Suspicious,
+		Reason:          verdict.Reason,
+		TimeLimit:       status,
+	}
+	if paused && result.Reason == "" {
+		result.Reason = pauseReason
+	}
+	return result, nil
+}
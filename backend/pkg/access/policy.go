@@ -0,0 +1,330 @@
+package access
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// roles a route can require, from most to least open
+const (
+	RolePublic = "public" // anyone, no profile needed
+	RoleUser   = "user"   // any selected profile
+	RoleEditor = "editor" // editor and admin profiles
+	RoleAdmin  = "admin"  // admin profiles only
+	RoleDeny   = "deny"   // nobody - the route is switched off
+)
+
+// RoleViewer is the role of profiles that can only study: read the library and keep
+// their own progress. Profiles are viewers, editors or admins, each with the rights of
+// the one before. As a route role it's the same as user.
+const RoleViewer = "viewer"
+
+// ProfileRoles are the roles a profile can have, from fewest rights to most
+var ProfileRoles = []string{RoleViewer, RoleEditor, RoleAdmin}
+
+// where an effective policy came from
+const (
+	SourceDefault = "default" // the instance-wide default role
+	SourceBuiltin = "builtin" // shipped with the app
+	SourceConfig  = "config"  // the policy file
+)
+
+// Rule maps a route pattern to the role it requires.
+// Patterns look like mux patterns: "GET /api/courses/{id}". The method may be "*" and
+// the path may end in "/*" to cover everything below it.
+type Rule struct {
+	Pattern string `json:"pattern"`
+	Role    string `json:"role"`
+}
+
+// Config is the policy file format
+type Config struct {
+	DefaultRole string `json:"default_role"` // for routes no rule covers, public if empty
+	Rules       []Rule `json:"policies"`
+}
+
+// Effective is the policy that applies to one registered route
+type Effective struct {
+	Route  string `json:"route"`
+	Role   string `json:"role"`
+	Source string `json:"source"`
+	Rule   string `json:"rule,omitempty"` // the pattern that matched, empty for the default
+}
+
+// builtinRules keep the app usable and locked down out of the box, the policy file can change them
+var builtinRules = []Rule{
+	// the instance
+	{Pattern: "* /api/admin/*", Role: RoleAdmin},
+	{Pattern: "GET /metrics", Role: RoleAdmin},
+	{Pattern: "POST /api/tasks/cleanup", Role: RoleAdmin},
+
+	// the library
+	{Pattern: "GET /api/courses/scan", Role: RoleEditor},
+	{Pattern: "GET /api/courses/directories", Role: RoleEditor},
+	{Pattern: "POST /api/courses", Role: RoleEditor},
+	{Pattern: "POST /api/courses/batch", Role: RoleEditor},
+	{Pattern: "POST /api/courses/import-bundle", Role: RoleEditor},
+	{Pattern: "POST /api/courses/bulk-delete", Role: RoleEditor},
+	{Pattern: "PUT /api/courses/order", Role: RoleEditor},
+	{Pattern: "PATCH /api/courses/{id}", Role: RoleEditor},
+	{Pattern: "DELETE /api/courses/{id}", Role: RoleEditor},
+	{Pattern: "POST /api/courses/{id}/clone", Role: RoleEditor},
+	{Pattern: "PUT /api/courses/{id}/prerequisites", Role: RoleEditor},
+	{Pattern: "POST /api/courses/{id}/modules/reorder", Role: RoleEditor},
+	{Pattern: "POST /api/courses/{id}/modules/merge", Role: RoleEditor},
+	{Pattern: "PATCH /api/modules/{id}", Role: RoleEditor},
+	{Pattern: "POST /api/modules/{id}/content/reorder", Role: RoleEditor},
+	{Pattern: "PATCH /api/content/{id}", Role: RoleEditor},
+	{Pattern: "POST /api/content/{id}/hidden", Role: RoleEditor},
+	{Pattern: "PUT /api/content/{id}/chapters", Role: RoleEditor},
+	{Pattern: "GET /api/tasks", Role: RoleEditor},
+	{Pattern: "POST /api/tasks/{id}/retry", Role: RoleEditor},
+
+	// a selected profile, the handlers check it's the caller's own or the caller is an admin
+	{Pattern: "GET /api/content/*", Role: RoleUser}, // course restrictions need a profile
+	{Pattern: "PUT /api/profiles", Role: RoleUser},
+	{Pattern: "DELETE /api/profiles", Role: RoleUser},
+	{Pattern: "GET /api/profiles/{id}/time-limits", Role: RoleUser},
+
+	// before anyone is logged in
+	{Pattern: "GET /api/profiles", Role: RolePublic},
+	{Pattern: "POST /api/profiles/{id}/select", Role: RolePublic},
+	{Pattern: "POST /api/guest", Role: RolePublic},
+	{Pattern: "GET /api/profiles/{id}/avatar", Role: RolePublic},
+	{Pattern: "GET /api/auth/oidc/*", Role: RolePublic},
+	{Pattern: "POST /api/email/verify", Role: RolePublic},
+	{Pattern: "GET /api/share/*", Role: RolePublic}, // share links are for outsiders
+	{Pattern: "POST /api/share/*", Role: RolePublic},
+	{Pattern: "GET /api/offline/*", Role: RolePublic}, // downloads carry their own signature
+	{Pattern: "GET /api/maintenance", Role: RolePublic},
+}
+
+// Policies resolves which role each registered route requires
+type Policies struct {
+	mu          sync.RWMutex
+	defaultRole string
+	builtin     []Rule
+	config      []Rule
+	routes      []string
+	resolved    map[string]Effective
+}
+
+// New creates policies from a config, nil means builtin rules only
+func New(config *Config) (*Policies, error) {
+	p := &Policies{
+		defaultRole: RolePublic,
+		builtin:     builtinRules,
+		resolved:    make(map[string]Effective),
+	}
+	if config == nil {
+		return p, nil
+	}
+
+	if config.DefaultRole != "" {
+		if !validRole(config.DefaultRole) {
+			return nil, fmt.Errorf("invalid default_role %q", config.DefaultRole)
+		}
+		p.defaultRole = config.DefaultRole
+	}
+
+	for _, rule := range config.Rules {
+		if !validRole(rule.Role) {
+			return nil, fmt.Errorf("invalid role %q for %q", rule.Role, rule.Pattern)
+		}
+		if _, _, err := splitPattern(rule.Pattern); err != nil {
+			return nil, err
+		}
+	}
+	p.config = config.Rules
+	return p, nil
+}
+
+// Load reads a policy file. An empty path means builtin rules only.
+func Load(path string) (*Policies, error) {
+	if path == "" {
+		return New(nil)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading policy file: %w", err)
+	}
+
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("error parsing policy file %s: %w", path, err)
+	}
+	return New(&config)
+}
+
+// OverrideBuiltin replaces the builtin rule with the same pattern, or adds one. It's for
+// settings that change what the app needs out of the box, like profile selection being
+// switched off for OIDC login; rules from the policy file still win over it.
+func (p *Policies) OverrideBuiltin(rule Rule) error {
+	if !validRole(rule.Role) {
+		return fmt.Errorf("invalid role %q for %q", rule.Role, rule.Pattern)
+	}
+	if _, _, err := splitPattern(rule.Pattern); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	builtin := slices.DeleteFunc(slices.Clone(p.builtin), func(r Rule) bool { return r.Pattern == rule.Pattern })
+	p.builtin = append(builtin, rule)
+	clear(p.resolved)
+	return nil
+}
+
+// Register adds a route so it shows up in the effective policy list
+func (p *Policies) Register(route string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.routes = append(p.routes, route)
+	delete(p.resolved, route)
+}
+
+// Resolve returns the policy for a registered route pattern.
+// Config rules win over builtin ones, and within each the most specific pattern wins.
+func (p *Policies) Resolve(route string) Effective {
+	p.mu.RLock()
+	effective, ok := p.resolved[route]
+	p.mu.RUnlock()
+	if ok {
+		return effective
+	}
+
+	effective = Effective{Route: route, Role: p.defaultRole, Source: SourceDefault}
+	if rule, found := bestMatch(p.config, route); found {
+		effective.Role, effective.Source, effective.Rule = rule.Role, SourceConfig, rule.Pattern
+	} else if rule, found := bestMatch(p.builtin, route); found {
+		effective.Role, effective.Source, effective.Rule = rule.Role, SourceBuiltin, rule.Pattern
+	}
+
+	p.mu.Lock()
+	p.resolved[route] = effective
+	p.mu.Unlock()
+	return effective
+}
+
+// All returns the effective policy of every registered route, sorted by path
+func (p *Policies) All() []Effective {
+	p.mu.RLock()
+	routes := append([]string(nil), p.routes...)
+	p.mu.RUnlock()
+
+	all := make([]Effective, 0, len(routes))
+	for _, route := range routes {
+		all = append(all, p.Resolve(route))
+	}
+	sort.Slice(all, func(i, j int) bool {
+		_, pathI, _ := splitPattern(all[i].Route)
+		_, pathJ, _ := splitPattern(all[j].Route)
+		if pathI != pathJ {
+			return pathI < pathJ
+		}
+		return all[i].Route < all[j].Route
+	})
+	return all
+}
+
+// DefaultRole is the role for routes no rule covers
+func (p *Policies) DefaultRole() string {
+	return p.defaultRole
+}
+
+// Allows reports whether a caller whose profile has profileRole may use a route with the
+// given role. profileRole is empty when nobody is logged in.
+func Allows(role, profileRole string) bool {
+	switch role {
+	case RolePublic:
+		return true
+	case RoleUser, RoleViewer:
+		return profileRole != ""
+	case RoleEditor:
+		return profileRole == RoleEditor || profileRole == RoleAdmin
+	case RoleAdmin:
+		return profileRole == RoleAdmin
+	default:
+		return false // deny, or anything we don't understand
+	}
+}
+
+// ValidProfileRole reports whether role is one a profile can have
+func ValidProfileRole(role string) bool {
+	return slices.Contains(ProfileRoles, role)
+}
+
+// bestMatch finds the most specific rule matching a route - exact beats prefix, longer prefix beats shorter
+func bestMatch(rules []Rule, route string) (Rule, bool) {
+	method, path, err := splitPattern(route)
+	if err != nil {
+		return Rule{}, false
+	}
+
+	var best Rule
+	bestScore := -1
+	for _, rule := range rules {
+		score := matchScore(rule.Pattern, method, path)
+		if score > bestScore {
+			best, bestScore = rule, score
+		}
+	}
+	return best, bestScore >= 0
+}
+
+// matchScore says how specifically a rule pattern matches, -1 for no match
+func matchScore(pattern, method, path string) int {
+	ruleMethod, rulePath, err := splitPattern(pattern)
+	if err != nil {
+		return -1
+	}
+	if ruleMethod != "*" && ruleMethod != method {
+		return -1
+	}
+
+	score := 0
+	if ruleMethod != "*" {
+		score = 1 // a named method beats a wildcard one with the same path
+	}
+
+	if prefix, ok := strings.CutSuffix(rulePath, "/*"); ok {
+		if path != prefix && !strings.HasPrefix(path, prefix+"/") {
+			return -1
+		}
+		return score + 2*len(prefix)
+	}
+
+	if rulePath != path {
+		return -1
+	}
+	return score + 2*len(rulePath) + 1_000_000 // exact matches always win
+}
+
+// splitPattern splits "GET /api/x" into method and path. Patterns without a method match any.
+func splitPattern(pattern string) (string, string, error) {
+	method, path, found := strings.Cut(strings.TrimSpace(pattern), " ")
+	if !found {
+		method, path = "*", method
+	}
+	path = strings.TrimSpace(path)
+	if !strings.HasPrefix(path, "/") {
+		return "", "", fmt.Errorf("invalid policy pattern %q: path must start with /", pattern)
+	}
+	return strings.ToUpper(method), path, nil
+}
+
+// validRole reports whether role is one of the known roles
+func validRole(role string) bool {
+	switch role {
+	case RolePublic, RoleUser, RoleViewer, RoleEditor, RoleAdmin, RoleDeny:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package access
+
+import "testing"
+
+func TestBestMatch(t *testing.T) {
+	rules := []Rule{
+		{Pattern: "* /api/admin/*", Role: RoleAdmin},
+		{Pattern: "GET /api/admin/stats", Role: RoleEditor},
+		{Pattern: "/api/courses/*", Role: RoleUser},
+		{Pattern: "GET /api/courses/*", Role: RolePublic},
+		{Pattern: "POST /api/courses", Role: RoleEditor},
+		{Pattern: "* /api/courses/{id}/notes/*", Role: RoleViewer},
+		{Pattern: "DELETE /api/courses/{id}", Role: RoleDeny},
+	}
+
+	tests := []struct {
+		route string
+		want  string // the pattern that should win, empty for no match
+	}{
+		{"GET /api/admin/stats", "GET /api/admin/stats"},                   // exact beats prefix
+		{"POST /api/admin/stats", "* /api/admin/*"},                        // exact rule is for another method
+		{"GET /api/admin", "* /api/admin/*"},                               // a prefix covers its own path
+		{"GET /api/courses/{id}", "GET /api/courses/*"},                    // named method beats any method
+		{"PATCH /api/courses/{id}", "/api/courses/*"},                      // no method means any
+		{"POST /api/courses", "POST /api/courses"},                         // exact beats a prefix of the same path
+		{"GET /api/courses/{id}/notes/{n}", "* /api/courses/{id}/notes/*"}, // longer prefix beats method
+		{"DELETE /api/courses/{id}", "DELETE /api/courses/{id}"},
+		{"GET /api/coursesx", ""}, // a prefix only matches whole segments
+		{"GET /api/profiles", ""},
+		{"not a route", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.route, func(t *testing.T) {
+			rule, found := bestMatch(rules, tt.route)
+			if tt.want == "" {
+				if found {
+					t.Errorf("matched %q, want no match", rule.Pattern)
+				}
+				return
+			}
+			if !found || rule.Pattern != tt.want {
+				t.Errorf("matched %q (found %v), want %q", rule.Pattern, found, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllows(t *testing.T) {
+	tests := []struct {
+		role    string
+		profile string
+		want    bool
+	}{
+		{RolePublic, "", true},
+		{RolePublic, RoleViewer, true},
+		{RoleUser, "", false},
+		{RoleUser, RoleViewer, true},
+		{RoleViewer, "", false},
+		{RoleViewer, RoleViewer, true},
+		{RoleEditor, RoleViewer, false},
+		{RoleEditor, RoleEditor, true},
+		{RoleEditor, RoleAdmin, true},
+		{RoleAdmin, RoleEditor, false},
+		{RoleAdmin, RoleAdmin, true},
+		{RoleDeny, RoleAdmin, false},
+		{"superuser", RoleAdmin, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.role+"/"+tt.profile, func(t *testing.T) {
+			if got := Allows(tt.role, tt.profile); got != tt.want {
+				t.Errorf("Allows(%q, %q) = %v, want %v", tt.role, tt.profile, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	p, err := New(&Config{
+		DefaultRole: RoleUser,
+		Rules: []Rule{
+			{Pattern: "* /api/courses/*", Role: RoleAdmin},
+			{Pattern: "GET /api/share/*", Role: RoleDeny},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.OverrideBuiltin(Rule{Pattern: "POST /api/profiles/{id}/select", Role: RoleDeny}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		route  string
+		role   string
+		source string
+	}{
+		// any config rule beats a builtin one, even a less specific one
+		{"PATCH /api/courses/{id}", RoleAdmin, SourceConfig},
+		{"GET /api/share/{token}", RoleDeny, SourceConfig},
+		{"GET /api/admin/users", RoleAdmin, SourceBuiltin},
+		{"POST /api/profiles/{id}/select", RoleDeny, SourceBuiltin},
+		{"GET /api/profiles", RolePublic, SourceBuiltin},
+		{"GET /metrics", RoleAdmin, SourceBuiltin},
+		{"GET /api/tasks", RoleEditor, SourceBuiltin},
+		{"GET /api/profiles/{id}/time-limits", RoleUser, SourceBuiltin},
+		{"GET /api/streaks", RoleUser, SourceDefault},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.route, func(t *testing.T) {
+			got := p.Resolve(tt.route)
+			if got.Role != tt.role || got.Source != tt.source {
+				t.Errorf("got %s from %s, want %s from %s", got.Role, got.Source, tt.role, tt.source)
+			}
+		})
+	}
+}
+
+func TestNewRejectsInvalidRules(t *testing.T) {
+	configs := map[string]*Config{
+		"default role": {DefaultRole: "root"},
+		"rule role":    {Rules: []Rule{{Pattern: "GET /api/x", Role: "root"}}},
+		"rule pattern": {Rules: []Rule{{Pattern: "GET api/x", Role: RoleUser}}},
+	}
+	for name, config := range configs {
+		if _, err := New(config); err == nil {
+			t.Errorf("%s: invalid config accepted", name)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package artifacts
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// TypeUsage is the disk usage of one artifact type
+type TypeUsage struct {
+	Bytes int64 `json:"bytes"`
+	Files int   `json:"files"`
+}
+
+// CourseUsage is the disk usage of one course's artifacts, split by type
+type CourseUsage struct {
+	CourseID   string               `json:"course_id"`
+	TotalBytes int64                `json:"total_bytes"`
+	Types      map[string]TypeUsage `json:"types"`
+}
+
+// Usage walks the store and sums up disk usage per course and type
+func (s *Store) Usage() (map[string]*CourseUsage, error) {
+	usage := make(map[string]*CourseUsage)
+	for _, artifactType := range Types {
+		files, err := s.listFiles(artifactType)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, f := range files {
+			course, ok := usage[f.courseID]
+			if !ok {
+				course = &CourseUsage{CourseID: f.courseID, Types: make(map[string]TypeUsage)}
+				usage[f.courseID] = course
+			}
+			typeUsage := course.Types[artifactType]
+			typeUsage.Bytes += f.size
+			typeUsage.Files++
+			course.Types[artifactType] = typeUsage
+			course.TotalBytes += f.size
+		}
+	}
+	return usage, nil
+}
+
+// CleanupResult says what a janitor pass removed
+type CleanupResult struct {
+	FilesRemoved   int      `json:"files_removed"`
+	BytesFreed     int64    `json:"bytes_freed"`
+	OrphansRemoved []string `json:"orphans_removed"` // course folders whose course is gone
+	Errors         []string `json:"errors,omitempty"`
+}
+
+// Clean applies the retention policies and removes artifacts of courses that no longer exist
+// knownCourses may be nil to skip orphan removal.
+func (s *Store) Clean(policies map[string]Policy, knownCourses map[string]bool, now time.Time) CleanupResult {
+	result := CleanupResult{OrphansRemoved: []string{}}
+
+	for _, artifactType := range Types {
+		if knownCourses != nil {
+			s.removeOrphans(artifactType, knownCourses, &result)
+		}
+
+		policy, ok := policies[artifactType]
+		if !ok {
+			continue
+		}
+
+		files, err := s.listFiles(artifactType)
+		if err != nil {
+			result.Errors = append(result.Errors, err.Error())
+			continue
+		}
+
+		var total int64
+		for _, f := range files {
+			total += f.size
+		}
+
+		// files are oldest first, so expiring and shrinking both walk from the front
+		for _, f := range files {
+			expired := policy.MaxAge > 0 && now.Sub(f.modTime) > policy.MaxAge
+			oversize := policy.MaxBytes > 0 && total > policy.MaxBytes
+			if !expired && !oversize {
+				break // everything after this is newer and we're under the size limit
+			}
+
+			if err := os.Remove(f.path); err != nil {
+				result.Errors = append(result.Errors, err.Error())
+				continue
+			}
+			total -= f.size
+			result.FilesRemoved++
+			result.BytesFreed += f.size
+		}
+	}
+
+	return result
+}
+
+// removeOrphans deletes course folders for courses that are no longer in the database
+func (s *Store) removeOrphans(artifactType string, knownCourses map[string]bool, result *CleanupResult) {
+	entries, err := os.ReadDir(filepath.Join(s.Root, artifactType))
+	if err != nil {
+		return // type folder doesn't exist yet
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() || knownCourses[entry.Name()] {
+			continue
+		}
+
+		dir := filepath.Join(s.Root, artifactType, entry.Name())
+		freed, files := dirSize(dir)
+		if err := os.RemoveAll(dir); err != nil {
+			result.Errors = append(result.Errors, err.Error())
+			continue
+		}
+		log.Printf("Removed orphaned %s artifacts for course %s", artifactType, entry.Name())
+		result.OrphansRemoved = append(result.OrphansRemoved, artifactType+"/"+entry.Name())
+		result.FilesRemoved += files
+		result.BytesFreed += freed
+	}
+}
+
+// dirSize adds up the files below a directory
+func dirSize(dir string) (int64, int) {
+	var size int64
+	var count int
+	filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			size += info.Size()
+			count++
+		}
+		return nil
+	})
+	return size, count
+}
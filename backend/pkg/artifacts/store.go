@@ -0,0 +1,125 @@
+package artifacts
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/pkg/util"
+	"github.com/google/uuid"
+)
+
+// artifact types - each one gets its own folder under the artifacts root
+const (
+	Thumbnails    = "thumbnails"
+	HLS           = "hls"
+	Transcripts   = "transcripts"
+	ExtractedText = "text"
+	Sprites       = "sprites"  // timeline preview sheets of videos
+	Pages         = "pages"    // PDF pages rendered for the reader
+	Chapters      = "chapters" // chapter markers read from audio and video files
+	Images        = "images"   // resized copies of image content
+)
+
+// Types lists every artifact type in a stable order
+var Types = []string{Thumbnails, HLS, Transcripts, ExtractedText, Sprites, Pages, Chapters, Images}
+
+// Policy limits how long and how much of one artifact type we keep. Zero means no limit.
+type Policy struct {
+	Type     string        `json:"type"`
+	MaxAge   time.Duration `json:"max_age"`   // delete files not modified for this long
+	MaxBytes int64         `json:"max_bytes"` // delete oldest files until the type fits
+}
+
+// default policies - HLS renditions are the big ones, text is tiny
+var defaultPolicies = map[string]Policy{
+	Thumbnails:    {Type: Thumbnails, MaxBytes: 1 << 30},
+	HLS:           {Type: HLS, MaxAge: 30 * 24 * time.Hour, MaxBytes: 50 << 30},
+	Transcripts:   {Type: Transcripts},
+	ExtractedText: {Type: ExtractedText},
+	Sprites:       {Type: Sprites, MaxBytes: 2 << 30},
+	Pages:         {Type: Pages, MaxAge: 30 * 24 * time.Hour, MaxBytes: 2 << 30},
+	Chapters:      {Type: Chapters},
+	Images:        {Type: Images, MaxAge: 30 * 24 * time.Hour, MaxBytes: 2 << 30},
+}
+
+// LoadPolicies reads ARTIFACT_<TYPE>_MAX_AGE and ARTIFACT_<TYPE>_MAX_MB from the environment
+func LoadPolicies() map[string]Policy {
+	policies := make(map[string]Policy, len(Types))
+	for _, artifactType := range Types {
+		policy := defaultPolicies[artifactType]
+		prefix := "ARTIFACT_" + strings.ToUpper(artifactType)
+		policy.MaxAge = util.GetEnvDuration(prefix+"_MAX_AGE", policy.MaxAge)
+		policy.MaxBytes = int64(util.GetEnvInt(prefix+"_MAX_MB", int(policy.MaxBytes>>20))) << 20
+		policies[artifactType] = policy
+	}
+	return policies
+}
+
+// Store knows the on-disk layout: <root>/<type>/<course id>/...
+type Store struct {
+	Root string
+}
+
+// NewStore creates a store rooted at the configured artifacts directory
+func NewStore() *Store {
+	return &Store{Root: util.GetArtifactsDirectory()}
+}
+
+// CourseDir returns the folder for one course's artifacts of a type
+func (s *Store) CourseDir(artifactType string, courseID uuid.UUID) string {
+	return filepath.Join(s.Root, artifactType, courseID.String())
+}
+
+// ItemDir returns the folder for one content item's artifacts of a type
+func (s *Store) ItemDir(artifactType string, courseID, itemID uuid.UUID) string {
+	return filepath.Join(s.CourseDir(artifactType, courseID), itemID.String())
+}
+
+// file is one artifact file found while walking the store
+type file struct {
+	path     string
+	courseID string
+	size     int64
+	modTime  time.Time
+}
+
+// listFiles returns every file of an artifact type, oldest first
+func (s *Store) listFiles(artifactType string) ([]file, error) {
+	typeDir := filepath.Join(s.Root, artifactType)
+	var files []file
+
+	err := filepath.WalkDir(typeDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == typeDir {
+				return filepath.SkipDir // nothing generated yet
+			}
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil // file vanished while walking
+		}
+
+		rel, _ := filepath.Rel(typeDir, path)
+		courseID, _, _ := strings.Cut(filepath.ToSlash(rel), "/")
+		files = append(files, file{path: path, courseID: courseID, size: info.Size(), modTime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error walking %s artifacts: %w", artifactType, err)
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.Before(files[j].modTime)
+	})
+	return files, nil
+}
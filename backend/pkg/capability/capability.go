@@ -0,0 +1,139 @@
+package capability
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// optional subsystems some content needs before it can be used
+const (
+	Transcoding = "transcoding" // video needs converting before browsers can play it
+	OCR         = "ocr"         // scanned PDF needs text recognition to be searchable
+)
+
+// tools maps each capability to the external binary that provides it
+var tools = map[string]string{
+	Transcoding: "ffmpeg",
+	OCR:         "tesseract",
+}
+
+// how much of a file we sniff - enough to find codec tags without reading whole videos
+const (
+	sniffSize    = 512 * 1024
+	maxPDFSniff  = 8 * 1024 * 1024
+	hevcNameHint = "x265|h265|h.265|hevc"
+)
+
+// markers that show up in mp4/mov sample descriptions or mkv codec ids for HEVC
+var hevcMarkers = [][]byte{[]byte("hvc1"), []byte("hev1"), []byte("V_MPEGH/ISO/HEVC")}
+
+// Tool returns the binary name needed for a capability
+func Tool(name string) string {
+	return tools[name]
+}
+
+// Available checks whether the tool behind a capability is installed on this machine
+func Available(name string) bool {
+	tool, ok := tools[name]
+	if !ok {
+		return false
+	}
+	_, err := exec.LookPath(tool)
+	return err == nil
+}
+
+// Detect works out which optional capabilities a content file needs
+// Best effort: it only sniffs file headers, so unreadable files just report nothing.
+func Detect(fullPath, contentType string) []string {
+	switch contentType {
+	case "video":
+		if needsTranscoding(fullPath) {
+			return []string{Transcoding}
+		}
+	case "pdf":
+		if looksScanned(fullPath) {
+			return []string{OCR}
+		}
+	}
+	return nil
+}
+
+// needsTranscoding spots HEVC video and containers browsers can't play natively
+func needsTranscoding(fullPath string) bool {
+	switch strings.ToLower(filepath.Ext(fullPath)) {
+	case ".avi", ".wmv":
+		return true
+	}
+
+	name := strings.ToLower(filepath.Base(fullPath))
+	for _, hint := range strings.Split(hevcNameHint, "|") {
+		if strings.Contains(name, hint) {
+			return true
+		}
+	}
+
+	head, tail, err := sniff(fullPath, sniffSize)
+	if err != nil {
+		return false
+	}
+	for _, marker := range hevcMarkers {
+		// moov atom can be at either end of an mp4
+		if bytes.Contains(head, marker) || bytes.Contains(tail, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// looksScanned guesses a PDF is scanned pages: images but no fonts at all
+func looksScanned(fullPath string) bool {
+	f, err := os.Open(fullPath)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, maxPDFSniff))
+	if err != nil {
+		return false
+	}
+
+	// compressed object streams hide the resource dictionaries, so we can't tell
+	if bytes.Contains(data, []byte("/ObjStm")) {
+		return false
+	}
+	return bytes.Contains(data, []byte("/Image")) && !bytes.Contains(data, []byte("/Font"))
+}
+
+// sniff reads up to n bytes from the start and end of a file
+func sniff(fullPath string, n int64) ([]byte, []byte, error) {
+	f, err := os.Open(fullPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	head := make([]byte, min(n, info.Size()))
+	if _, err := io.ReadFull(f, head); err != nil {
+		return nil, nil, err
+	}
+
+	if info.Size() <= n {
+		return head, nil, nil
+	}
+
+	tail := make([]byte, n)
+	if _, err := f.ReadAt(tail, info.Size()-n); err != nil && err != io.EOF {
+		return head, nil, err
+	}
+	return head, tail, nil
+}
@@ -0,0 +1,73 @@
+package chapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrToolMissing is returned when ffprobe isn't installed
+var ErrToolMissing = errors.New("ffprobe is not installed")
+
+// how long ffprobe may take to read the chapter list
+const probeTimeout = 30 * time.Second
+
+// Chapter is one chapter marker of an audio or video file, times in seconds
+type Chapter struct {
+	Title string  `json:"title"`
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+}
+
+// probeOutput is the part of `ffprobe -show_chapters` we use
+type probeOutput struct {
+	Chapters []struct {
+		StartTime string            `json:"start_time"`
+		EndTime   string            `json:"end_time"`
+		Tags      map[string]string `json:"tags"`
+	} `json:"chapters"`
+}
+
+// Extract reads the chapter markers embedded in a media file - m4b audiobooks, mp4/mkv
+// videos and anything else ffprobe understands. Files without chapters give an empty list.
+func Extract(ctx context.Context, path string) ([]Chapter, error) {
+	if _, err := exec.LookPath("ffprobe"); err != nil {
+		return nil, ErrToolMissing
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, "ffprobe", "-v", "error", "-print_format", "json", "-show_chapters", path).Output()
+	if err != nil {
+		return nil, fmt.Errorf("ffprobe failed: %w", err)
+	}
+
+	var probe probeOutput
+	if err := json.Unmarshal(output, &probe); err != nil {
+		return nil, fmt.Errorf("error reading ffprobe output: %w", err)
+	}
+
+	chapters := make([]Chapter, 0, len(probe.Chapters))
+	for i, c := range probe.Chapters {
+		start, _ := strconv.ParseFloat(c.StartTime, 64)
+		end, _ := strconv.ParseFloat(c.EndTime, 64)
+		chapters = append(chapters, Chapter{Title: title(c.Tags, i), Start: start, End: end})
+	}
+	return chapters, nil
+}
+
+// title picks the chapter's title tag, whatever case the muxer wrote it in
+func title(tags map[string]string, index int) string {
+	for key, value := range tags {
+		if strings.EqualFold(key, "title") && strings.TrimSpace(value) != "" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return "Chapter " + strconv.Itoa(index+1)
+}
@@ -0,0 +1,86 @@
+package events
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// progress milestones the course service publishes
+const (
+	ContentCompleted = "content.completed" // a profile finished a content item
+	ModuleCompleted  = "module.completed"  // ...and with it every item of a module
+	CourseCompleted  = "course.completed"  // ...and with it the whole course
+)
+
+// gamification milestones
+const (
+	LevelUp = "level.up" // a profile's XP took it to a new level
+)
+
+// Event is something that happened to a profile. IDs that don't apply are uuid.Nil.
+type Event struct {
+	Type          string    `json:"type"`
+	ProfileID     uuid.UUID `json:"profile_id"`
+	CourseID      uuid.UUID `json:"course_id"`
+	ModuleID      uuid.UUID `json:"module_id,omitempty"`
+	ContentItemID uuid.UUID `json:"content_item_id,omitempty"`
+	Title         string    `json:"title"`           // of the item, module or course the event is about, or the level
+	Level         int       `json:"level,omitempty"` // reached, for level.up
+	OccurredAt    time.Time `json:"occurred_at"`
+}
+
+// Handler reacts to an event. Errors are logged, there's nobody to return them to.
+type Handler func(ctx context.Context, event Event) error
+
+// Bus hands events to whoever subscribed to their type, so features like notifications
+// or webhooks can react to progress without the publisher knowing about them.
+// Handlers run in the background: a slow one never holds up the request that published.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+// NewBus creates a bus without subscribers
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[string][]Handler)}
+}
+
+// Subscribe calls handler for every event of the given type from now on
+func (b *Bus) Subscribe(eventType string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+// Publish passes an event to each of its type's subscribers in its own goroutine.
+// A nil bus drops events, for services built without one.
+func (b *Bus) Publish(event Event) {
+	if b == nil {
+		return
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	b.mu.RLock()
+	handlers := b.handlers[event.Type]
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("Event handler for %s panicked: %v", event.Type, r)
+				}
+			}()
+
+			if err := handler(context.Background(), event); err != nil {
+				log.Printf("Event handler for %s failed: %v", event.Type, err)
+			}
+		}()
+	}
+}
@@ -0,0 +1,103 @@
+package heartbeat
+
+import (
+	"time"
+
+	"github.com/NeroQue/course-management-backend/pkg/util"
+)
+
+// Policy decides how much watch time a player heartbeat is worth.
+// Players left open overnight keep sending heartbeats (or send one huge
+// delta when the tab wakes up) so we cap what gets credited between beats.
+type Policy struct {
+	MaxGap        time.Duration // most time credited between two heartbeats
+	IdleTimeout   time.Duration // gap after which the session counts as paused
+	MaxSessionLen time.Duration // continuous sessions longer than this get flagged
+	Tolerance     time.Duration // clock skew allowed before a delta looks fake
+}
+
+// Result is what a single heartbeat ended up being worth
+type Result struct {
+	Credited   time.Duration // watch time we actually count
+	Paused     bool          // gap was too long, nothing credited
+	Suspicious bool          // looks like an idle tab or a bogus client
+	Reason     string        // why it was paused/flagged
+	Session    time.Duration // continuous session length after this beat
+}
+
+// DefaultPolicy works for players that send a heartbeat every 15-30 seconds
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxGap:        2 * time.Minute,
+		IdleTimeout:   10 * time.Minute,
+		MaxSessionLen: 6 * time.Hour,
+		Tolerance:     5 * time.Second,
+	}
+}
+
+// LoadPolicy reads the policy from env vars, falling back to the defaults
+func LoadPolicy() Policy {
+	defaults := DefaultPolicy()
+	return Policy{
+		MaxGap:        util.GetEnvDuration("HEARTBEAT_MAX_GAP", defaults.MaxGap),
+		IdleTimeout:   util.GetEnvDuration("HEARTBEAT_IDLE_TIMEOUT", defaults.IdleTimeout),
+		MaxSessionLen: util.GetEnvDuration("HEARTBEAT_MAX_SESSION", defaults.MaxSessionLen),
+		Tolerance:     util.GetEnvDuration("HEARTBEAT_TOLERANCE", defaults.Tolerance),
+	}
+}
+
+// Evaluate works out the credit for a heartbeat that reports `reported` of
+// watch time. prev is the previous heartbeat for the same user/item (zero if
+// this is the first one) and session is how long the current continuous
+// session has been running so far.
+func (p Policy) Evaluate(prev, now time.Time, reported, session time.Duration) Result {
+	if reported < 0 {
+		reported = 0
+	}
+
+	// first heartbeat - we have nothing to compare against, so only trust it up to the cap
+	if prev.IsZero() {
+		credited := min(reported, p.MaxGap)
+		result := Result{Credited: credited, Session: credited}
+		if reported > p.MaxGap+p.Tolerance {
+			result.Suspicious = true
+			result.Reason = "first heartbeat reported more time than allowed between beats"
+		}
+		return result
+	}
+
+	gap := now.Sub(prev)
+	result := Result{}
+
+	// clock went backwards or the beats arrived out of order - nothing passed we can credit
+	if gap < 0 {
+		gap = 0
+		result.Suspicious = true
+		result.Reason = "heartbeat is older than the previous one"
+	}
+
+	// player was left alone - pause instead of crediting the whole gap
+	if gap > p.IdleTimeout {
+		result.Paused = true
+		result.Reason = "no heartbeat for " + gap.Round(time.Second).String() + ", session paused"
+		return result
+	}
+
+	credited := min(reported, gap, p.MaxGap)
+	result.Credited = credited
+	result.Session = session + credited
+
+	// client claims more watch time than actually passed on the wall clock
+	if reported > gap+p.Tolerance {
+		result.Suspicious = true
+		result.Reason = "reported " + reported.String() + " but only " + gap.Round(time.Second).String() + " passed"
+	}
+
+	// nobody watches for this long without a break - probably an idle tab
+	if result.Session > p.MaxSessionLen {
+		result.Suspicious = true
+		result.Reason = "continuous session longer than " + p.MaxSessionLen.String()
+	}
+
+	return result
+}
@@ -0,0 +1,133 @@
+package heartbeat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEvaluate(t *testing.T) {
+	p := DefaultPolicy()
+	now := time.Date(2024, 3, 1, 20, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		prev     time.Time
+		reported time.Duration
+		session  time.Duration
+
+		credited   time.Duration
+		paused     bool
+		suspicious bool
+		total      time.Duration // session after the beat
+	}{
+		{
+			name:     "first heartbeat credited in full",
+			reported: 30 * time.Second,
+			credited: 30 * time.Second,
+			total:    30 * time.Second,
+		},
+		{
+			name:       "first heartbeat capped at max gap",
+			reported:   time.Hour,
+			credited:   p.MaxGap,
+			suspicious: true,
+			total:      p.MaxGap,
+		},
+		{
+			name:     "first heartbeat within tolerance of the cap",
+			reported: p.MaxGap + p.Tolerance,
+			credited: p.MaxGap,
+			total:    p.MaxGap,
+		},
+		{
+			name:     "negative report counts as nothing",
+			reported: -time.Minute,
+			credited: 0,
+			total:    0,
+		},
+		{
+			name:     "regular beat",
+			prev:     now.Add(-30 * time.Second),
+			reported: 30 * time.Second,
+			session:  time.Hour,
+			credited: 30 * time.Second,
+			total:    time.Hour + 30*time.Second,
+		},
+		{
+			name:     "capped at the wall clock gap",
+			prev:     now.Add(-20 * time.Second),
+			reported: 22 * time.Second,
+			credited: 20 * time.Second,
+			total:    20 * time.Second,
+		},
+		{
+			name:       "more than the gap plus tolerance is suspicious",
+			prev:       now.Add(-20 * time.Second),
+			reported:   time.Minute,
+			credited:   20 * time.Second,
+			suspicious: true,
+			total:      20 * time.Second,
+		},
+		{
+			name:       "beat before the previous one credits nothing",
+			prev:       now.Add(time.Minute),
+			reported:   30 * time.Second,
+			session:    time.Hour,
+			credited:   0,
+			suspicious: true,
+			total:      time.Hour,
+		},
+		{
+			name:     "capped at max gap",
+			prev:     now.Add(-5 * time.Minute),
+			reported: 5 * time.Minute,
+			credited: p.MaxGap,
+			total:    p.MaxGap,
+		},
+		{
+			name:     "gap right at the idle timeout still counts",
+			prev:     now.Add(-p.IdleTimeout),
+			reported: time.Minute,
+			credited: time.Minute,
+			total:    time.Minute,
+		},
+		{
+			name:     "idle gap pauses the session",
+			prev:     now.Add(-p.IdleTimeout - time.Second),
+			reported: time.Minute,
+			session:  time.Hour,
+			paused:   true,
+		},
+		{
+			name:       "session over the max length is suspicious",
+			prev:       now.Add(-30 * time.Second),
+			reported:   30 * time.Second,
+			session:    p.MaxSessionLen,
+			credited:   30 * time.Second,
+			suspicious: true,
+			total:      p.MaxSessionLen + 30*time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := p.Evaluate(tt.prev, now, tt.reported, tt.session)
+
+			if got.Credited != tt.credited {
+				t.Errorf("credited %v, want %v", got.Credited, tt.credited)
+			}
+			if got.Paused != tt.paused {
+				t.Errorf("paused %v, want %v", got.Paused, tt.paused)
+			}
+			if got.Suspicious != tt.suspicious {
+				t.Errorf("suspicious %v, want %v (%s)", got.Suspicious, tt.suspicious, got.Reason)
+			}
+			if got.Session != tt.total {
+				t.Errorf("session %v, want %v", got.Session, tt.total)
+			}
+			if (got.Paused || got.Suspicious) && got.Reason == "" {
+				t.Error("paused or flagged without a reason")
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package jwt
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/pkg/secret"
+	"github.com/NeroQue/course-management-backend/pkg/util"
+)
+
+// Issuer goes in the iss claim of every token we sign
+const Issuer = "course-management"
+
+// MinKeyBytes is the shortest signing key accepted, the size of an HS256 hash
+const MinKeyBytes = 32
+
+// token errors. Anything wrong with a token other than its age is ErrInvalidToken.
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrExpiredToken = errors.New("token expired")
+)
+
+// the only header we issue or accept - no "none", no algorithm switching
+var header = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// Claims are what a session token says about its holder
+type Claims struct {
+	Subject   string `json:"sub"`           // the profile ID
+	SessionID string `json:"sid,omitempty"` // the server-side session, for revocation and impersonation
+	Issuer    string `json:"iss"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// Signer issues and checks HS256 JSON Web Tokens
+type Signer struct {
+	key []byte
+	TTL time.Duration // how long issued tokens are good for
+}
+
+// NewSigner creates a signer with the given key, which must be at least MinKeyBytes long
+func NewSigner(key []byte, ttl time.Duration) (*Signer, error) {
+	if len(key) < MinKeyBytes {
+		return nil, fmt.Errorf("signing key must be at least %d bytes, got %d", MinKeyBytes, len(key))
+	}
+	if ttl <= 0 {
+		return nil, fmt.Errorf("token lifetime must be positive, got %s", ttl)
+	}
+	return &Signer{key: key, TTL: ttl}, nil
+}
+
+// Sign issues a token for subject and session, good for the signer's TTL from now
+func (s *Signer) Sign(subject, sessionID string, now time.Time) (string, error) {
+	payload, err := json.Marshal(Claims{
+		Subject:   subject,
+		SessionID: sessionID,
+		Issuer:    Issuer,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(s.TTL).Unix(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("error encoding token claims: %w", err)
+	}
+
+	unsigned := header + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + s.signature(unsigned), nil
+}
+
+// Verify checks a token's header, signature, issuer and expiry, and returns its claims
+func (s *Signer) Verify(token string, now time.Time) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != header {
+		return nil, fmt.Errorf("%w: malformed", ErrInvalidToken)
+	}
+
+	// compare in constant time so the signature can't be worked out byte by byte
+	expected := s.signature(parts[0] + "." + parts[1])
+	if !hmac.Equal([]byte(parts[2]), []byte(expected)) {
+		return nil, fmt.Errorf("%w: bad signature", ErrInvalidToken)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed payload", ErrInvalidToken)
+	}
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("%w: malformed claims", ErrInvalidToken)
+	}
+
+	if claims.Issuer != Issuer {
+		return nil, fmt.Errorf("%w: issued by %q", ErrInvalidToken, claims.Issuer)
+	}
+	if claims.ExpiresAt == 0 || !now.Before(time.Unix(claims.ExpiresAt, 0)) {
+		return nil, ErrExpiredToken
+	}
+	return &claims, nil
+}
+
+// signature returns the base64url HMAC-SHA256 of the header and payload
+func (s *Signer) signature(unsigned string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// LoadSigner sets up the session token signer from JWT_SIGNING_KEY and SESSION_TTL (default
+// 24h, JWT_TTL is still read for older setups). Without a key a random one is used, which
+// logs everyone out when the server restarts and can't be shared between instances.
+func LoadSigner() (*Signer, error) {
+	key := []byte(os.Getenv("JWT_SIGNING_KEY"))
+	if len(key) == 0 {
+		token, err := secret.NewToken(MinKeyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("error generating signing key: %w", err)
+		}
+		key = []byte(token)
+		log.Printf("Warning: JWT_SIGNING_KEY is not set, sessions won't survive a restart")
+	}
+	ttl := util.GetEnvDuration("SESSION_TTL", util.GetEnvDuration("JWT_TTL", 24*time.Hour))
+	return NewSigner(key, ttl)
+}
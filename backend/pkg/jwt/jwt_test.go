@@ -0,0 +1,119 @@
+package jwt
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testKey = []byte("0123456789abcdef0123456789abcdef")
+
+// signClaims signs arbitrary claims with s, for tokens Sign would never issue
+func signClaims(t *testing.T, s *Signer, claims Claims) string {
+	t.Helper()
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsigned := header + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + s.signature(unsigned)
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	signer, err := NewSigner(testKey, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := NewSigner([]byte(strings.Repeat("x", MinKeyBytes)), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := signer.Sign("profile-1", "session-1", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.Split(token, ".")
+
+	forged, _ := json.Marshal(Claims{Subject: "admin", Issuer: Issuer, IssuedAt: now.Unix(), ExpiresAt: now.Add(time.Hour).Unix()})
+	noneHeader := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`))
+	otherToken, _ := other.Sign("profile-1", "session-1", now)
+
+	tests := []struct {
+		name  string
+		token string
+		at    time.Time
+		want  error // nil for a valid token
+	}{
+		{"valid", token, now, nil},
+		{"valid until just before expiry", token, now.Add(time.Hour - time.Second), nil},
+		{"expired at its expiry", token, now.Add(time.Hour), ErrExpiredToken},
+		{"expired later", token, now.Add(48 * time.Hour), ErrExpiredToken},
+		{"swapped payload", parts[0] + "." + base64.RawURLEncoding.EncodeToString(forged) + "." + parts[2], now, ErrInvalidToken},
+		{"flipped signature", parts[0] + "." + parts[1] + "." + flip(parts[2]), now, ErrInvalidToken},
+		{"no signature", parts[0] + "." + parts[1] + ".", now, ErrInvalidToken},
+		{"alg none", noneHeader + "." + parts[1] + ".", now, ErrInvalidToken},
+		{"other header, same signature", noneHeader + "." + parts[1] + "." + parts[2], now, ErrInvalidToken},
+		{"signed with another key", otherToken, now, ErrInvalidToken},
+		{"too few parts", parts[0] + "." + parts[1], now, ErrInvalidToken},
+		{"too many parts", token + ".x", now, ErrInvalidToken},
+		{"empty", "", now, ErrInvalidToken},
+		{"other issuer", signClaims(t, signer, Claims{Subject: "profile-1", Issuer: "someone-else", ExpiresAt: now.Add(time.Hour).Unix()}), now, ErrInvalidToken},
+		{"no issuer", signClaims(t, signer, Claims{Subject: "profile-1", ExpiresAt: now.Add(time.Hour).Unix()}), now, ErrInvalidToken},
+		{"no expiry", signClaims(t, signer, Claims{Subject: "profile-1", Issuer: Issuer}), now, ErrExpiredToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := signer.Verify(tt.token, tt.at)
+			if tt.want != nil {
+				if !errors.Is(err, tt.want) {
+					t.Fatalf("got error %v, want %v", err, tt.want)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("valid token refused: %v", err)
+			}
+			if claims.Subject != "profile-1" || claims.SessionID != "session-1" || claims.Issuer != Issuer {
+				t.Errorf("unexpected claims %+v", claims)
+			}
+		})
+	}
+}
+
+// flip changes the first character of a base64url string to another valid one
+func flip(s string) string {
+	if s[0] == 'A' {
+		return "B" + s[1:]
+	}
+	return "A" + s[1:]
+}
+
+func TestNewSigner(t *testing.T) {
+	tests := []struct {
+		name string
+		key  []byte
+		ttl  time.Duration
+		ok   bool
+	}{
+		{"valid", testKey, time.Hour, true},
+		{"short key", testKey[:MinKeyBytes-1], time.Hour, false},
+		{"no key", nil, time.Hour, false},
+		{"zero ttl", testKey, 0, false},
+		{"negative ttl", testKey, -time.Minute, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSigner(tt.key, tt.ttl)
+			if (err == nil) != tt.ok {
+				t.Errorf("got error %v, want ok %v", err, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package levels
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Curve is the total XP needed for each level past the first, in ascending order.
+// Everyone starts at level 1 with 0 XP. Past the last threshold levels keep coming at
+// the curve's last step, so there's no level cap.
+type Curve []int
+
+// default curve - early levels come quickly, later ones take a course or two each
+var defaultCurve = Curve{100, 250, 450, 700, 1000, 1400, 1900, 2500, 3200, 4000}
+
+// LoadCurve reads LEVEL_THRESHOLDS, a comma separated list of ascending XP totals like
+// "100,250,450". A missing or invalid list means the default curve.
+func LoadCurve() Curve {
+	value := os.Getenv("LEVEL_THRESHOLDS")
+	if value == "" {
+		return defaultCurve
+	}
+
+	curve := make(Curve, 0, strings.Count(value, ",")+1)
+	for _, field := range strings.Split(value, ",") {
+		threshold, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil {
+			log.Printf("Warning: invalid LEVEL_THRESHOLDS (%q), using the default curve", value)
+			return defaultCurve
+		}
+		curve = append(curve, threshold)
+	}
+	if err := curve.Validate(); err != nil {
+		log.Printf("Warning: invalid LEVEL_THRESHOLDS (%q), using the default curve: %v", value, err)
+		return defaultCurve
+	}
+	return curve
+}
+
+// Validate checks the thresholds are positive and ascending
+func (c Curve) Validate() error {
+	if len(c) == 0 {
+		return errors.New("no level thresholds")
+	}
+	for i, threshold := range c {
+		if threshold <= 0 {
+			return fmt.Errorf("level threshold %d must be positive", threshold)
+		}
+		if i > 0 && threshold <= c[i-1] {
+			return fmt.Errorf("level threshold %d must be above %d", threshold, c[i-1])
+		}
+	}
+	return nil
+}
+
+// Level returns the level a profile with xp experience is at and the XP it still needs
+// for the next one
+func (c Curve) Level(xp int) (level, toNext int) {
+	if len(c) == 0 {
+		c = defaultCurve
+	}
+
+	level = 1
+	for _, threshold := range c {
+		if xp < threshold {
+			return level, threshold - xp
+		}
+		level++
+	}
+
+	last := c[len(c)-1]
+	step := last
+	if len(c) > 1 {
+		step = last - c[len(c)-2]
+	}
+	beyond := (xp - last) / step
+	return level + beyond, last + (beyond+1)*step - xp
+}
@@ -0,0 +1,116 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net"
+	"net/smtp"
+	"os"
+	"strings"
+	"time"
+)
+
+// ErrInvalidMessage is returned for a message that can't be sent as it is, like one
+// whose address or subject would break out of its header
+var ErrInvalidMessage = errors.New("invalid mail message")
+
+// Message is a plain text email to one recipient
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers emails
+type Mailer interface {
+	Send(ctx context.Context, message Message) error
+}
+
+// FromEnv sets up SMTP delivery from SMTP_HOST, SMTP_PORT (default 587), SMTP_USERNAME,
+// SMTP_PASSWORD and SMTP_FROM. Without SMTP_HOST emails only go to the log, which is
+// fine for trying things out but leaks whatever is in them to whoever reads it.
+func FromEnv() Mailer {
+	host := os.Getenv("SMTP_HOST")
+	if host == "" {
+		log.Printf("Warning: SMTP_HOST is not set, emails are written to the log instead of sent")
+		return LogMailer{}
+	}
+
+	port := os.Getenv("SMTP_PORT")
+	if port == "" {
+		port = "587"
+	}
+	from := os.Getenv("SMTP_FROM")
+	if from == "" {
+		from = "course-management@" + host
+	}
+	return &SMTP{
+		Addr:     net.JoinHostPort(host, port),
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     from,
+	}
+}
+
+// SMTP sends through a mail server, upgrading to TLS when the server offers it
+type SMTP struct {
+	Addr     string // host:port
+	Username string // no authentication when empty
+	Password string
+	From     string
+}
+
+// Send delivers the message. net/smtp has no deadlines, so ctx only stops a send that
+// hasn't started yet.
+func (s *SMTP) Send(ctx context.Context, message Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	data, err := compose(s.From, message, time.Now())
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if s.Username != "" {
+		host, _, _ := net.SplitHostPort(s.Addr)
+		auth = smtp.PlainAuth("", s.Username, s.Password, host)
+	}
+	if err := smtp.SendMail(s.Addr, auth, s.From, []string{message.To}, data); err != nil {
+		return fmt.Errorf("error sending mail to %s: %w", message.To, err)
+	}
+	return nil
+}
+
+// LogMailer writes emails to the log, for instances without a mail server
+type LogMailer struct{}
+
+// Send logs the message
+func (LogMailer) Send(ctx context.Context, message Message) error {
+	log.Printf("Mail to %s: %s\n%s", message.To, message.Subject, message.Body)
+	return nil
+}
+
+// compose builds the raw message with its headers
+func compose(from string, message Message, now time.Time) ([]byte, error) {
+	for _, value := range []string{from, message.To, message.Subject} {
+		if strings.ContainsAny(value, "\r\n") {
+			return nil, fmt.Errorf("%w: line break in a header", ErrInvalidMessage)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + message.To + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", message.Subject) + "\r\n")
+	b.WriteString("Date: " + now.Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(message.Body, "\r\n", "\n"), "\n", "\r\n"))
+	return []byte(b.String()), nil
+}
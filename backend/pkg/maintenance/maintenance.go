@@ -0,0 +1,126 @@
+package maintenance
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/pkg/util"
+)
+
+// reasons maintenance can be on for, besides whatever callers of Enter pass
+const (
+	ReasonManual = "switched on by an admin"
+	ReasonFile   = "maintenance file present" // e.g. an external migration runner
+)
+
+// State is what the API reports about maintenance mode
+type State struct {
+	Active     bool       `json:"active"`
+	Reasons    []string   `json:"reasons,omitempty"` // everything currently holding it on
+	Since      *time.Time `json:"since,omitempty"`
+	RetryAfter int        `json:"retry_after_seconds"` // what 503 responses tell clients
+}
+
+// hold is one reason maintenance is on
+type hold struct {
+	reason string
+	since  time.Time
+}
+
+// global like the task manager - every part of the app sees the same switch.
+// Several things can hold maintenance on at once (an admin, a restore, a migration);
+// it only lifts once all of them let go.
+var mode = struct {
+	sync.Mutex
+	holds  map[uint64]hold
+	next   uint64
+	manual uint64 // hold ID of the admin switch, 0 when off
+}{holds: make(map[uint64]hold)}
+
+// Enter turns maintenance on until the returned release is called.
+// Release is safe to call more than once.
+func Enter(reason string) (release func()) {
+	mode.Lock()
+	mode.next++
+	id := mode.next
+	mode.holds[id] = hold{reason: reason, since: time.Now()}
+	mode.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			mode.Lock()
+			delete(mode.holds, id)
+			mode.Unlock()
+		})
+	}
+}
+
+// SetManual flips the admin switch. Automatic holds stay in place either way.
+func SetManual(enabled bool, reason string) {
+	mode.Lock()
+	defer mode.Unlock()
+
+	if mode.manual != 0 {
+		delete(mode.holds, mode.manual)
+		mode.manual = 0
+	}
+	if !enabled {
+		return
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		reason = ReasonManual
+	}
+	mode.next++
+	mode.manual = mode.next
+	mode.holds[mode.manual] = hold{reason: reason, since: time.Now()}
+}
+
+// Current reports whether maintenance is on and why. MAINTENANCE_FILE, when set,
+// names a file whose presence also turns it on - tools outside the server (goose
+// migrations, database dumps) touch it before they start and remove it when done.
+func Current() State {
+	state := State{RetryAfter: int(RetryAfter().Seconds())}
+
+	mode.Lock()
+	ids := make([]uint64, 0, len(mode.holds))
+	for id := range mode.holds {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(a, b int) bool { return ids[a] < ids[b] })
+	for _, id := range ids {
+		h := mode.holds[id]
+		state.Reasons = append(state.Reasons, h.reason)
+		if state.Since == nil || h.since.Before(*state.Since) {
+			since := h.since
+			state.Since = &since
+		}
+	}
+	mode.Unlock()
+
+	if path := os.Getenv("MAINTENANCE_FILE"); path != "" {
+		if info, err := os.Stat(path); err == nil {
+			reason := ReasonFile
+			if content, err := os.ReadFile(path); err == nil && strings.TrimSpace(string(content)) != "" {
+				reason = strings.TrimSpace(string(content))
+			}
+			state.Reasons = append(state.Reasons, reason)
+			if modTime := info.ModTime(); state.Since == nil || modTime.Before(*state.Since) {
+				state.Since = &modTime
+			}
+		}
+	}
+
+	state.Active = len(state.Reasons) > 0
+	return state
+}
+
+// RetryAfter is how long clients are told to wait, MAINTENANCE_RETRY_AFTER (default 30s)
+func RetryAfter() time.Duration {
+	return util.GetEnvDuration("MAINTENANCE_RETRY_AFTER", 30*time.Second)
+}
@@ -0,0 +1,353 @@
+package markdown
+
+import (
+	"html"
+	"net/url"
+	"strings"
+)
+
+// inline renders emphasis, code spans, links, images and line breaks. Anything that
+// isn't markdown syntax is escaped, including raw HTML tags.
+func inline(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && s[i+1] == '\n':
+			b.WriteString("<br>\n")
+			i += 2
+		case c == '\\' && i+1 < len(s) && isPunct(s[i+1]):
+			b.WriteString(html.EscapeString(s[i+1 : i+2]))
+			i += 2
+		case c == ' ' && hardBreak(s, i):
+			b.WriteString("<br>\n")
+			i += strings.IndexByte(s[i:], '\n') + 1
+		case c == '`':
+			i = codeSpan(&b, s, i)
+		case c == '!' && i+1 < len(s) && s[i+1] == '[':
+			if next, ok := link(&b, s, i+1, true); ok {
+				i = next
+			} else {
+				b.WriteString("!")
+				i++
+			}
+		case c == '[':
+			if next, ok := link(&b, s, i, false); ok {
+				i = next
+			} else {
+				b.WriteString("[")
+				i++
+			}
+		case c == '<':
+			if next, ok := autolink(&b, s, i); ok {
+				i = next
+			} else {
+				b.WriteString("&lt;")
+				i++
+			}
+		case c == '*' || c == '_' || c == '~':
+			i = emphasis(&b, s, i)
+		default:
+			b.WriteString(html.EscapeString(s[i : i+1]))
+			i++
+		}
+	}
+	return b.String()
+}
+
+// hardBreak reports whether the spaces at i are two or more right before a line break
+func hardBreak(s string, i int) bool {
+	j := i
+	for j < len(s) && s[j] == ' ' {
+		j++
+	}
+	return j-i >= 2 && j < len(s) && s[j] == '\n'
+}
+
+// codeSpan renders `code`, matching backtick runs of the same length
+func codeSpan(b *strings.Builder, s string, i int) int {
+	n := runLength(s, i, '`')
+	for j := i + n; j < len(s); {
+		if s[j] != '`' {
+			j++
+			continue
+		}
+		m := runLength(s, j, '`')
+		if m == n {
+			code := strings.ReplaceAll(s[i+n:j], "\n", " ")
+			if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+				code = code[1 : len(code)-1]
+			}
+			b.WriteString("<code>" + html.EscapeString(code) + "</code>")
+			return j + n
+		}
+		j += m
+	}
+
+	// no closing run - the backticks are just text
+	b.WriteString(s[i : i+n])
+	return i + n
+}
+
+// link renders [text](url "title") or, for images, ![alt](url "title").
+// Returns false if s[i:] isn't a complete link so the caller can treat it as text.
+func link(b *strings.Builder, s string, i int, image bool) (int, bool) {
+	end := closingBracket(s, i)
+	if end < 0 || end+1 >= len(s) || s[end+1] != '(' {
+		return i, false
+	}
+	text := s[i+1 : end]
+
+	dest, title, next, ok := linkTarget(s, end+2)
+	if !ok {
+		return i, false
+	}
+
+	href := safeURL(dest)
+	if image {
+		alt := plainText(inline(text))
+		if href == "" {
+			b.WriteString(html.EscapeString(alt))
+			return next, true
+		}
+		b.WriteString("<img src=\"" + html.EscapeString(href) + "\" alt=\"" + html.EscapeString(alt) + "\"")
+		if title != "" {
+			b.WriteString(" title=\"" + html.EscapeString(title) + "\"")
+		}
+		b.WriteString(" loading=\"lazy\">")
+		return next, true
+	}
+
+	content := inline(text)
+	if href == "" {
+		b.WriteString(content)
+		return next, true
+	}
+	writeAnchor(b, href, title, content)
+	return next, true
+}
+
+// closingBracket finds the ] matching the [ at i, skipping nested brackets and code spans
+func closingBracket(s string, i int) int {
+	depth := 0
+	for j := i; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '`':
+			n := runLength(s, j, '`')
+			if k := strings.Index(s[j+n:], s[j:j+n]); k >= 0 {
+				j += n + k + n - 1
+			} else {
+				j += n - 1
+			}
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return j
+			}
+		}
+	}
+	return -1
+}
+
+// linkTarget parses `url "title")` starting right after the opening parenthesis
+func linkTarget(s string, i int) (dest, title string, next int, ok bool) {
+	i = skipSpaces(s, i)
+
+	if i < len(s) && s[i] == '<' {
+		end := strings.IndexAny(s[i:], ">\n")
+		if end < 0 || s[i+end] != '>' {
+			return "", "", 0, false
+		}
+		dest = s[i+1 : i+end]
+		i += end + 1
+	} else {
+		start, depth := i, 0
+		for ; i < len(s) && s[i] > ' '; i++ {
+			if s[i] == '\\' && i+1 < len(s) {
+				i++
+			} else if s[i] == '(' {
+				depth++
+			} else if s[i] == ')' {
+				if depth == 0 {
+					break
+				}
+				depth--
+			}
+		}
+		dest = s[start:i]
+	}
+
+	i = skipSpaces(s, i)
+	if i < len(s) && (s[i] == '"' || s[i] == '\'') {
+		quote := s[i]
+		end := strings.IndexByte(s[i+1:], quote)
+		if end < 0 {
+			return "", "", 0, false
+		}
+		title = s[i+1 : i+1+end]
+		i = skipSpaces(s, i+end+2)
+	}
+
+	if i >= len(s) || s[i] != ')' {
+		return "", "", 0, false
+	}
+	return unescape(dest), unescape(title), i + 1, true
+}
+
+// autolink renders <https://...> and <someone@example.com>
+func autolink(b *strings.Builder, s string, i int) (int, bool) {
+	end := strings.IndexAny(s[i+1:], "<> \n")
+	if end < 0 || s[i+1+end] != '>' {
+		return i, false
+	}
+	target := s[i+1 : i+1+end]
+
+	href := target
+	if !strings.Contains(target, ":") && strings.Contains(target, "@") {
+		href = "mailto:" + target
+	}
+	if !strings.Contains(href, ":") || safeURL(href) == "" {
+		return i, false
+	}
+
+	writeAnchor(b, href, "", html.EscapeString(target))
+	return i + end + 2, true
+}
+
+// writeAnchor writes a link, opening external ones in a new tab
+func writeAnchor(b *strings.Builder, href, title, content string) {
+	b.WriteString("<a href=\"" + html.EscapeString(href) + "\"")
+	if title != "" {
+		b.WriteString(" title=\"" + html.EscapeString(title) + "\"")
+	}
+	if strings.HasPrefix(href, "http://") || strings.HasPrefix(href, "https://") {
+		b.WriteString(" target=\"_blank\" rel=\"noopener noreferrer\"")
+	}
+	b.WriteString(">" + content + "</a>")
+}
+
+// safeURL returns the URL if it's relative or uses an allowed scheme, empty otherwise -
+// javascript: and data: links don't make it into the page
+func safeURL(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if strings.IndexFunc(raw, func(r rune) bool { return r < ' ' || r == 0x7f }) >= 0 {
+		return ""
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "", "http", "https", "mailto":
+		return raw
+	default:
+		return ""
+	}
+}
+
+// emphasis renders *em*, **strong**, ***both*** (or with underscores) and ~~strikethrough~~.
+// Delimiters without a matching closer are written as text.
+func emphasis(b *strings.Builder, s string, i int) int {
+	d := s[i]
+	n := runLength(s, i, d)
+
+	// underscores inside words (snake_case) are not emphasis
+	opens := n <= 3 && i+n < len(s) && s[i+n] != ' ' && s[i+n] != '\n' &&
+		(d != '_' || i == 0 || !isWordChar(s[i-1])) &&
+		(d != '~' || n == 2)
+
+	if opens {
+		if end := closingDelimiter(s, i+n, d, n); end > 0 {
+			content := inline(s[i+n : end])
+			switch {
+			case d == '~':
+				b.WriteString("<del>" + content + "</del>")
+			case n == 3:
+				b.WriteString("<strong><em>" + content + "</em></strong>")
+			case n == 2:
+				b.WriteString("<strong>" + content + "</strong>")
+			default:
+				b.WriteString("<em>" + content + "</em>")
+			}
+			return end + n
+		}
+	}
+
+	b.WriteString(s[i : i+n])
+	return i + n
+}
+
+// closingDelimiter finds a run of exactly n d's that can close emphasis opened before from
+func closingDelimiter(s string, from int, d byte, n int) int {
+	for j := from; j < len(s); j++ {
+		switch {
+		case s[j] == '\\':
+			j++
+		case s[j] == '`':
+			// emphasis markers inside code spans don't count
+			m := runLength(s, j, '`')
+			if k := strings.Index(s[j+m:], s[j:j+m]); k >= 0 {
+				j += m + k + m - 1
+			} else {
+				j += m - 1
+			}
+		case s[j] == d:
+			m := runLength(s, j, d)
+			closes := m == n && j > from && s[j-1] != ' ' && s[j-1] != '\n' &&
+				(d != '_' || j+m >= len(s) || !isWordChar(s[j+m]))
+			if closes {
+				return j
+			}
+			j += m - 1
+		}
+	}
+	return -1
+}
+
+// runLength counts how many c's start at i
+func runLength(s string, i int, c byte) int {
+	n := 0
+	for i+n < len(s) && s[i+n] == c {
+		n++
+	}
+	return n
+}
+
+// skipSpaces moves past spaces and line breaks
+func skipSpaces(s string, i int) int {
+	for i < len(s) && (s[i] == ' ' || s[i] == '\n') {
+		i++
+	}
+	return i
+}
+
+// unescape drops the backslash from escaped punctuation
+func unescape(s string) string {
+	if !strings.Contains(s, "\\") {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && isPunct(s[i+1]) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// isPunct reports whether c is ASCII punctuation, which a backslash can escape
+func isPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
+
+// isWordChar reports whether c is part of a word, for underscore rules.
+// Bytes of multi-byte characters count as word characters.
+func isWordChar(c byte) bool {
+	return c >= 0x80 || c == '_' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,520 @@
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Heading is a section heading, with the anchor ID it got in the HTML
+type Heading struct {
+	Level int
+	Text  string
+	ID    string
+}
+
+// CodeBlock describes a code block so the frontend can highlight it.
+// Index matches the data-code-block attribute on its <code> element.
+type CodeBlock struct {
+	Index    int
+	Language string // from the fence info string, empty if none was given
+	Lines    int
+}
+
+// Document is rendered HTML plus what the frontend needs for a table of contents and highlighting
+type Document struct {
+	HTML       string
+	Headings   []Heading
+	CodeBlocks []CodeBlock
+}
+
+// Render converts markdown to HTML. Raw HTML in the source is escaped, never passed
+// through, and links only keep http, https, mailto and relative targets - so the
+// output is safe to put in a page as it is.
+func Render(src string) Document {
+	r := &renderer{ids: map[string]int{}}
+	var b strings.Builder
+	r.blocks(&b, splitLines(src), false)
+	return Document{HTML: b.String(), Headings: r.headings, CodeBlocks: r.code}
+}
+
+// RenderPlain turns plain text into escaped paragraphs, keeping its line breaks
+func RenderPlain(src string) Document {
+	var b strings.Builder
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			b.WriteString("<p>" + strings.Join(paragraph, "<br>\n") + "</p>\n")
+			paragraph = nil
+		}
+	}
+
+	for _, line := range splitLines(src) {
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+		paragraph = append(paragraph, html.EscapeString(strings.TrimRight(line, " ")))
+	}
+	flush()
+
+	return Document{HTML: b.String()}
+}
+
+// renderer collects headings and code blocks while it writes HTML
+type renderer struct {
+	headings []Heading
+	code     []CodeBlock
+	ids      map[string]int // heading anchors handed out so far
+}
+
+var (
+	atxHeading     = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	thematicBreak  = regexp.MustCompile(`^ {0,3}(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	fenceOpen      = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})[ \t]*([^`]*?)[ \t]*$")
+	bulletMarker   = regexp.MustCompile(`^( {0,3})([-*+])( +|$)`)
+	orderedMarker  = regexp.MustCompile(`^( {0,3})(\d{1,9})([.)])( +|$)`)
+	tableDelimiter = regexp.MustCompile(`^ {0,3}\|?[ \t]*:?-+:?[ \t]*(?:\|[ \t]*:?-+:?[ \t]*)*\|?[ \t]*$`)
+	taskMarker     = regexp.MustCompile(`^\[([ xX])\][ \t]+`)
+	safeLanguage   = regexp.MustCompile(`^[A-Za-z0-9_+#.-]+$`)
+)
+
+// blocks renders a run of lines. Tight list items leave their paragraphs unwrapped.
+func (r *renderer) blocks(b *strings.Builder, lines []string, tight bool) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			i++
+		case leadingSpaces(line) >= 4:
+			i = r.indentedCode(b, lines, i)
+		case fenceOpen.MatchString(line):
+			i = r.fencedCode(b, lines, i)
+		case atxHeading.MatchString(line):
+			m := atxHeading.FindStringSubmatch(line)
+			r.heading(b, len(m[1]), m[2])
+			i++
+		case thematicBreak.MatchString(line):
+			b.WriteString("<hr>\n")
+			i++
+		case isBlockquote(line):
+			i = r.blockquote(b, lines, i)
+		case listMarker(line) != nil:
+			i = r.list(b, lines, i)
+		case i+1 < len(lines) && strings.Contains(line, "|") && tableDelimiter.MatchString(lines[i+1]):
+			i = r.table(b, lines, i)
+		default:
+			i = r.paragraph(b, lines, i, tight)
+		}
+	}
+}
+
+// paragraph collects lines until something else starts, turning into a heading if underlined
+func (r *renderer) paragraph(b *strings.Builder, lines []string, i int, tight bool) int {
+	var text []string
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			break
+		}
+
+		// setext headings: the paragraph so far, underlined with === or ---
+		if len(text) > 0 && leadingSpaces(line) < 4 {
+			if strings.Trim(trimmed, "=") == "" {
+				r.heading(b, 1, strings.Join(text, "\n"))
+				return i + 1
+			}
+			if strings.Trim(trimmed, "-") == "" {
+				r.heading(b, 2, strings.Join(text, "\n"))
+				return i + 1
+			}
+		}
+
+		if len(text) > 0 && startsBlock(line) {
+			break
+		}
+		text = append(text, strings.TrimLeft(line, " "))
+	}
+
+	content := inline(strings.Join(text, "\n"))
+	if tight {
+		b.WriteString(content + "\n")
+	} else {
+		b.WriteString("<p>" + content + "</p>\n")
+	}
+	return i
+}
+
+// startsBlock reports whether a line interrupts a paragraph
+func startsBlock(line string) bool {
+	if fenceOpen.MatchString(line) || atxHeading.MatchString(line) || thematicBreak.MatchString(line) || isBlockquote(line) {
+		return true
+	}
+	marker := listMarker(line)
+	// only lists starting at 1 interrupt a paragraph, so "in 1984. we..." stays text
+	return marker != nil && !marker.empty && (!marker.ordered || marker.start == 1)
+}
+
+// heading writes a heading with an anchor ID and remembers it for the table of contents
+func (r *renderer) heading(b *strings.Builder, level int, text string) {
+	content := inline(strings.TrimSpace(text))
+	plain := plainText(content)
+	id := r.anchor(plain)
+	r.headings = append(r.headings, Heading{Level: level, Text: plain, ID: id})
+
+	tag := "h" + strconv.Itoa(level)
+	b.WriteString("<" + tag + " id=\"" + html.EscapeString(id) + "\">" + content + "</" + tag + ">\n")
+}
+
+// anchor turns heading text into a unique ID, e.g. "Getting started" becomes "getting-started"
+func (r *renderer) anchor(text string) string {
+	var slug strings.Builder
+	dash := false
+	for _, c := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(c) || unicode.IsDigit(c):
+			slug.WriteRune(c)
+			dash = false
+		case (c == ' ' || c == '-' || c == '_') && slug.Len() > 0 && !dash:
+			slug.WriteByte('-')
+			dash = true
+		}
+	}
+
+	id := strings.TrimSuffix(slug.String(), "-")
+	if id == "" {
+		id = "section"
+	}
+
+	seen := r.ids[id]
+	r.ids[id] = seen + 1
+	if seen > 0 {
+		id += "-" + strconv.Itoa(seen)
+	}
+	return id
+}
+
+// fencedCode renders a ``` or ~~~ block, up to the matching fence or the end of the text
+func (r *renderer) fencedCode(b *strings.Builder, lines []string, i int) int {
+	m := fenceOpen.FindStringSubmatch(lines[i])
+	indent, fence := len(m[1]), m[2]
+	language, _, _ := strings.Cut(m[3], " ")
+
+	var content []string
+	for i++; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" && leadingSpaces(lines[i]) < 4 {
+			i++
+			break
+		}
+		content = append(content, trimIndent(lines[i], indent))
+	}
+
+	r.codeBlock(b, language, content)
+	return i
+}
+
+// indentedCode renders lines indented by four spaces or more
+func (r *renderer) indentedCode(b *strings.Builder, lines []string, i int) int {
+	var content []string
+	for ; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) != "" && leadingSpaces(lines[i]) < 4 {
+			break
+		}
+		content = append(content, trimIndent(lines[i], 4))
+	}
+	for len(content) > 0 && strings.TrimSpace(content[len(content)-1]) == "" {
+		content = content[:len(content)-1]
+		i--
+	}
+
+	r.codeBlock(b, "", content)
+	return i
+}
+
+// codeBlock writes escaped code and records its language for highlighting
+func (r *renderer) codeBlock(b *strings.Builder, language string, content []string) {
+	if !safeLanguage.MatchString(language) {
+		language = ""
+	}
+	index := len(r.code)
+	r.code = append(r.code, CodeBlock{Index: index, Language: language, Lines: len(content)})
+
+	b.WriteString("<pre><code")
+	if language != "" {
+		b.WriteString(" class=\"language-" + html.EscapeString(language) + "\"")
+	}
+	b.WriteString(" data-code-block=\"" + strconv.Itoa(index) + "\">")
+	for _, line := range content {
+		b.WriteString(html.EscapeString(line) + "\n")
+	}
+	b.WriteString("</code></pre>\n")
+}
+
+// isBlockquote reports whether a line starts with > (after at most three spaces)
+func isBlockquote(line string) bool {
+	return leadingSpaces(line) < 4 && strings.HasPrefix(strings.TrimLeft(line, " "), ">")
+}
+
+// blockquote strips the > markers and renders what's inside, lazy continuation lines included
+func (r *renderer) blockquote(b *strings.Builder, lines []string, i int) int {
+	var inner []string
+	for ; i < len(lines) && strings.TrimSpace(lines[i]) != ""; i++ {
+		line := lines[i]
+		if isBlockquote(line) {
+			line = strings.TrimPrefix(strings.TrimLeft(line, " "), ">")
+			line = strings.TrimPrefix(line, " ")
+		} else if startsBlock(line) {
+			break
+		}
+		inner = append(inner, line)
+	}
+
+	b.WriteString("<blockquote>\n")
+	r.blocks(b, inner, false)
+	b.WriteString("</blockquote>\n")
+	return i
+}
+
+// marker is a parsed list item marker
+type marker struct {
+	ordered bool
+	start   int // number of an ordered item
+	delim   string
+	indent  int  // where the content of the item starts
+	empty   bool // nothing follows the marker on its line
+}
+
+// listMarker parses "- ", "* ", "+ ", "1. " or "1) " at the start of a line
+func listMarker(line string) *marker {
+	if m := bulletMarker.FindStringSubmatch(line); m != nil && !thematicBreak.MatchString(line) {
+		return newMarker(line, false, 0, m[2], len(m[1])+len(m[2]), m[3])
+	}
+	if m := orderedMarker.FindStringSubmatch(line); m != nil {
+		start, _ := strconv.Atoi(m[2])
+		return newMarker(line, true, start, m[3], len(m[1])+len(m[2])+len(m[3]), m[4])
+	}
+	return nil
+}
+
+// newMarker works out where item content starts - one space after the marker if it's
+// followed by an indented code block, otherwise after all the spaces
+func newMarker(line string, ordered bool, start int, delim string, width int, spaces string) *marker {
+	mk := &marker{ordered: ordered, start: start, delim: delim, indent: width + len(spaces)}
+	if len(spaces) == 0 || len(spaces) > 4 {
+		mk.indent = width + 1
+	}
+	mk.empty = strings.TrimSpace(line[min(width, len(line)):]) == ""
+	return mk
+}
+
+// list renders a run of items of the same kind. Items separated by blank lines are loose
+// and keep their paragraphs, tight items don't.
+func (r *renderer) list(b *strings.Builder, lines []string, i int) int {
+	first := listMarker(lines[i])
+	var items [][]string
+	loose := false
+
+	for i < len(lines) {
+		mk := listMarker(lines[i])
+		if mk == nil || mk.ordered != first.ordered || mk.delim != first.delim {
+			break
+		}
+
+		item := []string{lines[i][min(mk.indent, len(lines[i])):]}
+		blank := false
+		for i++; i < len(lines); i++ {
+			line := lines[i]
+			if strings.TrimSpace(line) == "" {
+				blank = true
+				item = append(item, "")
+				continue
+			}
+			if leadingSpaces(line) >= mk.indent {
+				if blank {
+					loose = true
+				}
+				blank = false
+				item = append(item, trimIndent(line, mk.indent))
+				continue
+			}
+			// an unindented line carries on the item's paragraph unless something else starts
+			if !blank && !startsBlock(line) && listMarker(line) == nil {
+				item = append(item, strings.TrimLeft(line, " "))
+				continue
+			}
+			break
+		}
+
+		for len(item) > 0 && item[len(item)-1] == "" {
+			item = item[:len(item)-1]
+		}
+		items = append(items, item)
+
+		// a blank line before the next item makes the whole list loose
+		if blank && i < len(lines) {
+			if next := listMarker(lines[i]); next != nil && next.ordered == first.ordered && next.delim == first.delim {
+				loose = true
+			}
+		}
+	}
+
+	tag := "ul"
+	if first.ordered {
+		tag = "ol"
+	}
+	b.WriteString("<" + tag)
+	if first.ordered && first.start != 1 {
+		b.WriteString(" start=\"" + strconv.Itoa(first.start) + "\"")
+	}
+	b.WriteString(">\n")
+
+	for _, item := range items {
+		b.WriteString("<li>")
+		if len(item) > 0 {
+			if m := taskMarker.FindStringSubmatch(item[0]); m != nil {
+				b.WriteString("<input type=\"checkbox\" disabled")
+				if m[1] != " " {
+					b.WriteString(" checked")
+				}
+				b.WriteString("> ")
+				item[0] = item[0][len(m[0]):]
+			}
+		}
+		r.blocks(b, item, !loose)
+		b.WriteString("</li>\n")
+	}
+
+	b.WriteString("</" + tag + ">\n")
+	return i
+}
+
+// table renders a GitHub style table: header row, delimiter row, then body rows
+func (r *renderer) table(b *strings.Builder, lines []string, i int) int {
+	header := splitCells(lines[i])
+	var aligns []string
+	for _, cell := range splitCells(lines[i+1]) {
+		left, right := strings.HasPrefix(cell, ":"), strings.HasSuffix(cell, ":")
+		switch {
+		case left && right:
+			aligns = append(aligns, "center")
+		case right:
+			aligns = append(aligns, "right")
+		case left:
+			aligns = append(aligns, "left")
+		default:
+			aligns = append(aligns, "")
+		}
+	}
+
+	row := func(cells []string, tag string) {
+		b.WriteString("<tr>")
+		for c := range header {
+			b.WriteString("<" + tag)
+			if c < len(aligns) && aligns[c] != "" {
+				b.WriteString(" style=\"text-align:" + aligns[c] + "\"")
+			}
+			b.WriteString(">")
+			if c < len(cells) {
+				b.WriteString(inline(cells[c]))
+			}
+			b.WriteString("</" + tag + ">")
+		}
+		b.WriteString("</tr>\n")
+	}
+
+	b.WriteString("<table>\n<thead>\n")
+	row(header, "th")
+	b.WriteString("</thead>\n")
+
+	i += 2
+	if i < len(lines) && strings.TrimSpace(lines[i]) != "" && !startsBlock(lines[i]) {
+		b.WriteString("<tbody>\n")
+		for ; i < len(lines) && strings.TrimSpace(lines[i]) != "" && !startsBlock(lines[i]); i++ {
+			row(splitCells(lines[i]), "td")
+		}
+		b.WriteString("</tbody>\n")
+	}
+
+	b.WriteString("</table>\n")
+	return i
+}
+
+// splitCells splits a table row on unescaped pipes, ignoring the outer ones
+func splitCells(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, "\\|") {
+		line = line[:len(line)-1]
+	}
+
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// splitLines normalises line endings and expands leading tabs to four spaces
+func splitLines(src string) []string {
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	src = strings.ReplaceAll(src, "\r", "\n")
+	lines := strings.Split(src, "\n")
+	for i, line := range lines {
+		rest := strings.TrimLeft(line, " \t")
+		if lead := line[:len(line)-len(rest)]; strings.Contains(lead, "\t") {
+			width := 0
+			for _, c := range lead {
+				if c == '\t' {
+					width += 4 - width%4
+				} else {
+					width++
+				}
+			}
+			lines[i] = strings.Repeat(" ", width) + rest
+		}
+	}
+	return lines
+}
+
+// leadingSpaces counts the spaces a line starts with
+func leadingSpaces(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// trimIndent removes up to n leading spaces
+func trimIndent(line string, n int) string {
+	return line[min(n, leadingSpaces(line)):]
+}
+
+// plainText strips the tags from rendered inline HTML and unescapes what's left
+func plainText(rendered string) string {
+	var b strings.Builder
+	inTag := false
+	for _, c := range rendered {
+		switch {
+		case c == '<':
+			inTag = true
+		case c == '>' && inTag:
+			inTag = false
+		case !inTag:
+			b.WriteRune(c)
+		}
+	}
+	return html.UnescapeString(b.String())
+}
@@ -0,0 +1,45 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderSanitizes(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"javascript link", "[x](javascript:alert(1))", "<p>x</p>\n"},
+		{"javascript link, mixed case and spaces", "[x]( JavaScript:alert(1))", "<p>x</p>\n"},
+		{"javascript link in angle brackets", "[x](<javascript:alert(1)>)", "<p>x</p>\n"},
+		{"vbscript link", "[x](vbscript:msgbox)", "<p>x</p>\n"},
+		{"control character in the scheme", "[x](<java\tscript:alert(1)>)", "<p>x</p>\n"},
+		{"javascript autolink", "<javascript:alert(1)>", "<p>&lt;javascript:alert(1)&gt;</p>\n"},
+		{"data image", "![i](data:image/png;base64,AAA)", "<p>i</p>\n"},
+		{"script tag", "<script>alert(1)</script>", "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>\n"},
+		{"event handler", "<img src=x onerror=alert(1)>", "<p>&lt;img src=x onerror=alert(1)&gt;</p>\n"},
+		{"html in a heading", "# <b>hi</b>", "<h1 id=\"bhib\">&lt;b&gt;hi&lt;/b&gt;</h1>\n"},
+		{"html in link text", "[<b>x</b>](/ok)", "<p><a href=\"/ok\">&lt;b&gt;x&lt;/b&gt;</a></p>\n"},
+		{"html in code", "```\n<b>\n```", "<pre><code data-code-block=\"0\">&lt;b&gt;\n</code></pre>\n"},
+		{"quote breaking out of href", "[a](/p?q=\"><script>)", "<p><a href=\"/p?q=&#34;&gt;&lt;script&gt;\">a</a></p>\n"},
+		{"quote breaking out of title", "[a](/p 't\" onclick=\"x')", "<p><a href=\"/p\" title=\"t&#34; onclick=&#34;x\">a</a></p>\n"},
+		{"https link", "[x](https://example.com)", "<p><a href=\"https://example.com\" target=\"_blank\" rel=\"noopener noreferrer\">x</a></p>\n"},
+		{"mailto autolink", "<someone@example.com>", "<p><a href=\"mailto:someone@example.com\">someone@example.com</a></p>\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Render(tt.src).HTML
+			if got != tt.want {
+				t.Errorf("Render(%q)\n got %q\nwant %q", tt.src, got, tt.want)
+			}
+			for _, unsafe := range []string{"<script", "javascript:", "vbscript:", "data:", "onerror=\"", "<img src=x"} {
+				if strings.Contains(strings.ToLower(got), unsafe) && !strings.Contains(tt.want, unsafe) {
+					t.Errorf("output contains %q: %q", unsafe, got)
+				}
+			}
+		})
+	}
+}
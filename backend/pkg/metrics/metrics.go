@@ -0,0 +1,135 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// metric names exported at /metrics - kept here so alerts have one place to look
+const (
+	TaskFailures         = "cms_task_failures_total"
+	FailedImports        = "cms_failed_imports_total"
+	FailedTranscodes     = "cms_failed_transcodes_total"
+	LastSuccessfulScan   = "cms_last_successful_scan_timestamp_seconds"
+	LastSuccessfulImport = "cms_last_successful_import_timestamp_seconds"
+)
+
+// family is one metric with all its label values
+type family struct {
+	name   string
+	help   string
+	kind   string             // counter or gauge
+	label  string             // label name, empty for unlabeled metrics
+	values map[string]float64 // label value -> current value
+}
+
+// Registry keeps every metric in memory - nothing fancy, we only need a few
+type Registry struct {
+	mu       sync.Mutex
+	families map[string]*family
+}
+
+// global registry - same singleton approach as the task manager
+var registry = newRegistry()
+
+func newRegistry() *Registry {
+	r := &Registry{families: make(map[string]*family)}
+
+	r.register(TaskFailures, "Background tasks that ended in failure.", "counter", "type")
+	r.register(FailedImports, "Course imports that failed, by failure type.", "counter", "type")
+	r.register(FailedTranscodes, "Media tool runs that failed - ffmpeg, pdftoppm or the transcriber.", "counter", "type")
+	r.register(LastSuccessfulScan, "Unix time of the last library scan that completed without errors.", "gauge", "")
+	r.register(LastSuccessfulImport, "Unix time of the last course import that succeeded.", "gauge", "")
+
+	return r
+}
+
+func (r *Registry) register(name, help, kind, label string) {
+	r.families[name] = &family{
+		name:   name,
+		help:   help,
+		kind:   kind,
+		label:  label,
+		values: make(map[string]float64),
+	}
+}
+
+// IncCounter bumps a counter by one for the given label value
+func IncCounter(name, labelValue string) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	f, ok := registry.families[name]
+	if !ok {
+		return
+	}
+	f.values[labelValue]++
+}
+
+// SetGauge sets an unlabeled gauge
+func SetGauge(name string, value float64) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	f, ok := registry.families[name]
+	if !ok {
+		return
+	}
+	f.values[""] = value
+}
+
+// SetTimestamp sets a gauge to the given time as unix seconds
+func SetTimestamp(name string, t time.Time) {
+	SetGauge(name, float64(t.Unix()))
+}
+
+// WriteText writes all metrics in the Prometheus text exposition format
+func WriteText(w io.Writer) error {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	names := make([]string, 0, len(registry.families))
+	for name := range registry.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		f := registry.families[name]
+		fmt.Fprintf(&b, "# HELP %s %s\n", f.name, f.help)
+		fmt.Fprintf(&b, "# TYPE %s %s\n", f.name, f.kind)
+
+		// unlabeled gauges are always exported so alerts can use absent()/time() math
+		if f.label == "" {
+			fmt.Fprintf(&b, "%s %g\n", f.name, f.values[""])
+			continue
+		}
+
+		labelValues := make([]string, 0, len(f.values))
+		for value := range f.values {
+			labelValues = append(labelValues, value)
+		}
+		sort.Strings(labelValues)
+
+		for _, value := range labelValues {
+			fmt.Fprintf(&b, "%s{%s=%q} %g\n", f.name, f.label, value, f.values[value])
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Handler serves GET /metrics for Prometheus to scrape
+func Handler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if err := WriteText(w); err != nil {
+		http.Error(w, "failed to write metrics", http.StatusInternalServerError)
+	}
+}
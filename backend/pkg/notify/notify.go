@@ -0,0 +1,72 @@
+package notify
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// how often a profile wants to hear about a category
+const (
+	Immediate = "immediate" // deliver each event as it happens
+	Daily     = "daily"     // one digest per day
+	Weekly    = "weekly"    // one digest per week
+)
+
+// ValidFrequency checks a frequency is one we know how to schedule
+func ValidFrequency(frequency string) bool {
+	switch frequency {
+	case Immediate, Daily, Weekly:
+		return true
+	}
+	return false
+}
+
+// DigestDue reports whether a batched category should be sent now
+// lastSent is zero if nothing has been sent yet, in which case the first digest goes out right away.
+func DigestDue(frequency string, lastSent, now time.Time) bool {
+	if lastSent.IsZero() {
+		return true
+	}
+
+	switch frequency {
+	case Daily:
+		return now.Sub(lastSent) >= 24*time.Hour
+	case Weekly:
+		return now.Sub(lastSent) >= 7*24*time.Hour
+	default:
+		return true
+	}
+}
+
+// Item is one event inside a message
+type Item struct {
+	Title     string    `json:"title"`
+	Body      string    `json:"body,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Message is what gets handed to a sender - a single event or a digest of several
+type Message struct {
+	ProfileID uuid.UUID `json:"profile_id"`
+	Category  string    `json:"category"`
+	Frequency string    `json:"frequency"`
+	Items     []Item    `json:"items"`
+}
+
+// Sender delivers messages somewhere - webhooks, email etc. plug in here
+type Sender interface {
+	Send(ctx context.Context, message Message) error
+}
+
+// LogSender just writes messages to the log; the in-app inbox reads straight from the database
+type LogSender struct{}
+
+// Send logs the message
+func (LogSender) Send(ctx context.Context, message Message) error {
+	log.Printf("Notification (%s, %s) for %s: %d items", message.Category, message.Frequency,
+		message.ProfileID, len(message.Items))
+	return nil
+}
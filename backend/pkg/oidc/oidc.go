@@ -0,0 +1,463 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// how long any one call to the provider may take
+const requestTimeout = 10 * time.Second
+
+// an unknown key ID makes us fetch the provider's keys again, but not more often than this
+const keyRefreshInterval = time.Minute
+
+// clocks of the provider and ours may be off by this much
+const clockSkew = time.Minute
+
+// ErrInvalidIDToken is returned for an ID token that doesn't check out
+var ErrInvalidIDToken = errors.New("invalid ID token")
+
+// Config says which provider to log in with and who we are to it
+type Config struct {
+	Issuer       string   // OIDC_ISSUER, the provider's URL as it appears in its tokens
+	ClientID     string   // OIDC_CLIENT_ID
+	ClientSecret string   // OIDC_CLIENT_SECRET, empty for a public client - PKCE protects the code either way
+	RedirectURL  string   // OIDC_REDIRECT_URL, our /api/auth/oidc/callback as the browser reaches it
+	Scopes       []string // OIDC_SCOPES, space separated
+}
+
+// LoadConfig reads the provider settings from the environment. Without OIDC_ISSUER
+// OIDC login is off and this returns nil.
+func LoadConfig() *Config {
+	issuer := os.Getenv("OIDC_ISSUER")
+	if issuer == "" {
+		return nil
+	}
+
+	config := &Config{
+		Issuer:       issuer,
+		ClientID:     os.Getenv("OIDC_CLIENT_ID"),
+		ClientSecret: os.Getenv("OIDC_CLIENT_SECRET"),
+		RedirectURL:  os.Getenv("OIDC_REDIRECT_URL"),
+		Scopes:       strings.Fields(os.Getenv("OIDC_SCOPES")),
+	}
+	if config.ClientID == "" || config.RedirectURL == "" {
+		log.Printf("Warning: OIDC_ISSUER is set but OIDC_CLIENT_ID or OIDC_REDIRECT_URL is not, OIDC login stays off")
+		return nil
+	}
+	if len(config.Scopes) == 0 {
+		config.Scopes = []string{"openid", "profile", "email"}
+	}
+	if !slices.Contains(config.Scopes, "openid") {
+		config.Scopes = append([]string{"openid"}, config.Scopes...)
+	}
+	return config
+}
+
+// Claims are what an ID token says about who logged in
+type Claims struct {
+	Issuer            string   `json:"iss"`
+	Subject           string   `json:"sub"` // stable ID of the user at the provider
+	Audience          audience `json:"aud"`
+	AuthorizedParty   string   `json:"azp,omitempty"`
+	ExpiresAt         int64    `json:"exp"`
+	IssuedAt          int64    `json:"iat"`
+	Nonce             string   `json:"nonce,omitempty"`
+	Name              string   `json:"name,omitempty"`
+	PreferredUsername string   `json:"preferred_username,omitempty"`
+	Email             string   `json:"email,omitempty"`
+	EmailVerified     bool     `json:"email_verified,omitempty"`
+}
+
+// DisplayName picks the friendliest name the provider shared, the subject as a last resort
+func (c *Claims) DisplayName() string {
+	for _, name := range []string{c.Name, c.PreferredUsername, c.Email} {
+		if name = strings.TrimSpace(name); name != "" {
+			return name
+		}
+	}
+	return c.Subject
+}
+
+// audience is the aud claim, which may be a single string or a list
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*a = list
+	return nil
+}
+
+// metadata is the part of the provider's discovery document we use
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Provider talks to one OpenID Connect provider: sends users to it, redeems the codes
+// they come back with and checks the ID tokens. Its discovery document is fetched on
+// first use, so the server starts even while the provider is down.
+type Provider struct {
+	Config Config
+	Client *http.Client
+
+	mu          sync.Mutex
+	metadata    *metadata
+	keys        map[string]crypto.PublicKey // signing keys by key ID
+	keysFetched time.Time
+}
+
+// NewProvider creates a provider for a config
+func NewProvider(config Config) *Provider {
+	return &Provider{
+		Config: config,
+		Client: &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// AuthURL returns where to send the browser to log in. state comes back with the user,
+// nonce comes back in the ID token and verifier has to be handed to Exchange (PKCE).
+func (p *Provider) AuthURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := url.Parse(meta.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization endpoint %q: %w", meta.AuthorizationEndpoint, err)
+	}
+	challenge := sha256.Sum256([]byte(verifier))
+
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.Config.ClientID)
+	query.Set("redirect_uri", p.Config.RedirectURL)
+	query.Set("scope", strings.Join(p.Config.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+	return authURL.String(), nil
+}
+
+// Exchange redeems the code a user came back with and returns their raw ID token
+func (p *Provider) Exchange(ctx context.Context, code, verifier string) (string, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.Config.RedirectURL},
+		"code_verifier": {verifier},
+		"client_id":     {p.Config.ClientID},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("error creating token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.Config.ClientSecret != "" {
+		// client_secret_basic, the one method every provider supports
+		req.SetBasicAuth(url.QueryEscape(p.Config.ClientID), url.QueryEscape(p.Config.ClientSecret))
+	}
+
+	var tokens struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	status, err := p.do(req, &tokens)
+	if err != nil {
+		return "", fmt.Errorf("token request failed: %w", err)
+	}
+	if tokens.Error != "" {
+		return "", fmt.Errorf("provider refused the code: %s %s", tokens.Error, tokens.ErrorDescription)
+	}
+	if status != http.StatusOK {
+		return "", fmt.Errorf("token endpoint returned %d", status)
+	}
+	if tokens.IDToken == "" {
+		return "", fmt.Errorf("%w: the provider didn't return one, is the openid scope allowed?", ErrInvalidIDToken)
+	}
+	return tokens.IDToken, nil
+}
+
+// Verify checks an ID token's signature against the provider's keys, and that it was
+// issued by the provider, for us, recently, and for the login that sent nonce
+func (p *Provider) Verify(ctx context.Context, token, nonce string, now time.Time) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed", ErrInvalidIDToken)
+	}
+
+	var header struct {
+		Algorithm string `json:"alg"`
+		KeyID     string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: malformed header", ErrInvalidIDToken)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed signature", ErrInvalidIDToken)
+	}
+
+	key, err := p.key(ctx, header.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := verifySignature(header.Algorithm, key, digest[:], signature); err != nil {
+		return nil, err
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: malformed claims", ErrInvalidIDToken)
+	}
+
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case claims.Issuer != meta.Issuer:
+		return nil, fmt.Errorf("%w: issued by %q", ErrInvalidIDToken, claims.Issuer)
+	case !slices.Contains(claims.Audience, p.Config.ClientID):
+		return nil, fmt.Errorf("%w: not meant for this client", ErrInvalidIDToken)
+	case len(claims.Audience) > 1 && claims.AuthorizedParty != p.Config.ClientID:
+		return nil, fmt.Errorf("%w: issued to %q", ErrInvalidIDToken, claims.AuthorizedParty)
+	case !now.Before(time.Unix(claims.ExpiresAt, 0).Add(clockSkew)):
+		return nil, fmt.Errorf("%w: expired", ErrInvalidIDToken)
+	case claims.Nonce != nonce:
+		return nil, fmt.Errorf("%w: issued for another login", ErrInvalidIDToken)
+	case claims.Subject == "":
+		return nil, fmt.Errorf("%w: no subject", ErrInvalidIDToken)
+	}
+	return &claims, nil
+}
+
+// discover fetches the provider's discovery document, once
+func (p *Provider) discover(ctx context.Context) (*metadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+
+	endpoint := strings.TrimSuffix(p.Config.Issuer, "/") + "/.well-known/openid-configuration"
+	var meta metadata
+	if err := p.getJSON(ctx, endpoint, &meta); err != nil {
+		return nil, fmt.Errorf("error reading provider configuration: %w", err)
+	}
+	// a provider answering for another issuer is misconfigured or not who we think it is
+	if strings.TrimSuffix(meta.Issuer, "/") != strings.TrimSuffix(p.Config.Issuer, "/") {
+		return nil, fmt.Errorf("provider says it is %q, expected %q", meta.Issuer, p.Config.Issuer)
+	}
+	if meta.AuthorizationEndpoint == "" || meta.TokenEndpoint == "" || meta.JWKSURI == "" {
+		return nil, errors.New("provider configuration is missing endpoints")
+	}
+
+	p.metadata = &meta
+	return p.metadata, nil
+}
+
+// key returns the provider's signing key with an ID, fetching the key set again when
+// it's unknown - providers rotate keys. A token without a key ID needs a single key.
+func (p *Provider) key(ctx context.Context, keyID string) (crypto.PublicKey, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key := p.lookupKey(keyID); key != nil {
+		return key, nil
+	}
+	if time.Since(p.keysFetched) < keyRefreshInterval {
+		return nil, fmt.Errorf("%w: unknown signing key %q", ErrInvalidIDToken, keyID)
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := p.getJSON(ctx, meta.JWKSURI, &set); err != nil {
+		return nil, fmt.Errorf("error reading provider keys: %w", err)
+	}
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			log.Printf("Warning: skipping provider key %q: %v", jwk.KeyID, err)
+			continue
+		}
+		keys[jwk.KeyID] = key
+	}
+	p.keys, p.keysFetched = keys, time.Now()
+
+	if key := p.lookupKey(keyID); key != nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w: unknown signing key %q", ErrInvalidIDToken, keyID)
+}
+
+// lookupKey finds a cached key, nil when there's no match. Callers hold p.mu.
+func (p *Provider) lookupKey(keyID string) crypto.PublicKey {
+	if keyID == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key
+		}
+	}
+	return p.keys[keyID]
+}
+
+// getJSON fetches a document from the provider
+func (p *Provider) getJSON(ctx context.Context, endpoint string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	status, err := p.do(req, v)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("%s returned %d", endpoint, status)
+	}
+	return nil
+}
+
+// do sends a request and decodes the JSON answer into v, whatever the status
+func (p *Provider) do(req *http.Request, v any) (int, error) {
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return 0, fmt.Errorf("error reading response: %w", err)
+	}
+	if err := json.Unmarshal(body, v); err != nil && resp.StatusCode == http.StatusOK {
+		return 0, fmt.Errorf("error decoding response: %w", err)
+	}
+	return resp.StatusCode, nil
+}
+
+// jsonWebKey is one key of the provider's key set, RSA or P-256
+type jsonWebKey struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+	Curve   string `json:"crv"`
+	X       string `json:"x"`
+	Y       string `json:"y"`
+}
+
+// publicKey converts the key to its crypto form
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.KeyType {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("malformed modulus: %w", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return nil, errors.New("malformed exponent")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		if k.Curve != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %s", k.Curve)
+		}
+		x, errX := base64.RawURLEncoding.DecodeString(k.X)
+		y, errY := base64.RawURLEncoding.DecodeString(k.Y)
+		if errX != nil || errY != nil || len(x) != 32 || len(y) != 32 {
+			return nil, errors.New("malformed point")
+		}
+		return ecdsa.ParseUncompressedPublicKey(elliptic.P256(), append(append([]byte{4}, x...), y...))
+	}
+	return nil, fmt.Errorf("unsupported key type %q", k.KeyType)
+}
+
+// verifySignature checks an RS256 or ES256 signature over a SHA-256 digest. The algorithm
+// has to fit the key, so a token can't pick a weaker check than the provider signs with.
+func verifySignature(algorithm string, key crypto.PublicKey, digest, signature []byte) error {
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		if algorithm != "RS256" {
+			break
+		}
+		if rsa.VerifyPKCS1v15(key, crypto.SHA256, digest, signature) != nil {
+			return fmt.Errorf("%w: bad signature", ErrInvalidIDToken)
+		}
+		return nil
+	case *ecdsa.PublicKey:
+		if algorithm != "ES256" {
+			break
+		}
+		if len(signature) != 64 {
+			return fmt.Errorf("%w: malformed signature", ErrInvalidIDToken)
+		}
+		r, s := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+		if !ecdsa.Verify(key, digest, r, s) {
+			return fmt.Errorf("%w: bad signature", ErrInvalidIDToken)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: algorithm %q doesn't match the signing key", ErrInvalidIDToken, algorithm)
+}
+
+// decodeSegment decodes one base64url JSON part of a token
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,53 @@
+package parser
+
+import (
+	"strings"
+	"sync"
+)
+
+// ContentTypeUnknown is what files we can't classify end up as
+const ContentTypeUnknown = "unknown"
+
+// ContentTypes are the types the app knows how to show
+var ContentTypes = []string{"video", "audio", "pdf", "text", "image", "presentation", "document", "spreadsheet"}
+
+// extension overrides set by users - global like the task manager, every parser shares them
+var extensionRegistry = struct {
+	sync.RWMutex
+	types map[string]string
+}{types: make(map[string]string)}
+
+// RegisterExtension maps an extension to a content type for all future parsing.
+// User mappings win over the built-in list.
+func RegisterExtension(ext, contentType string) {
+	extensionRegistry.Lock()
+	defer extensionRegistry.Unlock()
+	extensionRegistry.types[NormalizeExtension(ext)] = contentType
+}
+
+// lookupExtension returns the user mapping for an extension, if any
+func lookupExtension(ext string) (string, bool) {
+	extensionRegistry.RLock()
+	defer extensionRegistry.RUnlock()
+	contentType, ok := extensionRegistry.types[NormalizeExtension(ext)]
+	return contentType, ok
+}
+
+// NormalizeExtension lower-cases an extension and makes sure it starts with a dot
+func NormalizeExtension(ext string) string {
+	ext = strings.ToLower(strings.TrimSpace(ext))
+	if ext != "" && !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+	return ext
+}
+
+// IsContentType reports whether contentType is one the app knows
+func IsContentType(contentType string) bool {
+	for _, known := range ContentTypes {
+		if known == contentType {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -9,6 +11,8 @@ import (
 	"strings"
 
 	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/NeroQue/course-management-backend/pkg/chapters"
+	"github.com/NeroQue/course-management-backend/pkg/pdf"
 	"github.com/google/uuid"
 )
 
@@ -116,8 +120,9 @@ func (p *CourseParser) ParseCourseFolder(folderPath string) (*models.Course, err
 		return nil, fmt.Errorf("specified path is not a directory: %s", folderPath)
 	}
 
-	// scan the folder structure
-	modules, err := p.scanCourseFolder(folderPath)
+	// scan the folder structure, keeping track of anything we had to leave out
+	var warnings []models.ImportWarning
+	modules, err := p.scanCourseFolder(folderPath, &warnings)
 	if err != nil {
 		return nil, err
 	}
@@ -138,11 +143,21 @@ func (p *CourseParser) ParseCourseFolder(folderPath string) (*models.Course, err
 		Modules:      modules,
 	}
 
+	// the manifest, if there is one, knows better than the folder name
+	manifest, err := ReadManifest(folderPath)
+	if err != nil {
+		addWarning(&warnings, models.WarnInvalidManifest, p.relativeTo(p.BasePath, filepath.Join(folderPath, ManifestFileName)),
+			"Course manifest was ignored: "+err.Error())
+	} else if manifest != nil {
+		applyManifest(course, manifest)
+	}
+	course.Warnings = warnings
+
 	return course, nil
 }
 
 // scanCourseFolder recursively scans folder and builds the course structure
-func (p *CourseParser) scanCourseFolder(folderPath string) ([]*models.Module, error) {
+func (p *CourseParser) scanCourseFolder(folderPath string, warnings *[]models.ImportWarning) ([]*models.Module, error) {
 	var modules []*models.Module
 
 	entries, err := os.ReadDir(folderPath)
@@ -169,12 +184,13 @@ func (p *CourseParser) scanCourseFolder(folderPath string) ([]*models.Module, er
 			}
 
 			// scan for content inside this module
-			contentItems, err := p.scanModuleForContentRecursive(modulePath, p.BasePath)
+			contentItems, err := p.scanModuleForContentRecursive(modulePath, p.BasePath, warnings)
 			if err != nil {
 				log.Printf("Error scanning module %s: %v", entry.Name(), err)
+				addWarning(warnings, models.WarnSkippedFile, relativePath, "Module folder could not be read: "+err.Error())
 			} else {
-				module.ContentItems = contentItems
-				log.Printf("Module '%s' found %d content items", entry.Name(), len(contentItems))
+				module.ContentItems = groupVariants(contentItems)
+				log.Printf("Module '%s' found %d content items", entry.Name(), len(module.ContentItems))
 			}
 
 			modules = append(modules, module)
@@ -182,6 +198,17 @@ func (p *CourseParser) scanCourseFolder(folderPath string) ([]*models.Module, er
 		}
 	}
 
+	// files next to module folders don't belong to any module, so they're left out
+	if len(modules) > 0 {
+		for _, entry := range entries {
+			if entry.IsDir() || entry.Name() == ManifestFileName {
+				continue
+			}
+			addWarning(warnings, models.WarnSkippedFile, p.relativeTo(p.BasePath, filepath.Join(folderPath, entry.Name())),
+				"File sits next to module folders and was not imported")
+		}
+	}
+
 	// if no subdirectories, treat files in this folder as one module
 	if len(modules) == 0 {
 		module := &models.Module{
@@ -192,14 +219,23 @@ func (p *CourseParser) scanCourseFolder(folderPath string) ([]*models.Module, er
 			ContentItems: []*models.ContentItem{},
 		}
 
-		contentItems, err := p.scanModuleForContentRecursive(folderPath, p.BasePath)
+		contentItems, err := p.scanModuleForContentRecursive(folderPath, p.BasePath, warnings)
 		if err != nil {
 			return nil, fmt.Errorf("error scanning for content: %w", err)
 		}
 
-		module.ContentItems = contentItems
+		// the manifest describes the course, it isn't part of it
+		manifestPath := p.relativeTo(p.BasePath, filepath.Join(folderPath, ManifestFileName))
+		for i, item := range contentItems {
+			if item.RelativePath == manifestPath {
+				contentItems = append(contentItems[:i], contentItems[i+1:]...)
+				break
+			}
+		}
+
+		module.ContentItems = groupVariants(contentItems)
 		modules = append(modules, module)
-		log.Printf("Created default module with %d content items", len(contentItems))
+		log.Printf("Created default module with %d content items", len(module.ContentItems))
 	}
 
 	log.Printf("Course parsing completed: found %d modules", len(modules))
@@ -207,7 +243,7 @@ func (p *CourseParser) scanCourseFolder(folderPath string) ([]*models.Module, er
 }
 
 // scanModuleForContentRecursive finds all the actual content files in a module
-func (p *CourseParser) scanModuleForContentRecursive(modulePath, basePath string) ([]*models.ContentItem, error) {
+func (p *CourseParser) scanModuleForContentRecursive(modulePath, basePath string, warnings *[]models.ImportWarning) ([]*models.ContentItem, error) {
 	var contentItems []*models.ContentItem
 
 	entries, err := os.ReadDir(modulePath)
@@ -221,9 +257,10 @@ func (p *CourseParser) scanModuleForContentRecursive(modulePath, basePath string
 
 		if entry.IsDir() {
 			// recursively scan subdirectories
-			subContentItems, err := p.scanModuleForContentRecursive(entryPath, basePath)
+			subContentItems, err := p.scanModuleForContentRecursive(entryPath, basePath, warnings)
 			if err != nil {
 				log.Printf("Error scanning subdirectory %s: %v", entry.Name(), err)
+				addWarning(warnings, models.WarnSkippedFile, p.relativeTo(basePath, entryPath), "Folder could not be read: "+err.Error())
 				continue
 			}
 			contentItems = append(contentItems, subContentItems...)
@@ -232,6 +269,7 @@ func (p *CourseParser) scanModuleForContentRecursive(modulePath, basePath string
 			info, err := entry.Info()
 			if err != nil {
 				log.Printf("Error getting info for %s: %v", entry.Name(), err)
+				addWarning(warnings, models.WarnSkippedFile, p.relativeTo(basePath, entryPath), "File could not be read: "+err.Error())
 				continue
 			}
 
@@ -253,26 +291,69 @@ func (p *CourseParser) scanModuleForContentRecursive(modulePath, basePath string
 				Order:        i, // use file order in directory
 			}
 
+			// the reader shows "page n of m" and PDF progress is tracked by page
+			if contentType == "pdf" {
+				pages, err := pdf.PageCount(context.Background(), entryPath)
+				if err != nil {
+					log.Printf("Could not count pages of %s: %v", entry.Name(), err)
+				}
+				contentItem.PageCount = pages
+			}
+
+			// chapter markers come with the file so the player can navigate right away
+			if contentType == "audio" || contentType == "video" {
+				markers, err := chapters.Extract(context.Background(), entryPath)
+				if err != nil && !errors.Is(err, chapters.ErrToolMissing) {
+					log.Printf("Could not read chapters of %s: %v", entry.Name(), err)
+				}
+				for _, marker := range markers {
+					contentItem.Chapters = append(contentItem.Chapters, models.ChapterMarker{Title: marker.Title, Start: marker.Start})
+				}
+			}
+
 			contentItems = append(contentItems, contentItem)
 		}
 	}
 
-	return contentItems, nil
+	return dropPairedSubtitles(contentItems), nil
 }
 
 // scanModuleForContent scans module for content (kept for compatibility)
 func (p *CourseParser) scanModuleForContent(modulePath string) ([]*models.ContentItem, error) {
 	// just use the recursive version
-	return p.scanModuleForContentRecursive(modulePath, p.BasePath)
+	return p.scanModuleForContentRecursive(modulePath, p.BasePath, nil)
+}
+
+// relativeTo makes path relative to basePath, falling back to the full path
+func (p *CourseParser) relativeTo(basePath, path string) string {
+	relativePath, err := filepath.Rel(basePath, path)
+	if err != nil {
+		return path
+	}
+	return relativePath
+}
+
+// addWarning records an import warning if the caller is collecting them
+func addWarning(warnings *[]models.ImportWarning, code, path, message string) {
+	if warnings == nil {
+		return
+	}
+	*warnings = append(*warnings, models.ImportWarning{Code: code, Path: path, Message: message})
 }
 
 // determineContentType figures out what kind of file this is based on extension
 func (p *CourseParser) determineContentType(filename string) string {
 	ext := strings.ToLower(filepath.Ext(filename))
 
+	if contentType, ok := lookupExtension(ext); ok {
+		return contentType
+	}
+
 	switch ext {
 	case ".mp4", ".avi", ".mov", ".mkv", ".wmv":
 		return "video"
+	case ".mp3", ".m4a", ".m4b", ".aac", ".ogg", ".oga", ".opus", ".flac", ".wav":
+		return "audio"
 	case ".pdf":
 		return "pdf"
 	case ".md", ".txt":
@@ -286,6 +367,6 @@ func (p *CourseParser) determineContentType(filename string) string {
 	case ".xls", ".xlsx":
 		return "spreadsheet"
 	default:
-		return "unknown"
+		return ContentTypeUnknown
 	}
 }
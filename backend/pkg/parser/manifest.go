@@ -0,0 +1,91 @@
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+)
+
+// ManifestFileName is the optional file in a course folder that describes the course
+const ManifestFileName = "course.json"
+
+// Manifest is course metadata supplied by whoever put the folder together.
+// Every field is optional; whatever is set wins over what we'd guess from the folder.
+type Manifest struct {
+	Title       string   `json:"title,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Difficulty  string   `json:"difficulty,omitempty"`
+	Language    string   `json:"language,omitempty"`
+
+	Instructors []ManifestInstructor `json:"instructors,omitempty"`
+}
+
+// ManifestInstructor is written either as just a name or as an object
+type ManifestInstructor struct {
+	Name   string `json:"name"`
+	Bio    string `json:"bio,omitempty"`
+	Avatar string `json:"avatar,omitempty"` // URL of a picture
+}
+
+// UnmarshalJSON accepts "Jane Doe" as well as {"name": "Jane Doe", ...}
+func (i *ManifestInstructor) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*i = ManifestInstructor{Name: name}
+		return nil
+	}
+
+	type plain ManifestInstructor // without this method, so it doesn't recurse
+	return json.Unmarshal(data, (*plain)(i))
+}
+
+// ReadManifest reads the manifest of a course folder. A folder without one returns nil, nil.
+func ReadManifest(folderPath string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(folderPath, ManifestFileName))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading %s: %w", ManifestFileName, err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", ManifestFileName, err)
+	}
+	return &manifest, nil
+}
+
+// applyManifest copies the manifest's non-empty fields onto a parsed course.
+// Values are taken as written; the importer validates them before saving.
+func applyManifest(course *models.Course, manifest *Manifest) {
+	if title := strings.TrimSpace(manifest.Title); title != "" {
+		course.Title = title
+	}
+	if description := strings.TrimSpace(manifest.Description); description != "" {
+		course.Description = description
+	}
+	if len(manifest.Tags) > 0 {
+		course.Tags = manifest.Tags
+	}
+	course.Difficulty = manifest.Difficulty
+	course.Language = manifest.Language
+
+	for _, instructor := range manifest.Instructors {
+		name := strings.TrimSpace(instructor.Name)
+		if name == "" {
+			continue
+		}
+		course.Instructors = append(course.Instructors, models.Instructor{
+			Name:      name,
+			Bio:       strings.TrimSpace(instructor.Bio),
+			AvatarURL: strings.TrimSpace(instructor.Avatar),
+		})
+	}
+}
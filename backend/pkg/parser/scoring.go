@@ -0,0 +1,174 @@
+package parser
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// thresholds used when scoring a candidate course directory
+const (
+	recommendedScore = 50               // candidates at or above this get pre-selected
+	maxScanDepth     = 6                // don't walk deeper than this when scoring
+	largeCourseSize  = 50 * 1024 * 1024 // 50MB looks like real course material
+)
+
+// bracketTagRegex matches things like "[FreeCourseSite.com]" that clutter folder names
+var bracketTagRegex = regexp.MustCompile(`\[[^\]]*\]|\([^)]*\.(com|net|org|io)\)`)
+
+// CandidateScore is a scanned directory plus how likely it is to be a course
+type CandidateScore struct {
+	FileInfo
+	Score          int      `json:"score"`           // 0-100 confidence
+	Recommended    bool     `json:"recommended"`     // whether the UI should pre-select it
+	SuggestedTitle string   `json:"suggested_title"` // cleaned up folder name
+	ModuleCount    int      `json:"module_count"`    // modules an import would create
+	VideoCount     int      `json:"video_count"`     // video files found
+	FileCount      int      `json:"file_count"`      // all files found
+	TotalSize      int64    `json:"total_size"`      // bytes of all files
+	MaxDepth       int      `json:"max_depth"`       // deepest nesting below the course dir
+	Reasons        []string `json:"reasons"`         // why it scored the way it did
+}
+
+// ScoreCourseDirectory walks a directory and estimates how likely it is to be a course
+// Looks at videos, numbered naming, total size and how deep the nesting goes.
+func (p *CourseParser) ScoreCourseDirectory(dir FileInfo) CandidateScore {
+	candidate := CandidateScore{
+		FileInfo:       dir,
+		SuggestedTitle: SuggestTitle(dir.Name),
+		Reasons:        []string{},
+	}
+
+	var knownContent int
+	err := filepath.WalkDir(dir.Path, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // unreadable entries just don't count
+		}
+
+		rel, relErr := filepath.Rel(dir.Path, path)
+		if relErr != nil || rel == "." {
+			return nil
+		}
+		depth := strings.Count(rel, string(filepath.Separator)) + 1
+
+		if entry.IsDir() {
+			if depth > maxScanDepth {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if strings.HasPrefix(entry.Name(), ".") {
+			return nil // hidden files like .DS_Store
+		}
+
+		if depth > candidate.MaxDepth {
+			candidate.MaxDepth = depth
+		}
+		candidate.FileCount++
+		if info, infoErr := entry.Info(); infoErr == nil {
+			candidate.TotalSize += info.Size()
+		}
+
+		switch p.determineContentType(entry.Name()) {
+		case "video":
+			candidate.VideoCount++
+			knownContent++
+		case "unknown":
+		default:
+			knownContent++
+		}
+		return nil
+	})
+	if err != nil {
+		candidate.Reasons = append(candidate.Reasons, "could not fully read directory")
+	}
+
+	// top level layout decides module count and numbering
+	entries, _ := os.ReadDir(dir.Path)
+	var subdirs, topFiles, numbered, named int
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		named++
+		if startsWithNumber(entry.Name()) {
+			numbered++
+		}
+		if entry.IsDir() {
+			subdirs++
+		} else {
+			topFiles++
+		}
+	}
+	candidate.ModuleCount = subdirs
+	if subdirs == 0 && topFiles > 0 {
+		candidate.ModuleCount = 1 // parser puts loose files in a "Main Content" module
+	}
+
+	score := 0
+	if candidate.VideoCount > 0 {
+		score += 40
+		candidate.Reasons = append(candidate.Reasons, "contains videos")
+	} else if knownContent > 0 {
+		score += 15
+		candidate.Reasons = append(candidate.Reasons, "contains documents but no videos")
+	} else {
+		candidate.Reasons = append(candidate.Reasons, "no recognised course content")
+	}
+
+	if named > 0 && numbered*2 >= named {
+		score += 25
+		candidate.Reasons = append(candidate.Reasons, "entries are numbered")
+	} else if numbered > 0 {
+		score += 10
+		candidate.Reasons = append(candidate.Reasons, "some entries are numbered")
+	}
+
+	if candidate.TotalSize >= largeCourseSize {
+		score += 15
+		candidate.Reasons = append(candidate.Reasons, "large enough to be a course")
+	} else if candidate.TotalSize > 0 {
+		score += 5
+	}
+
+	switch {
+	case knownContent == 0:
+		// structure alone doesn't make a random folder a course
+	case candidate.MaxDepth >= 1 && candidate.MaxDepth <= 3:
+		score += 20
+		candidate.Reasons = append(candidate.Reasons, "shallow module structure")
+	case candidate.MaxDepth > 3:
+		score += 5
+		candidate.Reasons = append(candidate.Reasons, "deeply nested")
+	}
+
+	if score > 100 {
+		score = 100
+	}
+	candidate.Score = score
+	candidate.Recommended = score >= recommendedScore
+
+	return candidate
+}
+
+// SuggestTitle turns a folder name into something readable
+// e.g. "[FreeCourseSite.com] Udemy_-_Go_Basics" -> "Udemy - Go Basics"
+func SuggestTitle(name string) string {
+	title := bracketTagRegex.ReplaceAllString(name, " ")
+	title = strings.NewReplacer("_", " ", ".", " ").Replace(title)
+	title = strings.Join(strings.Fields(title), " ")
+	title = strings.Trim(title, " -")
+	if title == "" {
+		return name
+	}
+	return title
+}
+
+// startsWithNumber checks for names like "01 Intro" or "3. Setup"
+func startsWithNumber(name string) bool {
+	return name != "" && unicode.IsDigit(rune(name[0]))
+}
@@ -0,0 +1,107 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+)
+
+// subtitleExtensions are the caption formats we pair with videos
+var subtitleExtensions = map[string]bool{".srt": true, ".vtt": true}
+
+// SubtitleFile is a caption file found next to a video
+type SubtitleFile struct {
+	Name     string // file name, doubles as the track ID
+	Path     string // full path on disk
+	Language string // two letter code from the file name, empty if it has none
+	Format   string // "srt" or "vtt"
+}
+
+// IsSubtitleFile reports whether a file name has a caption extension
+func IsSubtitleFile(name string) bool {
+	return subtitleExtensions[strings.ToLower(filepath.Ext(name))]
+}
+
+// pairsWith reports whether a caption file belongs to a video: same name once language
+// and resolution tags are stripped, so "lesson.en.srt" pairs with "lesson-720p.mp4"
+func pairsWith(subtitleName, videoName string) bool {
+	return strings.EqualFold(parseVariantTag(subtitleName).stem, parseVariantTag(videoName).stem)
+}
+
+// FindSubtitles lists the caption files next to a video that pair with it, one per
+// language - WebVTT wins when a language exists in both formats
+func FindSubtitles(videoPath string) ([]SubtitleFile, error) {
+	entries, err := os.ReadDir(filepath.Dir(videoPath))
+	if err != nil {
+		return nil, err
+	}
+
+	byLanguage := make(map[string]SubtitleFile)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !IsSubtitleFile(name) || !pairsWith(name, filepath.Base(videoPath)) {
+			continue
+		}
+
+		file := SubtitleFile{
+			Name:     name,
+			Path:     filepath.Join(filepath.Dir(videoPath), name),
+			Language: parseVariantTag(name).language,
+			Format:   strings.TrimPrefix(strings.ToLower(filepath.Ext(name)), "."),
+		}
+		if existing, ok := byLanguage[file.Language]; ok && existing.Format == "vtt" {
+			continue
+		}
+		byLanguage[file.Language] = file
+	}
+
+	files := make([]SubtitleFile, 0, len(byLanguage))
+	for _, file := range byLanguage {
+		files = append(files, file)
+	}
+	// untagged (usually the course's own language) first, then by language
+	sort.Slice(files, func(i, j int) bool {
+		if (files[i].Language == "") != (files[j].Language == "") {
+			return files[i].Language == ""
+		}
+		return files[i].Language < files[j].Language
+	})
+	return files, nil
+}
+
+// dropPairedSubtitles removes caption files that belong to a video in the same folder -
+// they're served with the video instead of showing up as content of their own
+func dropPairedSubtitles(items []*models.ContentItem) []*models.ContentItem {
+	var videos []*models.ContentItem
+	for _, item := range items {
+		if item.ContentType == "video" {
+			videos = append(videos, item)
+		}
+	}
+	if len(videos) == 0 {
+		return items
+	}
+
+	kept := items[:0]
+	for _, item := range items {
+		if IsSubtitleFile(item.RelativePath) && pairedWithAny(item, videos) {
+			continue
+		}
+		kept = append(kept, item)
+	}
+	return kept
+}
+
+// pairedWithAny reports whether a caption item pairs with one of the videos in its folder
+func pairedWithAny(subtitle *models.ContentItem, videos []*models.ContentItem) bool {
+	for _, video := range videos {
+		if filepath.Dir(video.RelativePath) == filepath.Dir(subtitle.RelativePath) &&
+			pairsWith(filepath.Base(subtitle.RelativePath), filepath.Base(video.RelativePath)) {
+			return true
+		}
+	}
+	return false
+}
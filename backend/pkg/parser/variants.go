@@ -0,0 +1,168 @@
+package parser
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// resolutionRegex matches resolution tags like "720p" or "4k" at the end of a file name
+var resolutionRegex = regexp.MustCompile(`^(\d{3,4}p|[248]k)$`)
+
+// variantLanguages are the language tags we recognise in file names. Kept to common
+// two letter codes so words like "to" or "go" don't turn files into variants.
+var variantLanguages = map[string]bool{
+	"ar": true, "cs": true, "da": true, "de": true, "el": true, "en": true, "es": true,
+	"fi": true, "fr": true, "he": true, "hi": true, "hu": true, "id": true, "ja": true,
+	"ko": true, "nl": true, "no": true, "pl": true, "pt": true, "ro": true, "ru": true,
+	"sv": true, "th": true, "tr": true, "uk": true, "vi": true, "zh": true,
+}
+
+// variantTag is what we could read from a file name's trailing tags
+type variantTag struct {
+	stem       string // name without extension and tags
+	resolution string
+	language   string
+}
+
+// parseVariantTag strips trailing resolution and language tags off a file name,
+// so "lesson-en-720p.mp4" becomes stem "lesson" with language "en" and resolution "720p"
+func parseVariantTag(filename string) variantTag {
+	ext := filepath.Ext(filename)
+	tag := variantTag{stem: strings.TrimSuffix(filename, ext)}
+
+	for {
+		cut := strings.LastIndexAny(tag.stem, "-_. ")
+		if cut <= 0 {
+			return tag
+		}
+		token := strings.ToLower(tag.stem[cut+1:])
+		switch {
+		case tag.resolution == "" && resolutionRegex.MatchString(token):
+			tag.resolution = token
+		case tag.language == "" && variantLanguages[token]:
+			tag.language = token
+		default:
+			return tag
+		}
+		tag.stem = tag.stem[:cut]
+	}
+}
+
+// resolutionHeight turns a resolution tag into a comparable number of lines
+func resolutionHeight(resolution string) int {
+	switch resolution {
+	case "2k":
+		return 1440
+	case "4k":
+		return 2160
+	case "8k":
+		return 4320
+	}
+	height, _ := strconv.Atoi(strings.TrimSuffix(resolution, "p"))
+	return height
+}
+
+// groupVariants merges files in the same folder that are the same lesson in different
+// resolutions or languages into one item. The highest resolution becomes the primary file
+// the item points at; every file, primary included, is listed in Variants.
+func groupVariants(items []*models.ContentItem) []*models.ContentItem {
+	type group struct {
+		items  []*models.ContentItem
+		tags   []variantTag
+		tagged bool // at least one file carried a tag, otherwise these aren't variants
+	}
+
+	groups := make(map[string]*group)
+	var keys []string
+	keyOf := make(map[*models.ContentItem]string)
+
+	for _, item := range items {
+		tag := parseVariantTag(filepath.Base(item.RelativePath))
+		key := filepath.Dir(item.RelativePath) + "\x00" + strings.ToLower(tag.stem) + "\x00" + item.ContentType
+		g, ok := groups[key]
+		if !ok {
+			g = &group{}
+			groups[key] = g
+			keys = append(keys, key)
+		}
+		g.items = append(g.items, item)
+		g.tags = append(g.tags, tag)
+		g.tagged = g.tagged || tag.resolution != "" || tag.language != ""
+		keyOf[item] = key
+	}
+
+	grouped := make([]*models.ContentItem, 0, len(items))
+	done := make(map[string]bool)
+	for _, item := range items {
+		key := keyOf[item]
+		g := groups[key]
+		if len(g.items) < 2 || !g.tagged {
+			grouped = append(grouped, item)
+			continue
+		}
+		if done[key] {
+			continue
+		}
+		done[key] = true
+		grouped = append(grouped, mergeVariants(g.items, g.tags))
+	}
+	return grouped
+}
+
+// mergeVariants builds the single item that stands for a group of variant files
+func mergeVariants(items []*models.ContentItem, tags []variantTag) *models.ContentItem {
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+	// highest resolution first, untagged language (the original) before translations
+	sort.SliceStable(order, func(a, b int) bool {
+		ta, tb := tags[order[a]], tags[order[b]]
+		if ha, hb := resolutionHeight(ta.resolution), resolutionHeight(tb.resolution); ha != hb {
+			return ha > hb
+		}
+		if (ta.language == "") != (tb.language == "") {
+			return ta.language == ""
+		}
+		return ta.language < tb.language
+	})
+
+	primary := items[order[0]]
+	ext := filepath.Ext(primary.RelativePath)
+	merged := &models.ContentItem{
+		ID:           primary.ID,
+		Title:        tags[order[0]].stem + ext,
+		Description:  primary.Description,
+		RelativePath: primary.RelativePath,
+		ContentType:  primary.ContentType,
+		Duration:     primary.Duration,
+		PageCount:    primary.PageCount,
+		Chapters:     primary.Chapters,
+		Size:         primary.Size,
+		Order:        items[0].Order,
+	}
+
+	for i, idx := range order {
+		tag := tags[idx]
+		label := strings.TrimSpace(tag.language + " " + tag.resolution)
+		if label == "" {
+			label = "original"
+		}
+		merged.Variants = append(merged.Variants, models.ContentVariant{
+			ID:           uuid.New(),
+			Label:        label,
+			Resolution:   tag.resolution,
+			Language:     tag.language,
+			RelativePath: items[idx].RelativePath,
+			Size:         items[idx].Size,
+			Primary:      i == 0,
+		})
+	}
+	return merged
+}
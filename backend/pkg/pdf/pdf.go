@@ -0,0 +1,90 @@
+package pdf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/pkg/metrics"
+)
+
+// ErrToolMissing is returned when poppler's pdftoppm isn't installed
+var ErrToolMissing = errors.New("pdftoppm is not installed")
+
+// how long poppler may take for one file
+const toolTimeout = 30 * time.Second
+
+// files larger than this aren't scanned in Go when pdfinfo isn't around
+const maxScanBytes = 64 << 20
+
+var (
+	pdfinfoPages = regexp.MustCompile(`(?m)^Pages:\s+(\d+)`)
+	pageObject   = regexp.MustCompile(`/Type\s*/Page\b`) // \b keeps /Pages out
+)
+
+// PageCount returns how many pages a PDF has, 0 if it can't tell. pdfinfo is asked first;
+// without it the file is scanned for page objects, which works unless they're compressed.
+func PageCount(ctx context.Context, path string) (int, error) {
+	if _, err := exec.LookPath("pdfinfo"); err == nil {
+		ctx, cancel := context.WithTimeout(ctx, toolTimeout)
+		defer cancel()
+
+		output, err := exec.CommandContext(ctx, "pdfinfo", path).Output()
+		if err != nil {
+			return 0, fmt.Errorf("pdfinfo failed: %w", err)
+		}
+		if m := pdfinfoPages.FindSubmatch(output); m != nil {
+			return strconv.Atoi(string(m[1]))
+		}
+		return 0, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+	if info.Size() > maxScanBytes {
+		return 0, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return len(pageObject.FindAllIndex(data, -1)), nil
+}
+
+// RenderPage writes page (1-based) of a PDF to dest as a JPEG width pixels wide.
+// The file appears at dest only once it's complete.
+func RenderPage(ctx context.Context, src string, page, width int, dest string) error {
+	if _, err := exec.LookPath("pdftoppm"); err != nil {
+		return ErrToolMissing
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return fmt.Errorf("error creating page folder: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, toolTimeout)
+	defer cancel()
+
+	// pdftoppm adds the extension itself
+	tmp := strings.TrimSuffix(dest, filepath.Ext(dest)) + ".tmp"
+	n := strconv.Itoa(page)
+	output, err := exec.CommandContext(ctx, "pdftoppm", "-jpeg", "-f", n, "-l", n, "-singlefile",
+		"-scale-to-x", strconv.Itoa(width), "-scale-to-y", "-1", src, tmp).CombinedOutput()
+	if err != nil {
+		os.Remove(tmp + ".jpg")
+		if !errors.Is(ctx.Err(), context.Canceled) {
+			metrics.IncCounter(metrics.FailedTranscodes, "pdftoppm")
+		}
+		return fmt.Errorf("pdftoppm failed: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	return os.Rename(tmp+".jpg", dest)
+}
@@ -0,0 +1,126 @@
+package rewards
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/NeroQue/course-management-backend/pkg/levels"
+)
+
+// Rules are the amounts the gamification features pay out and charge, so deployments can
+// tune the reward curve without code changes
+type Rules struct {
+	XP      XPRules      `json:"xp"`
+	Gems    GemRules     `json:"gems"`
+	Streaks StreakRules  `json:"streaks"`
+	Quests  QuestRules   `json:"quests"`
+	Levels  levels.Curve `json:"levels"` // total XP per level past the first
+}
+
+// XPRules are the experience points for finishing things. Each award also scales with the
+// estimated study time of what was finished.
+type XPRules struct {
+	Content        int `json:"content"`          // for finishing any item
+	Module         int `json:"module"`           // for finishing a whole module
+	Course         int `json:"course"`           // for finishing a whole course
+	PerStudyMinute int `json:"per_study_minute"` // on top of the above
+}
+
+// GemRules are the gems earned for goals and levels and what the shop charges
+type GemRules struct {
+	GoalDay    int `json:"goal_day"`    // for each day a daily goal is met
+	CourseGoal int `json:"course_goal"` // for meeting a course completion goal
+	LevelUp    int `json:"level_up"`    // for reaching a new level
+	ThemeCost  int `json:"theme_cost"`  // per profile theme
+}
+
+// StreakRules are the limits of streak freezes and repairs
+type StreakRules struct {
+	FreezeCost       int `json:"freeze_cost"`        // gems per freeze
+	MaxFreezes       int `json:"max_freezes"`        // freezes a profile can hold at once
+	RepairCost       int `json:"repair_cost"`        // gems per missed day bought back
+	RepairWindowDays int `json:"repair_window_days"` // how long after it broke a streak can be repaired, 0 for never
+}
+
+// QuestRules are how many weekly quests a profile gets and what finishing one pays
+type QuestRules struct {
+	PerWeek int `json:"per_week"`
+	XP      int `json:"xp"`
+	Gems    int `json:"gems"`
+}
+
+// Default returns the built-in rules. The level curve comes from LEVEL_THRESHOLDS when set.
+func Default() *Rules {
+	return &Rules{
+		XP:      XPRules{Content: 10, Module: 50, Course: 200, PerStudyMinute: 1},
+		Gems:    GemRules{GoalDay: 5, CourseGoal: 25, LevelUp: 10, ThemeCost: 100},
+		Streaks: StreakRules{FreezeCost: 50, MaxFreezes: 2, RepairCost: 30, RepairWindowDays: 3},
+		Quests:  QuestRules{PerWeek: 3, XP: 100, Gems: 15},
+		Levels:  levels.LoadCurve(),
+	}
+}
+
+// Load reads the rules file, GAMIFICATION_RULES_FILE. Anything the file leaves out keeps
+// its default; an empty path means the defaults. Unknown keys are an error so a typo
+// doesn't quietly leave a default in place.
+func Load(path string) (*Rules, error) {
+	rules := Default()
+	if path == "" {
+		return rules, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading gamification rules file: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(rules); err != nil {
+		return nil, fmt.Errorf("error parsing gamification rules file %s: %w", path, err)
+	}
+	if err := rules.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid gamification rules file %s: %w", path, err)
+	}
+	return rules, nil
+}
+
+// Validate checks no amount is negative, things cost something and the level curve ascends
+func (r *Rules) Validate() error {
+	amounts := []struct {
+		name   string
+		amount int
+	}{
+		{"xp.content", r.XP.Content},
+		{"xp.module", r.XP.Module},
+		{"xp.course", r.XP.Course},
+		{"xp.per_study_minute", r.XP.PerStudyMinute},
+		{"gems.goal_day", r.Gems.GoalDay},
+		{"gems.course_goal", r.Gems.CourseGoal},
+		{"gems.level_up", r.Gems.LevelUp},
+		{"streaks.max_freezes", r.Streaks.MaxFreezes},
+		{"streaks.repair_window_days", r.Streaks.RepairWindowDays},
+		{"quests.per_week", r.Quests.PerWeek},
+		{"quests.xp", r.Quests.XP},
+		{"quests.gems", r.Quests.Gems},
+	}
+	for _, rule := range amounts {
+		if rule.amount < 0 {
+			return fmt.Errorf("%s must not be negative", rule.name)
+		}
+	}
+
+	if r.Gems.ThemeCost <= 0 {
+		return errors.New("gems.theme_cost must be positive")
+	}
+	if r.Streaks.FreezeCost <= 0 {
+		return errors.New("streaks.freeze_cost must be positive")
+	}
+	if r.Streaks.RepairCost <= 0 {
+		return errors.New("streaks.repair_cost must be positive")
+	}
+	return r.Levels.Validate()
+}
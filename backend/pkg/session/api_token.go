@@ -0,0 +1,78 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/pkg/secret"
+	"github.com/google/uuid"
+)
+
+// APITokenPrefix starts every API token, which tells them apart from session tokens
+const APITokenPrefix = "cms_"
+
+// how often the last use of a token gets written down
+const apiTokenUseInterval = time.Minute
+
+// APIToken is the long-lived token a script made its request with instead of a session
+type APIToken struct {
+	ID        uuid.UUID
+	ProfileID uuid.UUID
+	Scope     string // the highest role requests made with it get
+}
+
+// IsAPIToken reports whether a token from a request is an API token
+func IsAPIToken(token string) bool {
+	return strings.HasPrefix(token, APITokenPrefix)
+}
+
+// ResolveAPIToken looks up an API token, nil when it's unknown, expired or revoked
+func ResolveAPIToken(ctx context.Context, token string) (*APIToken, error) {
+	if store == nil || store.DB == nil || !IsAPIToken(token) {
+		return nil, nil
+	}
+
+	row, err := store.DB.GetAPITokenByHash(ctx, secret.HashToken(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error retrieving API token: %w", err)
+	}
+	now := time.Now()
+	if row.RevokedAt.Valid || (row.ExpiresAt.Valid && !now.Before(row.ExpiresAt.Time)) {
+		return nil, nil
+	}
+
+	// scripts can poll, don't write on every request
+	if !row.LastUsedAt.Valid || now.Sub(row.LastUsedAt.Time) > apiTokenUseInterval {
+		if err := store.DB.RecordAPITokenUse(ctx, row.ID); err != nil {
+			log.Printf("Error recording use of API token %s: %v", row.ID, err)
+		}
+	}
+
+	return &APIToken{ID: row.ID, ProfileID: row.ProfileID, Scope: row.Scope}, nil
+}
+
+// WithAPIToken returns ctx for a request made with an API token. It acts as the token's
+// profile without a session, so there's nothing to log out of or impersonate with.
+func WithAPIToken(ctx context.Context, token *APIToken) context.Context {
+	return context.WithValue(ctx, contextKey{}, &current{token: token})
+}
+
+// CurrentAPIToken returns the API token the request was made with, nil for sessions
+func CurrentAPIToken(ctx context.Context) *APIToken {
+	c := fromContext(ctx)
+	if c == nil {
+		return nil
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.token
+}
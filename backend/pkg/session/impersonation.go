@@ -0,0 +1,103 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/google/uuid"
+)
+
+// ErrNoSession is returned when impersonation is changed on a request without a session
+var ErrNoSession = errors.New("no active session")
+
+// Impersonation is an admin acting as another profile until ExpiresAt
+type Impersonation struct {
+	AdminID   uuid.UUID // the profile that actually logged in
+	ProfileID uuid.UUID // the profile requests are made as
+	ExpiresAt time.Time
+}
+
+// Expired reports whether the time box has run out
+func (i *Impersonation) Expired() bool {
+	return !time.Now().Before(i.ExpiresAt)
+}
+
+// impersonationOf reads the impersonation off a session row, nil if there is none
+func impersonationOf(session *database.Session) *Impersonation {
+	if session == nil || !session.ImpersonatedID.Valid || !session.ImpersonationExpiresAt.Valid {
+		return nil
+	}
+	return &Impersonation{
+		AdminID:   session.UserID,
+		ProfileID: session.ImpersonatedID.UUID,
+		ExpiresAt: session.ImpersonationExpiresAt.Time,
+	}
+}
+
+// CurrentImpersonation returns the request's running impersonation, nil if there is none or it expired
+func CurrentImpersonation(ctx context.Context) *Impersonation {
+	impersonation := impersonationOf(sessionOf(ctx))
+	if impersonation == nil || impersonation.Expired() {
+		return nil
+	}
+	return impersonation
+}
+
+// GetRealUser returns the profile that logged in, even while it impersonates someone
+func GetRealUser(ctx context.Context) uuid.UUID {
+	session := sessionOf(ctx)
+	if session == nil {
+		if token := CurrentAPIToken(ctx); token != nil {
+			return token.ProfileID
+		}
+		return uuid.Nil
+	}
+	return session.UserID
+}
+
+// StartImpersonation makes the request's session act as profileID until expiresAt.
+// Checking that the caller is allowed to is up to the caller.
+func StartImpersonation(ctx context.Context, profileID uuid.UUID, expiresAt time.Time) error {
+	return setImpersonation(ctx, uuid.NullUUID{UUID: profileID, Valid: true}, sql.NullTime{Time: expiresAt, Valid: true})
+}
+
+// StopImpersonation ends impersonation on the request's session and returns what was
+// running, expired or not. Returns nil if the session wasn't impersonating anyone.
+func StopImpersonation(ctx context.Context) (*Impersonation, error) {
+	session := sessionOf(ctx)
+	if session == nil {
+		return nil, ErrNoSession
+	}
+
+	stopped := impersonationOf(session)
+	if stopped == nil {
+		return nil, nil
+	}
+	if err := setImpersonation(ctx, uuid.NullUUID{}, sql.NullTime{}); err != nil {
+		return nil, err
+	}
+	return stopped, nil
+}
+
+// setImpersonation saves the impersonation columns and refreshes the request's session
+func setImpersonation(ctx context.Context, profileID uuid.NullUUID, expiresAt sql.NullTime) error {
+	session := sessionOf(ctx)
+	if store == nil || store.DB == nil || session == nil {
+		return ErrNoSession
+	}
+
+	updated, err := store.DB.SetSessionImpersonation(ctx, database.SetSessionImpersonationParams{
+		ID:                     session.ID,
+		ImpersonatedID:         profileID,
+		ImpersonationExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return fmt.Errorf("error saving impersonation: %w", err)
+	}
+	setSession(ctx, &updated)
+	return nil
+}
@@ -2,142 +2,295 @@ package session
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"log"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/pkg/jwt"
 	"github.com/google/uuid"
 )
 
-// SessionStore manages user sessions - kinda like a simple auth system
+// SessionStore manages user sessions - kinda like a simple auth system.
+// Every client that selects a profile gets its own session and a signed token (a JWT)
+// naming it, which it sends back with each request (see TokenFromRequest). The token
+// is checked before the database is asked about the session. Sessions last the token
+// signer's TTL and slide along while they're used, see Refresh.
 type SessionStore struct {
-	DB             *database.Queries
-	mu             sync.RWMutex      // for thread safety
-	currentSession *database.Session // cache current user
+	DB     *database.Queries
+	Tokens *jwt.Signer // issues and checks session tokens
 }
 
-// global session store - not ideal but works for now
+// how often a session's last use gets written down
+const sessionSeenInterval = time.Minute
+
+// longest User-Agent kept to describe the device a session was started from
+const maxDeviceLength = 256
+
+// global session store, set up once at startup
 var store *SessionStore
 
-// Initialize sets up the session store with database
-func Initialize(db *database.Queries) {
-	store = &SessionStore{
-		DB:             db,
-		currentSession: nil,
+// Initialize sets up the session store with database and token signer
+func Initialize(db *database.Queries, tokens *jwt.Signer) {
+	store = &SessionStore{DB: db, Tokens: tokens}
+}
+
+// current is the session of the request being served. It's a pointer in the context so
+// that logging in or starting impersonation shows up for the rest of the request.
+type current struct {
+	mu      sync.RWMutex
+	session *database.Session
+	token   *APIToken // set instead of the session for requests made with an API token
+	device  string    // the client's User-Agent, kept with sessions it starts
+}
+
+type contextKey struct{}
+
+// WithSession returns ctx carrying the request's session, nil for an anonymous request.
+// device describes the client, it's shown in the list of a profile's sessions.
+func WithSession(ctx context.Context, session *database.Session, device string) context.Context {
+	return context.WithValue(ctx, contextKey{}, &current{session: session, device: device})
+}
+
+// fromContext returns the request's session holder, nil outside a request
+func fromContext(ctx context.Context) *current {
+	c, _ := ctx.Value(contextKey{}).(*current)
+	return c
+}
+
+// sessionOf returns the request's session, nil if nobody is logged in
+func sessionOf(ctx context.Context) *database.Session {
+	c := fromContext(ctx)
+	if c == nil {
+		return nil
 	}
 
-	// try to load any existing session on startup
-	go loadActiveSession()
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.session
 }
 
-// loadActiveSession tries to restore the last active session
-func loadActiveSession() {
-	if store == nil || store.DB == nil {
-		log.Println("Warning: Cannot load active session, session store not initialized")
-		return
+// Resolve checks a token and looks up the session it names. A forged, malformed or
+// expired token is an error wrapping jwt.ErrInvalidToken or jwt.ErrExpiredToken, and so is
+// one for a session that expired; a valid one for a session that was ended gives nil.
+func Resolve(ctx context.Context, token string) (*database.Session, error) {
+	if store == nil || store.DB == nil || store.Tokens == nil || token == "" {
+		return nil, nil
 	}
 
-	session, err := store.DB.GetActiveSession(context.Background())
+	claims, err := store.Tokens.Verify(token, time.Now())
 	if err != nil {
-		// no big deal if there's no active session
-		return
+		return nil, err
+	}
+	sessionID, err := uuid.Parse(claims.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: bad session ID", jwt.ErrInvalidToken)
 	}
 
-	store.mu.Lock()
-	store.currentSession = &session
-	store.mu.Unlock()
+	session, err := store.DB.GetSessionByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error retrieving session: %w", err)
+	}
+	if session.UserID.String() != claims.Subject {
+		return nil, fmt.Errorf("%w: session belongs to someone else", jwt.ErrInvalidToken)
+	}
+	now := time.Now()
+	if !now.Before(session.ExpiresAt) {
+		return nil, fmt.Errorf("%w: session ended at %s", jwt.ErrExpiredToken, session.ExpiresAt.Format(time.RFC3339))
+	}
+
+	// pages fire off plenty of requests, don't write on every one
+	if now.Sub(session.LastSeenAt) > sessionSeenInterval {
+		if err := store.DB.TouchSession(ctx, session.ID); err != nil {
+			log.Printf("Error recording use of session %s: %v", session.ID, err)
+		}
+	}
+	return &session, nil
 }
 
-// SetCurrentUser sets the currently logged in user
-func SetCurrentUser(userID uuid.UUID) {
-	if store == nil || store.DB == nil {
-		log.Println("Warning: Cannot set current user, session store not initialized")
-		return
+// Refresh keeps the request's session going while it's used: once less than half its
+// lifetime is left it gets a whole one again, along with a token that lasts as long. It
+// returns the new token, or "" when the session didn't need it or there's none.
+func Refresh(ctx context.Context) (string, error) {
+	current := sessionOf(ctx)
+	if current == nil || store == nil || store.DB == nil || store.Tokens == nil {
+		return "", nil
 	}
 
-	// Delete any existing sessions first
-	// This ensures we only have one active session
-	ClearAllSessions()
+	now := time.Now()
+	if !needsRefresh(current.ExpiresAt, now, store.Tokens.TTL) {
+		return "", nil
+	}
 
-	// Create a new session in the database
-	sessionID := uuid.New()
-	session, err := store.DB.CreateSession(context.Background(), database.CreateSessionParams{
-		ID:     sessionID,
-		UserID: userID,
+	refreshed, err := store.DB.RefreshSession(ctx, database.RefreshSessionParams{
+		ID:        current.ID,
+		ExpiresAt: now.Add(store.Tokens.TTL),
 	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil // ended while this request was on its way
+		}
+		return "", fmt.Errorf("error refreshing session: %w", err)
+	}
+	token, err := store.Tokens.Sign(refreshed.UserID.String(), refreshed.ID.String(), now)
+	if err != nil {
+		return "", err
+	}
+
+	setSession(ctx, &refreshed)
+	return token, nil
+}
+
+// needsRefresh reports whether a session that ends at expiresAt has used up half its ttl
+func needsRefresh(expiresAt, now time.Time, ttl time.Duration) bool {
+	return expiresAt.Sub(now) <= ttl/2
+}
 
+// SetCurrentUser logs the requesting client in as userID with a new session and returns
+// its token. A session the client already had is ended, other clients' are left alone.
+func SetCurrentUser(ctx context.Context, userID uuid.UUID) (string, error) {
+	if store == nil || store.DB == nil || store.Tokens == nil {
+		return "", errors.New("session store not initialized")
+	}
+
+	// switching profiles replaces this client's old session
+	ClearCurrentUser(ctx)
+
+	device := ""
+	if c := fromContext(ctx); c != nil {
+		device = deviceName(c.device)
+	}
+
+	now := time.Now()
+	session, err := store.DB.CreateSession(ctx, database.CreateSessionParams{
+		ID:        uuid.New(),
+		UserID:    userID,
+		ExpiresAt: now.Add(store.Tokens.TTL),
+		UserAgent: device,
+	})
 	if err != nil {
-		log.Printf("Error creating session: %v", err)
-		return
+		return "", fmt.Errorf("error creating session: %w", err)
+	}
+	token, err := store.Tokens.Sign(userID.String(), session.ID.String(), now)
+	if err != nil {
+		return "", err
 	}
 
-	// Cache the new session
-	store.mu.Lock()
-	store.currentSession = &session
-	store.mu.Unlock()
+	setSession(ctx, &session)
+	return token, nil
 }
 
-// GetCurrentUser retrieves the currently logged in user ID
-func GetCurrentUser() uuid.UUID {
-	if store == nil {
+// deviceName cuts a User-Agent down to what's kept with a session, without splitting a character
+func deviceName(userAgent string) string {
+	if len(userAgent) <= maxDeviceLength {
+		return userAgent
+	}
+	return strings.ToValidUTF8(userAgent[:maxDeviceLength], "")
+}
+
+// GetCurrentUser retrieves the user ID the request is made as.
+// While an admin impersonates someone this is the impersonated profile, see GetRealUser.
+func GetCurrentUser(ctx context.Context) uuid.UUID {
+	session := sessionOf(ctx)
+	if session == nil {
+		if token := CurrentAPIToken(ctx); token != nil {
+			return token.ProfileID
+		}
 		return uuid.Nil
 	}
 
-	store.mu.RLock()
-	defer store.mu.RUnlock()
+	if impersonation := impersonationOf(session); impersonation != nil && !impersonation.Expired() {
+		return impersonation.ProfileID
+	}
 
-	if store.currentSession == nil {
+	return session.UserID
+}
+
+// CurrentSessionID returns the ID of the request's session, uuid.Nil when there's none
+func CurrentSessionID(ctx context.Context) uuid.UUID {
+	session := sessionOf(ctx)
+	if session == nil {
 		return uuid.Nil
 	}
-
-	return store.currentSession.UserID
+	return session.ID
 }
 
-// IsLoggedIn checks if any user is currently logged in
-func IsLoggedIn() bool {
-	return GetCurrentUser() != uuid.Nil
+// IsLoggedIn checks if the request comes from a logged in user
+func IsLoggedIn(ctx context.Context) bool {
+	return GetCurrentUser(ctx) != uuid.Nil
 }
 
-// ClearCurrentUser clears the current user session
-func ClearCurrentUser() {
-	if store == nil || store.DB == nil {
+// ClearCurrentUser ends the requesting client's session
+func ClearCurrentUser(ctx context.Context) {
+	session := sessionOf(ctx)
+	if session == nil || store == nil || store.DB == nil {
 		return
 	}
 
-	store.mu.RLock()
-	session := store.currentSession
-	store.mu.RUnlock()
-
-	if session != nil {
-		// Delete the session from the database
-		err := store.DB.DeleteSession(context.Background(), session.ID)
-		if err != nil {
-			log.Printf("Error deleting session: %v", err)
-		}
+	// Delete the session from the database
+	if err := store.DB.DeleteSession(ctx, session.ID); err != nil {
+		log.Printf("Error deleting session: %v", err)
 	}
 
-	// Clear the cached session
-	store.mu.Lock()
-	store.currentSession = nil
-	store.mu.Unlock()
+	setSession(ctx, nil)
 }
 
-// ClearAllSessions removes all sessions from the database
+// ClearAllSessions removes all sessions from the database, logging every client out.
 // Typically used for testing or when you need to force logout all users
 func ClearAllSessions() error {
 	if store == nil || store.DB == nil {
 		return nil
 	}
 
-	err := store.DB.DeleteAllSessions(context.Background())
+	return store.DB.DeleteAllSessions(context.Background())
+}
+
+// PruneExpired deletes the sessions that have expired and returns how many went
+func PruneExpired(ctx context.Context) (int64, error) {
+	if store == nil || store.DB == nil {
+		return 0, nil
+	}
+
+	pruned, err := store.DB.DeleteExpiredSessions(ctx, time.Now())
 	if err != nil {
-		return err
+		return 0, fmt.Errorf("error pruning sessions: %w", err)
+	}
+	return pruned, nil
+}
+
+// PruneRoutine clears out expired sessions every interval. They can't be used any more,
+// but clients that never come back would otherwise leave theirs behind for good.
+func PruneRoutine(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		pruned, err := PruneExpired(context.Background())
+		if err != nil {
+			log.Printf("Error pruning expired sessions: %v", err)
+			continue
+		}
+		if pruned > 0 {
+			log.Printf("Pruned %d expired sessions", pruned)
+		}
 	}
+}
 
-	// Clear the cached session
-	store.mu.Lock()
-	store.currentSession = nil
-	store.mu.Unlock()
+// setSession swaps the request's session, for the rest of the request
+func setSession(ctx context.Context, session *database.Session) {
+	c := fromContext(ctx)
+	if c == nil {
+		return
+	}
 
-	return nil
+	c.mu.Lock()
+	c.session = session
+	c.mu.Unlock()
 }
@@ -0,0 +1,216 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/internal/database"
+	"github.com/NeroQue/course-management-backend/pkg/jwt"
+	"github.com/google/uuid"
+)
+
+func TestGetCurrentUser(t *testing.T) {
+	userID, otherID := uuid.New(), uuid.New()
+	impersonating := func(expiresAt time.Time) *database.Session {
+		return &database.Session{
+			ID:                     uuid.New(),
+			UserID:                 userID,
+			ImpersonatedID:         uuid.NullUUID{UUID: otherID, Valid: true},
+			ImpersonationExpiresAt: sql.NullTime{Time: expiresAt, Valid: true},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		current uuid.UUID
+		real    uuid.UUID
+	}{
+		{"outside a request", context.Background(), uuid.Nil, uuid.Nil},
+		{"anonymous", WithSession(context.Background(), nil, ""), uuid.Nil, uuid.Nil},
+		{"session", WithSession(context.Background(), &database.Session{ID: uuid.New(), UserID: userID}, ""), userID, userID},
+		{"impersonating", WithSession(context.Background(), impersonating(time.Now().Add(time.Hour)), ""), otherID, userID},
+		{"impersonation expired", WithSession(context.Background(), impersonating(time.Now().Add(-time.Second)), ""), userID, userID},
+		{"api token", WithAPIToken(context.Background(), &APIToken{ID: uuid.New(), ProfileID: otherID}), otherID, otherID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetCurrentUser(tt.ctx); got != tt.current {
+				t.Errorf("GetCurrentUser = %s, want %s", got, tt.current)
+			}
+			if got := GetRealUser(tt.ctx); got != tt.real {
+				t.Errorf("GetRealUser = %s, want %s", got, tt.real)
+			}
+			if got := IsLoggedIn(tt.ctx); got != (tt.current != uuid.Nil) {
+				t.Errorf("IsLoggedIn = %v", got)
+			}
+		})
+	}
+}
+
+func TestSetSessionLastsForTheRequest(t *testing.T) {
+	ctx := WithSession(context.Background(), nil, "")
+	userID := uuid.New()
+
+	setSession(ctx, &database.Session{ID: uuid.New(), UserID: userID})
+	if got := GetCurrentUser(ctx); got != userID {
+		t.Fatalf("after logging in got %s, want %s", got, userID)
+	}
+
+	setSession(ctx, nil)
+	if got := GetCurrentUser(ctx); got != uuid.Nil {
+		t.Fatalf("after logging out got %s", got)
+	}
+}
+
+func TestTokenFromRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		cookie string
+		want   string
+	}{
+		{"nothing", "", "", ""},
+		{"bearer header", "Bearer abc", "", "abc"},
+		{"bearer header with spaces", "Bearer  abc ", "", "abc"},
+		{"cookie", "", "from-cookie", "from-cookie"},
+		{"header beats cookie", "Bearer abc", "from-cookie", "abc"},
+		{"other scheme falls back to the cookie", "Basic dXNlcjpwYXNz", "from-cookie", "from-cookie"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/api/profiles", nil)
+			if tt.header != "" {
+				r.Header.Set("Authorization", tt.header)
+			}
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: CookieName, Value: tt.cookie})
+			}
+			if got := TokenFromRequest(r); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveRefusesBadTokens(t *testing.T) {
+	signer, err := jwt.NewSigner([]byte("session-test-key-session-test-key"), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	previous := store
+	t.Cleanup(func() { store = previous })
+	// every case fails before the database is asked, there is none behind it
+	store = &SessionStore{DB: database.New(nil), Tokens: signer}
+
+	userID := uuid.New().String()
+	other, err := jwt.NewSigner([]byte("another-key-another-key-another-k"), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged, _ := other.Sign(userID, uuid.New().String(), time.Now())
+	expired, _ := signer.Sign(userID, uuid.New().String(), time.Now().Add(-2*time.Hour))
+	badSession, _ := signer.Sign(userID, "not-a-session", time.Now())
+
+	tests := []struct {
+		name  string
+		token string
+		want  error // nil for no session and no error
+	}{
+		{"no token", "", nil},
+		{"expired", expired, jwt.ErrExpiredToken},
+		{"signed with another key", forged, jwt.ErrInvalidToken},
+		{"not a token", "abc", jwt.ErrInvalidToken},
+		{"session ID that isn't one", badSession, jwt.ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session, err := Resolve(context.Background(), tt.token)
+			if session != nil {
+				t.Fatalf("got session %+v", session)
+			}
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("got error %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("got error %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNeedsRefresh(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	ttl := 24 * time.Hour
+
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		want      bool
+	}{
+		{"just started", now.Add(ttl), false},
+		{"more than half left", now.Add(ttl/2 + time.Second), false},
+		{"exactly half left", now.Add(ttl / 2), true},
+		{"less than half left", now.Add(time.Hour), true},
+		{"already over", now.Add(-time.Minute), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := needsRefresh(tt.expiresAt, now, ttl); got != tt.want {
+				t.Errorf("needsRefresh = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeviceName(t *testing.T) {
+	long := strings.Repeat("a", maxDeviceLength+10)
+	split := strings.Repeat("a", maxDeviceLength-1) + "é" // é is two bytes, the cut falls inside it
+
+	tests := []struct {
+		name      string
+		userAgent string
+		want      string
+	}{
+		{"empty", "", ""},
+		{"short", "Mozilla/5.0", "Mozilla/5.0"},
+		{"exactly the limit", long[:maxDeviceLength], long[:maxDeviceLength]},
+		{"too long", long, long[:maxDeviceLength]},
+		{"cut inside a character", split, split[:maxDeviceLength-1]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := deviceName(tt.userAgent); got != tt.want {
+				t.Errorf("deviceName = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCurrentSessionID(t *testing.T) {
+	session := &database.Session{ID: uuid.New(), UserID: uuid.New()}
+
+	if got := CurrentSessionID(WithSession(context.Background(), session, "")); got != session.ID {
+		t.Errorf("got %s, want %s", got, session.ID)
+	}
+	if got := CurrentSessionID(WithSession(context.Background(), nil, "")); got != uuid.Nil {
+		t.Errorf("anonymous request has session %s", got)
+	}
+	if got := CurrentSessionID(WithAPIToken(context.Background(), &APIToken{ProfileID: uuid.New()})); got != uuid.Nil {
+		t.Errorf("API token request has session %s", got)
+	}
+}
@@ -0,0 +1,53 @@
+package session
+
+import (
+	"net/http"
+	"strings"
+)
+
+// CookieName is the cookie browsers get their session token in
+const CookieName = "cms_session"
+
+// RefreshHeader carries the new token when a request refreshed its session. Browsers get
+// it as a cookie as well, other clients should send it from then on.
+const RefreshHeader = "X-Session-Token"
+
+// TokenFromRequest returns the session token a request carries: an Authorization
+// Bearer header for API clients, or the session cookie for browsers
+func TokenFromRequest(r *http.Request) string {
+	if header := r.Header.Get("Authorization"); header != "" {
+		if token, ok := strings.CutPrefix(header, "Bearer "); ok {
+			return strings.TrimSpace(token)
+		}
+	}
+
+	if cookie, err := r.Cookie(CookieName); err == nil {
+		return cookie.Value
+	}
+	return ""
+}
+
+// SetCookie hands a browser its session token. Scripts can't read it.
+func SetCookie(w http.ResponseWriter, r *http.Request, token string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     CookieName,
+		Value:    token,
+		Path:     "/",
+		MaxAge:   int(store.Tokens.TTL.Seconds()), // no use keeping it past the token's expiry
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// ClearCookie removes the session cookie from the browser
+func ClearCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     CookieName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
@@ -0,0 +1,109 @@
+package studytime
+
+import (
+	"time"
+
+	"github.com/NeroQue/course-management-backend/pkg/util"
+)
+
+// Heuristics turn files into minutes of study. Videos have real durations
+// (once probed); everything else is guessed from file size.
+type Heuristics struct {
+	ReadingWPM        int           // words per minute for text and markdown
+	BytesPerWord      int           // rough size of a word in plain text, including the space
+	PDFBytesPerPage   int           // average PDF page size - scanned PDFs blow this up, so it's capped
+	PDFMinutesPerPage time.Duration // time to actually read a page
+	PDFMaxPages       int           // cap so a 200MB scanned PDF doesn't look like a year of study
+	VideoFallback     time.Duration // for videos we couldn't get a duration for
+	OtherItem         time.Duration // slides, documents, images...
+	VideoOverhead     float64       // pausing, rewinding, taking notes - 1.2 means 20% extra
+}
+
+// DefaultHeuristics are tuned for typical course downloads
+func DefaultHeuristics() Heuristics {
+	return Heuristics{
+		ReadingWPM:        200,
+		BytesPerWord:      6,
+		PDFBytesPerPage:   60 * 1024,
+		PDFMinutesPerPage: 2 * time.Minute,
+		PDFMaxPages:       500,
+		VideoFallback:     10 * time.Minute,
+		OtherItem:         5 * time.Minute,
+		VideoOverhead:     1.2,
+	}
+}
+
+// LoadHeuristics reads the heuristics from env vars, falling back to the defaults
+func LoadHeuristics() Heuristics {
+	defaults := DefaultHeuristics()
+	return Heuristics{
+		ReadingWPM:        util.GetEnvInt("STUDY_READING_WPM", defaults.ReadingWPM),
+		BytesPerWord:      defaults.BytesPerWord,
+		PDFBytesPerPage:   util.GetEnvInt("STUDY_PDF_BYTES_PER_PAGE", defaults.PDFBytesPerPage),
+		PDFMinutesPerPage: util.GetEnvDuration("STUDY_PDF_TIME_PER_PAGE", defaults.PDFMinutesPerPage),
+		PDFMaxPages:       util.GetEnvInt("STUDY_PDF_MAX_PAGES", defaults.PDFMaxPages),
+		VideoFallback:     util.GetEnvDuration("STUDY_VIDEO_FALLBACK", defaults.VideoFallback),
+		OtherItem:         util.GetEnvDuration("STUDY_OTHER_ITEM_TIME", defaults.OtherItem),
+		VideoOverhead:     defaults.VideoOverhead,
+	}
+}
+
+// Estimate is how long one item should take, and whether we had to guess
+type Estimate struct {
+	Duration time.Duration
+	Guessed  bool // true when no real duration was known
+}
+
+// Item estimates study time for one content item
+func (h Heuristics) Item(contentType string, size int64, durationSeconds int) Estimate {
+	switch contentType {
+	case "video":
+		if durationSeconds > 0 {
+			watch := time.Duration(durationSeconds) * time.Second
+			return Estimate{Duration: time.Duration(float64(watch) * h.VideoOverhead)}
+		}
+		return Estimate{Duration: h.VideoFallback, Guessed: true}
+
+	case "text":
+		if h.ReadingWPM <= 0 || h.BytesPerWord <= 0 {
+			return Estimate{Duration: h.OtherItem, Guessed: true}
+		}
+		words := size / int64(h.BytesPerWord)
+		minutes := float64(words) / float64(h.ReadingWPM)
+		return Estimate{Duration: atLeastAMinute(time.Duration(minutes * float64(time.Minute))), Guessed: true}
+
+	case "pdf":
+		if h.PDFBytesPerPage <= 0 {
+			return Estimate{Duration: h.OtherItem, Guessed: true}
+		}
+		pages := int(size/int64(h.PDFBytesPerPage)) + 1
+		if h.PDFMaxPages > 0 && pages > h.PDFMaxPages {
+			pages = h.PDFMaxPages
+		}
+		return Estimate{Duration: time.Duration(pages) * h.PDFMinutesPerPage, Guessed: true}
+
+	default:
+		return Estimate{Duration: h.OtherItem, Guessed: true}
+	}
+}
+
+// FinishDate works out when `remaining` of study is done at `perWeek` starting from `start`.
+// Returns the zero time when perWeek is not positive.
+func FinishDate(start time.Time, remaining, perWeek time.Duration) time.Time {
+	if perWeek <= 0 {
+		return time.Time{}
+	}
+	if remaining <= 0 {
+		return start
+	}
+
+	days := float64(remaining) / float64(perWeek) * 7
+	return start.Add(time.Duration(days * float64(24*time.Hour)))
+}
+
+func atLeastAMinute(d time.Duration) time.Duration {
+	if d < time.Minute {
+		return time.Minute
+	}
+	return d
+}
@@ -0,0 +1,61 @@
+package subtitles
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// srtTiming matches an SRT cue timing line, which only differs from WebVTT in its decimal commas
+var srtTiming = regexp.MustCompile(`^\s*(\d+:\d{2}:\d{2})[,.](\d{3})\s*-->\s*(\d+:\d{2}:\d{2})[,.](\d{3})(.*)$`)
+
+// windows1252 covers the bytes 0x80-0x9f where Windows-1252 differs from Latin-1.
+// Zero entries are unassigned and fall back to Latin-1.
+var windows1252 = [32]rune{
+	'€', 0, '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', 0, 'Ž', 0,
+	0, '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', 0, 'ž', 'Ÿ',
+}
+
+// ToWebVTT converts a caption file to WebVTT. SRT gets a header and dotted timings,
+// WebVTT passes through with a header added if it was missing. Files that aren't
+// UTF-8 are read as Windows-1252, which is what most older SRT files are.
+func ToWebVTT(data []byte, format string) []byte {
+	text := decode(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+
+	if format == "vtt" {
+		if !strings.HasPrefix(text, "WEBVTT") {
+			text = "WEBVTT\n\n" + text
+		}
+		return []byte(text)
+	}
+
+	var out bytes.Buffer
+	out.WriteString("WEBVTT\n\n")
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		if m := srtTiming.FindStringSubmatch(line); m != nil {
+			line = m[1] + "." + m[2] + " --> " + m[3] + "." + m[4] + m[5]
+		}
+		out.WriteString(line + "\n")
+	}
+	return out.Bytes()
+}
+
+// decode returns UTF-8 text, converting from Windows-1252 when the bytes aren't valid UTF-8
+func decode(data []byte) string {
+	if utf8.Valid(data) {
+		return string(data)
+	}
+
+	var b strings.Builder
+	for _, c := range data {
+		if c >= 0x80 && c < 0xa0 && windows1252[c-0x80] != 0 {
+			b.WriteRune(windows1252[c-0x80])
+		} else {
+			b.WriteRune(rune(c))
+		}
+	}
+	return b.String()
+}
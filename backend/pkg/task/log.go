@@ -0,0 +1,62 @@
+package task
+
+import (
+	"fmt"
+	"time"
+)
+
+// maxLogEntries is how many lines a task keeps, older ones make room for new ones
+const maxLogEntries = 100
+
+// log levels
+const (
+	LogInfo    = "info"
+	LogWarning = "warning"
+	LogError   = "error"
+)
+
+// LogEntry is one line of a task's log
+type LogEntry struct {
+	Time    time.Time `json:"time"`
+	Level   string    `json:"level"` // info, warning or error
+	Message string    `json:"message"`
+}
+
+// Logf adds an info line to the task's log
+func Logf(taskID string, format string, args ...interface{}) {
+	appendLog(taskID, LogInfo, fmt.Sprintf(format, args...))
+}
+
+// Warnf adds a warning to the task's log, for something that went wrong without failing the task
+func Warnf(taskID string, format string, args ...interface{}) {
+	appendLog(taskID, LogWarning, fmt.Sprintf(format, args...))
+}
+
+// Errorf adds an error to the task's log
+func Errorf(taskID string, format string, args ...interface{}) {
+	appendLog(taskID, LogError, fmt.Sprintf(format, args...))
+}
+
+// appendLog adds a line to the task's log, dropping the oldest once it's full
+func appendLog(taskID, level, message string) {
+	if manager == nil {
+		return
+	}
+
+	manager.mu.Lock()
+	defer manager.mu.Unlock()
+
+	task, exists := manager.tasks[taskID]
+	if !exists {
+		return
+	}
+	addLogEntry(task, level, message)
+}
+
+// addLogEntry adds a line to a task the caller holds the lock for
+func addLogEntry(task *Task, level, message string) {
+	if len(task.Logs) >= maxLogEntries {
+		task.Logs = append(task.Logs[:0], task.Logs[len(task.Logs)-maxLogEntries+1:]...)
+	}
+	task.Logs = append(task.Logs, LogEntry{Time: time.Now(), Level: level, Message: message})
+}
@@ -0,0 +1,59 @@
+package task
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestTaskLog(t *testing.T) {
+	Initialize(Config{Workers: 1})
+	taskID := CreateTask("test_log")
+
+	Logf(taskID, "copied %d files", 3)
+	Warnf(taskID, "skipped %s", "a.txt")
+	Errorf(taskID, "could not read %s", "b.txt")
+	SetTaskError(taskID, "gave up")
+	Logf("no-such-task", "goes nowhere")
+
+	task, _ := GetTask(taskID)
+	want := []LogEntry{
+		{Level: LogInfo, Message: "copied 3 files"},
+		{Level: LogWarning, Message: "skipped a.txt"},
+		{Level: LogError, Message: "could not read b.txt"},
+		{Level: LogError, Message: "Failed: gave up"},
+	}
+	if len(task.Logs) != len(want) {
+		t.Fatalf("got %d log lines, want %d: %+v", len(task.Logs), len(want), task.Logs)
+	}
+	for i, entry := range task.Logs {
+		if entry.Level != want[i].Level || entry.Message != want[i].Message {
+			t.Errorf("line %d is %s %q, want %s %q", i, entry.Level, entry.Message, want[i].Level, want[i].Message)
+		}
+		if entry.Time.IsZero() {
+			t.Errorf("line %d has no time", i)
+		}
+	}
+
+	// GetTask hands out a copy, changing it doesn't change the task
+	task.Logs[0].Message = "changed"
+	if again, _ := GetTask(taskID); again.Logs[0].Message != "copied 3 files" {
+		t.Error("changing a task from GetTask changed its log")
+	}
+}
+
+func TestTaskLogKeepsTheLatestLines(t *testing.T) {
+	Initialize(Config{Workers: 1})
+	taskID := CreateTask("test_log")
+
+	for i := range maxLogEntries + 25 {
+		Logf(taskID, "line %d", i)
+	}
+
+	task, _ := GetTask(taskID)
+	if len(task.Logs) != maxLogEntries {
+		t.Fatalf("kept %d lines, want %d", len(task.Logs), maxLogEntries)
+	}
+	if first, last := task.Logs[0].Message, task.Logs[maxLogEntries-1].Message; first != "line 25" || last != fmt.Sprintf("line %d", maxLogEntries+24) {
+		t.Errorf("kept %q to %q", first, last)
+	}
+}
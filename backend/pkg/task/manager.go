@@ -1,9 +1,14 @@
 package task
 
 import (
+	"context"
+	"encoding/json"
+	"log"
+	"slices"
 	"sync"
 	"time"
 
+	"github.com/NeroQue/course-management-backend/pkg/metrics"
 	"github.com/google/uuid"
 )
 
@@ -19,38 +24,61 @@ const (
 
 // Task represents a background job that might take a while
 type Task struct {
-	ID           string      `json:"id"`
-	Type         string      `json:"type"`                    // what kind of task
-	Status       Status      `json:"status"`                  // current state
-	Progress     float32     `json:"progress"`                // 0-100 percent done
-	CreatedAt    time.Time   `json:"created_at"`              // when it started
-	StartedAt    time.Time   `json:"started_at,omitempty"`    // when processing began
-	CompletedAt  time.Time   `json:"completed_at,omitempty"`  // when it finished
-	Message      string      `json:"message,omitempty"`       // status updates
-	ErrorMessage string      `json:"error_message,omitempty"` // what went wrong
-	Result       interface{} `json:"result,omitempty"`        // final results
+	ID           string          `json:"id"`
+	Type         string          `json:"type"`                    // what kind of task
+	Status       Status          `json:"status"`                  // current state
+	Progress     float32         `json:"progress"`                // 0-100 percent done
+	CreatedAt    time.Time       `json:"created_at"`              // when it started
+	StartedAt    time.Time       `json:"started_at,omitempty"`    // when processing began
+	CompletedAt  time.Time       `json:"completed_at,omitempty"`  // when it finished
+	Message      string          `json:"message,omitempty"`       // status updates
+	ErrorMessage string          `json:"error_message,omitempty"` // what went wrong
+	Result       interface{}     `json:"result,omitempty"`        // final results
+	Input        json.RawMessage `json:"-"`                       // what it was submitted with, a retry runs on it again
+	Retries      int             `json:"retries"`                 // times it was retried after failing
+	Logs         []LogEntry      `json:"logs,omitempty"`          // the latest lines its work logged, see Logf
+
+	submitted bool // run by Submit, so its work can be built again for a retry
 }
 
 // TaskManager keeps track of all running tasks
 type TaskManager struct {
 	tasks map[string]*Task
 	mu    sync.RWMutex // for thread safety
+	pool  *pool        // runs submitted work, see Submit
+
+	maxRetries int // how often a failed task may be retried
+
+	store Store      // keeps submitted tasks across restarts, nil keeps them in memory only
+	saves chan *Task // task copies waiting to be saved, see persist
 }
 
 // global task manager - another singleton but whatever
 var manager *TaskManager
 
-// Initialize sets up the task manager
-func Initialize() {
+// Initialize sets up the task manager and starts its workers
+func Initialize(config Config) {
 	manager = &TaskManager{
 		tasks: make(map[string]*Task),
+		pool:  newPool(config),
+
+		maxRetries: config.MaxRetries,
+		store:      config.Store,
+	}
+	if manager.maxRetries == 0 {
+		manager.maxRetries = DefaultMaxRetries
+	}
+	if manager.store != nil {
+		manager.saves = make(chan *Task, saveQueueSize)
+		go manager.saveLoop()
 	}
 }
 
-// CreateTask makes a new task and returns its ID
+// CreateTask makes a new task and returns its ID. Work for it runs wherever the caller
+// likes, Submit runs it on the task workers instead.
 func CreateTask(taskType string) string {
 	if manager == nil {
-		Initialize()
+		Initialize(Config{})
 	}
 
 	taskID := uuid.New().String()
@@ -69,7 +97,7 @@ func CreateTask(taskType string) string {
 	return taskID
 }
 
-// GetTask retrieves task info by ID
+// GetTask retrieves task info by ID. It's a copy, the task itself keeps changing while it runs.
 func GetTask(taskID string) (*Task, bool) {
 	if manager == nil {
 		return nil, false
@@ -79,7 +107,12 @@ func GetTask(taskID string) (*Task, bool) {
 	defer manager.mu.RUnlock()
 
 	task, exists := manager.tasks[taskID]
-	return task, exists
+	if !exists {
+		return nil, false
+	}
+	snapshot := *task
+	snapshot.Logs = slices.Clone(task.Logs)
+	return &snapshot, true
 }
 
 // UpdateTaskStatus changes the task status
@@ -96,6 +129,10 @@ func UpdateTaskStatus(taskID string, status Status) {
 		return
 	}
 
+	if status == StatusFailed && task.Status != StatusFailed {
+		metrics.IncCounter(metrics.TaskFailures, task.Type)
+	}
+
 	task.Status = status
 	if status == StatusProcessing && task.StartedAt.IsZero() {
 		task.StartedAt = time.Now()
@@ -103,6 +140,7 @@ func UpdateTaskStatus(taskID string, status Status) {
 	if status == StatusCompleted || status == StatusFailed {
 		task.CompletedAt = time.Now()
 	}
+	manager.persist(task)
 }
 
 // UpdateTaskProgress updates how much of the task is done
@@ -142,6 +180,11 @@ func SetTaskMessage(taskID string, message string) {
 
 // SetTaskError marks task as failed with error message
 func SetTaskError(taskID string, errorMessage string) {
+	failTask(taskID, errorMessage, nil)
+}
+
+// failTask marks task as failed, keeping whatever result it got to
+func failTask(taskID string, errorMessage string, result interface{}) {
 	if manager == nil {
 		return
 	}
@@ -154,9 +197,18 @@ func SetTaskError(taskID string, errorMessage string) {
 		return
 	}
 
+	if task.Status != StatusFailed {
+		metrics.IncCounter(metrics.TaskFailures, task.Type)
+	}
+
 	task.Status = StatusFailed
 	task.ErrorMessage = errorMessage
+	addLogEntry(task, LogError, "Failed: "+errorMessage)
+	if result != nil {
+		task.Result = result
+	}
 	task.CompletedAt = time.Now()
+	manager.persist(task)
 }
 
 // CompleteTask marks task as done with optional result data
@@ -177,6 +229,7 @@ func CompleteTask(taskID string, result interface{}) {
 	task.Progress = 100
 	task.Result = result
 	task.CompletedAt = time.Now()
+	manager.persist(task)
 }
 
 // CleanupOldTasks removes completed tasks older than the specified age
@@ -200,6 +253,12 @@ func CleanupOldTasks(maxAge time.Duration) int {
 		}
 	}
 
+	if manager.store != nil {
+		if err := manager.store.DeleteTasksBefore(context.Background(), cutoff); err != nil {
+			log.Printf("Error deleting old tasks: %v", err)
+		}
+	}
+
 	return cleaned
 }
 
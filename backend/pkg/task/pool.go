@@ -0,0 +1,213 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"slices"
+	"sync"
+	"time"
+)
+
+// defaults for what Config leaves out
+const (
+	DefaultWorkers    = 4 // tasks running at the same time
+	DefaultMaxRetries = 3 // times a failed task may be retried
+)
+
+// retry errors
+var (
+	ErrTaskNotFound = errors.New("task not found")
+	ErrNotRetryable = errors.New("task can't be retried")
+	ErrRetryLimit   = errors.New("task was retried too often")
+)
+
+// Work is what a task does. It can report progress on taskID while it runs; the result
+// it returns completes the task and an error fails it, keeping the result alongside.
+type Work func(ctx context.Context, taskID string) (interface{}, error)
+
+// Config sets how many tasks run at once
+type Config struct {
+	Workers int            // tasks running at the same time across all types, DefaultWorkers if 0
+	Limits  map[string]int // lower caps for single task types, like imports that are heavy on the disk
+
+	MaxRetries int // times a failed task may be retried, DefaultMaxRetries if 0 and none if negative
+
+	Store Store // keeps submitted tasks across restarts, see Restore. Memory only if nil.
+}
+
+// job is a submitted task waiting for a worker
+type job struct {
+	taskID   string
+	taskType string
+	work     Work
+}
+
+// pool runs submitted tasks on a fixed set of workers. Tasks start in the order they
+// were submitted, except that one whose type is at its limit lets the ones behind it go first.
+type pool struct {
+	mu      sync.Mutex
+	ready   *sync.Cond // signalled when a job is queued or a worker frees up a type's slot
+	queue   []job
+	limits  map[string]int
+	running map[string]int // tasks running per type
+}
+
+// newPool starts the workers
+func newPool(config Config) *pool {
+	workers := config.Workers
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+
+	p := &pool{
+		limits:  make(map[string]int),
+		running: make(map[string]int),
+	}
+	for taskType, limit := range config.Limits {
+		if limit > 0 {
+			p.limits[taskType] = limit
+		}
+	}
+	p.ready = sync.NewCond(&p.mu)
+
+	for range workers {
+		go p.worker()
+	}
+	return p
+}
+
+// Submit creates a task of a registered type and queues its work to run once a worker is
+// free, returning the task ID straight away. The task stays pending until then. The work
+// is built from input, see Register; a task that can't be built fails straight away.
+func Submit(taskType string, input interface{}) string {
+	taskID := CreateTask(taskType)
+
+	raw, err := encodeInput(input)
+	var work Work
+	if err == nil {
+		work, err = buildWork(taskType, raw)
+	}
+	if err != nil {
+		log.Printf("Error submitting %s task %s: %v", taskType, taskID, err)
+		failTask(taskID, err.Error(), nil)
+		return taskID
+	}
+
+	manager.mu.Lock()
+	task := manager.tasks[taskID]
+	task.Input = raw
+	task.Message = "Waiting for a free worker"
+	task.submitted = true
+	manager.persist(task)
+	manager.mu.Unlock()
+
+	manager.pool.enqueue(job{taskID: taskID, taskType: taskType, work: work})
+	return taskID
+}
+
+// Retry queues a failed task to run again, with its work built afresh from the input it
+// was submitted with. It keeps its ID, and starts over as pending with a higher retry count.
+func Retry(taskID string) (*Task, error) {
+	if manager == nil {
+		return nil, ErrTaskNotFound
+	}
+
+	manager.mu.Lock()
+	task, exists := manager.tasks[taskID]
+	if !exists {
+		manager.mu.Unlock()
+		return nil, ErrTaskNotFound
+	}
+	if task.Status != StatusFailed || !task.submitted {
+		manager.mu.Unlock()
+		return nil, fmt.Errorf("%w: it's %s, only failed background tasks can be", ErrNotRetryable, task.Status)
+	}
+	if task.Retries >= manager.maxRetries {
+		manager.mu.Unlock()
+		return nil, fmt.Errorf("%w: it was retried %d times already", ErrRetryLimit, task.Retries)
+	}
+	work, err := buildWork(task.Type, task.Input)
+	if err != nil {
+		manager.mu.Unlock()
+		return nil, fmt.Errorf("%w: %v", ErrNotRetryable, err)
+	}
+
+	task.Retries++
+	task.Status = StatusPending
+	task.Progress = 0
+	task.StartedAt = time.Time{}
+	task.CompletedAt = time.Time{}
+	task.Message = "Waiting for a free worker"
+	task.ErrorMessage = ""
+	task.Result = nil
+	addLogEntry(task, LogInfo, fmt.Sprintf("Retry %d of %d", task.Retries, manager.maxRetries))
+	manager.persist(task)
+	retried := *task
+	retried.Logs = slices.Clone(task.Logs)
+	manager.mu.Unlock()
+
+	manager.pool.enqueue(job{taskID: taskID, taskType: retried.Type, work: work})
+	return &retried, nil
+}
+
+// enqueue adds a job to the end of the queue
+func (p *pool) enqueue(j job) {
+	p.mu.Lock()
+	p.queue = append(p.queue, j)
+	p.mu.Unlock()
+	p.ready.Signal()
+}
+
+// worker runs queued jobs one after the other, for good
+func (p *pool) worker() {
+	for {
+		j := p.next()
+		p.run(j)
+
+		p.mu.Lock()
+		p.running[j.taskType]--
+		p.mu.Unlock()
+		// a job held back by its type's limit may be able to go now
+		p.ready.Broadcast()
+	}
+}
+
+// next waits for the first queued job whose type isn't at its limit and takes it
+func (p *pool) next() job {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for {
+		for i, j := range p.queue {
+			if limit, ok := p.limits[j.taskType]; ok && p.running[j.taskType] >= limit {
+				continue
+			}
+			p.queue = append(p.queue[:i], p.queue[i+1:]...)
+			p.running[j.taskType]++
+			return j
+		}
+		p.ready.Wait()
+	}
+}
+
+// run does one job and records how it went. A panic fails the task instead of taking
+// the server down with it.
+func (p *pool) run(j job) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Task %s (%s) panicked: %v\n%s", j.taskID, j.taskType, r, debug.Stack())
+			SetTaskError(j.taskID, fmt.Sprintf("task crashed: %v", r))
+		}
+	}()
+
+	UpdateTaskStatus(j.taskID, StatusProcessing)
+	result, err := j.work(context.Background(), j.taskID)
+	if err != nil {
+		failTask(j.taskID, err.Error(), result)
+		return
+	}
+	CompleteTask(j.taskID, result)
+}
@@ -0,0 +1,119 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// waitForStatus polls a task until it reaches status, failing the test after a few seconds
+func waitForStatus(t *testing.T, taskID string, status Status) *Task {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		task, ok := GetTask(taskID)
+		if ok && task.Status == status {
+			return task
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("task %s is %+v, want %s", taskID, task, status)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	Initialize(Config{Workers: 2})
+	Register("test_echo", func(input string) Work {
+		return func(ctx context.Context, taskID string) (interface{}, error) {
+			if input == "fail" {
+				return "partial", errors.New("asked to fail")
+			}
+			return "echo: " + input, nil
+		}
+	})
+	Register("test_panic", func(struct{}) Work {
+		return func(ctx context.Context, taskID string) (interface{}, error) {
+			panic("boom")
+		}
+	})
+
+	tests := []struct {
+		name     string
+		taskType string
+		input    interface{}
+		status   Status
+		result   interface{}
+		errorMsg string
+	}{
+		{"completes with its result", "test_echo", "hi", StatusCompleted, "echo: hi", ""},
+		{"fails keeping its result", "test_echo", "fail", StatusFailed, "partial", "asked to fail"},
+		{"panic fails the task", "test_panic", struct{}{}, StatusFailed, nil, "task crashed: boom"},
+		{"unknown type fails straight away", "test_unknown", nil, StatusFailed, nil, "no work registered"},
+		{"input that doesn't decode", "test_echo", 42, StatusFailed, nil, "error reading input"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := waitForStatus(t, Submit(tt.taskType, tt.input), tt.status)
+			if got.Result != tt.result {
+				t.Errorf("result %v, want %v", got.Result, tt.result)
+			}
+			if !strings.Contains(got.ErrorMessage, tt.errorMsg) {
+				t.Errorf("error %q, want it to contain %q", got.ErrorMessage, tt.errorMsg)
+			}
+			if got.CompletedAt.IsZero() {
+				t.Error("finished task has no completion time")
+			}
+		})
+	}
+}
+
+func TestTypeLimit(t *testing.T) {
+	Initialize(Config{Workers: 3, Limits: map[string]int{"test_heavy": 1}})
+
+	release := make(chan struct{})
+	var running, most atomic.Int32
+	Register("test_heavy", func(struct{}) Work {
+		return func(ctx context.Context, taskID string) (interface{}, error) {
+			now := running.Add(1)
+			for {
+				seen := most.Load()
+				if now <= seen || most.CompareAndSwap(seen, now) {
+					break
+				}
+			}
+			<-release
+			running.Add(-1)
+			return nil, nil
+		}
+	})
+	Register("test_light", func(struct{}) Work {
+		return func(ctx context.Context, taskID string) (interface{}, error) {
+			return nil, nil
+		}
+	})
+
+	heavy := []string{Submit("test_heavy", nil), Submit("test_heavy", nil), Submit("test_heavy", nil)}
+	light := Submit("test_light", nil)
+
+	// the light task gets past the heavy ones waiting for their type's slot
+	waitForStatus(t, light, StatusCompleted)
+	waitForStatus(t, heavy[0], StatusProcessing)
+	for _, id := range heavy[1:] {
+		if task, _ := GetTask(id); task.Status != StatusPending {
+			t.Errorf("heavy task %s is %s while another one runs", id, task.Status)
+		}
+	}
+
+	close(release)
+	for _, id := range heavy {
+		waitForStatus(t, id, StatusCompleted)
+	}
+	if most.Load() != 1 {
+		t.Errorf("%d heavy tasks ran at once, the limit is 1", most.Load())
+	}
+}
@@ -0,0 +1,62 @@
+package task
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrUnknownType is returned for submitting a task of a type nothing registered
+var ErrUnknownType = errors.New("no work registered for this task type")
+
+// builder makes the work for one task from the input it was submitted with
+type builder func(input json.RawMessage) (Work, error)
+
+// builders by task type, see Register
+var registry = struct {
+	mu       sync.RWMutex
+	builders map[string]builder
+}{builders: make(map[string]builder)}
+
+// Register sets what tasks of taskType do. Submit hands build the task's input, decoded
+// into I from the JSON stored with the task, and Retry does the same with the stored copy -
+// so a retry runs on exactly what the task was given, nothing the first run kept.
+func Register[I any](taskType string, build func(input I) Work) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	registry.builders[taskType] = func(raw json.RawMessage) (Work, error) {
+		var input I
+		if len(raw) > 0 {
+			if err := json.Unmarshal(raw, &input); err != nil {
+				return nil, fmt.Errorf("error reading input of %s task: %w", taskType, err)
+			}
+		}
+		return build(input), nil
+	}
+}
+
+// buildWork makes the work for a task of taskType from its stored input
+func buildWork(taskType string, input json.RawMessage) (Work, error) {
+	registry.mu.RLock()
+	build, ok := registry.builders[taskType]
+	registry.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, taskType)
+	}
+	return build(input)
+}
+
+// encodeInput stores what a task was submitted with, nil stays nil
+func encodeInput(input interface{}) (json.RawMessage, error) {
+	if input == nil {
+		return nil, nil
+	}
+	raw, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("error storing task input: %w", err)
+	}
+	return raw, nil
+}
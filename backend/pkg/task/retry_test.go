@@ -0,0 +1,197 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyInput is what test_flaky tasks are submitted with
+type flakyInput struct {
+	Name     string `json:"name"`
+	Failures int32  `json:"failures"` // runs that fail before one succeeds
+}
+
+// registerFlaky registers a task type that fails its first runs, counting them in runs
+func registerFlaky(runs *atomic.Int32) {
+	Register("test_flaky", func(input flakyInput) Work {
+		return func(ctx context.Context, taskID string) (interface{}, error) {
+			if runs.Add(1) <= input.Failures {
+				return nil, errors.New("not yet")
+			}
+			return "done with " + input.Name, nil
+		}
+	})
+}
+
+func TestRetry(t *testing.T) {
+	Initialize(Config{Workers: 1, MaxRetries: 2})
+	var runs atomic.Int32
+	registerFlaky(&runs)
+
+	taskID := Submit("test_flaky", flakyInput{Name: "a", Failures: 2})
+	waitForStatus(t, taskID, StatusFailed)
+
+	if _, err := Retry(taskID); err != nil {
+		t.Fatalf("first retry: %v", err)
+	}
+	waitForStatus(t, taskID, StatusFailed)
+
+	retried, err := Retry(taskID)
+	if err != nil {
+		t.Fatalf("second retry: %v", err)
+	}
+	if retried.Retries != 2 || retried.ErrorMessage != "" {
+		t.Errorf("retried task is %+v", retried)
+	}
+	done := waitForStatus(t, taskID, StatusCompleted)
+	if done.Result != "done with a" {
+		t.Errorf("result %v, want it built from the input again", done.Result)
+	}
+
+	if _, err := Retry(taskID); !errors.Is(err, ErrNotRetryable) {
+		t.Errorf("retrying a completed task: got %v, want ErrNotRetryable", err)
+	}
+	if _, err := Retry("no-such-task"); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("retrying a missing task: got %v, want ErrTaskNotFound", err)
+	}
+
+	// CreateTask tasks run their work elsewhere, there's nothing to run again
+	created := CreateTask("test_manual")
+	SetTaskError(created, "failed outside the workers")
+	if _, err := Retry(created); !errors.Is(err, ErrNotRetryable) {
+		t.Errorf("retrying a task that wasn't submitted: got %v, want ErrNotRetryable", err)
+	}
+}
+
+func TestRetryLimit(t *testing.T) {
+	Initialize(Config{Workers: 1, MaxRetries: 1})
+	var runs atomic.Int32
+	registerFlaky(&runs)
+
+	taskID := Submit("test_flaky", flakyInput{Name: "b", Failures: 5})
+	waitForStatus(t, taskID, StatusFailed)
+	if _, err := Retry(taskID); err != nil {
+		t.Fatalf("first retry: %v", err)
+	}
+	waitForStatus(t, taskID, StatusFailed)
+
+	if _, err := Retry(taskID); !errors.Is(err, ErrRetryLimit) {
+		t.Errorf("got %v, want ErrRetryLimit", err)
+	}
+}
+
+func TestInputIsNotShown(t *testing.T) {
+	raw, err := json.Marshal(Task{ID: "x", Input: json.RawMessage(`{"secret":"s"}`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var shown map[string]interface{}
+	if err := json.Unmarshal(raw, &shown); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := shown["input"]; ok {
+		t.Errorf("task JSON has its input: %s", raw)
+	}
+}
+
+// memoryStore is a Store that keeps the latest copy of each task
+type memoryStore struct {
+	mu    sync.Mutex
+	tasks map[string]Task
+	order []string
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{tasks: make(map[string]Task)}
+}
+
+func (s *memoryStore) SaveTask(ctx context.Context, t *Task) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.tasks[t.ID]; !ok {
+		s.order = append(s.order, t.ID)
+	}
+	s.tasks[t.ID] = *t
+	return nil
+}
+
+func (s *memoryStore) LoadTasks(ctx context.Context) ([]*Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var tasks []*Task
+	for _, id := range s.order {
+		if t := s.tasks[id]; t.Status != StatusCompleted {
+			tasks = append(tasks, &t)
+		}
+	}
+	return tasks, nil
+}
+
+func (s *memoryStore) DeleteTasksBefore(ctx context.Context, cutoff time.Time) error {
+	return nil
+}
+
+// stored waits for the store to have a copy of the task in status
+func (s *memoryStore) stored(t *testing.T, taskID string, status Status) Task {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		s.mu.Lock()
+		task, ok := s.tasks[taskID]
+		s.mu.Unlock()
+		if ok && task.Status == status {
+			return task
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("stored task %s is %+v, want %s", taskID, task, status)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestRestoreAfterRestart(t *testing.T) {
+	store := newMemoryStore()
+	Initialize(Config{Workers: 1, Store: store})
+	var runs atomic.Int32
+	registerFlaky(&runs)
+
+	failed := Submit("test_flaky", flakyInput{Name: "c", Failures: 1})
+	saved := store.stored(t, failed, StatusFailed)
+	if string(saved.Input) != `{"name":"c","failures":1}` {
+		t.Errorf("stored input %s", saved.Input)
+	}
+
+	// a task the last run never got to finish
+	interrupted := Task{ID: "interrupted", Type: "test_flaky", Status: StatusProcessing, Input: json.RawMessage(`{"name":"d"}`)}
+	store.SaveTask(context.Background(), &interrupted)
+
+	// restart
+	Initialize(Config{Workers: 1, Store: store})
+	restored, err := Restore(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored != 2 {
+		t.Fatalf("restored %d tasks, want 2", restored)
+	}
+
+	if got := waitForStatus(t, "interrupted", StatusFailed); got.ErrorMessage == "" {
+		t.Error("interrupted task failed without saying why")
+	}
+	store.stored(t, "interrupted", StatusFailed)
+
+	for id, want := range map[string]string{failed: "done with c", "interrupted": "done with d"} {
+		if _, err := Retry(id); err != nil {
+			t.Fatalf("retrying %s after the restart: %v", id, err)
+		}
+		if got := waitForStatus(t, id, StatusCompleted); got.Result != want {
+			t.Errorf("%s: result %v, want %v", id, got.Result, want)
+		}
+		store.stored(t, id, StatusCompleted)
+	}
+}
@@ -0,0 +1,73 @@
+package task
+
+import (
+	"context"
+	"log"
+	"slices"
+	"time"
+)
+
+// Store keeps submitted tasks somewhere that outlives the process, so a task that failed
+// before a restart can still be retried after it. Only tasks run by Submit are stored,
+// nothing else could be retried anyway.
+type Store interface {
+	SaveTask(ctx context.Context, t *Task) error                   // insert or update
+	LoadTasks(ctx context.Context) ([]*Task, error)                // every task that didn't complete
+	DeleteTasksBefore(ctx context.Context, cutoff time.Time) error // finished tasks, see CleanupOldTasks
+}
+
+// saveQueueSize is how many task changes can wait for the store before changing a task waits too
+const saveQueueSize = 256
+
+// persist queues a copy of a submitted task for the store. The caller holds the lock, so
+// copies are queued - and saved - in the order the task changed.
+func (m *TaskManager) persist(task *Task) {
+	if m.saves == nil || !task.submitted {
+		return
+	}
+	snapshot := *task
+	snapshot.Logs = slices.Clone(task.Logs)
+	m.saves <- &snapshot
+}
+
+// saveLoop writes queued task copies to the store, for good
+func (m *TaskManager) saveLoop() {
+	for t := range m.saves {
+		if err := m.store.SaveTask(context.Background(), t); err != nil {
+			log.Printf("Error saving task %s: %v", t.ID, err)
+		}
+	}
+}
+
+// Restore loads the tasks the store kept from earlier runs, so they show up and can be
+// retried again. Tasks that were still pending or running when the server stopped never
+// finished and count as failed. Returns how many tasks were loaded.
+func Restore(ctx context.Context) (int, error) {
+	if manager == nil || manager.store == nil {
+		return 0, nil
+	}
+
+	tasks, err := manager.store.LoadTasks(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	manager.mu.Lock()
+	defer manager.mu.Unlock()
+
+	for _, t := range tasks {
+		if _, exists := manager.tasks[t.ID]; exists {
+			continue
+		}
+		t.submitted = true
+		if t.Status == StatusPending || t.Status == StatusProcessing {
+			t.Status = StatusFailed
+			t.ErrorMessage = "interrupted by a server restart"
+			t.CompletedAt = time.Now()
+			addLogEntry(t, LogError, "Failed: "+t.ErrorMessage)
+			manager.persist(t)
+		}
+		manager.tasks[t.ID] = t
+	}
+	return len(tasks), nil
+}
@@ -0,0 +1,145 @@
+package thumbnail
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sprite sheet layout - frames are small, they only have to be recognisable while scrubbing
+const (
+	SpriteFrameWidth = 160
+	spriteColumns    = 10
+	spriteRows       = 10
+	spriteMaxFrames  = 600 // long lectures get a wider interval instead of hundreds of sheets
+)
+
+// SpriteIndex is the WebVTT file that maps playback times to frames on the sheets
+const SpriteIndex = "index.vtt"
+
+// how long ffmpeg may take for all sheets of one video
+const spriteTimeout = 15 * time.Minute
+
+// spriteInterval returns the seconds between preview frames for a video: every
+// interval seconds, stretched for long videos so they stay under spriteMaxFrames
+func spriteInterval(duration float64, interval int) int {
+	return max(interval, int(math.Ceil(duration/spriteMaxFrames)))
+}
+
+// GenerateSprites writes timeline preview sheets of a video to destDir - sheet-001.jpg and
+// on, each a grid of frames one interval apart - plus a WebVTT index whose cues point at
+// the frames with #xywh= fragments. The folder appears only once every file is written.
+func GenerateSprites(ctx context.Context, src, destDir string, interval int) error {
+	if err := CheckSpriteTools(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, spriteTimeout)
+	defer cancel()
+
+	duration, err := probeDuration(ctx, src)
+	if err != nil {
+		return err
+	}
+	interval = spriteInterval(duration, interval)
+	frames := max(int(math.Ceil(duration/float64(interval))), 1)
+	rows := min(spriteRows, (frames+spriteColumns-1)/spriteColumns)
+
+	tmp := destDir + ".tmp"
+	os.RemoveAll(tmp)
+	if err := os.MkdirAll(tmp, 0755); err != nil {
+		return fmt.Errorf("error creating sprite folder: %w", err)
+	}
+
+	filter := fmt.Sprintf("fps=1/%d,scale=%d:-2,tile=%dx%d", interval, SpriteFrameWidth, spriteColumns, rows)
+	err = run(ctx, "ffmpeg", "-nostdin", "-loglevel", "error", "-y", "-i", src,
+		"-vf", filter, "-q:v", "5", filepath.Join(tmp, "sheet-%03d.jpg"))
+	if err != nil {
+		os.RemoveAll(tmp)
+		return err
+	}
+
+	frameWidth, frameHeight, err := frameSize(filepath.Join(tmp, "sheet-001.jpg"), rows)
+	if err != nil {
+		os.RemoveAll(tmp)
+		return err
+	}
+
+	index := spriteIndex(duration, interval, frames, rows, frameWidth, frameHeight)
+	if err := os.WriteFile(filepath.Join(tmp, SpriteIndex), []byte(index), 0644); err != nil {
+		os.RemoveAll(tmp)
+		return fmt.Errorf("error writing sprite index: %w", err)
+	}
+
+	os.RemoveAll(destDir)
+	return os.Rename(tmp, destDir)
+}
+
+// CheckSpriteTools returns ErrToolMissing unless ffmpeg and ffprobe are installed
+func CheckSpriteTools() error {
+	for _, tool := range []string{"ffmpeg", "ffprobe"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			return fmt.Errorf("%w: %s", ErrToolMissing, tool)
+		}
+	}
+	return nil
+}
+
+// probeDuration asks ffprobe how long a video is in seconds
+func probeDuration(ctx context.Context, src string) (float64, error) {
+	output, err := exec.CommandContext(ctx, "ffprobe", "-v", "error",
+		"-show_entries", "format=duration", "-of", "default=noprint_wrappers=1:nokey=1", src).Output()
+	if err != nil {
+		return 0, fmt.Errorf("ffprobe failed: %w", err)
+	}
+	duration, err := strconv.ParseFloat(strings.TrimSpace(string(output)), 64)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("ffprobe reported no duration for %s", filepath.Base(src))
+	}
+	return duration, nil
+}
+
+// frameSize works out one frame's size from the first sheet, which is always a full grid
+func frameSize(sheet string, rows int) (int, int, error) {
+	f, err := os.Open(sheet)
+	if err != nil {
+		return 0, 0, fmt.Errorf("ffmpeg wrote no sprite sheets: %w", err)
+	}
+	defer f.Close()
+
+	config, _, err := image.DecodeConfig(f)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error reading sprite sheet: %w", err)
+	}
+	return config.Width / spriteColumns, config.Height / rows, nil
+}
+
+// spriteIndex builds the WebVTT cues, one per frame, pointing into the sheets relative to the index
+func spriteIndex(duration float64, interval, frames, rows, width, height int) string {
+	var b strings.Builder
+	b.WriteString("WEBVTT\n")
+
+	perSheet := spriteColumns * rows
+	for i := 0; i < frames; i++ {
+		start := float64(i * interval)
+		end := min(float64((i+1)*interval), duration)
+		cell := i % perSheet
+		fmt.Fprintf(&b, "\n%s --> %s\nsheet-%03d.jpg#xywh=%d,%d,%d,%d\n",
+			vttTimestamp(start), vttTimestamp(end), i/perSheet+1,
+			(cell%spriteColumns)*width, (cell/spriteColumns)*height, width, height)
+	}
+	return b.String()
+}
+
+// vttTimestamp formats seconds as HH:MM:SS.mmm
+func vttTimestamp(seconds float64) string {
+	ms := int64(math.Round(seconds * 1000))
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", ms/3600000, ms/60000%60, ms/1000%60, ms%1000)
+}
@@ -0,0 +1,224 @@
+package thumbnail
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/pkg/metrics"
+
+	// decoders for the image formats courses ship with
+	_ "image/gif"
+	_ "image/png"
+)
+
+// Width of generated thumbnails, the height follows the aspect ratio
+const Width = 320
+
+// how long an external tool may take for one thumbnail
+const toolTimeout = 30 * time.Second
+
+// how much of each end of a file goes into its checksum
+const checksumSample = 64 * 1024
+
+// images with more pixels than this aren't decoded - 100 megapixels is already a poster
+const maxPixels = 100_000_000
+
+var (
+	ErrUnsupported = errors.New("no thumbnails for this content type")
+	ErrToolMissing = errors.New("thumbnail tool is not installed")
+)
+
+// tools maps content types to the binary that renders them - images are done in Go
+var tools = map[string]string{
+	"video": "ffmpeg",
+	"pdf":   "pdftoppm",
+}
+
+// Supported reports whether thumbnails can be made for a content type at all
+func Supported(contentType string) bool {
+	return contentType == "image" || tools[contentType] != ""
+}
+
+// Checksum identifies a file's content without reading all of it: the size plus the
+// first and last 64KB. Good enough to notice a replaced video, cheap enough per request.
+func Checksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	binary.Write(h, binary.LittleEndian, info.Size())
+	if _, err := io.Copy(h, io.LimitReader(f, checksumSample)); err != nil {
+		return "", err
+	}
+	if info.Size() > 2*checksumSample {
+		if _, err := f.Seek(-checksumSample, io.SeekEnd); err != nil {
+			return "", err
+		}
+		if _, err := io.Copy(h, f); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Generate writes a JPEG thumbnail of src to dest. Videos are grabbed at seekSeconds,
+// PDFs show their first page. The file appears at dest only once it's complete.
+func Generate(ctx context.Context, src, contentType, dest string, seekSeconds int) error {
+	if !Supported(contentType) {
+		return fmt.Errorf("%w: %s", ErrUnsupported, contentType)
+	}
+	if tool := tools[contentType]; tool != "" {
+		if _, err := exec.LookPath(tool); err != nil {
+			return fmt.Errorf("%w: %s", ErrToolMissing, tool)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return fmt.Errorf("error creating thumbnail folder: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, toolTimeout)
+	defer cancel()
+
+	tmp := strings.TrimSuffix(dest, filepath.Ext(dest)) + ".tmp"
+	var err error
+	switch contentType {
+	case "video":
+		err = grabFrame(ctx, src, tmp+".jpg", seekSeconds)
+		if err != nil && seekSeconds > 0 {
+			err = grabFrame(ctx, src, tmp+".jpg", 0) // shorter than we guessed
+		}
+	case "pdf":
+		err = run(ctx, "pdftoppm", "-jpeg", "-f", "1", "-l", "1", "-singlefile",
+			"-scale-to-x", strconv.Itoa(Width), "-scale-to-y", "-1", src, tmp)
+	case "image":
+		err = scaleImage(src, tmp+".jpg")
+	}
+	if err != nil {
+		os.Remove(tmp + ".jpg")
+		return err
+	}
+
+	return os.Rename(tmp+".jpg", dest)
+}
+
+// grabFrame has ffmpeg write one scaled frame
+func grabFrame(ctx context.Context, src, dest string, seekSeconds int) error {
+	return run(ctx, "ffmpeg", "-nostdin", "-loglevel", "error", "-y",
+		"-ss", strconv.Itoa(seekSeconds), "-i", src,
+		"-frames:v", "1", "-vf", "scale="+strconv.Itoa(Width)+":-2", dest)
+}
+
+// run executes a tool and includes its output in the error when it fails. Failures count
+// in the failed transcodes metric, unless the request was called off.
+func run(ctx context.Context, name string, args ...string) error {
+	output, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	if err != nil {
+		if !errors.Is(ctx.Err(), context.Canceled) {
+			metrics.IncCounter(metrics.FailedTranscodes, name)
+		}
+		return fmt.Errorf("%s failed: %w: %s", name, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// scaleImage shrinks an image to Width for its thumbnail
+func scaleImage(src, dest string) error {
+	return Resize(src, dest, Width, 0, 80)
+}
+
+// Resize writes a JPEG of src that fits in maxWidth x maxHeight (0 means no limit), keeping
+// the aspect ratio. Pixels are averaged in blocks, images are only ever scaled down - smaller
+// ones are re-encoded as they are.
+func Resize(src, dest string, maxWidth, maxHeight, quality int) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// a small file can still claim a huge canvas, check before decoding it all
+	config, _, err := image.DecodeConfig(f)
+	if err != nil {
+		return fmt.Errorf("error decoding image: %w", err)
+	}
+	if config.Width*config.Height > maxPixels {
+		return fmt.Errorf("image is too large to resize: %dx%d", config.Width, config.Height)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return fmt.Errorf("error decoding image: %w", err)
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if maxWidth > 0 && width > maxWidth {
+		height = max(height*maxWidth/width, 1)
+		width = maxWidth
+	}
+	if maxHeight > 0 && height > maxHeight {
+		width = max(width*maxHeight/height, 1)
+		height = maxHeight
+	}
+
+	scaled := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := max(bounds.Min.Y+(y+1)*bounds.Dy()/height, y0+1)
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := max(bounds.Min.X+(x+1)*bounds.Dx()/width, x0+1)
+			scaled.Set(x, y, average(img, x0, y0, x1, y1))
+		}
+	}
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	if err := jpeg.Encode(out, scaled, &jpeg.Options{Quality: quality}); err != nil {
+		out.Close()
+		return fmt.Errorf("error encoding image: %w", err)
+	}
+	return out.Close()
+}
+
+// average is the mean colour of a block of pixels, on white since JPEG has no transparency
+func average(img image.Image, x0, y0, x1, y1 int) color.RGBA {
+	var r, g, b, a, n uint64
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			pr, pg, pb, pa := img.At(x, y).RGBA()
+			r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+			n++
+		}
+	}
+	white := 0xffff*n - a // colours are premultiplied, so transparency shows the background
+	return color.RGBA{R: uint8((r + white) / n >> 8), G: uint8((g + white) / n >> 8), B: uint8((b + white) / n >> 8), A: 0xff}
+}
@@ -0,0 +1,116 @@
+package tiering
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/NeroQue/course-management-backend/pkg/util"
+)
+
+// Backend is somewhere cold files can be parked. The disk backend is the only
+// one for now, an object store with slow retrieval would implement the same thing.
+type Backend interface {
+	Name() string
+	// Store moves the file at src into the backend under key
+	Store(key, src string) error
+	// Restore moves the file stored under key back to dst
+	Restore(key, dst string) error
+}
+
+// ErrUnsafeKey is returned for keys that would escape the backend root
+var ErrUnsafeKey = errors.New("unsafe cold storage key")
+
+// DiskBackend keeps cold files in a directory, usually on a slower or cheaper disk
+type DiskBackend struct {
+	Root string
+}
+
+// NewBackend returns the configured cold backend, or nil when tiering is off
+func NewBackend() Backend {
+	root := util.GetColdStorageDirectory()
+	if root == "" {
+		return nil
+	}
+	return &DiskBackend{Root: root}
+}
+
+// Name identifies the backend in the admin API
+func (b *DiskBackend) Name() string {
+	return "disk:" + b.Root
+}
+
+// Store moves src into the cold directory
+func (b *DiskBackend) Store(key, src string) error {
+	dst, err := b.path(key)
+	if err != nil {
+		return err
+	}
+	return moveFile(src, dst)
+}
+
+// Restore moves a cold file back to dst
+func (b *DiskBackend) Restore(key, dst string) error {
+	src, err := b.path(key)
+	if err != nil {
+		return err
+	}
+	return moveFile(src, dst)
+}
+
+// path resolves a key inside the backend root
+func (b *DiskBackend) path(key string) (string, error) {
+	clean := filepath.Clean(key)
+	if clean == "." || filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: %s", ErrUnsafeKey, key)
+	}
+	return filepath.Join(b.Root, clean), nil
+}
+
+// moveFile renames when it can and copies across devices when it can't.
+// The source is only removed once the copy is safely on disk.
+func moveFile(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return fmt.Errorf("error creating %s: %w", filepath.Dir(dst), err)
+	}
+
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("error opening %s: %w", src, err)
+	}
+	defer in.Close()
+
+	tmp := dst + ".partial"
+	out, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("error creating %s: %w", tmp, err)
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(tmp)
+		return fmt.Errorf("error copying %s: %w", src, err)
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		os.Remove(tmp)
+		return fmt.Errorf("error syncing %s: %w", tmp, err)
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("error closing %s: %w", tmp, err)
+	}
+
+	if err := os.Rename(tmp, dst); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("error moving %s into place: %w", tmp, err)
+	}
+	return os.Remove(src)
+}
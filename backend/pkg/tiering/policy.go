@@ -0,0 +1,39 @@
+package tiering
+
+import (
+	"time"
+
+	"github.com/NeroQue/course-management-backend/pkg/util"
+)
+
+// storage tiers a content item can be in
+const (
+	Hot  = "hot"  // in the courses directory, served directly
+	Cold = "cold" // moved to the cold backend, restored on next access
+)
+
+// Policy decides which items are rarely used enough to move to cold storage
+type Policy struct {
+	ColdAfter   time.Duration `json:"cold_after"`   // no access for this long
+	MaxAccesses int           `json:"max_accesses"` // and accessed at most this many times overall
+	MinBytes    int64         `json:"min_bytes"`    // small files aren't worth moving
+	BatchSize   int           `json:"batch_size"`   // items moved per policy pass
+}
+
+// default policy - only big media nobody has touched in a quarter
+var defaultPolicy = Policy{
+	ColdAfter:   90 * 24 * time.Hour,
+	MaxAccesses: 3,
+	MinBytes:    100 << 20,
+	BatchSize:   100,
+}
+
+// LoadPolicy reads TIERING_COLD_AFTER, TIERING_MAX_ACCESSES, TIERING_MIN_MB and TIERING_BATCH_SIZE
+func LoadPolicy() Policy {
+	policy := defaultPolicy
+	policy.ColdAfter = util.GetEnvDuration("TIERING_COLD_AFTER", policy.ColdAfter)
+	policy.MaxAccesses = util.GetEnvInt("TIERING_MAX_ACCESSES", policy.MaxAccesses)
+	policy.MinBytes = int64(util.GetEnvInt("TIERING_MIN_MB", int(policy.MinBytes>>20))) << 20
+	policy.BatchSize = util.GetEnvInt("TIERING_BATCH_SIZE", policy.BatchSize)
+	return policy
+}
@@ -0,0 +1,109 @@
+package transcribe
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// API sends the audio to an OpenAI-compatible /audio/transcriptions endpoint
+type API struct {
+	URL     string        // full endpoint URL, TRANSCRIBE_API_URL
+	Key     string        // bearer token, TRANSCRIBE_API_KEY - may be empty for local servers
+	Model   string        // TRANSCRIBE_API_MODEL
+	Timeout time.Duration // upload plus processing
+}
+
+// Name identifies the provider in stored transcripts
+func (a *API) Name() string {
+	return "api:" + a.Model
+}
+
+// Transcribe uploads the file (its audio track when ffmpeg is around) and asks for VTT back
+func (a *API) Transcribe(ctx context.Context, mediaPath, language string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.Timeout)
+	defer cancel()
+
+	dir, err := os.MkdirTemp("", "transcribe-")
+	if err != nil {
+		return "", fmt.Errorf("error creating temporary folder: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	input, err := extractAudio(ctx, mediaPath, dir)
+	if err != nil {
+		return "", err
+	}
+	if input == "" {
+		input = mediaPath
+	}
+
+	body, contentType, err := a.form(input, language)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.URL, body)
+	if err != nil {
+		return "", fmt.Errorf("error creating transcription request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	if a.Key != "" {
+		req.Header.Set("Authorization", "Bearer "+a.Key)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("transcription request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	result, err := io.ReadAll(io.LimitReader(resp.Body, 32<<20))
+	if err != nil {
+		return "", fmt.Errorf("error reading transcription response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("transcription API returned %s: %s", resp.Status, strings.TrimSpace(string(result[:min(len(result), 500)])))
+	}
+	return string(result), nil
+}
+
+// form builds the multipart body - small enough after audio extraction to hold in memory
+func (a *API) form(path, language string) (io.Reader, string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, "", err
+	}
+	defer f.Close()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	fields := map[string]string{"model": a.Model, "response_format": "vtt"}
+	if language != "" {
+		fields["language"] = language
+	}
+	for name, value := range fields {
+		if err := writer.WriteField(name, value); err != nil {
+			return nil, "", err
+		}
+	}
+
+	part, err := writer.CreateFormFile("file", filepath.Base(path))
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err := io.Copy(part, f); err != nil {
+		return nil, "", fmt.Errorf("error reading media file: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+	return &body, writer.FormDataContentType(), nil
+}
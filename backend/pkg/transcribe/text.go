@@ -0,0 +1,39 @@
+package transcribe
+
+import (
+	"regexp"
+	"strings"
+)
+
+// vttTag matches voice and styling tags inside cue text, e.g. <v Speaker> or <i>
+var vttTag = regexp.MustCompile(`<[^>]*>`)
+
+// PlainText pulls the spoken text out of WebVTT for searching and reading: no header, cue
+// numbers, timings or tags, and lines repeated by rolling captions only once
+func PlainText(vtt string) string {
+	vtt = strings.ReplaceAll(vtt, "\r\n", "\n")
+
+	var lines []string
+	for _, block := range strings.Split(vtt, "\n\n") {
+		blockLines := strings.Split(strings.TrimSpace(block), "\n")
+		timing := -1
+		for i, line := range blockLines {
+			if strings.Contains(line, "-->") {
+				timing = i
+				break
+			}
+		}
+		if timing < 0 {
+			continue // header, NOTE or STYLE block
+		}
+
+		for _, line := range blockLines[timing+1:] {
+			line = strings.TrimSpace(vttTag.ReplaceAllString(line, ""))
+			if line == "" || (len(lines) > 0 && lines[len(lines)-1] == line) {
+				continue
+			}
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
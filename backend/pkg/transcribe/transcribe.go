@@ -0,0 +1,92 @@
+package transcribe
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/NeroQue/course-management-backend/pkg/util"
+)
+
+// Transcriber turns the speech in an audio or video file into WebVTT captions.
+// language is a two letter hint and may be empty to let the provider detect it.
+type Transcriber interface {
+	Name() string
+	Transcribe(ctx context.Context, mediaPath, language string) (string, error)
+}
+
+// FromEnv sets up the provider picked by TRANSCRIBE_PROVIDER - "whisper" for a local
+// whisper binary, "api" for an OpenAI-compatible transcription endpoint. Anything else
+// leaves transcription off and returns nil.
+func FromEnv() Transcriber {
+	timeout := util.GetEnvDuration("TRANSCRIBE_TIMEOUT", 2*time.Hour)
+
+	switch provider := strings.ToLower(os.Getenv("TRANSCRIBE_PROVIDER")); provider {
+	case "whisper":
+		return &Whisper{
+			Binary:  envOr("WHISPER_BINARY", "whisper"),
+			Model:   envOr("WHISPER_MODEL", "base"),
+			Timeout: timeout,
+		}
+	case "api":
+		if os.Getenv("TRANSCRIBE_API_URL") == "" {
+			log.Printf("Warning: TRANSCRIBE_PROVIDER is api but TRANSCRIBE_API_URL is not set, transcription is off")
+			return nil
+		}
+		return &API{
+			URL:     os.Getenv("TRANSCRIBE_API_URL"),
+			Key:     os.Getenv("TRANSCRIBE_API_KEY"),
+			Model:   envOr("TRANSCRIBE_API_MODEL", "whisper-1"),
+			Timeout: timeout,
+		}
+	case "":
+		return nil
+	default:
+		log.Printf("Warning: unknown TRANSCRIBE_PROVIDER %q, transcription is off", provider)
+		return nil
+	}
+}
+
+// envOr reads an environment variable with a default
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// extractAudio writes the audio track of a media file as small mono MP3 - speech doesn't
+// need more, and it keeps uploads far below API size limits. Returns "" when ffmpeg isn't
+// installed so the caller can fall back to the original file.
+func extractAudio(ctx context.Context, src, dir string) (string, error) {
+	if _, err := exec.LookPath("ffmpeg"); err != nil {
+		return "", nil
+	}
+
+	dest := filepath.Join(dir, "audio.mp3")
+	output, err := exec.CommandContext(ctx, "ffmpeg", "-nostdin", "-loglevel", "error", "-y", "-i", src,
+		"-vn", "-ac", "1", "-ar", "16000", "-b:a", "32k", dest).CombinedOutput()
+	if err != nil {
+		return "", &toolError{tool: "ffmpeg", err: err, output: string(output)}
+	}
+	return dest, nil
+}
+
+// toolError is a failed external command with what it printed
+type toolError struct {
+	tool   string
+	err    error
+	output string
+}
+
+func (e *toolError) Error() string {
+	return e.tool + " failed: " + e.err.Error() + ": " + strings.TrimSpace(e.output)
+}
+
+func (e *toolError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,77 @@
+package transcribe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ErrToolMissing is returned when the whisper binary isn't installed
+var ErrToolMissing = errors.New("whisper is not installed")
+
+// Whisper runs a local whisper command line (the openai-whisper one) on the file
+type Whisper struct {
+	Binary  string        // command to run, WHISPER_BINARY
+	Model   string        // model name, WHISPER_MODEL - "base" is a fair trade of speed and accuracy
+	Timeout time.Duration // a long lecture on CPU takes a while
+}
+
+// Name identifies the provider in stored transcripts
+func (w *Whisper) Name() string {
+	return "whisper:" + w.Model
+}
+
+// Transcribe runs whisper with VTT output into a temporary folder and reads the result
+func (w *Whisper) Transcribe(ctx context.Context, mediaPath, language string) (string, error) {
+	if _, err := exec.LookPath(w.Binary); err != nil {
+		return "", fmt.Errorf("%w: %s", ErrToolMissing, w.Binary)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, w.Timeout)
+	defer cancel()
+
+	dir, err := os.MkdirTemp("", "transcribe-")
+	if err != nil {
+		return "", fmt.Errorf("error creating temporary folder: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// whisper decodes with ffmpeg itself, a small audio file just saves it work on big videos
+	input, err := extractAudio(ctx, mediaPath, dir)
+	if err != nil {
+		return "", err
+	}
+	if input == "" {
+		input = mediaPath
+	}
+
+	args := []string{input, "--model", w.Model, "--output_format", "vtt", "--output_dir", dir, "--verbose", "False"}
+	if language != "" {
+		args = append(args, "--language", language)
+	}
+	output, err := exec.CommandContext(ctx, w.Binary, args...).CombinedOutput()
+	if err != nil {
+		return "", &toolError{tool: w.Binary, err: err, output: lastLines(string(output), 5)}
+	}
+
+	stem := strings.TrimSuffix(filepath.Base(input), filepath.Ext(input))
+	vtt, err := os.ReadFile(filepath.Join(dir, stem+".vtt"))
+	if err != nil {
+		return "", fmt.Errorf("whisper wrote no transcript: %w", err)
+	}
+	return string(vtt), nil
+}
+
+// lastLines keeps the end of a long tool output, where the error usually is
+func lastLines(s string, n int) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,53 @@
+package util
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// GetEnvDuration reads a duration like "90s" or "2h" from the environment
+func GetEnvDuration(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("Warning: invalid duration for %s (%q), using default %v", key, value, fallback)
+		return fallback
+	}
+	return duration
+}
+
+// GetEnvInt reads an integer from the environment
+func GetEnvInt(key string, fallback int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Warning: invalid number for %s (%q), using default %d", key, value, fallback)
+		return fallback
+	}
+	return number
+}
+
+// GetEnvBool reads a boolean like "true" or "0" from the environment
+func GetEnvBool(key string, fallback bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	flag, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Warning: invalid boolean for %s (%q), using default %t", key, value, fallback)
+		return fallback
+	}
+	return flag
+}
@@ -40,3 +40,28 @@ func ResolveCourseFilePath(relativePath string) string {
 	baseDir := GetCoursesDirectory()
 	return filepath.Join(baseDir, relativePath)
 }
+
+// GetArtifactsDirectory returns where generated files (thumbnails, HLS, transcripts...) live
+func GetArtifactsDirectory() string {
+	artifactsDir := os.Getenv("ARTIFACTS_DIR")
+	if artifactsDir == "" {
+		artifactsDir = "./artifacts"
+	}
+	return artifactsDir
+}
+
+// GetColdStorageDirectory returns where rarely used course media is moved to.
+// Empty means tiering is switched off.
+func GetColdStorageDirectory() string {
+	return os.Getenv("COLD_STORAGE_DIR")
+}
+
+// GetDataDirectory returns where files users upload (avatars...) are kept. Unlike
+// artifacts these can't be generated again, so it shouldn't be cleaned up or cached.
+func GetDataDirectory() string {
+	dataDir := os.Getenv("DATA_DIR")
+	if dataDir == "" {
+		dataDir = "./data"
+	}
+	return dataDir
+}
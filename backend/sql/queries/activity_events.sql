@@ -0,0 +1,15 @@
+-- name: CreateActivityEvent :exec
+INSERT INTO activity_events (id, profile_id, event_type, course_id, content_item_id, title, created_at)
+VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, now());
+
+-- name: ListActivityEvents :many
+SELECT * FROM activity_events
+WHERE profile_id = $1 AND created_at >= @since::timestamp
+ORDER BY created_at DESC, id
+LIMIT $2 OFFSET $3;
+
+-- name: ListFeedEvents :many
+SELECT * FROM activity_events
+WHERE profile_id = $1 AND event_type = ANY(@event_types::text[])
+ORDER BY created_at DESC, id
+LIMIT $2 OFFSET $3;
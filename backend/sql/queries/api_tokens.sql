@@ -0,0 +1,23 @@
+-- name: CreateAPIToken :one
+INSERT INTO api_tokens (id, profile_id, name, token_hash, scope, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, now())
+RETURNING *;
+
+-- name: GetAPITokenByHash :one
+SELECT * FROM api_tokens
+WHERE token_hash = $1;
+
+-- name: ListAPITokensByProfile :many
+SELECT * FROM api_tokens
+WHERE profile_id = $1
+ORDER BY created_at DESC;
+
+-- name: RevokeAPIToken :execrows
+UPDATE api_tokens
+SET revoked_at = now()
+WHERE id = $1 AND profile_id = $2 AND revoked_at IS NULL;
+
+-- name: RecordAPITokenUse :exec
+UPDATE api_tokens
+SET last_used_at = now()
+WHERE id = $1;
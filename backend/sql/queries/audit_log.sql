@@ -0,0 +1,12 @@
+-- name: CreateAuditEntry :one
+INSERT INTO audit_log (
+    id, actor_id, subject_id, action, entity_type, entity_id, details, created_at
+) VALUES (
+    gen_random_uuid(), $1, $2, $3, $4, $5, $6, now()
+)
+RETURNING *;
+
+-- name: ListAuditEntries :many
+SELECT * FROM audit_log
+ORDER BY created_at DESC
+LIMIT $1 OFFSET $2;
@@ -0,0 +1,22 @@
+-- name: CreateChangeRecord :one
+INSERT INTO change_history (
+    id, batch_id, entity_type, entity_id, field, old_value, new_value, changed_by, created_at
+) VALUES (
+    gen_random_uuid(), $1, $2, $3, $4, $5, $6, $7, now()
+)
+RETURNING *;
+
+-- name: ListChangesByBatch :many
+SELECT * FROM change_history
+WHERE batch_id = $1
+ORDER BY created_at ASC;
+
+-- name: ListChangesByEntity :many
+SELECT * FROM change_history
+WHERE entity_type = $1 AND entity_id = $2
+ORDER BY created_at DESC;
+
+-- name: MarkBatchReverted :exec
+UPDATE change_history
+SET reverted_at = now()
+WHERE batch_id = $1;
@@ -0,0 +1,17 @@
+-- name: CreateContentBookmark :one
+INSERT INTO content_bookmarks (id, content_item_id, user_id, position, label, created_at)
+VALUES (gen_random_uuid(), $1, $2, $3, $4, now())
+RETURNING *;
+
+-- name: GetContentBookmark :one
+SELECT * FROM content_bookmarks
+WHERE id = $1;
+
+-- name: DeleteContentBookmark :exec
+DELETE FROM content_bookmarks
+WHERE id = $1;
+
+-- name: ListContentBookmarks :many
+SELECT * FROM content_bookmarks
+WHERE content_item_id = $1 AND user_id = $2
+ORDER BY position, created_at;
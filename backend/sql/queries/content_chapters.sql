@@ -0,0 +1,18 @@
+-- name: CreateContentChapter :exec
+INSERT INTO content_chapters (content_item_id, position, title, start_time)
+VALUES ($1, $2, $3, $4);
+
+-- name: ListContentChapters :many
+SELECT * FROM content_chapters
+WHERE content_item_id = $1
+ORDER BY position;
+
+-- name: ListContentChaptersByModule :many
+SELECT ch.* FROM content_chapters ch
+JOIN content_items ci ON ci.id = ch.content_item_id
+WHERE ci.module_id = $1
+ORDER BY ch.content_item_id, ch.position;
+
+-- name: DeleteContentChapters :exec
+DELETE FROM content_chapters
+WHERE content_item_id = $1;
@@ -0,0 +1,14 @@
+-- name: ListCourseContentChecksums :many
+SELECT cs.* FROM content_checksums cs
+JOIN content_items ci ON ci.id = cs.content_item_id
+JOIN modules m ON m.id = ci.module_id
+WHERE m.course_id = $1;
+
+-- name: UpsertContentChecksum :exec
+INSERT INTO content_checksums (content_item_id, size, modified_ns, sha256)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (content_item_id)
+DO UPDATE SET size = EXCLUDED.size,
+    modified_ns = EXCLUDED.modified_ns,
+    sha256 = EXCLUDED.sha256,
+    created_at = now();
@@ -0,0 +1,15 @@
+-- name: CreateContentItemVariant :exec
+INSERT INTO content_item_variants (id, content_item_id, label, resolution, language, relative_path, size, is_primary, position)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: ListContentItemVariants :many
+SELECT * FROM content_item_variants
+WHERE content_item_id = $1
+ORDER BY position;
+
+-- name: ListContentItemVariantsByModule :many
+SELECT v.* FROM content_item_variants v
+JOIN content_items ci ON ci.id = v.content_item_id
+WHERE ci.module_id = $1
+ORDER BY v.content_item_id, v.position;
+
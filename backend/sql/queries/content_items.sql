@@ -17,9 +17,10 @@ INSERT INTO content_items (
     content_type,
     duration,
     size,
-    "order"
+    "order",
+    page_count
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
 )
 RETURNING *;
 
@@ -39,3 +40,71 @@ RETURNING *;
 DELETE FROM content_items
 WHERE id = $1;
 
+
+-- name: ListAllContentItemsWithCourse :many
+SELECT ci.*, m.course_id FROM content_items ci
+JOIN modules m ON ci.module_id = m.id
+ORDER BY m.course_id, m."order", ci."order";
+
+-- name: UpdateContentItemTitle :exec
+UPDATE content_items
+SET
+    title = $2,
+    updated_at = now()
+WHERE id = $1;
+
+-- name: MoveContentItem :exec
+UPDATE content_items
+SET
+    module_id = $2,
+    "order" = $3,
+    updated_at = now()
+WHERE id = $1;
+
+-- name: SetContentItemHidden :one
+UPDATE content_items
+SET
+    hidden = $2,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateContentItemOrder :exec
+UPDATE content_items
+SET
+    "order" = $2,
+    updated_at = now()
+WHERE id = $1;
+
+-- name: RestoreContentItem :exec
+INSERT INTO content_items (id, module_id, title, description, relative_path, content_type, duration, size, "order", page_count, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, now())
+ON CONFLICT (id)
+DO UPDATE SET
+    module_id = EXCLUDED.module_id,
+    title = EXCLUDED.title,
+    description = EXCLUDED.description,
+    relative_path = EXCLUDED.relative_path,
+    content_type = EXCLUDED.content_type,
+    duration = EXCLUDED.duration,
+    size = EXCLUDED.size,
+    "order" = EXCLUDED."order",
+    page_count = EXCLUDED.page_count,
+    updated_at = now();
+
+-- name: DeleteContentItemsNotIn :exec
+DELETE FROM content_items
+WHERE module_id = @module_id AND NOT (id = ANY(@keep_ids::uuid[]));
+
+-- name: GetContentItemLocation :one
+SELECT ci.id, ci.title, ci.relative_path, ci.content_type, ci.module_id, m.course_id, c.creator_id
+FROM content_items ci
+JOIN modules m ON m.id = ci.module_id
+JOIN courses c ON c.id = m.course_id
+WHERE ci.id = $1;
+
+-- name: ListCourseContentItems :many
+SELECT ci.* FROM content_items ci
+JOIN modules m ON m.id = ci.module_id
+WHERE m.course_id = $1
+ORDER BY m."order", ci."order";
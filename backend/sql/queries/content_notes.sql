@@ -0,0 +1,34 @@
+-- name: CreateContentNote :one
+INSERT INTO content_notes (id, content_item_id, user_id, content, position, created_at, updated_at)
+VALUES (gen_random_uuid(), $1, $2, $3, $4, now(), now())
+RETURNING *;
+
+-- name: GetContentNote :one
+SELECT * FROM content_notes
+WHERE id = $1;
+
+-- name: UpdateContentNote :one
+UPDATE content_notes
+SET content = $2,
+    position = $3,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteContentNote :exec
+DELETE FROM content_notes
+WHERE id = $1;
+
+-- name: ListContentNotes :many
+SELECT * FROM content_notes
+WHERE content_item_id = $1 AND user_id = $2
+ORDER BY position NULLS FIRST, created_at;
+
+-- name: ListCourseContentNotes :many
+SELECT n.id, n.content_item_id, n.user_id, n.content, n.position, n.created_at, n.updated_at,
+    ci.title AS content_title, ci.content_type, ci.module_id, m.title AS module_title
+FROM content_notes n
+JOIN content_items ci ON ci.id = n.content_item_id
+JOIN modules m ON m.id = ci.module_id
+WHERE m.course_id = $1 AND n.user_id = $2
+ORDER BY m."order", ci."order", n.position NULLS FIRST, n.created_at;
@@ -0,0 +1,12 @@
+-- name: AddContentItemRequirement :exec
+INSERT INTO content_item_requirements (content_item_id, capability, created_at)
+VALUES ($1, $2, now())
+ON CONFLICT (content_item_id, capability) DO NOTHING;
+
+-- name: ListCourseRequirements :many
+SELECT r.capability, ci.id AS content_item_id, ci.title, ci.relative_path
+FROM content_item_requirements r
+JOIN content_items ci ON ci.id = r.content_item_id
+JOIN modules m ON m.id = ci.module_id
+WHERE m.course_id = $1
+ORDER BY r.capability, m."order", ci."order";
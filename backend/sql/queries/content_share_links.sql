@@ -0,0 +1,28 @@
+-- name: CreateShareLink :one
+INSERT INTO content_share_links (id, token_hash, content_item_id, created_by, password_hash, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, now())
+RETURNING *;
+
+-- name: GetShareLink :one
+SELECT * FROM content_share_links
+WHERE id = $1;
+
+-- name: GetShareLinkByTokenHash :one
+SELECT * FROM content_share_links
+WHERE token_hash = $1;
+
+-- name: ListShareLinksByContentItem :many
+SELECT * FROM content_share_links
+WHERE content_item_id = $1
+ORDER BY created_at DESC;
+
+-- name: RevokeShareLink :execrows
+UPDATE content_share_links
+SET revoked_at = now()
+WHERE id = $1 AND revoked_at IS NULL;
+
+-- name: RecordShareLinkView :exec
+UPDATE content_share_links
+SET view_count = view_count + 1,
+    last_viewed_at = now()
+WHERE id = $1;
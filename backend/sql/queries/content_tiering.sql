@@ -0,0 +1,61 @@
+-- name: RecordContentAccess :one
+INSERT INTO content_tiering (content_item_id, access_count, last_accessed_at, updated_at)
+VALUES ($1, 1, now(), now())
+ON CONFLICT (content_item_id)
+DO UPDATE SET
+    access_count = content_tiering.access_count + 1,
+    last_accessed_at = now(),
+    updated_at = now()
+RETURNING *;
+
+-- name: GetContentTiering :one
+SELECT * FROM content_tiering
+WHERE content_item_id = $1;
+
+-- name: MarkContentCold :exec
+INSERT INTO content_tiering (content_item_id, tier, cold_key, tiered_at, updated_at)
+VALUES ($1, 'cold', $2, now(), now())
+ON CONFLICT (content_item_id)
+DO UPDATE SET
+    tier = 'cold',
+    cold_key = EXCLUDED.cold_key,
+    tiered_at = now(),
+    updated_at = now();
+
+-- name: MarkColdKeyHot :exec
+UPDATE content_tiering
+SET tier = 'hot',
+    cold_key = NULL,
+    tiered_at = now(),
+    updated_at = now()
+WHERE cold_key = $1;
+
+-- name: ListColdCandidates :many
+SELECT ci.id, ci.relative_path, ci.size
+FROM content_items ci
+LEFT JOIN content_tiering t ON t.content_item_id = ci.id
+WHERE COALESCE(t.tier, 'hot') = 'hot'
+  AND COALESCE(t.last_accessed_at, ci.created_at) < @cutoff::timestamp
+  AND COALESCE(t.access_count, 0) <= @max_accesses::int
+  AND ci.size >= @min_bytes::bigint
+  AND NOT EXISTS (
+      SELECT 1 FROM content_items other
+      WHERE other.relative_path = ci.relative_path AND other.id <> ci.id
+  )
+ORDER BY COALESCE(t.last_accessed_at, ci.created_at)
+LIMIT @max_items;
+
+-- name: CountContentByTier :many
+SELECT COALESCE(t.tier, 'hot')::text AS tier, COUNT(*) AS items, COALESCE(SUM(ci.size), 0)::bigint AS bytes
+FROM content_items ci
+LEFT JOIN content_tiering t ON t.content_item_id = ci.id
+GROUP BY 1
+ORDER BY 1;
+
+-- name: ListColdContent :many
+SELECT ci.id, ci.title, ci.relative_path, ci.size, t.access_count, t.last_accessed_at, t.tiered_at
+FROM content_tiering t
+JOIN content_items ci ON ci.id = t.content_item_id
+WHERE t.tier = 'cold'
+ORDER BY t.tiered_at DESC
+LIMIT $1;
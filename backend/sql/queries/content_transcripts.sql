@@ -0,0 +1,14 @@
+-- name: UpsertContentTranscript :one
+INSERT INTO content_transcripts (content_item_id, provider, language, vtt, text)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (content_item_id)
+DO UPDATE SET provider = EXCLUDED.provider,
+    language = EXCLUDED.language,
+    vtt = EXCLUDED.vtt,
+    text = EXCLUDED.text,
+    updated_at = now()
+RETURNING *;
+
+-- name: GetContentTranscript :one
+SELECT * FROM content_transcripts
+WHERE content_item_id = $1;
@@ -0,0 +1,24 @@
+-- name: UpsertContentTypeMapping :one
+INSERT INTO content_type_mappings (extension, content_type, created_by, created_at, updated_at)
+VALUES ($1, $2, $3, now(), now())
+ON CONFLICT (extension)
+DO UPDATE SET
+    content_type = EXCLUDED.content_type,
+    created_by = EXCLUDED.created_by,
+    updated_at = now()
+RETURNING *;
+
+-- name: ListContentTypeMappings :many
+SELECT * FROM content_type_mappings
+ORDER BY extension;
+
+-- name: ListUnknownContentItems :many
+SELECT id, relative_path FROM content_items
+WHERE content_type = 'unknown'
+ORDER BY relative_path;
+
+-- name: SetContentItemsType :execrows
+UPDATE content_items
+SET content_type = @content_type,
+    updated_at = now()
+WHERE id = ANY(@ids::uuid[]);
@@ -0,0 +1,19 @@
+-- name: AssignCourse :exec
+INSERT INTO course_assignments (course_id, profile_id, assigned_by, created_at)
+VALUES ($1, $2, $3, now())
+ON CONFLICT (course_id, profile_id) DO NOTHING;
+
+-- name: ClearCourseAssignments :exec
+DELETE FROM course_assignments
+WHERE profile_id = $1;
+
+-- name: ListAssignedCourseIDs :many
+SELECT course_id FROM course_assignments
+WHERE profile_id = $1
+ORDER BY created_at, course_id;
+
+-- name: SetProfileCoursesRestricted :exec
+UPDATE profiles
+SET courses_restricted = $2,
+    updated_at = now()
+WHERE id = $1;
@@ -0,0 +1,13 @@
+-- name: EnrollProfile :exec
+INSERT INTO course_enrollments (course_id, user_id, created_at)
+VALUES ($1, $2, now())
+ON CONFLICT (course_id, user_id) DO NOTHING;
+
+-- name: ListCourseEnrollments :many
+SELECT user_id FROM course_enrollments
+WHERE course_id = $1
+ORDER BY created_at;
+
+-- name: ListEnrolledCourseIDs :many
+SELECT course_id FROM course_enrollments
+WHERE user_id = $1;
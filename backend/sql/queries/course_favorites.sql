@@ -0,0 +1,13 @@
+-- name: AddCourseFavorite :exec
+INSERT INTO course_favorites (course_id, user_id, created_at)
+VALUES ($1, $2, now())
+ON CONFLICT (course_id, user_id) DO NOTHING;
+
+-- name: RemoveCourseFavorite :exec
+DELETE FROM course_favorites
+WHERE course_id = $1 AND user_id = $2;
+
+-- name: ListFavoriteCourseIDs :many
+SELECT course_id FROM course_favorites
+WHERE user_id = $1
+ORDER BY created_at DESC;
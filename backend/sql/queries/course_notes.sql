@@ -0,0 +1,24 @@
+-- name: GetCourseNote :one
+SELECT * FROM course_notes
+WHERE course_id = $1 AND user_id = $2;
+
+-- name: UpsertCourseNote :one
+INSERT INTO course_notes (
+    id, course_id, user_id, content, created_at, updated_at
+) VALUES (
+    gen_random_uuid(), $1, $2, $3, now(), now()
+)
+ON CONFLICT (course_id, user_id)
+DO UPDATE SET
+    content = EXCLUDED.content,
+    updated_at = now()
+RETURNING *;
+
+-- name: DeleteCourseNote :exec
+DELETE FROM course_notes
+WHERE course_id = $1 AND user_id = $2;
+
+-- name: ListCourseNotesByUser :many
+SELECT * FROM course_notes
+WHERE user_id = $1
+ORDER BY updated_at DESC;
@@ -0,0 +1,17 @@
+-- name: AddCoursePrerequisite :exec
+INSERT INTO course_prerequisites (course_id, prerequisite_id, created_at)
+VALUES ($1, $2, now())
+ON CONFLICT (course_id, prerequisite_id) DO NOTHING;
+
+-- name: ClearCoursePrerequisites :exec
+DELETE FROM course_prerequisites
+WHERE course_id = $1;
+
+-- name: ListCoursePrerequisites :many
+SELECT prerequisite_id FROM course_prerequisites
+WHERE course_id = $1
+ORDER BY created_at, prerequisite_id;
+
+-- name: ListAllCoursePrerequisites :many
+SELECT course_id, prerequisite_id FROM course_prerequisites
+ORDER BY course_id, created_at;
@@ -0,0 +1,15 @@
+-- name: SetCourseSortOrder :exec
+INSERT INTO course_sort_orders (user_id, course_ids, updated_at)
+VALUES ($1, $2, now())
+ON CONFLICT (user_id)
+DO UPDATE SET
+    course_ids = EXCLUDED.course_ids,
+    updated_at = now();
+
+-- name: GetCourseSortOrder :one
+SELECT course_ids FROM course_sort_orders
+WHERE user_id = $1;
+
+-- name: CountExistingCourses :one
+SELECT COUNT(*) FROM courses
+WHERE id = ANY(@ids::uuid[]);
@@ -17,9 +17,12 @@ INSERT INTO courses (
     title,
     description,
     creator_id,
-    relative_path
+    relative_path,
+    tags,
+    difficulty,
+    language
 ) VALUES (
-    $1, $2, $3, $4, $5
+    $1, $2, $3, $4, $5, $6, $7, $8
 )
 RETURNING *;
 
@@ -28,6 +31,10 @@ UPDATE courses
 SET
     title = $2,
     description = $3,
+    tags = $4,
+    difficulty = $5,
+    creator_id = $6,
+    language = $7,
     updated_at = now()
 WHERE id = $1
 RETURNING *;
@@ -36,3 +43,48 @@ RETURNING *;
 DELETE FROM courses
 WHERE id = $1;
 
+
+-- name: GetCourseByRelativePath :one
+SELECT * FROM courses
+WHERE relative_path = $1 AND cloned_from IS NULL
+LIMIT 1;
+
+-- name: UpdateCourseTitle :exec
+UPDATE courses
+SET
+    title = $2,
+    updated_at = now()
+WHERE id = $1;
+
+-- name: RestoreCourse :exec
+INSERT INTO courses (id, title, description, creator_id, relative_path, updated_at)
+VALUES ($1, $2, $3, $4, $5, now())
+ON CONFLICT (id)
+DO UPDATE SET
+    title = EXCLUDED.title,
+    description = EXCLUDED.description,
+    creator_id = EXCLUDED.creator_id,
+    relative_path = EXCLUDED.relative_path,
+    updated_at = now();
+
+-- name: CountCourseContent :one
+SELECT COUNT(DISTINCT m.id) AS modules, COUNT(ci.id) AS content_items
+FROM modules m
+LEFT JOIN content_items ci ON ci.module_id = m.id
+WHERE m.course_id = $1;
+
+-- name: CountCourseProgress :one
+SELECT COUNT(*)
+FROM user_progress up
+JOIN content_items ci ON ci.id = up.content_item_id
+JOIN modules m ON m.id = ci.module_id
+WHERE m.course_id = $1;
+
+-- name: CreateCourseClone :one
+INSERT INTO courses (id, title, description, creator_id, relative_path, tags, difficulty, language, cloned_from)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: CountCoursesByRelativePath :one
+SELECT COUNT(*) FROM courses
+WHERE relative_path = $1;
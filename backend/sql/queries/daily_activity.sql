@@ -0,0 +1,30 @@
+-- name: RecordDailyActivity :one
+INSERT INTO daily_activity (profile_id, day)
+VALUES ($1, $2)
+ON CONFLICT (profile_id, day)
+DO UPDATE SET updates = daily_activity.updates + 1
+RETURNING updates;
+
+-- name: ListActivityDays :many
+SELECT day FROM daily_activity
+WHERE profile_id = $1
+ORDER BY day DESC;
+
+-- name: AdvanceProfileStreak :exec
+UPDATE profiles
+SET streak = CASE WHEN last_active_date = @day::date - 1 THEN streak + 1 ELSE 1 END,
+    longest_streak = GREATEST(longest_streak, CASE WHEN last_active_date = @day::date - 1 THEN streak + 1 ELSE 1 END),
+    last_active_date = @day::date
+WHERE id = @id AND (last_active_date IS NULL OR last_active_date < @day::date);
+
+-- name: SetProfileStreak :exec
+UPDATE profiles
+SET streak = $2,
+    longest_streak = $3,
+    last_active_date = $4
+WHERE id = $1;
+
+-- name: CreateRepairedActivityDay :execrows
+INSERT INTO daily_activity (profile_id, day, updates, repaired)
+VALUES (@profile_id, @day, 0, true)
+ON CONFLICT (profile_id, day) DO NOTHING;
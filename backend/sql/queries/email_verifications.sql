@@ -0,0 +1,36 @@
+-- name: SetProfileEmail :one
+UPDATE profiles
+SET email = $2,
+    email_verified_at = NULL,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: SetProfileEmailVerified :one
+UPDATE profiles
+SET email_verified_at = now(),
+    updated_at = now()
+WHERE id = $1 AND email = $2
+RETURNING *;
+
+-- name: UpsertEmailVerification :one
+INSERT INTO email_verifications (profile_id, email, token_hash, expires_at, created_at)
+VALUES (@profile_id, @email, @token_hash, @expires_at, now())
+ON CONFLICT (profile_id) DO UPDATE
+SET email = EXCLUDED.email,
+    token_hash = EXCLUDED.token_hash,
+    expires_at = EXCLUDED.expires_at,
+    created_at = now()
+RETURNING *;
+
+-- name: GetEmailVerification :one
+SELECT * FROM email_verifications
+WHERE profile_id = $1;
+
+-- name: GetEmailVerificationByHash :one
+SELECT * FROM email_verifications
+WHERE token_hash = $1;
+
+-- name: DeleteEmailVerification :exec
+DELETE FROM email_verifications
+WHERE profile_id = $1;
@@ -0,0 +1,59 @@
+-- name: CreateXPAward :execrows
+INSERT INTO xp_awards (profile_id, source, subject_id, xp, created_at)
+VALUES (@profile_id, @source, @subject_id, @xp, now())
+ON CONFLICT (profile_id, source, subject_id) DO NOTHING;
+
+-- name: AddProfileExperience :one
+UPDATE profiles
+SET experience = experience + @xp,
+    updated_at = now()
+WHERE id = @id
+RETURNING experience;
+
+-- name: AddProfileGems :one
+UPDATE profiles
+SET gems = gems + @gems,
+    updated_at = now()
+WHERE id = @id
+RETURNING gems;
+
+-- name: SpendProfileGems :one
+UPDATE profiles
+SET gems = gems - @cost,
+    updated_at = now()
+WHERE id = @id AND gems >= @cost
+RETURNING gems;
+
+-- name: CreateProfileUnlock :execrows
+INSERT INTO profile_unlocks (profile_id, item, created_at)
+VALUES (@profile_id, @item, now())
+ON CONFLICT DO NOTHING;
+
+-- name: ListProfileUnlocks :many
+SELECT * FROM profile_unlocks
+WHERE profile_id = @profile_id
+ORDER BY created_at;
+
+-- name: BuyStreakFreeze :one
+UPDATE profiles
+SET gems = gems - @cost,
+    streak_freezes = streak_freezes + 1,
+    updated_at = now()
+WHERE id = @id AND gems >= @cost AND streak_freezes < @max_freezes
+RETURNING gems, streak_freezes;
+
+-- name: GetStreakFreezeDay :one
+SELECT * FROM streak_freeze_days
+WHERE profile_id = @profile_id AND day = @day;
+
+-- name: UseStreakFreeze :one
+UPDATE profiles
+SET streak_freezes = streak_freezes - 1,
+    updated_at = now()
+WHERE id = @id AND streak_freezes > 0
+RETURNING streak_freezes;
+
+-- name: CreateStreakFreezeDay :exec
+INSERT INTO streak_freeze_days (profile_id, day)
+VALUES (@profile_id, @day)
+ON CONFLICT DO NOTHING;
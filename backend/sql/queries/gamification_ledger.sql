@@ -0,0 +1,9 @@
+-- name: CreateLedgerEntry :exec
+INSERT INTO gamification_ledger (id, profile_id, stat, amount, balance, reason, subject, source_event, description, created_at)
+VALUES (gen_random_uuid(), @profile_id, @stat, @amount, @balance, @reason, @subject, @source_event, @description, now());
+
+-- name: ListLedgerEntries :many
+SELECT * FROM gamification_ledger
+WHERE profile_id = @profile_id AND (@stat::text = '' OR stat = @stat::text)
+ORDER BY created_at DESC, id
+LIMIT @max_results OFFSET @skip;
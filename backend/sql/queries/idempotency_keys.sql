@@ -0,0 +1,22 @@
+-- name: ClaimIdempotencyKey :execrows
+INSERT INTO idempotency_keys (profile_id, route, key, request_hash, created_at)
+VALUES ($1, $2, $3, $4, now())
+ON CONFLICT (profile_id, route, key) DO NOTHING;
+
+-- name: GetIdempotencyKey :one
+SELECT * FROM idempotency_keys
+WHERE profile_id = $1 AND route = $2 AND key = $3;
+
+-- name: CompleteIdempotencyKey :exec
+UPDATE idempotency_keys
+SET status_code = $4,
+    response_body = $5
+WHERE profile_id = $1 AND route = $2 AND key = $3;
+
+-- name: ReleaseIdempotencyKey :exec
+DELETE FROM idempotency_keys
+WHERE profile_id = $1 AND route = $2 AND key = $3;
+
+-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys
+WHERE created_at < $1;
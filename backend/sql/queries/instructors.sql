@@ -0,0 +1,53 @@
+-- name: CreateInstructor :one
+INSERT INTO instructors (id, name, bio, avatar_url, created_at, updated_at)
+VALUES ($1, $2, $3, $4, now(), now())
+RETURNING *;
+
+-- name: GetInstructor :one
+SELECT * FROM instructors
+WHERE id = $1;
+
+-- name: GetInstructorByName :one
+SELECT * FROM instructors
+WHERE lower(name) = lower(@name::text);
+
+-- name: ListInstructors :many
+SELECT i.*, COUNT(ci.course_id) AS course_count
+FROM instructors i
+LEFT JOIN course_instructors ci ON ci.instructor_id = i.id
+GROUP BY i.id
+ORDER BY i.name;
+
+-- name: UpdateInstructor :one
+UPDATE instructors
+SET name = $2,
+    bio = $3,
+    avatar_url = $4,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteInstructor :execrows
+DELETE FROM instructors
+WHERE id = $1;
+
+-- name: AddCourseInstructor :exec
+INSERT INTO course_instructors (course_id, instructor_id, position)
+VALUES ($1, $2, $3)
+ON CONFLICT (course_id, instructor_id) DO NOTHING;
+
+-- name: ClearCourseInstructors :exec
+DELETE FROM course_instructors
+WHERE course_id = $1;
+
+-- name: ListCourseInstructors :many
+SELECT i.* FROM course_instructors ci
+JOIN instructors i ON i.id = ci.instructor_id
+WHERE ci.course_id = $1
+ORDER BY ci.position;
+
+-- name: ListInstructorCourses :many
+SELECT c.id, c.title FROM course_instructors ci
+JOIN courses c ON c.id = ci.course_id
+WHERE ci.instructor_id = $1
+ORDER BY c.title;
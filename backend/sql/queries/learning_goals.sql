@@ -0,0 +1,62 @@
+-- name: CreateLearningGoal :one
+INSERT INTO learning_goals (id, profile_id, goal_type, target_minutes, course_id, due_date, status, evaluated_through, target_items, created_at, updated_at)
+VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, 'active', $6, $7, now(), now())
+RETURNING *;
+
+-- name: GetLearningGoal :one
+SELECT * FROM learning_goals
+WHERE id = $1;
+
+-- name: ListLearningGoals :many
+SELECT * FROM learning_goals
+WHERE profile_id = $1
+ORDER BY created_at;
+
+-- name: ListActiveLearningGoals :many
+SELECT * FROM learning_goals
+WHERE status = 'active'
+ORDER BY profile_id, created_at;
+
+-- name: UpdateLearningGoal :one
+UPDATE learning_goals
+SET target_minutes = $2,
+    due_date = $3,
+    status = $4,
+    target_items = $5,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteLearningGoal :exec
+DELETE FROM learning_goals
+WHERE id = $1;
+
+-- name: SetLearningGoalEvaluation :exec
+UPDATE learning_goals
+SET status = $2,
+    streak = $3,
+    longest_streak = $4,
+    evaluated_through = $5,
+    updated_at = now()
+WHERE id = $1;
+
+-- name: CreateGoalEvaluation :exec
+INSERT INTO goal_evaluations (goal_id, day, met, minutes, items, frozen)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (goal_id, day) DO UPDATE SET met = EXCLUDED.met, minutes = EXCLUDED.minutes, items = EXCLUDED.items, frozen = EXCLUDED.frozen;
+
+-- name: ListGoalEvaluations :many
+SELECT * FROM goal_evaluations
+WHERE goal_id = $1
+ORDER BY day DESC
+LIMIT $2;
+
+-- name: GetWatchSecondsBetween :one
+SELECT COALESCE(SUM(seconds), 0)::bigint AS total_seconds
+FROM watch_sessions
+WHERE profile_id = $1 AND started_at >= @from_time::timestamp AND started_at < @to_time::timestamp AND NOT suspicious;
+
+-- name: CountCompletionsBetween :one
+SELECT COUNT(*)
+FROM user_progress
+WHERE user_id = $1 AND completed AND completed_at >= @from_time::timestamp AND completed_at < @to_time::timestamp;
@@ -0,0 +1,45 @@
+-- name: CreateLearningPath :one
+INSERT INTO learning_paths (id, title, description, creator_id, created_at, updated_at)
+VALUES ($1, $2, $3, $4, now(), now())
+RETURNING *;
+
+-- name: GetLearningPath :one
+SELECT * FROM learning_paths
+WHERE id = $1;
+
+-- name: ListLearningPaths :many
+SELECT * FROM learning_paths
+ORDER BY title, id;
+
+-- name: UpdateLearningPath :one
+UPDATE learning_paths
+SET title = $2,
+    description = $3,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteLearningPath :execrows
+DELETE FROM learning_paths
+WHERE id = $1;
+
+-- name: AddLearningPathCourse :exec
+INSERT INTO learning_path_courses (path_id, course_id, position)
+VALUES ($1, $2, $3);
+
+-- name: ClearLearningPathCourses :exec
+DELETE FROM learning_path_courses
+WHERE path_id = $1;
+
+-- name: ListLearningPathCourses :many
+SELECT pc.path_id, pc.course_id, c.title
+FROM learning_path_courses pc
+JOIN courses c ON c.id = pc.course_id
+WHERE pc.path_id = $1
+ORDER BY pc.position;
+
+-- name: ListAllLearningPathCourses :many
+SELECT pc.path_id, pc.course_id, c.title
+FROM learning_path_courses pc
+JOIN courses c ON c.id = pc.course_id
+ORDER BY pc.path_id, pc.position;
@@ -0,0 +1,22 @@
+-- name: CreateLibrarySnapshot :one
+INSERT INTO library_snapshots (id, label, created_by, course_count, data, created_at)
+VALUES (gen_random_uuid(), $1, $2, $3, $4, now())
+RETURNING id, label, created_by, course_count, restored_at, created_at;
+
+-- name: ListLibrarySnapshots :many
+SELECT id, label, created_by, course_count, restored_at, created_at
+FROM library_snapshots
+ORDER BY created_at DESC;
+
+-- name: GetLibrarySnapshot :one
+SELECT * FROM library_snapshots
+WHERE id = $1;
+
+-- name: MarkLibrarySnapshotRestored :exec
+UPDATE library_snapshots
+SET restored_at = now()
+WHERE id = $1;
+
+-- name: DeleteLibrarySnapshot :execrows
+DELETE FROM library_snapshots
+WHERE id = $1;
@@ -34,3 +34,37 @@ RETURNING *;
 DELETE FROM modules
 WHERE id = $1;
 
+
+-- name: ListAllModules :many
+SELECT * FROM modules
+ORDER BY course_id, "order" ASC;
+
+-- name: UpdateModuleTitle :exec
+UPDATE modules
+SET
+    title = $2,
+    updated_at = now()
+WHERE id = $1;
+
+-- name: UpdateModuleOrder :exec
+UPDATE modules
+SET
+    "order" = $2,
+    updated_at = now()
+WHERE id = $1;
+
+-- name: RestoreModule :exec
+INSERT INTO modules (id, course_id, title, description, relative_path, "order", updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, now())
+ON CONFLICT (id)
+DO UPDATE SET
+    course_id = EXCLUDED.course_id,
+    title = EXCLUDED.title,
+    description = EXCLUDED.description,
+    relative_path = EXCLUDED.relative_path,
+    "order" = EXCLUDED."order",
+    updated_at = now();
+
+-- name: DeleteModulesNotIn :exec
+DELETE FROM modules
+WHERE course_id = @course_id AND NOT (id = ANY(@keep_ids::uuid[]));
@@ -0,0 +1,41 @@
+-- name: ListNotificationPreferences :many
+SELECT * FROM notification_preferences
+WHERE profile_id = $1
+ORDER BY category;
+
+-- name: UpsertNotificationPreference :one
+INSERT INTO notification_preferences (profile_id, category, frequency, updated_at)
+VALUES ($1, $2, $3, now())
+ON CONFLICT (profile_id, category)
+DO UPDATE SET
+    frequency = EXCLUDED.frequency,
+    updated_at = now()
+RETURNING *;
+
+-- name: MarkDigestSent :exec
+INSERT INTO notification_preferences (profile_id, category, frequency, last_digest_at, updated_at)
+VALUES ($1, $2, $3, $4, now())
+ON CONFLICT (profile_id, category)
+DO UPDATE SET
+    last_digest_at = EXCLUDED.last_digest_at;
+
+-- name: CreateNotification :one
+INSERT INTO notifications (id, profile_id, category, title, body, created_at)
+VALUES (gen_random_uuid(), $1, $2, $3, $4, now())
+RETURNING *;
+
+-- name: ListPendingNotifications :many
+SELECT * FROM notifications
+WHERE delivered_at IS NULL
+ORDER BY profile_id, category, created_at;
+
+-- name: MarkNotificationsDelivered :exec
+UPDATE notifications
+SET delivered_at = now()
+WHERE id = ANY(@ids::uuid[]);
+
+-- name: ListNotificationsByProfile :many
+SELECT * FROM notifications
+WHERE profile_id = $1
+ORDER BY created_at DESC
+LIMIT $2;
@@ -0,0 +1,22 @@
+-- name: GetProfileIdentity :one
+SELECT *
+FROM profile_identities
+WHERE issuer = $1
+  AND subject = $2;
+
+-- name: CountProfileIdentities :one
+SELECT COUNT(*)
+FROM profile_identities
+WHERE profile_id = $1;
+
+-- name: CreateProfileIdentity :execrows
+INSERT INTO profile_identities (issuer, subject, profile_id, email, created_at, last_login_at)
+VALUES ($1, $2, $3, $4, now(), now())
+ON CONFLICT (issuer, subject) DO NOTHING;
+
+-- name: TouchProfileIdentity :exec
+UPDATE profile_identities
+SET email         = $3,
+    last_login_at = now()
+WHERE issuer = $1
+  AND subject = $2;
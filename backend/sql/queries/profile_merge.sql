@@ -0,0 +1,91 @@
+-- name: MergeProfileProgress :execrows
+INSERT INTO user_progress (id, user_id, content_item_id, completed, progress_pct, last_position, last_accessed, completed_at, created_at, updated_at)
+SELECT gen_random_uuid(), @target_id::uuid, content_item_id, completed, progress_pct, last_position, last_accessed, completed_at, created_at, now()
+FROM user_progress
+WHERE user_id = @source_id
+ON CONFLICT (user_id, content_item_id) DO UPDATE
+SET completed = user_progress.completed OR EXCLUDED.completed,
+    progress_pct = GREATEST(user_progress.progress_pct, EXCLUDED.progress_pct),
+    last_position = CASE WHEN EXCLUDED.progress_pct > user_progress.progress_pct THEN EXCLUDED.last_position ELSE user_progress.last_position END,
+    last_accessed = GREATEST(user_progress.last_accessed, EXCLUDED.last_accessed),
+    completed_at = LEAST(user_progress.completed_at, EXCLUDED.completed_at),
+    updated_at = now();
+
+-- name: MergeProfileCourseNotes :execrows
+INSERT INTO course_notes (id, course_id, user_id, content, created_at, updated_at)
+SELECT gen_random_uuid(), course_id, @target_id::uuid, content, created_at, updated_at
+FROM course_notes
+WHERE user_id = @source_id
+ON CONFLICT (course_id, user_id) DO UPDATE
+SET content = course_notes.content || E'\n\n' || EXCLUDED.content,
+    updated_at = now();
+
+-- name: MoveProfileContentNotes :execrows
+UPDATE content_notes
+SET user_id = @target_id
+WHERE user_id = @source_id;
+
+-- name: MoveProfileBookmarks :execrows
+UPDATE content_bookmarks
+SET user_id = @target_id
+WHERE user_id = @source_id;
+
+-- name: MergeProfileFavorites :execrows
+INSERT INTO course_favorites (course_id, user_id, created_at)
+SELECT course_id, @target_id::uuid, created_at
+FROM course_favorites
+WHERE user_id = @source_id
+ON CONFLICT (course_id, user_id) DO NOTHING;
+
+-- name: GetOverlappingXP :one
+SELECT COALESCE(SUM(s.xp), 0)::int AS xp
+FROM xp_awards s
+JOIN xp_awards t ON t.source = s.source AND t.subject_id = s.subject_id
+WHERE s.profile_id = @source_id AND t.profile_id = @target_id;
+
+-- name: MergeProfileXPAwards :exec
+INSERT INTO xp_awards (profile_id, source, subject_id, xp, created_at)
+SELECT @target_id::uuid, source, subject_id, xp, created_at
+FROM xp_awards
+WHERE profile_id = @source_id
+ON CONFLICT (profile_id, source, subject_id) DO NOTHING;
+
+-- name: MergeProfileActivity :exec
+INSERT INTO daily_activity (profile_id, day, updates, repaired)
+SELECT @target_id::uuid, day, updates, repaired
+FROM daily_activity
+WHERE profile_id = @source_id
+ON CONFLICT (profile_id, day) DO UPDATE
+SET updates = daily_activity.updates + EXCLUDED.updates,
+    repaired = daily_activity.repaired AND EXCLUDED.repaired;
+
+-- name: MergeProfileFreezeDays :exec
+INSERT INTO streak_freeze_days (profile_id, day)
+SELECT @target_id::uuid, day
+FROM streak_freeze_days
+WHERE profile_id = @source_id
+ON CONFLICT DO NOTHING;
+
+-- name: MergeProfileUnlocks :exec
+INSERT INTO profile_unlocks (profile_id, item, created_at)
+SELECT @target_id::uuid, item, created_at
+FROM profile_unlocks
+WHERE profile_id = @source_id
+ON CONFLICT DO NOTHING;
+
+-- name: AddProfileStreakFreezes :one
+UPDATE profiles
+SET streak_freezes = streak_freezes + @freezes,
+    updated_at = now()
+WHERE id = @id
+RETURNING streak_freezes;
+
+-- name: MoveProfileCourses :exec
+UPDATE courses
+SET creator_id = @target_id
+WHERE creator_id = @source_id;
+
+-- name: MoveProfileIdentities :exec
+UPDATE profile_identities
+SET profile_id = @target_id
+WHERE profile_id = @source_id;
@@ -1,15 +1,20 @@
 -- name: CreateProfile :one
-INSERT INTO profiles (id, created_at, updated_at, name)
+INSERT INTO profiles (id, created_at, updated_at, name, role)
 VALUES (
     $1,
     now(),
     now(),
-    $2
+    $2,
+    $3
 )
 RETURNING *;
 
+-- name: LockProfiles :exec
+LOCK TABLE profiles IN SHARE ROW EXCLUSIVE MODE;
+
 -- name: GetAllProfiles :many
-SELECT * FROM profiles;
+SELECT * FROM profiles
+WHERE NOT is_guest;
 
 -- name: GetProfileById :one
 SELECT *
@@ -40,4 +45,36 @@ WHERE name LIKE $1;
 
 -- name: GetProfilesCount :one
 SELECT COUNT(*)
-FROM profiles;
\ No newline at end of file
+FROM profiles
+WHERE NOT is_guest;
+
+-- name: SetProfileRole :one
+UPDATE profiles
+SET role       = $2,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: SetProfileAvatarUpdated :one
+UPDATE profiles
+SET avatar_updated_at = now(),
+    updated_at        = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateGuestProfile :one
+INSERT INTO profiles (id, created_at, updated_at, name, is_guest)
+VALUES (@id, now(), now(), @name, true)
+RETURNING *;
+
+-- name: DeleteGuestProfile :execrows
+DELETE
+FROM profiles
+WHERE id = $1 AND is_guest;
+
+-- name: DeleteAbandonedGuestProfiles :many
+DELETE FROM profiles
+WHERE is_guest AND NOT EXISTS (
+    SELECT 1 FROM sessions s WHERE s.user_id = profiles.id
+)
+RETURNING id;
@@ -0,0 +1,48 @@
+-- name: CreateQuest :execrows
+INSERT INTO quests (id, profile_id, week, quest_type, title, target, course_id, reward_xp, reward_gems, created_at)
+VALUES (gen_random_uuid(), @profile_id, @week, @quest_type, @title, @target, @course_id, @reward_xp, @reward_gems, now())
+ON CONFLICT (profile_id, week, quest_type) DO NOTHING;
+
+-- name: ListQuests :many
+SELECT * FROM quests
+WHERE profile_id = @profile_id AND week = @week
+ORDER BY created_at, quest_type;
+
+-- name: SetQuestProgress :exec
+UPDATE quests
+SET progress = @progress
+WHERE id = @id;
+
+-- name: CompleteQuest :execrows
+UPDATE quests
+SET progress = target,
+    completed_at = now()
+WHERE id = @id AND completed_at IS NULL;
+
+-- name: CountCompletionsOfTypeBetween :one
+SELECT COUNT(*)
+FROM user_progress up
+JOIN content_items ci ON ci.id = up.content_item_id
+WHERE up.user_id = @user_id AND ci.content_type = @content_type AND up.completed
+  AND up.completed_at >= @from_time::timestamp AND up.completed_at < @to_time::timestamp;
+
+-- name: CountModulesFinishedBetween :one
+SELECT COUNT(*) FROM (
+    SELECT m.id
+    FROM modules m
+    JOIN content_items ci ON ci.module_id = m.id AND NOT ci.hidden
+    LEFT JOIN user_progress up ON up.content_item_id = ci.id AND up.user_id = @user_id
+    WHERE m.course_id = @course_id
+    GROUP BY m.id
+    HAVING bool_and(COALESCE(up.completed, false))
+       AND MAX(up.completed_at) >= @from_time::timestamp AND MAX(up.completed_at) < @to_time::timestamp
+) finished;
+
+-- name: ListStartedCourseIDs :many
+SELECT m.course_id
+FROM user_progress up
+JOIN content_items ci ON ci.id = up.content_item_id
+JOIN modules m ON m.id = ci.module_id
+WHERE up.user_id = @user_id
+GROUP BY m.course_id
+ORDER BY MAX(up.last_accessed) DESC NULLS LAST;
@@ -0,0 +1,18 @@
+-- name: SearchCourses :many
+SELECT * FROM courses
+WHERE title ILIKE @pattern::text OR description ILIKE @pattern::text
+ORDER BY (title ILIKE @pattern::text) DESC, title
+LIMIT @max_results;
+
+-- name: SearchContentItems :many
+SELECT ci.id, ci.title, ci.content_type, ci.module_id, m.course_id, c.title AS course_title,
+    COALESCE(t.text, '')::text AS transcript,
+    COALESCE(to_tsvector('simple', t.text) @@ plainto_tsquery('simple', @query::text), false)::boolean AS transcript_match
+FROM content_items ci
+JOIN modules m ON m.id = ci.module_id
+JOIN courses c ON c.id = m.course_id
+LEFT JOIN content_transcripts t ON t.content_item_id = ci.id
+WHERE ci.title ILIKE @pattern::text
+   OR to_tsvector('simple', t.text) @@ plainto_tsquery('simple', @query::text)
+ORDER BY (ci.title ILIKE @pattern::text) DESC, c.title, m."order", ci."order"
+LIMIT @max_results;
@@ -1,18 +1,16 @@
 -- name: CreateSession :one
-INSERT INTO sessions (id, user_id, created_at, updated_at)
+INSERT INTO sessions (id, user_id, expires_at, user_agent, last_seen_at, created_at, updated_at)
 VALUES (
     $1,
     $2,
+    $3,
+    $4,
+    now(),
     now(),
     now()
 )
 RETURNING *;
 
--- name: GetActiveSession :one
-SELECT * FROM sessions
-ORDER BY created_at DESC
-LIMIT 1;
-
 -- name: DeleteSession :exec
 DELETE FROM sessions
 WHERE id = $1;
@@ -23,3 +21,33 @@ DELETE FROM sessions;
 -- name: GetSessionByID :one
 SELECT * FROM sessions
 WHERE id = $1;
+
+-- name: ListSessionsByProfile :many
+SELECT * FROM sessions
+WHERE user_id = $1
+ORDER BY last_seen_at DESC;
+
+-- name: TouchSession :exec
+UPDATE sessions
+SET last_seen_at = now()
+WHERE id = $1;
+
+-- name: SetSessionImpersonation :one
+UPDATE sessions
+SET
+    impersonated_id = $2,
+    impersonation_expires_at = $3,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: RefreshSession :one
+UPDATE sessions
+SET expires_at = $2,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteExpiredSessions :execrows
+DELETE FROM sessions
+WHERE expires_at < $1;
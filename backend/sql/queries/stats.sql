@@ -0,0 +1,43 @@
+-- name: ListCompletionsSince :many
+SELECT up.completed_at, m.course_id
+FROM user_progress up
+JOIN content_items ci ON up.content_item_id = ci.id
+JOIN modules m ON ci.module_id = m.id
+WHERE up.user_id = $1 AND up.completed AND up.completed_at >= @since::timestamp;
+
+-- name: ListWatchSessionsSince :many
+SELECT ws.started_at, ws.seconds, m.course_id
+FROM watch_sessions ws
+JOIN content_items ci ON ws.content_item_id = ci.id
+JOIN modules m ON ci.module_id = m.id
+WHERE ws.profile_id = $1 AND ws.started_at >= @since::timestamp AND NOT ws.suspicious;
+
+-- name: ListActivityCoursesSince :many
+SELECT created_at, course_id
+FROM activity_events
+WHERE profile_id = $1 AND course_id IS NOT NULL AND created_at >= @since::timestamp;
+
+-- name: ListDailyActivityBetween :many
+SELECT day, updates FROM daily_activity
+WHERE profile_id = $1 AND day >= @first_day::date AND day <= @last_day::date AND NOT repaired
+ORDER BY day;
+
+-- name: ListXPLeaderboard :many
+SELECT p.id, p.name, COALESCE(SUM(a.xp), 0)::bigint AS score,
+    (RANK() OVER (ORDER BY COALESCE(SUM(a.xp), 0) DESC))::bigint AS rank
+FROM profiles p
+LEFT JOIN xp_awards a ON a.profile_id = p.id AND a.created_at >= @since::timestamp
+WHERE NOT p.is_guest
+GROUP BY p.id, p.name
+ORDER BY score DESC, p.name, p.id
+LIMIT @max_results OFFSET @skip;
+
+-- name: ListWatchLeaderboard :many
+SELECT p.id, p.name, COALESCE(SUM(ws.seconds), 0)::bigint AS score,
+    (RANK() OVER (ORDER BY COALESCE(SUM(ws.seconds), 0) DESC))::bigint AS rank
+FROM profiles p
+LEFT JOIN watch_sessions ws ON ws.profile_id = p.id AND ws.started_at >= @since::timestamp AND NOT ws.suspicious
+WHERE NOT p.is_guest
+GROUP BY p.id, p.name
+ORDER BY score DESC, p.name, p.id
+LIMIT @max_results OFFSET @skip;
@@ -0,0 +1,13 @@
+-- name: SaveTask :exec
+INSERT INTO tasks (id, type, status, input, result, message, error_message, retries, logs, created_at, started_at, completed_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+ON CONFLICT (id) DO UPDATE SET status = EXCLUDED.status, result = EXCLUDED.result, message = EXCLUDED.message, error_message = EXCLUDED.error_message, retries = EXCLUDED.retries, logs = EXCLUDED.logs, started_at = EXCLUDED.started_at, completed_at = EXCLUDED.completed_at;
+
+-- name: ListUnfinishedTasks :many
+SELECT * FROM tasks
+WHERE status <> 'completed'
+ORDER BY created_at;
+
+-- name: DeleteFinishedTasksBefore :execrows
+DELETE FROM tasks
+WHERE status IN ('completed', 'failed') AND completed_at < $1;
@@ -0,0 +1,35 @@
+-- name: GetProfileTimeLimits :one
+SELECT * FROM profile_time_limits
+WHERE profile_id = $1;
+
+-- name: UpsertProfileTimeLimits :one
+INSERT INTO profile_time_limits (
+    profile_id,
+    daily_minutes,
+    weekly_minutes,
+    warning_pct
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (profile_id)
+DO UPDATE SET
+    daily_minutes = EXCLUDED.daily_minutes,
+    weekly_minutes = EXCLUDED.weekly_minutes,
+    warning_pct = EXCLUDED.warning_pct,
+    updated_at = now()
+RETURNING *;
+
+-- name: DeleteProfileTimeLimits :exec
+DELETE FROM profile_time_limits
+WHERE profile_id = $1;
+
+-- name: AddViewingTime :exec
+INSERT INTO viewing_time_daily (profile_id, day, seconds)
+VALUES ($1, $2, $3)
+ON CONFLICT (profile_id, day)
+DO UPDATE SET seconds = viewing_time_daily.seconds + EXCLUDED.seconds;
+
+-- name: GetViewingSecondsSince :one
+SELECT COALESCE(SUM(seconds), 0)::bigint AS total_seconds
+FROM viewing_time_daily
+WHERE profile_id = $1 AND day >= $2;
@@ -4,16 +4,21 @@ WHERE user_id = $1 AND content_item_id = $2;
 
 -- name: UpsertUserProgress :one
 INSERT INTO user_progress (
-    id, user_id, content_item_id, completed, progress_pct, last_position, last_accessed, created_at, updated_at
+    id, user_id, content_item_id, completed, progress_pct, last_position, last_accessed, completed_at, created_at, updated_at
 ) VALUES (
-    gen_random_uuid(), $1, $2, $3, $4, $5, $6, now(), now()
+    gen_random_uuid(), @user_id, @content_item_id, @completed, @progress_pct, @last_position, @last_accessed,
+    CASE WHEN @completed THEN now() END, now(), now()
 )
 ON CONFLICT (user_id, content_item_id)
 DO UPDATE SET
-    completed = EXCLUDED.completed,
-    progress_pct = EXCLUDED.progress_pct,
+    completed = CASE WHEN @force::boolean THEN EXCLUDED.completed ELSE user_progress.completed OR EXCLUDED.completed END,
+    progress_pct = CASE WHEN @force::boolean THEN EXCLUDED.progress_pct
+        ELSE GREATEST(user_progress.progress_pct, EXCLUDED.progress_pct) END,
     last_position = EXCLUDED.last_position,
     last_accessed = EXCLUDED.last_accessed,
+    completed_at = CASE
+        WHEN EXCLUDED.completed OR (user_progress.completed AND NOT @force::boolean) THEN COALESCE(user_progress.completed_at, now())
+    END,
     updated_at = now()
 RETURNING *;
 
@@ -31,7 +36,7 @@ SELECT
     COALESCE(AVG(up.progress_pct), 0) as avg_progress
 FROM content_items ci
 LEFT JOIN user_progress up ON ci.id = up.content_item_id AND up.user_id = $2
-WHERE ci.module_id = $1;
+WHERE ci.module_id = $1 AND NOT ci.hidden;
 
 -- name: GetCourseProgressStats :one
 SELECT
@@ -40,6 +45,115 @@ SELECT
     COUNT(DISTINCT ci.id) FILTER (WHERE up.completed = true) as completed_items,
     MAX(up.last_accessed) as last_accessed
 FROM modules m
-LEFT JOIN content_items ci ON m.id = ci.module_id
+LEFT JOIN content_items ci ON m.id = ci.module_id AND NOT ci.hidden
 LEFT JOIN user_progress up ON ci.id = up.content_item_id AND up.user_id = $2
 WHERE m.course_id = $1;
+
+-- name: DeleteUserCourseProgress :execrows
+DELETE FROM user_progress
+WHERE user_id = @user_id AND content_item_id IN (
+    SELECT ci.id FROM content_items ci
+    JOIN modules m ON ci.module_id = m.id
+    WHERE m.course_id = @course_id
+);
+
+-- name: CopyCourseProgress :execrows
+INSERT INTO user_progress (id, user_id, content_item_id, completed, progress_pct, last_position, last_accessed, completed_at, created_at, updated_at)
+SELECT gen_random_uuid(), @target_id::uuid, content_item_id, completed, progress_pct, last_position, last_accessed, completed_at, created_at, now()
+FROM user_progress
+WHERE user_id = @source_id AND content_item_id IN (
+    SELECT ci.id FROM content_items ci
+    JOIN modules m ON ci.module_id = m.id
+    WHERE m.course_id = @course_id
+)
+ON CONFLICT (user_id, content_item_id) DO UPDATE
+SET completed = user_progress.completed OR EXCLUDED.completed,
+    progress_pct = GREATEST(user_progress.progress_pct, EXCLUDED.progress_pct),
+    last_position = CASE WHEN EXCLUDED.progress_pct > user_progress.progress_pct THEN EXCLUDED.last_position ELSE user_progress.last_position END,
+    last_accessed = GREATEST(user_progress.last_accessed, EXCLUDED.last_accessed),
+    completed_at = LEAST(user_progress.completed_at, EXCLUDED.completed_at),
+    updated_at = now();
+
+-- name: CompleteModuleItems :execrows
+INSERT INTO user_progress (
+    id, user_id, content_item_id, completed, progress_pct, last_accessed, completed_at, created_at, updated_at
+)
+SELECT gen_random_uuid(), @user_id::uuid, ci.id, true, 100, now(), now(), now(), now()
+FROM content_items ci
+WHERE ci.module_id = @module_id AND NOT ci.hidden
+ON CONFLICT (user_id, content_item_id)
+DO UPDATE SET
+    completed = true,
+    progress_pct = 100,
+    last_accessed = now(),
+    completed_at = COALESCE(user_progress.completed_at, now()),
+    updated_at = now()
+WHERE NOT user_progress.completed OR user_progress.progress_pct < 100;
+
+-- name: CompleteCourseItems :execrows
+INSERT INTO user_progress (
+    id, user_id, content_item_id, completed, progress_pct, last_accessed, completed_at, created_at, updated_at
+)
+SELECT gen_random_uuid(), @user_id::uuid, ci.id, true, 100, now(), now(), now(), now()
+FROM content_items ci
+JOIN modules m ON ci.module_id = m.id
+WHERE m.course_id = @course_id AND NOT ci.hidden
+ON CONFLICT (user_id, content_item_id)
+DO UPDATE SET
+    completed = true,
+    progress_pct = 100,
+    last_accessed = now(),
+    completed_at = COALESCE(user_progress.completed_at, now()),
+    updated_at = now()
+WHERE NOT user_progress.completed OR user_progress.progress_pct < 100;
+
+-- name: ListUserProgressRecords :many
+SELECT c.id AS course_id, c.title AS course_title, m.title AS module_title,
+    ci.id AS content_item_id, ci.title AS content_title, ci.content_type,
+    up.progress_pct, up.completed, up.completed_at, up.last_accessed
+FROM user_progress up
+JOIN content_items ci ON up.content_item_id = ci.id
+JOIN modules m ON ci.module_id = m.id
+JOIN courses c ON m.course_id = c.id
+WHERE up.user_id = $1
+ORDER BY c.title, c.id, m."order", ci."order";
+
+-- name: SyncUserProgress :one
+INSERT INTO user_progress (
+    id, user_id, content_item_id, completed, progress_pct, last_position, last_accessed, completed_at, created_at, updated_at
+) VALUES (
+    gen_random_uuid(), $1, $2, $3, $4, $5, $6, CASE WHEN $3 THEN $6 END, now(), now()
+)
+ON CONFLICT (user_id, content_item_id)
+DO UPDATE SET
+    completed = user_progress.completed OR EXCLUDED.completed,
+    progress_pct = GREATEST(user_progress.progress_pct, EXCLUDED.progress_pct),
+    last_position = CASE
+        WHEN EXCLUDED.progress_pct > user_progress.progress_pct THEN EXCLUDED.last_position
+        WHEN EXCLUDED.progress_pct = user_progress.progress_pct
+            AND EXCLUDED.last_accessed >= COALESCE(user_progress.last_accessed, EXCLUDED.last_accessed) THEN EXCLUDED.last_position
+        ELSE user_progress.last_position
+    END,
+    last_accessed = GREATEST(user_progress.last_accessed, EXCLUDED.last_accessed),
+    completed_at = CASE
+        WHEN user_progress.completed OR EXCLUDED.completed THEN COALESCE(user_progress.completed_at, EXCLUDED.completed_at, now())
+    END,
+    updated_at = now()
+RETURNING *;
+
+-- name: ListCoursesItemProgress :many
+SELECT m.course_id, m.id AS module_id, ci.id AS content_item_id, ci.content_type, ci.duration, ci.size,
+    up.completed, up.progress_pct, up.last_position, up.last_accessed
+FROM modules m
+LEFT JOIN content_items ci ON ci.module_id = m.id AND NOT ci.hidden
+LEFT JOIN user_progress up ON up.content_item_id = ci.id AND up.user_id = @user_id
+WHERE m.course_id = ANY(@course_ids::uuid[])
+ORDER BY m.course_id, m."order", m.id, ci."order";
+
+-- name: ListModuleItemProgress :many
+SELECT ci.id AS content_item_id, ci.content_type, ci.duration, ci.size,
+    up.completed, up.progress_pct, up.last_position, up.last_accessed
+FROM content_items ci
+LEFT JOIN user_progress up ON up.content_item_id = ci.id AND up.user_id = @user_id
+WHERE ci.module_id = @module_id AND NOT ci.hidden
+ORDER BY ci."order";
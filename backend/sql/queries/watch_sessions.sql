@@ -0,0 +1,24 @@
+-- name: GetLatestWatchSession :one
+SELECT * FROM watch_sessions
+WHERE profile_id = $1 AND content_item_id = $2
+ORDER BY last_beat_at DESC
+LIMIT 1;
+
+-- name: CreateWatchSession :one
+INSERT INTO watch_sessions (id, profile_id, content_item_id, started_at, last_beat_at, seconds, suspicious, flag_reason)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: UpdateWatchSession :one
+UPDATE watch_sessions
+SET last_beat_at = $2,
+    seconds = $3,
+    suspicious = $4,
+    flag_reason = $5
+WHERE id = $1
+RETURNING *;
+
+-- name: GetTotalWatchSeconds :one
+SELECT COALESCE(SUM(seconds), 0)::bigint AS total_seconds
+FROM watch_sessions
+WHERE profile_id = $1 AND NOT suspicious;
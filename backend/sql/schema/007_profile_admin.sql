@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE profiles ADD COLUMN is_admin BOOLEAN NOT NULL DEFAULT false;
+
+-- make the oldest existing profile an admin so someone can manage the instance
+UPDATE profiles SET is_admin = true
+WHERE id = (SELECT id FROM profiles ORDER BY created_at ASC LIMIT 1);
+
+-- +goose Down
+ALTER TABLE profiles DROP COLUMN is_admin;
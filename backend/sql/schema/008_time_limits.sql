@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS profile_time_limits (
+    profile_id UUID PRIMARY KEY REFERENCES profiles(id) ON DELETE CASCADE,
+    daily_minutes INT,
+    weekly_minutes INT,
+    warning_pct INT NOT NULL DEFAULT 80,
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS viewing_time_daily (
+    profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    seconds INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (profile_id, day)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS viewing_time_daily;
+DROP TABLE IF EXISTS profile_time_limits;
@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS course_notes (
+    id UUID PRIMARY KEY,
+    course_id UUID NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    content TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now(),
+    UNIQUE(course_id, user_id)
+);
+
+CREATE INDEX idx_course_notes_user_id ON course_notes(user_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_course_notes_user_id;
+DROP TABLE IF EXISTS course_notes;
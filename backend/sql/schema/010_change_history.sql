@@ -0,0 +1,21 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS change_history (
+    id UUID PRIMARY KEY,
+    batch_id UUID NOT NULL,
+    entity_type TEXT NOT NULL,
+    entity_id UUID NOT NULL,
+    field TEXT NOT NULL,
+    old_value TEXT,
+    new_value TEXT,
+    changed_by UUID REFERENCES profiles(id) ON DELETE SET NULL,
+    reverted_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX idx_change_history_batch_id ON change_history(batch_id);
+CREATE INDEX idx_change_history_entity ON change_history(entity_type, entity_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_change_history_entity;
+DROP INDEX IF EXISTS idx_change_history_batch_id;
+DROP TABLE IF EXISTS change_history;
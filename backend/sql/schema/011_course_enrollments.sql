@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS course_enrollments (
+    course_id UUID NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT now(),
+    PRIMARY KEY (course_id, user_id)
+);
+
+CREATE INDEX idx_course_enrollments_user_id ON course_enrollments(user_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_course_enrollments_user_id;
+DROP TABLE IF EXISTS course_enrollments;
@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS audit_log (
+    id UUID PRIMARY KEY,
+    actor_id UUID REFERENCES profiles(id) ON DELETE SET NULL,
+    subject_id UUID REFERENCES profiles(id) ON DELETE SET NULL,
+    action TEXT NOT NULL,
+    entity_type TEXT,
+    entity_id UUID,
+    details TEXT,
+    created_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX idx_audit_log_created_at ON audit_log(created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_audit_log_created_at;
+DROP TABLE IF EXISTS audit_log;
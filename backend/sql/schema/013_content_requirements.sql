@@ -0,0 +1,10 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS content_item_requirements (
+    content_item_id UUID NOT NULL REFERENCES content_items(id) ON DELETE CASCADE,
+    capability TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT now(),
+    PRIMARY KEY (content_item_id, capability)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS content_item_requirements;
@@ -0,0 +1,26 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    category TEXT NOT NULL,
+    frequency TEXT NOT NULL DEFAULT 'immediate',
+    last_digest_at TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT now(),
+    PRIMARY KEY (profile_id, category)
+);
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id UUID PRIMARY KEY,
+    profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    category TEXT NOT NULL,
+    title TEXT NOT NULL,
+    body TEXT,
+    delivered_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX idx_notifications_pending ON notifications(profile_id, category) WHERE delivered_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_notifications_pending;
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS notification_preferences;
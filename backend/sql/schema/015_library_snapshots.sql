@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS library_snapshots (
+    id UUID PRIMARY KEY,
+    label TEXT NOT NULL,
+    created_by UUID REFERENCES profiles(id) ON DELETE SET NULL,
+    course_count INT NOT NULL DEFAULT 0,
+    data TEXT NOT NULL,
+    restored_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT now()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS library_snapshots;
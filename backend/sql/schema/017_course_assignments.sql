@@ -0,0 +1,18 @@
+-- +goose Up
+-- restricted profiles only see the courses assigned to them
+ALTER TABLE profiles ADD COLUMN courses_restricted BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS course_assignments (
+    course_id UUID NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    assigned_by UUID REFERENCES profiles(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT now(),
+    PRIMARY KEY (course_id, profile_id)
+);
+
+CREATE INDEX idx_course_assignments_profile_id ON course_assignments(profile_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_course_assignments_profile_id;
+DROP TABLE IF EXISTS course_assignments;
+ALTER TABLE profiles DROP COLUMN courses_restricted;
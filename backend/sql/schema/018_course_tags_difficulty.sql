@@ -0,0 +1,10 @@
+-- +goose Up
+ALTER TABLE courses ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE courses ADD COLUMN difficulty TEXT;
+
+CREATE INDEX idx_courses_tags ON courses USING GIN (tags);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_courses_tags;
+ALTER TABLE courses DROP COLUMN difficulty;
+ALTER TABLE courses DROP COLUMN tags;
@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS course_prerequisites (
+    course_id UUID NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    prerequisite_id UUID NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT now(),
+    PRIMARY KEY (course_id, prerequisite_id),
+    CHECK (course_id <> prerequisite_id)
+);
+
+CREATE INDEX idx_course_prerequisites_prerequisite_id ON course_prerequisites(prerequisite_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_course_prerequisites_prerequisite_id;
+DROP TABLE IF EXISTS course_prerequisites;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- extensions users mapped to a content type themselves, on top of the built-in list
+CREATE TABLE IF NOT EXISTS content_type_mappings (
+    extension TEXT PRIMARY KEY,
+    content_type TEXT NOT NULL,
+    created_by UUID REFERENCES profiles(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX idx_content_items_content_type ON content_items(content_type);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_content_items_content_type;
+DROP TABLE IF EXISTS content_type_mappings;
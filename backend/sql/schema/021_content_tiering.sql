@@ -0,0 +1,17 @@
+-- +goose Up
+-- access tracking and storage tier per content item - no row means hot and never accessed
+CREATE TABLE IF NOT EXISTS content_tiering (
+    content_item_id UUID PRIMARY KEY REFERENCES content_items(id) ON DELETE CASCADE,
+    tier TEXT NOT NULL DEFAULT 'hot',
+    access_count INT NOT NULL DEFAULT 0,
+    last_accessed_at TIMESTAMP,
+    cold_key TEXT,
+    tiered_at TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX idx_content_tiering_tier ON content_tiering(tier);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_content_tiering_tier;
+DROP TABLE IF EXISTS content_tiering;
@@ -0,0 +1,24 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS learning_paths (
+    id UUID PRIMARY KEY,
+    title TEXT NOT NULL,
+    description TEXT,
+    creator_id UUID REFERENCES profiles(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now()
+);
+
+-- the courses of a path, in the order they should be taken
+CREATE TABLE IF NOT EXISTS learning_path_courses (
+    path_id UUID NOT NULL REFERENCES learning_paths(id) ON DELETE CASCADE,
+    course_id UUID NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    PRIMARY KEY (path_id, course_id)
+);
+
+CREATE INDEX idx_learning_path_courses_course_id ON learning_path_courses(course_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_learning_path_courses_course_id;
+DROP TABLE IF EXISTS learning_path_courses;
+DROP TABLE IF EXISTS learning_paths;
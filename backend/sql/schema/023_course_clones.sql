@@ -0,0 +1,9 @@
+-- +goose Up
+-- clones share the original's files, so several courses can point at the same folder
+ALTER TABLE courses ADD COLUMN cloned_from UUID REFERENCES courses(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_courses_relative_path ON courses(relative_path);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_courses_relative_path;
+ALTER TABLE courses DROP COLUMN IF EXISTS cloned_from;
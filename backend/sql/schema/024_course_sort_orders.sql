@@ -0,0 +1,10 @@
+-- +goose Up
+-- each profile's own arrangement of the course list, stored whole so it's replaced in one go
+CREATE TABLE IF NOT EXISTS course_sort_orders (
+    user_id UUID PRIMARY KEY REFERENCES profiles(id) ON DELETE CASCADE,
+    course_ids UUID[] NOT NULL DEFAULT '{}',
+    updated_at TIMESTAMP DEFAULT now()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS course_sort_orders;
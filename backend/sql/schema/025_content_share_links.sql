@@ -0,0 +1,20 @@
+-- +goose Up
+-- public links to a single content item - only hashes of the token and password are kept
+CREATE TABLE IF NOT EXISTS content_share_links (
+    id UUID PRIMARY KEY,
+    token_hash TEXT NOT NULL UNIQUE,
+    content_item_id UUID NOT NULL REFERENCES content_items(id) ON DELETE CASCADE,
+    created_by UUID REFERENCES profiles(id) ON DELETE SET NULL,
+    password_hash TEXT,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP,
+    view_count INT NOT NULL DEFAULT 0,
+    last_viewed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX idx_content_share_links_content_item_id ON content_share_links(content_item_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_content_share_links_content_item_id;
+DROP TABLE IF EXISTS content_share_links;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- hidden items stay in the course but don't count towards progress
+ALTER TABLE content_items ADD COLUMN hidden BOOLEAN NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE content_items DROP COLUMN IF EXISTS hidden;
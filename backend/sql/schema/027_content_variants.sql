@@ -0,0 +1,20 @@
+-- +goose Up
+-- other versions of the same lesson (resolutions, languages) grouped under one item
+CREATE TABLE IF NOT EXISTS content_item_variants (
+    id UUID PRIMARY KEY,
+    content_item_id UUID NOT NULL REFERENCES content_items(id) ON DELETE CASCADE,
+    label TEXT NOT NULL,
+    resolution TEXT,
+    language TEXT,
+    relative_path TEXT NOT NULL,
+    size BIGINT,
+    is_primary BOOLEAN NOT NULL DEFAULT false,
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX idx_content_item_variants_item ON content_item_variants(content_item_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_content_item_variants_item;
+DROP TABLE IF EXISTS content_item_variants;
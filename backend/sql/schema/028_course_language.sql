@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE courses ADD COLUMN language TEXT;
+
+-- +goose Down
+ALTER TABLE courses DROP COLUMN IF EXISTS language;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- an admin's session can act as another profile until the expiry
+ALTER TABLE sessions ADD COLUMN impersonated_id UUID REFERENCES profiles(id) ON DELETE SET NULL;
+ALTER TABLE sessions ADD COLUMN impersonation_expires_at TIMESTAMP;
+
+-- +goose Down
+ALTER TABLE sessions DROP COLUMN IF EXISTS impersonation_expires_at;
+ALTER TABLE sessions DROP COLUMN IF EXISTS impersonated_id;
@@ -0,0 +1,27 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS instructors (
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL,
+    bio TEXT,
+    avatar_url TEXT,
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now()
+);
+
+-- manifests refer to instructors by name, so names are unique regardless of case
+CREATE UNIQUE INDEX idx_instructors_name ON instructors(lower(name));
+
+CREATE TABLE IF NOT EXISTS course_instructors (
+    course_id UUID NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    instructor_id UUID NOT NULL REFERENCES instructors(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (course_id, instructor_id)
+);
+
+CREATE INDEX idx_course_instructors_instructor ON course_instructors(instructor_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_course_instructors_instructor;
+DROP TABLE IF EXISTS course_instructors;
+DROP INDEX IF EXISTS idx_instructors_name;
+DROP TABLE IF EXISTS instructors;
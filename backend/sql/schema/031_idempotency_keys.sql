@@ -0,0 +1,19 @@
+-- +goose Up
+-- remembers the response to a request sent with an Idempotency-Key so a retry gets the
+-- same answer instead of repeating the work - status_code stays NULL while it's running
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    route TEXT NOT NULL,
+    key TEXT NOT NULL,
+    request_hash TEXT NOT NULL,
+    status_code INT,
+    response_body TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (profile_id, route, key)
+);
+
+CREATE INDEX idx_idempotency_keys_created_at ON idempotency_keys(created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_idempotency_keys_created_at;
+DROP TABLE IF EXISTS idempotency_keys;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- number of pages of PDF content, read at import - NULL for other types or when it couldn't be read
+ALTER TABLE content_items ADD COLUMN page_count INT;
+
+-- +goose Down
+ALTER TABLE content_items DROP COLUMN IF EXISTS page_count;
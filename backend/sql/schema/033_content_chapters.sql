@@ -0,0 +1,14 @@
+-- +goose Up
+-- chapter markers of audio and video items, read from the file at import or edited by hand.
+-- Only the start is stored, a chapter runs until the next one starts.
+CREATE TABLE IF NOT EXISTS content_chapters (
+    content_item_id UUID NOT NULL REFERENCES content_items(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    title TEXT NOT NULL,
+    start_time DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMP DEFAULT now(),
+    PRIMARY KEY (content_item_id, position)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS content_chapters;
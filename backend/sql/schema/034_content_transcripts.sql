@@ -0,0 +1,18 @@
+-- +goose Up
+-- speech-to-text transcripts of audio and video items, one per item. vtt keeps the timings
+-- for captions, text is the plain version the search endpoint matches against.
+CREATE TABLE IF NOT EXISTS content_transcripts (
+    content_item_id UUID PRIMARY KEY REFERENCES content_items(id) ON DELETE CASCADE,
+    provider TEXT NOT NULL,
+    language TEXT,
+    vtt TEXT NOT NULL,
+    text TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now()
+);
+
+-- 'simple' skips stemming - lectures come in any language and the course language is only a hint
+CREATE INDEX IF NOT EXISTS idx_content_transcripts_text ON content_transcripts USING GIN (to_tsvector('simple', text));
+
+-- +goose Down
+DROP TABLE IF EXISTS content_transcripts;
@@ -0,0 +1,14 @@
+-- +goose Up
+-- full SHA-256 of content files for clients verifying offline downloads. Hashing a video
+-- takes a while, so the result is kept until the file's size or modification time changes.
+-- modified_ns is the modification time in unix nanoseconds so it compares exactly.
+CREATE TABLE IF NOT EXISTS content_checksums (
+    content_item_id UUID PRIMARY KEY REFERENCES content_items(id) ON DELETE CASCADE,
+    size BIGINT NOT NULL,
+    modified_ns BIGINT NOT NULL,
+    sha256 TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT now()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS content_checksums;
@@ -0,0 +1,28 @@
+-- +goose Up
+-- one row per profile per day with any learning activity, streaks are worked out from these.
+-- day is the calendar day in the configured streak timezone.
+CREATE TABLE IF NOT EXISTS daily_activity (
+    profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    updates INTEGER NOT NULL DEFAULT 1,
+    PRIMARY KEY (profile_id, day)
+);
+
+-- the running streak is kept on the profile so listing profiles doesn't need the history
+ALTER TABLE profiles ADD COLUMN streak INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE profiles ADD COLUMN longest_streak INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE profiles ADD COLUMN last_active_date DATE;
+
+-- progress only remembers the last access per item, but that's a start for existing profiles
+INSERT INTO daily_activity (profile_id, day, updates)
+SELECT user_id, last_accessed::date, COUNT(*)
+FROM user_progress
+WHERE last_accessed IS NOT NULL
+GROUP BY user_id, last_accessed::date
+ON CONFLICT DO NOTHING;
+
+-- +goose Down
+ALTER TABLE profiles DROP COLUMN IF EXISTS last_active_date;
+ALTER TABLE profiles DROP COLUMN IF EXISTS longest_streak;
+ALTER TABLE profiles DROP COLUMN IF EXISTS streak;
+DROP TABLE IF EXISTS daily_activity;
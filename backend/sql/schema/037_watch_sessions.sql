@@ -0,0 +1,19 @@
+-- +goose Up
+-- continuous stretches of watching one item, built from player heartbeats. seconds is the
+-- watch time credited after the heartbeat policy, not what the player claimed.
+CREATE TABLE IF NOT EXISTS watch_sessions (
+    id UUID PRIMARY KEY,
+    profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    content_item_id UUID NOT NULL REFERENCES content_items(id) ON DELETE CASCADE,
+    started_at TIMESTAMP NOT NULL,
+    last_beat_at TIMESTAMP NOT NULL,
+    seconds INTEGER NOT NULL DEFAULT 0,
+    suspicious BOOLEAN NOT NULL DEFAULT false,
+    flag_reason TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_watch_sessions_profile_item ON watch_sessions(profile_id, content_item_id, last_beat_at DESC);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_watch_sessions_profile_item;
+DROP TABLE IF EXISTS watch_sessions;
@@ -0,0 +1,18 @@
+-- +goose Up
+-- what each profile did and when, for the history timeline. Titles are copied into the
+-- event so the timeline still reads right after a course or item is deleted.
+CREATE TABLE IF NOT EXISTS activity_events (
+    id UUID PRIMARY KEY,
+    profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    event_type TEXT NOT NULL,
+    course_id UUID REFERENCES courses(id) ON DELETE SET NULL,
+    content_item_id UUID REFERENCES content_items(id) ON DELETE SET NULL,
+    title TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_activity_events_profile ON activity_events(profile_id, created_at DESC);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_activity_events_profile;
+DROP TABLE IF EXISTS activity_events;
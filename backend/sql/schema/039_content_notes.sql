@@ -0,0 +1,18 @@
+-- +goose Up
+-- markdown notes a profile keeps on single content items, optionally pinned to a
+-- moment in a video or audio item (position in seconds)
+CREATE TABLE IF NOT EXISTS content_notes (
+    id UUID PRIMARY KEY,
+    content_item_id UUID NOT NULL REFERENCES content_items(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    content TEXT NOT NULL,
+    position DOUBLE PRECISION,
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_content_notes_user_item ON content_notes(user_id, content_item_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_content_notes_user_item;
+DROP TABLE IF EXISTS content_notes;
@@ -0,0 +1,16 @@
+-- +goose Up
+-- moments in a video a profile wants to come back to, position in seconds
+CREATE TABLE IF NOT EXISTS content_bookmarks (
+    id UUID PRIMARY KEY,
+    content_item_id UUID NOT NULL REFERENCES content_items(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    position DOUBLE PRECISION NOT NULL,
+    label TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_content_bookmarks_user_item ON content_bookmarks(user_id, content_item_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_content_bookmarks_user_item;
+DROP TABLE IF EXISTS content_bookmarks;
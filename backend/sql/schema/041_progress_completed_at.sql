@@ -0,0 +1,9 @@
+-- +goose Up
+-- when an item was finished, kept through later progress updates until it's reopened
+ALTER TABLE user_progress ADD COLUMN IF NOT EXISTS completed_at TIMESTAMP;
+
+-- the best guess for existing completions is their last update
+UPDATE user_progress SET completed_at = updated_at WHERE completed AND completed_at IS NULL;
+
+-- +goose Down
+ALTER TABLE user_progress DROP COLUMN IF EXISTS completed_at;
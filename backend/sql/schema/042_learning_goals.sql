@@ -0,0 +1,33 @@
+-- +goose Up
+-- goals profiles set themselves. daily_minutes goals are judged day by day and keep their
+-- own streak; course_completion goals are met once the course is done by due_date.
+CREATE TABLE IF NOT EXISTS learning_goals (
+    id UUID PRIMARY KEY,
+    profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    goal_type TEXT NOT NULL,
+    target_minutes INTEGER,
+    course_id UUID REFERENCES courses(id) ON DELETE CASCADE,
+    due_date DATE,
+    status TEXT NOT NULL DEFAULT 'active',
+    streak INTEGER NOT NULL DEFAULT 0,
+    longest_streak INTEGER NOT NULL DEFAULT 0,
+    evaluated_through DATE,
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_learning_goals_profile ON learning_goals(profile_id);
+
+-- one verdict per goal and day
+CREATE TABLE IF NOT EXISTS goal_evaluations (
+    goal_id UUID NOT NULL REFERENCES learning_goals(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    met BOOLEAN NOT NULL,
+    minutes INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (goal_id, day)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS goal_evaluations;
+DROP INDEX IF EXISTS idx_learning_goals_profile;
+DROP TABLE IF EXISTS learning_goals;
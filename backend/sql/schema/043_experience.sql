@@ -0,0 +1,17 @@
+-- +goose Up
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS experience INTEGER NOT NULL DEFAULT 0;
+
+-- every XP award, one per profile and completed item, module or course, so resetting
+-- progress and finishing again doesn't pay out twice
+CREATE TABLE IF NOT EXISTS xp_awards (
+    profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    source TEXT NOT NULL,
+    subject_id UUID NOT NULL,
+    xp INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT now(),
+    PRIMARY KEY (profile_id, source, subject_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS xp_awards;
+ALTER TABLE profiles DROP COLUMN IF EXISTS experience;
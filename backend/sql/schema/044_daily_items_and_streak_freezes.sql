@@ -0,0 +1,25 @@
+-- +goose Up
+-- daily_items goals count items completed per day instead of minutes studied
+ALTER TABLE learning_goals ADD COLUMN IF NOT EXISTS target_items INTEGER;
+ALTER TABLE goal_evaluations ADD COLUMN IF NOT EXISTS items INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE goal_evaluations ADD COLUMN IF NOT EXISTS frozen BOOLEAN NOT NULL DEFAULT false;
+
+-- gems are earned by meeting daily goals and spent on streak freezes, which keep daily
+-- goal streaks alive over a missed day
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS gems INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS streak_freezes INTEGER NOT NULL DEFAULT 0;
+
+-- days a freeze was used on, one freeze covers every daily goal of the profile that day
+CREATE TABLE IF NOT EXISTS streak_freeze_days (
+    profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    PRIMARY KEY (profile_id, day)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS streak_freeze_days;
+ALTER TABLE profiles DROP COLUMN IF EXISTS streak_freezes;
+ALTER TABLE profiles DROP COLUMN IF EXISTS gems;
+ALTER TABLE goal_evaluations DROP COLUMN IF EXISTS frozen;
+ALTER TABLE goal_evaluations DROP COLUMN IF EXISTS items;
+ALTER TABLE learning_goals DROP COLUMN IF EXISTS target_items;
@@ -0,0 +1,26 @@
+-- +goose Up
+-- every change to a profile's gems, earned or spent, with the balance it left
+CREATE TABLE IF NOT EXISTS gem_transactions (
+    id UUID PRIMARY KEY,
+    profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    amount INTEGER NOT NULL,
+    balance INTEGER NOT NULL,
+    reason TEXT NOT NULL,
+    subject TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_gem_transactions_profile ON gem_transactions(profile_id, created_at DESC);
+
+-- things bought with gems that stay bought, like profile themes
+CREATE TABLE IF NOT EXISTS profile_unlocks (
+    profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    item TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT now(),
+    PRIMARY KEY (profile_id, item)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS profile_unlocks;
+DROP TABLE IF EXISTS gem_transactions;
@@ -0,0 +1,44 @@
+-- +goose Up
+-- every change to a profile's XP, gems, streaks and streak freezes, with what it left the
+-- stat at, why, and the event that set it off. Takes over from gem_transactions.
+CREATE TABLE IF NOT EXISTS gamification_ledger (
+    id UUID PRIMARY KEY,
+    profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    stat TEXT NOT NULL,
+    amount INTEGER NOT NULL,
+    balance INTEGER NOT NULL,
+    reason TEXT NOT NULL,
+    subject TEXT NOT NULL DEFAULT '',
+    source_event TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_gamification_ledger_profile ON gamification_ledger(profile_id, created_at DESC);
+
+INSERT INTO gamification_ledger (id, profile_id, stat, amount, balance, reason, subject, description, created_at)
+SELECT id, profile_id, 'gems', amount, balance, reason, subject, description, created_at
+FROM gem_transactions;
+
+DROP TABLE IF EXISTS gem_transactions;
+
+-- +goose Down
+CREATE TABLE IF NOT EXISTS gem_transactions (
+    id UUID PRIMARY KEY,
+    profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    amount INTEGER NOT NULL,
+    balance INTEGER NOT NULL,
+    reason TEXT NOT NULL,
+    subject TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_gem_transactions_profile ON gem_transactions(profile_id, created_at DESC);
+
+INSERT INTO gem_transactions (id, profile_id, amount, balance, reason, subject, description, created_at)
+SELECT id, profile_id, amount, balance, reason, subject, description, created_at
+FROM gamification_ledger
+WHERE stat = 'gems';
+
+DROP TABLE IF EXISTS gamification_ledger;
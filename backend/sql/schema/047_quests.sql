@@ -0,0 +1,21 @@
+-- +goose Up
+-- weekly challenges handed to each profile, a new set every Monday. Progress is counted
+-- from what the profile did that week and saved as it goes.
+CREATE TABLE IF NOT EXISTS quests (
+    id UUID PRIMARY KEY,
+    profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    week DATE NOT NULL,
+    quest_type TEXT NOT NULL,
+    title TEXT NOT NULL,
+    target INTEGER NOT NULL,
+    progress INTEGER NOT NULL DEFAULT 0,
+    course_id UUID REFERENCES courses(id) ON DELETE CASCADE,
+    reward_xp INTEGER NOT NULL,
+    reward_gems INTEGER NOT NULL,
+    completed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT now(),
+    UNIQUE (profile_id, week, quest_type)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS quests;
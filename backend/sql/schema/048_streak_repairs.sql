@@ -0,0 +1,8 @@
+-- +goose Up
+-- days bought back with gems to mend a broken streak. They count towards streaks like any
+-- active day but aren't activity, so statistics leave them out.
+ALTER TABLE daily_activity ADD COLUMN IF NOT EXISTS repaired BOOLEAN NOT NULL DEFAULT false;
+
+-- +goose Down
+DELETE FROM daily_activity WHERE repaired;
+ALTER TABLE daily_activity DROP COLUMN IF EXISTS repaired;
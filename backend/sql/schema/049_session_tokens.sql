@@ -0,0 +1,8 @@
+-- +goose Up
+-- every client gets its own session, found by the token it sends back. Only a hash of the
+-- token is kept. The single shared session from before has no token, so it goes.
+DELETE FROM sessions;
+ALTER TABLE sessions ADD COLUMN token_hash TEXT NOT NULL UNIQUE;
+
+-- +goose Down
+ALTER TABLE sessions DROP COLUMN IF EXISTS token_hash;
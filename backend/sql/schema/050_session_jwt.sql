@@ -0,0 +1,9 @@
+-- +goose Up
+-- sessions are now found by the ID in their signed token, nothing about the token is kept.
+-- Tokens handed out before this can't be checked any more, so their sessions go.
+DELETE FROM sessions;
+ALTER TABLE sessions DROP COLUMN IF EXISTS token_hash;
+
+-- +goose Down
+DELETE FROM sessions;
+ALTER TABLE sessions ADD COLUMN token_hash TEXT NOT NULL UNIQUE;
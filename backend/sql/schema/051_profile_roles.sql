@@ -0,0 +1,14 @@
+-- +goose Up
+-- profiles get a role instead of an admin flag: viewers study, editors also manage the
+-- library, admins also manage the instance
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS role TEXT NOT NULL DEFAULT 'viewer'
+    CHECK (role IN ('admin', 'editor', 'viewer'));
+
+-- admins stay admins, everyone else starts as a viewer - an admin can make editors
+UPDATE profiles SET role = CASE WHEN is_admin THEN 'admin' ELSE 'viewer' END;
+ALTER TABLE profiles DROP COLUMN IF EXISTS is_admin;
+
+-- +goose Down
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS is_admin BOOLEAN NOT NULL DEFAULT false;
+UPDATE profiles SET is_admin = (role = 'admin');
+ALTER TABLE profiles DROP COLUMN IF EXISTS role;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- the picture itself lives in the data directory, the timestamp tells clients when it changed
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS avatar_updated_at TIMESTAMP;
+
+-- +goose Down
+ALTER TABLE profiles DROP COLUMN IF EXISTS avatar_updated_at;
@@ -0,0 +1,18 @@
+-- +goose Up
+-- accounts at an OpenID Connect provider that log in as a local profile. The subject is
+-- only unique per issuer, so both make up the key.
+CREATE TABLE IF NOT EXISTS profile_identities (
+    issuer TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    email TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    last_login_at TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (issuer, subject)
+);
+
+CREATE INDEX idx_profile_identities_profile ON profile_identities(profile_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_profile_identities_profile;
+DROP TABLE IF EXISTS profile_identities;
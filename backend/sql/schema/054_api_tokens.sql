@@ -0,0 +1,20 @@
+-- +goose Up
+-- long-lived tokens scripts use instead of a session. Only a hash of the token is kept,
+-- the scope is the highest role requests made with it get.
+CREATE TABLE IF NOT EXISTS api_tokens (
+    id UUID PRIMARY KEY,
+    profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    scope TEXT NOT NULL CHECK (scope IN ('admin', 'editor', 'viewer')),
+    expires_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    last_used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_api_tokens_profile ON api_tokens(profile_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_api_tokens_profile;
+DROP TABLE IF EXISTS api_tokens;
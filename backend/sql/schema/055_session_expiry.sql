@@ -0,0 +1,12 @@
+-- +goose Up
+-- sessions end after SESSION_TTL without being used. Existing ones get a day from their
+-- last change, the default lifetime.
+ALTER TABLE sessions ADD COLUMN expires_at TIMESTAMP;
+UPDATE sessions SET expires_at = COALESCE(updated_at, now()) + interval '24 hours';
+ALTER TABLE sessions ALTER COLUMN expires_at SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_sessions_expires_at ON sessions(expires_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_sessions_expires_at;
+ALTER TABLE sessions DROP COLUMN IF EXISTS expires_at;
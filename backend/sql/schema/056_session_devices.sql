@@ -0,0 +1,13 @@
+-- +goose Up
+-- what a session was started from and when it was last used, so people can see where
+-- they're logged in and end the sessions they don't recognise
+ALTER TABLE sessions ADD COLUMN user_agent TEXT NOT NULL DEFAULT '';
+ALTER TABLE sessions ADD COLUMN last_seen_at TIMESTAMP NOT NULL DEFAULT now();
+UPDATE sessions SET last_seen_at = COALESCE(updated_at, created_at, now());
+
+CREATE INDEX IF NOT EXISTS idx_sessions_user_last_seen ON sessions(user_id, last_seen_at DESC);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_sessions_user_last_seen;
+ALTER TABLE sessions DROP COLUMN IF EXISTS last_seen_at;
+ALTER TABLE sessions DROP COLUMN IF EXISTS user_agent;
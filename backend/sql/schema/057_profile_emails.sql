@@ -0,0 +1,19 @@
+-- +goose Up
+-- an optional contact address, only used once it's verified. Profiles of one household
+-- may well share one, so it isn't unique.
+ALTER TABLE profiles ADD COLUMN email TEXT;
+ALTER TABLE profiles ADD COLUMN email_verified_at TIMESTAMP;
+
+-- the one verification a profile has pending, found by the hash of the emailed token
+CREATE TABLE IF NOT EXISTS email_verifications (
+    profile_id UUID PRIMARY KEY REFERENCES profiles(id) ON DELETE CASCADE,
+    email TEXT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS email_verifications;
+ALTER TABLE profiles DROP COLUMN IF EXISTS email_verified_at;
+ALTER TABLE profiles DROP COLUMN IF EXISTS email;
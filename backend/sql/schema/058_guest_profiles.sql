@@ -0,0 +1,10 @@
+-- +goose Up
+-- guest profiles let visitors try the library. Each lasts as long as its one session:
+-- it's deleted with everything it did on logout, or once the session is gone.
+ALTER TABLE profiles ADD COLUMN is_guest BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX idx_profiles_guest ON profiles(id) WHERE is_guest;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_profiles_guest;
+ALTER TABLE profiles DROP COLUMN IF EXISTS is_guest;
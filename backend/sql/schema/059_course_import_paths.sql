@@ -0,0 +1,24 @@
+-- +goose Up
+-- a folder can only be imported once, checked in the database so two imports of the same
+-- folder can't both get in. Clones share their original's folder and don't count.
+-- Stored paths get the same cleanup imports do, so "foo/" and "./foo" are one folder.
+UPDATE courses
+SET relative_path = btrim(regexp_replace(regexp_replace(relative_path, '/{2,}', '/', 'g'), '^(\./)+', ''), '/');
+
+-- folders imported twice before this became clones of the first import
+UPDATE courses c
+SET cloned_from = first.id
+FROM (
+    SELECT DISTINCT ON (relative_path) id, relative_path
+    FROM courses
+    WHERE cloned_from IS NULL
+    ORDER BY relative_path, created_at, id
+) first
+WHERE c.relative_path = first.relative_path
+  AND c.cloned_from IS NULL
+  AND c.id <> first.id;
+
+CREATE UNIQUE INDEX idx_courses_imported_path ON courses(relative_path) WHERE cloned_from IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_courses_imported_path;
@@ -0,0 +1,24 @@
+-- +goose Up
+-- background tasks submitted to the workers, kept so a task that failed (or was cut off by a
+-- restart) can still be retried with the input it was submitted with. input, result and logs
+-- are the JSON the tasks API shows.
+CREATE TABLE IF NOT EXISTS tasks (
+    id UUID PRIMARY KEY,
+    type TEXT NOT NULL,
+    status TEXT NOT NULL,
+    input TEXT NOT NULL DEFAULT '',
+    result TEXT NOT NULL DEFAULT '',
+    message TEXT NOT NULL DEFAULT '',
+    error_message TEXT NOT NULL DEFAULT '',
+    retries INTEGER NOT NULL DEFAULT 0,
+    logs TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    started_at TIMESTAMP,
+    completed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_tasks_status ON tasks(status, completed_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tasks_status;
+DROP TABLE IF EXISTS tasks;